@@ -86,6 +86,18 @@ func (a *AIAgent) GenerateResponse(ctx context.Context, prompt string, options *
 	return client.GenerateResponse(ctx, prompt, options)
 }
 
+// GenerateMessages sends a conversation using the AI client
+func (a *AIAgent) GenerateMessages(ctx context.Context, messages []core.Message, options *core.AIOptions) (*core.AIResponse, error) {
+	client := a.AI
+	if client == nil {
+		client = a.aiClient
+	}
+	if client == nil {
+		return nil, fmt.Errorf("no AI client configured")
+	}
+	return core.GenerateMessages(ctx, client, messages, options)
+}
+
 // SetAI sets the AI client for the agent
 func (a *AIAgent) SetAI(client core.AIClient) {
 	a.AI = client
@@ -0,0 +1,191 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+	"github.com/itsneelabh/gomind/telemetry"
+)
+
+// ErrBudgetExceeded matches, via errors.Is, every error returned when a call
+// is rejected because the spend limit has been reached
+var ErrBudgetExceeded = errors.New("AI budget exceeded")
+
+// BudgetExceededError is returned when a BudgetClient rejects a call
+type BudgetExceededError struct {
+	Limit  float64
+	Spend  float64
+	Window time.Duration
+}
+
+func (e *BudgetExceededError) Error() string {
+	if e.Window <= 0 {
+		return fmt.Sprintf("%s: spent $%.4f of $%.4f", ErrBudgetExceeded.Error(), e.Spend, e.Limit)
+	}
+	return fmt.Sprintf("%s: spent $%.4f of $%.4f in the last %s", ErrBudgetExceeded.Error(), e.Spend, e.Limit, e.Window)
+}
+
+// Is makes errors.Is(err, ErrBudgetExceeded) true
+func (e *BudgetExceededError) Is(target error) bool {
+	return target == ErrBudgetExceeded
+}
+
+// WithBudget rejects calls with a *BudgetExceededError once the estimated
+// spend within the rolling window reaches limit (USD). Calls already in
+// flight are not cancelled, so spend can overshoot the limit by up to one
+// call per concurrent caller. Costs are estimated from each response's
+// usage with the pricing table (see RegisterModelPrice).
+//
+// The spend is tracked by the client's own CostTracker unless one is given
+// with WithCostTracker, which lets several clients share a budget and lets
+// you read the spend.
+func WithBudget(limit float64, window time.Duration) AIOption {
+	return func(c *AIConfig) {
+		c.BudgetLimit = limit
+		c.BudgetWindow = window
+	}
+}
+
+// WithCostTracker records the estimated cost of every call to tracker.
+// Combined with WithBudget, the budget is enforced against this tracker,
+// over the tracker's window rather than WithBudget's.
+func WithCostTracker(tracker *CostTracker) AIOption {
+	return func(c *AIConfig) {
+		c.CostTracker = tracker
+	}
+}
+
+// BudgetClient wraps an AIClient, records the estimated cost of every call
+// to a CostTracker and rejects calls once the spend reaches a limit
+type BudgetClient struct {
+	client  core.AIClient
+	tracker *CostTracker
+	limit   float64
+	logger  core.Logger
+}
+
+// NewBudgetClient wraps client so the cost of every call is recorded to
+// tracker. With a positive limit, calls are rejected once tracker.Spend()
+// reaches it. NewClient does this automatically for WithBudget and
+// WithCostTracker; use this function for clients built another way, such as
+// a ChainClient. Streaming support of the wrapped client is preserved.
+func NewBudgetClient(client core.AIClient, tracker *CostTracker, limit float64, logger core.Logger) core.AIClient {
+	if logger == nil {
+		logger = &core.NoOpLogger{}
+	}
+	budget := &BudgetClient{client: client, tracker: tracker, limit: limit, logger: logger}
+	if streaming, ok := client.(core.StreamingAIClient); ok {
+		return &budgetStreamingClient{BudgetClient: budget, streaming: streaming}
+	}
+	return budget
+}
+
+// Tracker returns the CostTracker the client records to
+func (b *BudgetClient) Tracker() *CostTracker {
+	return b.tracker
+}
+
+// Unwrap returns the wrapped client
+func (b *BudgetClient) Unwrap() core.AIClient {
+	return b.client
+}
+
+// SetLogger updates the logger and propagates it to the wrapped client
+func (b *BudgetClient) SetLogger(logger core.Logger) {
+	if logger == nil {
+		b.logger = &core.NoOpLogger{}
+	} else if cal, ok := logger.(core.ComponentAwareLogger); ok {
+		b.logger = cal.WithComponent("framework/ai")
+	} else {
+		b.logger = logger
+	}
+
+	if logger != nil {
+		b.tracker.SetLogger(logger)
+		if loggable, ok := b.client.(interface{ SetLogger(core.Logger) }); ok {
+			loggable.SetLogger(logger)
+		}
+	}
+}
+
+// GenerateResponse checks the budget, delegates to the wrapped client and
+// records the cost of the response
+func (b *BudgetClient) GenerateResponse(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+	if err := b.check(ctx); err != nil {
+		return nil, err
+	}
+	response, err := b.client.GenerateResponse(ctx, prompt, options)
+	b.record(options, response)
+	return response, err
+}
+
+// GenerateMessages checks the budget, delegates to the wrapped client and
+// records the cost of the response
+func (b *BudgetClient) GenerateMessages(ctx context.Context, messages []core.Message, options *core.AIOptions) (*core.AIResponse, error) {
+	if err := b.check(ctx); err != nil {
+		return nil, err
+	}
+	response, err := core.GenerateMessages(ctx, b.client, messages, options)
+	b.record(options, response)
+	return response, err
+}
+
+// check rejects the call when the spend has reached the limit
+func (b *BudgetClient) check(ctx context.Context) error {
+	if b.limit <= 0 {
+		return nil
+	}
+	spend := b.tracker.Spend()
+	if spend < b.limit {
+		return nil
+	}
+
+	b.logger.WarnWithContext(ctx, "AI call rejected by budget", map[string]interface{}{
+		"operation": "ai_budget",
+		"limit":     b.limit,
+		"spend":     spend,
+		"window":    b.tracker.Window().String(),
+	})
+	telemetry.Counter("ai.budget.rejected",
+		"module", telemetry.ModuleAI,
+	)
+	return &BudgetExceededError{Limit: b.limit, Spend: spend, Window: b.tracker.Window()}
+}
+
+// record adds the response's cost to the tracker. Failed calls without a
+// response cost nothing.
+func (b *BudgetClient) record(options *core.AIOptions, response *core.AIResponse) {
+	if response == nil {
+		return
+	}
+	model := response.Model
+	if model == "" && options != nil {
+		model = options.Model
+	}
+	b.tracker.Record(model, response.Usage)
+}
+
+// budgetStreamingClient is a BudgetClient for clients that can stream
+type budgetStreamingClient struct {
+	*BudgetClient
+	streaming core.StreamingAIClient
+}
+
+// StreamResponse checks the budget, streams from the wrapped client and
+// records the cost of the complete response
+func (b *budgetStreamingClient) StreamResponse(ctx context.Context, prompt string, options *core.AIOptions, callback core.StreamCallback) (*core.AIResponse, error) {
+	if err := b.check(ctx); err != nil {
+		return nil, err
+	}
+	response, err := b.streaming.StreamResponse(ctx, prompt, options, callback)
+	b.record(options, response)
+	return response, err
+}
+
+// SupportsStreaming reports whether the wrapped client can stream
+func (b *budgetStreamingClient) SupportsStreaming() bool {
+	return b.streaming.SupportsStreaming()
+}
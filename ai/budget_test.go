@@ -0,0 +1,79 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+func TestNewClient_WithBudget(t *testing.T) {
+	originalRegistry := registry
+	defer func() { registry = originalRegistry }()
+
+	calls := 0
+	registry = &ProviderRegistry{providers: make(map[string]ProviderFactory)}
+	registry.providers["mock"] = &mockFactory{
+		name: "mock",
+		client: &mockAIClient{generateFunc: func(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+			calls++
+			// $0.50 per call at gpt-4o prices
+			return &core.AIResponse{Content: "ok", Model: "gpt-4o", Usage: core.TokenUsage{PromptTokens: 200_000}}, nil
+		}},
+	}
+
+	tracker := NewCostTracker(time.Hour)
+	client, err := NewClient(WithProvider("mock"), WithBudget(1.00, time.Hour), WithCostTracker(tracker))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GenerateResponse(context.Background(), "hi", nil); err != nil {
+			t.Fatalf("Call %d within budget failed: %v", i+1, err)
+		}
+	}
+	if spend := tracker.Spend(); !approxEqual(spend, 1.00) {
+		t.Errorf("Spend() = %v, want 1.00", spend)
+	}
+
+	_, err = client.GenerateResponse(context.Background(), "hi", nil)
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Expected ErrBudgetExceeded, got %v", err)
+	}
+	var exceeded *BudgetExceededError
+	if !errors.As(err, &exceeded) || exceeded.Limit != 1.00 || exceeded.Window != time.Hour {
+		t.Errorf("Expected a BudgetExceededError with the limit and window, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected the rejected call not to reach the provider, got %d calls", calls)
+	}
+
+	tracker.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, err := client.GenerateResponse(context.Background(), "hi", nil); err != nil {
+		t.Errorf("Expected calls to resume once spend leaves the window, got %v", err)
+	}
+}
+
+func TestNewBudgetClient_PreservesStreaming(t *testing.T) {
+	inner := &streamingMockAIClient{name: "stream", supportsStreaming: true, response: "hello"}
+	tracker := NewCostTracker(0)
+
+	client := NewBudgetClient(inner, tracker, 0, nil)
+	streaming, ok := client.(core.StreamingAIClient)
+	if !ok || !streaming.SupportsStreaming() {
+		t.Fatal("Expected the budget client to keep streaming support")
+	}
+	if _, err := streaming.StreamResponse(context.Background(), "hi", &core.AIOptions{Model: "gpt-4o"}, func(core.StreamChunk) error { return nil }); err != nil {
+		t.Fatalf("StreamResponse() error = %v", err)
+	}
+	if inner.streamCallCount != 1 {
+		t.Errorf("Expected the stream to reach the provider, got %d calls", inner.streamCallCount)
+	}
+
+	if _, ok := NewBudgetClient(&mockAIClient{}, tracker, 0, nil).(core.StreamingAIClient); ok {
+		t.Error("Expected a non-streaming client to stay non-streaming")
+	}
+}
@@ -0,0 +1,284 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+	"github.com/itsneelabh/gomind/telemetry"
+)
+
+// ResponseCacheKeyPrefix prefixes the keys CachingClient writes to Memory
+const ResponseCacheKeyPrefix = "gomind:ai:cache:"
+
+// CachingClient wraps an AIClient and serves repeated GenerateResponse calls
+// from a core.Memory backend. The cache key is a hash of the prompt and the
+// options that shape the output: system prompt, model, temperature, max
+// tokens, tools and response format.
+//
+// Only deterministic calls are cached by default, i.e. those that reach the
+// provider with Temperature 0. Providers replace an unset Temperature with
+// their default (0.7 for the built-in ones), so the decision is made on the
+// options after the wrapped client's defaults are applied, looking through
+// the wrappers NewClient adds; a ChainClient is deterministic only if every
+// provider in it is. Set CacheNonDeterministic to cache every call.
+// Streaming calls are passed through uncached.
+type CachingClient struct {
+	inner  core.AIClient
+	memory core.Memory
+	ttl    time.Duration
+	logger core.Logger
+
+	// CacheNonDeterministic caches calls with Temperature > 0 too, trading
+	// response variety for cost
+	CacheNonDeterministic bool
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// CacheStats reports how effective a CachingClient has been. Calls that
+// are not cacheable count as neither hits nor misses.
+type CacheStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// HitRate returns Hits / (Hits + Misses), or 0 before any lookup
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// NewCachingClient wraps inner so identical GenerateResponse calls are
+// answered from memory for ttl. A cached response has zero Usage, since
+// the provider was not called.
+func NewCachingClient(inner core.AIClient, memory core.Memory, ttl time.Duration) *CachingClient {
+	return &CachingClient{
+		inner:  inner,
+		memory: memory,
+		ttl:    ttl,
+		logger: &core.NoOpLogger{},
+	}
+}
+
+// SetLogger updates the logger and propagates it to the wrapped client
+func (c *CachingClient) SetLogger(logger core.Logger) {
+	if logger == nil {
+		c.logger = &core.NoOpLogger{}
+	} else if cal, ok := logger.(core.ComponentAwareLogger); ok {
+		c.logger = cal.WithComponent("framework/ai")
+	} else {
+		c.logger = logger
+	}
+
+	if logger != nil {
+		if loggable, ok := c.inner.(interface{ SetLogger(core.Logger) }); ok {
+			loggable.SetLogger(logger)
+		}
+	}
+}
+
+// Unwrap returns the wrapped client
+func (c *CachingClient) Unwrap() core.AIClient {
+	return c.inner
+}
+
+// Stats returns the hit and miss counts since the client was created
+func (c *CachingClient) Stats() CacheStats {
+	return CacheStats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
+
+// GenerateResponse returns a cached response when one exists, otherwise
+// calls the wrapped client and caches its response. Cache read and write
+// failures are logged and never fail the call.
+func (c *CachingClient) GenerateResponse(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+	if c.memory == nil || !c.cacheable(options) {
+		return c.inner.GenerateResponse(ctx, prompt, options)
+	}
+
+	key, err := responseCacheKey(prompt, options)
+	if err != nil {
+		return c.inner.GenerateResponse(ctx, prompt, options)
+	}
+	return c.serve(ctx, key, func() (*core.AIResponse, error) {
+		return c.inner.GenerateResponse(ctx, prompt, options)
+	})
+}
+
+// GenerateMessages is GenerateResponse for conversations. The cache key
+// covers every message, so a conversation is only served from cache when
+// it matches turn for turn.
+func (c *CachingClient) GenerateMessages(ctx context.Context, messages []core.Message, options *core.AIOptions) (*core.AIResponse, error) {
+	if c.memory == nil || !c.cacheable(options) {
+		return core.GenerateMessages(ctx, c.inner, messages, options)
+	}
+
+	key, err := messagesCacheKey(messages, options)
+	if err != nil {
+		return core.GenerateMessages(ctx, c.inner, messages, options)
+	}
+	return c.serve(ctx, key, func() (*core.AIResponse, error) {
+		return core.GenerateMessages(ctx, c.inner, messages, options)
+	})
+}
+
+// serve returns the response cached under key, or calls generate and
+// caches its response
+func (c *CachingClient) serve(ctx context.Context, key string, generate func() (*core.AIResponse, error)) (*core.AIResponse, error) {
+	if cached, ok := c.lookup(ctx, key); ok {
+		c.hits.Add(1)
+		telemetry.Counter("ai.cache.lookups",
+			"module", telemetry.ModuleAI,
+			"result", "hit",
+		)
+		c.logger.DebugWithContext(ctx, "AI response served from cache", map[string]interface{}{
+			"operation": "ai_cache_hit",
+			"model":     cached.Model,
+			"provider":  cached.Provider,
+		})
+		return cached, nil
+	}
+
+	c.misses.Add(1)
+	telemetry.Counter("ai.cache.lookups",
+		"module", telemetry.ModuleAI,
+		"result", "miss",
+	)
+
+	response, err := generate()
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(ctx, key, response)
+	return response, nil
+}
+
+// defaultsApplier is implemented by clients that fill in unset options
+// before calling the provider, such as those embedding providers.BaseClient
+type defaultsApplier interface {
+	ApplyDefaults(options *core.AIOptions) *core.AIOptions
+}
+
+// cacheable reports whether a call with options may be served from cache
+func (c *CachingClient) cacheable(options *core.AIOptions) bool {
+	return c.CacheNonDeterministic || deterministic(c.inner, options)
+}
+
+// aiClientWrapper is implemented by clients that delegate to another one,
+// such as those NewClient adds for rate limits, budgets and input guards
+type aiClientWrapper interface {
+	Unwrap() core.AIClient
+}
+
+// deterministic reports whether client sends options to the provider with
+// Temperature 0. Wrappers are unwrapped to reach the provider; clients that
+// do not expose their defaults are taken at the options' word.
+func deterministic(client core.AIClient, options *core.AIOptions) bool {
+	switch inner := client.(type) {
+	case aiClientWrapper:
+		return deterministic(inner.Unwrap(), options)
+	case *ChainClient:
+		for _, provider := range inner.providers {
+			if !deterministic(provider, options) {
+				return false
+			}
+		}
+		return true
+	case defaultsApplier:
+		// Apply to a copy, ApplyDefaults fills in the options it is given
+		return inner.ApplyDefaults(cloneAIOptions(options)).Temperature <= 0
+	}
+	return options == nil || options.Temperature <= 0
+}
+
+// lookup returns the cached response for key, if any
+func (c *CachingClient) lookup(ctx context.Context, key string) (*core.AIResponse, bool) {
+	value, err := c.memory.Get(ctx, key)
+	if err != nil {
+		c.logger.WarnWithContext(ctx, "AI response cache read failed", map[string]interface{}{
+			"operation": "ai_cache_read",
+			"error":     err.Error(),
+		})
+		return nil, false
+	}
+	if value == "" {
+		return nil, false
+	}
+
+	var response core.AIResponse
+	if err := json.Unmarshal([]byte(value), &response); err != nil {
+		return nil, false
+	}
+	response.Usage = core.TokenUsage{}
+	return &response, true
+}
+
+// store caches response under key
+func (c *CachingClient) store(ctx context.Context, key string, response *core.AIResponse) {
+	data, err := json.Marshal(response)
+	if err == nil {
+		err = c.memory.Set(ctx, key, string(data), c.ttl)
+	}
+	if err != nil {
+		c.logger.WarnWithContext(ctx, "AI response cache write failed", map[string]interface{}{
+			"operation": "ai_cache_write",
+			"error":     err.Error(),
+		})
+	}
+}
+
+// StreamResponse streams from the wrapped client without caching
+func (c *CachingClient) StreamResponse(ctx context.Context, prompt string, options *core.AIOptions, callback core.StreamCallback) (*core.AIResponse, error) {
+	streaming, ok := c.inner.(core.StreamingAIClient)
+	if !ok {
+		return nil, fmt.Errorf("wrapped client does not support streaming")
+	}
+	return streaming.StreamResponse(ctx, prompt, options, callback)
+}
+
+// SupportsStreaming reports whether the wrapped client can stream
+func (c *CachingClient) SupportsStreaming() bool {
+	streaming, ok := c.inner.(core.StreamingAIClient)
+	return ok && streaming.SupportsStreaming()
+}
+
+// responseCacheKey hashes the prompt and the output-shaping options
+func responseCacheKey(prompt string, options *core.AIOptions) (string, error) {
+	return cacheKey(prompt, nil, options)
+}
+
+// messagesCacheKey is responseCacheKey for conversations
+func messagesCacheKey(messages []core.Message, options *core.AIOptions) (string, error) {
+	return cacheKey("", messages, options)
+}
+
+func cacheKey(prompt string, messages []core.Message, options *core.AIOptions) (string, error) {
+	if options == nil {
+		options = &core.AIOptions{}
+	}
+	data, err := json.Marshal(struct {
+		Prompt       string                `json:"prompt"`
+		Messages     []core.Message        `json:"messages,omitempty"`
+		SystemPrompt string                `json:"system_prompt"`
+		Model        string                `json:"model"`
+		Temperature  float32               `json:"temperature"`
+		MaxTokens    int                   `json:"max_tokens"`
+		Tools        []core.ToolDefinition `json:"tools,omitempty"`
+		Format       string                `json:"response_format,omitempty"`
+	}{prompt, messages, options.SystemPrompt, options.Model, options.Temperature, options.MaxTokens, options.Tools, options.ResponseFormat})
+	if err != nil {
+		return "", fmt.Errorf("failed to build cache key: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return ResponseCacheKeyPrefix + hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,245 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// failingMemory is a core.Memory whose every operation fails
+type failingMemory struct{}
+
+func (failingMemory) Get(context.Context, string) (string, error) {
+	return "", errors.New("backend down")
+}
+func (failingMemory) Set(context.Context, string, string, time.Duration) error {
+	return errors.New("backend down")
+}
+func (failingMemory) Delete(context.Context, string) error         { return nil }
+func (failingMemory) Exists(context.Context, string) (bool, error) { return false, nil }
+
+func countingClient(calls *int) *mockAIClient {
+	return &mockAIClient{
+		generateFunc: func(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+			*calls++
+			return &core.AIResponse{
+				Content:  "answer to " + prompt,
+				Model:    "mock-model",
+				Provider: "mock",
+				Usage:    core.TokenUsage{PromptTokens: 4, CompletionTokens: 6, TotalTokens: 10},
+			}, nil
+		},
+	}
+}
+
+func TestCachingClient_HitAndMiss(t *testing.T) {
+	calls := 0
+	memory := core.NewInMemoryStore()
+	client := NewCachingClient(countingClient(&calls), memory, time.Minute)
+	ctx := context.Background()
+	options := &core.AIOptions{Model: "m", SystemPrompt: "be terse"}
+
+	first, err := client.GenerateResponse(ctx, "q", options)
+	if err != nil {
+		t.Fatalf("GenerateResponse() error = %v", err)
+	}
+	second, err := client.GenerateResponse(ctx, "q", options)
+	if err != nil {
+		t.Fatalf("GenerateResponse() error = %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("inner client called %d times, want 1", calls)
+	}
+	if second.Content != first.Content || second.Model != "mock-model" || second.Provider != "mock" {
+		t.Errorf("cached response = %+v, want %+v", second, first)
+	}
+	if second.Usage != (core.TokenUsage{}) {
+		t.Errorf("cached response Usage = %+v, want zero", second.Usage)
+	}
+	if stats := client.Stats(); stats.Hits != 1 || stats.Misses != 1 || stats.HitRate() != 0.5 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+
+	key, _ := responseCacheKey("q", options)
+	if !strings.HasPrefix(key, ResponseCacheKeyPrefix) {
+		t.Errorf("key %q lacks prefix", key)
+	}
+	if exists, _ := memory.Exists(ctx, key); !exists {
+		t.Error("Expected the response to be stored in memory")
+	}
+}
+
+func TestCachingClient_KeyCoversOptions(t *testing.T) {
+	calls := 0
+	client := NewCachingClient(countingClient(&calls), core.NewInMemoryStore(), time.Minute)
+	ctx := context.Background()
+
+	variants := []*core.AIOptions{
+		nil,
+		{Model: "a"},
+		{Model: "b"},
+		{Model: "a", SystemPrompt: "x"},
+		{Model: "a", MaxTokens: 10},
+		{Model: "a", Tools: []core.ToolDefinition{{Name: "t"}}},
+	}
+	for _, options := range variants {
+		if _, err := client.GenerateResponse(ctx, "q", options); err != nil {
+			t.Fatalf("GenerateResponse() error = %v", err)
+		}
+	}
+	if _, err := client.GenerateResponse(ctx, "other prompt", nil); err != nil {
+		t.Fatalf("GenerateResponse() error = %v", err)
+	}
+
+	if want := len(variants) + 1; calls != want {
+		t.Errorf("inner client called %d times, want %d", calls, want)
+	}
+}
+
+func TestCachingClient_NonDeterministic(t *testing.T) {
+	calls := 0
+	client := NewCachingClient(countingClient(&calls), core.NewInMemoryStore(), time.Minute)
+	ctx := context.Background()
+	options := &core.AIOptions{Temperature: 0.7}
+
+	_, _ = client.GenerateResponse(ctx, "q", options)
+	_, _ = client.GenerateResponse(ctx, "q", options)
+	if calls != 2 {
+		t.Errorf("inner client called %d times, want 2 with temperature > 0", calls)
+	}
+	if stats := client.Stats(); stats.Hits != 0 || stats.Misses != 0 {
+		t.Errorf("uncacheable calls should not be counted, got %+v", stats)
+	}
+
+	client.CacheNonDeterministic = true
+	_, _ = client.GenerateResponse(ctx, "q", options)
+	_, _ = client.GenerateResponse(ctx, "q", options)
+	if calls != 3 {
+		t.Errorf("inner client called %d times, want 3 with CacheNonDeterministic", calls)
+	}
+}
+
+// defaultingClient fills in Temperature like the built-in providers do
+type defaultingClient struct {
+	*mockAIClient
+	temperature float32
+}
+
+func (c *defaultingClient) ApplyDefaults(options *core.AIOptions) *core.AIOptions {
+	if options == nil {
+		options = &core.AIOptions{}
+	}
+	if options.Temperature == 0 {
+		options.Temperature = c.temperature
+	}
+	return options
+}
+
+func TestCachingClient_DecidesAfterProviderDefaults(t *testing.T) {
+	calls := 0
+	ctx := context.Background()
+	options := &core.AIOptions{Model: "m"}
+
+	sampling := NewCachingClient(&defaultingClient{mockAIClient: countingClient(&calls), temperature: 0.7}, core.NewInMemoryStore(), time.Minute)
+	_, _ = sampling.GenerateResponse(ctx, "q", options)
+	_, _ = sampling.GenerateResponse(ctx, "q", nil)
+	_, _ = sampling.GenerateResponse(ctx, "q", options)
+	if calls != 3 {
+		t.Errorf("inner client called %d times, want 3 when the default temperature is 0.7", calls)
+	}
+	if options.Temperature != 0 {
+		t.Errorf("caller's options were modified: Temperature = %v", options.Temperature)
+	}
+
+	calls = 0
+	greedy := NewCachingClient(&defaultingClient{mockAIClient: countingClient(&calls)}, core.NewInMemoryStore(), time.Minute)
+	_, _ = greedy.GenerateResponse(ctx, "q", options)
+	_, _ = greedy.GenerateResponse(ctx, "q", options)
+	if calls != 1 {
+		t.Errorf("inner client called %d times, want 1 when the default temperature is 0", calls)
+	}
+
+	calls = 0
+	chain := &ChainClient{providers: []core.AIClient{
+		&defaultingClient{mockAIClient: countingClient(&calls)},
+		&defaultingClient{mockAIClient: countingClient(&calls), temperature: 0.7},
+	}}
+	if deterministic(chain, options) {
+		t.Error("Expected a chain with a sampling provider to be non-deterministic")
+	}
+}
+
+func TestCachingClient_LooksThroughNewClientWrappers(t *testing.T) {
+	originalRegistry := registry
+	defer func() { registry = originalRegistry }()
+
+	calls := 0
+	registry = &ProviderRegistry{providers: make(map[string]ProviderFactory)}
+	registry.providers["mock"] = &mockFactory{
+		name:   "mock",
+		client: &defaultingClient{mockAIClient: countingClient(&calls), temperature: 0.7},
+	}
+
+	inner, err := NewClient(WithProvider("mock"), WithRateLimit(6000, 0), WithBudget(100, time.Hour))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	client := NewCachingClient(inner, core.NewInMemoryStore(), time.Minute)
+
+	ctx := context.Background()
+	_, _ = client.GenerateResponse(ctx, "q", nil)
+	_, _ = client.GenerateResponse(ctx, "q", nil)
+	if calls != 2 {
+		t.Errorf("inner client called %d times, want 2 when the wrapped provider samples at 0.7", calls)
+	}
+}
+
+func TestCachingClient_ErrorsNotCached(t *testing.T) {
+	calls := 0
+	inner := &mockAIClient{
+		generateFunc: func(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+			calls++
+			return nil, errors.New("provider unavailable")
+		},
+	}
+	client := NewCachingClient(inner, core.NewInMemoryStore(), time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GenerateResponse(context.Background(), "q", nil); err == nil {
+			t.Fatal("Expected the inner error")
+		}
+	}
+	if calls != 2 {
+		t.Errorf("inner client called %d times, want 2", calls)
+	}
+}
+
+func TestCachingClient_MemoryFailure(t *testing.T) {
+	calls := 0
+	client := NewCachingClient(countingClient(&calls), failingMemory{}, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.GenerateResponse(context.Background(), "q", nil)
+		if err != nil || resp.Content != "answer to q" {
+			t.Fatalf("GenerateResponse() = %+v, %v; cache failures must not fail the call", resp, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("inner client called %d times, want 2", calls)
+	}
+}
+
+func TestCachingClient_StreamingPassThrough(t *testing.T) {
+	client := NewCachingClient(&mockAIClient{}, core.NewInMemoryStore(), time.Minute)
+	if client.SupportsStreaming() {
+		t.Error("Expected no streaming support from a non-streaming inner client")
+	}
+	if _, err := client.StreamResponse(context.Background(), "q", nil, nil); err == nil {
+		t.Error("Expected an error streaming through a non-streaming inner client")
+	}
+}
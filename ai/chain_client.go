@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/itsneelabh/gomind/ai/providers"
 	"github.com/itsneelabh/gomind/core"
 	"github.com/itsneelabh/gomind/telemetry"
 )
@@ -39,7 +40,7 @@ func NewChainClient(opts ...ChainOption) (*ChainClient, error) {
 
 	// Validate provider aliases at configuration time
 	validProviders := []string{
-		"openai", "anthropic", "gemini", // Base providers
+		"openai", "anthropic", "gemini", "ollama", // Base providers
 		"openai.deepseek", "openai.groq", "openai.xai", // OpenAI-compatible
 		"openai.together", "openai.qwen", "openai.ollama",
 	}
@@ -160,6 +161,23 @@ func (c *ChainClient) SetLogger(logger core.Logger) {
 //
 // See: ai/MODEL_ALIAS_CROSS_PROVIDER_PROPOSAL.md for the options mutation bug fix
 func (c *ChainClient) GenerateResponse(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+	return c.generate(ctx, len(prompt), options, func(ctx context.Context, provider core.AIClient, options *core.AIOptions) (*core.AIResponse, error) {
+		return provider.GenerateResponse(ctx, prompt, options)
+	})
+}
+
+// GenerateMessages sends a conversation through the chain with the same
+// failover behavior as GenerateResponse. Each provider receives it in its
+// native chat format (see core.GenerateMessages).
+func (c *ChainClient) GenerateMessages(ctx context.Context, messages []core.Message, options *core.AIOptions) (*core.AIResponse, error) {
+	promptLength := len(providers.ConversationText(messages))
+	return c.generate(ctx, promptLength, options, func(ctx context.Context, provider core.AIClient, options *core.AIOptions) (*core.AIResponse, error) {
+		return core.GenerateMessages(ctx, provider, messages, options)
+	})
+}
+
+// generate tries call on each provider in order, see GenerateResponse
+func (c *ChainClient) generate(ctx context.Context, promptLength int, options *core.AIOptions, call func(context.Context, core.AIClient, *core.AIOptions) (*core.AIResponse, error)) (*core.AIResponse, error) {
 	startTime := time.Now()
 
 	// Start parent span for the entire chain operation
@@ -180,7 +198,7 @@ func (c *ChainClient) GenerateResponse(ctx context.Context, prompt string, optio
 	// Set span attributes for the chain operation
 	span.SetAttribute("ai.chain.providers_count", len(c.providers))
 	span.SetAttribute("ai.chain.original_model", originalModel)
-	span.SetAttribute("ai.prompt_length", len(prompt))
+	span.SetAttribute("ai.prompt_length", promptLength)
 
 	// Log chain request start with trace correlation
 	if c.logger != nil {
@@ -189,7 +207,7 @@ func (c *ChainClient) GenerateResponse(ctx context.Context, prompt string, optio
 			"original_model":   originalModel,
 			"providers_count":  len(c.providers),
 			"provider_aliases": c.providerAliases,
-			"prompt_length":    len(prompt),
+			"prompt_length":    promptLength,
 		})
 	}
 
@@ -235,7 +253,7 @@ func (c *ChainClient) GenerateResponse(ctx context.Context, prompt string, optio
 
 		// Each provider already has circuit breaker protection internally
 		// This follows the framework principle: "External API calls must be protected by circuit breakers"
-		resp, err := provider.GenerateResponse(ctx, prompt, providerOpts)
+		resp, err := call(ctx, provider, providerOpts)
 		attemptDuration := time.Since(attemptStart)
 
 		if err == nil {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/itsneelabh/gomind/ai/providers"
 	"github.com/itsneelabh/gomind/core"
 )
 
@@ -24,6 +25,10 @@ func NewClient(opts ...AIOption) (core.AIClient, error) {
 		opt(config)
 	}
 
+	if err := validateStreamTimeouts(config); err != nil {
+		return nil, err
+	}
+
 	// Apply component-specific logging for AI module
 	if config.Logger != nil {
 		if cal, ok := config.Logger.(core.ComponentAwareLogger); ok {
@@ -77,6 +82,23 @@ func NewClient(opts ...AIOption) (core.AIClient, error) {
 	}
 
 	client := factory.Create(config)
+	if config.DebugRecorder != nil {
+		client = core.NewRecordingAIClient(client, config.DebugRecorder, config.Logger)
+	}
+	if limiter := rateLimiterFor(config); limiter != nil {
+		client = NewRateLimitedClient(client, limiter, config.Logger)
+	}
+	if config.BudgetLimit > 0 || config.CostTracker != nil {
+		tracker := config.CostTracker
+		if tracker == nil {
+			tracker = NewCostTracker(config.BudgetWindow)
+		}
+		tracker.SetLogger(config.Logger)
+		client = NewBudgetClient(client, tracker, config.BudgetLimit, config.Logger)
+	}
+	if config.InputGuard != nil {
+		client = NewGuardedClient(client, config.InputGuard, config.Logger)
+	}
 	if config.Logger != nil {
 		config.Logger.Info("AI client created successfully", map[string]interface{}{
 			"operation":   "ai_client_creation",
@@ -89,6 +111,24 @@ func NewClient(opts ...AIOption) (core.AIClient, error) {
 	return client, nil
 }
 
+// validateStreamTimeouts checks that the idle timeout can fire before the
+// total timeout, applying the provider defaults for unset values
+func validateStreamTimeouts(config *AIConfig) error {
+	total := config.StreamTotalTimeout
+	if total <= 0 {
+		total = providers.DefaultStreamTotalTimeout
+	}
+	idle := config.StreamIdleTimeout
+	if idle <= 0 {
+		idle = providers.DefaultStreamIdleTimeout
+	}
+	if config.StreamTotalTimeout < 0 || config.StreamIdleTimeout < 0 || idle >= total {
+		return fmt.Errorf("%w: stream idle timeout (%s) must be positive and less than stream total timeout (%s)",
+			core.ErrInvalidConfiguration, idle, total)
+	}
+	return nil
+}
+
 // MustNewClient creates a new AI client and panics on error
 func MustNewClient(opts ...AIOption) core.AIClient {
 	client, err := NewClient(opts...)
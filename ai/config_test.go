@@ -1,6 +1,7 @@
 package ai
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -129,3 +130,32 @@ func (t *testProviderFactory) Name() string {
 func (t *testProviderFactory) Description() string {
 	return "Test Provider"
 }
+
+func TestNewClient_StreamTimeoutValidation(t *testing.T) {
+	_ = Register(&testProviderFactory{})
+
+	tests := []struct {
+		name    string
+		opts    []AIOption
+		wantErr bool
+	}{
+		{"defaults", nil, false},
+		{"idle below total", []AIOption{WithStreamTotalTimeout(time.Minute), WithStreamIdleTimeout(10 * time.Second)}, false},
+		{"idle equals total", []AIOption{WithStreamTotalTimeout(time.Minute), WithStreamIdleTimeout(time.Minute)}, true},
+		{"total below default idle", []AIOption{WithStreamTotalTimeout(30 * time.Second)}, true},
+		{"negative idle", []AIOption{WithStreamIdleTimeout(-time.Second)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]AIOption{WithProvider("test-provider"), WithAPIKey("test-key")}, tt.opts...)
+			_, err := NewClient(opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, core.ErrInvalidConfiguration) {
+				t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package ai
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+	"github.com/itsneelabh/gomind/telemetry"
+)
+
+// Cost estimation for LLM calls.
+//
+// Prices are looked up by model-name prefix, like tokenizers, so a price
+// registered for "gpt-4o" covers dated snapshots such as
+// "gpt-4o-2024-08-06". The built-in table holds list prices in USD at the
+// time of writing; providers change them and negotiated or fine-tuned
+// prices differ, so register your own with RegisterModelPrice:
+//
+//	ai.RegisterModelPrice("ft:gpt-4o-mini", ai.ModelPrice{InputPerMillion: 0.30, OutputPerMillion: 1.20})
+//
+// Models without a price are estimated at the highest input and output
+// prices in the table, so unknown models never look cheaper than they are.
+
+// ModelPrice is the cost of a model in USD per million tokens
+type ModelPrice struct {
+	InputPerMillion  float64
+	OutputPerMillion float64
+}
+
+// Cost returns the USD cost of usage at this price
+func (p ModelPrice) Cost(usage core.TokenUsage) float64 {
+	return (float64(usage.PromptTokens)*p.InputPerMillion + float64(usage.CompletionTokens)*p.OutputPerMillion) / 1e6
+}
+
+// priceRegistry maps model-name prefixes to prices. The longest matching
+// prefix wins so "gpt-4o-mini" can override "gpt-4o".
+type priceRegistry struct {
+	mu       sync.RWMutex
+	prefixes []string
+	byPrefix map[string]ModelPrice
+}
+
+var modelPrices = newPriceRegistry()
+
+func newPriceRegistry() *priceRegistry {
+	r := &priceRegistry{byPrefix: make(map[string]ModelPrice)}
+	for prefix, price := range map[string]ModelPrice{
+		// OpenAI
+		"gpt-5":         {1.25, 10},
+		"gpt-5-mini":    {0.25, 2},
+		"gpt-5-nano":    {0.05, 0.40},
+		"gpt-4.1":       {2, 8},
+		"gpt-4.1-mini":  {0.40, 1.60},
+		"gpt-4.1-nano":  {0.10, 0.40},
+		"gpt-4o":        {2.50, 10},
+		"gpt-4o-mini":   {0.15, 0.60},
+		"gpt-4-turbo":   {10, 30},
+		"gpt-4":         {30, 60},
+		"gpt-3.5-turbo": {0.50, 1.50},
+		"o1":            {15, 60},
+		"o3":            {2, 8},
+		"o3-mini":       {1.10, 4.40},
+		"o4-mini":       {1.10, 4.40},
+
+		// Anthropic, including Bedrock model IDs
+		"claude-opus-4":              {15, 75},
+		"claude-sonnet-4":            {3, 15},
+		"claude-3-7-sonnet":          {3, 15},
+		"claude-3-5-sonnet":          {3, 15},
+		"claude-3-5-haiku":           {0.80, 4},
+		"claude-3-haiku":             {0.25, 1.25},
+		"anthropic.claude-opus-4":    {15, 75},
+		"anthropic.claude-sonnet-4":  {3, 15},
+		"anthropic.claude-3-5-haiku": {0.80, 4},
+
+		// Google
+		"gemini-2.5-pro":        {1.25, 10},
+		"gemini-2.5-flash":      {0.30, 2.50},
+		"gemini-2.5-flash-lite": {0.10, 0.40},
+		"gemini-2.0-flash":      {0.10, 0.40},
+	} {
+		r.register(prefix, price)
+	}
+	return r
+}
+
+func (r *priceRegistry) register(prefix string, price ModelPrice) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.byPrefix[prefix]; !exists {
+		r.prefixes = append(r.prefixes, prefix)
+		sort.Slice(r.prefixes, func(i, j int) bool {
+			return len(r.prefixes[i]) > len(r.prefixes[j])
+		})
+	}
+	r.byPrefix[prefix] = price
+}
+
+func (r *priceRegistry) lookup(model string) (ModelPrice, bool) {
+	model = strings.ToLower(model)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, prefix := range r.prefixes {
+		if strings.HasPrefix(model, prefix) {
+			return r.byPrefix[prefix], true
+		}
+	}
+	return ModelPrice{}, false
+}
+
+// highest returns the highest input and output prices in the table
+func (r *priceRegistry) highest() ModelPrice {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var max ModelPrice
+	for _, price := range r.byPrefix {
+		if price.InputPerMillion > max.InputPerMillion {
+			max.InputPerMillion = price.InputPerMillion
+		}
+		if price.OutputPerMillion > max.OutputPerMillion {
+			max.OutputPerMillion = price.OutputPerMillion
+		}
+	}
+	return max
+}
+
+// RegisterModelPrice sets the price for all models whose name starts with
+// modelPrefix (case-insensitive). Registering an existing prefix replaces
+// its price, so built-in prices can be corrected.
+func RegisterModelPrice(modelPrefix string, price ModelPrice) error {
+	if modelPrefix == "" {
+		return fmt.Errorf("model prefix cannot be empty")
+	}
+	if price.InputPerMillion < 0 || price.OutputPerMillion < 0 {
+		return fmt.Errorf("model price cannot be negative")
+	}
+	modelPrices.register(strings.ToLower(modelPrefix), price)
+	return nil
+}
+
+// LookupModelPrice returns the registered price for model
+func LookupModelPrice(model string) (ModelPrice, bool) {
+	return modelPrices.lookup(model)
+}
+
+// EstimateCost returns the USD cost of usage on model. known is false when
+// the model has no registered price, in which case the cost is estimated
+// at the highest registered prices.
+func EstimateCost(model string, usage core.TokenUsage) (cost float64, known bool) {
+	price, known := modelPrices.lookup(model)
+	if !known {
+		price = modelPrices.highest()
+	}
+	return price.Cost(usage), known
+}
+
+// costEntry is one call's cost, for the rolling window
+type costEntry struct {
+	at   time.Time
+	cost float64
+}
+
+// CostTracker adds up the estimated cost of AI calls over a rolling window.
+// Feed it each response's usage with Record, and read the current spend
+// with Spend, e.g. for a telemetry gauge. A tracker can be shared by
+// several clients with WithCostTracker. It is safe for concurrent use.
+type CostTracker struct {
+	window time.Duration
+	logger core.Logger
+
+	mu      sync.Mutex
+	entries []costEntry
+	total   float64
+	warned  map[string]bool
+	now     func() time.Time
+}
+
+// NewCostTracker returns a tracker whose Spend covers the last window.
+// A window of 0 tracks spend since creation.
+func NewCostTracker(window time.Duration) *CostTracker {
+	return &CostTracker{
+		window: window,
+		logger: &core.NoOpLogger{},
+		warned: make(map[string]bool),
+		now:    time.Now,
+	}
+}
+
+// SetLogger sets the logger used to warn about models without a price
+func (t *CostTracker) SetLogger(logger core.Logger) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if logger == nil {
+		t.logger = &core.NoOpLogger{}
+	} else if cal, ok := logger.(core.ComponentAwareLogger); ok {
+		t.logger = cal.WithComponent("framework/ai")
+	} else {
+		t.logger = logger
+	}
+}
+
+// Window returns the length of the rolling window, or 0 for all-time
+func (t *CostTracker) Window() time.Duration {
+	return t.window
+}
+
+// Record adds the estimated cost of usage on model and returns it. The
+// first call for a model without a registered price logs a warning.
+func (t *CostTracker) Record(model string, usage core.TokenUsage) float64 {
+	cost, known := EstimateCost(model, usage)
+
+	t.mu.Lock()
+	now := t.now()
+	if t.window > 0 {
+		// Without a window only the running total is needed
+		t.entries = append(t.entries, costEntry{at: now, cost: cost})
+	}
+	t.total += cost
+	t.prune(now)
+	spend := t.total
+	warn := !known && !t.warned[model]
+	if warn {
+		t.warned[model] = true
+	}
+	logger := t.logger
+	t.mu.Unlock()
+
+	if warn {
+		logger.Warn("No price registered for AI model, estimating at the highest known price", map[string]interface{}{
+			"operation": "ai_cost_estimate",
+			"model":     model,
+			"hint":      "Register the price with ai.RegisterModelPrice",
+		})
+	}
+	telemetry.Gauge("ai.cost.spend_usd", spend,
+		"module", telemetry.ModuleAI,
+	)
+	return cost
+}
+
+// Spend returns the estimated USD spend within the window
+func (t *CostTracker) Spend() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune(t.now())
+	return t.total
+}
+
+// prune drops entries older than the window. Callers must hold t.mu.
+func (t *CostTracker) prune(now time.Time) {
+	if t.window <= 0 {
+		return
+	}
+	cutoff := now.Add(-t.window)
+	expired := 0
+	for expired < len(t.entries) && !t.entries[expired].at.After(cutoff) {
+		t.total -= t.entries[expired].cost
+		expired++
+	}
+	if expired > 0 {
+		t.entries = t.entries[expired:]
+		if len(t.entries) == 0 {
+			t.total = 0 // Avoid drift from floating-point subtraction
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package ai
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestEstimateCost(t *testing.T) {
+	usage := core.TokenUsage{PromptTokens: 1_000_000, CompletionTokens: 500_000, TotalTokens: 1_500_000}
+
+	cost, known := EstimateCost("gpt-4o-mini-2024-07-18", usage)
+	if !known || !approxEqual(cost, 0.15+0.30) {
+		t.Errorf("EstimateCost(gpt-4o-mini) = %v, %v; want 0.45, true", cost, known)
+	}
+	cost, _ = EstimateCost("GPT-4o", usage)
+	if !approxEqual(cost, 2.50+5) {
+		t.Errorf("Expected the longest prefix to win case-insensitively, got %v", cost)
+	}
+
+	highest := modelPrices.highest()
+	cost, known = EstimateCost("my-finetune", usage)
+	if known || !approxEqual(cost, highest.Cost(usage)) {
+		t.Errorf("Expected unknown models at the highest prices, got %v, %v", cost, known)
+	}
+}
+
+func TestRegisterModelPrice(t *testing.T) {
+	original := modelPrices
+	modelPrices = newPriceRegistry()
+	defer func() { modelPrices = original }()
+
+	if err := RegisterModelPrice("ft:gpt-4o-mini", ModelPrice{InputPerMillion: 0.30, OutputPerMillion: 1.20}); err != nil {
+		t.Fatalf("RegisterModelPrice() error = %v", err)
+	}
+	price, ok := LookupModelPrice("ft:gpt-4o-mini:acme:support")
+	if !ok || price.OutputPerMillion != 1.20 {
+		t.Errorf("LookupModelPrice() = %+v, %v", price, ok)
+	}
+	if err := RegisterModelPrice("gpt-4o", ModelPrice{InputPerMillion: 2, OutputPerMillion: 8}); err != nil {
+		t.Fatalf("RegisterModelPrice() error = %v", err)
+	}
+	if price, _ := LookupModelPrice("gpt-4o"); price.InputPerMillion != 2 {
+		t.Errorf("Expected the built-in price to be replaced, got %+v", price)
+	}
+
+	if RegisterModelPrice("", ModelPrice{}) == nil {
+		t.Error("Expected an error for an empty prefix")
+	}
+	if RegisterModelPrice("x", ModelPrice{InputPerMillion: -1}) == nil {
+		t.Error("Expected an error for a negative price")
+	}
+}
+
+func TestCostTracker_RollingWindow(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tracker := NewCostTracker(time.Hour)
+	tracker.now = func() time.Time { return now }
+	usage := core.TokenUsage{PromptTokens: 1_000_000}
+
+	tracker.Record("gpt-4o", usage) // $2.50
+	now = now.Add(30 * time.Minute)
+	tracker.Record("gpt-4o-mini", usage) // $0.15
+	if spend := tracker.Spend(); !approxEqual(spend, 2.65) {
+		t.Errorf("Spend() = %v, want 2.65", spend)
+	}
+
+	now = now.Add(31 * time.Minute)
+	if spend := tracker.Spend(); !approxEqual(spend, 0.15) {
+		t.Errorf("Expected the first call to leave the window, Spend() = %v", spend)
+	}
+	now = now.Add(time.Hour)
+	if spend := tracker.Spend(); spend != 0 {
+		t.Errorf("Spend() = %v, want 0", spend)
+	}
+}
+
+func TestCostTracker_NoWindowKeepsOnlyTotal(t *testing.T) {
+	tracker := NewCostTracker(0)
+	usage := core.TokenUsage{PromptTokens: 1_000_000}
+
+	for i := 0; i < 3; i++ {
+		tracker.Record("gpt-4o", usage) // $2.50
+	}
+	if spend := tracker.Spend(); !approxEqual(spend, 7.5) {
+		t.Errorf("Spend() = %v, want 7.5", spend)
+	}
+	if len(tracker.entries) != 0 {
+		t.Errorf("Expected no per-call entries without a window, got %d", len(tracker.entries))
+	}
+}
+
+func TestCostTracker_WarnsOnceForUnknownModels(t *testing.T) {
+	logger := &testLogger{}
+	tracker := NewCostTracker(0)
+	tracker.SetLogger(logger)
+
+	tracker.Record("mystery-model", core.TokenUsage{PromptTokens: 10})
+	tracker.Record("mystery-model", core.TokenUsage{PromptTokens: 10})
+	tracker.Record("gpt-4o", core.TokenUsage{PromptTokens: 10})
+
+	if len(logger.logs) != 1 || !strings.HasPrefix(logger.logs[0], "WARN: ") {
+		t.Errorf("Expected one warning, got %v", logger.logs)
+	}
+}
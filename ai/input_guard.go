@@ -0,0 +1,179 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/itsneelabh/gomind/core"
+	"github.com/itsneelabh/gomind/telemetry"
+)
+
+// InputGuard inspects a prompt before it is sent to the provider. Returning
+// allow=false blocks the call; reason is reported in the error and logs.
+type InputGuard func(ctx context.Context, prompt string) (allow bool, reason string)
+
+// ErrInputRejected matches, via errors.Is, every error returned when an
+// InputGuard blocks a prompt
+var ErrInputRejected = errors.New("input rejected")
+
+// InputRejectedError is returned when an InputGuard blocks a prompt
+type InputRejectedError struct {
+	Reason string
+}
+
+func (e *InputRejectedError) Error() string {
+	if e.Reason == "" {
+		return ErrInputRejected.Error()
+	}
+	return fmt.Sprintf("%s: %s", ErrInputRejected.Error(), e.Reason)
+}
+
+// Is makes errors.Is(err, ErrInputRejected) true
+func (e *InputRejectedError) Is(target error) bool {
+	return target == ErrInputRejected
+}
+
+// WithInputGuard runs guard on every prompt before it reaches the provider.
+// Use it for agents that pass untrusted user input to the model, e.g. with
+// PromptInjectionGuard.
+func WithInputGuard(guard InputGuard) AIOption {
+	return func(c *AIConfig) {
+		c.InputGuard = guard
+	}
+}
+
+// injectionPatterns are phrasings common in prompt-injection and jailbreak
+// attempts. They are deliberately narrow to keep false positives rare.
+var injectionPatterns = []struct {
+	pattern *regexp.Regexp
+	reason  string
+}{
+	{regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override)\s+(all\s+|any\s+|the\s+|your\s+)*(previous|prior|above|earlier|preceding|system)\s+(instructions|prompts?|rules|directions|messages)`), "attempt to override previous instructions"},
+	{regexp.MustCompile(`(?i)\b(reveal|show|print|repeat|output)\s+(me\s+)?(your|the)\s+(system\s+prompt|hidden\s+instructions|initial\s+instructions)`), "attempt to extract the system prompt"},
+	{regexp.MustCompile(`(?i)\byou\s+are\s+now\s+(in\s+)?(developer|dan|jailbreak|unrestricted|god)\s*(mode)?\b`), "jailbreak role change"},
+	{regexp.MustCompile(`(?i)\b(enable|enter|activate)\s+(developer|jailbreak|dan|unrestricted)\s+mode\b`), "jailbreak mode request"},
+	{regexp.MustCompile(`(?i)\bpretend\s+(that\s+)?you\s+(have\s+no|are\s+not\s+bound\s+by|don'?t\s+have)\s+(restrictions|rules|guidelines|limits)`), "attempt to remove restrictions"},
+}
+
+// PromptInjectionGuard returns a heuristic InputGuard that blocks prompts
+// containing common injection phrasings such as "ignore previous
+// instructions". It is a first line of defense, not a complete one: it
+// catches unsophisticated attempts and can be combined with your own checks.
+func PromptInjectionGuard() InputGuard {
+	return func(ctx context.Context, prompt string) (bool, string) {
+		for _, p := range injectionPatterns {
+			if p.pattern.MatchString(prompt) {
+				return false, p.reason
+			}
+		}
+		return true, ""
+	}
+}
+
+// NewGuardedClient wraps client so every prompt is checked by guard first.
+// NewClient does this automatically when WithInputGuard is set; use this
+// function for clients built another way, such as a ChainClient. Streaming
+// support of the wrapped client is preserved.
+func NewGuardedClient(client core.AIClient, guard InputGuard, logger core.Logger) core.AIClient {
+	if guard == nil {
+		return client
+	}
+	if logger == nil {
+		logger = &core.NoOpLogger{}
+	}
+	guarded := &guardedClient{client: client, guard: guard, logger: logger}
+	if streaming, ok := client.(core.StreamingAIClient); ok {
+		return &guardedStreamingClient{guardedClient: guarded, streaming: streaming}
+	}
+	return guarded
+}
+
+// guardedClient applies an InputGuard before delegating to another client
+type guardedClient struct {
+	client core.AIClient
+	guard  InputGuard
+	logger core.Logger
+}
+
+// Unwrap returns the wrapped client
+func (g *guardedClient) Unwrap() core.AIClient {
+	return g.client
+}
+
+// SetLogger updates the logger and propagates it to the wrapped client
+func (g *guardedClient) SetLogger(logger core.Logger) {
+	if logger == nil {
+		g.logger = &core.NoOpLogger{}
+	} else if cal, ok := logger.(core.ComponentAwareLogger); ok {
+		g.logger = cal.WithComponent("framework/ai")
+	} else {
+		g.logger = logger
+	}
+
+	if logger != nil {
+		if loggable, ok := g.client.(interface{ SetLogger(core.Logger) }); ok {
+			loggable.SetLogger(logger)
+		}
+	}
+}
+
+// GenerateResponse checks the prompt, then delegates to the wrapped client
+func (g *guardedClient) GenerateResponse(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+	if err := g.check(ctx, prompt); err != nil {
+		return nil, err
+	}
+	return g.client.GenerateResponse(ctx, prompt, options)
+}
+
+// GenerateMessages checks every user message, then delegates to the wrapped
+// client. System, assistant and tool messages are not checked.
+func (g *guardedClient) GenerateMessages(ctx context.Context, messages []core.Message, options *core.AIOptions) (*core.AIResponse, error) {
+	for _, message := range messages {
+		if message.Role != core.RoleUser {
+			continue
+		}
+		if err := g.check(ctx, message.Content); err != nil {
+			return nil, err
+		}
+	}
+	return core.GenerateMessages(ctx, g.client, messages, options)
+}
+
+// check runs the guard and records a rejection
+func (g *guardedClient) check(ctx context.Context, prompt string) error {
+	allow, reason := g.guard(ctx, prompt)
+	if allow {
+		return nil
+	}
+
+	g.logger.WarnWithContext(ctx, "AI input rejected by guard", map[string]interface{}{
+		"operation":     "ai_input_guard",
+		"reason":        reason,
+		"prompt_length": len(prompt),
+	})
+	telemetry.Counter("ai.input_guard.rejected",
+		"module", telemetry.ModuleAI,
+	)
+	return &InputRejectedError{Reason: reason}
+}
+
+// guardedStreamingClient is a guardedClient for clients that can stream
+type guardedStreamingClient struct {
+	*guardedClient
+	streaming core.StreamingAIClient
+}
+
+// StreamResponse checks the prompt, then streams from the wrapped client
+func (g *guardedStreamingClient) StreamResponse(ctx context.Context, prompt string, options *core.AIOptions, callback core.StreamCallback) (*core.AIResponse, error) {
+	if err := g.check(ctx, prompt); err != nil {
+		return nil, err
+	}
+	return g.streaming.StreamResponse(ctx, prompt, options, callback)
+}
+
+// SupportsStreaming reports whether the wrapped client can stream
+func (g *guardedStreamingClient) SupportsStreaming() bool {
+	return g.streaming.SupportsStreaming()
+}
@@ -18,3 +18,7 @@ type (
 	StreamCallback    = core.StreamCallback
 	StreamingAIClient = core.StreamingAIClient
 )
+
+// Message is a single turn in a chat conversation, as sent by
+// core.GenerateMessages
+type Message = core.Message
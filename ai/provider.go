@@ -1,10 +1,12 @@
 package ai
 
 import (
+	"context"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/itsneelabh/gomind/ai/providers"
 	"github.com/itsneelabh/gomind/core"
 )
 
@@ -35,9 +37,18 @@ type AIConfig struct {
 	APIKey  string
 	BaseURL string
 
-	// Connection settings
-	Timeout    time.Duration
-	MaxRetries int
+	// Connection settings. Timeout bounds a single GenerateResponse call
+	// (see WithRequestTimeout); streamed responses are bounded by
+	// StreamTotalTimeout and StreamIdleTimeout instead. Zero stream
+	// timeouts keep the provider defaults.
+	Timeout            time.Duration
+	MaxRetries         int
+	StreamTotalTimeout time.Duration
+	StreamIdleTimeout  time.Duration
+
+	// RetryPolicy, when set, replaces MaxRetries for HTTP-based providers.
+	// See WithRetryPolicy.
+	RetryPolicy *RetryPolicy
 
 	// Model configuration
 	Model       string
@@ -54,6 +65,34 @@ type AIConfig struct {
 	Logger    core.Logger
 	Telemetry core.Telemetry
 
+	// IdempotencyKeyFunc derives an idempotency key from the request context
+	// (typically the request ID). Providers that support it send the key so a
+	// network-retried request isn't billed twice. See WithIdempotencyKeyFunc.
+	IdempotencyKeyFunc func(ctx context.Context) string
+
+	// InputGuard, when set, checks every prompt before it reaches the
+	// provider. See WithInputGuard.
+	InputGuard InputGuard
+
+	// DebugRecorder, when set, records every call that reaches the
+	// provider. See WithDebugRecorder.
+	DebugRecorder core.AIInteractionRecorder
+
+	// BudgetLimit and BudgetWindow cap the estimated spend (USD) over a
+	// rolling window. See WithBudget.
+	BudgetLimit  float64
+	BudgetWindow time.Duration
+
+	// CostTracker, when set, records the estimated cost of every call. See
+	// WithCostTracker.
+	CostTracker *CostTracker
+
+	// RateLimit, ModelRateLimits and RateLimiter limit the requests and
+	// tokens per minute sent to the provider. See WithRateLimit.
+	RateLimit       *RateLimit
+	ModelRateLimits map[string]RateLimit
+	RateLimiter     *RateLimiter
+
 	// Advanced options
 	Headers map[string]string
 	Extra   map[string]interface{}
@@ -107,13 +146,37 @@ func WithAWSCredentials(accessKey, secretKey, sessionToken string) AIOption {
 	}
 }
 
-// WithTimeout sets the request timeout
+// WithTimeout sets the request timeout. It is equivalent to WithRequestTimeout.
 func WithTimeout(timeout time.Duration) AIOption {
+	return WithRequestTimeout(timeout)
+}
+
+// WithRequestTimeout bounds each non-streaming GenerateResponse call,
+// including reading the response. Streaming calls are not affected; see
+// WithStreamTotalTimeout and WithStreamIdleTimeout.
+func WithRequestTimeout(timeout time.Duration) AIOption {
 	return func(c *AIConfig) {
 		c.Timeout = timeout
 	}
 }
 
+// WithStreamTotalTimeout bounds the whole of a StreamResponse call, from
+// sending the request to the last chunk. Default: 10 minutes.
+func WithStreamTotalTimeout(timeout time.Duration) AIOption {
+	return func(c *AIConfig) {
+		c.StreamTotalTimeout = timeout
+	}
+}
+
+// WithStreamIdleTimeout ends a StreamResponse call when no data arrives for
+// the given duration, so a stalled stream fails without waiting for the total
+// timeout. It must be shorter than the stream total timeout. Default: 2 minutes.
+func WithStreamIdleTimeout(timeout time.Duration) AIOption {
+	return func(c *AIConfig) {
+		c.StreamIdleTimeout = timeout
+	}
+}
+
 // WithMaxRetries sets the maximum number of retries
 func WithMaxRetries(retries int) AIOption {
 	return func(c *AIConfig) {
@@ -121,6 +184,34 @@ func WithMaxRetries(retries int) AIOption {
 	}
 }
 
+// RetryPolicy controls how transient provider failures are retried: the
+// number of retries, the base backoff delay (doubled per retry), a cap on
+// each delay, jitter, and a predicate over the failed response or network
+// error. It is core.AIRetryPolicy, so it can also be set per call as
+// core.AIOptions.RetryPolicy.
+type RetryPolicy = providers.RetryConfig
+
+// WithRetryPolicy retries GenerateResponse calls according to policy, unless
+// a call sets its own AIOptions.RetryPolicy. Without a policy, clients retry
+// network errors, 429 and 5xx up to MaxRetries times with plain exponential
+// backoff. Either way, a Retry-After header from the provider sets the
+// delay, capped by the policy's MaxDelay, and the caller's context cancels
+// the wait. The policy applies to the OpenAI, Anthropic, Gemini and Ollama
+// providers; Bedrock uses the AWS SDK's own retries. Streaming requests are
+// never retried.
+//
+//	ai.WithRetryPolicy(&ai.RetryPolicy{
+//	    MaxRetries: 4,
+//	    RetryDelay: 500 * time.Millisecond,
+//	    MaxDelay:   10 * time.Second,
+//	    Jitter:     0.2,
+//	})
+func WithRetryPolicy(policy *RetryPolicy) AIOption {
+	return func(c *AIConfig) {
+		c.RetryPolicy = policy
+	}
+}
+
 // WithModel sets the model to use
 func WithModel(model string) AIOption {
 	return func(c *AIConfig) {
@@ -197,6 +288,45 @@ func WithTelemetry(telemetry core.Telemetry) AIOption {
 	}
 }
 
+// WithIdempotencyKeyFunc sets a function that derives an idempotency key for
+// each provider call, usually from the request ID in the context. The provider
+// appends a short hash of the request body so distinct calls made for the same
+// request get distinct keys, while retries of the same call reuse one key.
+// Returning "" skips the header for that call.
+//
+// Currently honored by the OpenAI provider (including OpenAI-compatible aliases),
+// which sends it as the Idempotency-Key header. Other providers ignore it.
+//
+// Example:
+//
+//	ai.WithIdempotencyKeyFunc(func(ctx context.Context) string {
+//	    return telemetry.GetBaggage(ctx)["request_id"]
+//	})
+func WithIdempotencyKeyFunc(fn func(ctx context.Context) string) AIOption {
+	return func(c *AIConfig) {
+		c.IdempotencyKeyFunc = fn
+	}
+}
+
+// WithDebugRecorder records every call that reaches the provider, with its
+// complete prompt, response, model, token usage, duration and outcome, to
+// recorder. Calls are recorded against the request ID in their context (see
+// core.CorrelationIDs); calls without one are not recorded. Use it for
+// agents that call the AI client directly, so those calls show up in the LLM
+// debug store next to the orchestrator's. Agents configured with
+// core.WithAIDebugRecorder are wrapped automatically.
+//
+// Example:
+//
+//	client, _ := ai.NewClient(
+//	    ai.WithDebugRecorder(orchestration.NewLLMDebugRecorder(store)),
+//	)
+func WithDebugRecorder(recorder core.AIInteractionRecorder) AIOption {
+	return func(c *AIConfig) {
+		c.DebugRecorder = recorder
+	}
+}
+
 // WithProviderAlias sets the provider alias for OpenAI-compatible services (Phase 2)
 // Examples: "openai.deepseek", "openai.groq", "openai.together"
 // FOLLOWS FRAMEWORK PRINCIPLE: Intelligent Configuration Over Convention
@@ -51,13 +51,22 @@ func NewClient(apiKey, baseURL string, logger core.Logger) *Client {
 
 // GenerateResponse generates a response using Anthropic's native Messages API
 func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+	return c.GenerateMessages(ctx, []core.Message{{Role: core.RoleUser, Content: prompt}}, options)
+}
+
+// GenerateMessages generates a response to a conversation using Anthropic's
+// native Messages API. System messages are sent as the system prompt.
+func (c *Client) GenerateMessages(ctx context.Context, conversation []core.Message, options *core.AIOptions) (*core.AIResponse, error) {
 	// Start distributed tracing span
 	ctx, span := c.StartSpan(ctx, "ai.generate_response")
 	defer span.End()
 
+	prompt := providers.ConversationText(conversation)
+
 	// Set initial span attributes
 	span.SetAttribute("ai.provider", "anthropic")
 	span.SetAttribute("ai.prompt_length", len(prompt))
+	span.SetAttribute("ai.message_count", len(conversation))
 
 	if c.apiKey == "" {
 		if c.Logger != nil {
@@ -74,6 +83,10 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 	// Apply defaults
 	options = c.ApplyDefaults(options)
 
+	// Bound the call by options.Timeout; an earlier ctx deadline still wins
+	ctx, cancel := c.RequestContext(ctx, options)
+	defer cancel()
+
 	// Resolve model alias (e.g., "smart" -> "claude-3-5-sonnet-20241022")
 	options.Model = resolveModel(options.Model)
 
@@ -85,11 +98,11 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 	startTime := time.Now()
 
 	// Build messages in Anthropic format
-	messages := []Message{
-		{
-			Role:    "user",
-			Content: prompt,
-		},
+	system, turns := core.SplitSystemMessages(conversation, options.SystemPrompt)
+	messages, err := buildMessages(turns)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
 	}
 
 	// Build request body using native Anthropic format
@@ -98,11 +111,20 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 		Messages:    messages,
 		MaxTokens:   options.MaxTokens,
 		Temperature: options.Temperature,
+		System:      system,
 	}
 
-	// Add system prompt if provided
-	if options.SystemPrompt != "" {
-		reqBody.System = options.SystemPrompt
+	// Add tools the model may call
+	for _, tool := range options.Tools {
+		schema := tool.Parameters
+		if len(schema) == 0 {
+			schema = json.RawMessage(`{"type":"object","properties":{}}`)
+		}
+		reqBody.Tools = append(reqBody.Tools, Tool{
+			Name:        tool.Name,
+			Description: tool.Description,
+			InputSchema: schema,
+		})
 	}
 
 	jsonData, err := json.Marshal(reqBody)
@@ -203,15 +225,23 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	// Extract text content from response
+	// Extract text content and tool calls from response
 	var content string
+	var toolCalls []core.ToolCall
 	for _, item := range anthropicResp.Content {
-		if item.Type == "text" {
+		switch item.Type {
+		case "text":
 			content += item.Text
+		case "tool_use":
+			arguments := item.Input
+			if arguments == nil {
+				arguments = map[string]interface{}{}
+			}
+			toolCalls = append(toolCalls, core.ToolCall{ID: item.ID, Name: item.Name, Arguments: arguments})
 		}
 	}
 
-	if content == "" {
+	if content == "" && len(toolCalls) == 0 {
 		if c.Logger != nil {
 			c.Logger.ErrorWithContext(ctx, "Anthropic request failed - empty response", map[string]interface{}{
 				"operation": "ai_request_error",
@@ -226,7 +256,7 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 	}
 
 	result := &core.AIResponse{
-		Content:  content,
+		Content:  providers.FormatContent(content, options),
 		Model:    anthropicResp.Model,
 		Provider: "anthropic",
 		Usage: core.TokenUsage{
@@ -234,6 +264,7 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 			CompletionTokens: anthropicResp.Usage.OutputTokens,
 			TotalTokens:      anthropicResp.Usage.InputTokens + anthropicResp.Usage.OutputTokens,
 		},
+		ToolCalls: toolCalls,
 	}
 
 	// Add token usage to span for cost tracking and debugging
@@ -275,6 +306,10 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 	// Apply defaults
 	options = c.ApplyDefaults(options)
 
+	// Bound the call by options.Timeout; an earlier ctx deadline still wins
+	ctx, cancel := c.StreamRequestContext(ctx, options)
+	defer cancel()
+
 	// Resolve model alias
 	options.Model = resolveModel(options.Model)
 
@@ -329,8 +364,8 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 	req.Header.Set("anthropic-version", APIVersion)
 	req.Header.Set("Accept", "text/event-stream")
 
-	// Execute request
-	resp, err := c.HTTPClient.Do(req)
+	// Execute request (no retry for streaming; bounded by the stream timeouts)
+	resp, err := c.ExecuteStream(req)
 	if err != nil {
 		if c.Logger != nil {
 			c.Logger.ErrorWithContext(ctx, "Anthropic streaming request failed - send error", map[string]interface{}{
@@ -488,6 +523,9 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 			}
 			if event.Usage != nil {
 				outputTokens = event.Usage.OutputTokens
+				if event.Usage.InputTokens > 0 {
+					inputTokens = event.Usage.InputTokens
+				}
 			}
 
 		case "message_stop":
@@ -541,3 +579,49 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 func (c *Client) SupportsStreaming() bool {
 	return true
 }
+
+// buildMessages converts a conversation without system messages to the
+// Messages API format. Tool calls become tool_use blocks and tool results
+// become tool_result blocks in a user message; consecutive tool results are
+// merged, as the API requires user and assistant turns to alternate.
+func buildMessages(conversation []core.Message) ([]Message, error) {
+	if len(conversation) == 0 {
+		return nil, core.ErrNoMessages
+	}
+
+	messages := make([]Message, 0, len(conversation))
+	for i, message := range conversation {
+		switch message.Role {
+		case core.RoleUser:
+			messages = append(messages, Message{Role: "user", Content: message.Content})
+		case core.RoleAssistant:
+			if len(message.ToolCalls) == 0 {
+				messages = append(messages, Message{Role: "assistant", Content: message.Content})
+				continue
+			}
+			blocks := []ContentBlock{}
+			if message.Content != "" {
+				blocks = append(blocks, ContentBlock{Type: "text", Text: message.Content})
+			}
+			for _, call := range message.ToolCalls {
+				input := call.Arguments
+				if input == nil {
+					input = map[string]interface{}{}
+				}
+				blocks = append(blocks, ContentBlock{Type: "tool_use", ID: call.ID, Name: call.Name, Input: input})
+			}
+			messages = append(messages, Message{Role: "assistant", Content: blocks})
+		case core.RoleTool:
+			result := ContentBlock{Type: "tool_result", ToolUseID: message.ToolCallID, Content: message.Content}
+			if i > 0 && conversation[i-1].Role == core.RoleTool {
+				last := &messages[len(messages)-1]
+				last.Content = append(last.Content.([]ContentBlock), result)
+				continue
+			}
+			messages = append(messages, Message{Role: "user", Content: []ContentBlock{result}})
+		default:
+			return nil, fmt.Errorf("unsupported message role %q", message.Role)
+		}
+	}
+	return messages, nil
+}
@@ -0,0 +1,155 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+func TestClient_StreamResponse_TerminalChunkCarriesUsage(t *testing.T) {
+	tests := []struct {
+		name       string
+		delta      string
+		wantInput  int
+		wantOutput int
+	}{
+		{"output tokens only", `{"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":7}}`, 25, 7},
+		{"cumulative input tokens", `{"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"input_tokens":30,"output_tokens":7}}`, 30, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				events := []string{
+					`event: message_start` + "\n" + `data: {"type":"message_start","message":{"id":"msg_1","type":"message","role":"assistant","model":"claude-test","usage":{"input_tokens":25,"output_tokens":1}}}`,
+					`event: content_block_delta` + "\n" + `data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hello"}}`,
+					`event: message_delta` + "\n" + `data: ` + tt.delta,
+					`event: message_stop` + "\n" + `data: {"type":"message_stop"}`,
+				}
+				for _, event := range events {
+					_, _ = w.Write([]byte(event + "\n\n"))
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient("test-key", server.URL, nil)
+
+			var terminal *core.StreamChunk
+			resp, err := client.StreamResponse(context.Background(), "hi", &core.AIOptions{Model: "claude-test"}, func(chunk core.StreamChunk) error {
+				if chunk.Usage != nil {
+					terminal = &chunk
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("StreamResponse() error = %v", err)
+			}
+
+			if terminal == nil || terminal.FinishReason != "end_turn" {
+				t.Fatalf("Expected a terminal chunk with usage, got %+v", terminal)
+			}
+			want := core.TokenUsage{PromptTokens: tt.wantInput, CompletionTokens: tt.wantOutput, TotalTokens: tt.wantInput + tt.wantOutput}
+			if *terminal.Usage != want {
+				t.Errorf("Terminal chunk usage = %+v, want %+v", *terminal.Usage, want)
+			}
+			if resp.Usage != want {
+				t.Errorf("Response usage = %+v, want %+v", resp.Usage, want)
+			}
+		})
+	}
+}
+
+func TestClient_GenerateResponse_ToolUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req AnthropicRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Tools) != 2 || req.Tools[0].Name != "get_weather" || len(req.Tools[1].InputSchema) == 0 {
+			t.Errorf("Unexpected tools in request: %+v", req.Tools)
+		}
+
+		_, _ = w.Write([]byte(`{"model":"claude-test","stop_reason":"tool_use","content":[{"type":"tool_use","id":"toolu_1","name":"get_weather","input":{"city":"Paris"}}],"usage":{"input_tokens":20,"output_tokens":5}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL, nil)
+	resp, err := client.GenerateResponse(context.Background(), "Weather in Paris?", &core.AIOptions{
+		Model: "claude-test",
+		Tools: []core.ToolDefinition{
+			{Name: "get_weather", Parameters: json.RawMessage(`{"type":"object","properties":{"city":{"type":"string"}}}`)},
+			{Name: "get_time"}, // No parameters still needs an input_schema
+		},
+	})
+	if err != nil {
+		t.Fatalf("GenerateResponse() error = %v", err)
+	}
+
+	if resp.Content != "" || len(resp.ToolCalls) != 1 {
+		t.Fatalf("Expected only a tool call, got content %q and %+v", resp.Content, resp.ToolCalls)
+	}
+	call := resp.ToolCalls[0]
+	if call.ID != "toolu_1" || call.Name != "get_weather" || call.Arguments["city"] != "Paris" {
+		t.Errorf("Unexpected tool call: %+v", call)
+	}
+}
+
+func TestClient_GenerateMessages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			System   string `json:"system"`
+			Messages []struct {
+				Role    string          `json:"role"`
+				Content json.RawMessage `json:"content"`
+			} `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		if req.System != "Be brief.\n\nYou are a travel assistant." {
+			t.Errorf("Expected the system prompts merged, got %q", req.System)
+		}
+		wantRoles := []string{"user", "assistant", "user", "user"}
+		if len(req.Messages) != len(wantRoles) {
+			t.Fatalf("Expected %d messages, got %+v", len(wantRoles), req.Messages)
+		}
+		for i, role := range wantRoles {
+			if req.Messages[i].Role != role {
+				t.Errorf("Message %d role = %s, want %s", i, req.Messages[i].Role, role)
+			}
+		}
+
+		var toolUse []ContentBlock
+		if err := json.Unmarshal(req.Messages[1].Content, &toolUse); err != nil || len(toolUse) != 2 || toolUse[0].Type != "tool_use" || toolUse[1].Input == nil {
+			t.Errorf("Expected tool_use blocks with inputs, got %s", req.Messages[1].Content)
+		}
+		var results []ContentBlock
+		if err := json.Unmarshal(req.Messages[2].Content, &results); err != nil || len(results) != 2 || results[1].ToolUseID != "toolu_2" {
+			t.Errorf("Expected both tool results in one user message, got %s", req.Messages[2].Content)
+		}
+
+		_, _ = w.Write([]byte(`{"model":"claude-test","content":[{"type":"text","text":"Pack an umbrella."}],"usage":{"input_tokens":40,"output_tokens":5}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL, nil)
+	resp, err := client.GenerateMessages(context.Background(), []core.Message{
+		{Role: core.RoleSystem, Content: "You are a travel assistant."},
+		{Role: core.RoleUser, Content: "Weather and time in Paris?"},
+		{Role: core.RoleAssistant, ToolCalls: []core.ToolCall{
+			{ID: "toolu_1", Name: "get_weather", Arguments: map[string]interface{}{"city": "Paris"}},
+			{ID: "toolu_2", Name: "get_time"},
+		}},
+		{Role: core.RoleTool, ToolCallID: "toolu_1", Content: "rain"},
+		{Role: core.RoleTool, ToolCallID: "toolu_2", Content: "14:00"},
+		{Role: core.RoleUser, Content: "What should I pack?"},
+	}, &core.AIOptions{Model: "claude-test", SystemPrompt: "Be brief."})
+	if err != nil {
+		t.Fatalf("GenerateMessages() error = %v", err)
+	}
+	if resp.Content != "Pack an umbrella." {
+		t.Errorf("Content = %q", resp.Content)
+	}
+}
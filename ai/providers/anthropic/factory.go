@@ -73,13 +73,24 @@ func (f *Factory) Create(config *ai.AIConfig) core.AIClient {
 
 	// Apply timeout if specified
 	if config.Timeout > 0 {
-		client.HTTPClient.Timeout = config.Timeout
+		client.RequestTimeout = config.Timeout
+	}
+
+	// Apply streaming timeouts if specified
+	if config.StreamTotalTimeout > 0 {
+		client.StreamTotalTimeout = config.StreamTotalTimeout
+	}
+	if config.StreamIdleTimeout > 0 {
+		client.StreamIdleTimeout = config.StreamIdleTimeout
 	}
 
 	// Apply retry configuration
 	if config.MaxRetries > 0 {
 		client.MaxRetries = config.MaxRetries
 	}
+	if config.RetryPolicy != nil {
+		client.RetryPolicy = config.RetryPolicy
+	}
 
 	// Apply model defaults
 	if config.Model != "" {
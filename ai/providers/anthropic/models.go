@@ -1,6 +1,7 @@
 package anthropic
 
 import (
+	"encoding/json"
 	"os"
 	"strings"
 )
@@ -15,12 +16,32 @@ type AnthropicRequest struct {
 	TopP        float32   `json:"top_p,omitempty"`
 	TopK        int       `json:"top_k,omitempty"`
 	Stream      bool      `json:"stream,omitempty"`
+	Tools       []Tool    `json:"tools,omitempty"`
+}
+
+// Tool represents a tool definition in the Messages API
+type Tool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"input_schema"`
 }
 
 // Message represents a message in the conversation
 type Message struct {
-	Role    string `json:"role"` // "user" or "assistant"
-	Content string `json:"content"`
+	Role    string      `json:"role"`    // "user" or "assistant"
+	Content interface{} `json:"content"` // string, or []ContentBlock for tool use and results
+}
+
+// ContentBlock is a request content block: text, tool_use (replaying an
+// assistant's tool call) or tool_result (answering one)
+type ContentBlock struct {
+	Type      string      `json:"type"`
+	Text      string      `json:"text,omitempty"`
+	ID        string      `json:"id,omitempty"`
+	Name      string      `json:"name,omitempty"`
+	Input     interface{} `json:"input,omitempty"`
+	ToolUseID string      `json:"tool_use_id,omitempty"`
+	Content   string      `json:"content,omitempty"`
 }
 
 // AnthropicResponse represents the response from Anthropic API
@@ -35,10 +56,14 @@ type AnthropicResponse struct {
 	Usage        Usage         `json:"usage"`
 }
 
-// ContentItem represents a content block in the response
+// ContentItem represents a content block in the response. Text blocks set
+// Text; tool_use blocks set ID, Name and Input.
 type ContentItem struct {
-	Type string `json:"type"`
-	Text string `json:"text"`
+	Type  string                 `json:"type"`
+	Text  string                 `json:"text"`
+	ID    string                 `json:"id,omitempty"`
+	Name  string                 `json:"name,omitempty"`
+	Input map[string]interface{} `json:"input,omitempty"`
 }
 
 // Usage represents token usage information
@@ -90,8 +115,10 @@ type StreamDelta struct {
 	StopReason string `json:"stop_reason,omitempty"`
 }
 
-// StreamUsage contains token usage in streaming responses
+// StreamUsage contains cumulative token usage in message_delta events.
+// InputTokens is only reported by newer API versions.
 type StreamUsage struct {
+	InputTokens  int `json:"input_tokens,omitempty"`
 	OutputTokens int `json:"output_tokens"`
 }
 
@@ -3,7 +3,10 @@ package providers
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/itsneelabh/gomind/core"
@@ -21,9 +24,22 @@ type BaseClient struct {
 	// Telemetry for distributed tracing
 	Telemetry core.Telemetry
 
-	// Retry configuration
-	MaxRetries int
-	RetryDelay time.Duration
+	// Retry configuration. RetryPolicy, when set, replaces MaxRetries and
+	// RetryDelay and adds a delay cap, jitter and a retry predicate. A
+	// per-call AIOptions.RetryPolicy replaces both for that call.
+	MaxRetries  int
+	RetryDelay  time.Duration
+	RetryPolicy *RetryConfig
+
+	// RequestTimeout bounds unary calls whose context has no deadline and
+	// whose options set no Timeout. It is applied per call through
+	// RequestContext rather than as HTTPClient.Timeout, so a longer caller
+	// deadline is never cut short.
+	RequestTimeout time.Duration
+
+	// Streaming bounds for streamed responses. See WatchStream.
+	StreamTotalTimeout time.Duration
+	StreamIdleTimeout  time.Duration
 
 	// Default configuration
 	DefaultModel        string
@@ -39,13 +55,14 @@ func NewBaseClient(timeout time.Duration, logger core.Logger) *BaseClient {
 	}
 
 	return &BaseClient{
-		HTTPClient: &http.Client{
-			Timeout: timeout,
-		},
+		HTTPClient:         &http.Client{},
+		RequestTimeout:     timeout,
 		Logger:             logger,
 		Telemetry:          nil, // Set via SetTelemetry or factory
 		MaxRetries:         3,
 		RetryDelay:         time.Second,
+		StreamTotalTimeout: DefaultStreamTotalTimeout,
+		StreamIdleTimeout:  DefaultStreamIdleTimeout,
 		DefaultTemperature: 0.7,
 		DefaultMaxTokens:   1000,
 	}
@@ -81,16 +98,62 @@ func (b *BaseClient) StartSpan(ctx context.Context, name string) (context.Contex
 	return ctx, &core.NoOpSpan{}
 }
 
-// ExecuteWithRetry performs an HTTP request with exponential backoff retry.
+// RequestContext bounds ctx by options.Timeout, if set. context.WithTimeout
+// keeps an earlier parent deadline, so the sooner of the two applies. When
+// neither options nor ctx set a limit, RequestTimeout is applied. It also
+// carries options.RetryPolicy to ExecuteWithRetry.
+func (b *BaseClient) RequestContext(ctx context.Context, options *core.AIOptions) (context.Context, context.CancelFunc) {
+	if options != nil && options.RetryPolicy != nil {
+		ctx = context.WithValue(ctx, retryPolicyKey{}, options.RetryPolicy)
+	}
+	if options != nil && options.Timeout > 0 {
+		return context.WithTimeout(ctx, options.Timeout)
+	}
+	if _, ok := ctx.Deadline(); !ok && b.RequestTimeout > 0 {
+		return context.WithTimeout(ctx, b.RequestTimeout)
+	}
+	return ctx, func() {}
+}
+
+// StreamRequestContext is RequestContext for streaming calls. It applies
+// options.Timeout only; streams are otherwise bounded by WatchStream.
+func (b *BaseClient) StreamRequestContext(ctx context.Context, options *core.AIOptions) (context.Context, context.CancelFunc) {
+	if options == nil || options.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, options.Timeout)
+}
+
+// retryPolicyKey carries a per-call retry policy from RequestContext
+type retryPolicyKey struct{}
+
+// retryPolicy returns the call's retry policy from ctx, or the client's
+func (b *BaseClient) retryPolicy(ctx context.Context) *RetryConfig {
+	if policy, ok := ctx.Value(retryPolicyKey{}).(*RetryConfig); ok {
+		return policy
+	}
+	return b.RetryPolicy
+}
+
+// ExecuteWithRetry performs an HTTP request with exponential backoff retry,
+// using the retry policy RequestContext put in ctx or else the client's.
 // Each retry attempt creates a child span visible in Jaeger for debugging.
 func (b *BaseClient) ExecuteWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
 	var lastErr error
+	policy := b.retryPolicy(ctx)
+	maxRetries := b.MaxRetries
+	if policy != nil {
+		maxRetries = policy.MaxRetries
+	}
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
 
-	for attempt := 0; attempt <= b.MaxRetries; attempt++ {
+	for attempt := 0; attempt <= maxRetries; attempt++ {
 		// Create a span for each attempt (visible in Jaeger as child spans)
 		attemptCtx, attemptSpan := b.StartSpan(ctx, "ai.http_attempt")
 		attemptSpan.SetAttribute("ai.attempt", attempt+1)
-		attemptSpan.SetAttribute("ai.max_retries", b.MaxRetries)
+		attemptSpan.SetAttribute("ai.max_retries", maxRetries)
 		attemptSpan.SetAttribute("ai.is_retry", attempt > 0)
 
 		if attempt > 0 && b.Logger != nil && lastErr != nil {
@@ -104,7 +167,7 @@ func (b *BaseClient) ExecuteWithRetry(ctx context.Context, req *http.Request) (*
 			b.Logger.WarnWithContext(attemptCtx, "AI request retry attempt", map[string]interface{}{
 				"operation":   "ai_request_retry",
 				"attempt":     attempt,
-				"max_retries": b.MaxRetries,
+				"max_retries": maxRetries,
 				"last_error":  lastErr.Error(),
 			})
 		}
@@ -146,7 +209,7 @@ func (b *BaseClient) ExecuteWithRetry(ctx context.Context, req *http.Request) (*
 		}
 
 		// Return non-retryable client errors immediately
-		if err == nil && resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != 429 {
+		if err == nil && !shouldRetry(policy, resp, nil) {
 			attemptSpan.SetAttribute("ai.attempt_status", "client_error")
 			attemptSpan.SetAttribute("http.status_code", resp.StatusCode)
 			attemptSpan.SetAttribute("ai.retryable", false)
@@ -163,7 +226,26 @@ func (b *BaseClient) ExecuteWithRetry(ctx context.Context, req *http.Request) (*
 			return resp, nil
 		}
 
+		// The caller's deadline passed or it cancelled: retrying cannot succeed,
+		// and err already wraps context.DeadlineExceeded or context.Canceled
+		if err != nil && ctx.Err() != nil {
+			attemptSpan.RecordError(err)
+			attemptSpan.SetAttribute("ai.attempt_status", "context_done")
+			attemptSpan.SetAttribute("ai.retryable", false)
+			attemptSpan.End()
+			return nil, err
+		}
+
+		if err != nil && !shouldRetry(policy, nil, err) {
+			attemptSpan.RecordError(err)
+			attemptSpan.SetAttribute("ai.attempt_status", "network_error")
+			attemptSpan.SetAttribute("ai.retryable", false)
+			attemptSpan.End()
+			return nil, err
+		}
+
 		// Save error for potential return
+		var retryAfter time.Duration
 		if err != nil {
 			lastErr = err
 			attemptSpan.RecordError(err)
@@ -173,6 +255,7 @@ func (b *BaseClient) ExecuteWithRetry(ctx context.Context, req *http.Request) (*
 			attemptSpan.RecordError(lastErr)
 			attemptSpan.SetAttribute("ai.attempt_status", "server_error")
 			attemptSpan.SetAttribute("http.status_code", resp.StatusCode)
+			retryAfter = parseRetryAfter(resp)
 			_ = resp.Body.Close() // Error can be safely ignored in error path
 		}
 
@@ -180,22 +263,15 @@ func (b *BaseClient) ExecuteWithRetry(ctx context.Context, req *http.Request) (*
 		attemptSpan.End()
 
 		// Check if we should retry
-		if attempt < b.MaxRetries {
+		if attempt < maxRetries {
 			// Calculate delay with exponential backoff
-			// Ensure safe conversion to uint to prevent overflow
-			var shiftAmount uint
-			if attempt >= 0 && attempt < 32 {
-				shiftAmount = uint(attempt)
-			} else {
-				shiftAmount = 31 // Cap at max reasonable value
-			}
-			delay := b.RetryDelay * time.Duration(1<<shiftAmount)
+			delay := b.retryDelay(policy, attempt, retryAfter)
 
 			if b.Logger != nil {
 				b.Logger.WarnWithContext(ctx, "AI request failed, retrying", map[string]interface{}{
 					"operation":        "ai_request_retry_wait",
 					"attempt":          attempt + 1,
-					"max_retries":      b.MaxRetries,
+					"max_retries":      maxRetries,
 					"retry_delay_ms":   delay.Milliseconds(),
 					"error":            lastErr.Error(),
 					"error_type":       fmt.Sprintf("%T", lastErr),
@@ -229,13 +305,13 @@ func (b *BaseClient) ExecuteWithRetry(ctx context.Context, req *http.Request) (*
 	if b.Logger != nil {
 		b.Logger.ErrorWithContext(ctx, "AI request failed after all retries", map[string]interface{}{
 			"operation":      "ai_request_final_failure",
-			"total_attempts": b.MaxRetries + 1,
+			"total_attempts": maxRetries + 1,
 			"final_error":    lastErr.Error(),
 			"error_type":     fmt.Sprintf("%T", lastErr),
 		})
 	}
 
-	return nil, fmt.Errorf("request failed after %d retries: %w", b.MaxRetries, lastErr)
+	return nil, fmt.Errorf("request failed after %d retries: %w", maxRetries, lastErr)
 }
 
 // LogError logs an error with provider context
@@ -288,6 +364,16 @@ func (b *BaseClient) HandleError(statusCode int, body []byte, provider string) e
 	}
 }
 
+// ConversationText joins the content of messages, for request logs and
+// span attributes of GenerateMessages calls
+func ConversationText(messages []core.Message) string {
+	contents := make([]string, 0, len(messages))
+	for _, message := range messages {
+		contents = append(contents, message.Content)
+	}
+	return strings.Join(contents, "\n\n")
+}
+
 // LogRequest logs outgoing API requests
 func (b *BaseClient) LogRequest(provider, model, prompt string) {
 	b.Logger.Info("AI request initiated", map[string]interface{}{
@@ -346,33 +432,95 @@ func (b *BaseClient) LogResponseContent(provider, model, content string) {
 	})
 }
 
-// RetryConfig holds retry configuration
-type RetryConfig struct {
-	MaxRetries int
-	RetryDelay time.Duration
-	// Optional: custom retry predicate
-	ShouldRetry func(resp *http.Response, err error) bool
-}
+// RetryConfig holds retry configuration. Set it as BaseClient.RetryPolicy
+// (or with ai.WithRetryPolicy) to control how transient provider failures are
+// retried, or as AIOptions.RetryPolicy for a single call.
+type RetryConfig = core.AIRetryPolicy
 
 // DefaultRetryConfig returns sensible retry defaults
 func DefaultRetryConfig() RetryConfig {
 	return RetryConfig{
-		MaxRetries: 3,
-		RetryDelay: time.Second,
-		ShouldRetry: func(resp *http.Response, err error) bool {
-			// Retry on network errors
-			if err != nil {
-				return true
-			}
-			// Retry on 5xx errors
-			if resp != nil && resp.StatusCode >= 500 {
-				return true
-			}
-			// Retry on rate limit (with backoff)
-			if resp != nil && resp.StatusCode == 429 {
-				return true
-			}
-			return false
-		},
+		MaxRetries:  3,
+		RetryDelay:  time.Second,
+		ShouldRetry: defaultShouldRetry,
+	}
+}
+
+// defaultShouldRetry retries network errors, rate limits and server errors
+func defaultShouldRetry(resp *http.Response, err error) bool {
+	// Retry on network errors
+	if err != nil {
+		return true
+	}
+	// Retry on 5xx errors
+	if resp != nil && resp.StatusCode >= 500 {
+		return true
+	}
+	// Retry on rate limit (with backoff)
+	if resp != nil && resp.StatusCode == 429 {
+		return true
+	}
+	return false
+}
+
+// shouldRetry applies the retry policy's predicate, if any
+func shouldRetry(policy *RetryConfig, resp *http.Response, err error) bool {
+	if policy != nil && policy.ShouldRetry != nil {
+		return policy.ShouldRetry(resp, err)
+	}
+	return defaultShouldRetry(resp, err)
+}
+
+// retryDelay returns the wait before retry number attempt+1. A Retry-After
+// value from the provider wins over the computed backoff, and the policy's
+// MaxDelay caps both.
+func (b *BaseClient) retryDelay(policy *RetryConfig, attempt int, retryAfter time.Duration) time.Duration {
+	base := b.RetryDelay
+	jitter := 0.0
+	var maxDelay time.Duration
+	if policy != nil {
+		base = policy.RetryDelay
+		jitter = policy.Jitter
+		maxDelay = policy.MaxDelay
+	}
+
+	delay := retryAfter
+	if delay <= 0 {
+		// Ensure safe conversion to uint to prevent overflow
+		var shiftAmount uint
+		if attempt >= 0 && attempt < 32 {
+			shiftAmount = uint(attempt)
+		} else {
+			shiftAmount = 31 // Cap at max reasonable value
+		}
+		delay = base * time.Duration(1<<shiftAmount)
+
+		if jitter > 0 {
+			delay = time.Duration(float64(delay) * (1 + jitter*(2*rand.Float64()-1)))
+		}
+	}
+	if maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// parseRetryAfter parses the Retry-After header (seconds or HTTP date)
+func parseRetryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
 	}
+	return 0
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -73,8 +74,11 @@ func TestNewBaseClient(t *testing.T) {
 				t.Fatal("expected non-nil client")
 			}
 
-			if client.HTTPClient.Timeout != tt.timeout {
-				t.Errorf("expected timeout %v, got %v", tt.timeout, client.HTTPClient.Timeout)
+			if client.RequestTimeout != tt.timeout {
+				t.Errorf("expected request timeout %v, got %v", tt.timeout, client.RequestTimeout)
+			}
+			if client.HTTPClient.Timeout != 0 {
+				t.Errorf("expected no HTTP client timeout, got %v", client.HTTPClient.Timeout)
 			}
 
 			if tt.logger == nil {
@@ -413,6 +417,105 @@ func TestBaseClient_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestBaseClient_RequestContext(t *testing.T) {
+	client := NewBaseClient(0, nil)
+
+	tests := []struct {
+		name        string
+		parent      time.Duration // 0 means no parent deadline
+		timeout     time.Duration
+		wantWithin  time.Duration
+		wantNoLimit bool
+	}{
+		{"no timeout keeps ctx", 0, 0, 0, true},
+		{"timeout only", 0, 50 * time.Millisecond, 50 * time.Millisecond, false},
+		{"earlier ctx deadline wins", 20 * time.Millisecond, time.Hour, 20 * time.Millisecond, false},
+		{"earlier timeout wins", time.Hour, 30 * time.Millisecond, 30 * time.Millisecond, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := context.Background()
+			if tt.parent > 0 {
+				var cancel context.CancelFunc
+				parent, cancel = context.WithTimeout(parent, tt.parent)
+				defer cancel()
+			}
+
+			ctx, cancel := client.RequestContext(parent, &core.AIOptions{Timeout: tt.timeout})
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			if tt.wantNoLimit {
+				if ok {
+					t.Errorf("Expected no deadline, got %v", deadline)
+				}
+				return
+			}
+			if !ok || time.Until(deadline) > tt.wantWithin {
+				t.Errorf("Expected a deadline within %v, got %v", tt.wantWithin, time.Until(deadline))
+			}
+		})
+	}
+}
+
+func TestBaseClient_RequestContextDefaultTimeout(t *testing.T) {
+	client := NewBaseClient(100*time.Millisecond, nil)
+
+	ctx, cancel := client.RequestContext(context.Background(), nil)
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > 100*time.Millisecond {
+		t.Errorf("Expected the default timeout without a ctx deadline, got %v (set=%v)", time.Until(deadline), ok)
+	}
+
+	// A longer caller deadline is kept, not cut to the default
+	parent, cancelParent := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelParent()
+	ctx, cancel = client.RequestContext(parent, nil)
+	defer cancel()
+	if deadline, _ := ctx.Deadline(); time.Until(deadline) < time.Minute {
+		t.Errorf("Expected the caller's hour-long deadline, got %v", time.Until(deadline))
+	}
+
+	ctx, cancel = client.StreamRequestContext(context.Background(), nil)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("Expected streams to be left to the stream timeouts")
+	}
+}
+
+func TestBaseClient_ExecuteWithRetry_DeadlineStopsRetries(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	client := NewBaseClient(180*time.Second, nil)
+	client.RetryDelay = time.Millisecond
+
+	ctx, cancel := client.RequestContext(context.Background(), &core.AIOptions{Timeout: 50 * time.Millisecond})
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+
+	start := time.Now()
+	_, err := client.ExecuteWithRetry(ctx, req)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected an error wrapping context.DeadlineExceeded, got %v", err)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("Expected no retries after the deadline, got %d attempts", n)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the call to end at the deadline, took %v", elapsed)
+	}
+}
+
 // mockTelemetry tracks telemetry calls for testing
 type mockTelemetry struct {
 	spanStarted bool
@@ -654,3 +757,162 @@ func TestHandleError_DefaultCase(t *testing.T) {
 		t.Errorf("expected error %q, got %q", expected, err.Error())
 	}
 }
+
+func TestBaseClient_ExecuteWithRetry_RetryPolicy(t *testing.T) {
+	t.Run("honors Retry-After", func(t *testing.T) {
+		var calls []time.Time
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, time.Now())
+			if len(calls) == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := NewBaseClient(time.Minute, nil)
+		client.RetryPolicy = &RetryConfig{MaxRetries: 2, RetryDelay: time.Millisecond}
+
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		resp, err := client.ExecuteWithRetry(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+
+		if len(calls) != 2 {
+			t.Fatalf("expected 2 calls, got %d", len(calls))
+		}
+		if wait := calls[1].Sub(calls[0]); wait < 900*time.Millisecond {
+			t.Errorf("expected the retry to wait for Retry-After, waited %v", wait)
+		}
+	})
+
+	t.Run("custom predicate", func(t *testing.T) {
+		callCount := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			callCount++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := NewBaseClient(time.Minute, nil)
+		client.RetryPolicy = &RetryConfig{
+			MaxRetries: 3,
+			RetryDelay: time.Millisecond,
+			ShouldRetry: func(resp *http.Response, err error) bool {
+				return resp != nil && resp.StatusCode == http.StatusTooManyRequests
+			},
+		}
+
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		resp, err := client.ExecuteWithRetry(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if callCount != 1 || resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("expected one call returning 503, got %d calls, status %d", callCount, resp.StatusCode)
+		}
+	})
+
+	t.Run("context cancels the wait", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		client := NewBaseClient(time.Minute, nil)
+		client.RetryPolicy = &RetryConfig{MaxRetries: 3, RetryDelay: time.Millisecond}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+
+		start := time.Now()
+		_, err := client.ExecuteWithRetry(ctx, req)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Error("expected cancellation to interrupt the Retry-After wait")
+		}
+	})
+}
+
+func TestBaseClient_RetryDelay(t *testing.T) {
+	client := NewBaseClient(time.Minute, nil)
+	client.RetryDelay = 100 * time.Millisecond
+
+	// Default: plain exponential backoff, but Retry-After still wins
+	if got := client.retryDelay(nil, 2, 0); got != 400*time.Millisecond {
+		t.Errorf("retryDelay(2) = %v, want 400ms", got)
+	}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+	if got := parseRetryAfter(resp); got != 5*time.Second {
+		t.Errorf("parseRetryAfter() = %v, want 5s", got)
+	}
+	if got := client.retryDelay(nil, 0, 5*time.Second); got != 5*time.Second {
+		t.Errorf("expected Retry-After to set the delay without a policy, got %v", got)
+	}
+
+	policy := &RetryConfig{RetryDelay: 100 * time.Millisecond, Jitter: 0.5}
+	for i := 0; i < 50; i++ {
+		if got := client.retryDelay(policy, 1, 0); got < 100*time.Millisecond || got > 300*time.Millisecond {
+			t.Fatalf("retryDelay(1) with 50%% jitter = %v, want within 100ms-300ms", got)
+		}
+	}
+	if got := client.retryDelay(policy, 0, 5*time.Second); got != 5*time.Second {
+		t.Errorf("expected Retry-After to set the delay, got %v", got)
+	}
+
+	// MaxDelay caps both the backoff and Retry-After
+	policy.MaxDelay = 2 * time.Second
+	if got := client.retryDelay(policy, 0, time.Hour); got != 2*time.Second {
+		t.Errorf("expected Retry-After to be capped at MaxDelay, got %v", got)
+	}
+	policy.RetryDelay, policy.Jitter = time.Second, 0
+	if got := client.retryDelay(policy, 5, 0); got != 2*time.Second {
+		t.Errorf("expected backoff to be capped at MaxDelay, got %v", got)
+	}
+}
+
+func TestBaseClient_ExecuteWithRetry_PerCallPolicy(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewBaseClient(time.Minute, nil)
+	client.RetryPolicy = &RetryConfig{MaxRetries: 3, RetryDelay: time.Millisecond}
+
+	// The call's policy replaces the client's
+	ctx, cancel := client.RequestContext(context.Background(), &core.AIOptions{
+		RetryPolicy: &core.AIRetryPolicy{MaxRetries: 1, RetryDelay: time.Millisecond},
+	})
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+	resp, err := client.ExecuteWithRetry(ctx, req)
+	if err == nil {
+		resp.Body.Close()
+	}
+	if callCount != 2 {
+		t.Errorf("expected the per-call policy's 2 attempts, got %d", callCount)
+	}
+
+	// Without one, the client's policy applies
+	callCount = 0
+	req, _ = http.NewRequest("GET", server.URL, nil)
+	resp, err = client.ExecuteWithRetry(context.Background(), req)
+	if err == nil {
+		resp.Body.Close()
+	}
+	if callCount != 4 {
+		t.Errorf("expected the client policy's 4 attempts, got %d", callCount)
+	}
+}
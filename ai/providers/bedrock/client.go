@@ -43,17 +43,30 @@ func NewClient(cfg aws.Config, region string, logger core.Logger) *Client {
 
 // GenerateResponse generates a response using AWS Bedrock's Converse API
 func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+	return c.GenerateMessages(ctx, []core.Message{{Role: core.RoleUser, Content: prompt}}, options)
+}
+
+// GenerateMessages generates a response to a conversation using AWS
+// Bedrock's Converse API. System messages are sent as system content.
+func (c *Client) GenerateMessages(ctx context.Context, conversation []core.Message, options *core.AIOptions) (*core.AIResponse, error) {
 	// Start distributed tracing span
 	ctx, span := c.StartSpan(ctx, "ai.generate_response")
 	defer span.End()
 
+	prompt := providers.ConversationText(conversation)
+
 	// Set initial span attributes
 	span.SetAttribute("ai.provider", "bedrock")
 	span.SetAttribute("ai.prompt_length", len(prompt))
+	span.SetAttribute("ai.message_count", len(conversation))
 
 	// Apply defaults
 	options = c.ApplyDefaults(options)
 
+	// Bound the call by options.Timeout; an earlier ctx deadline still wins
+	ctx, cancel := c.RequestContext(ctx, options)
+	defer cancel()
+
 	// Add model to span attributes after defaults are applied
 	span.SetAttribute("ai.model", options.Model)
 	span.SetAttribute("ai.region", c.region)
@@ -63,15 +76,11 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 	startTime := time.Now()
 
 	// Build messages for Converse API
-	messages := []types.Message{
-		{
-			Role: types.ConversationRoleUser,
-			Content: []types.ContentBlock{
-				&types.ContentBlockMemberText{
-					Value: prompt,
-				},
-			},
-		},
+	system, turns := core.SplitSystemMessages(conversation, options.SystemPrompt)
+	messages, err := buildMessages(turns)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
 	}
 
 	// Build the Converse input
@@ -81,10 +90,10 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 	}
 
 	// Add system prompt if provided
-	if options.SystemPrompt != "" {
+	if system != "" {
 		input.System = []types.SystemContentBlock{
 			&types.SystemContentBlockMemberText{
-				Value: options.SystemPrompt,
+				Value: system,
 			},
 		}
 	}
@@ -176,7 +185,7 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 
 	// Build the response
 	result := &core.AIResponse{
-		Content:  content,
+		Content:  providers.FormatContent(content, options),
 		Model:    options.Model,
 		Provider: "bedrock",
 	}
@@ -222,6 +231,10 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 	// Apply defaults
 	options = c.ApplyDefaults(options)
 
+	// Bound the call by options.Timeout; an earlier ctx deadline still wins
+	ctx, cancel := c.StreamRequestContext(ctx, options)
+	defer cancel()
+
 	// Add model to span attributes after defaults are applied
 	span.SetAttribute("ai.model", options.Model)
 	span.SetAttribute("ai.region", c.region)
@@ -274,9 +287,12 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 	}
 	input.InferenceConfig = inferenceConfig
 
-	// Start the stream
-	output, err := c.bedrockClient.ConverseStream(ctx, input)
+	// Start the stream, bounded by the stream timeouts rather than the request timeout
+	streamCtx, touch, stopWatch := c.WatchStream(ctx)
+	defer stopWatch()
+	output, err := c.bedrockClient.ConverseStream(streamCtx, input)
 	if err != nil {
+		err = providers.StreamError(streamCtx, err)
 		if c.Logger != nil {
 			c.Logger.ErrorWithContext(ctx, "Bedrock stream request failed - stream error", map[string]interface{}{
 				"operation": "ai_stream_error",
@@ -303,6 +319,7 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 		if !ok {
 			break
 		}
+		touch()
 
 		switch v := event.(type) {
 		case *types.ConverseStreamOutputMemberContentBlockDelta:
@@ -375,7 +392,7 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 	}
 
 	// Check for stream errors
-	if err := eventStream.Err(); err != nil {
+	if err := providers.StreamError(streamCtx, eventStream.Err()); err != nil {
 		if c.Logger != nil {
 			c.Logger.ErrorWithContext(ctx, "Bedrock stream error during processing", map[string]interface{}{
 				"operation": "ai_stream_error",
@@ -557,3 +574,38 @@ func CreateAWSConfig(ctx context.Context, region string, credentials ...aws.Cred
 
 	return cfg, nil
 }
+
+// buildMessages converts a conversation without system messages to Converse
+// messages. Tool results, which this client does not send as tool result
+// blocks, are sent as user text. Consecutive messages of the same role are
+// merged into one message, as the Converse API requires user and assistant
+// turns to alternate.
+func buildMessages(conversation []core.Message) ([]types.Message, error) {
+	if len(conversation) == 0 {
+		return nil, core.ErrNoMessages
+	}
+
+	messages := make([]types.Message, 0, len(conversation))
+	for _, message := range conversation {
+		var role types.ConversationRole
+		text := message.Content
+		switch message.Role {
+		case core.RoleUser:
+			role = types.ConversationRoleUser
+		case core.RoleAssistant:
+			role = types.ConversationRoleAssistant
+		case core.RoleTool:
+			role, text = types.ConversationRoleUser, "Tool result: "+message.Content
+		default:
+			return nil, fmt.Errorf("unsupported message role %q", message.Role)
+		}
+		block := &types.ContentBlockMemberText{Value: text}
+		if len(messages) > 0 && messages[len(messages)-1].Role == role {
+			last := &messages[len(messages)-1]
+			last.Content = append(last.Content, block)
+			continue
+		}
+		messages = append(messages, types.Message{Role: role, Content: []types.ContentBlock{block}})
+	}
+	return messages, nil
+}
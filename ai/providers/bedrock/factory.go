@@ -104,7 +104,15 @@ func (f *Factory) Create(config *ai.AIConfig) core.AIClient {
 
 	// Apply timeout if specified
 	if config.Timeout > 0 {
-		client.BaseClient.HTTPClient.Timeout = config.Timeout
+		client.BaseClient.RequestTimeout = config.Timeout
+	}
+
+	// Apply streaming timeouts if specified
+	if config.StreamTotalTimeout > 0 {
+		client.BaseClient.StreamTotalTimeout = config.StreamTotalTimeout
+	}
+	if config.StreamIdleTimeout > 0 {
+		client.BaseClient.StreamIdleTimeout = config.StreamIdleTimeout
 	}
 
 	// Apply retry configuration
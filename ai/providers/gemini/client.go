@@ -46,13 +46,23 @@ func NewClient(apiKey, baseURL string, logger core.Logger) *Client {
 
 // GenerateResponse generates a response using Gemini's native GenerateContent API
 func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+	return c.GenerateMessages(ctx, []core.Message{{Role: core.RoleUser, Content: prompt}}, options)
+}
+
+// GenerateMessages generates a response to a conversation using Gemini's
+// native GenerateContent API. System messages are sent as the system
+// instruction.
+func (c *Client) GenerateMessages(ctx context.Context, conversation []core.Message, options *core.AIOptions) (*core.AIResponse, error) {
 	// Start distributed tracing span
 	ctx, span := c.StartSpan(ctx, "ai.generate_response")
 	defer span.End()
 
+	prompt := providers.ConversationText(conversation)
+
 	// Set initial span attributes
 	span.SetAttribute("ai.provider", "gemini")
 	span.SetAttribute("ai.prompt_length", len(prompt))
+	span.SetAttribute("ai.message_count", len(conversation))
 
 	if c.apiKey == "" {
 		if c.Logger != nil {
@@ -69,6 +79,10 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 	// Apply defaults
 	options = c.ApplyDefaults(options)
 
+	// Bound the call by options.Timeout; an earlier ctx deadline still wins
+	ctx, cancel := c.RequestContext(ctx, options)
+	defer cancel()
+
 	// Resolve model alias (e.g., "smart" -> "gemini-1.5-pro")
 	options.Model = resolveModel(options.Model)
 
@@ -80,29 +94,28 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 	startTime := time.Now()
 
 	// Build contents in Gemini format
-	contents := []Content{
-		{
-			Role: "user",
-			Parts: []Part{
-				{Text: prompt},
-			},
-		},
+	system, turns := core.SplitSystemMessages(conversation, options.SystemPrompt)
+	contents, err := buildContents(turns)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
 	}
 
 	// Build request body using native Gemini format
 	reqBody := GeminiRequest{
 		Contents: contents,
 		GenerationConfig: &GenerationConfig{
-			Temperature:     options.Temperature,
-			MaxOutputTokens: options.MaxTokens,
+			Temperature:      options.Temperature,
+			MaxOutputTokens:  options.MaxTokens,
+			ResponseMimeType: responseMimeType(options),
 		},
 	}
 
 	// Add system instruction if provided
-	if options.SystemPrompt != "" {
+	if system != "" {
 		reqBody.SystemInstruction = &SystemInstruction{
 			Parts: []Part{
-				{Text: options.SystemPrompt},
+				{Text: system},
 			},
 		}
 	}
@@ -241,7 +254,7 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 	}
 
 	result := &core.AIResponse{
-		Content:  content,
+		Content:  providers.FormatContent(content, options),
 		Model:    options.Model,
 		Provider: "gemini",
 		Usage: core.TokenUsage{
@@ -290,6 +303,10 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 	// Apply defaults
 	options = c.ApplyDefaults(options)
 
+	// Bound the call by options.Timeout; an earlier ctx deadline still wins
+	ctx, cancel := c.StreamRequestContext(ctx, options)
+	defer cancel()
+
 	// Resolve model alias
 	options.Model = resolveModel(options.Model)
 
@@ -309,8 +326,9 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 			},
 		},
 		GenerationConfig: &GenerationConfig{
-			Temperature:     options.Temperature,
-			MaxOutputTokens: options.MaxTokens,
+			Temperature:      options.Temperature,
+			MaxOutputTokens:  options.MaxTokens,
+			ResponseMimeType: responseMimeType(options),
 		},
 	}
 
@@ -356,8 +374,8 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "text/event-stream")
 
-	// Execute request
-	resp, err := c.HTTPClient.Do(req)
+	// Execute request (no retry for streaming; bounded by the stream timeouts)
+	resp, err := c.ExecuteStream(req)
 	if err != nil {
 		if c.Logger != nil {
 			c.Logger.ErrorWithContext(ctx, "Gemini streaming request failed - send error", map[string]interface{}{
@@ -538,3 +556,44 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 func (c *Client) SupportsStreaming() bool {
 	return true
 }
+
+// responseMimeType maps AIOptions.ResponseFormat to Gemini's responseMimeType
+func responseMimeType(options *core.AIOptions) string {
+	if options.ResponseFormat == core.ResponseFormatJSON {
+		return "application/json"
+	}
+	return ""
+}
+
+// buildContents converts a conversation without system messages to Gemini
+// contents. Assistant messages use the "model" role; tool results, which
+// this client does not send as function responses, are sent as user text.
+// Consecutive messages of the same role are merged into one content, as
+// Gemini expects user and model turns to alternate.
+func buildContents(conversation []core.Message) ([]Content, error) {
+	if len(conversation) == 0 {
+		return nil, core.ErrNoMessages
+	}
+
+	contents := make([]Content, 0, len(conversation))
+	for _, message := range conversation {
+		var role, text string
+		switch message.Role {
+		case core.RoleUser:
+			role, text = "user", message.Content
+		case core.RoleAssistant:
+			role, text = "model", message.Content
+		case core.RoleTool:
+			role, text = "user", "Tool result: "+message.Content
+		default:
+			return nil, fmt.Errorf("unsupported message role %q", message.Role)
+		}
+		if len(contents) > 0 && contents[len(contents)-1].Role == role {
+			last := &contents[len(contents)-1]
+			last.Parts = append(last.Parts, Part{Text: text})
+			continue
+		}
+		contents = append(contents, Content{Role: role, Parts: []Part{{Text: text}}})
+	}
+	return contents, nil
+}
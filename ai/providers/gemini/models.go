@@ -36,6 +36,9 @@ type GenerationConfig struct {
 	TopK            int      `json:"topK,omitempty"`
 	MaxOutputTokens int      `json:"maxOutputTokens,omitempty"`
 	StopSequences   []string `json:"stopSequences,omitempty"`
+
+	// ResponseMimeType "application/json" constrains output to JSON
+	ResponseMimeType string `json:"responseMimeType,omitempty"`
 }
 
 // SafetySetting represents safety configuration
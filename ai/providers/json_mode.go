@@ -0,0 +1,93 @@
+package providers
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// ExtractJSON returns the JSON object in a model response that was asked for
+// JSON but wrapped it in markdown fences or prose. Valid JSON is returned
+// as is; otherwise the body of the first code fence, or else the first
+// balanced {...} object, is returned if it is valid JSON. Text without a
+// usable object is returned unchanged so the caller's decoder reports it.
+func ExtractJSON(text string) string {
+	trimmed := strings.TrimSpace(text)
+	if json.Valid([]byte(trimmed)) {
+		return trimmed
+	}
+
+	if fenced, ok := fencedBlock(trimmed); ok && json.Valid([]byte(fenced)) {
+		return fenced
+	}
+
+	if start := strings.Index(trimmed, "{"); start != -1 {
+		if end := balancedObjectEnd(trimmed, start); end != -1 {
+			if object := trimmed[start:end]; json.Valid([]byte(object)) {
+				return object
+			}
+		}
+	}
+
+	return text
+}
+
+// fencedBlock returns the contents of the first ``` fence, without its
+// language tag
+func fencedBlock(text string) (string, bool) {
+	start := strings.Index(text, "```")
+	if start == -1 {
+		return "", false
+	}
+	body := text[start+3:]
+	end := strings.Index(body, "```")
+	if end == -1 {
+		return "", false
+	}
+	body = body[:end]
+
+	// Drop a language tag such as "json" on the opening line
+	if newline := strings.IndexByte(body, '\n'); newline != -1 && !strings.ContainsAny(body[:newline], "{[") {
+		body = body[newline+1:]
+	}
+	return strings.TrimSpace(body), true
+}
+
+// balancedObjectEnd returns the index just past the brace that closes the
+// object opened at start, ignoring braces inside strings, or -1
+func balancedObjectEnd(text string, start int) int {
+	depth := 0
+	inString := false
+	escaped := false
+
+	for i := start; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return -1
+}
+
+// FormatContent applies options.ResponseFormat to the content of a
+// non-streaming response
+func FormatContent(content string, options *core.AIOptions) string {
+	if options != nil && options.ResponseFormat == core.ResponseFormatJSON {
+		return ExtractJSON(content)
+	}
+	return content
+}
@@ -0,0 +1,46 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+func TestExtractJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"clean object", ` {"a":1} `, `{"a":1}`},
+		{"json fence", "```json\n{\"a\":1}\n```", `{"a":1}`},
+		{"bare fence", "```\n{\"a\":1}\n```", `{"a":1}`},
+		{"fence after prose", "Here you go:\n```json\n{\"a\":[1,2]}\n```\nAnything else?", `{"a":[1,2]}`},
+		{"prose around object", `Sure! {"a":"}{","b":{"c":"\"x\""}} Hope that helps.`, `{"a":"}{","b":{"c":"\"x\""}}`},
+		{"clean array", `[1,2]`, `[1,2]`},
+		{"no json", "I cannot help with that.", "I cannot help with that."},
+		{"unbalanced", `{"a":1`, `{"a":1`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractJSON(tt.in); got != tt.want {
+				t.Errorf("ExtractJSON(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatContent(t *testing.T) {
+	fenced := "```json\n{\"a\":1}\n```"
+
+	if got := FormatContent(fenced, &core.AIOptions{ResponseFormat: core.ResponseFormatJSON}); got != `{"a":1}` {
+		t.Errorf("JSON mode: got %q", got)
+	}
+	if got := FormatContent(fenced, &core.AIOptions{ResponseFormat: core.ResponseFormatText}); got != fenced {
+		t.Errorf("text mode should not change content, got %q", got)
+	}
+	if got := FormatContent(fenced, nil); got != fenced {
+		t.Errorf("nil options should not change content, got %q", got)
+	}
+}
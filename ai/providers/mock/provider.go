@@ -58,6 +58,7 @@ type Client struct {
 	CallCount     int
 	LastPrompt    string
 	LastOptions   *core.AIOptions
+	LastMessages  []core.Message // Set by GenerateMessages only
 
 	// Streaming configuration
 	ChunkSize   int           // Size of each chunk when streaming (default: 10)
@@ -117,6 +118,19 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 	}, nil
 }
 
+// GenerateMessages records the conversation and returns a mock response.
+// LastPrompt and LastOptions hold the conversation as flattened by
+// core.FlattenMessages.
+func (c *Client) GenerateMessages(ctx context.Context, messages []core.Message, options *core.AIOptions) (*core.AIResponse, error) {
+	c.LastMessages = messages
+	prompt, flattened, err := core.FlattenMessages(messages, options)
+	if err != nil {
+		c.CallCount++
+		return nil, err
+	}
+	return c.GenerateResponse(ctx, prompt, flattened)
+}
+
 // StreamResponse returns a mock streaming response for testing
 func (c *Client) StreamResponse(ctx context.Context, prompt string, options *core.AIOptions, callback core.StreamCallback) (*core.AIResponse, error) {
 	c.CallCount++
@@ -0,0 +1,471 @@
+package ollama
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/itsneelabh/gomind/ai/providers"
+	"github.com/itsneelabh/gomind/core"
+)
+
+const (
+	// DefaultBaseURL is the default local Ollama endpoint
+	DefaultBaseURL = "http://localhost:11434"
+)
+
+// Client implements core.AIClient for Ollama's native API
+type Client struct {
+	*providers.BaseClient
+	baseURL string
+}
+
+// NewClient creates a new Ollama client. No API key is needed; baseURL is
+// the server root (e.g. "http://localhost:11434"), not the /v1 path used by
+// Ollama's OpenAI-compatible API.
+func NewClient(baseURL string, logger core.Logger) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	base := providers.NewBaseClient(180*time.Second, logger) // Local models can be slow to load
+	base.DefaultModel = "default"
+	base.DefaultMaxTokens = 1000
+
+	return &Client{
+		BaseClient: base,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// buildRequest builds a /api/chat request, mapping AIOptions onto Ollama's
+// options object. Ollama accepts every core message role as is.
+func (c *Client) buildRequest(conversation []core.Message, options *core.AIOptions, stream bool) (ChatRequest, error) {
+	var messages []ChatMessage
+	if options.SystemPrompt != "" {
+		messages = append(messages, ChatMessage{Role: core.RoleSystem, Content: options.SystemPrompt})
+	}
+	turns := 0
+	for _, message := range conversation {
+		switch message.Role {
+		case core.RoleSystem:
+		case core.RoleUser, core.RoleAssistant, core.RoleTool:
+			turns++
+		default:
+			return ChatRequest{}, fmt.Errorf("unsupported message role %q", message.Role)
+		}
+		messages = append(messages, ChatMessage{Role: message.Role, Content: message.Content})
+	}
+	if turns == 0 {
+		return ChatRequest{}, core.ErrNoMessages
+	}
+
+	req := ChatRequest{
+		Model:    options.Model,
+		Messages: messages,
+		Stream:   stream,
+		Options: &Options{
+			Temperature: options.Temperature,
+			NumPredict:  options.MaxTokens,
+		},
+	}
+	if options.ResponseFormat == core.ResponseFormatJSON {
+		req.Format = "json"
+	}
+	return req, nil
+}
+
+// usage converts Ollama's eval counts to core.TokenUsage
+func usage(resp *ChatResponse) core.TokenUsage {
+	return core.TokenUsage{
+		PromptTokens:     resp.PromptEvalCount,
+		CompletionTokens: resp.EvalCount,
+		TotalTokens:      resp.PromptEvalCount + resp.EvalCount,
+	}
+}
+
+// GenerateResponse generates a response using Ollama's /api/chat endpoint
+func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+	return c.GenerateMessages(ctx, []core.Message{{Role: core.RoleUser, Content: prompt}}, options)
+}
+
+// GenerateMessages generates a response to a conversation using Ollama's
+// /api/chat endpoint
+func (c *Client) GenerateMessages(ctx context.Context, conversation []core.Message, options *core.AIOptions) (*core.AIResponse, error) {
+	// Start distributed tracing span
+	ctx, span := c.StartSpan(ctx, "ai.generate_response")
+	defer span.End()
+
+	prompt := providers.ConversationText(conversation)
+
+	// Set initial span attributes
+	span.SetAttribute("ai.provider", "ollama")
+	span.SetAttribute("ai.prompt_length", len(prompt))
+	span.SetAttribute("ai.message_count", len(conversation))
+
+	// Apply defaults and resolve model alias (e.g., "fast" -> "llama3.2:1b")
+	options = c.ApplyDefaults(options)
+	options.Model = resolveModel(options.Model)
+
+	// Bound the call by options.Timeout; an earlier ctx deadline still wins
+	ctx, cancel := c.RequestContext(ctx, options)
+	defer cancel()
+
+	span.SetAttribute("ai.model", options.Model)
+
+	// Log request
+	c.LogRequest("ollama", options.Model, prompt)
+	startTime := time.Now()
+
+	chatRequest, err := c.buildRequest(conversation, options, false)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	jsonData, err := json.Marshal(chatRequest)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/chat", bytes.NewBuffer(jsonData))
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Execute with retry
+	resp, err := c.ExecuteWithRetry(ctx, req)
+	if err != nil {
+		if c.Logger != nil {
+			c.Logger.ErrorWithContext(ctx, "Ollama request failed - send error", map[string]interface{}{
+				"operation": "ai_request_error",
+				"provider":  "ollama",
+				"base_url":  c.baseURL,
+				"error":     err.Error(),
+				"phase":     "request_execution",
+			})
+		}
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Handle errors (e.g. 404 when the model has not been pulled)
+	if resp.StatusCode != http.StatusOK {
+		if c.Logger != nil {
+			c.Logger.ErrorWithContext(ctx, "Ollama request failed - API error", map[string]interface{}{
+				"operation":   "ai_request_error",
+				"provider":    "ollama",
+				"status_code": resp.StatusCode,
+				"phase":       "api_response",
+			})
+		}
+		apiErr := c.HandleError(resp.StatusCode, body, "Ollama")
+		span.RecordError(apiErr)
+		span.SetAttribute("http.status_code", resp.StatusCode)
+		return nil, apiErr
+	}
+
+	var chatResp ChatResponse
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if chatResp.Error != "" {
+		apiErr := fmt.Errorf("Ollama API error: %s", chatResp.Error)
+		span.RecordError(apiErr)
+		return nil, apiErr
+	}
+
+	result := &core.AIResponse{
+		Content:  providers.FormatContent(chatResp.Message.Content, options),
+		Model:    firstNonEmpty(chatResp.Model, options.Model),
+		Provider: "ollama",
+		Usage:    usage(&chatResp),
+	}
+
+	// Add token usage to span for cost tracking and debugging
+	span.SetAttribute("ai.prompt_tokens", result.Usage.PromptTokens)
+	span.SetAttribute("ai.completion_tokens", result.Usage.CompletionTokens)
+	span.SetAttribute("ai.total_tokens", result.Usage.TotalTokens)
+	span.SetAttribute("ai.response_length", len(result.Content))
+
+	// Log response
+	c.LogResponse(ctx, "ollama", result.Model, result.Usage, time.Since(startTime))
+	c.LogResponseContent("ollama", result.Model, result.Content)
+
+	return result, nil
+}
+
+// StreamResponse streams a response from /api/chat. Ollama streams
+// newline-delimited JSON rather than server-sent events; the final object
+// has done=true and carries the token counts.
+func (c *Client) StreamResponse(ctx context.Context, prompt string, options *core.AIOptions, callback core.StreamCallback) (*core.AIResponse, error) {
+	// Start distributed tracing span
+	ctx, span := c.StartSpan(ctx, "ai.stream_response")
+	defer span.End()
+
+	// Set initial span attributes
+	span.SetAttribute("ai.provider", "ollama")
+	span.SetAttribute("ai.streaming", true)
+	span.SetAttribute("ai.prompt_length", len(prompt))
+
+	// Apply defaults and resolve model alias
+	options = c.ApplyDefaults(options)
+	options.Model = resolveModel(options.Model)
+
+	// Bound the call by options.Timeout; an earlier ctx deadline still wins
+	ctx, cancel := c.StreamRequestContext(ctx, options)
+	defer cancel()
+
+	span.SetAttribute("ai.model", options.Model)
+
+	// Log request
+	c.LogRequest("ollama", options.Model, prompt)
+	startTime := time.Now()
+
+	chatRequest, err := c.buildRequest([]core.Message{{Role: core.RoleUser, Content: prompt}}, options, true)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	jsonData, err := json.Marshal(chatRequest)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/chat", bytes.NewBuffer(jsonData))
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/x-ndjson")
+
+	// Execute request (no retry for streaming; bounded by the stream timeouts)
+	resp, err := c.ExecuteStream(req)
+	if err != nil {
+		if c.Logger != nil {
+			c.Logger.ErrorWithContext(ctx, "Ollama streaming request failed - send error", map[string]interface{}{
+				"operation": "ai_stream_error",
+				"provider":  "ollama",
+				"base_url":  c.baseURL,
+				"error":     err.Error(),
+				"phase":     "request_execution",
+			})
+		}
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// Handle error responses
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		if c.Logger != nil {
+			c.Logger.ErrorWithContext(ctx, "Ollama streaming request failed - API error", map[string]interface{}{
+				"operation":   "ai_stream_error",
+				"provider":    "ollama",
+				"status_code": resp.StatusCode,
+				"phase":       "api_response",
+			})
+		}
+		apiErr := c.HandleError(resp.StatusCode, body, "Ollama")
+		span.RecordError(apiErr)
+		span.SetAttribute("http.status_code", resp.StatusCode)
+		return nil, apiErr
+	}
+
+	// Parse NDJSON stream
+	reader := bufio.NewReader(resp.Body)
+	var fullContent strings.Builder
+	var tokenUsage core.TokenUsage
+	model := options.Model
+	chunkIndex := 0
+	var finishReason string
+
+	partial := func() *core.AIResponse {
+		return &core.AIResponse{
+			Content:  fullContent.String(),
+			Model:    model,
+			Provider: "ollama",
+			Usage:    tokenUsage,
+		}
+	}
+
+	for finishReason == "" {
+		// Check context cancellation
+		select {
+		case <-ctx.Done():
+			if fullContent.Len() > 0 {
+				return partial(), core.ErrStreamPartiallyCompleted
+			}
+			return nil, ctx.Err()
+		default:
+		}
+
+		line, err := reader.ReadBytes('\n')
+		if err != nil && (err != io.EOF || len(bytes.TrimSpace(line)) == 0) {
+			if err == io.EOF {
+				break
+			}
+			if fullContent.Len() > 0 {
+				span.SetAttribute("ai.stream_partial", true)
+				return partial(), core.ErrStreamPartiallyCompleted
+			}
+			span.RecordError(err)
+			return nil, fmt.Errorf("error reading stream: %w", err)
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		var chunk ChatResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			if c.Logger != nil {
+				c.Logger.DebugWithContext(ctx, "Ollama stream - failed to parse chunk", map[string]interface{}{
+					"operation": "ai_stream_parse",
+					"provider":  "ollama",
+					"error":     err.Error(),
+				})
+			}
+			continue
+		}
+
+		// Ollama reports mid-stream failures as an error object
+		if chunk.Error != "" {
+			streamErr := fmt.Errorf("Ollama stream error: %s", chunk.Error)
+			span.RecordError(streamErr)
+			if fullContent.Len() > 0 {
+				return partial(), core.ErrStreamPartiallyCompleted
+			}
+			return nil, streamErr
+		}
+
+		if chunk.Model != "" {
+			model = chunk.Model
+		}
+
+		if chunk.Message.Content != "" {
+			fullContent.WriteString(chunk.Message.Content)
+
+			streamChunk := core.StreamChunk{
+				Content: chunk.Message.Content,
+				Delta:   true,
+				Index:   chunkIndex,
+				Model:   model,
+			}
+			chunkIndex++
+
+			if err := callback(streamChunk); err != nil {
+				span.SetAttribute("ai.stream_stopped_by_callback", true)
+				return partial(), nil
+			}
+		}
+
+		if chunk.Done {
+			tokenUsage = usage(&chunk)
+			finishReason = firstNonEmpty(chunk.DoneReason, "stop")
+		}
+	}
+
+	// Send final chunk with finish reason and usage
+	if finishReason != "" {
+		finalChunk := core.StreamChunk{
+			Delta:        false,
+			Index:        chunkIndex,
+			FinishReason: finishReason,
+			Model:        model,
+			Usage:        &tokenUsage,
+		}
+		_ = callback(finalChunk)
+	}
+
+	result := partial()
+
+	// Add token usage to span
+	span.SetAttribute("ai.prompt_tokens", result.Usage.PromptTokens)
+	span.SetAttribute("ai.completion_tokens", result.Usage.CompletionTokens)
+	span.SetAttribute("ai.total_tokens", result.Usage.TotalTokens)
+	span.SetAttribute("ai.response_length", len(result.Content))
+	span.SetAttribute("ai.chunks_sent", chunkIndex)
+
+	// Log response
+	c.LogResponse(ctx, "ollama", result.Model, result.Usage, time.Since(startTime))
+	c.LogResponseContent("ollama", result.Model, result.Content)
+
+	return result, nil
+}
+
+// SupportsStreaming returns true as Ollama supports native streaming
+func (c *Client) SupportsStreaming() bool {
+	return true
+}
+
+// ListModels returns the names of the models pulled on the Ollama server,
+// from its /api/tags endpoint
+func (c *Client) ListModels(ctx context.Context) ([]string, error) {
+	return listModels(ctx, c.HTTPClient, c.baseURL)
+}
+
+// listModels queries /api/tags on the server at baseURL
+func listModels(ctx context.Context, client *http.Client, baseURL string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", strings.TrimSuffix(baseURL, "/")+"/api/tags", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach Ollama at %s: %w", baseURL, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Ollama API error (status %d) listing models", resp.StatusCode)
+	}
+
+	var tags TagsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return nil, fmt.Errorf("failed to parse model list: %w", err)
+	}
+
+	models := make([]string, 0, len(tags.Models))
+	for _, m := range tags.Models {
+		models = append(models, firstNonEmpty(m.Name, m.Model))
+	}
+	return models, nil
+}
+
+// firstNonEmpty returns the first non-empty string from the provided values
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,206 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/itsneelabh/gomind/ai"
+	"github.com/itsneelabh/gomind/core"
+)
+
+func TestHostURL(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"", DefaultBaseURL},
+		{"127.0.0.1", "http://127.0.0.1:11434"},
+		{"gpu-box:8080", "http://gpu-box:8080"},
+		{"https://ollama.internal", "https://ollama.internal:11434"},
+		{"http://ollama.internal:11434/", "http://ollama.internal:11434"},
+		{"ollama.internal/proxy", "http://ollama.internal:11434/proxy"},
+		{"[::1]", "http://[::1]:11434"},
+	}
+
+	for _, tt := range tests {
+		if got := hostURL(tt.host); got != tt.want {
+			t.Errorf("hostURL(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestClient_GenerateResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/chat" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		var req ChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Invalid request body: %v", err)
+		}
+		if req.Stream || req.Model != "mistral" || req.Options == nil || req.Options.NumPredict != 64 || req.Options.Temperature != 0.2 {
+			t.Errorf("Unexpected request: %+v options=%+v", req, req.Options)
+		}
+		if len(req.Messages) != 2 || req.Messages[0].Role != "system" || req.Messages[1].Content != "hi" {
+			t.Errorf("Unexpected messages: %+v", req.Messages)
+		}
+
+		_, _ = w.Write([]byte(`{"model":"mistral","message":{"role":"assistant","content":"Hello!"},"done":true,"done_reason":"stop","prompt_eval_count":9,"eval_count":3}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	resp, err := client.GenerateResponse(context.Background(), "hi", &core.AIOptions{
+		Model:        "mistral",
+		Temperature:  0.2,
+		MaxTokens:    64,
+		SystemPrompt: "Be brief",
+	})
+	if err != nil {
+		t.Fatalf("GenerateResponse() error = %v", err)
+	}
+
+	if resp.Content != "Hello!" || resp.Provider != "ollama" || resp.Model != "mistral" {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+	if resp.Usage != (core.TokenUsage{PromptTokens: 9, CompletionTokens: 3, TotalTokens: 12}) {
+		t.Errorf("Usage = %+v", resp.Usage)
+	}
+}
+
+func TestClient_GenerateResponse_JSONMode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ChatRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Format != "json" {
+			t.Errorf("Format = %q, want json", req.Format)
+		}
+		_, _ = w.Write([]byte(`{"model":"llama3.2","message":{"role":"assistant","content":"Result: {\"ok\":true}"},"done":true}`))
+	}))
+	defer server.Close()
+
+	resp, err := NewClient(server.URL, nil).GenerateResponse(context.Background(), "hi", &core.AIOptions{ResponseFormat: core.ResponseFormatJSON})
+	if err != nil {
+		t.Fatalf("GenerateResponse() error = %v", err)
+	}
+	if resp.Content != `{"ok":true}` {
+		t.Errorf("Content = %q, want the bare JSON object", resp.Content)
+	}
+}
+
+func TestClient_GenerateResponse_ModelNotPulled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"model \"llama3.2\" not found, try pulling it first"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, nil).GenerateResponse(context.Background(), "hi", nil)
+	if err == nil || !strings.Contains(err.Error(), "try pulling it first") {
+		t.Errorf("Expected the Ollama error message, got %v", err)
+	}
+}
+
+func TestClient_StreamResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ChatRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if !req.Stream {
+			t.Error("Expected stream=true")
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		lines := []string{
+			`{"model":"llama3.2","message":{"role":"assistant","content":"Hel"},"done":false}`,
+			`{"model":"llama3.2","message":{"role":"assistant","content":"lo"},"done":false}`,
+			`{"model":"llama3.2","message":{"role":"assistant","content":""},"done":true,"done_reason":"stop","prompt_eval_count":5,"eval_count":2}`,
+		}
+		for _, line := range lines {
+			_, _ = w.Write([]byte(line + "\n"))
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer server.Close()
+
+	var deltas []string
+	var terminal *core.StreamChunk
+	resp, err := NewClient(server.URL, nil).StreamResponse(context.Background(), "hi", nil, func(chunk core.StreamChunk) error {
+		if chunk.Delta {
+			deltas = append(deltas, chunk.Content)
+		} else {
+			terminal = &chunk
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamResponse() error = %v", err)
+	}
+
+	if strings.Join(deltas, "|") != "Hel|lo" || resp.Content != "Hello" {
+		t.Errorf("deltas = %q, content = %q", deltas, resp.Content)
+	}
+	if terminal == nil || terminal.FinishReason != "stop" || terminal.Usage == nil || terminal.Usage.TotalTokens != 7 {
+		t.Errorf("Expected a terminal chunk with usage, got %+v", terminal)
+	}
+}
+
+func TestClient_StreamResponse_ErrorLine(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"error":"model runner has unexpectedly stopped"}` + "\n"))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, nil).StreamResponse(context.Background(), "hi", nil, func(core.StreamChunk) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "unexpectedly stopped") {
+		t.Errorf("Expected the stream error, got %v", err)
+	}
+}
+
+func TestFactory_DetectAndListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/tags" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"models":[{"name":"llama3:latest"},{"name":"mistral:7b"}]}`))
+	}))
+	defer server.Close()
+
+	factory := &Factory{}
+
+	t.Setenv("OLLAMA_HOST", "")
+	if _, available := factory.DetectEnvironment(); available {
+		t.Error("Expected Ollama to be unavailable without OLLAMA_HOST")
+	}
+
+	t.Setenv("OLLAMA_HOST", server.URL)
+	if priority, available := factory.DetectEnvironment(); !available || priority != factory.Priority() {
+		t.Errorf("DetectEnvironment() = %d, %v", priority, available)
+	}
+
+	var info *ai.ProviderInfo
+	for _, p := range ai.GetProviderInfo() {
+		if p.Name == "ollama" {
+			info = &p
+		}
+	}
+	if info == nil || strings.Join(info.Models, ",") != "llama3:latest,mistral:7b" {
+		t.Errorf("Expected discovered models in ProviderInfo, got %+v", info)
+	}
+
+	// With no cloud provider registered, auto-detection selects Ollama
+	detected, err := ai.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	client, ok := detected.(*Client)
+	if !ok {
+		t.Fatalf("Expected the Ollama client, got %T", detected)
+	}
+	if client.baseURL != server.URL {
+		t.Errorf("baseURL = %q, want OLLAMA_HOST %q", client.baseURL, server.URL)
+	}
+}
@@ -0,0 +1,122 @@
+package ollama
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/itsneelabh/gomind/ai"
+	"github.com/itsneelabh/gomind/core"
+)
+
+func init() {
+	ai.MustRegister(&Factory{})
+}
+
+// Factory creates Ollama AI clients
+type Factory struct{}
+
+// Name returns the provider name
+func (f *Factory) Name() string {
+	return "ollama"
+}
+
+// Description returns provider description
+func (f *Factory) Description() string {
+	return "Local Ollama models with native chat API"
+}
+
+// Priority returns provider priority
+func (f *Factory) Priority() int {
+	return 55 // Below every cloud provider, above the openai.ollama localhost probe
+}
+
+// Create creates a new Ollama client
+func (f *Factory) Create(config *ai.AIConfig) core.AIClient {
+	// Use base URL from config or OLLAMA_HOST, with default
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = hostURL(os.Getenv("OLLAMA_HOST"))
+	}
+
+	// Get logger from config with proper component wrapping
+	logger := config.Logger
+	if logger == nil {
+		logger = &core.NoOpLogger{}
+	} else if cal, ok := logger.(core.ComponentAwareLogger); ok {
+		logger = cal.WithComponent("framework/ai")
+	}
+
+	// Log provider initialization
+	logger.Info("Ollama provider initialized", map[string]interface{}{
+		"operation": "ai_provider_init",
+		"provider":  "ollama",
+		"base_url":  baseURL,
+		"model":     config.Model,
+	})
+
+	// Create the client with full configuration
+	client := NewClient(baseURL, logger)
+
+	// Set telemetry for distributed tracing
+	if config.Telemetry != nil {
+		client.SetTelemetry(config.Telemetry)
+	}
+
+	// Apply timeout if specified
+	if config.Timeout > 0 {
+		client.RequestTimeout = config.Timeout
+	}
+
+	// Apply streaming timeouts if specified
+	if config.StreamTotalTimeout > 0 {
+		client.StreamTotalTimeout = config.StreamTotalTimeout
+	}
+	if config.StreamIdleTimeout > 0 {
+		client.StreamIdleTimeout = config.StreamIdleTimeout
+	}
+
+	// Apply retry configuration
+	if config.MaxRetries > 0 {
+		client.MaxRetries = config.MaxRetries
+	}
+	if config.RetryPolicy != nil {
+		client.RetryPolicy = config.RetryPolicy
+	}
+
+	// Apply model defaults
+	if config.Model != "" {
+		client.DefaultModel = config.Model
+	}
+
+	// Apply temperature default
+	if config.Temperature > 0 {
+		client.DefaultTemperature = config.Temperature
+	}
+
+	// Apply max tokens default
+	if config.MaxTokens > 0 {
+		client.DefaultMaxTokens = config.MaxTokens
+	}
+
+	return client
+}
+
+// DetectEnvironment reports Ollama as available when OLLAMA_HOST is set.
+// Its priority is below every cloud provider, so a configured API key still
+// wins auto-detection.
+func (f *Factory) DetectEnvironment() (priority int, available bool) {
+	if os.Getenv("OLLAMA_HOST") != "" {
+		return f.Priority(), true
+	}
+	return 0, false
+}
+
+// ListModels returns the models pulled on the server at OLLAMA_HOST. It is
+// used by ai.GetProviderInfo.
+func (f *Factory) ListModels() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return listModels(ctx, http.DefaultClient, hostURL(os.Getenv("OLLAMA_HOST")))
+}
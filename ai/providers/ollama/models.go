@@ -0,0 +1,106 @@
+package ollama
+
+import (
+	"os"
+	"strings"
+)
+
+// ChatRequest represents a request to Ollama's native /api/chat endpoint
+type ChatRequest struct {
+	Model    string        `json:"model"`
+	Messages []ChatMessage `json:"messages"`
+	Stream   bool          `json:"stream"`
+	Options  *Options      `json:"options,omitempty"`
+	Format   string        `json:"format,omitempty"` // "json" constrains output to JSON
+}
+
+// ChatMessage represents a message in the conversation
+type ChatMessage struct {
+	Role    string `json:"role"` // "system", "user", "assistant" or "tool"
+	Content string `json:"content"`
+}
+
+// Options holds Ollama model parameters. NumPredict is Ollama's name for
+// the maximum number of tokens to generate.
+type Options struct {
+	Temperature float32 `json:"temperature,omitempty"`
+	NumPredict  int     `json:"num_predict,omitempty"`
+}
+
+// ChatResponse is the /api/chat response. When streaming, Ollama sends one
+// ChatResponse per line; the last has Done set and carries the token counts.
+type ChatResponse struct {
+	Model           string      `json:"model"`
+	CreatedAt       string      `json:"created_at"`
+	Message         ChatMessage `json:"message"`
+	Done            bool        `json:"done"`
+	DoneReason      string      `json:"done_reason,omitempty"`
+	PromptEvalCount int         `json:"prompt_eval_count,omitempty"`
+	EvalCount       int         `json:"eval_count,omitempty"`
+	Error           string      `json:"error,omitempty"`
+}
+
+// TagsResponse is the /api/tags response listing locally pulled models
+type TagsResponse struct {
+	Models []ModelInfo `json:"models"`
+}
+
+// ModelInfo describes a locally pulled model
+type ModelInfo struct {
+	Name       string `json:"name"`
+	Model      string `json:"model"`
+	ModifiedAt string `json:"modified_at"`
+	Size       int64  `json:"size"`
+}
+
+// modelAliases maps portable names to commonly pulled Ollama models.
+// Model availability depends on what the user has pulled locally; override
+// with GOMIND_OLLAMA_MODEL_{ALIAS}, as for the openai.ollama alias.
+var modelAliases = map[string]string{
+	"default": "llama3.2",    // Most commonly pulled model
+	"fast":    "llama3.2:1b", // Smallest/fastest variant
+	"smart":   "llama3.1:8b", // Larger general-purpose model
+	"code":    "codellama",   // Code-specialized model
+}
+
+// resolveModel returns the actual model name for an alias.
+// Priority: 1) Env var override, 2) Hardcoded alias, 3) Pass-through
+func resolveModel(model string) string {
+	// Check for environment variable override: GOMIND_OLLAMA_MODEL_{ALIAS}
+	envKey := "GOMIND_OLLAMA_MODEL_" + strings.ToUpper(model)
+	if override := os.Getenv(envKey); override != "" {
+		return override
+	}
+
+	// Check hardcoded aliases
+	if actual, exists := modelAliases[model]; exists {
+		return actual
+	}
+
+	return model
+}
+
+// hostURL turns an OLLAMA_HOST value into a base URL. Like the ollama CLI,
+// it accepts "host", "host:port" or a full URL and defaults to http and
+// port 11434.
+func hostURL(host string) string {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return DefaultBaseURL
+	}
+
+	scheme := "http"
+	if i := strings.Index(host, "://"); i >= 0 {
+		scheme, host = host[:i], host[i+3:]
+	}
+	path := ""
+	if i := strings.Index(host, "/"); i >= 0 {
+		host, path = host[:i], strings.TrimSuffix(host[i:], "/")
+	}
+
+	// Add the default port unless one is given (allowing for IPv6 brackets)
+	if i := strings.LastIndex(host, ":"); i < 0 || strings.HasSuffix(host, "]") {
+		host += ":11434"
+	}
+	return scheme + "://" + host + path
+}
@@ -4,6 +4,8 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,8 +24,14 @@ type Client struct {
 	baseURL                  string
 	providerAlias            string // For request-time alias resolution (e.g., "openai.deepseek")
 	ReasoningTokenMultiplier int    // Token multiplier for reasoning models (0 = use default 5x)
+
+	// IdempotencyKeyFunc derives the base idempotency key for a request (nil = disabled)
+	IdempotencyKeyFunc func(ctx context.Context) string
 }
 
+// IdempotencyKeyHeader is the header used to send idempotency keys
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 // NewClient creates a new OpenAI client with configuration
 func NewClient(apiKey, baseURL, providerAlias string, logger core.Logger) *Client {
 	if baseURL == "" {
@@ -53,6 +61,22 @@ func (c *Client) getProviderName() string {
 	return c.providerAlias
 }
 
+// idempotencyKey derives the idempotency key for a request body. The base key
+// from IdempotencyKeyFunc is suffixed with a hash of the body so that separate
+// calls sharing a request ID don't collide, while retries of the same body
+// (which reuse the same *http.Request) send the same key.
+func (c *Client) idempotencyKey(ctx context.Context, body []byte) string {
+	if c.IdempotencyKeyFunc == nil {
+		return ""
+	}
+	base := c.IdempotencyKeyFunc(ctx)
+	if base == "" {
+		return ""
+	}
+	sum := sha256.Sum256(body)
+	return base + "-" + hex.EncodeToString(sum[:8])
+}
+
 // truncateForLog truncates a string for logging purposes
 func truncateForLog(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -63,13 +87,21 @@ func truncateForLog(s string, maxLen int) string {
 
 // GenerateResponse generates a response using OpenAI
 func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+	return c.GenerateMessages(ctx, []core.Message{{Role: core.RoleUser, Content: prompt}}, options)
+}
+
+// GenerateMessages generates a response to a conversation using OpenAI
+func (c *Client) GenerateMessages(ctx context.Context, conversation []core.Message, options *core.AIOptions) (*core.AIResponse, error) {
 	// Start distributed tracing span
 	ctx, span := c.StartSpan(ctx, "ai.generate_response")
 	defer span.End()
 
+	prompt := providers.ConversationText(conversation)
+
 	// Set initial span attributes
 	span.SetAttribute("ai.provider", "openai")
 	span.SetAttribute("ai.prompt_length", len(prompt))
+	span.SetAttribute("ai.message_count", len(conversation))
 
 	if c.apiKey == "" {
 		if c.Logger != nil {
@@ -86,6 +118,10 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 	// Apply defaults
 	options = c.ApplyDefaults(options)
 
+	// Bound the call by options.Timeout; an earlier ctx deadline still wins
+	ctx, cancel := c.RequestContext(ctx, options)
+	defer cancel()
+
 	// Resolve model alias at request time (e.g., "smart" -> "gpt-4")
 	options.Model = ResolveModel(c.providerAlias, options.Model)
 
@@ -97,22 +133,20 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 	startTime := time.Now()
 
 	// Build messages
-	messages := []map[string]string{}
-
-	if options.SystemPrompt != "" {
-		messages = append(messages, map[string]string{
-			"role":    "system",
-			"content": options.SystemPrompt,
-		})
+	messages, err := buildMessages(conversation, options.SystemPrompt)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
 	}
 
-	messages = append(messages, map[string]string{
-		"role":    "user",
-		"content": prompt,
-	})
-
 	// Build request body (handles reasoning model differences automatically)
 	reqBody := buildRequestBody(options.Model, messages, options.MaxTokens, options.Temperature, false, c.ReasoningTokenMultiplier)
+	if len(options.Tools) > 0 {
+		reqBody["tools"] = buildTools(options.Tools)
+	}
+	if options.ResponseFormat == core.ResponseFormatJSON {
+		reqBody["response_format"] = map[string]string{"type": core.ResponseFormatJSON}
+	}
 
 	// Log reasoning model parameter adjustments (uses WithContext for trace correlation)
 	if c.Logger != nil && IsReasoningModel(options.Model) {
@@ -161,6 +195,11 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	idempotencyKey := c.idempotencyKey(ctx, jsonData)
+	if idempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+		span.SetAttribute("ai.idempotency_key", idempotencyKey)
+	}
 
 	// Execute with retry
 	resp, err := c.ExecuteWithRetry(ctx, req)
@@ -278,8 +317,14 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 		responseContent = openAIResp.Choices[0].Message.ReasoningContent
 	}
 
+	toolCalls, err := parseToolCalls(openAIResp.Choices[0].Message.ToolCalls)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
 	result := &core.AIResponse{
-		Content:  responseContent,
+		Content:  providers.FormatContent(responseContent, options),
 		Model:    openAIResp.Model,
 		Provider: c.getProviderName(),
 		Usage: core.TokenUsage{
@@ -287,6 +332,8 @@ func (c *Client) GenerateResponse(ctx context.Context, prompt string, options *c
 			CompletionTokens: openAIResp.Usage.CompletionTokens,
 			TotalTokens:      openAIResp.Usage.TotalTokens,
 		},
+		IdempotencyKey: idempotencyKey,
+		ToolCalls:      toolCalls,
 	}
 
 	// Add token usage to span for cost tracking and debugging
@@ -328,6 +375,10 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 	// Apply defaults
 	options = c.ApplyDefaults(options)
 
+	// Bound the call by options.Timeout; an earlier ctx deadline still wins
+	ctx, cancel := c.StreamRequestContext(ctx, options)
+	defer cancel()
+
 	// Resolve model alias at request time
 	options.Model = ResolveModel(c.providerAlias, options.Model)
 
@@ -339,22 +390,17 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 	startTime := time.Now()
 
 	// Build messages
-	messages := []map[string]string{}
-
-	if options.SystemPrompt != "" {
-		messages = append(messages, map[string]string{
-			"role":    "system",
-			"content": options.SystemPrompt,
-		})
+	messages, err := buildMessages([]core.Message{{Role: core.RoleUser, Content: prompt}}, options.SystemPrompt)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
 	}
 
-	messages = append(messages, map[string]string{
-		"role":    "user",
-		"content": prompt,
-	})
-
 	// Build request body with streaming enabled (handles reasoning model differences automatically)
 	reqBody := buildRequestBody(options.Model, messages, options.MaxTokens, options.Temperature, true, c.ReasoningTokenMultiplier)
+	if options.ResponseFormat == core.ResponseFormatJSON {
+		reqBody["response_format"] = map[string]string{"type": core.ResponseFormatJSON}
+	}
 
 	// Log reasoning model parameter adjustments (uses WithContext for trace correlation)
 	if c.Logger != nil && IsReasoningModel(options.Model) {
@@ -403,10 +449,15 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	idempotencyKey := c.idempotencyKey(ctx, jsonData)
+	if idempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+		span.SetAttribute("ai.idempotency_key", idempotencyKey)
+	}
 	req.Header.Set("Accept", "text/event-stream")
 
-	// Execute request (no retry for streaming - connection establishment only)
-	resp, err := c.HTTPClient.Do(req)
+	// Execute request (no retry for streaming; bounded by the stream timeouts)
+	resp, err := c.ExecuteStream(req)
 	if err != nil {
 		if c.Logger != nil {
 			c.Logger.ErrorWithContext(ctx, "OpenAI streaming request failed - send error", map[string]interface{}{
@@ -455,10 +506,11 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 			// Return partial result with what we have
 			if fullContent.Len() > 0 {
 				return &core.AIResponse{
-					Content:  fullContent.String(),
-					Model:    model,
-					Provider: c.getProviderName(),
-					Usage:    usage,
+					Content:        fullContent.String(),
+					Model:          model,
+					Provider:       c.getProviderName(),
+					Usage:          usage,
+					IdempotencyKey: idempotencyKey,
 				}, core.ErrStreamPartiallyCompleted
 			}
 			return nil, ctx.Err()
@@ -474,10 +526,11 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 			if fullContent.Len() > 0 {
 				span.SetAttribute("ai.stream_partial", true)
 				return &core.AIResponse{
-					Content:  fullContent.String(),
-					Model:    model,
-					Provider: c.getProviderName(),
-					Usage:    usage,
+					Content:        fullContent.String(),
+					Model:          model,
+					Provider:       c.getProviderName(),
+					Usage:          usage,
+					IdempotencyKey: idempotencyKey,
 				}, core.ErrStreamPartiallyCompleted
 			}
 			span.RecordError(err)
@@ -554,10 +607,11 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 					// Callback requested stop
 					span.SetAttribute("ai.stream_stopped_by_callback", true)
 					return &core.AIResponse{
-						Content:  fullContent.String(),
-						Model:    model,
-						Provider: c.getProviderName(),
-						Usage:    usage,
+						Content:        fullContent.String(),
+						Model:          model,
+						Provider:       c.getProviderName(),
+						Usage:          usage,
+						IdempotencyKey: idempotencyKey,
 					}, nil
 				}
 			}
@@ -582,10 +636,11 @@ func (c *Client) StreamResponse(ctx context.Context, prompt string, options *cor
 	}
 
 	result := &core.AIResponse{
-		Content:  fullContent.String(),
-		Model:    model,
-		Provider: c.getProviderName(),
-		Usage:    usage,
+		Content:        fullContent.String(),
+		Model:          model,
+		Provider:       c.getProviderName(),
+		Usage:          usage,
+		IdempotencyKey: idempotencyKey,
 	}
 
 	// Add token usage to span for cost tracking
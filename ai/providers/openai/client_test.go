@@ -3,6 +3,7 @@ package openai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/itsneelabh/gomind/ai"
 	"github.com/itsneelabh/gomind/core"
 )
 
@@ -404,6 +406,64 @@ func TestClient_GenerateResponseWithDefaults(t *testing.T) {
 	}
 }
 
+func TestClient_GenerateMessages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []map[string]interface{} `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		wantRoles := []string{"system", "system", "user", "assistant", "tool", "user"}
+		if len(req.Messages) != len(wantRoles) {
+			t.Fatalf("expected %d messages, got %+v", len(wantRoles), req.Messages)
+		}
+		for i, role := range wantRoles {
+			if req.Messages[i]["role"] != role {
+				t.Errorf("message %d role = %v, want %s", i, req.Messages[i]["role"], role)
+			}
+		}
+		if req.Messages[0]["content"] != "Be brief." {
+			t.Errorf("expected the SystemPrompt first, got %v", req.Messages[0])
+		}
+		calls, _ := req.Messages[3]["tool_calls"].([]interface{})
+		if len(calls) != 1 {
+			t.Fatalf("expected the assistant tool call to be replayed, got %v", req.Messages[3])
+		}
+		function := calls[0].(map[string]interface{})["function"].(map[string]interface{})
+		if function["name"] != "get_weather" || function["arguments"] != `{"city":"Paris"}` {
+			t.Errorf("unexpected tool call: %v", function)
+		}
+		if req.Messages[4]["tool_call_id"] != "call_1" {
+			t.Errorf("expected the tool result to reference call_1, got %v", req.Messages[4])
+		}
+
+		_, _ = w.Write([]byte(`{"choices": [{"message": {"content": "Bring an umbrella."}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL, "", nil)
+	resp, err := client.GenerateMessages(context.Background(), []core.Message{
+		{Role: core.RoleSystem, Content: "You are a travel assistant."},
+		{Role: core.RoleUser, Content: "Weather in Paris?"},
+		{Role: core.RoleAssistant, ToolCalls: []core.ToolCall{{ID: "call_1", Name: "get_weather", Arguments: map[string]interface{}{"city": "Paris"}}}},
+		{Role: core.RoleTool, ToolCallID: "call_1", Content: `{"forecast": "rain"}`},
+		{Role: core.RoleUser, Content: "Should I pack anything?"},
+	}, &core.AIOptions{Model: "gpt-4o", SystemPrompt: "Be brief."})
+	if err != nil {
+		t.Fatalf("GenerateMessages() error = %v", err)
+	}
+	if resp.Content != "Bring an umbrella." {
+		t.Errorf("Content = %q", resp.Content)
+	}
+
+	if _, err := client.GenerateMessages(context.Background(), []core.Message{{Role: core.RoleSystem, Content: "Only a system prompt"}}, nil); !errors.Is(err, core.ErrNoMessages) {
+		t.Errorf("expected ErrNoMessages, got %v", err)
+	}
+	if _, err := client.GenerateMessages(context.Background(), []core.Message{{Role: "narrator", Content: "Meanwhile"}}, nil); err == nil {
+		t.Error("expected an error for an unknown role")
+	}
+}
+
 func TestClient_GenerateResponseContextCancellation(t *testing.T) {
 	// Server that delays response
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -783,6 +843,53 @@ func TestClient_StreamResponse_Success(t *testing.T) {
 	}
 }
 
+func TestClient_StreamResponse_TerminalChunkCarriesUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if opts, _ := body["stream_options"].(map[string]interface{}); opts["include_usage"] != true {
+			t.Errorf("Expected stream_options.include_usage, got %v", body["stream_options"])
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		chunks := []string{
+			`data: {"id":"1","model":"gpt-4","choices":[{"delta":{"content":"Hi"},"finish_reason":"stop"}]}`,
+			`data: {"id":"1","model":"gpt-4","choices":[],"usage":{"prompt_tokens":12,"completion_tokens":4,"total_tokens":16}}`,
+			`data: [DONE]`,
+		}
+		for _, chunk := range chunks {
+			w.Write([]byte(chunk + "\n\n"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL, "", &mockLogger{})
+
+	var terminal *core.StreamChunk
+	resp, err := client.StreamResponse(context.Background(), "test", &core.AIOptions{Model: "gpt-4"}, func(chunk core.StreamChunk) error {
+		if chunk.Usage != nil {
+			if chunk.FinishReason == "" {
+				t.Errorf("Usage should only be set on the terminal chunk, got %+v", chunk)
+			}
+			terminal = &chunk
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamResponse() error = %v", err)
+	}
+
+	if terminal == nil {
+		t.Fatal("Expected a terminal chunk with usage")
+	}
+	if terminal.Usage.PromptTokens != 12 || terminal.Usage.CompletionTokens != 4 || terminal.Usage.TotalTokens != 16 {
+		t.Errorf("Terminal chunk usage = %+v", *terminal.Usage)
+	}
+	if resp.Usage.TotalTokens != 16 {
+		t.Errorf("Response usage = %+v, want 16 total tokens", resp.Usage)
+	}
+}
+
 func TestClient_StreamResponse_MissingAPIKey(t *testing.T) {
 	logger := &mockLogger{}
 	client := NewClient("", "", "", logger) // No API key
@@ -972,3 +1079,184 @@ func TestClient_StreamResponse_ContextCancellation(t *testing.T) {
 		t.Error("Expected either partial content or error on cancellation")
 	}
 }
+
+func TestClient_IdempotencyKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"model": "gpt-4", "choices": [{"message": {"content": "ok"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL, "", &mockLogger{})
+	ctx := context.Background()
+
+	// Disabled by default
+	if _, err := client.GenerateResponse(ctx, "hello", nil); err != nil {
+		t.Fatalf("GenerateResponse() error = %v", err)
+	}
+	if keys[0] != "" {
+		t.Errorf("Expected no idempotency key by default, got %q", keys[0])
+	}
+
+	client.IdempotencyKeyFunc = func(ctx context.Context) string { return "req-123" }
+	resp, err := client.GenerateResponse(ctx, "hello", nil)
+	if err != nil {
+		t.Fatalf("GenerateResponse() error = %v", err)
+	}
+	_, _ = client.GenerateResponse(ctx, "hello", nil)
+	_, _ = client.GenerateResponse(ctx, "different prompt", nil)
+
+	if !strings.HasPrefix(keys[1], "req-123-") {
+		t.Errorf("Expected key derived from request ID, got %q", keys[1])
+	}
+	if resp.IdempotencyKey != keys[1] {
+		t.Errorf("AIResponse.IdempotencyKey = %q, want %q", resp.IdempotencyKey, keys[1])
+	}
+	if keys[1] != keys[2] {
+		t.Errorf("Identical requests should share a key: %q vs %q", keys[1], keys[2])
+	}
+	if keys[1] == keys[3] {
+		t.Error("Different requests with the same request ID must not share a key")
+	}
+}
+
+func TestClient_GenerateResponse_ToolCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Tools []struct {
+				Type     string `json:"type"`
+				Function struct {
+					Name       string          `json:"name"`
+					Parameters json.RawMessage `json:"parameters"`
+				} `json:"function"`
+			} `json:"tools"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if len(body.Tools) != 1 || body.Tools[0].Type != "function" || body.Tools[0].Function.Name != "get_weather" || len(body.Tools[0].Function.Parameters) == 0 {
+			t.Errorf("Unexpected tools in request: %+v", body.Tools)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"model":"gpt-4","choices":[{"message":{"role":"assistant","content":null,"tool_calls":[{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}}]},"finish_reason":"tool_calls"}],"usage":{"total_tokens":10}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL, "", &mockLogger{})
+	resp, err := client.GenerateResponse(context.Background(), "Weather in Paris?", &core.AIOptions{
+		Model: "gpt-4",
+		Tools: []core.ToolDefinition{{
+			Name:        "get_weather",
+			Description: "Current weather for a city",
+			Parameters:  json.RawMessage(`{"type":"object","properties":{"city":{"type":"string"}},"required":["city"]}`),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("GenerateResponse() error = %v", err)
+	}
+
+	if len(resp.ToolCalls) != 1 {
+		t.Fatalf("Expected 1 tool call, got %+v", resp.ToolCalls)
+	}
+	call := resp.ToolCalls[0]
+	if call.ID != "call_1" || call.Name != "get_weather" || call.Arguments["city"] != "Paris" {
+		t.Errorf("Unexpected tool call: %+v", call)
+	}
+}
+
+func TestClient_GenerateResponse_OptionsTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient("test-key", server.URL, "", &mockLogger{})
+
+	start := time.Now()
+	_, err := client.GenerateResponse(context.Background(), "test", &core.AIOptions{
+		Model:   "gpt-4",
+		Timeout: 50 * time.Millisecond,
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected an error wrapping context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected Options.Timeout to bound the call, took %v", elapsed)
+	}
+}
+
+func TestClient_StreamResponse_CancelClosesConnection(t *testing.T) {
+	disconnected := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(disconnected)
+		w.Header().Set("Content-Type", "text/event-stream")
+		for {
+			_, _ = w.Write([]byte(`data: {"id":"1","model":"gpt-4","choices":[{"delta":{"content":"tick"}}]}` + "\n\n"))
+			w.(http.Flusher).Flush()
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL, "", nil)
+	stream, cancel := ai.GenerateStreamWithCancel(context.Background(), client, "test", nil)
+
+	if item := <-stream; item.Err != nil || item.Content != "tick" {
+		t.Fatalf("Unexpected first item: %+v", item)
+	}
+
+	// The caller stops reading; cancel returns only once the producer exited
+	stopped := make(chan struct{})
+	go func() {
+		cancel()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("cancel did not return; the stream goroutine leaked")
+	}
+
+	if _, ok := <-stream; ok {
+		t.Error("Expected the stream channel to be closed after cancel")
+	}
+	select {
+	case <-disconnected:
+	case <-time.After(time.Second):
+		t.Fatal("Provider connection was not closed after cancel")
+	}
+}
+
+func TestClient_GenerateResponse_JSONMode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if format, _ := body["response_format"].(map[string]interface{}); format["type"] != "json_object" {
+			t.Errorf("Expected response_format json_object, got %v", body["response_format"])
+		}
+
+		// Compatible backends may ignore response_format and add fences
+		_, _ = w.Write([]byte(`{"model":"gpt-4","choices":[{"message":{"role":"assistant","content":"` + "```json\\n{\\\"ok\\\":true}\\n```" + `"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL, "", nil)
+	resp, err := client.GenerateResponse(context.Background(), "Reply in JSON", &core.AIOptions{
+		Model:          "gpt-4",
+		ResponseFormat: core.ResponseFormatJSON,
+	})
+	if err != nil {
+		t.Fatalf("GenerateResponse() error = %v", err)
+	}
+	if resp.Content != `{"ok":true}` {
+		t.Errorf("Content = %q, want the bare JSON object", resp.Content)
+	}
+}
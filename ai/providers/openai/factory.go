@@ -54,13 +54,24 @@ func (f *Factory) Create(config *ai.AIConfig) core.AIClient {
 
 	// Apply timeout if specified
 	if config.Timeout > 0 {
-		client.HTTPClient.Timeout = config.Timeout
+		client.RequestTimeout = config.Timeout
+	}
+
+	// Apply streaming timeouts if specified
+	if config.StreamTotalTimeout > 0 {
+		client.StreamTotalTimeout = config.StreamTotalTimeout
+	}
+	if config.StreamIdleTimeout > 0 {
+		client.StreamIdleTimeout = config.StreamIdleTimeout
 	}
 
 	// Apply retry configuration
 	if config.MaxRetries > 0 {
 		client.MaxRetries = config.MaxRetries
 	}
+	if config.RetryPolicy != nil {
+		client.RetryPolicy = config.RetryPolicy
+	}
 
 	// Apply model defaults
 	// CRITICAL: Use the "default" ALIAS (not resolved model name) to enable env var overrides
@@ -102,6 +113,9 @@ func (f *Factory) Create(config *ai.AIConfig) core.AIClient {
 		client.ReasoningTokenMultiplier = config.ReasoningTokenMultiplier
 	}
 
+	// Send idempotency keys so network-retried requests aren't billed twice
+	client.IdempotencyKeyFunc = config.IdempotencyKeyFunc
+
 	// Apply custom headers if any
 	if len(config.Headers) > 0 {
 		// Create a custom transport to add headers
@@ -180,8 +180,8 @@ func TestFactory_Create(t *testing.T) {
 				Timeout: 60 * time.Second,
 			},
 			verify: func(t *testing.T, c *Client) {
-				if c.HTTPClient.Timeout != 60*time.Second {
-					t.Errorf("expected timeout 60s, got %v", c.HTTPClient.Timeout)
+				if c.RequestTimeout != 60*time.Second {
+					t.Errorf("expected timeout 60s, got %v", c.RequestTimeout)
 				}
 			},
 		},
@@ -25,9 +25,21 @@ type Choice struct {
 // Message represents a chat message
 // For reasoning models (GPT-5, o1, o3, o4), content may be in ReasoningContent field
 type Message struct {
-	Role             string `json:"role"`
-	Content          string `json:"content"`
-	ReasoningContent string `json:"reasoning_content,omitempty"` // GPT-5/o-series reasoning models
+	Role             string     `json:"role"`
+	Content          string     `json:"content"`
+	ReasoningContent string     `json:"reasoning_content,omitempty"` // GPT-5/o-series reasoning models
+	ToolCalls        []ToolCall `json:"tool_calls,omitempty"`
+}
+
+// ToolCall represents a function call requested by the model. Arguments is
+// a JSON-encoded object.
+type ToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
 }
 
 // Usage represents token usage information
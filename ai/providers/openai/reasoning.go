@@ -59,7 +59,7 @@ const DefaultReasoningTokenMultiplier = 5
 //
 // The reasoningTokenMultiplier parameter allows callers to configure the multiplier.
 // Use DefaultReasoningTokenMultiplier (5) if no custom value is needed.
-func buildRequestBody(model string, messages []map[string]string, maxTokens int, temperature float32, streaming bool, reasoningTokenMultiplier int) map[string]interface{} {
+func buildRequestBody(model string, messages []map[string]interface{}, maxTokens int, temperature float32, streaming bool, reasoningTokenMultiplier int) map[string]interface{} {
 	reqBody := map[string]interface{}{
 		"model":    model,
 		"messages": messages,
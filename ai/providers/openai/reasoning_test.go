@@ -63,7 +63,7 @@ func TestIsReasoningModel(t *testing.T) {
 }
 
 func TestBuildRequestBody_StandardModel(t *testing.T) {
-	messages := []map[string]string{
+	messages := []map[string]interface{}{
 		{"role": "user", "content": "Hello"},
 	}
 
@@ -98,7 +98,7 @@ func TestBuildRequestBody_StandardModel(t *testing.T) {
 }
 
 func TestBuildRequestBody_ReasoningModel(t *testing.T) {
-	messages := []map[string]string{
+	messages := []map[string]interface{}{
 		{"role": "user", "content": "Hello"},
 	}
 
@@ -128,7 +128,7 @@ func TestBuildRequestBody_ReasoningModel(t *testing.T) {
 }
 
 func TestBuildRequestBody_ReasoningModelCustomMultiplier(t *testing.T) {
-	messages := []map[string]string{
+	messages := []map[string]interface{}{
 		{"role": "user", "content": "Hello"},
 	}
 
@@ -144,7 +144,7 @@ func TestBuildRequestBody_ReasoningModelCustomMultiplier(t *testing.T) {
 }
 
 func TestBuildRequestBody_Streaming(t *testing.T) {
-	messages := []map[string]string{
+	messages := []map[string]interface{}{
 		{"role": "user", "content": "Hello"},
 	}
 
@@ -178,7 +178,7 @@ func TestBuildRequestBody_Streaming(t *testing.T) {
 }
 
 func TestBuildRequestBody_AllReasoningModelFamilies(t *testing.T) {
-	messages := []map[string]string{
+	messages := []map[string]interface{}{
 		{"role": "user", "content": "Test"},
 	}
 
@@ -0,0 +1,115 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// buildTools converts tool definitions to the chat completions "tools" format
+func buildTools(tools []core.ToolDefinition) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(tools))
+	for _, tool := range tools {
+		function := map[string]interface{}{
+			"name":        tool.Name,
+			"description": tool.Description,
+		}
+		if len(tool.Parameters) > 0 {
+			function["parameters"] = tool.Parameters
+		}
+		result = append(result, map[string]interface{}{
+			"type":     "function",
+			"function": function,
+		})
+	}
+	return result
+}
+
+// parseToolCalls decodes the JSON arguments of the model's tool calls
+func parseToolCalls(calls []ToolCall) ([]core.ToolCall, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+
+	result := make([]core.ToolCall, 0, len(calls))
+	for _, call := range calls {
+		arguments := map[string]interface{}{}
+		if call.Function.Arguments != "" {
+			if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil {
+				return nil, fmt.Errorf("invalid arguments for tool %s: %w", call.Function.Name, err)
+			}
+		}
+		result = append(result, core.ToolCall{
+			ID:        call.ID,
+			Name:      call.Function.Name,
+			Arguments: arguments,
+		})
+	}
+	return result, nil
+}
+
+// buildMessages converts a conversation to the chat completions format,
+// with systemPrompt as the first message
+func buildMessages(conversation []core.Message, systemPrompt string) ([]map[string]interface{}, error) {
+	messages := make([]map[string]interface{}, 0, len(conversation)+1)
+	if systemPrompt != "" {
+		messages = append(messages, map[string]interface{}{
+			"role":    core.RoleSystem,
+			"content": systemPrompt,
+		})
+	}
+
+	turns := 0
+	for _, message := range conversation {
+		converted := map[string]interface{}{
+			"role":    message.Role,
+			"content": message.Content,
+		}
+		switch message.Role {
+		case core.RoleSystem:
+		case core.RoleUser:
+		case core.RoleAssistant:
+			if len(message.ToolCalls) > 0 {
+				calls, err := buildToolCallMessages(message.ToolCalls)
+				if err != nil {
+					return nil, err
+				}
+				converted["tool_calls"] = calls
+			}
+		case core.RoleTool:
+			converted["tool_call_id"] = message.ToolCallID
+		default:
+			return nil, fmt.Errorf("unsupported message role %q", message.Role)
+		}
+		if message.Role != core.RoleSystem {
+			turns++
+		}
+		messages = append(messages, converted)
+	}
+	if turns == 0 {
+		return nil, core.ErrNoMessages
+	}
+	return messages, nil
+}
+
+// buildToolCallMessages converts an assistant message's tool calls back to
+// the format the model returned them in
+func buildToolCallMessages(calls []core.ToolCall) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, 0, len(calls))
+	for _, call := range calls {
+		arguments, err := json.Marshal(call.Arguments)
+		if err != nil {
+			return nil, fmt.Errorf("invalid arguments for tool %s: %w", call.Name, err)
+		}
+		result = append(result, map[string]interface{}{
+			"id":   call.ID,
+			"type": "function",
+			"function": map[string]interface{}{
+				"name":      call.Name,
+				"arguments": string(arguments),
+			},
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,132 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// Default streaming timeouts. Streams legitimately run far longer than a
+// unary request, so they are bounded separately from RequestTimeout.
+const (
+	DefaultStreamTotalTimeout = 10 * time.Minute
+	DefaultStreamIdleTimeout  = 2 * time.Minute
+)
+
+// WatchStream returns a context for a streaming call that is cancelled when
+// the stream runs longer than StreamTotalTimeout or when touch is not called
+// for StreamIdleTimeout. Call touch whenever data arrives and stop when the
+// stream is finished. A zero timeout falls back to DefaultStreamTotalTimeout
+// or DefaultStreamIdleTimeout, matching the AIConfig validation. Deadlines on
+// ctx still apply, so whichever limit is reached first ends the stream.
+//
+// Once the context is done, context.Cause reports core.ErrStreamTimeout or
+// core.ErrStreamIdleTimeout; StreamError uses this to explain read errors.
+func (b *BaseClient) WatchStream(ctx context.Context) (streamCtx context.Context, touch func(), stop func()) {
+	total, idle := b.streamTimeouts()
+	streamCtx, cancel := context.WithCancelCause(ctx)
+	totalTimer := time.AfterFunc(total, func() { cancel(core.ErrStreamTimeout) })
+
+	var mu sync.Mutex
+	idleTimer := time.AfterFunc(idle, func() { cancel(core.ErrStreamIdleTimeout) })
+	touch = func() {
+		mu.Lock()
+		defer mu.Unlock()
+		idleTimer.Reset(idle)
+	}
+
+	stop = func() {
+		totalTimer.Stop()
+		idleTimer.Stop()
+		cancel(nil)
+	}
+	return streamCtx, touch, stop
+}
+
+// streamTimeouts returns the effective stream bounds, substituting the
+// defaults for unset values
+func (b *BaseClient) streamTimeouts() (total, idle time.Duration) {
+	total, idle = b.StreamTotalTimeout, b.StreamIdleTimeout
+	if total <= 0 {
+		total = DefaultStreamTotalTimeout
+	}
+	if idle <= 0 {
+		idle = DefaultStreamIdleTimeout
+	}
+	return total, idle
+}
+
+// StreamError returns core.ErrStreamTimeout or core.ErrStreamIdleTimeout,
+// wrapping err, when streamCtx was ended by a stream timeout. Other errors are
+// returned unchanged.
+func StreamError(streamCtx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	cause := context.Cause(streamCtx)
+	if errors.Is(cause, core.ErrStreamTimeout) || errors.Is(cause, core.ErrStreamIdleTimeout) {
+		if errors.Is(err, cause) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", cause, err)
+	}
+	return err
+}
+
+// ExecuteStream sends a streaming HTTP request. Unlike ExecuteWithRetry it
+// does not retry and ignores any HTTPClient.Timeout, which would otherwise
+// cut off long streams; the stream is bounded by StreamTotalTimeout and
+// StreamIdleTimeout instead. Reads from the returned body reset the idle
+// timer, and read errors caused by a stream timeout wrap the matching
+// core sentinel. Closing the body releases the timers.
+func (b *BaseClient) ExecuteStream(req *http.Request) (*http.Response, error) {
+	streamCtx, touch, stop := b.WatchStream(req.Context())
+
+	client := &http.Client{}
+	if b.HTTPClient != nil {
+		copied := *b.HTTPClient
+		client = &copied
+	}
+	client.Timeout = 0
+
+	resp, err := client.Do(req.WithContext(streamCtx))
+	if err != nil {
+		err = StreamError(streamCtx, err)
+		stop()
+		return nil, err
+	}
+	touch()
+	resp.Body = &watchedBody{body: resp.Body, ctx: streamCtx, touch: touch, stop: stop}
+	return resp, nil
+}
+
+// watchedBody resets the idle timer on every read and explains timeouts
+type watchedBody struct {
+	body  io.ReadCloser
+	ctx   context.Context
+	touch func()
+	stop  func()
+}
+
+func (w *watchedBody) Read(p []byte) (int, error) {
+	n, err := w.body.Read(p)
+	if n > 0 {
+		w.touch()
+	}
+	if err != nil && err != io.EOF {
+		err = StreamError(w.ctx, err)
+	}
+	return n, err
+}
+
+func (w *watchedBody) Close() error {
+	err := w.body.Close()
+	w.stop()
+	return err
+}
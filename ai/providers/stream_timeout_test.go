@@ -0,0 +1,112 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// chunkedServer writes count chunks, pausing interval before each one
+func chunkedServer(t *testing.T, count int, interval time.Duration) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		for i := 0; i < count; i++ {
+			select {
+			case <-time.After(interval):
+			case <-r.Context().Done():
+				return
+			}
+			_, _ = fmt.Fprintf(w, "data: %d\n", i)
+			flusher.Flush()
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func readStream(t *testing.T, client *BaseClient, url string) error {
+	req, err := http.NewRequestWithContext(context.Background(), "GET", url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.ExecuteStream(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, err = io.ReadAll(resp.Body)
+	return err
+}
+
+func TestExecuteStream_OutlivesRequestTimeout(t *testing.T) {
+	server := chunkedServer(t, 5, 20*time.Millisecond)
+
+	client := NewBaseClient(30*time.Millisecond, nil)
+	client.StreamIdleTimeout = 200 * time.Millisecond
+
+	if err := readStream(t, client, server.URL); err != nil {
+		t.Fatalf("Expected a steady stream to outlive the request timeout, got %v", err)
+	}
+}
+
+func TestExecuteStream_IdleTimeout(t *testing.T) {
+	server := chunkedServer(t, 2, 200*time.Millisecond)
+
+	client := NewBaseClient(time.Minute, nil)
+	client.StreamIdleTimeout = 50 * time.Millisecond
+
+	err := readStream(t, client, server.URL)
+	if !errors.Is(err, core.ErrStreamIdleTimeout) {
+		t.Fatalf("Expected ErrStreamIdleTimeout, got %v", err)
+	}
+}
+
+func TestExecuteStream_TotalTimeout(t *testing.T) {
+	server := chunkedServer(t, 50, 10*time.Millisecond)
+
+	client := NewBaseClient(time.Minute, nil)
+	client.StreamTotalTimeout = 80 * time.Millisecond
+	client.StreamIdleTimeout = 50 * time.Millisecond
+
+	err := readStream(t, client, server.URL)
+	if !errors.Is(err, core.ErrStreamTimeout) {
+		t.Fatalf("Expected ErrStreamTimeout, got %v", err)
+	}
+}
+
+func TestStreamTimeouts_ZeroUsesDefaults(t *testing.T) {
+	client := &BaseClient{}
+
+	total, idle := client.streamTimeouts()
+	if total != DefaultStreamTotalTimeout || idle != DefaultStreamIdleTimeout {
+		t.Errorf("Expected defaults %v/%v for zero timeouts, got %v/%v",
+			DefaultStreamTotalTimeout, DefaultStreamIdleTimeout, total, idle)
+	}
+
+	client.StreamTotalTimeout = time.Second
+	client.StreamIdleTimeout = 100 * time.Millisecond
+	total, idle = client.streamTimeouts()
+	if total != time.Second || idle != 100*time.Millisecond {
+		t.Errorf("Expected configured timeouts to be kept, got %v/%v", total, idle)
+	}
+}
+
+func TestStreamError_PassesThroughOtherErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	original := errors.New("connection reset")
+	if got := StreamError(ctx, original); got != original {
+		t.Errorf("Expected errors without a stream timeout cause to pass through, got %v", got)
+	}
+	if StreamError(ctx, nil) != nil {
+		t.Error("Expected nil for a nil error")
+	}
+}
@@ -0,0 +1,419 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+	"github.com/itsneelabh/gomind/telemetry"
+)
+
+// defaultEstimatedCompletionTokens is reserved for the response when a call
+// does not set MaxTokens, matching the providers' default
+const defaultEstimatedCompletionTokens = 1000
+
+// RateLimit is a client-side limit matching a provider's quota. A zero
+// field is not limited.
+type RateLimit struct {
+	RequestsPerMinute int
+	TokensPerMinute   int
+}
+
+// RateLimiter holds token buckets for requests and tokens per minute, one
+// pair per limit. Calls over the limit wait for capacity instead of failing,
+// which smooths bursts of parallel calls into the provider's quota.
+//
+// The token cost of a call is reserved before it is sent: the estimated
+// prompt tokens plus MaxTokens. Once the response arrives, the reservation
+// is reconciled with the actual Usage.TotalTokens.
+//
+// A limiter can be shared by several clients with WithRateLimiter, so they
+// draw on one quota. It is safe for concurrent use.
+type RateLimiter struct {
+	mu       sync.Mutex
+	limits   map[string]RateLimit // By model prefix; "" is the default
+	prefixes []string             // Longest first
+	buckets  map[string]*rateBuckets
+	now      func() time.Time
+}
+
+// rateBuckets are the request and token buckets of one limit
+type rateBuckets struct {
+	requests *tokenBucket
+	tokens   *tokenBucket
+}
+
+// tokenBucket refills at a per-minute rate up to one minute's worth. Its
+// balance goes negative when calls reserve more than is available; the
+// deficit is how long the next caller waits.
+type tokenBucket struct {
+	capacity  float64
+	perSecond float64
+	available float64
+	updated   time.Time
+}
+
+func newTokenBucket(perMinute int, now time.Time) *tokenBucket {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &tokenBucket{
+		capacity:  float64(perMinute),
+		perSecond: float64(perMinute) / 60,
+		available: float64(perMinute),
+		updated:   now,
+	}
+}
+
+// reserve takes n from the bucket and returns the amount taken and how long
+// until the balance is back to zero. Reservations are capped at the capacity
+// so a single oversized call waits at most a minute.
+func (b *tokenBucket) reserve(n float64, now time.Time) (float64, time.Duration) {
+	if b == nil {
+		return 0, 0
+	}
+	b.refill(now)
+	taken := min(n, b.capacity)
+	b.available -= taken
+	if b.available >= 0 {
+		return taken, 0
+	}
+	return taken, time.Duration(-b.available / b.perSecond * float64(time.Second))
+}
+
+// refund returns n to the bucket, e.g. a cancelled reservation or an
+// overestimate. A negative n takes an underestimate.
+func (b *tokenBucket) refund(n float64, now time.Time) {
+	if b == nil {
+		return
+	}
+	b.refill(now)
+	b.available = min(b.available+n, b.capacity)
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated).Seconds()
+	if elapsed > 0 {
+		b.available = min(b.available+elapsed*b.perSecond, b.capacity)
+		b.updated = now
+	}
+}
+
+// NewRateLimiter returns a limiter applying limit to every model without a
+// more specific limit from SetModelLimit
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	l := &RateLimiter{
+		limits:  make(map[string]RateLimit),
+		buckets: make(map[string]*rateBuckets),
+		now:     time.Now,
+	}
+	l.limits[""] = limit
+	l.prefixes = []string{""}
+	return l
+}
+
+// SetModelLimit limits calls to models whose name starts with modelPrefix
+// (case-insensitive) separately from the default limit. The longest
+// matching prefix wins. Calls that leave AIOptions.Model empty use the
+// default limit.
+func (l *RateLimiter) SetModelLimit(modelPrefix string, limit RateLimit) {
+	modelPrefix = strings.ToLower(modelPrefix)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.limits[modelPrefix]; !exists {
+		l.prefixes = append(l.prefixes, modelPrefix)
+		sort.Slice(l.prefixes, func(i, j int) bool {
+			return len(l.prefixes[i]) > len(l.prefixes[j])
+		})
+	}
+	l.limits[modelPrefix] = limit
+	delete(l.buckets, modelPrefix)
+}
+
+// rateReservation is the capacity taken by one call
+type rateReservation struct {
+	key    string
+	tokens float64
+}
+
+// wait blocks until model has capacity for one request of estimatedTokens,
+// or ctx is done, and returns how long it waited. The reservation must be
+// passed to reconcile once the call completes.
+func (l *RateLimiter) wait(ctx context.Context, model string, estimatedTokens int) (*rateReservation, time.Duration, error) {
+	l.mu.Lock()
+	now := l.now()
+	key := l.match(model)
+	buckets := l.bucketsFor(key, now)
+	requests, requestDelay := buckets.requests.reserve(1, now)
+	tokens, tokenDelay := buckets.tokens.reserve(float64(estimatedTokens), now)
+	reservation := &rateReservation{key: key, tokens: tokens}
+	delay := max(requestDelay, tokenDelay)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return reservation, 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return reservation, delay, nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		now := l.now()
+		buckets.requests.refund(requests, now)
+		buckets.tokens.refund(tokens, now)
+		l.mu.Unlock()
+		return nil, 0, fmt.Errorf("waiting for AI rate limit: %w", ctx.Err())
+	}
+}
+
+// reconcile corrects the token reservation with the tokens actually used.
+// A failed call without usage gets its tokens back; its request still counts.
+func (l *RateLimiter) reconcile(reservation *rateReservation, response *core.AIResponse) {
+	actual := 0.0
+	if response != nil {
+		actual = float64(response.Usage.TotalTokens)
+	}
+	if response != nil && actual == 0 {
+		return // Provider reported no usage; keep the estimate
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if buckets, ok := l.buckets[reservation.key]; ok {
+		buckets.tokens.refund(reservation.tokens-actual, l.now())
+	}
+}
+
+// match returns the longest prefix matching model. Callers must hold l.mu.
+func (l *RateLimiter) match(model string) string {
+	model = strings.ToLower(model)
+	for _, prefix := range l.prefixes {
+		if prefix != "" && strings.HasPrefix(model, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// bucketsFor returns the buckets of key, creating them on first use.
+// Callers must hold l.mu.
+func (l *RateLimiter) bucketsFor(key string, now time.Time) *rateBuckets {
+	buckets, ok := l.buckets[key]
+	if !ok {
+		limit := l.limits[key]
+		buckets = &rateBuckets{
+			requests: newTokenBucket(limit.RequestsPerMinute, now),
+			tokens:   newTokenBucket(limit.TokensPerMinute, now),
+		}
+		l.buckets[key] = buckets
+	}
+	return buckets
+}
+
+// WithRateLimit limits the client to requestsPerMinute calls and
+// tokensPerMinute tokens (prompt plus completion), waiting for capacity
+// rather than failing. Set them a little below the provider's quota to
+// avoid 429s. A zero value is not limited.
+func WithRateLimit(requestsPerMinute, tokensPerMinute int) AIOption {
+	return func(c *AIConfig) {
+		c.RateLimit = &RateLimit{RequestsPerMinute: requestsPerMinute, TokensPerMinute: tokensPerMinute}
+	}
+}
+
+// WithModelRateLimit limits calls to models whose name starts with
+// modelPrefix separately, for providers whose quotas differ per model
+func WithModelRateLimit(modelPrefix string, requestsPerMinute, tokensPerMinute int) AIOption {
+	return func(c *AIConfig) {
+		if c.ModelRateLimits == nil {
+			c.ModelRateLimits = make(map[string]RateLimit)
+		}
+		c.ModelRateLimits[modelPrefix] = RateLimit{RequestsPerMinute: requestsPerMinute, TokensPerMinute: tokensPerMinute}
+	}
+}
+
+// WithRateLimiter enforces limiter's limits, so several clients calling the
+// same provider account share one quota. WithRateLimit and
+// WithModelRateLimit are ignored when a limiter is given.
+func WithRateLimiter(limiter *RateLimiter) AIOption {
+	return func(c *AIConfig) {
+		c.RateLimiter = limiter
+	}
+}
+
+// rateLimiterFor returns the limiter configured by the rate limit options,
+// or nil if none were used
+func rateLimiterFor(config *AIConfig) *RateLimiter {
+	if config.RateLimiter != nil {
+		return config.RateLimiter
+	}
+	if config.RateLimit == nil && len(config.ModelRateLimits) == 0 {
+		return nil
+	}
+
+	var limit RateLimit
+	if config.RateLimit != nil {
+		limit = *config.RateLimit
+	}
+	limiter := NewRateLimiter(limit)
+	for prefix, modelLimit := range config.ModelRateLimits {
+		limiter.SetModelLimit(prefix, modelLimit)
+	}
+	return limiter
+}
+
+// rateLimitedClient waits for its RateLimiter before every call
+type rateLimitedClient struct {
+	client  core.AIClient
+	limiter *RateLimiter
+	logger  core.Logger
+}
+
+// NewRateLimitedClient wraps client so every call waits for capacity in
+// limiter. NewClient does this automatically for the rate limit options;
+// use this function for clients built another way. Streaming support of
+// the wrapped client is preserved.
+func NewRateLimitedClient(client core.AIClient, limiter *RateLimiter, logger core.Logger) core.AIClient {
+	if limiter == nil {
+		return client
+	}
+	if logger == nil {
+		logger = &core.NoOpLogger{}
+	}
+	limited := &rateLimitedClient{client: client, limiter: limiter, logger: logger}
+	if streaming, ok := client.(core.StreamingAIClient); ok {
+		return &rateLimitedStreamingClient{rateLimitedClient: limited, streaming: streaming}
+	}
+	return limited
+}
+
+// Unwrap returns the wrapped client
+func (r *rateLimitedClient) Unwrap() core.AIClient {
+	return r.client
+}
+
+// SetLogger updates the logger and propagates it to the wrapped client
+func (r *rateLimitedClient) SetLogger(logger core.Logger) {
+	if logger == nil {
+		r.logger = &core.NoOpLogger{}
+	} else if cal, ok := logger.(core.ComponentAwareLogger); ok {
+		r.logger = cal.WithComponent("framework/ai")
+	} else {
+		r.logger = logger
+	}
+
+	if logger != nil {
+		if loggable, ok := r.client.(interface{ SetLogger(core.Logger) }); ok {
+			loggable.SetLogger(logger)
+		}
+	}
+}
+
+// GenerateResponse waits for capacity, then delegates to the wrapped client
+func (r *rateLimitedClient) GenerateResponse(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+	model, estimate := estimateCallTokens(options, func(model string) int {
+		tokens, _ := EstimateTokensForModel(model, prompt)
+		return tokens
+	})
+	reservation, err := r.wait(ctx, model, estimate)
+	if err != nil {
+		return nil, err
+	}
+	response, err := r.client.GenerateResponse(ctx, prompt, options)
+	r.limiter.reconcile(reservation, response)
+	return response, err
+}
+
+// GenerateMessages waits for capacity, then delegates to the wrapped client
+func (r *rateLimitedClient) GenerateMessages(ctx context.Context, messages []core.Message, options *core.AIOptions) (*core.AIResponse, error) {
+	model, estimate := estimateCallTokens(options, func(model string) int {
+		tokens, _ := EstimateMessageTokens(model, messages)
+		return tokens
+	})
+	reservation, err := r.wait(ctx, model, estimate)
+	if err != nil {
+		return nil, err
+	}
+	response, err := core.GenerateMessages(ctx, r.client, messages, options)
+	r.limiter.reconcile(reservation, response)
+	return response, err
+}
+
+// wait blocks for capacity and records how long the call was held back
+func (r *rateLimitedClient) wait(ctx context.Context, model string, estimate int) (*rateReservation, error) {
+	reservation, waited, err := r.limiter.wait(ctx, model, estimate)
+	if err != nil {
+		r.logger.WarnWithContext(ctx, "AI call cancelled while waiting for rate limit", map[string]interface{}{
+			"operation":        "ai_rate_limit",
+			"model":            model,
+			"estimated_tokens": estimate,
+			"error":            err.Error(),
+		})
+		return nil, err
+	}
+	if waited > 0 {
+		r.logger.DebugWithContext(ctx, "AI call delayed by rate limit", map[string]interface{}{
+			"operation":        "ai_rate_limit",
+			"model":            model,
+			"estimated_tokens": estimate,
+			"wait_ms":          waited.Milliseconds(),
+		})
+		telemetry.Histogram("ai.rate_limit.wait_ms", float64(waited.Milliseconds()),
+			"module", telemetry.ModuleAI,
+		)
+	}
+	return reservation, nil
+}
+
+// estimateCallTokens returns the model a call is limited under and its
+// estimated token cost: the prompt tokens estimated by promptTokens plus
+// MaxTokens
+func estimateCallTokens(options *core.AIOptions, promptTokens func(model string) int) (string, int) {
+	model := ""
+	completion := defaultEstimatedCompletionTokens
+	system := ""
+	if options != nil {
+		model = options.Model
+		system = options.SystemPrompt
+		if options.MaxTokens > 0 {
+			completion = options.MaxTokens
+		}
+	}
+	systemTokens, _ := EstimateTokensForModel(model, system)
+	return model, promptTokens(model) + systemTokens + completion
+}
+
+// rateLimitedStreamingClient is a rateLimitedClient for clients that can
+// stream
+type rateLimitedStreamingClient struct {
+	*rateLimitedClient
+	streaming core.StreamingAIClient
+}
+
+// StreamResponse waits for capacity, then streams from the wrapped client
+func (r *rateLimitedStreamingClient) StreamResponse(ctx context.Context, prompt string, options *core.AIOptions, callback core.StreamCallback) (*core.AIResponse, error) {
+	model, estimate := estimateCallTokens(options, func(model string) int {
+		tokens, _ := EstimateTokensForModel(model, prompt)
+		return tokens
+	})
+	reservation, err := r.wait(ctx, model, estimate)
+	if err != nil {
+		return nil, err
+	}
+	response, err := r.streaming.StreamResponse(ctx, prompt, options, callback)
+	r.limiter.reconcile(reservation, response)
+	return response, err
+}
+
+// SupportsStreaming reports whether the wrapped client can stream
+func (r *rateLimitedStreamingClient) SupportsStreaming() bool {
+	return r.streaming.SupportsStreaming()
+}
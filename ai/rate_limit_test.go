@@ -0,0 +1,143 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// newTestRateLimiter returns a limiter on a clock that only moves when the
+// returned function is called
+func newTestRateLimiter(limit RateLimit) (*RateLimiter, func(time.Duration)) {
+	limiter := NewRateLimiter(limit)
+	now := time.Unix(1700000000, 0)
+	limiter.now = func() time.Time { return now }
+	return limiter, func(d time.Duration) { now = now.Add(d) }
+}
+
+// cancelledContext returns a context that is already done, so a wait
+// returns at once with an error when the limit is reached
+func cancelledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestRateLimiter_RequestsPerMinute(t *testing.T) {
+	limiter, advance := newTestRateLimiter(RateLimit{RequestsPerMinute: 60})
+
+	for i := 0; i < 60; i++ {
+		if _, _, err := limiter.wait(cancelledContext(), "", 0); err != nil {
+			t.Fatalf("Expected request %d to be within the burst, got %v", i+1, err)
+		}
+	}
+	_, _, err := limiter.wait(cancelledContext(), "", 0)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the 61st request to wait, got %v", err)
+	}
+
+	// The cancelled wait gave its request back, so one second refills one
+	advance(time.Second)
+	if _, waited, err := limiter.wait(cancelledContext(), "", 0); err != nil || waited != 0 {
+		t.Errorf("Expected a request after the refill, got %v after %v", err, waited)
+	}
+}
+
+func TestRateLimiter_ReconcilesTokens(t *testing.T) {
+	limiter, _ := newTestRateLimiter(RateLimit{TokensPerMinute: 1000})
+
+	reservation, _, err := limiter.wait(cancelledContext(), "", 900)
+	if err != nil {
+		t.Fatalf("Expected the first call to proceed, got %v", err)
+	}
+	limiter.reconcile(reservation, &core.AIResponse{Usage: core.TokenUsage{TotalTokens: 100}})
+
+	// 800 of the 900 reserved tokens came back
+	if _, _, err := limiter.wait(cancelledContext(), "", 900); err != nil {
+		t.Fatalf("Expected the overestimate to be refunded, got %v", err)
+	}
+	if _, _, err := limiter.wait(cancelledContext(), "", 100); err == nil {
+		t.Fatal("Expected the bucket to be exhausted")
+	}
+
+	// Failed calls without a response get their tokens back
+	limiter, _ = newTestRateLimiter(RateLimit{TokensPerMinute: 1000})
+	reservation, _, _ = limiter.wait(cancelledContext(), "", 1000)
+	limiter.reconcile(reservation, nil)
+	if _, _, err := limiter.wait(cancelledContext(), "", 1000); err != nil {
+		t.Errorf("Expected a failed call's tokens to be refunded, got %v", err)
+	}
+}
+
+func TestRateLimiter_ModelLimits(t *testing.T) {
+	limiter, _ := newTestRateLimiter(RateLimit{RequestsPerMinute: 100})
+	limiter.SetModelLimit("GPT-4o", RateLimit{RequestsPerMinute: 1})
+
+	if _, _, err := limiter.wait(cancelledContext(), "gpt-4o-mini", 0); err != nil {
+		t.Fatalf("Expected the first gpt-4o call to proceed, got %v", err)
+	}
+	if _, _, err := limiter.wait(cancelledContext(), "gpt-4o-2024-08-06", 0); err == nil {
+		t.Error("Expected gpt-4o models to share the per-model limit")
+	}
+	if _, _, err := limiter.wait(cancelledContext(), "claude-sonnet-4", 0); err != nil {
+		t.Errorf("Expected other models to use the default limit, got %v", err)
+	}
+}
+
+func TestNewClient_WithRateLimit(t *testing.T) {
+	originalRegistry := registry
+	defer func() { registry = originalRegistry }()
+
+	calls := 0
+	registry = &ProviderRegistry{providers: make(map[string]ProviderFactory)}
+	registry.providers["mock"] = &mockFactory{
+		name: "mock",
+		client: &mockAIClient{generateFunc: func(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+			calls++
+			return &core.AIResponse{Content: "ok", Usage: core.TokenUsage{TotalTokens: 600}}, nil
+		}},
+	}
+
+	client, err := NewClient(WithProvider("mock"), WithRateLimit(0, 600))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	options := &core.AIOptions{MaxTokens: 500}
+	if _, err := client.GenerateResponse(context.Background(), "hi", options); err != nil {
+		t.Fatalf("Expected the first call to proceed, got %v", err)
+	}
+
+	// The first call used the whole minute's tokens, so the next one waits
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = client.GenerateResponse(ctx, "hi", options)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the call to wait until the deadline, got %v", err)
+	}
+	if time.Since(start) < 15*time.Millisecond {
+		t.Error("Expected the call to block rather than fail fast")
+	}
+	if calls != 1 {
+		t.Errorf("Expected the waiting call not to reach the provider, got %d calls", calls)
+	}
+}
+
+func TestNewRateLimitedClient_PreservesStreaming(t *testing.T) {
+	inner := &streamingMockAIClient{name: "stream", supportsStreaming: true, response: "hello"}
+	client := NewRateLimitedClient(inner, NewRateLimiter(RateLimit{RequestsPerMinute: 10}), nil)
+
+	streaming, ok := client.(core.StreamingAIClient)
+	if !ok || !streaming.SupportsStreaming() {
+		t.Fatal("Expected the rate-limited client to keep streaming support")
+	}
+	if _, err := streaming.StreamResponse(context.Background(), "hi", nil, func(core.StreamChunk) error { return nil }); err != nil {
+		t.Errorf("StreamResponse() error = %v", err)
+	}
+	if NewRateLimitedClient(inner, nil, nil) != inner {
+		t.Error("Expected a nil limiter to return the client unchanged")
+	}
+}
@@ -26,6 +26,12 @@ type ProviderFactory interface {
 	Description() string
 }
 
+// ModelLister is implemented by provider factories that can discover the
+// models available in the current environment, such as a local Ollama server
+type ModelLister interface {
+	ListModels() ([]string, error)
+}
+
 // ProviderRegistry manages registered AI providers
 type ProviderRegistry struct {
 	mu        sync.RWMutex
@@ -98,12 +104,16 @@ func GetProviderInfo() []ProviderInfo {
 	info := make([]ProviderInfo, 0, len(registry.providers))
 	for name, factory := range registry.providers {
 		priority, available := factory.DetectEnvironment()
-		info = append(info, ProviderInfo{
+		providerInfo := ProviderInfo{
 			Name:        name,
 			Description: factory.Description(),
 			Available:   available,
 			Priority:    priority,
-		})
+		}
+		if lister, ok := factory.(ModelLister); ok && available {
+			providerInfo.Models, _ = lister.ListModels()
+		}
+		info = append(info, providerInfo)
 	}
 
 	// Sort by priority (highest first), then by name
@@ -123,6 +133,7 @@ type ProviderInfo struct {
 	Description string
 	Available   bool
 	Priority    int
+	Models      []string // Discovered models, for providers implementing ModelLister
 }
 
 // detectBestProvider finds the best available provider from registry
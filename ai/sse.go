@@ -0,0 +1,165 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// AIStreamChunk is one item of a channel-based stream: either a chunk of the
+// response or, as the last item, the error that ended the stream
+type AIStreamChunk struct {
+	core.StreamChunk
+	Err error
+}
+
+// GenerateStream runs client.StreamResponse in the background and delivers
+// its chunks on the returned channel. The channel is closed when the stream
+// ends; if the stream fails, the last item carries Err. Cancelling ctx stops
+// the upstream provider request. Use GenerateStreamWithCancel when the caller
+// may stop reading before ctx is done.
+func GenerateStream(ctx context.Context, client core.StreamingAIClient, prompt string, options *core.AIOptions) <-chan AIStreamChunk {
+	stream, _ := generateStream(ctx, client, prompt, options)
+	return stream
+}
+
+// GenerateStreamWithCancel is GenerateStream with a cancel function for
+// callers that stop reading early. Calling cancel aborts the provider request,
+// which closes its connection, and closes the channel once the background
+// goroutine has returned. Always call cancel, typically with defer.
+func GenerateStreamWithCancel(ctx context.Context, client core.StreamingAIClient, prompt string, options *core.AIOptions) (<-chan AIStreamChunk, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, done := generateStream(ctx, client, prompt, options)
+	return stream, func() {
+		cancel()
+		<-done
+	}
+}
+
+// generateStream starts the producer goroutine; done is closed after it has
+// returned from StreamResponse and closed the stream
+func generateStream(ctx context.Context, client core.StreamingAIClient, prompt string, options *core.AIOptions) (<-chan AIStreamChunk, <-chan struct{}) {
+	stream := make(chan AIStreamChunk)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer close(stream)
+
+		send := func(item AIStreamChunk) error {
+			select {
+			case stream <- item:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		_, err := client.StreamResponse(ctx, prompt, options, func(chunk core.StreamChunk) error {
+			return send(AIStreamChunk{StreamChunk: chunk})
+		})
+		if err != nil && ctx.Err() == nil {
+			_ = send(AIStreamChunk{Err: err})
+		}
+	}()
+
+	return stream, done
+}
+
+// StreamToSSE relays stream to a browser as server-sent events. Each chunk is
+// written as a "data:" line holding the chunk as JSON, and a successful stream
+// ends with "data: [DONE]". An item carrying Err is written as an "error"
+// event and returned. Pass the request context as ctx: when the client
+// disconnects, StreamToSSE returns ctx.Err() and stops reading the stream, so
+// a stream from GenerateStream with the same ctx cancels its upstream request.
+// If StreamToSSE returns for another reason, such as a failed write, the rest
+// of the stream is drained; call the cancel function of a stream from
+// GenerateStreamWithCancel to stop the provider instead.
+func StreamToSSE(ctx context.Context, stream <-chan AIStreamChunk, w http.ResponseWriter) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		go drainStream(stream)
+		return fmt.Errorf("response writer does not support flushing")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable Nginx buffering
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-ctx.Done():
+			go drainStream(stream)
+			return ctx.Err()
+
+		case item, ok := <-stream:
+			if !ok {
+				// GenerateStream also closes the stream when ctx is cancelled
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				if _, err := fmt.Fprint(w, "data: [DONE]\n\n"); err != nil {
+					return err
+				}
+				flusher.Flush()
+				return nil
+			}
+
+			if item.Err != nil {
+				go drainStream(stream)
+				if err := writeSSEError(w, item.Err); err != nil {
+					return err
+				}
+				flusher.Flush()
+				return item.Err
+			}
+
+			data, err := json.Marshal(item.StreamChunk)
+			if err != nil {
+				go drainStream(stream)
+				return fmt.Errorf("failed to encode stream chunk: %w", err)
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				go drainStream(stream)
+				return err
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeSSEError writes err as an "error" event with a machine-readable code
+func writeSSEError(w http.ResponseWriter, err error) error {
+	code := "stream_error"
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		code = "timeout"
+	case errors.Is(err, context.Canceled):
+		code = "canceled"
+	case errors.Is(err, core.ErrStreamPartiallyCompleted):
+		code = "partial_response"
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"error": map[string]string{
+			"code":    code,
+			"message": err.Error(),
+		},
+	})
+	_, writeErr := fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+	return writeErr
+}
+
+// drainStream discards the rest of a stream so a producer that does not watch
+// its context is not left blocked on send
+func drainStream(stream <-chan AIStreamChunk) {
+	for range stream {
+	}
+}
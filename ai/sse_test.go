@@ -0,0 +1,152 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// endlessStreamClient streams chunks until its context is cancelled
+type endlessStreamClient struct {
+	cancelled chan struct{}
+}
+
+func (c *endlessStreamClient) GenerateResponse(ctx context.Context, prompt string, opts *core.AIOptions) (*core.AIResponse, error) {
+	return &core.AIResponse{}, nil
+}
+
+func (c *endlessStreamClient) StreamResponse(ctx context.Context, prompt string, opts *core.AIOptions, callback core.StreamCallback) (*core.AIResponse, error) {
+	for i := 0; ; i++ {
+		if err := callback(core.StreamChunk{Content: "tick", Delta: true, Index: i}); err != nil {
+			close(c.cancelled)
+			return nil, err
+		}
+	}
+}
+
+func (c *endlessStreamClient) SupportsStreaming() bool { return true }
+
+func sseDataLines(body string) []string {
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			lines = append(lines, data)
+		}
+	}
+	return lines
+}
+
+func TestStreamToSSE(t *testing.T) {
+	t.Run("writes chunks and DONE terminator", func(t *testing.T) {
+		client := &streamingMockAIClient{name: "mock", supportsStreaming: true, response: "Hello from the stream"}
+		rec := httptest.NewRecorder()
+
+		stream := GenerateStream(context.Background(), client, "hi", nil)
+		if err := StreamToSSE(context.Background(), stream, rec); err != nil {
+			t.Fatalf("StreamToSSE failed: %v", err)
+		}
+
+		if ct := rec.Header().Get("Content-Type"); ct != "text/event-stream" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		if !rec.Flushed {
+			t.Error("Expected response to be flushed")
+		}
+
+		lines := sseDataLines(rec.Body.String())
+		if len(lines) < 2 || lines[len(lines)-1] != "[DONE]" {
+			t.Fatalf("Expected data lines ending in [DONE], got %q", rec.Body.String())
+		}
+
+		var content strings.Builder
+		for _, line := range lines[:len(lines)-1] {
+			var chunk core.StreamChunk
+			if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+				t.Fatalf("Chunk is not JSON: %s", line)
+			}
+			content.WriteString(chunk.Content)
+		}
+		if content.String() != "Hello from the stream" {
+			t.Errorf("Relayed content = %q", content.String())
+		}
+	})
+
+	t.Run("writes error event", func(t *testing.T) {
+		upstreamErr := errors.New("rate limited")
+		client := &streamingMockAIClient{name: "mock", supportsStreaming: true, shouldFail: true, failWith: upstreamErr}
+		rec := httptest.NewRecorder()
+
+		err := StreamToSSE(context.Background(), GenerateStream(context.Background(), client, "hi", nil), rec)
+		if !errors.Is(err, upstreamErr) {
+			t.Errorf("Expected upstream error, got %v", err)
+		}
+
+		body := rec.Body.String()
+		if !strings.Contains(body, "event: error\n") || strings.Contains(body, "[DONE]") {
+			t.Fatalf("Expected error event without [DONE], got %q", body)
+		}
+		var event struct {
+			Error struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		lines := sseDataLines(body)
+		if err := json.Unmarshal([]byte(lines[len(lines)-1]), &event); err != nil {
+			t.Fatal(err)
+		}
+		if event.Error.Code != "stream_error" || event.Error.Message != "rate limited" {
+			t.Errorf("Unexpected error event: %+v", event)
+		}
+	})
+
+	t.Run("client disconnect cancels upstream", func(t *testing.T) {
+		client := &endlessStreamClient{cancelled: make(chan struct{})}
+		ctx, cancel := context.WithCancel(context.Background())
+		rec := httptest.NewRecorder()
+
+		stream := GenerateStream(ctx, client, "hi", nil)
+		done := make(chan error, 1)
+		go func() { done <- StreamToSSE(ctx, stream, rec) }()
+
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+
+		select {
+		case err := <-done:
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected context.Canceled, got %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("StreamToSSE did not return after disconnect")
+		}
+		select {
+		case <-client.cancelled:
+		case <-time.After(time.Second):
+			t.Fatal("Upstream stream was not cancelled")
+		}
+	})
+
+	t.Run("rejects writers without flush support", func(t *testing.T) {
+		stream := make(chan AIStreamChunk)
+		close(stream)
+		if err := StreamToSSE(context.Background(), stream, nonFlushingWriter{httptest.NewRecorder()}); err == nil {
+			t.Error("Expected error for non-flushing writer")
+		}
+	})
+}
+
+type nonFlushingWriter struct {
+	rec *httptest.ResponseRecorder
+}
+
+func (w nonFlushingWriter) Header() http.Header         { return w.rec.Header() }
+func (w nonFlushingWriter) Write(b []byte) (int, error) { return w.rec.Write(b) }
+func (w nonFlushingWriter) WriteHeader(code int)        { w.rec.WriteHeader(code) }
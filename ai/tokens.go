@@ -0,0 +1,240 @@
+package ai
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token estimates for prompt budgeting.
+//
+// Callers building large prompts can estimate their size before sending and
+// trim context proactively:
+//
+//	n, _ := ai.EstimateTokensForModel("gpt-4.1-mini", prompt)
+//	if n > budget {
+//	    prompt = trimContext(prompt, budget)
+//	}
+//
+// The built-in counts are estimates, not exact tokenizer output, and can
+// be off in either direction; leave headroom when budgeting. Accuracy per
+// provider:
+//   - OpenAI (gpt-*, o1/o3/o4, chatgpt-*): a word-piece heuristic modelled on
+//     tiktoken's cl100k/o200k pre-tokenizer. Typically within ~10% for English
+//     prose; less accurate for code and non-Latin scripts. Register a real
+//     tiktoken implementation via RegisterTokenizer for exact counts.
+//   - Anthropic (claude-*): Anthropic does not publish its tokenizer. Counts are
+//     a character-based estimate (~3.5 characters per token).
+//   - Gemini (gemini-*): character-based estimate (~4 characters per token).
+//   - Everything else: character-based fallback (~4 characters per token).
+//
+// The framework ships no BPE vocabularies to keep the dependency footprint
+// minimal. Applications that need exact counts can plug in their own, which
+// the estimate functions then use for matching models:
+//
+//	ai.RegisterTokenizer("gpt-4o", ai.TokenizerFunc(func(text string) (int, error) {
+//	    return len(enc.Encode(text, nil, nil)), nil
+//	}))
+
+// Tokenizer counts the tokens a model would see for a piece of text
+type Tokenizer interface {
+	CountTokens(text string) (int, error)
+}
+
+// TokenizerFunc adapts an ordinary function to the Tokenizer interface
+type TokenizerFunc func(text string) (int, error)
+
+// CountTokens calls f(text)
+func (f TokenizerFunc) CountTokens(text string) (int, error) {
+	return f(text)
+}
+
+// Chat formats add a fixed overhead per message (role markers, separators)
+// plus a few tokens priming the assistant reply. These match OpenAI's
+// published ChatML accounting and are a reasonable approximation elsewhere.
+const (
+	tokensPerMessage = 3
+	tokensPerReply   = 3
+)
+
+// tokenizerRegistry maps model-name prefixes to tokenizers. The longest
+// matching prefix wins so "gpt-4o" can override a generic "gpt-" entry.
+type tokenizerRegistry struct {
+	mu       sync.RWMutex
+	prefixes []string
+	byPrefix map[string]Tokenizer
+}
+
+var tokenizers = newTokenizerRegistry()
+
+func newTokenizerRegistry() *tokenizerRegistry {
+	r := &tokenizerRegistry{byPrefix: make(map[string]Tokenizer)}
+
+	openai := TokenizerFunc(func(text string) (int, error) { return estimateWordPieceTokens(text), nil })
+	for _, prefix := range []string{"gpt-", "o1", "o3", "o4", "chatgpt-", "text-embedding-"} {
+		r.register(prefix, openai)
+	}
+	r.register("claude", charRatioTokenizer(3.5))
+	r.register("anthropic.claude", charRatioTokenizer(3.5)) // Bedrock model IDs
+	r.register("gemini", charRatioTokenizer(4))
+	return r
+}
+
+func (r *tokenizerRegistry) register(prefix string, t Tokenizer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.byPrefix[prefix]; !exists {
+		r.prefixes = append(r.prefixes, prefix)
+		sort.Slice(r.prefixes, func(i, j int) bool {
+			return len(r.prefixes[i]) > len(r.prefixes[j])
+		})
+	}
+	r.byPrefix[prefix] = t
+}
+
+func (r *tokenizerRegistry) lookup(model string) Tokenizer {
+	model = strings.ToLower(model)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, prefix := range r.prefixes {
+		if strings.HasPrefix(model, prefix) {
+			return r.byPrefix[prefix]
+		}
+	}
+	return nil
+}
+
+// RegisterTokenizer registers a tokenizer for all models whose name starts
+// with modelPrefix (case-insensitive). Registering an existing prefix replaces
+// the previous tokenizer, so built-in estimators can be swapped for exact ones.
+func RegisterTokenizer(modelPrefix string, tokenizer Tokenizer) error {
+	if modelPrefix == "" {
+		return fmt.Errorf("model prefix cannot be empty")
+	}
+	if tokenizer == nil {
+		return fmt.Errorf("tokenizer cannot be nil")
+	}
+	tokenizers.register(strings.ToLower(modelPrefix), tokenizer)
+	return nil
+}
+
+// EstimateTokensForModel estimates the number of tokens text occupies for
+// the given model. The count is exact only when an exact tokenizer has been
+// registered for the model with RegisterTokenizer; the built-in ones are
+// heuristics. Falls back to EstimateTokens when no tokenizer matches.
+func EstimateTokensForModel(model, text string) (int, error) {
+	tokenizer := tokenizers.lookup(model)
+	if tokenizer == nil {
+		return EstimateTokens(text), nil
+	}
+	n, err := tokenizer.CountTokens(text)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count tokens for model %s: %w", model, err)
+	}
+	return n, nil
+}
+
+// EstimateMessageTokens estimates the number of prompt tokens a
+// conversation occupies for the given model, including per-message chat
+// formatting overhead. See EstimateTokensForModel for accuracy.
+func EstimateMessageTokens(model string, messages []Message) (int, error) {
+	total := 0
+	for _, msg := range messages {
+		roleTokens, err := EstimateTokensForModel(model, msg.Role)
+		if err != nil {
+			return 0, err
+		}
+		contentTokens, err := EstimateTokensForModel(model, msg.Content)
+		if err != nil {
+			return 0, err
+		}
+		total += tokensPerMessage + roleTokens + contentTokens
+	}
+	if len(messages) > 0 {
+		total += tokensPerReply
+	}
+	return total, nil
+}
+
+// EstimateTokens is the character-based fallback: roughly 4 characters per token.
+func EstimateTokens(text string) int {
+	return estimateByCharRatio(text, 4)
+}
+
+func charRatioTokenizer(charsPerToken float64) Tokenizer {
+	return TokenizerFunc(func(text string) (int, error) {
+		return estimateByCharRatio(text, charsPerToken), nil
+	})
+}
+
+func estimateByCharRatio(text string, charsPerToken float64) int {
+	chars := utf8.RuneCountInString(text)
+	if chars == 0 {
+		return 0
+	}
+	n := int(float64(chars)/charsPerToken + 0.999)
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// estimateWordPieceTokens approximates BPE tokenization, without a
+// vocabulary, from the way tiktoken's pre-tokenizer splits text: words (with their leading space) are usually one
+// token, long words split into ~4 character pieces, numbers group up to three
+// digits, and each punctuation mark or non-Latin rune is its own token.
+func estimateWordPieceTokens(text string) int {
+	tokens := 0
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '\n':
+			// Consecutive newlines merge into a single token
+			for i < len(runes) && runes[i] == '\n' {
+				i++
+			}
+			tokens++
+		case unicode.IsSpace(r):
+			// Single spaces attach to the following word; runs of spaces are tokens
+			start := i
+			for i < len(runes) && unicode.IsSpace(runes[i]) && runes[i] != '\n' {
+				i++
+			}
+			if i-start > 1 {
+				tokens++
+			}
+		case r < utf8.RuneSelf && unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && runes[i] < utf8.RuneSelf && unicode.IsLetter(runes[i]) {
+				i++
+			}
+			tokens += wordPieces(i - start)
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			tokens += (i - start + 2) / 3
+		default:
+			// Punctuation, symbols and non-Latin scripts: roughly one token per rune
+			i++
+			tokens++
+		}
+	}
+	return tokens
+}
+
+// wordPieces estimates how many BPE pieces an ASCII word of length n splits into
+func wordPieces(n int) int {
+	if n <= 6 {
+		return 1
+	}
+	return (n + 3) / 4
+}
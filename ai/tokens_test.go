@@ -0,0 +1,111 @@
+package ai
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEstimateTokensForModel_Fallback(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"a", 1},
+		{"abcd", 1},
+		{"abcde", 2},
+		{strings.Repeat("x", 400), 100},
+	}
+	for _, tt := range tests {
+		got, err := EstimateTokensForModel("unknown-model", tt.text)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("EstimateTokensForModel(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestEstimateTokensForModel_OpenAIHeuristic(t *testing.T) {
+	// tiktoken cl100k counts this sentence as 10 tokens
+	got, err := EstimateTokensForModel("gpt-4.1-mini", "The quick brown fox jumps over the lazy dog.")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got < 9 || got > 11 {
+		t.Errorf("Expected ~10 tokens, got %d", got)
+	}
+
+	// Numbers group in threes
+	got, _ = EstimateTokensForModel("gpt-4o", "123456789")
+	if got != 3 {
+		t.Errorf("Expected 3 tokens for 9 digits, got %d", got)
+	}
+}
+
+func TestEstimateTokensForModel_ProviderFamilies(t *testing.T) {
+	text := strings.Repeat("y", 35)
+
+	claude, _ := EstimateTokensForModel("claude-sonnet-4-5", text)
+	if claude != 10 {
+		t.Errorf("Expected 10 tokens for claude, got %d", claude)
+	}
+	gemini, _ := EstimateTokensForModel("gemini-2.5-flash", text)
+	if gemini != 9 {
+		t.Errorf("Expected 9 tokens for gemini, got %d", gemini)
+	}
+}
+
+func TestRegisterTokenizer(t *testing.T) {
+	if err := RegisterTokenizer("", TokenizerFunc(func(string) (int, error) { return 0, nil })); err == nil {
+		t.Error("Expected error for empty prefix")
+	}
+	if err := RegisterTokenizer("test-model", nil); err == nil {
+		t.Error("Expected error for nil tokenizer")
+	}
+
+	err := RegisterTokenizer("Test-Exact", TokenizerFunc(func(text string) (int, error) {
+		return len(strings.Fields(text)), nil
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got, _ := EstimateTokensForModel("test-exact-v1", "one two three")
+	if got != 3 {
+		t.Errorf("Expected registered tokenizer to be used, got %d", got)
+	}
+
+	// Longest prefix wins
+	_ = RegisterTokenizer("test-exact-v2", TokenizerFunc(func(string) (int, error) { return 42, nil }))
+	got, _ = EstimateTokensForModel("test-exact-v2-large", "one two three")
+	if got != 42 {
+		t.Errorf("Expected longest prefix tokenizer, got %d", got)
+	}
+
+	_ = RegisterTokenizer("test-failing", TokenizerFunc(func(string) (int, error) { return 0, errors.New("boom") }))
+	if _, err := EstimateTokensForModel("test-failing", "text"); err == nil {
+		t.Error("Expected tokenizer error to propagate")
+	}
+}
+
+func TestEstimateMessageTokens(t *testing.T) {
+	messages := []Message{
+		{Role: "system", Content: strings.Repeat("a", 40)},
+		{Role: "user", Content: strings.Repeat("b", 80)},
+	}
+	got, err := EstimateMessageTokens("unknown-model", messages)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// system(2) + 10 + 3, user(1) + 20 + 3, reply priming 3
+	if got != 42 {
+		t.Errorf("Expected 42 tokens, got %d", got)
+	}
+
+	empty, _ := EstimateMessageTokens("gpt-4o", nil)
+	if empty != 0 {
+		t.Errorf("Expected 0 tokens for no messages, got %d", empty)
+	}
+}
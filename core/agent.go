@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
 	"reflect"
 	"runtime/debug"
 	"sync"
@@ -75,6 +76,19 @@ type Capability struct {
 	// the service catalog used for AI orchestration decisions.
 	// Use cases: orchestration endpoints, admin endpoints, deprecated capabilities.
 	Internal bool `json:"internal,omitempty"`
+
+	// Execute is an optional typed implementation used when Handler is nil.
+	// Its result is wrapped in a ToolResponse envelope and ResultEnvelope is set.
+	Execute CapabilityFunc `json:"-"`
+
+	// ResultEnvelope advertises that responses are ToolResponse envelopes, so
+	// orchestrators can unwrap data, success, error and metadata reliably.
+	// Opt-in: capabilities without it return raw responses as before.
+	ResultEnvelope bool `json:"result_envelope,omitempty"`
+
+	// RateLimit overrides the component's HTTP.RateLimit for this
+	// capability. A zero RPS exempts the capability from rate limiting.
+	RateLimit *RateLimit `json:"rate_limit,omitempty"`
 }
 
 // BaseAgent provides the core agent functionality
@@ -105,6 +119,15 @@ type BaseAgent struct {
 	registeredPatterns map[string]bool // Track registered patterns to prevent duplicates
 	serverStarted      bool            // Track if server has started
 	mu                 sync.RWMutex    // Protect concurrent access
+
+	// Makes Deregister idempotent across Stop and the shutdown hook
+	deregistration deregistration
+
+	// Lifecycle state reported by /health/ready
+	readiness readiness
+
+	// Per-caller token buckets for capability rate limits
+	rateLimiter rateLimiter
 }
 
 // NewBaseAgent creates a new base agent with minimal dependencies
@@ -226,6 +249,7 @@ func (b *BaseAgent) Initialize(ctx context.Context) error {
 
 							// Update to new discovery
 							b.Discovery = newRegistry.(Discovery)
+							b.readiness.registered.Store(true)
 							b.Logger.Info("Discovery reference updated", map[string]interface{}{
 								"agent_id": b.ID,
 							})
@@ -257,6 +281,10 @@ func (b *BaseAgent) Initialize(ctx context.Context) error {
 		} else {
 			b.Memory = NewInMemoryStore()
 		}
+
+		if b.Config.Memory.Namespaced && b.Name != "" {
+			b.Memory = NewNamespacedMemory(b.Memory, b.Name)
+		}
 	}
 
 	if b.Discovery != nil {
@@ -288,6 +316,12 @@ func (b *BaseAgent) Initialize(ctx context.Context) error {
 			Metadata:     BuildServiceMetadata(b.Config),
 		}
 
+		if redisDiscovery, ok := b.Discovery.(*RedisDiscovery); ok && b.Config != nil {
+			redisDiscovery.SetHeartbeatJitter(b.Config.Discovery.HeartbeatJitter)
+			redisDiscovery.SetRegistrationStagger(b.Config.Discovery.RegistrationStagger)
+			redisDiscovery.StaggerRegistration(ctx)
+		}
+
 		if err := b.Discovery.Register(ctx, registration); err != nil {
 			b.Logger.Error("Failed to register with discovery", map[string]interface{}{
 				"error":      err,
@@ -297,8 +331,13 @@ func (b *BaseAgent) Initialize(ctx context.Context) error {
 			})
 			// Continue anyway - graceful degradation
 		} else {
+			b.readiness.registered.Store(true)
+
 			// Start heartbeat to keep registration alive (Redis-specific)
 			if redisDiscovery, ok := b.Discovery.(*RedisDiscovery); ok {
+				if b.Config != nil {
+					redisDiscovery.SetRegistrationCheckInterval(b.Config.Discovery.RegistrationCheckInterval)
+				}
 				redisDiscovery.StartHeartbeat(ctx, b.ID)
 				b.Logger.Info("Started heartbeat for agent registration", map[string]interface{}{
 					"agent_id":     b.ID,
@@ -306,6 +345,13 @@ func (b *BaseAgent) Initialize(ctx context.Context) error {
 					"interval_sec": int(redisDiscovery.ttl.Seconds() / 2),
 					"ttl_sec":      int(redisDiscovery.ttl.Seconds()),
 				})
+			} else if composite, ok := b.Discovery.(*CompositeDiscovery); ok {
+				composite.StartHeartbeat(ctx, b.ID)
+				b.Logger.Info("Started heartbeat for agent registration", map[string]interface{}{
+					"agent_id":   b.ID,
+					"agent_name": b.Name,
+					"backends":   len(composite.Backends()),
+				})
 			}
 		}
 	} else {
@@ -332,6 +378,14 @@ func (b *BaseAgent) Initialize(ctx context.Context) error {
 		)
 	}
 
+	// Record direct AI calls when a debug recorder is configured
+	if b.AI != nil && b.Config != nil && b.Config.AI.DebugRecorder != nil {
+		b.AI = NewRecordingAIClient(b.AI, b.Config.AI.DebugRecorder, b.Logger)
+	}
+
+	// Ready once registered, when discovery is in use
+	b.readiness.markInitialized(b.Discovery != nil || (b.Config != nil && b.Config.Discovery.Enabled))
+
 	b.Logger.Info("Agent initialization completed", map[string]interface{}{
 		"id":                 b.ID,
 		"name":               b.Name,
@@ -457,17 +511,28 @@ func (b *BaseAgent) RegisterCapability(cap Capability) {
 		})
 	}
 
+	// Typed capabilities always respond with the result envelope
+	if cap.Handler == nil && cap.Execute != nil {
+		cap.ResultEnvelope = true
+	}
+
 	// Append to capabilities list
 	b.Capabilities = append(b.Capabilities, cap)
 
 	// Register HTTP endpoint for the capability
+	var handler http.HandlerFunc
 	if cap.Handler != nil {
-		// Use custom handler if provided (no automatic telemetry/logging)
-		b.mux.HandleFunc(endpoint, cap.Handler)
+		// Use custom handler if provided (no automatic logging)
+		handler = cap.Handler
+	} else if cap.Execute != nil {
+		// Typed handler: wrap the returned data in a ToolResponse envelope
+		handler = newResultHandler(cap, b.Logger)
 	} else {
-		// Use generic handler with telemetry and logging
-		b.mux.HandleFunc(endpoint, b.handleCapabilityRequest(cap))
+		// Use generic handler with logging
+		handler = b.handleCapabilityRequest(cap)
 	}
+	b.mux.HandleFunc(endpoint, instrumentCapability(cap, "agent", func() Telemetry { return b.Telemetry },
+		rateLimitHandler(&b.rateLimiter, cap, func() *Config { return b.Config }, handler)))
 
 	// Track this pattern internally
 	b.registeredPatterns[endpoint] = true
@@ -480,22 +545,24 @@ func (b *BaseAgent) RegisterCapability(cap Capability) {
 	})
 }
 
+// ContextLogger returns the agent's logger bound to ctx, so every line it
+// writes carries the trace_id and span_id of the active request span.
+// Use it inside capability handlers with r.Context() or the Execute context.
+func (b *BaseAgent) ContextLogger(ctx context.Context) Logger {
+	return LoggerWithContext(b.Logger, ctx)
+}
+
 // handleCapabilityRequest creates an HTTP handler for a capability
 func (b *BaseAgent) handleCapabilityRequest(cap Capability) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		capStart := time.Now()
 
-		// Start telemetry span if available
-		if b.Telemetry != nil {
-			var span Span
-			_, span = b.Telemetry.StartSpan(ctx, fmt.Sprintf("capability.%s", cap.Name))
-			defer span.End()
-			span.SetAttribute("capability.name", cap.Name)
-		}
+		// The capability span is started by instrumentCapability
+		logger := b.ContextLogger(ctx)
 
 		// Log request
-		b.Logger.Info("Handling capability request", map[string]interface{}{
+		logger.Info("Handling capability request", map[string]interface{}{
 			"capability": cap.Name,
 			"method":     r.Method,
 		})
@@ -518,13 +585,13 @@ func (b *BaseAgent) handleCapabilityRequest(cap Capability) http.HandlerFunc {
 				)
 			}
 
-			b.Logger.Error("Failed to parse request", map[string]interface{}{
+			logger.Error("Failed to parse request", map[string]interface{}{
 				"error":      err,
 				"error_type": fmt.Sprintf("%T", err),
 				"path":       r.URL.Path,
 				"method":     r.Method,
 			})
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			WriteError(w, r, NewAPIError(CodeInvalidInput, "invalid JSON request body: %v", err))
 			return
 		}
 
@@ -556,7 +623,7 @@ func (b *BaseAgent) handleCapabilityRequest(cap Capability) http.HandlerFunc {
 		if err := json.NewEncoder(w).Encode(response); err != nil {
 			// Log error but response is already partially written
 			if b.Logger != nil {
-				b.Logger.Error("Failed to encode response", map[string]interface{}{
+				logger.Error("Failed to encode response", map[string]interface{}{
 					"error":              err,
 					"error_type":         fmt.Sprintf("%T", err),
 					"agent_id":           b.ID,
@@ -579,7 +646,7 @@ func (b *BaseAgent) handleSchemaRequest(cap Capability) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Only support GET requests for schemas
 		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			WriteError(w, r, &APIError{Code: "METHOD_NOT_ALLOWED", Message: "schema endpoints only support GET", HTTPStatus: http.StatusMethodNotAllowed})
 			return
 		}
 
@@ -701,63 +768,7 @@ func (b *BaseAgent) Start(ctx context.Context, port int) error {
 		"registered_endpoints": len(b.registeredPatterns),
 	})
 
-	// Add health endpoint if enabled
-	if b.Config.HTTP.EnableHealthCheck {
-		healthPath := b.Config.HTTP.HealthCheckPath
-		// Check if health path is already registered (shouldn't be, but be safe)
-		if !b.registeredPatterns[healthPath] {
-			b.mux.HandleFunc(healthPath, func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusOK)
-				if err := json.NewEncoder(w).Encode(map[string]string{
-					"status": "healthy",
-					"agent":  b.Name,
-					"id":     b.ID,
-				}); err != nil {
-					// Log error but response is already partially written
-					if b.Logger != nil {
-						b.Logger.Error("Failed to encode health response", map[string]interface{}{
-							"error":              err,
-							"error_type":         fmt.Sprintf("%T", err),
-							"agent_id":           b.ID,
-							"request_method":     r.Method,
-							"request_path":       r.URL.Path,
-							"request_remote":     r.RemoteAddr,
-							"capabilities_count": len(b.Capabilities),
-							"user_agent":         r.Header.Get("User-Agent"),
-							"content_length":     r.ContentLength,
-						})
-					}
-				}
-			})
-			b.registeredPatterns[healthPath] = true
-		}
-	}
-
-	// Add capabilities listing endpoint
-	capabilitiesPath := "/api/capabilities"
-	if !b.registeredPatterns[capabilitiesPath] {
-		b.mux.HandleFunc(capabilitiesPath, func(w http.ResponseWriter, r *http.Request) {
-			ApplyCORS(w, r, &b.Config.HTTP.CORS)
-			w.Header().Set("Content-Type", "application/json")
-			if err := json.NewEncoder(w).Encode(b.Capabilities); err != nil {
-				// Log error but response is already partially written
-				if b.Logger != nil {
-					b.Logger.Error("Failed to encode capabilities", map[string]interface{}{
-						"error":              err,
-						"error_type":         fmt.Sprintf("%T", err),
-						"agent_id":           b.ID,
-						"request_method":     r.Method,
-						"request_path":       r.URL.Path,
-						"request_remote":     r.RemoteAddr,
-						"capabilities_count": len(b.Capabilities),
-						"user_agent":         r.Header.Get("User-Agent"),
-						"content_length":     r.ContentLength,
-					})
-				}
-			}
-		})
-		b.registeredPatterns[capabilitiesPath] = true
-	}
+	b.setupStandardEndpoints()
 
 	if len(b.registeredPatterns) > 0 {
 		endpoints := make([]string, 0, len(b.registeredPatterns))
@@ -771,28 +782,7 @@ func (b *BaseAgent) Start(ctx context.Context, port int) error {
 		})
 	}
 
-	// Create handler with middleware stack
-	// Order (outermost to innermost): CORS -> User Middleware -> Logging -> Recovery -> Handler
-	// User middleware (e.g., TracingMiddleware) is placed after CORS to avoid tracing preflight requests,
-	// and before logging so traces can capture the full request lifecycle.
-	var handler http.Handler = b.mux
-
-	// Always wrap with panic recovery middleware (innermost - catches panics from handler)
-	handler = RecoveryMiddleware(b.Logger)(handler)
-
-	// Add request/response logging middleware
-	handler = LoggingMiddleware(b.Logger, b.Config.Development.Enabled)(handler)
-
-	// Apply user-provided middleware (e.g., telemetry.TracingMiddleware)
-	// These are applied in reverse order so the first middleware in the slice is outermost
-	for i := len(b.Config.HTTP.Middleware) - 1; i >= 0; i-- {
-		handler = b.Config.HTTP.Middleware[i](handler)
-	}
-
-	// Add CORS middleware if enabled (outermost - handles preflight requests)
-	if b.Config.HTTP.CORS.Enabled {
-		handler = CORSMiddleware(&b.Config.HTTP.CORS)(handler)
-	}
+	handler := b.buildHandler()
 
 	b.server = &http.Server{
 		Addr:              addr,
@@ -838,9 +828,140 @@ func (b *BaseAgent) Start(ctx context.Context, port int) error {
 	return nil
 }
 
+// setupStandardEndpoints registers the health and capability listing
+// endpoints. Callers must hold b.mu.
+func (b *BaseAgent) setupStandardEndpoints() {
+	// Add liveness and readiness endpoints if enabled. The configured
+	// health path stays an alias for liveness.
+	if b.Config.HTTP.EnableHealthCheck {
+		for _, path := range []string{b.Config.HTTP.HealthCheckPath, LivenessPath} {
+			if path != "" && !b.registeredPatterns[path] {
+				b.mux.HandleFunc(path, b.handleLiveness)
+				b.registeredPatterns[path] = true
+			}
+		}
+		if !b.registeredPatterns[ReadinessPath] {
+			b.mux.HandleFunc(ReadinessPath, readinessHandler(&b.readiness, func() *Config { return b.Config }, b.Logger))
+			b.registeredPatterns[ReadinessPath] = true
+		}
+	}
+
+	// Add capabilities listing endpoint
+	capabilitiesPath := "/api/capabilities"
+	if !b.registeredPatterns[capabilitiesPath] {
+		b.mux.HandleFunc(capabilitiesPath, func(w http.ResponseWriter, r *http.Request) {
+			ApplyCORS(w, r, &b.Config.HTTP.CORS)
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(b.Capabilities); err != nil {
+				// Log error but response is already partially written
+				if b.Logger != nil {
+					b.Logger.Error("Failed to encode capabilities", map[string]interface{}{
+						"error":              err,
+						"error_type":         fmt.Sprintf("%T", err),
+						"agent_id":           b.ID,
+						"request_method":     r.Method,
+						"request_path":       r.URL.Path,
+						"request_remote":     r.RemoteAddr,
+						"capabilities_count": len(b.Capabilities),
+						"user_agent":         r.Header.Get("User-Agent"),
+						"content_length":     r.ContentLength,
+					})
+				}
+			}
+		})
+		b.registeredPatterns[capabilitiesPath] = true
+	}
+
+	// Add OpenAPI catalog of the registered capabilities
+	if !b.registeredPatterns[OpenAPIPath] {
+		b.mux.HandleFunc(OpenAPIPath, openAPIHandler(b.Name, b.GetCapabilities, b.Logger))
+		b.registeredPatterns[OpenAPIPath] = true
+	}
+
+	// Add Prometheus metrics endpoint if configured
+	if b.Config.Telemetry.PrometheusPath != "" && !b.registeredPatterns[b.Config.Telemetry.PrometheusPath] {
+		b.mux.HandleFunc(b.Config.Telemetry.PrometheusPath, metricsHandler)
+		b.registeredPatterns[b.Config.Telemetry.PrometheusPath] = true
+	}
+}
+
+// handleLiveness reports that the agent process is alive
+func (b *BaseAgent) handleLiveness(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]string{
+		"status": "healthy",
+		"agent":  b.Name,
+		"id":     b.ID,
+	}); err != nil {
+		// Log error but response is already partially written
+		if b.Logger != nil {
+			b.Logger.Error("Failed to encode health response", map[string]interface{}{
+				"error":              err,
+				"error_type":         fmt.Sprintf("%T", err),
+				"agent_id":           b.ID,
+				"request_method":     r.Method,
+				"request_path":       r.URL.Path,
+				"request_remote":     r.RemoteAddr,
+				"capabilities_count": len(b.Capabilities),
+				"user_agent":         r.Header.Get("User-Agent"),
+				"content_length":     r.ContentLength,
+			})
+		}
+	}
+}
+
+// buildHandler wraps the mux with the standard middleware stack
+func (b *BaseAgent) buildHandler() http.Handler {
+	// Create handler with middleware stack
+	// Order (outermost to innermost): Request ID -> CORS -> User Middleware -> Request Metadata -> Logging -> Recovery -> Handler
+	// User middleware (e.g., TracingMiddleware) is placed after CORS to avoid tracing preflight requests,
+	// and before logging so traces can capture the full request lifecycle.
+	var handler http.Handler = b.mux
+
+	// Wrap with panic recovery middleware (innermost - catches panics from handler)
+	// unless disabled for debugging
+	if !b.Config.HTTP.DisablePanicRecovery {
+		handler = RecoveryMiddleware(b.Logger)(handler)
+	}
+
+	// Add request/response logging middleware
+	handler = LoggingMiddleware(b.Logger, b.Config.Development.Enabled)(handler)
+
+	// Restore request metadata sent by the calling component
+	handler = RequestMetadataMiddleware(b.Config.HTTP.TrustedMetadataKeys...)(handler)
+
+	// Apply user-provided middleware (e.g., telemetry.TracingMiddleware)
+	// These are applied in reverse order so the first middleware in the slice is outermost
+	for i := len(b.Config.HTTP.Middleware) - 1; i >= 0; i-- {
+		handler = b.Config.HTTP.Middleware[i](handler)
+	}
+
+	// Add CORS middleware if enabled (handles preflight requests)
+	if b.Config.HTTP.CORS.Enabled {
+		handler = CORSMiddleware(&b.Config.HTTP.CORS)(handler)
+	}
+
+	// Assign the request ID first so every layer can log it
+	handler = RequestIDMiddleware()(handler)
+
+	return handler
+}
+
+// Handler returns the agent's HTTP handler with standard endpoints and
+// middleware, as served by Start. It lets tests serve the agent with
+// httptest.Server without binding a port.
+func (b *BaseAgent) Handler() http.Handler {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.setupStandardEndpoints()
+	return b.buildHandler()
+}
+
 // Stop stops the HTTP server
 func (b *BaseAgent) Stop(ctx context.Context) error {
 	shutdownStart := time.Now()
+	b.readiness.draining.Store(true)
 
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -854,16 +975,9 @@ func (b *BaseAgent) Stop(ctx context.Context) error {
 			defer cancel()
 		}
 
-		// Unregister from discovery if available
+		// Unregister from discovery if available (logged by Deregister)
 		if b.Discovery != nil && b.Config.Discovery.Enabled {
-			if err := b.Discovery.Unregister(shutdownCtx, b.ID); err != nil {
-				b.Logger.Error("Failed to unregister from discovery", map[string]interface{}{
-					"error":      err,                    // Preserve full error object
-					"error_type": fmt.Sprintf("%T", err), // Log error type for debugging
-					"agent_id":   b.ID,
-					"operation":  "unregister",
-				})
-			}
+			_ = b.Deregister(shutdownCtx)
 		}
 
 		// Reset server state
@@ -904,7 +1018,16 @@ func (b *BaseAgent) Stop(ctx context.Context) error {
 	return nil
 }
 
-// RecoveryMiddleware creates a middleware that recovers from panics in HTTP handlers
+// PanicDetail is the ToolError.Details key set on the 500 envelope written
+// for a recovered handler panic, so callers can tell a crash from an
+// ordinary internal error.
+const PanicDetail = "panic"
+
+// RecoveryMiddleware creates a middleware that recovers from panics in HTTP handlers.
+// The client receives a 500 ToolResponse envelope carrying the trace and request IDs,
+// with Details[PanicDetail] set to "true".
+// The panic is logged with its stack and both IDs, and counted as the
+// http.handler.panics metric against the request's trace.
 func RecoveryMiddleware(logger Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -912,8 +1035,9 @@ func RecoveryMiddleware(logger Logger) func(http.Handler) http.Handler {
 				if err := recover(); err != nil {
 					// Log the panic with stack trace using structured logging
 					stackTrace := debug.Stack()
+					traceID, requestID := requestCorrelation(r)
 					if logger != nil {
-						logger.ErrorWithContext(r.Context(), "HTTP handler panic recovered", map[string]interface{}{
+						fields := map[string]interface{}{
 							"panic":      err,
 							"error_type": fmt.Sprintf("%T", err),
 							"path":       r.URL.Path,
@@ -921,11 +1045,37 @@ func RecoveryMiddleware(logger Logger) func(http.Handler) http.Handler {
 							"stack":      string(stackTrace),
 							"user_agent": r.UserAgent(),
 							"remote_ip":  r.RemoteAddr,
-						})
+						}
+						if traceID != "" {
+							fields["trace_id"] = traceID
+						}
+						if requestID != "" {
+							fields["request_id"] = requestID
+						}
+						logger.ErrorWithContext(r.Context(), "HTTP handler panic recovered", fields)
+					}
+
+					// Record the panic against the request's trace
+					if registry := GetGlobalMetricsRegistry(); registry != nil {
+						registry.EmitWithContext(r.Context(), "http.handler.panics", 1,
+							"method", r.Method,
+							"path", r.URL.Path,
+						)
 					}
 
-					// Return Internal Server Error to client
-					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+					// Return Internal Server Error to client, with the IDs needed to
+					// find this request in traces
+					response := &ToolResponse{
+						Success: false,
+						Error: &ToolError{
+							Code:     "INTERNAL_ERROR",
+							Message:  "Internal Server Error",
+							Category: CategoryServiceError,
+							Details:  map[string]string{PanicDetail: "true"},
+						},
+					}
+					withCorrelation(w, r, response)
+					writeToolResponse(w, http.StatusInternalServerError, response)
 				}
 			}()
 			next.ServeHTTP(w, r)
@@ -937,6 +1087,10 @@ func RecoveryMiddleware(logger Logger) func(http.Handler) http.Handler {
 type Framework struct {
 	component HTTPComponent
 	config    *Config
+
+	// Config reload state (see Reload)
+	reloadMu    sync.Mutex
+	reloadHooks []ConfigReloadHook
 }
 
 // applyConfigToComponent applies configuration to a component.
@@ -1080,6 +1234,7 @@ func NewFramework(component HTTPComponent, opts ...Option) (*Framework, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create config: %w", err)
 	}
+	config.enableReload()
 
 	// Update config for BaseAgent or BaseTool
 	// This supports both direct instances and types that embed BaseAgent/BaseTool
@@ -1098,6 +1253,16 @@ func (f *Framework) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to initialize component: %w", err)
 	}
 
-	// Start HTTP server
-	return f.component.Start(ctx, f.config.Port)
+	// Re-read reloadable settings on SIGHUP without restarting the server
+	if f.config.ReloadOnSignal {
+		stop := f.watchReloadSignal(ctx)
+		defer stop()
+	}
+
+	// Report what this component serves before the server blocks
+	f.logStartupInventory(ctx, os.Stdout)
+
+	// Start the HTTP server and shut down gracefully on cancellation (and,
+	// when leaving discovery on shutdown, on SIGTERM or SIGINT)
+	return f.runUntilShutdown(ctx)
 }
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoMessages is returned when GenerateMessages is called without any
+// user, assistant or tool message
+var ErrNoMessages = errors.New("no messages to send")
+
+// GenerateMessages sends a conversation to client. Clients implementing
+// MessageAIClient receive it in their native chat format; any other client
+// receives FlattenMessages' single prompt, so wrappers and test doubles
+// written against AIClient keep working.
+func GenerateMessages(ctx context.Context, client AIClient, messages []Message, options *AIOptions) (*AIResponse, error) {
+	if chat, ok := client.(MessageAIClient); ok {
+		return chat.GenerateMessages(ctx, messages, options)
+	}
+	prompt, flattened, err := FlattenMessages(messages, options)
+	if err != nil {
+		return nil, err
+	}
+	return client.GenerateResponse(ctx, prompt, flattened)
+}
+
+// SplitSystemMessages separates the system prompt from the conversation,
+// for providers that take it as a separate field. systemPrompt (usually
+// AIOptions.SystemPrompt) comes first, followed by the RoleSystem messages,
+// separated by blank lines.
+func SplitSystemMessages(messages []Message, systemPrompt string) (string, []Message) {
+	var system []string
+	if systemPrompt != "" {
+		system = append(system, systemPrompt)
+	}
+	conversation := make([]Message, 0, len(messages))
+	for _, message := range messages {
+		if message.Role == RoleSystem {
+			if message.Content != "" {
+				system = append(system, message.Content)
+			}
+			continue
+		}
+		conversation = append(conversation, message)
+	}
+	return strings.Join(system, "\n\n"), conversation
+}
+
+// FlattenMessages renders a conversation as a single prompt, for clients
+// without native message support. The system messages are merged into a
+// copy of options' SystemPrompt. A conversation of one user message becomes
+// that message's content unchanged; longer ones become a role-labelled
+// transcript.
+func FlattenMessages(messages []Message, options *AIOptions) (string, *AIOptions, error) {
+	flattened := &AIOptions{}
+	if options != nil {
+		copied := *options
+		flattened = &copied
+	}
+
+	system, conversation := SplitSystemMessages(messages, flattened.SystemPrompt)
+	if len(conversation) == 0 {
+		return "", nil, ErrNoMessages
+	}
+	flattened.SystemPrompt = system
+
+	if len(conversation) == 1 && conversation[0].Role == RoleUser {
+		return conversation[0].Content, flattened, nil
+	}
+
+	var transcript strings.Builder
+	for i, message := range conversation {
+		if i > 0 {
+			transcript.WriteString("\n\n")
+		}
+		switch message.Role {
+		case RoleUser:
+			transcript.WriteString("User: ")
+		case RoleAssistant:
+			transcript.WriteString("Assistant: ")
+		case RoleTool:
+			transcript.WriteString("Tool result: ")
+		default:
+			return "", nil, fmt.Errorf("unsupported message role %q", message.Role)
+		}
+		transcript.WriteString(message.Content)
+		for _, call := range message.ToolCalls {
+			fmt.Fprintf(&transcript, "\n[called tool %s with %v]", call.Name, call.Arguments)
+		}
+	}
+	return transcript.String(), flattened, nil
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// promptCapturingClient records the prompt and options of the last call
+type promptCapturingClient struct {
+	prompt  string
+	options *AIOptions
+}
+
+func (c *promptCapturingClient) GenerateResponse(ctx context.Context, prompt string, options *AIOptions) (*AIResponse, error) {
+	c.prompt, c.options = prompt, options
+	return &AIResponse{Content: "ok"}, nil
+}
+
+// nativeMessageClient implements MessageAIClient
+type nativeMessageClient struct {
+	promptCapturingClient
+	messages []Message
+}
+
+func (c *nativeMessageClient) GenerateMessages(ctx context.Context, messages []Message, options *AIOptions) (*AIResponse, error) {
+	c.messages = messages
+	return &AIResponse{Content: "native"}, nil
+}
+
+func TestFlattenMessages(t *testing.T) {
+	options := &AIOptions{SystemPrompt: "Be brief.", Model: "gpt-4o"}
+
+	prompt, flattened, err := FlattenMessages([]Message{
+		{Role: RoleSystem, Content: "You are a travel assistant."},
+		{Role: RoleUser, Content: "Weather in Paris?"},
+	}, options)
+	if err != nil {
+		t.Fatalf("FlattenMessages failed: %v", err)
+	}
+	if prompt != "Weather in Paris?" {
+		t.Errorf("Expected a single user message unchanged, got %q", prompt)
+	}
+	if flattened.SystemPrompt != "Be brief.\n\nYou are a travel assistant." || flattened.Model != "gpt-4o" {
+		t.Errorf("Unexpected options: %+v", flattened)
+	}
+	if options.SystemPrompt != "Be brief." {
+		t.Error("Expected the caller's options to be left unchanged")
+	}
+
+	prompt, _, err = FlattenMessages([]Message{
+		{Role: RoleUser, Content: "Weather in Paris?"},
+		{Role: RoleAssistant, ToolCalls: []ToolCall{{Name: "get_weather", Arguments: map[string]interface{}{"city": "Paris"}}}},
+		{Role: RoleTool, Content: "rain"},
+		{Role: RoleAssistant, Content: "It is raining."},
+		{Role: RoleUser, Content: "Should I pack an umbrella?"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("FlattenMessages failed: %v", err)
+	}
+	want := "User: Weather in Paris?\n\nAssistant: \n[called tool get_weather with map[city:Paris]]\n\nTool result: rain\n\nAssistant: It is raining.\n\nUser: Should I pack an umbrella?"
+	if prompt != want {
+		t.Errorf("Unexpected transcript:\n%s", prompt)
+	}
+
+	if _, _, err := FlattenMessages([]Message{{Role: RoleSystem, Content: "hi"}}, nil); !errors.Is(err, ErrNoMessages) {
+		t.Errorf("Expected ErrNoMessages, got %v", err)
+	}
+	if _, _, err := FlattenMessages([]Message{{Role: "narrator"}, {Role: RoleUser}}, nil); err == nil {
+		t.Error("Expected an error for an unknown role")
+	}
+}
+
+func TestGenerateMessages(t *testing.T) {
+	conversation := []Message{
+		{Role: RoleUser, Content: "Hi"},
+		{Role: RoleAssistant, Content: "Hello!"},
+		{Role: RoleUser, Content: "Weather in Paris?"},
+	}
+
+	native := &nativeMessageClient{}
+	response, err := GenerateMessages(context.Background(), native, conversation, nil)
+	if err != nil || response.Content != "native" || len(native.messages) != 3 {
+		t.Errorf("Expected the native method to receive the messages, got %v, %v", response, err)
+	}
+
+	plain := &promptCapturingClient{}
+	if _, err := GenerateMessages(context.Background(), plain, conversation, &AIOptions{SystemPrompt: "Be brief."}); err != nil {
+		t.Fatalf("GenerateMessages failed: %v", err)
+	}
+	if plain.prompt != "User: Hi\n\nAssistant: Hello!\n\nUser: Weather in Paris?" || plain.options.SystemPrompt != "Be brief." {
+		t.Errorf("Expected a flattened prompt, got %q with %+v", plain.prompt, plain.options)
+	}
+}
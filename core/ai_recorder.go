@@ -0,0 +1,187 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// AIInteractionTypeDirect is the AIInteraction.Type of calls a component makes
+// to its AI client directly, as opposed to the orchestrator's own calls
+const AIInteractionTypeDirect = "direct_call"
+
+// aiRecordTimeout bounds each asynchronous recording
+const aiRecordTimeout = time.Second
+
+// AIInteraction is one AI client call: the complete prompt and response,
+// token usage and outcome
+type AIInteraction struct {
+	Type             string
+	Timestamp        time.Time
+	DurationMs       int64
+	Prompt           string
+	SystemPrompt     string
+	Temperature      float64
+	MaxTokens        int
+	Model            string
+	Provider         string
+	Response         string
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	Success          bool
+	Error            string
+	Streamed         bool
+}
+
+// AIInteractionRecorder stores AI interactions against the request that made
+// them. The orchestration module's LLM debug stores are adapted to it, so
+// direct calls appear in the registry viewer next to orchestrated ones.
+// Implementations must be safe for concurrent use.
+type AIInteractionRecorder interface {
+	RecordAIInteraction(ctx context.Context, requestID string, interaction AIInteraction) error
+}
+
+// CorrelationIDs returns the trace and request IDs for ctx: from the
+// telemetry baggage when telemetry is enabled, with the request ID falling
+// back to the one set by RequestIDMiddleware
+func CorrelationIDs(ctx context.Context) (traceID, requestID string) {
+	baggage := getContextBaggage(ctx)
+	traceID = baggage["trace_id"]
+	requestID = baggage["request_id"]
+	if requestID == "" {
+		requestID = RequestIDFromContext(ctx)
+	}
+	return traceID, requestID
+}
+
+// NewRecordingAIClient wraps client so every GenerateResponse and
+// StreamResponse call made with a request ID in its context (see
+// CorrelationIDs) is recorded to recorder. Recording runs in the
+// background and never fails the call; recording errors are logged.
+// Streaming support of the wrapped client is preserved.
+func NewRecordingAIClient(client AIClient, recorder AIInteractionRecorder, logger Logger) AIClient {
+	if client == nil || recorder == nil {
+		return client
+	}
+	if _, ok := client.(recordingAIClient); ok {
+		return client
+	}
+	if logger == nil {
+		logger = &NoOpLogger{}
+	}
+	recording := &recordingClient{client: client, recorder: recorder, logger: logger}
+	if streaming, ok := client.(StreamingAIClient); ok {
+		return &recordingStreamingClient{recordingClient: recording, streaming: streaming}
+	}
+	return recording
+}
+
+// recordingAIClient marks clients that already record, so wrapping twice
+// does not record every call twice
+type recordingAIClient interface {
+	recordsAIInteractions()
+}
+
+// recordingClient records calls before returning them
+type recordingClient struct {
+	client   AIClient
+	recorder AIInteractionRecorder
+	logger   Logger
+}
+
+// Unwrap returns the wrapped client
+func (c *recordingClient) Unwrap() AIClient {
+	return c.client
+}
+
+func (c *recordingClient) recordsAIInteractions() {}
+
+// GenerateResponse delegates to the wrapped client and records the call
+func (c *recordingClient) GenerateResponse(ctx context.Context, prompt string, options *AIOptions) (*AIResponse, error) {
+	start := time.Now()
+	response, err := c.client.GenerateResponse(ctx, prompt, options)
+	c.record(ctx, start, prompt, options, response, err, false)
+	return response, err
+}
+
+// GenerateMessages delegates to the wrapped client and records the call,
+// with the conversation as a role-labelled transcript
+func (c *recordingClient) GenerateMessages(ctx context.Context, messages []Message, options *AIOptions) (*AIResponse, error) {
+	start := time.Now()
+	response, err := GenerateMessages(ctx, c.client, messages, options)
+	prompt, recorded, flattenErr := FlattenMessages(messages, options)
+	if flattenErr != nil {
+		recorded = options
+	}
+	c.record(ctx, start, prompt, recorded, response, err, false)
+	return response, err
+}
+
+// record stores the interaction asynchronously, keeping ctx's values (the
+// telemetry baggage links the record to its trace) but not its deadline
+func (c *recordingClient) record(ctx context.Context, start time.Time, prompt string, options *AIOptions, response *AIResponse, err error, streamed bool) {
+	_, requestID := CorrelationIDs(ctx)
+	if requestID == "" {
+		return
+	}
+
+	interaction := AIInteraction{
+		Type:       AIInteractionTypeDirect,
+		Timestamp:  start,
+		DurationMs: time.Since(start).Milliseconds(),
+		Prompt:     prompt,
+		Success:    err == nil,
+		Streamed:   streamed,
+	}
+	if options != nil {
+		interaction.SystemPrompt = options.SystemPrompt
+		interaction.Temperature = float64(options.Temperature)
+		interaction.MaxTokens = options.MaxTokens
+		interaction.Model = options.Model
+	}
+	if response != nil {
+		interaction.Response = response.Content
+		interaction.Provider = response.Provider
+		interaction.PromptTokens = response.Usage.PromptTokens
+		interaction.CompletionTokens = response.Usage.CompletionTokens
+		interaction.TotalTokens = response.Usage.TotalTokens
+		if response.Model != "" {
+			interaction.Model = response.Model
+		}
+	}
+	if err != nil {
+		interaction.Error = err.Error()
+	}
+
+	go func() {
+		recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), aiRecordTimeout)
+		defer cancel()
+		if err := c.recorder.RecordAIInteraction(recordCtx, requestID, interaction); err != nil {
+			c.logger.Warn("Failed to record AI interaction", map[string]interface{}{
+				"operation":  "ai_interaction_record",
+				"request_id": requestID,
+				"error":      err.Error(),
+			})
+		}
+	}()
+}
+
+// recordingStreamingClient is a recordingClient for clients that can stream
+type recordingStreamingClient struct {
+	*recordingClient
+	streaming StreamingAIClient
+}
+
+// StreamResponse streams from the wrapped client and records the complete
+// response
+func (c *recordingStreamingClient) StreamResponse(ctx context.Context, prompt string, options *AIOptions, callback StreamCallback) (*AIResponse, error) {
+	start := time.Now()
+	response, err := c.streaming.StreamResponse(ctx, prompt, options, callback)
+	c.record(ctx, start, prompt, options, response, err, true)
+	return response, err
+}
+
+// SupportsStreaming reports whether the wrapped client can stream
+func (c *recordingStreamingClient) SupportsStreaming() bool {
+	return c.streaming.SupportsStreaming()
+}
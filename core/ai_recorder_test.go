@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// channelRecorder delivers recorded interactions on a channel
+type channelRecorder struct {
+	recorded chan recordedInteraction
+}
+
+type recordedInteraction struct {
+	requestID   string
+	interaction AIInteraction
+}
+
+func newChannelRecorder() *channelRecorder {
+	return &channelRecorder{recorded: make(chan recordedInteraction, 10)}
+}
+
+func (r *channelRecorder) RecordAIInteraction(ctx context.Context, requestID string, interaction AIInteraction) error {
+	r.recorded <- recordedInteraction{requestID: requestID, interaction: interaction}
+	return nil
+}
+
+func (r *channelRecorder) next(t *testing.T) recordedInteraction {
+	t.Helper()
+	select {
+	case got := <-r.recorded:
+		return got
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the interaction to be recorded")
+		return recordedInteraction{}
+	}
+}
+
+// scriptedAIClient answers every call with response and err
+type scriptedAIClient struct {
+	response *AIResponse
+	err      error
+}
+
+func (c *scriptedAIClient) GenerateResponse(ctx context.Context, prompt string, options *AIOptions) (*AIResponse, error) {
+	return c.response, c.err
+}
+
+func (c *scriptedAIClient) StreamResponse(ctx context.Context, prompt string, options *AIOptions, callback StreamCallback) (*AIResponse, error) {
+	return c.response, c.err
+}
+
+func (c *scriptedAIClient) SupportsStreaming() bool { return true }
+
+func TestRecordingAIClient(t *testing.T) {
+	recorder := newChannelRecorder()
+	inner := &scriptedAIClient{response: &AIResponse{
+		Content:  "Sunny, 21C",
+		Model:    "gpt-4o-mini",
+		Provider: "openai",
+		Usage:    TokenUsage{PromptTokens: 12, CompletionTokens: 5, TotalTokens: 17},
+	}}
+	client := NewRecordingAIClient(inner, recorder, nil)
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+
+	if _, err := client.GenerateResponse(ctx, "Weather in Paris?", &AIOptions{SystemPrompt: "Be brief", Temperature: 0.2, MaxTokens: 50}); err != nil {
+		t.Fatalf("GenerateResponse failed: %v", err)
+	}
+	got := recorder.next(t)
+	interaction := got.interaction
+	if got.requestID != "req-1" || interaction.Type != AIInteractionTypeDirect {
+		t.Errorf("Unexpected record: %+v", got)
+	}
+	if interaction.Prompt != "Weather in Paris?" || interaction.SystemPrompt != "Be brief" || interaction.Response != "Sunny, 21C" {
+		t.Errorf("Expected the complete prompt and response, got %+v", interaction)
+	}
+	if interaction.Model != "gpt-4o-mini" || interaction.Provider != "openai" || interaction.TotalTokens != 17 || interaction.MaxTokens != 50 || !interaction.Success {
+		t.Errorf("Expected model, usage and outcome, got %+v", interaction)
+	}
+
+	streaming, ok := client.(StreamingAIClient)
+	if !ok {
+		t.Fatal("Expected the recording client to keep streaming support")
+	}
+	inner.err = errors.New("rate limited")
+	if _, err := streaming.StreamResponse(ctx, "Weather in Rome?", nil, func(StreamChunk) error { return nil }); err == nil {
+		t.Fatal("Expected the error to be returned")
+	}
+	if interaction := recorder.next(t).interaction; interaction.Success || interaction.Error != "rate limited" || !interaction.Streamed {
+		t.Errorf("Expected a failed streamed interaction, got %+v", interaction)
+	}
+
+	// Without a request ID there is no record to attach the call to
+	_, _ = client.GenerateResponse(context.Background(), "hi", nil)
+	select {
+	case got := <-recorder.recorded:
+		t.Errorf("Expected no record without a request ID, got %+v", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if NewRecordingAIClient(client, recorder, nil) != client {
+		t.Error("Expected an already recording client to be returned unchanged")
+	}
+}
+
+func TestWithAIDebugRecorder_WrapsAgentAIClient(t *testing.T) {
+	recorder := newChannelRecorder()
+	config, err := NewConfig(WithName("weather-agent"), WithAIDebugRecorder(recorder))
+	if err != nil {
+		t.Fatalf("NewConfig failed: %v", err)
+	}
+	agent := NewBaseAgentWithConfig(config)
+	agent.AI = &scriptedAIClient{response: &AIResponse{Content: "ok"}}
+
+	if err := agent.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if _, err := agent.AI.GenerateResponse(ContextWithRequestID(context.Background(), "req-2"), "hello", nil); err != nil {
+		t.Fatalf("GenerateResponse failed: %v", err)
+	}
+	if got := recorder.next(t); got.requestID != "req-2" || got.interaction.Response != "ok" {
+		t.Errorf("Unexpected record: %+v", got)
+	}
+}
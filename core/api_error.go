@@ -0,0 +1,182 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Standard error codes for APIError. Capabilities may use their own codes
+// too; these are the ones the framework knows a default HTTP status for.
+const (
+	// CodeInvalidInput: the request was malformed or failed validation (400)
+	CodeInvalidInput = "INVALID_INPUT"
+
+	// CodeNotFound: the requested resource does not exist (404)
+	CodeNotFound = "NOT_FOUND"
+
+	// CodeUpstreamFailure: a service the capability depends on failed (502)
+	CodeUpstreamFailure = "UPSTREAM_FAILURE"
+
+	// CodeTimeout: the capability or one of its dependencies timed out (504)
+	CodeTimeout = "TIMEOUT"
+)
+
+// standardCodeStatus maps the standard codes to their HTTP status
+var standardCodeStatus = map[string]int{
+	CodeInvalidInput:    http.StatusBadRequest,
+	CodeNotFound:        http.StatusNotFound,
+	CodeUpstreamFailure: http.StatusBadGateway,
+	CodeTimeout:         http.StatusGatewayTimeout,
+}
+
+// APIError is an error a capability returns to choose the HTTP status and
+// error code its caller sees. It is rendered in the standard ToolResponse
+// envelope by WriteError, and by the handlers of Execute-based and typed
+// capabilities:
+//
+//	return nil, &core.APIError{
+//	    Code:    core.CodeNotFound,
+//	    Message: fmt.Sprintf("no forecast for %q", city),
+//	    Details: map[string]interface{}{"city": city},
+//	}
+//
+// HTTPStatus defaults to the status of a standard code, or 500 for any
+// other code. The envelope's category follows from the status, and 429,
+// 502, 503 and 504 responses are marked retryable.
+type APIError struct {
+	Code       string                 `json:"code"`
+	Message    string                 `json:"message"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	HTTPStatus int                    `json:"-"`
+}
+
+// NewAPIError returns an APIError with a standard code's status and a
+// formatted message
+func NewAPIError(code string, format string, args ...interface{}) *APIError {
+	return &APIError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
+}
+
+// Status returns the HTTP status the error is rendered with
+func (e *APIError) Status() int {
+	if e.HTTPStatus != 0 {
+		return e.HTTPStatus
+	}
+	if status, ok := standardCodeStatus[e.Code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// toolError converts e into the envelope's error. Details values that are
+// not strings are JSON-encoded.
+func (e *APIError) toolError() *ToolError {
+	status := e.Status()
+	toolErr := &ToolError{
+		Code:     e.Code,
+		Message:  e.Message,
+		Category: categoryForStatus(status),
+	}
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		toolErr.Retryable = true
+	}
+	if len(e.Details) > 0 {
+		toolErr.Details = make(map[string]string, len(e.Details))
+		for key, value := range e.Details {
+			if s, ok := value.(string); ok {
+				toolErr.Details[key] = s
+				continue
+			}
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				encoded = []byte(fmt.Sprint(value))
+			}
+			toolErr.Details[key] = string(encoded)
+		}
+	}
+	return toolErr
+}
+
+// categoryForStatus is the inverse of HTTPStatusForCategory
+func categoryForStatus(status int) ErrorCategory {
+	switch {
+	case status == http.StatusNotFound:
+		return CategoryNotFound
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return CategoryAuthError
+	case status == http.StatusTooManyRequests:
+		return CategoryRateLimit
+	case status >= 400 && status < 500:
+		return CategoryInputError
+	default:
+		return CategoryServiceError
+	}
+}
+
+// toolErrorFor converts the errors the framework knows how to render into
+// an envelope error and HTTP status: *APIError, *ToolError,
+// *BadRequestError and context deadlines. It returns false for any other
+// error, for which callers choose their own fallback.
+func toolErrorFor(err error) (*ToolError, int, bool) {
+	var apiErr *APIError
+	var toolErr *ToolError
+	var badRequest *BadRequestError
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr.toolError(), apiErr.Status(), true
+	case errors.As(err, &toolErr):
+		return toolErr, HTTPStatusForCategory(toolErr.Category), true
+	case errors.As(err, &badRequest):
+		return &ToolError{
+			Code:     "INVALID_REQUEST",
+			Message:  badRequest.Message,
+			Category: CategoryInputError,
+		}, http.StatusBadRequest, true
+	case errors.Is(err, context.DeadlineExceeded):
+		timeout := &APIError{Code: CodeTimeout, Message: err.Error()}
+		return timeout.toolError(), timeout.Status(), true
+	}
+	return nil, 0, false
+}
+
+// WriteError writes err as a ToolResponse error envelope, with the trace
+// and request IDs, so custom capability handlers answer errors the same way
+// as the framework's own. Errors other than *APIError, *ToolError,
+// *BadRequestError and context deadlines are reported as a 500
+// INTERNAL_ERROR.
+//
+// Example:
+//
+//	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+//	    core.WriteError(w, r, core.NewAPIError(core.CodeInvalidInput, "invalid body: %v", err))
+//	    return
+//	}
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	writeErrorResponse(w, r, &ToolResponse{}, err)
+}
+
+// writeErrorResponse sets err as response's error and writes it
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, response *ToolResponse, err error) {
+	toolErr, status, ok := toolErrorFor(err)
+	if !ok {
+		toolErr = &ToolError{
+			Code:     "INTERNAL_ERROR",
+			Message:  err.Error(),
+			Category: CategoryServiceError,
+		}
+		status = http.StatusInternalServerError
+	}
+	response.Success = false
+	response.Data = nil
+	response.Error = toolErr
+	withCorrelation(w, r, response)
+	writeToolResponse(w, status, response)
+}
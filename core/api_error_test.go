@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		status    int
+		code      string
+		category  ErrorCategory
+		retryable bool
+	}{
+		{"invalid input", NewAPIError(CodeInvalidInput, "days must be positive"), http.StatusBadRequest, CodeInvalidInput, CategoryInputError, false},
+		{"not found", NewAPIError(CodeNotFound, "no such city"), http.StatusNotFound, CodeNotFound, CategoryNotFound, false},
+		{"upstream failure", NewAPIError(CodeUpstreamFailure, "weather API down"), http.StatusBadGateway, CodeUpstreamFailure, CategoryServiceError, true},
+		{"timeout", NewAPIError(CodeTimeout, "took too long"), http.StatusGatewayTimeout, CodeTimeout, CategoryServiceError, true},
+		{"explicit status", &APIError{Code: "QUOTA_EXCEEDED", Message: "daily quota used", HTTPStatus: http.StatusTooManyRequests}, http.StatusTooManyRequests, "QUOTA_EXCEEDED", CategoryRateLimit, true},
+		{"custom code", &APIError{Code: "LEDGER_LOCKED", Message: "locked"}, http.StatusInternalServerError, "LEDGER_LOCKED", CategoryServiceError, false},
+		{"wrapped", fmt.Errorf("lookup: %w", NewAPIError(CodeNotFound, "gone")), http.StatusNotFound, CodeNotFound, CategoryNotFound, false},
+		{"tool error", &ToolError{Code: "AUTH", Message: "bad key", Category: CategoryAuthError}, http.StatusUnauthorized, "AUTH", CategoryAuthError, false},
+		{"bad request", BadRequest("city is required"), http.StatusBadRequest, "INVALID_REQUEST", CategoryInputError, false},
+		{"deadline", fmt.Errorf("fetching: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, CodeTimeout, CategoryServiceError, true},
+		{"plain error", errors.New("boom"), http.StatusInternalServerError, "INTERNAL_ERROR", CategoryServiceError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/capabilities/forecast", nil)
+			req.Header.Set(RequestIDHeader, "req-1")
+			rec := httptest.NewRecorder()
+			WriteError(rec, req, tt.err)
+
+			if rec.Code != tt.status {
+				t.Errorf("Expected %d, got %d", tt.status, rec.Code)
+			}
+			response := decodeToolResponse(t, rec)
+			if response.Success || response.Error == nil {
+				t.Fatalf("Expected an error envelope, got %+v", response)
+			}
+			if response.Error.Code != tt.code || response.Error.Category != tt.category || response.Error.Retryable != tt.retryable {
+				t.Errorf("Unexpected error: %+v", response.Error)
+			}
+			if response.RequestID != "req-1" {
+				t.Errorf("Expected the request ID in the envelope, got %q", response.RequestID)
+			}
+		})
+	}
+}
+
+func TestAPIError_Details(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteError(rec, httptest.NewRequest("GET", "/", nil), &APIError{
+		Code:    CodeInvalidInput,
+		Message: "bad range",
+		Details: map[string]interface{}{"field": "days", "max": 14, "allowed": []string{"1", "7"}},
+	})
+
+	details := decodeToolResponse(t, rec).Error.Details
+	if details["field"] != "days" || details["max"] != "14" || details["allowed"] != `["1","7"]` {
+		t.Errorf("Unexpected details: %v", details)
+	}
+}
+
+func TestAPIError_FromCapabilities(t *testing.T) {
+	tool := NewTool("weather")
+	tool.RegisterCapability(Capability{
+		Name: "forecast",
+		Execute: func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			return nil, NewAPIError(CodeNotFound, "no forecast for %v", input["city"])
+		},
+	})
+	RegisterTypedCapability(tool, "alerts", func(ctx context.Context, req typedForecastRequest) (typedForecastResponse, error) {
+		return typedForecastResponse{}, &APIError{Code: CodeUpstreamFailure, Message: "alerts feed down"}
+	})
+	handler := tool.Handler()
+
+	for path, want := range map[string]int{
+		"/api/capabilities/forecast": http.StatusNotFound,
+		"/api/capabilities/alerts":   http.StatusBadGateway,
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("POST", path, strings.NewReader(`{"city": "Atlantis"}`)))
+		if rec.Code != want {
+			t.Errorf("%s: expected %d, got %d: %s", path, want, rec.Code, rec.Body.String())
+		}
+	}
+
+	agent := NewBaseAgent("weather-agent")
+	agent.RegisterCapability(Capability{Name: "legacy"})
+	rec := httptest.NewRecorder()
+	agent.Handler().ServeHTTP(rec, httptest.NewRequest("POST", "/api/capabilities/legacy", strings.NewReader(`{not json`)))
+	if rec.Code != http.StatusBadRequest || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("Expected a 400 JSON envelope, got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if response := decodeToolResponse(t, rec); response.Error == nil || response.Error.Code != CodeInvalidInput {
+		t.Errorf("Expected INVALID_INPUT, got %+v", response.Error)
+	}
+}
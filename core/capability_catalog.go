@@ -0,0 +1,376 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCatalogTTL is how long a capability catalog snapshot is served before refreshing
+const DefaultCatalogTTL = 30 * time.Second
+
+// CatalogSnapshot is a point-in-time view of every registered component and
+// the capabilities it offers. Snapshots are immutable once built; callers
+// must not modify the returned services.
+type CatalogSnapshot struct {
+	Services    []*ServiceInfo `json:"services"`
+	GeneratedAt time.Time      `json:"generated_at"`
+
+	byCapability map[string][]*ServiceInfo
+}
+
+// ServicesWithCapability returns the components that provide a capability
+func (s *CatalogSnapshot) ServicesWithCapability(capability string) []*ServiceInfo {
+	return s.byCapability[capability]
+}
+
+// CapabilityNames returns the sorted, de-duplicated capability names in the snapshot
+func (s *CatalogSnapshot) CapabilityNames() []string {
+	names := make([]string, 0, len(s.byCapability))
+	for name := range s.byCapability {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Age returns how long ago the snapshot was built
+func (s *CatalogSnapshot) Age() time.Duration {
+	return time.Since(s.GeneratedAt)
+}
+
+func newCatalogSnapshot(services []*ServiceInfo) *CatalogSnapshot {
+	snapshot := &CatalogSnapshot{
+		Services:     services,
+		GeneratedAt:  time.Now(),
+		byCapability: make(map[string][]*ServiceInfo),
+	}
+	for _, service := range services {
+		seen := make(map[string]bool, len(service.Capabilities))
+		for _, capability := range service.Capabilities {
+			if seen[capability.Name] {
+				continue
+			}
+			seen[capability.Name] = true
+			snapshot.byCapability[capability.Name] = append(snapshot.byCapability[capability.Name], service)
+		}
+	}
+	return snapshot
+}
+
+// CapabilityCatalog serves a cached, periodically refreshed snapshot of all
+// registered components and their capabilities. It replaces the pattern of
+// issuing several FindByCapability searches per request and de-duplicating
+// the results: the catalog is built with a single Discover call and reused
+// until it expires or is invalidated.
+type CapabilityCatalog struct {
+	discovery Discovery
+	ttl       time.Duration
+	logger    Logger
+
+	snapshot  atomic.Pointer[CatalogSnapshot]
+	stale     atomic.Bool
+	refreshMu sync.Mutex // Ensures only one refresh hits discovery at a time
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+
+	// Removes the Invalidate callback registered with the discovery
+	removeChangeListener func()
+
+	// Optional on-disk snapshot, see WithCatalogSnapshotFile
+	snapshotFile   string
+	snapshotMaxAge time.Duration
+	restoreOnce    sync.Once
+}
+
+// registryChangeNotifier is implemented by discovery backends that can tell
+// a catalog when a service registers or unregisters through them. The
+// returned function removes the callback.
+type registryChangeNotifier interface {
+	onRegistryChange(fn func()) (remove func())
+}
+
+// changeListeners is the callback set behind onRegistryChange
+type changeListeners struct {
+	mu     sync.Mutex
+	nextID int
+	fns    map[int]func()
+}
+
+// add registers fn and returns a function that removes it
+func (l *changeListeners) add(fn func()) func() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.fns == nil {
+		l.fns = make(map[int]func())
+	}
+	id := l.nextID
+	l.nextID++
+	l.fns[id] = fn
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.fns, id)
+	}
+}
+
+// notify runs every registered callback outside the lock
+func (l *changeListeners) notify() {
+	l.mu.Lock()
+	fns := make([]func(), 0, len(l.fns))
+	for _, fn := range l.fns {
+		fns = append(fns, fn)
+	}
+	l.mu.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// len returns the number of registered callbacks
+func (l *changeListeners) len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.fns)
+}
+
+var (
+	_ registryChangeNotifier = (*RedisRegistry)(nil)
+	_ registryChangeNotifier = (*MockDiscovery)(nil)
+)
+
+// CapabilityCatalogOption configures a CapabilityCatalog
+type CapabilityCatalogOption func(*CapabilityCatalog)
+
+// WithCatalogTTL sets how long a snapshot is served before it is rebuilt.
+// Default is DefaultCatalogTTL.
+func WithCatalogTTL(ttl time.Duration) CapabilityCatalogOption {
+	return func(c *CapabilityCatalog) {
+		if ttl > 0 {
+			c.ttl = ttl
+		}
+	}
+}
+
+// WithCatalogLogger sets the logger for catalog refreshes
+func WithCatalogLogger(logger Logger) CapabilityCatalogOption {
+	return func(c *CapabilityCatalog) {
+		if logger == nil {
+			return
+		}
+		if cal, ok := logger.(ComponentAwareLogger); ok {
+			c.logger = cal.WithComponent("framework/core")
+		} else {
+			c.logger = logger
+		}
+	}
+}
+
+// NewCapabilityCatalog creates a catalog backed by the given discovery.
+// The first snapshot is built lazily on the first Catalog call, or eagerly
+// when Start is called. When discovery is a RedisDiscovery or MockDiscovery,
+// registering or unregistering a service through it invalidates the catalog
+// until Stop is called, so call Stop when discarding a catalog whose
+// discovery outlives it.
+func NewCapabilityCatalog(discovery Discovery, opts ...CapabilityCatalogOption) *CapabilityCatalog {
+	c := &CapabilityCatalog{
+		discovery:      discovery,
+		ttl:            DefaultCatalogTTL,
+		logger:         &NoOpLogger{},
+		stopCh:         make(chan struct{}),
+		snapshotMaxAge: DefaultCatalogSnapshotMaxAge,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if notifier, ok := discovery.(registryChangeNotifier); ok {
+		c.removeChangeListener = notifier.onRegistryChange(c.Invalidate)
+	}
+	return c
+}
+
+// Catalog returns the current snapshot, rebuilding it first if it has expired
+// or been invalidated. If a rebuild fails and a previous snapshot exists, the
+// previous snapshot is returned so callers keep working during discovery outages.
+func (c *CapabilityCatalog) Catalog(ctx context.Context) (*CatalogSnapshot, error) {
+	c.restoreSnapshotFile()
+	if snapshot := c.snapshot.Load(); snapshot != nil && !c.stale.Load() && snapshot.Age() < c.ttl {
+		if registry := GetGlobalMetricsRegistry(); registry != nil {
+			registry.Counter("discovery.catalog.hits")
+		}
+		return snapshot, nil
+	}
+
+	snapshot, err := c.refreshIfNeeded(ctx)
+	if err != nil {
+		if previous := c.snapshot.Load(); previous != nil {
+			c.logger.WarnWithContext(ctx, "Serving stale capability catalog after refresh failure", map[string]interface{}{
+				"operation":    "catalog_refresh",
+				"error":        err.Error(),
+				"snapshot_age": previous.Age().String(),
+			})
+			return previous, nil
+		}
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// Refresh rebuilds the snapshot from discovery immediately
+func (c *CapabilityCatalog) Refresh(ctx context.Context) (*CatalogSnapshot, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	return c.refreshLocked(ctx)
+}
+
+// Invalidate marks the current snapshot stale so the next Catalog call rebuilds it.
+// Registrations made through the catalog's own discovery, and changes a
+// DiscoveryWatcher reports after Start, invalidate it automatically; call
+// Invalidate when you learn of other changes.
+func (c *CapabilityCatalog) Invalidate() {
+	c.stale.Store(true)
+}
+
+// Start refreshes the catalog in the background every TTL so Catalog calls
+// are always served from memory. It returns after the initial refresh; the
+// background loop runs until ctx is cancelled or Stop is called. When a
+// snapshot file restores the catalog, Start returns immediately and the
+// initial refresh runs in the background. When discovery implements
+// DiscoveryWatcher, registration changes invalidate the catalog as they are
+// reported instead of waiting for the next TTL.
+func (c *CapabilityCatalog) Start(ctx context.Context) error {
+	// Watch before the first refresh so no change falls between the two
+	stopWatching := c.watchDiscovery(ctx)
+
+	restored := c.restoreSnapshotFile()
+	if !restored {
+		if _, err := c.Refresh(ctx); err != nil {
+			stopWatching()
+			return err
+		}
+	}
+
+	go func() {
+		if restored {
+			if _, err := c.Refresh(ctx); err != nil {
+				c.logger.Warn("Initial capability catalog refresh failed, serving restored snapshot", map[string]interface{}{
+					"operation": "catalog_refresh",
+					"error":     err.Error(),
+				})
+			}
+		}
+
+		ticker := time.NewTicker(c.ttl)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.stopCh:
+				return
+			case <-ticker.C:
+				if _, err := c.Refresh(ctx); err != nil {
+					c.logger.Warn("Background capability catalog refresh failed", map[string]interface{}{
+						"operation": "catalog_refresh",
+						"error":     err.Error(),
+					})
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop ends background refreshing started by Start and stops listening for
+// registrations made through the catalog's discovery
+func (c *CapabilityCatalog) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stopCh)
+		if c.removeChangeListener != nil {
+			c.removeChangeListener()
+		}
+	})
+}
+
+// watchDiscovery invalidates the catalog on every event from a discovery
+// that implements DiscoveryWatcher, until ctx is cancelled, Stop is called
+// or the returned function is called
+func (c *CapabilityCatalog) watchDiscovery(ctx context.Context) func() {
+	watcher, ok := c.discovery.(DiscoveryWatcher)
+	if !ok {
+		return func() {}
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	events, err := watcher.Watch(watchCtx)
+	if err != nil {
+		cancel()
+		c.logger.Warn("Discovery watch unavailable, capability catalog refreshes on TTL only", map[string]interface{}{
+			"operation": "catalog_watch",
+			"error":     err.Error(),
+		})
+		return func() {}
+	}
+
+	go func() {
+		defer cancel()
+		for {
+			select {
+			case <-c.stopCh:
+				return
+			case _, ok := <-events:
+				if !ok {
+					return
+				}
+				c.Invalidate()
+			}
+		}
+	}()
+	return cancel
+}
+
+// refreshIfNeeded rebuilds the snapshot unless a concurrent caller already did
+func (c *CapabilityCatalog) refreshIfNeeded(ctx context.Context) (*CatalogSnapshot, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	if snapshot := c.snapshot.Load(); snapshot != nil && !c.stale.Load() && snapshot.Age() < c.ttl {
+		return snapshot, nil
+	}
+	return c.refreshLocked(ctx)
+}
+
+func (c *CapabilityCatalog) refreshLocked(ctx context.Context) (*CatalogSnapshot, error) {
+	start := time.Now()
+	// Clear the stale flag before discovering so an Invalidate racing with
+	// this refresh forces another one
+	c.stale.Store(false)
+
+	services, err := c.discovery.Discover(ctx, DiscoveryFilter{})
+	if err != nil {
+		c.stale.Store(true)
+		if registry := GetGlobalMetricsRegistry(); registry != nil {
+			registry.Counter("discovery.catalog.refreshes", "result", "error")
+		}
+		return nil, fmt.Errorf("failed to refresh capability catalog: %w", err)
+	}
+
+	snapshot := newCatalogSnapshot(services)
+	c.snapshot.Store(snapshot)
+	c.writeSnapshotFile(ctx)
+
+	if registry := GetGlobalMetricsRegistry(); registry != nil {
+		registry.Counter("discovery.catalog.refreshes", "result", "success")
+	}
+	c.logger.DebugWithContext(ctx, "Capability catalog refreshed", map[string]interface{}{
+		"operation":     "catalog_refresh",
+		"service_count": len(snapshot.Services),
+		"capabilities":  len(snapshot.byCapability),
+		"duration_ms":   time.Since(start).Milliseconds(),
+	})
+	return snapshot, nil
+}
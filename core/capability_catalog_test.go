@@ -0,0 +1,208 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingDiscovery counts Discover calls and can be switched to fail
+type countingDiscovery struct {
+	*MockDiscovery
+	calls atomic.Int32
+	fail  atomic.Bool
+}
+
+func (d *countingDiscovery) Discover(ctx context.Context, filter DiscoveryFilter) ([]*ServiceInfo, error) {
+	d.calls.Add(1)
+	if d.fail.Load() {
+		return nil, errors.New("redis unavailable")
+	}
+	return d.MockDiscovery.Discover(ctx, filter)
+}
+
+func newCatalogTestDiscovery(t *testing.T) *countingDiscovery {
+	t.Helper()
+	d := &countingDiscovery{MockDiscovery: NewMockDiscovery()}
+	ctx := context.Background()
+	_ = d.Register(ctx, &ServiceInfo{ID: "weather-1", Name: "weather", Type: ComponentTypeTool,
+		Capabilities: []Capability{{Name: "current_weather"}, {Name: "forecast"}}})
+	_ = d.Register(ctx, &ServiceInfo{ID: "weather-2", Name: "weather", Type: ComponentTypeTool,
+		Capabilities: []Capability{{Name: "current_weather"}}})
+	return d
+}
+
+func TestCapabilityCatalog_CachesSnapshot(t *testing.T) {
+	d := newCatalogTestDiscovery(t)
+	catalog := NewCapabilityCatalog(d, WithCatalogTTL(time.Minute))
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		snapshot, err := catalog.Catalog(ctx)
+		if err != nil {
+			t.Fatalf("Catalog() error = %v", err)
+		}
+		if len(snapshot.Services) != 2 {
+			t.Fatalf("Expected 2 services, got %d", len(snapshot.Services))
+		}
+	}
+	if calls := d.calls.Load(); calls != 1 {
+		t.Errorf("Expected a single Discover call, got %d", calls)
+	}
+
+	snapshot, _ := catalog.Catalog(ctx)
+	if got := len(snapshot.ServicesWithCapability("current_weather")); got != 2 {
+		t.Errorf("Expected 2 providers of current_weather, got %d", got)
+	}
+	names := snapshot.CapabilityNames()
+	if len(names) != 2 || names[0] != "current_weather" || names[1] != "forecast" {
+		t.Errorf("CapabilityNames() = %v", names)
+	}
+}
+
+func TestCapabilityCatalog_InvalidateAndExpiry(t *testing.T) {
+	d := newCatalogTestDiscovery(t)
+	catalog := NewCapabilityCatalog(d, WithCatalogTTL(50*time.Millisecond))
+	ctx := context.Background()
+
+	_, _ = catalog.Catalog(ctx)
+	_ = d.Register(ctx, &ServiceInfo{ID: "news-1", Name: "news", Capabilities: []Capability{{Name: "headlines"}}})
+
+	catalog.Invalidate()
+	snapshot, _ := catalog.Catalog(ctx)
+	if len(snapshot.ServicesWithCapability("headlines")) != 1 {
+		t.Error("Expected invalidated catalog to include the new service")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	_, _ = catalog.Catalog(ctx)
+	if calls := d.calls.Load(); calls != 3 {
+		t.Errorf("Expected refresh after invalidation and expiry (3 calls), got %d", calls)
+	}
+}
+
+func TestCapabilityCatalog_InvalidatedByRegistration(t *testing.T) {
+	d := newCatalogTestDiscovery(t)
+	catalog := NewCapabilityCatalog(d, WithCatalogTTL(time.Minute))
+	ctx := context.Background()
+
+	_, _ = catalog.Catalog(ctx)
+	_ = d.Register(ctx, &ServiceInfo{ID: "news-1", Name: "news", Capabilities: []Capability{{Name: "headlines"}}})
+	snapshot, _ := catalog.Catalog(ctx)
+	if len(snapshot.ServicesWithCapability("headlines")) != 1 {
+		t.Error("Expected registration to invalidate the catalog")
+	}
+
+	_ = d.Unregister(ctx, "news-1")
+	snapshot, _ = catalog.Catalog(ctx)
+	if len(snapshot.ServicesWithCapability("headlines")) != 0 {
+		t.Error("Expected unregistration to invalidate the catalog")
+	}
+	if calls := d.calls.Load(); calls != 3 {
+		t.Errorf("Expected a refresh per registry change (3 calls), got %d", calls)
+	}
+}
+
+func TestCapabilityCatalog_StopRemovesRegistryListener(t *testing.T) {
+	d := NewMockDiscovery()
+	first := NewCapabilityCatalog(d)
+	second := NewCapabilityCatalog(d)
+	if n := d.changeListeners.len(); n != 2 {
+		t.Fatalf("Expected a listener per catalog, got %d", n)
+	}
+
+	first.Stop()
+	first.Stop() // Idempotent
+	if n := d.changeListeners.len(); n != 1 {
+		t.Errorf("Expected Stop to remove the catalog's listener, %d left", n)
+	}
+	second.Stop()
+	if n := d.changeListeners.len(); n != 0 {
+		t.Errorf("Expected no listeners after both catalogs stopped, got %d", n)
+	}
+}
+
+// watchingDiscovery delivers the events sent on its channel to Watch callers
+type watchingDiscovery struct {
+	*countingDiscovery
+	events chan DiscoveryEvent
+}
+
+func (d *watchingDiscovery) Watch(ctx context.Context) (<-chan DiscoveryEvent, error) {
+	return d.events, nil
+}
+
+func TestCapabilityCatalog_InvalidatedByWatch(t *testing.T) {
+	d := &watchingDiscovery{countingDiscovery: newCatalogTestDiscovery(t), events: make(chan DiscoveryEvent)}
+	catalog := NewCapabilityCatalog(d, WithCatalogTTL(time.Minute))
+	ctx := context.Background()
+
+	if err := catalog.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer catalog.Stop()
+
+	// A registration made by another process, reported only by the watch
+	news := &ServiceInfo{ID: "news-1", Name: "news", Capabilities: []Capability{{Name: "headlines"}}}
+	d.MockDiscovery.mu.Lock()
+	d.MockDiscovery.services[news.ID] = news
+	d.MockDiscovery.mu.Unlock()
+
+	snapshot, _ := catalog.Catalog(ctx)
+	if len(snapshot.ServicesWithCapability("headlines")) != 0 {
+		t.Fatal("Expected the cached snapshot before any watch event")
+	}
+
+	d.events <- DiscoveryEvent{Type: DiscoveryEventAdded, ServiceID: news.ID, Service: news}
+	deadline := time.Now().Add(time.Second)
+	for !catalog.stale.Load() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	snapshot, _ = catalog.Catalog(ctx)
+	if len(snapshot.ServicesWithCapability("headlines")) != 1 {
+		t.Error("Expected the watch event to invalidate the catalog")
+	}
+}
+
+func TestCapabilityCatalog_ServesStaleOnFailure(t *testing.T) {
+	d := newCatalogTestDiscovery(t)
+	catalog := NewCapabilityCatalog(d)
+	ctx := context.Background()
+
+	d.fail.Store(true)
+	if _, err := catalog.Catalog(ctx); err == nil {
+		t.Fatal("Expected error when no snapshot exists and discovery fails")
+	}
+
+	d.fail.Store(false)
+	first, _ := catalog.Catalog(ctx)
+
+	d.fail.Store(true)
+	catalog.Invalidate()
+	snapshot, err := catalog.Catalog(ctx)
+	if err != nil {
+		t.Fatalf("Expected stale snapshot, got error %v", err)
+	}
+	if snapshot != first {
+		t.Error("Expected the previous snapshot to be served")
+	}
+}
+
+func TestCapabilityCatalog_StartRefreshesInBackground(t *testing.T) {
+	d := newCatalogTestDiscovery(t)
+	catalog := NewCapabilityCatalog(d, WithCatalogTTL(20*time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := catalog.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer catalog.Stop()
+
+	time.Sleep(70 * time.Millisecond)
+	if calls := d.calls.Load(); calls < 2 {
+		t.Errorf("Expected background refreshes, got %d Discover calls", calls)
+	}
+}
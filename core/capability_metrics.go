@@ -0,0 +1,111 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Capability invocation outcomes, the status label of capability.invocations
+const (
+	capabilityStatusSuccess     = "success"
+	capabilityStatusClientError = "client_error"
+	capabilityStatusServerError = "server_error"
+)
+
+// capabilityStatus classifies an HTTP status code as a capability outcome
+func capabilityStatus(code int) string {
+	switch {
+	case code >= 500:
+		return capabilityStatusServerError
+	case code >= 400:
+		return capabilityStatusClientError
+	default:
+		return capabilityStatusSuccess
+	}
+}
+
+// spanStarter starts spans; Telemetry and the telemetry module's metrics
+// registry both implement it
+type spanStarter interface {
+	StartSpan(ctx context.Context, name string) (context.Context, Span)
+}
+
+// instrumentCapability wraps a capability's handler so every invocation,
+// whatever kind of handler it has, emits:
+//
+//   - capability.invocations, a counter labelled by capability and status
+//     (success, client_error for 4xx, server_error for 5xx)
+//   - capability.latency_ms, a histogram labelled by capability
+//   - a span named capability.<name>
+//
+// The span comes from the component's Telemetry, or, while that is the
+// default NoOpTelemetry, from the global metrics registry when it can start
+// spans, as the telemetry module's does. When
+// neither metrics nor spans are available the handler is called directly.
+func instrumentCapability(cap Capability, componentType string, telemetry func() Telemetry, next http.HandlerFunc) http.HandlerFunc {
+	spanName := fmt.Sprintf("capability.%s", cap.Name)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		registry := GetGlobalMetricsRegistry()
+		var tracer spanStarter
+		if t := telemetry(); t != nil && !isNoOpTelemetry(t) {
+			tracer = t
+		} else if spans, ok := registry.(spanStarter); ok {
+			tracer = spans
+		}
+		if registry == nil && tracer == nil {
+			next(w, r)
+			return
+		}
+
+		start := time.Now()
+		ctx := r.Context()
+		var span Span
+		if tracer != nil {
+			ctx, span = tracer.StartSpan(ctx, spanName)
+			span.SetAttribute("capability.name", cap.Name)
+			span.SetAttribute("component.type", componentType)
+		}
+		wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		// Record in a deferred call so a panicking handler still counts, as a
+		// server error, before the recovery middleware answers with a 500
+		completed := false
+		defer func() {
+			code := wrapped.statusCode
+			if !completed {
+				code = http.StatusInternalServerError
+			}
+			status := capabilityStatus(code)
+
+			if registry != nil {
+				registry.Counter("capability.invocations",
+					"capability", cap.Name,
+					"status", status,
+				)
+				registry.Histogram("capability.latency_ms", float64(time.Since(start).Microseconds())/1000,
+					"capability", cap.Name,
+				)
+			}
+			if span != nil {
+				span.SetAttribute("http.status_code", code)
+				span.SetAttribute("capability.status", status)
+				if status == capabilityStatusServerError {
+					span.RecordError(fmt.Errorf("capability %s failed with HTTP status %d", cap.Name, code))
+				}
+				span.End()
+			}
+		}()
+
+		next(wrapped, r.WithContext(ctx))
+		completed = true
+	}
+}
+
+// isNoOpTelemetry reports whether t is the placeholder components start with
+func isNoOpTelemetry(t Telemetry) bool {
+	_, noop := t.(*NoOpTelemetry)
+	return noop
+}
@@ -0,0 +1,159 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// invocationRecorder records capability metrics and spans, standing in for
+// the telemetry module's registry or a component's Telemetry
+type invocationRecorder struct {
+	mockMetricsRegistry
+	mu         sync.Mutex
+	counters   []string
+	histograms []string
+	spans      []*recordedSpan
+}
+
+func (r *invocationRecorder) Counter(name string, labels ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counters = append(r.counters, name+"{"+strings.Join(labels, ",")+"}")
+}
+
+func (r *invocationRecorder) Histogram(name string, value float64, labels ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.histograms = append(r.histograms, name+"{"+strings.Join(labels, ",")+"}")
+}
+
+func (r *invocationRecorder) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	span := &recordedSpan{name: name, attributes: map[string]interface{}{}}
+	r.spans = append(r.spans, span)
+	return ctx, span
+}
+
+func (r *invocationRecorder) RecordMetric(name string, value float64, labels map[string]string) {}
+
+type recordedSpan struct {
+	name       string
+	attributes map[string]interface{}
+	errors     int
+	ended      bool
+}
+
+func (s *recordedSpan) End()                                       { s.ended = true }
+func (s *recordedSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *recordedSpan) RecordError(err error)                      { s.errors++ }
+
+func TestCapabilityStatus(t *testing.T) {
+	assert.Equal(t, "success", capabilityStatus(http.StatusOK))
+	assert.Equal(t, "success", capabilityStatus(http.StatusNoContent))
+	assert.Equal(t, "client_error", capabilityStatus(http.StatusBadRequest))
+	assert.Equal(t, "client_error", capabilityStatus(http.StatusTooManyRequests))
+	assert.Equal(t, "server_error", capabilityStatus(http.StatusInternalServerError))
+	assert.Equal(t, "server_error", capabilityStatus(http.StatusBadGateway))
+}
+
+func TestCapabilityInvocationMetrics(t *testing.T) {
+	originalRegistry := globalMetricsRegistry
+	defer func() { globalMetricsRegistry = originalRegistry }()
+	recorder := &invocationRecorder{}
+	globalMetricsRegistry = recorder
+
+	agent := NewBaseAgent("metrics-agent")
+	for name, code := range map[string]int{"ok": http.StatusOK, "bad": http.StatusBadRequest, "broken": http.StatusInternalServerError} {
+		code := code
+		agent.RegisterCapability(Capability{Name: name, Handler: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(code)
+		}})
+	}
+	handler := agent.Handler()
+	for _, name := range []string{"ok", "bad", "broken"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/capabilities/"+name, strings.NewReader("{}")))
+	}
+
+	assert.Equal(t, []string{
+		"capability.invocations{capability,ok,status,success}",
+		"capability.invocations{capability,bad,status,client_error}",
+		"capability.invocations{capability,broken,status,server_error}",
+	}, recorder.counters)
+	assert.Equal(t, []string{
+		"capability.latency_ms{capability,ok}",
+		"capability.latency_ms{capability,bad}",
+		"capability.latency_ms{capability,broken}",
+	}, recorder.histograms)
+
+	// Without its own Telemetry, the agent takes spans from the registry
+	require.Len(t, recorder.spans, 3)
+	assert.Equal(t, "capability.ok", recorder.spans[0].name)
+	assert.Equal(t, "agent", recorder.spans[0].attributes["component.type"])
+	assert.Equal(t, http.StatusBadRequest, recorder.spans[1].attributes["http.status_code"])
+	assert.Zero(t, recorder.spans[1].errors, "client errors are not span errors")
+	assert.Equal(t, 1, recorder.spans[2].errors)
+	for _, span := range recorder.spans {
+		assert.True(t, span.ended)
+	}
+}
+
+func TestCapabilityInvocationMetrics_ComponentTelemetry(t *testing.T) {
+	originalRegistry := globalMetricsRegistry
+	defer func() { globalMetricsRegistry = originalRegistry }()
+	registry := &invocationRecorder{}
+	globalMetricsRegistry = registry
+
+	telemetry := &invocationRecorder{}
+	tool := NewTool("metrics-tool")
+	tool.Telemetry = telemetry
+	tool.RegisterCapability(Capability{Name: "lookup", Description: "Generic handler"})
+
+	rec := httptest.NewRecorder()
+	tool.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/capabilities/lookup", strings.NewReader("{}")))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Equal(t, []string{"capability.invocations{capability,lookup,status,success}"}, registry.counters)
+	assert.Empty(t, registry.spans, "the component's own Telemetry is preferred")
+	require.Len(t, telemetry.spans, 1, "generic handlers no longer start a second span")
+	assert.Equal(t, "capability.lookup", telemetry.spans[0].name)
+	assert.Equal(t, "tool", telemetry.spans[0].attributes["component.type"])
+}
+
+func TestCapabilityInvocationMetrics_Panic(t *testing.T) {
+	originalRegistry := globalMetricsRegistry
+	defer func() { globalMetricsRegistry = originalRegistry }()
+	recorder := &invocationRecorder{}
+	globalMetricsRegistry = recorder
+
+	handler := instrumentCapability(Capability{Name: "explode"}, "tool", func() Telemetry { return nil },
+		func(w http.ResponseWriter, r *http.Request) { panic("boom") })
+
+	assert.Panics(t, func() {
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/capabilities/explode", nil))
+	})
+	assert.Equal(t, []string{"capability.invocations{capability,explode,status,server_error}"}, recorder.counters)
+}
+
+func TestCapabilityInvocationMetrics_NoTelemetry(t *testing.T) {
+	originalRegistry := globalMetricsRegistry
+	defer func() { globalMetricsRegistry = originalRegistry }()
+	globalMetricsRegistry = nil
+
+	called := false
+	next := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		_, isWrapped := w.(*responseWriter)
+		assert.False(t, isWrapped, "the handler runs unwrapped when telemetry is off")
+	}
+	instrumentCapability(Capability{Name: "plain"}, "tool", func() Telemetry { return nil }, next)(
+		httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/capabilities/plain", nil))
+	assert.True(t, called)
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CapabilityFunc implements a capability by returning data instead of writing
+// an HTTP response. Capabilities registered with Execute have their results
+// wrapped in the standard ToolResponse envelope:
+//
+//	{"success": true, "data": {...}, "metadata": {...}, "duration_ms": 42}
+//
+// Returning an *APIError or *ToolError sets the envelope error and HTTP
+// status (see WriteError); any other error is reported as a 503
+// CAPABILITY_ERROR. Error
+// envelopes carry the trace and request IDs, which are also sent in the
+// X-Trace-ID and X-Request-ID headers.
+type CapabilityFunc func(ctx context.Context, input map[string]interface{}) (interface{}, error)
+
+// resultMetadataKey is the context key for ResultMetadata
+type resultMetadataKey struct{}
+
+// SetResultMetadata attaches a metadata value to the envelope of the capability
+// currently executing. It has no effect outside an Execute-based capability.
+func SetResultMetadata(ctx context.Context, key string, value interface{}) {
+	if metadata, ok := ctx.Value(resultMetadataKey{}).(map[string]interface{}); ok {
+		metadata[key] = value
+	}
+}
+
+// ParseToolResponse decodes a response body as a ToolResponse envelope.
+// It returns false when the body is not a JSON object with a boolean
+// "success" field, so raw (non-envelope) responses are easy to detect.
+func ParseToolResponse(body []byte) (*ToolResponse, bool) {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return nil, false
+	}
+	var success bool
+	if raw, ok := probe["success"]; !ok || json.Unmarshal(raw, &success) != nil {
+		return nil, false
+	}
+
+	var response ToolResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, false
+	}
+	return &response, true
+}
+
+// newResultHandler adapts a CapabilityFunc into an HTTP handler that decodes the
+// JSON request body and writes the result as a ToolResponse envelope
+func newResultHandler(cap Capability, logger Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		metadata := make(map[string]interface{})
+		ctx := context.WithValue(r.Context(), resultMetadataKey{}, metadata)
+
+		input := make(map[string]interface{})
+		if r.Body != nil && r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+				response := &ToolResponse{
+					Success: false,
+					Error: &ToolError{
+						Code:     "INVALID_REQUEST",
+						Message:  fmt.Sprintf("invalid JSON request body: %v", err),
+						Category: CategoryInputError,
+					},
+					DurationMs: time.Since(start).Milliseconds(),
+				}
+				withCorrelation(w, r, response)
+				writeToolResponse(w, http.StatusBadRequest, response)
+				return
+			}
+		}
+
+		data, err := cap.Execute(ctx, input)
+		response := &ToolResponse{
+			Success:    err == nil,
+			Data:       data,
+			DurationMs: time.Since(start).Milliseconds(),
+		}
+		if len(metadata) > 0 {
+			response.Metadata = metadata
+		}
+
+		status := http.StatusOK
+		if err != nil {
+			toolErr, errStatus, ok := toolErrorFor(err)
+			if !ok {
+				toolErr = &ToolError{
+					Code:     "CAPABILITY_ERROR",
+					Message:  err.Error(),
+					Category: CategoryServiceError,
+				}
+				errStatus = HTTPStatusForCategory(toolErr.Category)
+			}
+			response.Data = nil
+			response.Error = toolErr
+			status = errStatus
+			withCorrelation(w, r, response)
+
+			if logger != nil {
+				logger.WarnWithContext(ctx, "Capability returned error", map[string]interface{}{
+					"operation":  "capability_execute",
+					"capability": cap.Name,
+					"error_code": toolErr.Code,
+					"category":   string(toolErr.Category),
+					"error":      toolErr.Message,
+					"trace_id":   response.TraceID,
+					"request_id": response.RequestID,
+				})
+			}
+		}
+
+		writeToolResponse(w, status, response)
+	}
+}
+
+func writeToolResponse(w http.ResponseWriter, status int, response *ToolResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response) // Response already committed; nothing to recover
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTypedCapability_WrapsResultEnvelope(t *testing.T) {
+	tool := NewTool("calculator")
+	tool.RegisterCapability(Capability{
+		Name: "add",
+		Execute: func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			a, _ := input["a"].(float64)
+			b, _ := input["b"].(float64)
+			SetResultMetadata(ctx, "source", "local")
+			return map[string]float64{"sum": a + b}, nil
+		},
+	})
+	tool.RegisterCapability(Capability{
+		Name: "lookup",
+		Execute: func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			return nil, &ToolError{Code: "NOT_FOUND", Message: "no such key", Category: CategoryNotFound}
+		},
+	})
+	tool.RegisterCapability(Capability{
+		Name: "flaky",
+		Execute: func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			return nil, errors.New("backend down")
+		},
+	})
+
+	if !tool.Capabilities[0].ResultEnvelope {
+		t.Error("Expected typed capability to advertise ResultEnvelope")
+	}
+
+	call := func(path, body string) (*httptest.ResponseRecorder, *ToolResponse) {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		tool.mux.ServeHTTP(rec, req)
+		envelope, ok := ParseToolResponse(rec.Body.Bytes())
+		if !ok {
+			t.Fatalf("Response is not an envelope: %s", rec.Body.String())
+		}
+		return rec, envelope
+	}
+
+	rec, envelope := call("/api/capabilities/add", `{"a": 2, "b": 3}`)
+	if rec.Code != http.StatusOK || !envelope.Success {
+		t.Fatalf("Expected success, got %d %+v", rec.Code, envelope)
+	}
+	if data, _ := envelope.Data.(map[string]interface{}); data["sum"] != 5.0 {
+		t.Errorf("Unexpected data: %+v", envelope.Data)
+	}
+	if envelope.Metadata["source"] != "local" {
+		t.Errorf("Expected metadata to be propagated, got %+v", envelope.Metadata)
+	}
+
+	rec, envelope = call("/api/capabilities/lookup", `{}`)
+	if rec.Code != http.StatusNotFound || envelope.Success || envelope.Error.Code != "NOT_FOUND" {
+		t.Errorf("Expected NOT_FOUND envelope, got %d %+v", rec.Code, envelope)
+	}
+
+	rec, envelope = call("/api/capabilities/flaky", `{}`)
+	if rec.Code != http.StatusServiceUnavailable || envelope.Error.Category != CategoryServiceError {
+		t.Errorf("Expected SERVICE_ERROR envelope, got %d %+v", rec.Code, envelope)
+	}
+
+	rec, envelope = call("/api/capabilities/add", `not json`)
+	if rec.Code != http.StatusBadRequest || envelope.Error.Category != CategoryInputError {
+		t.Errorf("Expected INPUT_ERROR envelope, got %d %+v", rec.Code, envelope)
+	}
+}
+
+func TestParseToolResponse(t *testing.T) {
+	tests := []struct {
+		body string
+		want bool
+	}{
+		{`{"success": true, "data": {"x": 1}}`, true},
+		{`{"success": false, "error": {"code": "E", "message": "m"}}`, true},
+		{`{"temperature": 20}`, false},
+		{`{"success": "yes"}`, false},
+		{`[1, 2]`, false},
+		{`plain text`, false},
+	}
+	for _, tt := range tests {
+		if _, got := ParseToolResponse([]byte(tt.body)); got != tt.want {
+			t.Errorf("ParseToolResponse(%s) = %v, want %v", tt.body, got, tt.want)
+		}
+	}
+}
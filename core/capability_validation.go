@@ -0,0 +1,109 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// capabilityNamePattern matches lowercase names such as "current-weather".
+// Underscores are accepted too, since snake_case names like "translate_text"
+// are common across existing components.
+var capabilityNamePattern = regexp.MustCompile(`^[a-z0-9]+([-_][a-z0-9]+)*$`)
+
+// validFieldHintTypes are the JSON types a FieldHint may declare
+var validFieldHintTypes = map[string]bool{
+	"string":  true,
+	"number":  true,
+	"integer": true,
+	"boolean": true,
+	"object":  true,
+	"array":   true,
+}
+
+// ValidateCapability checks capability metadata and returns every problem
+// found, or nil when the capability is valid. Each error wraps
+// ErrInvalidCapability.
+//
+// The checks are:
+//   - Name is lowercase kebab-case or snake_case ("fetch-weather", "fetch_weather")
+//   - Description is set, since AI orchestration relies on it
+//   - Endpoint, when set, starts with "/"
+//   - InputSummary/OutputSummary field hints have a name and a JSON type
+//   - Handler and Execute are not both set
+func ValidateCapability(cap Capability) []error {
+	var errs []error
+	invalid := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrInvalidCapability, fmt.Sprintf(format, args...)))
+	}
+
+	switch {
+	case cap.Name == "":
+		invalid("name is required")
+	case !capabilityNamePattern.MatchString(cap.Name):
+		invalid("name %q must be lowercase kebab-case or snake_case", cap.Name)
+	}
+
+	if strings.TrimSpace(cap.Description) == "" {
+		invalid("capability %q: description is required", cap.Name)
+	}
+
+	if cap.Endpoint != "" && !strings.HasPrefix(cap.Endpoint, "/") {
+		invalid("capability %q: endpoint %q must start with \"/\"", cap.Name, cap.Endpoint)
+	}
+
+	if cap.Handler != nil && cap.Execute != nil {
+		invalid("capability %q: set either Handler or Execute, not both", cap.Name)
+	}
+
+	for _, summary := range []struct {
+		label   string
+		summary *SchemaSummary
+	}{
+		{"input_summary", cap.InputSummary},
+		{"output_summary", cap.OutputSummary},
+	} {
+		if summary.summary == nil {
+			continue
+		}
+		hints := append(append([]FieldHint{}, summary.summary.RequiredFields...), summary.summary.OptionalFields...)
+		seen := make(map[string]bool, len(hints))
+		for i, hint := range hints {
+			if hint.Name == "" {
+				invalid("capability %q: %s field %d has no name", cap.Name, summary.label, i)
+				continue
+			}
+			if seen[hint.Name] {
+				invalid("capability %q: %s field %q is declared twice", cap.Name, summary.label, hint.Name)
+			}
+			seen[hint.Name] = true
+			if !validFieldHintTypes[hint.Type] {
+				invalid("capability %q: %s field %q has unknown type %q", cap.Name, summary.label, hint.Name, hint.Type)
+			}
+		}
+	}
+
+	return errs
+}
+
+// RegisterCapabilityStrict validates cap with ValidateCapability and
+// registers it only when it is valid. The returned error joins every
+// validation problem so callers can report them all at once.
+func (b *BaseAgent) RegisterCapabilityStrict(cap Capability) error {
+	if errs := ValidateCapability(cap); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	b.RegisterCapability(cap)
+	return nil
+}
+
+// RegisterCapabilityStrict validates cap with ValidateCapability and
+// registers it only when it is valid. See BaseAgent.RegisterCapabilityStrict.
+func (t *BaseTool) RegisterCapabilityStrict(cap Capability) error {
+	if errs := ValidateCapability(cap); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	t.RegisterCapability(cap)
+	return nil
+}
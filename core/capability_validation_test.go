@@ -0,0 +1,74 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCapability_Valid(t *testing.T) {
+	cap := Capability{
+		Name:        "current-weather",
+		Description: "Returns current weather for a city",
+		Endpoint:    "/api/weather",
+		InputSummary: &SchemaSummary{
+			RequiredFields: []FieldHint{{Name: "city", Type: "string"}},
+			OptionalFields: []FieldHint{{Name: "days", Type: "integer"}},
+		},
+	}
+	assert.Empty(t, ValidateCapability(cap))
+
+	cap.Name = "translate_text"
+	assert.Empty(t, ValidateCapability(cap))
+}
+
+func TestValidateCapability_AccumulatesErrors(t *testing.T) {
+	cap := Capability{
+		Name:     "Current_Weather",
+		Endpoint: "api/weather",
+		Handler:  func(http.ResponseWriter, *http.Request) {},
+		Execute: func(context.Context, map[string]interface{}) (interface{}, error) {
+			return nil, nil
+		},
+		InputSummary: &SchemaSummary{
+			RequiredFields: []FieldHint{{Name: "city", Type: "text"}, {Type: "string"}},
+			OptionalFields: []FieldHint{{Name: "city", Type: "string"}},
+		},
+	}
+
+	errs := ValidateCapability(cap)
+
+	require.Len(t, errs, 7)
+	for _, err := range errs {
+		assert.True(t, errors.Is(err, ErrInvalidCapability))
+	}
+	assert.Contains(t, errs[0].Error(), "lowercase kebab-case")
+	assert.Contains(t, errs[1].Error(), "description is required")
+	assert.Contains(t, errs[2].Error(), "must start with")
+	assert.Contains(t, errs[3].Error(), "not both")
+	assert.Contains(t, errs[4].Error(), `unknown type "text"`)
+	assert.Contains(t, errs[5].Error(), "has no name")
+	assert.Contains(t, errs[6].Error(), "declared twice")
+}
+
+func TestRegisterCapabilityStrict(t *testing.T) {
+	agent := NewBaseAgent("strict-agent")
+
+	err := agent.RegisterCapabilityStrict(Capability{Name: "Bad Name"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidCapability))
+	assert.Contains(t, err.Error(), "description is required")
+	assert.Empty(t, agent.Capabilities, "invalid capabilities are not registered")
+
+	require.NoError(t, agent.RegisterCapabilityStrict(Capability{Name: "forecast", Description: "Weather forecast"}))
+	require.Len(t, agent.Capabilities, 1)
+
+	tool := NewTool("strict-tool")
+	assert.Error(t, tool.RegisterCapabilityStrict(Capability{Name: "", Description: "missing name"}))
+	require.NoError(t, tool.RegisterCapabilityStrict(Capability{Name: "convert", Description: "Unit conversion"}))
+	assert.Len(t, tool.Capabilities, 1)
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultCatalogSnapshotMaxAge is how old a restored entry may be before it
+// is discarded
+const DefaultCatalogSnapshotMaxAge = 5 * time.Minute
+
+// WithCatalogSnapshotFile persists the catalog to path. The file is loaded
+// on Start (or the first Catalog call) so a restarted process serves the
+// previous catalog instead of waiting on discovery, and it is rewritten after
+// every successful refresh.
+func WithCatalogSnapshotFile(path string) CapabilityCatalogOption {
+	return func(c *CapabilityCatalog) {
+		c.snapshotFile = path
+	}
+}
+
+// WithCatalogSnapshotMaxAge sets how old a restored entry may be. Entries
+// last seen before that, or from a snapshot generated before that, are
+// dropped on load and picked up again by the next refresh. Default is
+// DefaultCatalogSnapshotMaxAge.
+func WithCatalogSnapshotMaxAge(age time.Duration) CapabilityCatalogOption {
+	return func(c *CapabilityCatalog) {
+		if age > 0 {
+			c.snapshotMaxAge = age
+		}
+	}
+}
+
+// SaveSnapshot writes the current snapshot to w as JSON
+func (c *CapabilityCatalog) SaveSnapshot(w io.Writer) error {
+	snapshot := c.snapshot.Load()
+	if snapshot == nil {
+		return fmt.Errorf("no capability catalog snapshot to save: %w", ErrNotInitialized)
+	}
+	if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+		return fmt.Errorf("failed to write capability catalog snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadSnapshot replaces the current snapshot with one read from r. Entries
+// older than the snapshot max age are discarded. The restored snapshot is
+// served for one TTL, after which Catalog refreshes from discovery as usual.
+func (c *CapabilityCatalog) LoadSnapshot(r io.Reader) error {
+	var saved CatalogSnapshot
+	if err := json.NewDecoder(r).Decode(&saved); err != nil {
+		return fmt.Errorf("failed to read capability catalog snapshot: %w", err)
+	}
+
+	cutoff := time.Now().Add(-c.snapshotMaxAge)
+	services := make([]*ServiceInfo, 0, len(saved.Services))
+	for _, service := range saved.Services {
+		if service == nil {
+			continue
+		}
+		seen := service.LastSeen
+		if seen.IsZero() {
+			seen = saved.GeneratedAt
+		}
+		if seen.Before(cutoff) {
+			continue
+		}
+		services = append(services, service)
+	}
+
+	c.snapshot.Store(newCatalogSnapshot(services))
+	c.stale.Store(false)
+	c.logger.Info("Capability catalog restored from snapshot", map[string]interface{}{
+		"operation":        "catalog_restore",
+		"service_count":    len(services),
+		"discarded_count":  len(saved.Services) - len(services),
+		"snapshot_created": saved.GeneratedAt,
+	})
+	return nil
+}
+
+// restoreSnapshotFile loads the snapshot file once, if one is configured and
+// exists. It reports whether a snapshot was restored.
+func (c *CapabilityCatalog) restoreSnapshotFile() bool {
+	restored := false
+	c.restoreOnce.Do(func() {
+		if c.snapshotFile == "" {
+			return
+		}
+		file, err := os.Open(c.snapshotFile)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				c.logger.Warn("Failed to open capability catalog snapshot", map[string]interface{}{
+					"operation": "catalog_restore",
+					"path":      c.snapshotFile,
+					"error":     err.Error(),
+				})
+			}
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		if err := c.LoadSnapshot(file); err != nil {
+			c.logger.Warn("Ignoring unreadable capability catalog snapshot", map[string]interface{}{
+				"operation": "catalog_restore",
+				"path":      c.snapshotFile,
+				"error":     err.Error(),
+			})
+			return
+		}
+		restored = true
+	})
+	return restored
+}
+
+// writeSnapshotFile saves the snapshot to the configured file. It writes a
+// temporary file and renames it so a crash never leaves a truncated snapshot.
+func (c *CapabilityCatalog) writeSnapshotFile(ctx context.Context) {
+	if c.snapshotFile == "" {
+		return
+	}
+
+	err := func() error {
+		tmp, err := os.CreateTemp(filepath.Dir(c.snapshotFile), filepath.Base(c.snapshotFile)+".tmp-*")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = os.Remove(tmp.Name()) }()
+
+		if err := c.SaveSnapshot(tmp); err != nil {
+			_ = tmp.Close()
+			return err
+		}
+		if err := tmp.Close(); err != nil {
+			return err
+		}
+		return os.Rename(tmp.Name(), c.snapshotFile)
+	}()
+	if err != nil {
+		c.logger.WarnWithContext(ctx, "Failed to write capability catalog snapshot", map[string]interface{}{
+			"operation": "catalog_persist",
+			"path":      c.snapshotFile,
+			"error":     err.Error(),
+		})
+	}
+}
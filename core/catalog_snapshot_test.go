@@ -0,0 +1,104 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCapabilityCatalog_SaveAndLoadSnapshot(t *testing.T) {
+	ctx := context.Background()
+	source := NewCapabilityCatalog(newCatalogTestDiscovery(t))
+
+	var buf bytes.Buffer
+	if err := source.SaveSnapshot(&buf); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("Expected ErrNotInitialized before the first refresh, got %v", err)
+	}
+	if _, err := source.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if err := source.SaveSnapshot(&buf); err != nil {
+		t.Fatalf("SaveSnapshot failed: %v", err)
+	}
+
+	discovery := newCatalogTestDiscovery(t)
+	discovery.fail.Store(true)
+	restored := NewCapabilityCatalog(discovery)
+	if err := restored.LoadSnapshot(&buf); err != nil {
+		t.Fatalf("LoadSnapshot failed: %v", err)
+	}
+
+	snapshot, err := restored.Catalog(ctx)
+	if err != nil {
+		t.Fatalf("Catalog failed: %v", err)
+	}
+	if len(snapshot.Services) != 2 || len(snapshot.ServicesWithCapability("forecast")) != 1 {
+		t.Errorf("Expected restored services, got %d", len(snapshot.Services))
+	}
+	if calls := discovery.calls.Load(); calls != 0 {
+		t.Errorf("Expected restored snapshot to be served without discovery, got %d calls", calls)
+	}
+}
+
+func TestCapabilityCatalog_LoadSnapshotDiscardsStaleEntries(t *testing.T) {
+	now := time.Now()
+	data, _ := json.Marshal(CatalogSnapshot{
+		GeneratedAt: now.Add(-time.Minute),
+		Services: []*ServiceInfo{
+			{ID: "fresh", Capabilities: []Capability{{Name: "translate"}}, LastSeen: now.Add(-time.Minute)},
+			{ID: "stale", Capabilities: []Capability{{Name: "translate"}}, LastSeen: now.Add(-time.Hour)},
+			{ID: "unknown", Capabilities: []Capability{{Name: "translate"}}},
+		},
+	})
+
+	catalog := NewCapabilityCatalog(newCatalogTestDiscovery(t), WithCatalogSnapshotMaxAge(10*time.Minute))
+	if err := catalog.LoadSnapshot(bytes.NewReader(data)); err != nil {
+		t.Fatalf("LoadSnapshot failed: %v", err)
+	}
+
+	var ids []string
+	for _, service := range catalog.snapshot.Load().Services {
+		ids = append(ids, service.ID)
+	}
+	if len(ids) != 2 || ids[0] != "fresh" || ids[1] != "unknown" {
+		t.Errorf("Expected fresh and unknown to survive, got %v", ids)
+	}
+}
+
+func TestCapabilityCatalog_SnapshotFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "catalog.json")
+
+	first := NewCapabilityCatalog(newCatalogTestDiscovery(t), WithCatalogSnapshotFile(path))
+	if _, err := first.Catalog(ctx); err != nil {
+		t.Fatalf("Catalog failed: %v", err)
+	}
+
+	// A restart while discovery is down still starts with the saved catalog
+	discovery := newCatalogTestDiscovery(t)
+	discovery.fail.Store(true)
+	second := NewCapabilityCatalog(discovery, WithCatalogSnapshotFile(path))
+	if err := second.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer second.Stop()
+
+	snapshot, err := second.Catalog(ctx)
+	if err != nil {
+		t.Fatalf("Catalog failed: %v", err)
+	}
+	if len(snapshot.Services) != 2 {
+		t.Errorf("Expected 2 services from the snapshot file, got %d", len(snapshot.Services))
+	}
+
+	// Without a snapshot file, Start still fails when discovery is down
+	third := NewCapabilityCatalog(discovery, WithCatalogSnapshotFile(filepath.Join(t.TempDir(), "missing.json")))
+	if err := third.Start(ctx); err == nil {
+		third.Stop()
+		t.Error("Expected Start to fail without a snapshot or discovery")
+	}
+}
@@ -0,0 +1,296 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCompositeHeartbeatInterval is how often CompositeDiscovery refreshes
+// backends that have no heartbeat of their own
+const DefaultCompositeHeartbeatInterval = 15 * time.Second
+
+// BackendError is the failure of one backend in a composite operation
+type BackendError struct {
+	Backend int   // Index of the backend passed to NewCompositeDiscovery
+	Err     error // The backend's error
+}
+
+// CompositeError collects per-backend failures. CompositeDiscovery returns it
+// only when every backend failed; partial failures are logged and reported
+// by LastBackendErrors instead.
+type CompositeError struct {
+	Op     string
+	Errors []BackendError
+}
+
+func (e *CompositeError) Error() string {
+	parts := make([]string, len(e.Errors))
+	for i, backendErr := range e.Errors {
+		parts[i] = fmt.Sprintf("backend %d: %v", backendErr.Backend, backendErr.Err)
+	}
+	return fmt.Sprintf("%s failed on all discovery backends: %s", e.Op, strings.Join(parts, "; "))
+}
+
+// Unwrap exposes the backend errors to errors.Is and errors.As
+func (e *CompositeError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, backendErr := range e.Errors {
+		errs[i] = backendErr.Err
+	}
+	return errs
+}
+
+// heartbeater is implemented by backends that keep their own registrations
+// alive, such as RedisRegistry and RedisDiscovery
+type heartbeater interface {
+	StartHeartbeat(ctx context.Context, serviceID string)
+	StopHeartbeat(ctx context.Context, serviceID string)
+}
+
+// CompositeDiscovery combines several discovery backends, for example while
+// migrating from one to another. Writes go to every backend and reads merge
+// their results. An operation only fails when every backend fails; use
+// LastBackendErrors to see which backends failed when some succeeded.
+type CompositeDiscovery struct {
+	backends []Discovery
+	logger   Logger
+
+	lastFailuresMu sync.Mutex
+	lastFailures   []BackendError
+
+	heartbeatInterval time.Duration
+	heartbeatsMu      sync.Mutex
+	heartbeats        map[string]context.CancelFunc
+}
+
+// NewCompositeDiscovery creates a discovery that fans out to backends. When
+// merging results, a service found in several backends is returned once,
+// from the backend with the most recent LastSeen.
+func NewCompositeDiscovery(backends ...Discovery) *CompositeDiscovery {
+	return &CompositeDiscovery{
+		backends:          backends,
+		logger:            &NoOpLogger{},
+		heartbeatInterval: DefaultCompositeHeartbeatInterval,
+		heartbeats:        make(map[string]context.CancelFunc),
+	}
+}
+
+// Backends returns the wrapped backends in order
+func (c *CompositeDiscovery) Backends() []Discovery {
+	return c.backends
+}
+
+// SetLogger sets the logger for the composite and its backends
+func (c *CompositeDiscovery) SetLogger(logger Logger) {
+	if logger == nil {
+		c.logger = &NoOpLogger{}
+	} else if cal, ok := logger.(ComponentAwareLogger); ok {
+		c.logger = cal.WithComponent("framework/core")
+	} else {
+		c.logger = logger
+	}
+	for _, backend := range c.backends {
+		if loggable, ok := backend.(interface{ SetLogger(Logger) }); ok {
+			loggable.SetLogger(logger)
+		}
+	}
+}
+
+// SetHeartbeatInterval sets how often StartHeartbeat refreshes backends
+// without their own heartbeat. Zero restores the default.
+func (c *CompositeDiscovery) SetHeartbeatInterval(interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultCompositeHeartbeatInterval
+	}
+	c.heartbeatInterval = interval
+}
+
+// LastBackendErrors returns the backends that failed in the most recently
+// completed operation, or nil if every backend succeeded. It is how callers
+// notice a partial failure, which the operation itself reports as success.
+// With concurrent operations, "most recent" is whichever finished last.
+func (c *CompositeDiscovery) LastBackendErrors() []BackendError {
+	c.lastFailuresMu.Lock()
+	defer c.lastFailuresMu.Unlock()
+	if len(c.lastFailures) == 0 {
+		return nil
+	}
+	return append([]BackendError(nil), c.lastFailures...)
+}
+
+// each runs fn against every backend and returns a CompositeError if all of
+// them failed. ignore marks errors that count as success.
+func (c *CompositeDiscovery) each(ctx context.Context, op string, fn func(Discovery) error, ignore ...error) error {
+	var failures []BackendError
+	for i, backend := range c.backends {
+		err := fn(backend)
+		for _, target := range ignore {
+			if errors.Is(err, target) {
+				err = nil
+			}
+		}
+		if err != nil {
+			failures = append(failures, BackendError{Backend: i, Err: err})
+		}
+	}
+	return c.result(ctx, op, failures)
+}
+
+// result records failures for LastBackendErrors, logs partial failures and
+// turns total failure into an error
+func (c *CompositeDiscovery) result(ctx context.Context, op string, failures []BackendError) error {
+	c.lastFailuresMu.Lock()
+	c.lastFailures = failures
+	c.lastFailuresMu.Unlock()
+
+	if len(failures) == 0 {
+		return nil
+	}
+	compositeErr := &CompositeError{Op: op, Errors: failures}
+	if len(failures) < len(c.backends) {
+		c.logger.WarnWithContext(ctx, "Discovery backend failed", map[string]interface{}{
+			"operation":       op,
+			"failed_backends": len(failures),
+			"total_backends":  len(c.backends),
+			"error":           compositeErr.Error(),
+		})
+		return nil
+	}
+	return compositeErr
+}
+
+// Register registers the service with every backend
+func (c *CompositeDiscovery) Register(ctx context.Context, info *ServiceInfo) error {
+	return c.each(ctx, "register", func(backend Discovery) error {
+		return backend.Register(ctx, info)
+	})
+}
+
+// UpdateHealth updates the service's health in every backend
+func (c *CompositeDiscovery) UpdateHealth(ctx context.Context, id string, status HealthStatus) error {
+	return c.each(ctx, "update_health", func(backend Discovery) error {
+		return backend.UpdateHealth(ctx, id, status)
+	})
+}
+
+// Unregister removes the service from every backend. Backends that no longer
+// know the service count as success.
+func (c *CompositeDiscovery) Unregister(ctx context.Context, id string) error {
+	return c.each(ctx, "unregister", func(backend Discovery) error {
+		return backend.Unregister(ctx, id)
+	}, ErrServiceNotFound)
+}
+
+// Discover queries every backend and merges the results
+func (c *CompositeDiscovery) Discover(ctx context.Context, filter DiscoveryFilter) ([]*ServiceInfo, error) {
+	merged := make(map[string]*ServiceInfo)
+	var order []string
+	var failures []BackendError
+
+	for i, backend := range c.backends {
+		services, err := backend.Discover(ctx, filter)
+		if err != nil {
+			failures = append(failures, BackendError{Backend: i, Err: err})
+			continue
+		}
+		for _, service := range services {
+			if service == nil {
+				continue
+			}
+			existing, found := merged[service.ID]
+			if !found {
+				order = append(order, service.ID)
+			}
+			if !found || service.LastSeen.After(existing.LastSeen) {
+				merged[service.ID] = service
+			}
+		}
+	}
+
+	if err := c.result(ctx, "discover", failures); err != nil {
+		return nil, err
+	}
+
+	services := make([]*ServiceInfo, 0, len(order))
+	for _, id := range order {
+		services = append(services, merged[id])
+	}
+	return services, nil
+}
+
+// FindService finds services by name across all backends
+func (c *CompositeDiscovery) FindService(ctx context.Context, serviceName string) ([]*ServiceInfo, error) {
+	return c.Discover(ctx, DiscoveryFilter{Name: serviceName})
+}
+
+// FindByCapability finds services by capability across all backends
+func (c *CompositeDiscovery) FindByCapability(ctx context.Context, capability string) ([]*ServiceInfo, error) {
+	return c.Discover(ctx, DiscoveryFilter{Capabilities: []string{capability}})
+}
+
+// StartHeartbeat keeps the service registered in every backend. Backends
+// with their own heartbeat start it; the rest get UpdateHealth calls every
+// heartbeat interval until ctx is cancelled or StopHeartbeat is called.
+func (c *CompositeDiscovery) StartHeartbeat(ctx context.Context, serviceID string) {
+	var passive []Discovery
+	for _, backend := range c.backends {
+		if hb, ok := backend.(heartbeater); ok {
+			hb.StartHeartbeat(ctx, serviceID)
+		} else {
+			passive = append(passive, backend)
+		}
+	}
+	if len(passive) == 0 {
+		return
+	}
+
+	hbCtx, cancel := context.WithCancel(ctx)
+	c.heartbeatsMu.Lock()
+	if previous, ok := c.heartbeats[serviceID]; ok {
+		previous()
+	}
+	c.heartbeats[serviceID] = cancel
+	c.heartbeatsMu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(c.heartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-hbCtx.Done():
+				return
+			case <-ticker.C:
+				for _, backend := range passive {
+					if err := backend.UpdateHealth(hbCtx, serviceID, HealthHealthy); err != nil && hbCtx.Err() == nil {
+						c.logger.WarnWithContext(hbCtx, "Failed to send heartbeat to discovery backend", map[string]interface{}{
+							"service_id": serviceID,
+							"error":      err.Error(),
+						})
+					}
+				}
+			}
+		}
+	}()
+}
+
+// StopHeartbeat stops the heartbeats started by StartHeartbeat
+func (c *CompositeDiscovery) StopHeartbeat(ctx context.Context, serviceID string) {
+	for _, backend := range c.backends {
+		if hb, ok := backend.(heartbeater); ok {
+			hb.StopHeartbeat(ctx, serviceID)
+		}
+	}
+
+	c.heartbeatsMu.Lock()
+	defer c.heartbeatsMu.Unlock()
+	if cancel, ok := c.heartbeats[serviceID]; ok {
+		cancel()
+		delete(c.heartbeats, serviceID)
+	}
+}
+
+var _ Discovery = (*CompositeDiscovery)(nil)
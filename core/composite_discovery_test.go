@@ -0,0 +1,212 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// failingDiscovery is a Discovery whose every call fails
+type failingDiscovery struct {
+	err error
+}
+
+func (f *failingDiscovery) Register(ctx context.Context, info *ServiceInfo) error { return f.err }
+func (f *failingDiscovery) UpdateHealth(ctx context.Context, id string, status HealthStatus) error {
+	return f.err
+}
+func (f *failingDiscovery) Unregister(ctx context.Context, id string) error { return f.err }
+func (f *failingDiscovery) Discover(ctx context.Context, filter DiscoveryFilter) ([]*ServiceInfo, error) {
+	return nil, f.err
+}
+func (f *failingDiscovery) FindService(ctx context.Context, name string) ([]*ServiceInfo, error) {
+	return nil, f.err
+}
+func (f *failingDiscovery) FindByCapability(ctx context.Context, capability string) ([]*ServiceInfo, error) {
+	return nil, f.err
+}
+
+// heartbeatCountingDiscovery counts UpdateHealth calls on top of MockDiscovery
+type heartbeatCountingDiscovery struct {
+	*MockDiscovery
+	updates atomic.Int32
+}
+
+func (c *heartbeatCountingDiscovery) UpdateHealth(ctx context.Context, id string, status HealthStatus) error {
+	c.updates.Add(1)
+	return c.MockDiscovery.UpdateHealth(ctx, id, status)
+}
+
+func TestCompositeDiscoveryRegisterFansOut(t *testing.T) {
+	ctx := context.Background()
+	first, second := NewMockDiscovery(), NewMockDiscovery()
+	composite := NewCompositeDiscovery(first, second)
+
+	info := &ServiceInfo{ID: "weather-1", Name: "weather", Capabilities: []Capability{{Name: "forecast"}}}
+	if err := composite.Register(ctx, info); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	for i, backend := range []*MockDiscovery{first, second} {
+		services, _ := backend.FindByCapability(ctx, "forecast")
+		if len(services) != 1 {
+			t.Errorf("backend %d has %d services, want 1", i, len(services))
+		}
+	}
+}
+
+func TestCompositeDiscoveryPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	healthy := NewMockDiscovery()
+	broken := &failingDiscovery{err: errors.New("connection refused")}
+	composite := NewCompositeDiscovery(broken, healthy)
+
+	info := &ServiceInfo{ID: "weather-1", Name: "weather"}
+	if err := composite.Register(ctx, info); err != nil {
+		t.Fatalf("Register() error = %v, want nil when one backend succeeds", err)
+	}
+
+	services, err := composite.FindService(ctx, "weather")
+	if err != nil {
+		t.Fatalf("FindService() error = %v", err)
+	}
+	if len(services) != 1 || services[0].ID != "weather-1" {
+		t.Errorf("FindService() = %v, want weather-1", services)
+	}
+}
+
+func TestCompositeDiscoveryLastBackendErrorsOnWrite(t *testing.T) {
+	ctx := context.Background()
+	errDown := errors.New("connection refused")
+	broken := &failingDiscovery{err: errDown}
+	composite := NewCompositeDiscovery(NewMockDiscovery(), broken)
+
+	if err := composite.Register(ctx, &ServiceInfo{ID: "weather-1", Name: "weather"}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	failures := composite.LastBackendErrors()
+	if len(failures) != 1 || failures[0].Backend != 1 || !errors.Is(failures[0].Err, errDown) {
+		t.Fatalf("LastBackendErrors() = %+v, want backend 1 with its error", failures)
+	}
+
+	// A fully successful operation clears the report
+	broken.err = nil
+	if err := composite.UpdateHealth(ctx, "weather-1", HealthHealthy); err != nil {
+		t.Fatalf("UpdateHealth() error = %v", err)
+	}
+	if failures := composite.LastBackendErrors(); failures != nil {
+		t.Errorf("LastBackendErrors() = %+v after a full success, want nil", failures)
+	}
+}
+
+func TestCompositeDiscoveryLastBackendErrorsOnRead(t *testing.T) {
+	ctx := context.Background()
+	healthy := NewMockDiscovery()
+	_ = healthy.Register(ctx, &ServiceInfo{ID: "weather-1", Name: "weather"})
+	errDown := errors.New("read timeout")
+	composite := NewCompositeDiscovery(&failingDiscovery{err: errDown}, healthy)
+
+	services, err := composite.FindService(ctx, "weather")
+	if err != nil || len(services) != 1 {
+		t.Fatalf("FindService() = %v, %v; want the healthy backend's result", services, err)
+	}
+	failures := composite.LastBackendErrors()
+	if len(failures) != 1 || failures[0].Backend != 0 || !errors.Is(failures[0].Err, errDown) {
+		t.Errorf("LastBackendErrors() = %+v, want backend 0 with its error", failures)
+	}
+}
+
+func TestCompositeDiscoveryAllBackendsFail(t *testing.T) {
+	ctx := context.Background()
+	errA := errors.New("backend a down")
+	errB := errors.New("backend b down")
+	composite := NewCompositeDiscovery(&failingDiscovery{err: errA}, &failingDiscovery{err: errB})
+
+	err := composite.Register(ctx, &ServiceInfo{ID: "weather-1"})
+	var compositeErr *CompositeError
+	if !errors.As(err, &compositeErr) {
+		t.Fatalf("Register() error = %v, want *CompositeError", err)
+	}
+	if compositeErr.Op != "register" || len(compositeErr.Errors) != 2 {
+		t.Errorf("CompositeError = %+v, want 2 register failures", compositeErr)
+	}
+	if compositeErr.Errors[1].Backend != 1 {
+		t.Errorf("second failure backend = %d, want 1", compositeErr.Errors[1].Backend)
+	}
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("errors.Is should match each backend error, got %v", err)
+	}
+
+	if _, err := composite.Discover(ctx, DiscoveryFilter{}); !errors.As(err, &compositeErr) {
+		t.Errorf("Discover() error = %v, want *CompositeError", err)
+	}
+}
+
+func TestCompositeDiscoveryMergePrefersFreshest(t *testing.T) {
+	ctx := context.Background()
+	first, second := NewMockDiscovery(), NewMockDiscovery()
+	composite := NewCompositeDiscovery(first, second)
+
+	now := time.Now()
+	_ = first.Register(ctx, &ServiceInfo{ID: "weather-1", Name: "weather", Address: "old", LastSeen: now.Add(-time.Minute)})
+	_ = second.Register(ctx, &ServiceInfo{ID: "weather-1", Name: "weather", Address: "new", LastSeen: now})
+	_ = second.Register(ctx, &ServiceInfo{ID: "weather-2", Name: "weather", LastSeen: now})
+
+	services, err := composite.FindService(ctx, "weather")
+	if err != nil {
+		t.Fatalf("FindService() error = %v", err)
+	}
+	if len(services) != 2 {
+		t.Fatalf("FindService() returned %d services, want 2", len(services))
+	}
+	for _, service := range services {
+		if service.ID == "weather-1" && service.Address != "new" {
+			t.Errorf("weather-1 address = %q, want the freshest registration", service.Address)
+		}
+	}
+}
+
+func TestCompositeDiscoveryUnregisterIgnoresNotFound(t *testing.T) {
+	ctx := context.Background()
+	first, second := NewMockDiscovery(), NewMockDiscovery()
+	composite := NewCompositeDiscovery(first, second)
+
+	_ = first.Register(ctx, &ServiceInfo{ID: "weather-1", Name: "weather"})
+	if err := composite.Unregister(ctx, "weather-1"); err != nil {
+		t.Fatalf("Unregister() error = %v", err)
+	}
+	if err := composite.Unregister(ctx, "weather-1"); err != nil {
+		t.Errorf("second Unregister() error = %v, want nil", err)
+	}
+}
+
+func TestCompositeDiscoveryHeartbeatUpdatesAllBackends(t *testing.T) {
+	ctx := context.Background()
+	first := &heartbeatCountingDiscovery{MockDiscovery: NewMockDiscovery()}
+	second := &heartbeatCountingDiscovery{MockDiscovery: NewMockDiscovery()}
+	composite := NewCompositeDiscovery(first, second)
+	composite.SetHeartbeatInterval(10 * time.Millisecond)
+
+	if err := composite.Register(ctx, &ServiceInfo{ID: "weather-1", Name: "weather"}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	composite.StartHeartbeat(ctx, "weather-1")
+
+	deadline := time.Now().Add(time.Second)
+	for (first.updates.Load() == 0 || second.updates.Load() == 0) && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	composite.StopHeartbeat(ctx, "weather-1")
+
+	if first.updates.Load() == 0 || second.updates.Load() == 0 {
+		t.Fatalf("heartbeats = %d/%d, want both backends updated", first.updates.Load(), second.updates.Load())
+	}
+
+	stopped := first.updates.Load()
+	time.Sleep(50 * time.Millisecond)
+	if first.updates.Load() > stopped+1 {
+		t.Errorf("heartbeats continued after StopHeartbeat")
+	}
+}
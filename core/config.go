@@ -10,6 +10,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -67,8 +69,29 @@ type Config struct {
 	// Kubernetes specific configuration
 	Kubernetes KubernetesConfig `json:"kubernetes"`
 
+	// Feature flags, loaded from GOMIND_FEATURE_<NAME> environment variables.
+	// Read them with FeatureEnabled so values stay consistent across reloads.
+	Features map[string]bool `json:"features,omitempty"`
+
+	// ReloadOnSignal makes Framework.Run re-read environment configuration on
+	// SIGHUP. Off by default so applications keep their own SIGHUP handling.
+	ReloadOnSignal bool `json:"reload_on_signal" env:"GOMIND_RELOAD_ON_SIGNAL" default:"false"`
+
 	// Logger instance for configuration operations (excluded from JSON)
 	logger Logger `json:"-"`
+
+	// configFiles are the files requested with WithConfigFile, loaded by
+	// NewConfig beneath environment variables and options
+	configFiles []string
+
+	// unknownConfigKeys holds config file keys to warn about once a logger exists
+	unknownConfigKeys map[string][]string
+
+	// loggerOptions customize the logger created when none is supplied
+	loggerOptions []LoggerOption
+
+	// reloadMu guards the fields Framework.Reload changes (see enableReload)
+	reloadMu *sync.RWMutex
 }
 
 // HTTPConfig contains HTTP server configuration including timeouts, limits, and CORS settings.
@@ -95,6 +118,22 @@ type HTTPConfig struct {
 	// Note: This field is excluded from JSON serialization as middleware functions
 	// cannot be serialized.
 	Middleware []func(http.Handler) http.Handler `json:"-"`
+
+	// DisablePanicRecovery lets handler panics crash the process instead of
+	// returning a 500, so they surface in a debugger (see WithPanicRecovery)
+	DisablePanicRecovery bool `json:"disable_panic_recovery" env:"GOMIND_HTTP_DISABLE_PANIC_RECOVERY"`
+
+	// RateLimit throttles each caller of each capability (see WithRateLimit)
+	RateLimit RateLimit `json:"rate_limit"`
+
+	// TrustedMetadataKeys are the request metadata keys restored from the
+	// baggage header of incoming requests (see WithTrustedRequestMetadata).
+	// Empty restores none.
+	TrustedMetadataKeys []string `json:"trusted_metadata_keys" env:"GOMIND_HTTP_TRUSTED_METADATA_KEYS" default:"request_id,user_id,tenant_id"`
+
+	// ReadinessChecks run on every request to /health/ready, after the
+	// component has initialized and registered. Added with WithReadinessCheck.
+	ReadinessChecks []ReadinessCheck `json:"-"`
 }
 
 // CORSConfig contains Cross-Origin Resource Sharing (CORS) configuration.
@@ -110,6 +149,9 @@ type CORSConfig struct {
 	ExposedHeaders   []string `json:"exposed_headers" env:"GOMIND_CORS_EXPOSED_HEADERS"`
 	AllowCredentials bool     `json:"allow_credentials" env:"GOMIND_CORS_CREDENTIALS" default:"false"`
 	MaxAge           int      `json:"max_age" env:"GOMIND_CORS_MAX_AGE" default:"86400"`
+
+	// reloadMu is the owning Config's reload lock, read by snapshot
+	reloadMu *sync.RWMutex
 }
 
 // DiscoveryConfig contains service discovery configuration.
@@ -127,6 +169,23 @@ type DiscoveryConfig struct {
 	// Retry configuration for handling initial connection failures
 	RetryOnFailure bool          `json:"retry_on_failure" env:"GOMIND_DISCOVERY_RETRY" default:"false"`
 	RetryInterval  time.Duration `json:"retry_interval" env:"GOMIND_DISCOVERY_RETRY_INTERVAL" default:"30s"`
+
+	// RegistrationCheckInterval controls how often a registered component
+	// verifies its own entry is still discoverable and re-registers if it was
+	// evicted. Zero disables the check.
+	RegistrationCheckInterval time.Duration `json:"registration_check_interval" env:"GOMIND_DISCOVERY_REGISTRATION_CHECK" default:"60s"`
+
+	// HeartbeatJitter varies each heartbeat interval by up to this fraction
+	// (0.1 = ±10%) so replicas don't heartbeat in lockstep. RegistrationStagger
+	// is the most a component waits before registering at startup.
+	HeartbeatJitter     float64       `json:"heartbeat_jitter" env:"GOMIND_DISCOVERY_HEARTBEAT_JITTER" default:"0.1"`
+	RegistrationStagger time.Duration `json:"registration_stagger" env:"GOMIND_DISCOVERY_REGISTRATION_STAGGER" default:"1s"`
+
+	// DeregisterOnShutdown makes Framework.Run unregister the component and
+	// stop its server on SIGTERM, SIGINT or context cancellation, waiting at
+	// most DeregisterTimeout for discovery
+	DeregisterOnShutdown bool          `json:"deregister_on_shutdown" env:"GOMIND_DISCOVERY_DEREGISTER_ON_SHUTDOWN" default:"true"`
+	DeregisterTimeout    time.Duration `json:"deregister_timeout" env:"GOMIND_DISCOVERY_DEREGISTER_TIMEOUT" default:"5s"`
 }
 
 // AIConfig contains AI client configuration for LLM integration.
@@ -144,6 +203,10 @@ type AIConfig struct {
 	Timeout       time.Duration `json:"timeout" env:"GOMIND_AI_TIMEOUT" default:"180s"`
 	RetryAttempts int           `json:"retry_attempts" env:"GOMIND_AI_RETRY_ATTEMPTS" default:"3"`
 	RetryDelay    time.Duration `json:"retry_delay" env:"GOMIND_AI_RETRY_DELAY" default:"1s"`
+
+	// DebugRecorder, when set, records the agent's direct AI calls
+	// (see WithAIDebugRecorder)
+	DebugRecorder AIInteractionRecorder `json:"-"`
 }
 
 // TelemetryConfig contains observability configuration for metrics and distributed tracing.
@@ -158,6 +221,9 @@ type TelemetryConfig struct {
 	TracingEnabled bool    `json:"tracing_enabled" env:"GOMIND_TELEMETRY_TRACING" default:"true"`
 	SamplingRate   float64 `json:"sampling_rate" env:"GOMIND_TELEMETRY_SAMPLING_RATE" default:"1.0"`
 	Insecure       bool    `json:"insecure" env:"GOMIND_TELEMETRY_INSECURE" default:"true"`
+	// PrometheusPath is where agents and tools serve metrics for Prometheus
+	// to scrape. Empty means no endpoint (see WithPrometheusEndpoint).
+	PrometheusPath string `json:"prometheus_path" env:"GOMIND_TELEMETRY_PROMETHEUS_PATH"`
 }
 
 // MemoryConfig contains state storage configuration.
@@ -169,6 +235,9 @@ type MemoryConfig struct {
 	MaxSize         int           `json:"max_size" env:"GOMIND_MEMORY_MAX_SIZE" default:"1000"`
 	DefaultTTL      time.Duration `json:"default_ttl" env:"GOMIND_MEMORY_DEFAULT_TTL" default:"1h"`
 	CleanupInterval time.Duration `json:"cleanup_interval" env:"GOMIND_MEMORY_CLEANUP_INTERVAL" default:"10m"`
+	// Namespaced prefixes an agent's memory keys with its name so agents
+	// sharing a backend can't see each other's keys
+	Namespaced bool `json:"namespaced" env:"GOMIND_MEMORY_NAMESPACED" default:"true"`
 }
 
 // ResilienceConfig contains fault tolerance and resilience patterns configuration.
@@ -215,6 +284,13 @@ type LoggingConfig struct {
 	Format     string `json:"format" env:"GOMIND_LOG_FORMAT" default:"json"`
 	Output     string `json:"output" env:"GOMIND_LOG_OUTPUT" default:"stdout"`
 	TimeFormat string `json:"time_format" env:"GOMIND_LOG_TIME_FORMAT" default:"2006-01-02T15:04:05.000Z07:00"`
+
+	// Sampling: when SampleInitial > 0, only the first SampleInitial lines with
+	// the same message (or LogSampleKeyField) are written per SampleInterval.
+	// The rest are dropped and summarized by the first line after the interval.
+	// Errors are never sampled. Disabled by default.
+	SampleInitial  int           `json:"sample_initial" env:"GOMIND_LOG_SAMPLE_INITIAL" default:"0"`
+	SampleInterval time.Duration `json:"sample_interval" env:"GOMIND_LOG_SAMPLE_INTERVAL" default:"1s"`
 }
 
 // DevelopmentConfig contains settings for local development and testing.
@@ -285,6 +361,9 @@ func DefaultConfig() *Config {
 			ShutdownTimeout:   10 * time.Second,
 			EnableHealthCheck: true,
 			HealthCheckPath:   "/health",
+			// The keys the orchestration executors send, so metadata set
+			// with WithRequestMetadata follows a request between components
+			TrustedMetadataKeys: []string{"request_id", "user_id", "tenant_id"},
 			CORS: CORSConfig{
 				Enabled:          false,
 				AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
@@ -302,6 +381,12 @@ func DefaultConfig() *Config {
 			TTL:               30 * time.Second,
 			RetryOnFailure:    false, // Disabled by default, opt-in
 			RetryInterval:     30 * time.Second,
+
+			RegistrationCheckInterval: 60 * time.Second,
+			HeartbeatJitter:           DefaultHeartbeatJitter,
+			RegistrationStagger:       DefaultRegistrationStagger,
+			DeregisterOnShutdown:      true,
+			DeregisterTimeout:         DefaultDeregisterTimeout,
 		},
 		AI: AIConfig{
 			Enabled:       false,
@@ -326,6 +411,7 @@ func DefaultConfig() *Config {
 			MaxSize:         1000,
 			DefaultTTL:      1 * time.Hour,
 			CleanupInterval: 10 * time.Minute,
+			Namespaced:      true,
 		},
 		Resilience: ResilienceConfig{
 			CircuitBreaker: CircuitBreakerConfig{
@@ -346,10 +432,11 @@ func DefaultConfig() *Config {
 			},
 		},
 		Logging: LoggingConfig{
-			Level:      "info",
-			Format:     "json",
-			Output:     "stdout",
-			TimeFormat: time.RFC3339Nano,
+			Level:          "info",
+			Format:         "json",
+			Output:         "stdout",
+			TimeFormat:     time.RFC3339Nano,
+			SampleInterval: time.Second,
 		},
 		Development: DevelopmentConfig{
 			Enabled:       false,
@@ -494,6 +581,29 @@ func (c *Config) LoadFromEnv() error {
 			c.HTTP.WriteTimeout = d
 		}
 	}
+	if v := os.Getenv("GOMIND_HTTP_SHUTDOWN_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			c.HTTP.ShutdownTimeout = d
+		}
+	}
+	if v := os.Getenv("GOMIND_RATE_LIMIT_RPS"); v != "" {
+		if rps, err := strconv.ParseFloat(v, 64); err == nil && rps >= 0 {
+			c.HTTP.RateLimit.RPS = rps
+		}
+	}
+	if v := os.Getenv("GOMIND_RATE_LIMIT_BURST"); v != "" {
+		if burst, err := strconv.Atoi(v); err == nil && burst >= 0 {
+			c.HTTP.RateLimit.Burst = burst
+		}
+	}
+
+	if v := os.Getenv("GOMIND_HTTP_DISABLE_PANIC_RECOVERY"); v != "" {
+		c.HTTP.DisablePanicRecovery = parseBool(v)
+	}
+
+	if v := os.Getenv("GOMIND_HTTP_TRUSTED_METADATA_KEYS"); v != "" {
+		c.HTTP.TrustedMetadataKeys = parseStringList(v)
+	}
 
 	// CORS settings
 	if v := os.Getenv("GOMIND_CORS_ENABLED"); v != "" {
@@ -574,6 +684,57 @@ func (c *Config) LoadFromEnv() error {
 			})
 		}
 	}
+	if v := os.Getenv("GOMIND_DISCOVERY_REGISTRATION_CHECK"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			c.Discovery.RegistrationCheckInterval = d
+			envVarsLoaded++
+			if c.logger != nil {
+				c.logger.Debug("Configuration loaded", map[string]interface{}{
+					"setting": "discovery_registration_check",
+					"source":  "GOMIND_DISCOVERY_REGISTRATION_CHECK",
+					"value":   d.String(),
+				})
+			}
+		} else if c.logger != nil {
+			c.logger.Warn("Invalid registration check interval in environment variable", map[string]interface{}{
+				"GOMIND_DISCOVERY_REGISTRATION_CHECK": v,
+				"error":                               err.Error(),
+			})
+		}
+	}
+	if v := os.Getenv("GOMIND_DISCOVERY_HEARTBEAT_JITTER"); v != "" {
+		if jitter, err := strconv.ParseFloat(v, 64); err == nil && jitter >= 0 && jitter <= maxHeartbeatJitter {
+			c.Discovery.HeartbeatJitter = jitter
+			envVarsLoaded++
+		} else if c.logger != nil {
+			c.logger.Warn("Invalid heartbeat jitter in environment variable", map[string]interface{}{
+				"GOMIND_DISCOVERY_HEARTBEAT_JITTER": v,
+			})
+		}
+	}
+	if v := os.Getenv("GOMIND_DISCOVERY_DEREGISTER_ON_SHUTDOWN"); v != "" {
+		c.Discovery.DeregisterOnShutdown = parseBool(v)
+	}
+	if v := os.Getenv("GOMIND_DISCOVERY_DEREGISTER_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			c.Discovery.DeregisterTimeout = d
+			envVarsLoaded++
+		} else if c.logger != nil {
+			c.logger.Warn("Invalid deregister timeout in environment variable", map[string]interface{}{
+				"GOMIND_DISCOVERY_DEREGISTER_TIMEOUT": v,
+			})
+		}
+	}
+	if v := os.Getenv("GOMIND_DISCOVERY_REGISTRATION_STAGGER"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			c.Discovery.RegistrationStagger = d
+			envVarsLoaded++
+		} else if c.logger != nil {
+			c.logger.Warn("Invalid registration stagger in environment variable", map[string]interface{}{
+				"GOMIND_DISCOVERY_REGISTRATION_STAGGER": v,
+			})
+		}
+	}
 
 	// AI settings
 	if v := os.Getenv("GOMIND_AI_ENABLED"); v != "" {
@@ -639,6 +800,19 @@ func (c *Config) LoadFromEnv() error {
 	} else if c.Telemetry.ServiceName == "" {
 		c.Telemetry.ServiceName = c.Name // Default to agent name
 	}
+	if v := os.Getenv("GOMIND_TELEMETRY_SAMPLING_RATE"); v != "" {
+		if rate, err := strconv.ParseFloat(v, 64); err == nil && rate >= 0 && rate <= 1 {
+			c.Telemetry.SamplingRate = rate
+			envVarsLoaded++
+		} else if c.logger != nil {
+			c.logger.Warn("Invalid sampling rate in environment variable", map[string]interface{}{
+				"GOMIND_TELEMETRY_SAMPLING_RATE": v,
+			})
+		}
+	}
+	if v := os.Getenv("GOMIND_TELEMETRY_PROMETHEUS_PATH"); v != "" {
+		c.Telemetry.PrometheusPath = v
+	}
 
 	// Memory settings
 	if v := os.Getenv("GOMIND_MEMORY_PROVIDER"); v != "" {
@@ -647,6 +821,9 @@ func (c *Config) LoadFromEnv() error {
 	if v := os.Getenv("GOMIND_MEMORY_REDIS_URL"); v != "" {
 		c.Memory.RedisURL = v
 	}
+	if v := os.Getenv("GOMIND_MEMORY_NAMESPACED"); v != "" {
+		c.Memory.Namespaced = parseBool(v)
+	}
 
 	// Logging settings
 	if v := os.Getenv("GOMIND_LOG_LEVEL"); v != "" {
@@ -655,6 +832,24 @@ func (c *Config) LoadFromEnv() error {
 	if v := os.Getenv("GOMIND_LOG_FORMAT"); v != "" {
 		c.Logging.Format = v
 	}
+	if v := os.Getenv("GOMIND_LOG_SAMPLE_INITIAL"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			c.Logging.SampleInitial = n
+		} else if c.logger != nil {
+			c.logger.Warn("Invalid log sample count in environment variable", map[string]interface{}{
+				"GOMIND_LOG_SAMPLE_INITIAL": v,
+			})
+		}
+	}
+	if v := os.Getenv("GOMIND_LOG_SAMPLE_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			c.Logging.SampleInterval = d
+		} else if c.logger != nil {
+			c.logger.Warn("Invalid log sample interval in environment variable", map[string]interface{}{
+				"GOMIND_LOG_SAMPLE_INTERVAL": v,
+			})
+		}
+	}
 
 	// Development settings
 	if v := os.Getenv("GOMIND_DEV_MODE"); v != "" {
@@ -678,6 +873,22 @@ func (c *Config) LoadFromEnv() error {
 		}
 	}
 
+	// Feature flags and reload settings
+	if features := loadFeatureFlagsFromEnv(); len(features) > 0 {
+		merged := make(map[string]bool, len(c.Features)+len(features))
+		for name, enabled := range c.Features {
+			merged[name] = enabled
+		}
+		for name, enabled := range features {
+			merged[name] = enabled
+		}
+		c.Features = merged
+		envVarsLoaded += len(features)
+	}
+	if v := os.Getenv("GOMIND_RELOAD_ON_SIGNAL"); v != "" {
+		c.ReloadOnSignal = parseBool(v)
+	}
+
 	// Kubernetes settings (auto-detect)
 	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
 		c.Kubernetes.Enabled = true
@@ -734,9 +945,13 @@ func (c *Config) LoadFromEnv() error {
 	return nil
 }
 
-// LoadFromFile loads configuration from a JSON file.
-// The file should contain a JSON object matching the Config struct.
-// File settings override environment variables but are overridden by functional options.
+// LoadFromFile loads configuration from a JSON (.json) or YAML (.yaml,
+// .yml) file into c, overriding the values already there. Keys match the
+// Config struct's json tags in both formats and durations may be written as
+// strings ("30s"). Unknown keys are logged as a warning and ignored.
+//
+// To layer a file beneath environment variables and options, use
+// WithConfigFile instead.
 //
 // Example JSON:
 //
@@ -826,46 +1041,38 @@ func (c *Config) LoadFromFile(path string) error {
 		})
 	}
 
-	// Parse based on extension
-	switch ext {
-	case ".json":
-		if c.logger != nil {
-			c.logger.Debug("Parsing JSON configuration file", map[string]interface{}{
-				"file_path": cleanPath,
-			})
-		}
-
-		if err := json.Unmarshal(data, c); err != nil {
-			if c.logger != nil {
-				c.logger.Error("Failed to parse JSON config file", map[string]interface{}{
-					"error":      err,
-					"error_type": fmt.Sprintf("%T", err),
-					"file_path":  cleanPath,
-					"file_size":  len(data),
-				})
-			}
-			return fmt.Errorf("failed to parse JSON config file: %w", ErrInvalidConfiguration)
-		}
+	format := "JSON"
+	if ext != ".json" {
+		format = "YAML"
+	}
+	if c.logger != nil {
+		c.logger.Debug("Parsing configuration file", map[string]interface{}{
+			"file_path": cleanPath,
+			"format":    format,
+		})
+	}
 
+	unknown, err := c.decodeConfigFile(data, ext)
+	if err != nil {
 		if c.logger != nil {
-			c.logger.Info("Configuration file loaded successfully", map[string]interface{}{
-				"file_path": cleanPath,
-				"format":    "JSON",
-				"file_size": len(data),
+			c.logger.Error("Failed to parse config file", map[string]interface{}{
+				"error":      err,
+				"error_type": fmt.Sprintf("%T", err),
+				"file_path":  cleanPath,
+				"format":     format,
+				"file_size":  len(data),
 			})
 		}
+		return fmt.Errorf("failed to parse %s config file %s: %v: %w", format, cleanPath, err, ErrInvalidConfiguration)
+	}
+	c.logUnknownConfigKeys(cleanPath, unknown)
 
-	case ".yaml", ".yml":
-		if c.logger != nil {
-			c.logger.Error("YAML configuration files not supported", map[string]interface{}{
-				"file_path":         cleanPath,
-				"extension":         ext,
-				"supported_formats": []string{".json"},
-			})
-		}
-		// For YAML support, we'd need to import gopkg.in/yaml.v3
-		// For now, return an error for YAML files
-		return fmt.Errorf("YAML config files not yet supported: %w", ErrInvalidConfiguration)
+	if c.logger != nil {
+		c.logger.Info("Configuration file loaded successfully", map[string]interface{}{
+			"file_path": cleanPath,
+			"format":    format,
+			"file_size": len(data),
+		})
 	}
 
 	return nil
@@ -952,6 +1159,41 @@ func parseStringList(s string) []string {
 	return result
 }
 
+// featureEnvPrefix is the environment variable prefix for feature flags
+const featureEnvPrefix = "GOMIND_FEATURE_"
+
+// loadFeatureFlagsFromEnv collects GOMIND_FEATURE_<NAME> variables.
+// Names are lowercased, so GOMIND_FEATURE_NEW_PLANNER=true enables "new_planner".
+func loadFeatureFlagsFromEnv() map[string]bool {
+	var features map[string]bool
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, featureEnvPrefix) {
+			continue
+		}
+		name, value, _ := strings.Cut(strings.TrimPrefix(kv, featureEnvPrefix), "=")
+		if name == "" {
+			continue
+		}
+		if features == nil {
+			features = make(map[string]bool)
+		}
+		features[strings.ToLower(name)] = parseBool(value)
+	}
+	return features
+}
+
+// WithFeature sets a feature flag. Environment variables loaded on reload
+// take precedence over flags set here.
+func WithFeature(name string, enabled bool) Option {
+	return func(c *Config) error {
+		if c.Features == nil {
+			c.Features = make(map[string]bool)
+		}
+		c.Features[strings.ToLower(name)] = enabled
+		return nil
+	}
+}
+
 // parseBool converts a string to a boolean value.
 // Accepts: "true", "1", "yes", "on" (case-insensitive) as true.
 // Everything else is false.
@@ -1161,6 +1403,145 @@ func WithDiscoveryCacheEnabled(enabled bool) Option {
 	}
 }
 
+// WithRegistrationCheckInterval sets how often a registered component checks
+// that it is still discoverable and re-registers if its entry was evicted.
+// Pass 0 to disable the check.
+func WithRegistrationCheckInterval(interval time.Duration) Option {
+	return func(c *Config) error {
+		c.Discovery.RegistrationCheckInterval = interval
+		return nil
+	}
+}
+
+// WithHeartbeatJitter sets how much each discovery heartbeat interval
+// varies, as a fraction between 0 and 0.5. Pass 0 for fixed intervals.
+func WithHeartbeatJitter(fraction float64) Option {
+	return func(c *Config) error {
+		if fraction < 0 || fraction > maxHeartbeatJitter {
+			return fmt.Errorf("heartbeat jitter %v must be between 0 and %v: %w", fraction, maxHeartbeatJitter, ErrInvalidConfiguration)
+		}
+		c.Discovery.HeartbeatJitter = fraction
+		return nil
+	}
+}
+
+// WithRegistrationStagger sets the most a component waits, at random, before
+// registering with discovery. Pass 0 to register immediately.
+func WithRegistrationStagger(maxDelay time.Duration) Option {
+	return func(c *Config) error {
+		c.Discovery.RegistrationStagger = maxDelay
+		return nil
+	}
+}
+
+// WithDeregisterOnShutdown controls whether Framework.Run unregisters the
+// component and stops its server on SIGTERM, SIGINT or context
+// cancellation. timeout bounds the wait for discovery; 0 keeps the current
+// value.
+func WithDeregisterOnShutdown(enabled bool, timeout time.Duration) Option {
+	return func(c *Config) error {
+		c.Discovery.DeregisterOnShutdown = enabled
+		if timeout > 0 {
+			c.Discovery.DeregisterTimeout = timeout
+		}
+		return nil
+	}
+}
+
+// WithShutdownTimeout sets how long Framework.Run waits for in-flight
+// requests to finish when shutting down before closing their connections.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(c *Config) error {
+		if timeout <= 0 {
+			return fmt.Errorf("shutdown timeout must be positive, got %v: %w", timeout, ErrInvalidConfiguration)
+		}
+		c.HTTP.ShutdownTimeout = timeout
+		return nil
+	}
+}
+
+// WithTrustedRequestMetadata sets the request metadata keys restored from
+// the baggage header of incoming requests, so handlers, their logs and their
+// spans carry the values an upstream component set with WithRequestMetadata.
+// It replaces the default of request_id, user_id and tenant_id. Any caller
+// can send a baggage header, so components reachable by untrusted callers
+// should narrow the list to keys whose values are safe as log fields and
+// metric labels, or call it with no keys to restore none.
+//
+// Example:
+//
+//	core.WithTrustedRequestMetadata("request_id") // only the request ID
+//	core.WithTrustedRequestMetadata()             // none
+func WithTrustedRequestMetadata(keys ...string) Option {
+	return func(c *Config) error {
+		c.HTTP.TrustedMetadataKeys = keys
+		return nil
+	}
+}
+
+// WithRateLimit limits every capability endpoint to rps requests per second
+// per caller, with bursts of up to burst requests. Callers are identified by
+// their remote IP. Throttled requests get a 429
+// with a Retry-After header. Capability.RateLimit overrides the limit for a
+// single capability. Pass rps 0 to disable.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Config) error {
+		if rps < 0 || burst < 0 {
+			return fmt.Errorf("rate limit must not be negative, got %g rps burst %d: %w", rps, burst, ErrInvalidConfiguration)
+		}
+		c.HTTP.RateLimit = RateLimit{RPS: rps, Burst: burst}
+		return nil
+	}
+}
+
+// WithAIDebugRecorder records every call an agent makes through its AI
+// client to recorder, against the request ID in the call's context, so
+// direct AI calls show up in the LLM debug store next to the orchestrator's.
+// The agent's AI client is wrapped with NewRecordingAIClient during
+// Initialize.
+//
+// Example:
+//
+//	store, _ := orchestration.NewRedisLLMDebugStore()
+//	framework, _ := core.NewFramework(agent,
+//	    core.WithAIDebugRecorder(orchestration.NewLLMDebugRecorder(store)),
+//	)
+func WithAIDebugRecorder(recorder AIInteractionRecorder) Option {
+	return func(c *Config) error {
+		c.AI.DebugRecorder = recorder
+		return nil
+	}
+}
+
+// WithPanicRecovery controls whether handler panics are recovered. Recovery
+// is on by default: a panicking capability is logged with its stack, counted
+// as http.handler.panics and answered with a 500, and the process keeps
+// serving. Disable it while debugging to let the panic crash the process.
+func WithPanicRecovery(enabled bool) Option {
+	return func(c *Config) error {
+		c.HTTP.DisablePanicRecovery = !enabled
+		return nil
+	}
+}
+
+// WithReadinessCheck adds a named check to the readiness endpoint
+// (/health/ready). The component only reports ready while every check
+// returns nil; each check is bounded by ReadinessCheckTimeout.
+//
+// Example:
+//
+//	core.WithReadinessCheck("redis", redisClient.HealthCheck),
+//	core.WithReadinessCheck("dependencies", agent.DependenciesReady("weather-tool")),
+func WithReadinessCheck(name string, fn func(ctx context.Context) error) Option {
+	return func(c *Config) error {
+		if name == "" || fn == nil {
+			return fmt.Errorf("readiness check needs a name and a function: %w", ErrInvalidConfiguration)
+		}
+		c.HTTP.ReadinessChecks = append(c.HTTP.ReadinessChecks, ReadinessCheck{Name: name, Check: fn})
+		return nil
+	}
+}
+
 // WithOpenAIAPIKey sets the OpenAI API key and automatically enables AI features.
 // The key should be a valid OpenAI API key starting with "sk-".
 // This is a convenience method equivalent to:
@@ -1225,6 +1606,32 @@ func WithTelemetry(enabled bool, endpoint string) Option {
 	}
 }
 
+// WithPrometheusEndpoint serves the framework's metrics at path, in the
+// Prometheus exposition format, on every agent and tool. An empty path
+// uses DefaultPrometheusPath ("/metrics").
+//
+// The metrics come from the telemetry module, which must be initialized
+// with its "prometheus" provider; until it is, the endpoint responds
+// 503 Service Unavailable.
+//
+// Example:
+//
+//	core.NewFramework(agent,
+//	    core.WithPrometheusEndpoint(""),
+//	)
+func WithPrometheusEndpoint(path string) Option {
+	return func(c *Config) error {
+		if path == "" {
+			path = DefaultPrometheusPath
+		}
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("prometheus endpoint path %q must start with /", path)
+		}
+		c.Telemetry.PrometheusPath = path
+		return nil
+	}
+}
+
 // WithEnableMetrics enables or disables metrics collection.
 // Metrics include request counts, latencies, error rates, etc.
 // Requires telemetry to be enabled with an endpoint.
@@ -1270,7 +1677,8 @@ func WithOTELEndpoint(endpoint string) Option {
 
 // WithLogLevel sets the minimum logging level.
 // Valid levels (from least to most verbose):
-//   - "error": Only errors
+//   - "fatal": Only fatal messages (logged right before the process exits)
+//   - "error": Errors and above
 //   - "warn": Warnings and above
 //   - "info": Informational messages and above (default)
 //   - "debug": Debug messages and above
@@ -1296,6 +1704,21 @@ func WithLogFormat(format string) Option {
 	}
 }
 
+// WithLogSampling limits repeated log lines: only the first `initial` lines
+// with the same message are written per interval, and a summary line reports
+// how many were suppressed. Error logs are never sampled. An initial of 0
+// disables sampling.
+func WithLogSampling(initial int, interval time.Duration) Option {
+	return func(c *Config) error {
+		if initial < 0 {
+			return fmt.Errorf("log sample count must be non-negative, got %d", initial)
+		}
+		c.Logging.SampleInitial = initial
+		c.Logging.SampleInterval = interval
+		return nil
+	}
+}
+
 // WithMemoryProvider sets the state storage provider.
 // Valid providers:
 //   - "inmemory": Local in-memory storage (default, not distributed)
@@ -1309,6 +1732,17 @@ func WithMemoryProvider(provider string) Option {
 	}
 }
 
+// WithMemoryNamespacing controls whether BaseAgent prefixes its memory keys
+// with the agent name (enabled by default). Disable it for agents that must
+// keep reading keys written before namespacing, or that deliberately share
+// keys with other agents.
+func WithMemoryNamespacing(enabled bool) Option {
+	return func(c *Config) error {
+		c.Memory.Namespaced = enabled
+		return nil
+	}
+}
+
 // WithCircuitBreaker enables the circuit breaker pattern for fault tolerance.
 // Parameters:
 //   - threshold: Number of consecutive failures before opening the circuit
@@ -1356,15 +1790,23 @@ func WithKubernetes(serviceDiscovery, leaderElection bool) Option {
 	}
 }
 
-// WithConfigFile loads configuration from a JSON file.
-// The file path can be absolute or relative to the working directory.
-// File configuration is applied before other options, so options
-// can override file settings.
+// WithConfigFile loads configuration from a JSON or YAML file (see
+// LoadFromFile). The file path can be absolute or relative to the working
+// directory.
+//
+// The file sits beneath environment variables and options, whatever its
+// position among the options:
+//
+//	explicit options > environment variables > config file > defaults
 //
-// This is useful for complex configurations or environment-specific settings.
+// When given more than once, later files override earlier ones. Unknown
+// keys are logged as a warning and ignored.
+//
+// This is useful for shipping one config.yaml per environment.
 func WithConfigFile(path string) Option {
 	return func(c *Config) error {
-		return c.LoadFromFile(path)
+		c.configFiles = append(c.configFiles, path)
+		return nil
 	}
 }
 
@@ -1445,9 +1887,10 @@ func WithLogger(logger Logger) Option {
 // NewConfig creates a new configuration with the provided options.
 // Configuration is applied in the following order:
 //  1. Default values from DefaultConfig()
-//  2. Environment variables via LoadFromEnv()
-//  3. Functional options (highest priority)
-//  4. Validation via Validate()
+//  2. Config files from WithConfigFile
+//  3. Environment variables via LoadFromEnv()
+//  4. Functional options (highest priority)
+//  5. Validation via Validate()
 //
 // Returns an error if any option fails or if the final configuration is invalid.
 //
@@ -1462,23 +1905,21 @@ func WithLogger(logger Logger) Option {
 //	    return err
 //	}
 func NewConfig(opts ...Option) (*Config, error) {
-	// Start with defaults
-	cfg := DefaultConfig()
-
-	// Load from environment first (includes validation per spec)
-	if err := cfg.LoadFromEnv(); err != nil {
-		return nil, fmt.Errorf("failed to load env config: %w", err)
+	cfg, err := buildConfig(nil, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	// Apply functional options (these override env vars)
-	for _, opt := range opts {
-		if err := opt(cfg); err != nil {
-			return nil, fmt.Errorf("failed to apply option: %w", err)
+	// Config files rank below env vars and options, so once the options
+	// have named them, rebuild with the files loaded first
+	if files := cfg.configFiles; len(files) > 0 {
+		if cfg, err = buildConfig(files, opts); err != nil {
+			return nil, err
 		}
 	}
 
 	if cfg.logger == nil {
-		logger := NewProductionLogger(cfg.Logging, cfg.Development, cfg.Name)
+		logger := NewProductionLogger(cfg.Logging, cfg.Development, cfg.Name, cfg.loggerOptions...)
 
 		// Track for metrics enabling when telemetry available
 		if prodLogger, ok := logger.(*ProductionLogger); ok {
@@ -1487,6 +1928,7 @@ func NewConfig(opts ...Option) (*Config, error) {
 
 		cfg.logger = logger
 	}
+	cfg.flushUnknownConfigKeys()
 
 	// Validate final configuration after options applied
 	if err := cfg.Validate(); err != nil {
@@ -1496,6 +1938,31 @@ func NewConfig(opts ...Option) (*Config, error) {
 	return cfg, nil
 }
 
+// buildConfig layers defaults, files, environment variables and options
+func buildConfig(files []string, opts []Option) (*Config, error) {
+	// Start with defaults
+	cfg := DefaultConfig()
+
+	for _, path := range files {
+		if err := cfg.LoadFromFile(path); err != nil {
+			return nil, fmt.Errorf("failed to load config file: %w", err)
+		}
+	}
+
+	// Load from environment (includes validation per spec)
+	if err := cfg.LoadFromEnv(); err != nil {
+		return nil, fmt.Errorf("failed to load env config: %w", err)
+	}
+
+	// Apply functional options (these override env vars)
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
 // ============================================================================
 // ProductionLogger Implementation - Layered Observability Architecture
 // ============================================================================
@@ -1510,8 +1977,10 @@ const (
 	LogLevelInfo
 	// LogLevelWarn is for warnings and potential issues
 	LogLevelWarn
-	// LogLevelError is the highest severity - only critical errors
+	// LogLevelError is for failures that need attention
 	LogLevelError
+	// LogLevelFatal is the highest severity - the process exits after logging
+	LogLevelFatal
 )
 
 // parseLogLevel converts string level to LogLevel enum
@@ -1524,6 +1993,8 @@ func parseLogLevel(level string) LogLevel {
 		return LogLevelWarn
 	case "error":
 		return LogLevelError
+	case "fatal":
+		return LogLevelFatal
 	case "info", "":
 		return LogLevelInfo
 	default:
@@ -1534,16 +2005,23 @@ func parseLogLevel(level string) LogLevel {
 
 // ProductionLogger provides layered observability for framework operations
 type ProductionLogger struct {
-	level          LogLevel // Numeric level for efficient comparison
+	level          LogLevel      // Numeric level for efficient comparison
+	sharedLevel    *atomic.Int32 // Shared with child loggers so SetLevel reaches all components
 	serviceName    string
 	component      string // Component identifier (e.g., "framework/core", "agent/<name>", "tool/<name>")
 	format         string
 	output         io.Writer
-	metricsEnabled bool // Metrics layer (enabled when telemetry available)
+	metricsEnabled bool        // Metrics layer (enabled when telemetry available)
+	sampler        *logSampler // Shared with child loggers; nil when sampling is off
+	now            func() time.Time
+	exit           func(code int) // Called by Fatal; os.Exit unless overridden
+	fatalExitCode  int
 }
 
-// NewProductionLogger creates a logger from LoggingConfig
-func NewProductionLogger(logging LoggingConfig, dev DevelopmentConfig, serviceName string) Logger {
+// NewProductionLogger creates a logger from LoggingConfig. Options can
+// redirect output (WithLogWriter, WithLogWriters) or inject a clock
+// (WithLogTimeFunc).
+func NewProductionLogger(logging LoggingConfig, dev DevelopmentConfig, serviceName string, opts ...LoggerOption) Logger {
 	var output io.Writer = os.Stdout
 	if logging.Output == "stderr" {
 		output = os.Stderr
@@ -1555,14 +2033,29 @@ func NewProductionLogger(logging LoggingConfig, dev DevelopmentConfig, serviceNa
 		level = LogLevelDebug
 	}
 
-	return &ProductionLogger{
+	sharedLevel := &atomic.Int32{}
+	sharedLevel.Store(int32(level))
+
+	p := &ProductionLogger{
 		level:          level,
+		sharedLevel:    sharedLevel,
 		serviceName:    serviceName,
 		component:      "framework/core", // Default component for framework internals
 		format:         logging.Format,
-		output:         output,
+		output:         newSyncWriter(output),
 		metricsEnabled: false, // Enabled by telemetry module when available
+		sampler:        newLogSampler(logging.SampleInitial, logging.SampleInterval),
+		now:            time.Now,
+		exit:           os.Exit,
+		fatalExitCode:  1,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.sampler != nil {
+		p.sampler.now = p.now
 	}
+	return p
 }
 
 // EnableMetrics is called by telemetry module to enable metrics layer
@@ -1584,14 +2077,39 @@ func (p *ProductionLogger) EnableMetrics() {
 func (p *ProductionLogger) WithComponent(component string) Logger {
 	return &ProductionLogger{
 		level:          p.level,
+		sharedLevel:    p.sharedLevel,
 		serviceName:    p.serviceName,
 		component:      component,
 		format:         p.format,
 		output:         p.output,
 		metricsEnabled: p.metricsEnabled,
+		sampler:        p.sampler,
+		now:            p.now,
+		exit:           p.exit,
+		fatalExitCode:  p.fatalExitCode,
 	}
 }
 
+// SetLevel changes the minimum log level at runtime. The change applies to
+// this logger and every component logger derived from it via WithComponent,
+// which is what makes log level changes effective on config reload.
+func (p *ProductionLogger) SetLevel(level string) {
+	parsed := parseLogLevel(level)
+	if p.sharedLevel != nil {
+		p.sharedLevel.Store(int32(parsed))
+		return
+	}
+	p.level = parsed
+}
+
+// enabled reports whether messages at the given level should be logged
+func (p *ProductionLogger) enabled(level LogLevel) bool {
+	if p.sharedLevel != nil {
+		return LogLevel(p.sharedLevel.Load()) <= level
+	}
+	return p.level <= level
+}
+
 // GetComponent returns the current component identifier for this logger.
 // This is useful for testing and debugging to verify the correct component
 // was set during logger creation.
@@ -1601,61 +2119,110 @@ func (p *ProductionLogger) GetComponent() string {
 
 // Debug logs debug-level messages (only when level is Debug)
 func (p *ProductionLogger) Debug(msg string, fields map[string]interface{}) {
-	if p.level <= LogLevelDebug {
+	if p.enabled(LogLevelDebug) {
 		p.logEvent("DEBUG", msg, fields, nil)
 	}
 }
 
 // Info logs informational messages (when level is Info or Debug)
 func (p *ProductionLogger) Info(msg string, fields map[string]interface{}) {
-	if p.level <= LogLevelInfo {
+	if p.enabled(LogLevelInfo) {
 		p.logEvent("INFO", msg, fields, nil)
 	}
 }
 
 // InfoWithContext logs informational messages with context
 func (p *ProductionLogger) InfoWithContext(ctx context.Context, msg string, fields map[string]interface{}) {
-	if p.level <= LogLevelInfo {
+	if p.enabled(LogLevelInfo) {
 		p.logEvent("INFO", msg, fields, ctx)
 	}
 }
 
 // Warn logs warning messages (when level is Warn, Info, or Debug)
 func (p *ProductionLogger) Warn(msg string, fields map[string]interface{}) {
-	if p.level <= LogLevelWarn {
+	if p.enabled(LogLevelWarn) {
 		p.logEvent("WARN", msg, fields, nil)
 	}
 }
 
-// Error logs error messages (always logged - highest severity)
+// Error logs error messages (logged at every level except fatal)
 func (p *ProductionLogger) Error(msg string, fields map[string]interface{}) {
-	// Errors always log regardless of level (highest severity)
-	p.logEvent("ERROR", msg, fields, nil)
+	if p.enabled(LogLevelError) {
+		p.logEvent("ERROR", msg, fields, nil)
+	}
 }
 
 // ErrorWithContext logs error messages with context
 func (p *ProductionLogger) ErrorWithContext(ctx context.Context, msg string, fields map[string]interface{}) {
-	// Errors always log regardless of level (highest severity)
-	p.logEvent("ERROR", msg, fields, ctx)
+	if p.enabled(LogLevelError) {
+		p.logEvent("ERROR", msg, fields, ctx)
+	}
+}
+
+// Fatal logs at FATAL level and then exits the process with the configured
+// exit code (1 unless set with WithLogFatalExitCode). Fatal messages are
+// always written, whatever the level.
+func (p *ProductionLogger) Fatal(msg string, fields map[string]interface{}) {
+	p.fatal(nil, msg, fields)
+}
+
+// FatalWithContext logs at FATAL level with context and then exits
+func (p *ProductionLogger) FatalWithContext(ctx context.Context, msg string, fields map[string]interface{}) {
+	p.fatal(ctx, msg, fields)
+}
+
+func (p *ProductionLogger) fatal(ctx context.Context, msg string, fields map[string]interface{}) {
+	p.logEvent("FATAL", msg, fields, ctx)
+	exit := p.exit
+	if exit == nil {
+		exit = os.Exit
+	}
+	code := p.fatalExitCode
+	if code == 0 {
+		code = 1
+	}
+	exit(code)
 }
 
 // WarnWithContext logs warning messages with context for request correlation
 func (p *ProductionLogger) WarnWithContext(ctx context.Context, msg string, fields map[string]interface{}) {
-	if p.level <= LogLevelWarn {
+	if p.enabled(LogLevelWarn) {
 		p.logEvent("WARN", msg, fields, ctx)
 	}
 }
 
 // DebugWithContext logs debug information with context for request correlation
 func (p *ProductionLogger) DebugWithContext(ctx context.Context, msg string, fields map[string]interface{}) {
-	if p.level <= LogLevelDebug {
+	if p.enabled(LogLevelDebug) {
 		p.logEvent("DEBUG", msg, fields, ctx)
 	}
 }
 
-// Core logging implementation with all three layers
+// logEvent applies sampling, then writes the event
 func (p *ProductionLogger) logEvent(level, msg string, fields map[string]interface{}, ctx context.Context) {
-	timestamp := time.Now().Format(time.RFC3339)
+	if p.sampler != nil && level != "ERROR" && level != "FATAL" {
+		allowed, suppressed := p.sampler.allow(level, msg, fields)
+		for _, s := range suppressed {
+			p.writeEvent(s.level, fmt.Sprintf("Suppressed %d similar log messages", s.suppressed), map[string]interface{}{
+				LogSampleKeyField: s.key,
+				"suppressed":      s.suppressed,
+			}, nil)
+		}
+		if !allowed {
+			return
+		}
+	}
+	p.writeEvent(level, msg, fields, ctx)
+}
+
+// Core logging implementation with all three layers
+func (p *ProductionLogger) writeEvent(level, msg string, fields map[string]interface{}, ctx context.Context) {
+	fields = RedactFields(fields, GetRedactor())
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+	timestamp := now().Format(time.RFC3339)
 
 	if p.format == "json" {
 		// Structured logging for production log aggregation
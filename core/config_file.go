@@ -0,0 +1,116 @@
+package core
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// decodeConfigFile parses a JSON or YAML config file into c. Keys follow the
+// Config struct's json tags in both formats, and durations may be written as
+// strings such as "30s". Keys that match no setting are skipped and returned
+// (as dotted paths) so the caller can warn about them.
+func (c *Config) decodeConfigFile(data []byte, ext string) ([]string, error) {
+	var raw map[string]interface{}
+	var err error
+	if ext == ".json" {
+		err = json.Unmarshal(data, &raw)
+	} else {
+		err = yaml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	normalizeConfigKeys(raw, reflect.TypeOf(*c), "", &unknown)
+	sort.Strings(unknown)
+
+	normalized, err := json.Marshal(raw)
+	if err != nil {
+		return unknown, err
+	}
+	return unknown, json.Unmarshal(normalized, c)
+}
+
+// normalizeConfigKeys walks raw alongside the struct type t. It removes keys
+// that t has no field for, recording them in unknown, and turns duration
+// strings into the nanosecond counts encoding/json expects.
+func normalizeConfigKeys(raw map[string]interface{}, t reflect.Type, prefix string, unknown *[]string) {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		// encoding/json matches keys case-insensitively
+		fields[strings.ToLower(name)] = field.Type
+	}
+
+	for key, value := range raw {
+		fieldType, ok := fields[strings.ToLower(key)]
+		if !ok {
+			*unknown = append(*unknown, prefix+key)
+			delete(raw, key)
+			continue
+		}
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		switch {
+		case fieldType == durationType:
+			if s, ok := value.(string); ok {
+				if d, err := time.ParseDuration(s); err == nil {
+					raw[key] = int64(d)
+				}
+			}
+		case fieldType.Kind() == reflect.Struct:
+			if nested, ok := value.(map[string]interface{}); ok {
+				normalizeConfigKeys(nested, fieldType, prefix+key+".", unknown)
+			}
+		}
+	}
+}
+
+// logUnknownConfigKeys warns about config file keys that match no setting.
+// Without a logger yet (NewConfig loads files before creating it) the
+// warning is kept until flushUnknownConfigKeys.
+func (c *Config) logUnknownConfigKeys(path string, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	if c.logger == nil {
+		if c.unknownConfigKeys == nil {
+			c.unknownConfigKeys = make(map[string][]string)
+		}
+		c.unknownConfigKeys[path] = keys
+		return
+	}
+	c.logger.Warn("Ignoring unknown keys in config file", map[string]interface{}{
+		"file_path":    path,
+		"unknown_keys": keys,
+	})
+}
+
+// flushUnknownConfigKeys logs the warnings kept by logUnknownConfigKeys
+func (c *Config) flushUnknownConfigKeys() {
+	pending := c.unknownConfigKeys
+	c.unknownConfigKeys = nil
+	for path, keys := range pending {
+		c.logUnknownConfigKeys(path, keys)
+	}
+}
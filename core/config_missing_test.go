@@ -152,24 +152,23 @@ func TestLoadFromFile_MissingCoverage(t *testing.T) {
 		}
 	})
 
-	// Test with YAML file (should return error as YAML not supported)
-	t.Run("YAML file not supported", func(t *testing.T) {
+	// Test with YAML file
+	t.Run("YAML file", func(t *testing.T) {
 		config := DefaultConfig()
 		tempDir := t.TempDir()
 		yamlFile := filepath.Join(tempDir, "config.yaml")
 
-		// Create YAML file (content doesn't matter as YAML is not supported)
 		yamlContent := `name: "test"`
 		err := os.WriteFile(yamlFile, []byte(yamlContent), 0644)
 		if err != nil {
 			t.Fatalf("Failed to create test file: %v", err)
 		}
 
-		err = config.LoadFromFile(yamlFile)
-
-		// Should return an error for YAML files
-		if err == nil {
-			t.Error("LoadFromFile() should return error for YAML files (not supported)")
+		if err := config.LoadFromFile(yamlFile); err != nil {
+			t.Errorf("LoadFromFile() should load YAML files, got %v", err)
+		}
+		if config.Name != "test" {
+			t.Errorf("Expected name from the YAML file, got %q", config.Name)
 		}
 	})
 
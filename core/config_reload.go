@@ -0,0 +1,301 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// enableReload gives c the lock that guards the fields Framework.Reload may
+// change while the server is handling requests: CORS settings, the log
+// level, the telemetry sampling rate and feature flags. The CORS settings
+// share it because the CORS middleware only holds a *CORSConfig. Configs
+// without a lock are never reloaded, so reading them needs no locking.
+func (c *Config) enableReload() {
+	if c.reloadMu == nil {
+		c.reloadMu = &sync.RWMutex{}
+	}
+	c.HTTP.CORS.reloadMu = c.reloadMu
+}
+
+// readLock read-locks mu, if set, and returns the matching unlock
+func readLock(mu *sync.RWMutex) func() {
+	if mu == nil {
+		return func() {}
+	}
+	mu.RLock()
+	return mu.RUnlock
+}
+
+// ConfigChange describes a single setting whose value differs after a reload
+type ConfigChange struct {
+	Setting  string `json:"setting"`
+	Previous string `json:"previous"`
+	Current  string `json:"current"`
+}
+
+// ConfigReloadResult reports the outcome of a configuration reload.
+// Applied changes are live immediately; RequiresRestart changes were
+// detected in the environment but ignored until the process restarts.
+type ConfigReloadResult struct {
+	Applied         []ConfigChange `json:"applied,omitempty"`
+	RequiresRestart []ConfigChange `json:"requires_restart,omitempty"`
+	HookErrors      []string       `json:"hook_errors,omitempty"`
+}
+
+// HasChanges reports whether the reload found any differences
+func (r *ConfigReloadResult) HasChanges() bool {
+	return len(r.Applied) > 0 || len(r.RequiresRestart) > 0
+}
+
+// ConfigReloadHook is called after the reloadable settings have been applied.
+// previous is a snapshot taken before the reload; current is the live config.
+// Hooks let other modules pick up reloaded values, e.g. toggling behaviour
+// behind current.FeatureEnabled.
+type ConfigReloadHook func(ctx context.Context, previous, current *Config) error
+
+// FeatureEnabled reports whether the named feature flag is enabled.
+// Names are case-insensitive. Unknown flags are disabled.
+func (c *Config) FeatureEnabled(name string) bool {
+	defer readLock(c.reloadMu)()
+	return c.Features[strings.ToLower(name)]
+}
+
+// snapshot returns a copy of the CORS configuration that is safe to read
+// while a reload is in progress. Reloads replace slices rather than mutating
+// them, so a shallow copy is sufficient.
+func (c *CORSConfig) snapshot() CORSConfig {
+	defer readLock(c.reloadMu)()
+	return *c
+}
+
+// OnConfigReload registers a hook that runs after every successful reload
+func (f *Framework) OnConfigReload(hook ConfigReloadHook) {
+	f.reloadMu.Lock()
+	defer f.reloadMu.Unlock()
+	f.reloadHooks = append(f.reloadHooks, hook)
+}
+
+// Reload re-reads environment-backed configuration and applies the subset
+// that can change without dropping connections:
+//   - Log level (GOMIND_LOG_LEVEL, GOMIND_DEBUG)
+//   - Telemetry sampling rate (GOMIND_TELEMETRY_SAMPLING_RATE), when the
+//     telemetry module is initialized with a ratio sampler
+//   - CORS origins, methods, headers and credentials (GOMIND_CORS_*)
+//   - Feature flags (GOMIND_FEATURE_*)
+//
+// Changes to settings that are bound at startup (port, address, discovery,
+// memory and AI providers, ...) are reported in RequiresRestart and left
+// untouched. Variables that have been unset keep their current values.
+// If the new environment fails validation, nothing is applied.
+func (f *Framework) Reload(ctx context.Context) (*ConfigReloadResult, error) {
+	f.reloadMu.Lock()
+	defer f.reloadMu.Unlock()
+
+	f.config.reloadMu.RLock()
+	previous := cloneConfigForReload(f.config)
+	f.config.reloadMu.RUnlock()
+
+	candidate := cloneConfigForReload(previous)
+	if err := candidate.LoadFromEnv(); err != nil {
+		if f.config.logger != nil {
+			f.config.logger.ErrorWithContext(ctx, "Configuration reload rejected", map[string]interface{}{
+				"operation": "config_reload",
+				"error":     err.Error(),
+			})
+		}
+		if registry := GetGlobalMetricsRegistry(); registry != nil {
+			registry.Counter("config.reloads", "result", "rejected")
+		}
+		return nil, fmt.Errorf("failed to reload configuration: %w", err)
+	}
+
+	result := &ConfigReloadResult{
+		Applied:         diffReloadableSettings(previous, candidate),
+		RequiresRestart: diffRestartSettings(previous, candidate),
+	}
+
+	// The sampling rate is applied through the telemetry module's sampler;
+	// without one it only takes effect on restart
+	samplingRate := previous.Telemetry.SamplingRate
+	if change := appendChange(nil, "telemetry.sampling_rate",
+		strconv.FormatFloat(previous.Telemetry.SamplingRate, 'g', -1, 64),
+		strconv.FormatFloat(candidate.Telemetry.SamplingRate, 'g', -1, 64)); change != nil {
+		if sampler, ok := GetGlobalMetricsRegistry().(interface{ SetSamplingRate(float64) bool }); ok &&
+			sampler.SetSamplingRate(candidate.Telemetry.SamplingRate) {
+			samplingRate = candidate.Telemetry.SamplingRate
+			result.Applied = append(result.Applied, change...)
+		} else {
+			result.RequiresRestart = append(result.RequiresRestart, change...)
+		}
+	}
+
+	f.config.reloadMu.Lock()
+	f.config.Logging.Level = candidate.Logging.Level
+	f.config.Development.DebugLogging = candidate.Development.DebugLogging
+	f.config.HTTP.CORS.AllowedOrigins = candidate.HTTP.CORS.AllowedOrigins
+	f.config.HTTP.CORS.AllowedMethods = candidate.HTTP.CORS.AllowedMethods
+	f.config.HTTP.CORS.AllowedHeaders = candidate.HTTP.CORS.AllowedHeaders
+	f.config.HTTP.CORS.AllowCredentials = candidate.HTTP.CORS.AllowCredentials
+	f.config.Telemetry.SamplingRate = samplingRate
+	f.config.Features = candidate.Features
+	f.config.reloadMu.Unlock()
+
+	if leveled, ok := f.config.logger.(interface{ SetLevel(string) }); ok {
+		leveled.SetLevel(effectiveLogLevel(f.config))
+	}
+
+	for _, hook := range f.reloadHooks {
+		if err := hook(ctx, previous, f.config); err != nil {
+			result.HookErrors = append(result.HookErrors, err.Error())
+		}
+	}
+
+	if f.config.logger != nil {
+		f.config.logger.InfoWithContext(ctx, "Configuration reloaded", map[string]interface{}{
+			"operation":        "config_reload",
+			"applied":          changedSettings(result.Applied),
+			"requires_restart": changedSettings(result.RequiresRestart),
+			"hook_errors":      len(result.HookErrors),
+		})
+		if len(result.RequiresRestart) > 0 {
+			f.config.logger.WarnWithContext(ctx, "Some configuration changes require a restart", map[string]interface{}{
+				"operation": "config_reload",
+				"settings":  changedSettings(result.RequiresRestart),
+			})
+		}
+	}
+	if registry := GetGlobalMetricsRegistry(); registry != nil {
+		registry.Counter("config.reloads", "result", "applied")
+	}
+
+	return result, nil
+}
+
+// watchReloadSignal reloads configuration whenever the process receives SIGHUP.
+// The returned function stops watching.
+func (f *Framework) watchReloadSignal(ctx context.Context) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case <-signals:
+				// Errors are logged by Reload; a bad environment must not stop the server
+				_, _ = f.Reload(ctx)
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// cloneConfigForReload copies a config, duplicating the feature flag map so
+// the copy can be modified without affecting the original
+func cloneConfigForReload(c *Config) *Config {
+	clone := *c
+	if c.Features != nil {
+		clone.Features = make(map[string]bool, len(c.Features))
+		for name, enabled := range c.Features {
+			clone.Features[name] = enabled
+		}
+	}
+	return &clone
+}
+
+// effectiveLogLevel mirrors NewProductionLogger: debug settings override the configured level
+func effectiveLogLevel(c *Config) string {
+	if c.Development.Enabled || c.Development.DebugLogging {
+		return "debug"
+	}
+	return c.Logging.Level
+}
+
+// diffReloadableSettings lists changes that Reload applies in place
+func diffReloadableSettings(prev, next *Config) []ConfigChange {
+	var changes []ConfigChange
+	changes = appendChange(changes, "logging.level", effectiveLogLevel(prev), effectiveLogLevel(next))
+	changes = appendChange(changes, "http.cors.allowed_origins",
+		strings.Join(prev.HTTP.CORS.AllowedOrigins, ","), strings.Join(next.HTTP.CORS.AllowedOrigins, ","))
+	changes = appendChange(changes, "http.cors.allowed_methods",
+		strings.Join(prev.HTTP.CORS.AllowedMethods, ","), strings.Join(next.HTTP.CORS.AllowedMethods, ","))
+	changes = appendChange(changes, "http.cors.allowed_headers",
+		strings.Join(prev.HTTP.CORS.AllowedHeaders, ","), strings.Join(next.HTTP.CORS.AllowedHeaders, ","))
+	changes = appendChange(changes, "http.cors.allow_credentials",
+		strconv.FormatBool(prev.HTTP.CORS.AllowCredentials), strconv.FormatBool(next.HTTP.CORS.AllowCredentials))
+
+	names := make(map[string]struct{}, len(prev.Features)+len(next.Features))
+	for name := range prev.Features {
+		names[name] = struct{}{}
+	}
+	for name := range next.Features {
+		names[name] = struct{}{}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	for _, name := range sorted {
+		changes = appendChange(changes, "features."+name,
+			strconv.FormatBool(prev.Features[name]), strconv.FormatBool(next.Features[name]))
+	}
+	return changes
+}
+
+// diffRestartSettings lists changes that are bound at startup and ignored by Reload.
+// Secrets such as API keys are reported as changed without their values.
+func diffRestartSettings(prev, next *Config) []ConfigChange {
+	var changes []ConfigChange
+	changes = appendChange(changes, "name", prev.Name, next.Name)
+	changes = appendChange(changes, "port", strconv.Itoa(prev.Port), strconv.Itoa(next.Port))
+	changes = appendChange(changes, "address", prev.Address, next.Address)
+	changes = appendChange(changes, "namespace", prev.Namespace, next.Namespace)
+	changes = appendChange(changes, "http.cors.enabled",
+		strconv.FormatBool(prev.HTTP.CORS.Enabled), strconv.FormatBool(next.HTTP.CORS.Enabled))
+	changes = appendChange(changes, "discovery.enabled",
+		strconv.FormatBool(prev.Discovery.Enabled), strconv.FormatBool(next.Discovery.Enabled))
+	changes = appendChange(changes, "discovery.redis_url", prev.Discovery.RedisURL, next.Discovery.RedisURL)
+	changes = appendChange(changes, "memory.provider", prev.Memory.Provider, next.Memory.Provider)
+	changes = appendChange(changes, "memory.redis_url", prev.Memory.RedisURL, next.Memory.RedisURL)
+	changes = appendChange(changes, "ai.model", prev.AI.Model, next.AI.Model)
+	changes = appendChange(changes, "ai.base_url", prev.AI.BaseURL, next.AI.BaseURL)
+	if prev.AI.APIKey != next.AI.APIKey {
+		changes = append(changes, ConfigChange{Setting: "ai.api_key", Previous: "[redacted]", Current: "[redacted]"})
+	}
+	changes = appendChange(changes, "telemetry.enabled",
+		strconv.FormatBool(prev.Telemetry.Enabled), strconv.FormatBool(next.Telemetry.Enabled))
+	changes = appendChange(changes, "telemetry.endpoint", prev.Telemetry.Endpoint, next.Telemetry.Endpoint)
+	changes = appendChange(changes, "logging.format", prev.Logging.Format, next.Logging.Format)
+	return changes
+}
+
+func appendChange(changes []ConfigChange, setting, prev, next string) []ConfigChange {
+	if prev == next {
+		return changes
+	}
+	return append(changes, ConfigChange{Setting: setting, Previous: prev, Current: next})
+}
+
+func changedSettings(changes []ConfigChange) []string {
+	settings := make([]string, len(changes))
+	for i, change := range changes {
+		settings[i] = change.Setting
+	}
+	return settings
+}
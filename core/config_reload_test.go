@@ -0,0 +1,160 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFramework_Reload(t *testing.T) {
+	t.Setenv("GOMIND_LOG_LEVEL", "info")
+	t.Setenv("GOMIND_DEV_MODE", "false")
+	t.Setenv("GOMIND_CORS_ORIGINS", "https://old.example.com")
+
+	agent := NewBaseAgent("reload-agent")
+	framework, err := NewFramework(agent, WithPort(8080), WithFeature("NewPlanner", false))
+	if err != nil {
+		t.Fatalf("NewFramework() error = %v", err)
+	}
+
+	var hookPrevious, hookCurrent string
+	framework.OnConfigReload(func(ctx context.Context, previous, current *Config) error {
+		hookPrevious = previous.Logging.Level
+		hookCurrent = current.Logging.Level
+		return nil
+	})
+
+	t.Setenv("GOMIND_LOG_LEVEL", "error")
+	t.Setenv("GOMIND_CORS_ORIGINS", "https://new.example.com")
+	t.Setenv("GOMIND_TELEMETRY_SAMPLING_RATE", "0.25")
+	t.Setenv("GOMIND_FEATURE_NEW_PLANNER", "true")
+	t.Setenv("GOMIND_PORT", "9090")
+
+	result, err := framework.Reload(context.Background())
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	applied := strings.Join(changedSettings(result.Applied), ",")
+	for _, setting := range []string{"logging.level", "http.cors.allowed_origins", "features.new_planner"} {
+		if !strings.Contains(applied, setting) {
+			t.Errorf("Expected %s to be applied, got %s", setting, applied)
+		}
+	}
+	if restart := strings.Join(changedSettings(result.RequiresRestart), ","); restart != "port,telemetry.sampling_rate" {
+		t.Errorf("Expected port and telemetry.sampling_rate to require restart, got %s", restart)
+	}
+
+	cfg := framework.config
+	if cfg.Port != 8080 {
+		t.Errorf("Port changed to %d; it must not be reloaded", cfg.Port)
+	}
+	if cfg.Telemetry.SamplingRate != 1.0 {
+		t.Errorf("SamplingRate changed to %v without a sampler to apply it", cfg.Telemetry.SamplingRate)
+	}
+	if !cfg.FeatureEnabled("new_planner") || !cfg.FeatureEnabled("NEW_PLANNER") {
+		t.Error("Expected new_planner feature to be enabled")
+	}
+	if hookPrevious != "info" || hookCurrent != "error" {
+		t.Errorf("Hook saw %q -> %q, want info -> error", hookPrevious, hookCurrent)
+	}
+
+	// CORS middleware reads the reloaded origins on the next request
+	handler := CORSMiddleware(&cfg.HTTP.CORS)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	cfg.HTTP.CORS.Enabled = true
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://new.example.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Header().Get("Access-Control-Allow-Origin") != "https://new.example.com" {
+		t.Error("Expected reloaded CORS origin to be allowed")
+	}
+}
+
+// samplingMetricsRegistry accepts sampling rate changes, as the telemetry
+// module's registry does when its sampler has a ratio
+type samplingMetricsRegistry struct {
+	mockMetricsRegistry
+	rate float64
+}
+
+func (r *samplingMetricsRegistry) SetSamplingRate(rate float64) bool {
+	r.rate = rate
+	return true
+}
+
+func TestFramework_ReloadAppliesSamplingRate(t *testing.T) {
+	originalRegistry := globalMetricsRegistry
+	defer func() { globalMetricsRegistry = originalRegistry }()
+	registry := &samplingMetricsRegistry{}
+	globalMetricsRegistry = registry
+
+	t.Setenv("GOMIND_TELEMETRY_SAMPLING_RATE", "1")
+	framework, err := NewFramework(NewBaseAgent("reload-agent"))
+	if err != nil {
+		t.Fatalf("NewFramework() error = %v", err)
+	}
+
+	t.Setenv("GOMIND_TELEMETRY_SAMPLING_RATE", "0.25")
+	result, err := framework.Reload(context.Background())
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if applied := strings.Join(changedSettings(result.Applied), ","); applied != "telemetry.sampling_rate" {
+		t.Errorf("Expected telemetry.sampling_rate to be applied, got %q", applied)
+	}
+	if len(result.RequiresRestart) != 0 {
+		t.Errorf("Expected no restart-required changes, got %v", changedSettings(result.RequiresRestart))
+	}
+	if registry.rate != 0.25 || framework.config.Telemetry.SamplingRate != 0.25 {
+		t.Errorf("Sampler got %v and config has %v, want 0.25", registry.rate, framework.config.Telemetry.SamplingRate)
+	}
+}
+
+func TestFramework_ReloadRejectsInvalidConfig(t *testing.T) {
+	t.Setenv("GOMIND_LOG_LEVEL", "info")
+	framework, err := NewFramework(NewBaseAgent("reload-agent"))
+	if err != nil {
+		t.Fatalf("NewFramework() error = %v", err)
+	}
+
+	// Telemetry enabled without an endpoint fails validation
+	t.Setenv("GOMIND_LOG_LEVEL", "debug")
+	t.Setenv("GOMIND_TELEMETRY_ENABLED", "true")
+	t.Setenv("GOMIND_TELEMETRY_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	if _, err := framework.Reload(context.Background()); err == nil {
+		t.Fatal("Expected reload with invalid configuration to fail")
+	}
+	if framework.config.Logging.Level != "info" {
+		t.Errorf("Log level changed to %q after rejected reload", framework.config.Logging.Level)
+	}
+}
+
+func TestProductionLogger_SetLevelPropagatesToComponents(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewProductionLogger(LoggingConfig{Level: "info", Format: "json"}, DevelopmentConfig{}, "svc").(*ProductionLogger)
+	logger.output = &buf
+	child := logger.WithComponent("agent/test").(*ProductionLogger)
+
+	child.Debug("hidden", nil)
+	if buf.Len() != 0 {
+		t.Fatal("Debug message logged at info level")
+	}
+
+	logger.SetLevel("debug")
+	child.Debug("visible", nil)
+	if !strings.Contains(buf.String(), "visible") {
+		t.Error("Expected child logger to pick up the new level")
+	}
+
+	buf.Reset()
+	logger.SetLevel("error")
+	child.Warn("hidden", nil)
+	if buf.Len() != 0 {
+		t.Error("Warn message logged at error level")
+	}
+}
@@ -437,6 +437,31 @@ func TestFunctionalOptions(t *testing.T) {
 		assert.Equal(t, "redis", cfg.Memory.Provider)
 	})
 
+	t.Run("WithMemoryNamespacing", func(t *testing.T) {
+		cfg, err := NewConfig()
+		require.NoError(t, err)
+		assert.True(t, cfg.Memory.Namespaced)
+
+		cfg, err = NewConfig(WithMemoryNamespacing(false))
+		require.NoError(t, err)
+		assert.False(t, cfg.Memory.Namespaced)
+	})
+
+	t.Run("WithHeartbeatJitter", func(t *testing.T) {
+		cfg, err := NewConfig()
+		require.NoError(t, err)
+		assert.Equal(t, DefaultHeartbeatJitter, cfg.Discovery.HeartbeatJitter)
+		assert.Equal(t, DefaultRegistrationStagger, cfg.Discovery.RegistrationStagger)
+
+		cfg, err = NewConfig(WithHeartbeatJitter(0.25), WithRegistrationStagger(0))
+		require.NoError(t, err)
+		assert.Equal(t, 0.25, cfg.Discovery.HeartbeatJitter)
+		assert.Equal(t, time.Duration(0), cfg.Discovery.RegistrationStagger)
+
+		_, err = NewConfig(WithHeartbeatJitter(0.9))
+		assert.ErrorIs(t, err, ErrInvalidConfiguration)
+	})
+
 	t.Run("WithCircuitBreaker", func(t *testing.T) {
 		cfg, err := NewConfig(WithCircuitBreaker(10, 60*time.Second))
 		require.NoError(t, err)
@@ -582,6 +607,64 @@ func TestConfigWithConfigFile(t *testing.T) {
 	assert.True(t, cfg.HTTP.CORS.Enabled)
 }
 
+// TestConfigFilePrecedence verifies explicit options > env vars > config file > defaults
+func TestConfigFilePrecedence(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	yamlData := `
+name: file-agent
+port: 7777
+namespace: file-namespace
+http:
+  shutdown_timeout: 30s
+  cors:
+    enabled: true
+discovery:
+  redis_url: redis://file:6379
+  heartbeat_interval: 20s
+`
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlData), 0644))
+
+	t.Setenv("GOMIND_PORT", "9999")
+	t.Setenv("GOMIND_NAMESPACE", "env-namespace")
+
+	// The file is named last but still ranks below env vars and options
+	cfg, err := NewConfig(
+		WithNamespace("option-namespace"),
+		WithConfigFile(configFile),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "option-namespace", cfg.Namespace, "option beats env var and file")
+	assert.Equal(t, 9999, cfg.Port, "env var beats file")
+	assert.Equal(t, "file-agent", cfg.Name, "file beats default")
+	assert.Equal(t, "redis://file:6379", cfg.Discovery.RedisURL)
+	assert.True(t, cfg.HTTP.CORS.Enabled)
+	assert.Equal(t, 30*time.Second, cfg.HTTP.ShutdownTimeout, "duration strings are parsed")
+	assert.Equal(t, 20*time.Second, cfg.Discovery.HeartbeatInterval)
+	assert.Equal(t, 120*time.Second, cfg.HTTP.IdleTimeout, "default kept when nothing overrides it")
+}
+
+// TestConfigFileUnknownKeys verifies unknown keys warn instead of failing
+func TestConfigFileUnknownKeys(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	jsonData := `{"name": "file-agent", "colour": "blue", "http": {"cors": {"enabled": true, "origins": ["*"]}}}`
+	require.NoError(t, os.WriteFile(configFile, []byte(jsonData), 0644))
+
+	logger := &MockLogger{}
+	cfg, err := NewConfig(WithConfigFile(configFile), WithLogger(logger))
+	require.NoError(t, err)
+
+	assert.Equal(t, "file-agent", cfg.Name)
+	assert.True(t, cfg.HTTP.CORS.Enabled)
+	var warned []interface{}
+	for _, entry := range logger.entries {
+		if entry.Level == "warn" {
+			warned = append(warned, entry.Fields["unknown_keys"])
+		}
+	}
+	assert.Equal(t, []interface{}{[]string{"colour", "http.cors.origins"}}, warned)
+}
+
 // BenchmarkNewConfig benchmarks configuration creation
 func BenchmarkNewConfig(b *testing.B) {
 	b.ResetTimer()
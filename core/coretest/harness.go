@@ -0,0 +1,389 @@
+// Package coretest runs GoMind tools and agents in-process for integration
+// tests. A Harness wires a component to in-memory discovery and memory
+// backends, serves it with httptest, and provides helpers to invoke
+// capabilities and check what the component registered.
+//
+// Nothing in this package needs Redis, an AI provider API key or a free port:
+//
+//	func TestWeatherTool(t *testing.T) {
+//	    h := coretest.New(t, NewWeatherTool())
+//	    h.AssertRegistered("current_weather")
+//
+//	    resp := h.Invoke("current_weather", map[string]interface{}{"location": "London"})
+//	    resp.AssertStatus(http.StatusOK)
+//	}
+package coretest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// Harness runs a single component behind an httptest.Server
+type Harness struct {
+	t         testing.TB
+	component core.HTTPComponent
+	server    *httptest.Server
+
+	// Discovery is the in-memory registry the component registered with.
+	// Share it between harnesses so agents can discover tools under test.
+	Discovery *core.MockDiscovery
+	// Memory is the in-memory store installed as the component's Memory
+	Memory *core.MemoryStore
+}
+
+// Option customizes a Harness
+type Option func(*harnessOptions)
+
+type harnessOptions struct {
+	discovery *core.MockDiscovery
+	ai        core.AIClient
+	logger    core.Logger
+	configure []func(*core.Config)
+}
+
+// WithDiscovery registers the component in an existing MockDiscovery instead
+// of a new one. Use it to run a tool and an agent that discovers it.
+func WithDiscovery(discovery *core.MockDiscovery) Option {
+	return func(o *harnessOptions) {
+		o.discovery = discovery
+	}
+}
+
+// WithAI installs an AI client on the component, e.g. the mock provider from
+// github.com/itsneelabh/gomind/ai/providers/mock
+func WithAI(client core.AIClient) Option {
+	return func(o *harnessOptions) {
+		o.ai = client
+	}
+}
+
+// WithLogger sets the component logger. By default components log nothing.
+func WithLogger(logger core.Logger) Option {
+	return func(o *harnessOptions) {
+		o.logger = logger
+	}
+}
+
+// WithConfig adjusts the component's configuration before it is initialized
+func WithConfig(configure func(*core.Config)) Option {
+	return func(o *harnessOptions) {
+		o.configure = append(o.configure, configure)
+	}
+}
+
+// New initializes component against in-memory backends and serves it. The
+// server is closed when the test finishes. component must be a *core.BaseTool
+// or *core.BaseAgent, or a struct pointer embedding one of them.
+func New(t testing.TB, component core.HTTPComponent, opts ...Option) *Harness {
+	t.Helper()
+
+	options := &harnessOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.discovery == nil {
+		options.discovery = core.NewMockDiscovery()
+	}
+
+	h := &Harness{
+		t:         t,
+		component: component,
+		Discovery: options.discovery,
+		Memory:    core.NewMemoryStore(),
+	}
+
+	agent, tool := findBase(component)
+	if agent == nil && tool == nil {
+		t.Fatalf("coretest: %T does not embed *core.BaseAgent or *core.BaseTool", component)
+	}
+
+	config := configFor(agent, tool)
+	config.Discovery.Enabled = true
+	config.Development.MockDiscovery = true
+	config.Kubernetes.Enabled = false
+	config.Memory.Provider = "inmemory"
+	for _, configure := range options.configure {
+		configure(config)
+	}
+
+	switch {
+	case agent != nil:
+		agent.Discovery = h.Discovery
+		if options.ai != nil {
+			agent.AI = options.ai
+		}
+		if options.logger != nil {
+			agent.Logger = options.logger
+		}
+	case tool != nil:
+		tool.Registry = h.Discovery
+		if options.ai != nil {
+			tool.AI = options.ai
+		}
+		if options.logger != nil {
+			tool.Logger = options.logger
+		}
+	}
+
+	if err := component.Initialize(context.Background()); err != nil {
+		t.Fatalf("coretest: failed to initialize %s: %v", component.GetName(), err)
+	}
+
+	// Initialize replaces the agent's memory, so install ours afterwards
+	var handler http.Handler
+	if agent != nil {
+		agent.Memory = h.Memory
+		handler = agent.Handler()
+	} else {
+		tool.Memory = h.Memory
+		handler = tool.Handler()
+	}
+
+	h.server = httptest.NewServer(handler)
+	t.Cleanup(h.server.Close)
+
+	h.registerServerAddress()
+	return h
+}
+
+// URL returns the base URL of the test server
+func (h *Harness) URL() string {
+	return h.server.URL
+}
+
+// Client returns an HTTP client for the test server
+func (h *Harness) Client() *http.Client {
+	return h.server.Client()
+}
+
+// Invoke POSTs input as JSON to the named capability's endpoint
+func (h *Harness) Invoke(capability string, input interface{}) *Response {
+	h.t.Helper()
+
+	endpoint := ""
+	for _, cap := range h.component.GetCapabilities() {
+		if cap.Name == capability {
+			endpoint = cap.Endpoint
+			break
+		}
+	}
+	if endpoint == "" {
+		h.t.Fatalf("coretest: %s has no capability %q", h.component.GetName(), capability)
+	}
+	return h.Do(http.MethodPost, endpoint, input)
+}
+
+// Get sends a GET request to path
+func (h *Harness) Get(path string) *Response {
+	h.t.Helper()
+	return h.Do(http.MethodGet, path, nil)
+}
+
+// Do sends a request to path. A non-nil body is encoded as JSON unless it is
+// already a string or []byte.
+func (h *Harness) Do(method, path string, body interface{}) *Response {
+	h.t.Helper()
+
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = strings.NewReader(b)
+	case []byte:
+		reader = bytes.NewReader(b)
+	default:
+		encoded, err := json.Marshal(b)
+		if err != nil {
+			h.t.Fatalf("coretest: failed to encode request body: %v", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, h.server.URL+path, reader)
+	if err != nil {
+		h.t.Fatalf("coretest: failed to build request: %v", err)
+	}
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := h.server.Client().Do(req)
+	if err != nil {
+		h.t.Fatalf("coretest: %s %s failed: %v", method, path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		h.t.Fatalf("coretest: failed to read response body: %v", err)
+	}
+	return &Response{t: h.t, StatusCode: resp.StatusCode, Header: resp.Header, Body: data}
+}
+
+// Registration returns the component's entry in discovery, or nil if it is
+// not registered
+func (h *Harness) Registration() *core.ServiceInfo {
+	services, err := h.Discovery.Discover(context.Background(), core.DiscoveryFilter{Name: h.component.GetName()})
+	if err != nil {
+		return nil
+	}
+	for _, service := range services {
+		if service.ID == h.component.GetID() {
+			return service
+		}
+	}
+	return nil
+}
+
+// AssertRegistered fails the test unless the component is registered in
+// discovery with every listed capability
+func (h *Harness) AssertRegistered(capabilities ...string) {
+	h.t.Helper()
+
+	registration := h.Registration()
+	if registration == nil {
+		h.t.Errorf("coretest: %s is not registered in discovery", h.component.GetName())
+		return
+	}
+
+	registered := make(map[string]bool, len(registration.Capabilities))
+	for _, cap := range registration.Capabilities {
+		registered[cap.Name] = true
+	}
+	for _, name := range capabilities {
+		if !registered[name] {
+			h.t.Errorf("coretest: %s is registered without capability %q", h.component.GetName(), name)
+		}
+	}
+}
+
+// registerServerAddress points the discovery entry at the test server so
+// other components under test can reach this one through discovery
+func (h *Harness) registerServerAddress() {
+	registration := h.Registration()
+	if registration == nil {
+		return
+	}
+
+	host, portStr, err := net.SplitHostPort(strings.TrimPrefix(h.server.URL, "http://"))
+	if err != nil {
+		return
+	}
+	port, _ := strconv.Atoi(portStr)
+
+	updated := *registration
+	updated.Address = host
+	updated.Port = port
+	updated.Capabilities = h.component.GetCapabilities()
+	if err := h.Discovery.Register(context.Background(), &updated); err != nil {
+		h.t.Fatalf("coretest: failed to update registration: %v", err)
+	}
+}
+
+// Response is a captured HTTP response
+type Response struct {
+	t          testing.TB
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// AssertStatus fails the test if the response status differs from want
+func (r *Response) AssertStatus(want int) *Response {
+	r.t.Helper()
+	if r.StatusCode != want {
+		r.t.Errorf("coretest: status = %d, want %d (body: %s)", r.StatusCode, want, r.Body)
+	}
+	return r
+}
+
+// JSON decodes the body into v, failing the test if it is not valid JSON
+func (r *Response) JSON(v interface{}) {
+	r.t.Helper()
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		r.t.Fatalf("coretest: response is not valid JSON: %v (body: %s)", err, r.Body)
+	}
+}
+
+// Envelope decodes a core.ToolResponse envelope, failing the test if the body
+// is not one
+func (r *Response) Envelope() *core.ToolResponse {
+	r.t.Helper()
+	envelope, ok := core.ParseToolResponse(r.Body)
+	if !ok {
+		r.t.Fatalf("coretest: response is not a result envelope: %s", r.Body)
+	}
+	return envelope
+}
+
+// String returns the body as a string
+func (r *Response) String() string {
+	return string(r.Body)
+}
+
+// findBase returns the BaseAgent or BaseTool behind component, looking
+// through embedded fields the same way the framework applies configuration
+func findBase(component core.HTTPComponent) (*core.BaseAgent, *core.BaseTool) {
+	switch base := component.(type) {
+	case *core.BaseAgent:
+		return base, nil
+	case *core.BaseTool:
+		return nil, base
+	}
+
+	v := reflect.ValueOf(component)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, nil
+	}
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !field.CanInterface() {
+			continue
+		}
+		switch base := field.Interface().(type) {
+		case *core.BaseAgent:
+			if base != nil {
+				return base, nil
+			}
+		case *core.BaseTool:
+			if base != nil {
+				return nil, base
+			}
+		}
+	}
+	return nil, nil
+}
+
+func configFor(agent *core.BaseAgent, tool *core.BaseTool) *core.Config {
+	if agent != nil {
+		if agent.Config == nil {
+			agent.Config = core.DefaultConfig()
+			agent.Config.Name = agent.Name
+		}
+		return agent.Config
+	}
+	if tool.Config == nil {
+		tool.Config = core.DefaultConfig()
+		tool.Config.Name = tool.Name
+	}
+	return tool.Config
+}
+
+// String describes the harness for test failure messages
+func (h *Harness) String() string {
+	return fmt.Sprintf("%s (%s) at %s", h.component.GetName(), h.component.GetID(), h.server.URL)
+}
@@ -0,0 +1,92 @@
+package coretest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+type greeterTool struct {
+	*core.BaseTool
+}
+
+func newGreeterTool() *greeterTool {
+	tool := &greeterTool{BaseTool: core.NewTool("greeter")}
+	tool.RegisterCapability(core.Capability{
+		Name:        "greet",
+		Description: "Greets someone by name",
+		Execute: func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			name, _ := input["name"].(string)
+			if name == "" {
+				return nil, &core.ToolError{Code: "MISSING_NAME", Message: "name is required", Category: core.CategoryInputError}
+			}
+			return map[string]string{"greeting": "Hello, " + name}, nil
+		},
+	})
+	tool.RegisterCapability(core.Capability{
+		Name: "remember",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			_ = tool.Memory.Set(r.Context(), "last_call", "remember", 0)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]bool{"stored": true})
+		},
+	})
+	return tool
+}
+
+func TestHarness_InvokesToolCapabilities(t *testing.T) {
+	h := New(t, newGreeterTool())
+	h.AssertRegistered("greet", "remember")
+
+	envelope := h.Invoke("greet", map[string]string{"name": "Ada"}).AssertStatus(http.StatusOK).Envelope()
+	if data, _ := envelope.Data.(map[string]interface{}); data["greeting"] != "Hello, Ada" {
+		t.Errorf("Unexpected greeting: %+v", envelope.Data)
+	}
+
+	envelope = h.Invoke("greet", map[string]string{}).AssertStatus(http.StatusBadRequest).Envelope()
+	if envelope.Success || envelope.Error.Code != "MISSING_NAME" {
+		t.Errorf("Expected MISSING_NAME error, got %+v", envelope)
+	}
+
+	var stored map[string]bool
+	h.Invoke("remember", nil).AssertStatus(http.StatusOK).JSON(&stored)
+	if !stored["stored"] {
+		t.Errorf("Unexpected response: %v", stored)
+	}
+	if value, _ := h.Memory.Get(context.Background(), "last_call"); value != "remember" {
+		t.Errorf("Expected capability to write to harness memory, got %q", value)
+	}
+
+	h.Get("/health").AssertStatus(http.StatusOK)
+}
+
+func TestHarness_RegistersTestServerAddress(t *testing.T) {
+	discovery := core.NewMockDiscovery()
+	tool := New(t, newGreeterTool(), WithDiscovery(discovery))
+
+	agent := core.NewBaseAgent("coordinator")
+	agentHarness := New(t, agent, WithDiscovery(discovery), WithAI(stubAI{}))
+	agentHarness.AssertRegistered()
+
+	if agent.AI == nil {
+		t.Error("Expected AI client to be injected")
+	}
+
+	services, err := agent.Discover(context.Background(), core.DiscoveryFilter{Capabilities: []string{"greet"}})
+	if err != nil || len(services) != 1 {
+		t.Fatalf("Expected agent to discover the tool, got %v (err: %v)", services, err)
+	}
+	registration := tool.Registration()
+	if services[0].Address != registration.Address || services[0].Port != registration.Port || registration.Port == 0 {
+		t.Errorf("Expected discovered address to point at the harness server, got %s:%d", services[0].Address, services[0].Port)
+	}
+}
+
+type stubAI struct{}
+
+func (stubAI) GenerateResponse(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+	return &core.AIResponse{Content: "ok"}, nil
+}
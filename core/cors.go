@@ -30,6 +30,9 @@ import (
 func CORSMiddleware(config *CORSConfig) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Snapshot so a concurrent config reload can't change settings mid-request
+			config := config.snapshot()
+
 			// Skip CORS if not enabled
 			if !config.Enabled {
 				next.ServeHTTP(w, r)
@@ -169,6 +172,8 @@ func isOriginAllowed(origin string, allowedOrigins []string) bool {
 //	    // ... rest of handler
 //	}
 func ApplyCORS(w http.ResponseWriter, r *http.Request, config *CORSConfig) {
+	cfg := config.snapshot()
+	config = &cfg
 	if !config.Enabled {
 		return
 	}
@@ -0,0 +1,276 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// DefaultDiscoveryWatchInterval is how often Watch diffs the registry when
+// Redis keyspace notifications are disabled
+const DefaultDiscoveryWatchInterval = 5 * time.Second
+
+// DiscoveryEventType describes a registration change
+type DiscoveryEventType string
+
+const (
+	DiscoveryEventAdded   DiscoveryEventType = "added"
+	DiscoveryEventUpdated DiscoveryEventType = "updated"
+	DiscoveryEventRemoved DiscoveryEventType = "removed"
+)
+
+// DiscoveryEvent reports a service being registered, changed or removed.
+// For removals Service is the last registration seen before it went away.
+type DiscoveryEvent struct {
+	Type      DiscoveryEventType `json:"type"`
+	ServiceID string             `json:"service_id"`
+	Service   *ServiceInfo       `json:"service"`
+}
+
+// DiscoveryWatcher is implemented by discovery backends that can stream
+// registration changes. Watch reports changes made after it is called, so
+// callers usually Discover once and then apply events. The channel is
+// closed when ctx is cancelled.
+type DiscoveryWatcher interface {
+	Watch(ctx context.Context) (<-chan DiscoveryEvent, error)
+}
+
+var _ DiscoveryWatcher = (*RedisDiscovery)(nil)
+
+// SetWatchInterval sets how often Watch polls when keyspace notifications
+// are unavailable. Zero restores DefaultDiscoveryWatchInterval.
+func (d *RedisDiscovery) SetWatchInterval(interval time.Duration) {
+	d.watchInterval = interval
+}
+
+// Watch streams registration changes. When the server publishes keyspace
+// notifications for string, generic and expired events (for example
+// notify-keyspace-events "KA" or "Kg$x"), changes are delivered as they
+// happen. Otherwise Watch falls back to diffing the registry every watch
+// interval. Heartbeats that only refresh LastSeen are not reported as
+// updates.
+func (d *RedisDiscovery) Watch(ctx context.Context) (<-chan DiscoveryEvent, error) {
+	known, err := d.watchSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan DiscoveryEvent, 16)
+	if d.keyspaceNotificationsEnabled(ctx) {
+		pattern := fmt.Sprintf("__keyspace@%d__:%s:services:*", d.client.Options().DB, d.namespace)
+		pubsub := d.client.PSubscribe(ctx, pattern)
+		if _, err := pubsub.Receive(ctx); err == nil {
+			go d.watchNotifications(ctx, pubsub, known, events)
+			return events, nil
+		}
+		_ = pubsub.Close()
+	}
+
+	if d.logger != nil {
+		d.logger.InfoWithContext(ctx, "Keyspace notifications unavailable, polling for discovery changes", map[string]interface{}{
+			"namespace": d.namespace,
+			"interval":  d.pollInterval().String(),
+		})
+	}
+	go d.watchPoll(ctx, known, events)
+	return events, nil
+}
+
+func (d *RedisDiscovery) pollInterval() time.Duration {
+	if d.watchInterval > 0 {
+		return d.watchInterval
+	}
+	return DefaultDiscoveryWatchInterval
+}
+
+// keyspaceNotificationsEnabled reports whether the server publishes the
+// keyspace events Watch relies on: set, del and expired
+func (d *RedisDiscovery) keyspaceNotificationsEnabled(ctx context.Context) bool {
+	values, err := d.client.ConfigGet(ctx, "notify-keyspace-events").Result()
+	if err != nil || len(values) < 2 {
+		return false
+	}
+	flags, _ := values[1].(string)
+	if !strings.Contains(flags, "K") {
+		return false
+	}
+	return strings.Contains(flags, "A") ||
+		(strings.Contains(flags, "$") && strings.Contains(flags, "g") && strings.Contains(flags, "x"))
+}
+
+// watchedService is a registration with the fingerprint used to detect changes
+type watchedService struct {
+	info        *ServiceInfo
+	fingerprint string
+}
+
+// newWatchedService fingerprints info without LastSeen, which every
+// heartbeat rewrites
+func newWatchedService(info *ServiceInfo) watchedService {
+	stripped := *info
+	stripped.LastSeen = time.Time{}
+	data, _ := json.Marshal(stripped)
+	return watchedService{info: info, fingerprint: string(data)}
+}
+
+// watchSnapshot loads every registration in the namespace
+func (d *RedisDiscovery) watchSnapshot(ctx context.Context) (map[string]watchedService, error) {
+	prefix := fmt.Sprintf("%s:services:", d.namespace)
+	snapshot := make(map[string]watchedService)
+
+	var cursor uint64
+	for {
+		keys, next, err := d.client.Scan(ctx, cursor, prefix+"*", int64(DefaultMigrationBatchSize)).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list services: %w", err)
+		}
+		if len(keys) > 0 {
+			values, err := d.client.MGet(ctx, keys...).Result()
+			if err != nil {
+				return nil, fmt.Errorf("failed to load services: %w", err)
+			}
+			for i, value := range values {
+				data, ok := value.(string)
+				if !ok {
+					continue // expired since the scan
+				}
+				var info ServiceInfo
+				if err := json.Unmarshal([]byte(data), &info); err != nil {
+					continue
+				}
+				snapshot[strings.TrimPrefix(keys[i], prefix)] = newWatchedService(&info)
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return snapshot, nil
+		}
+	}
+}
+
+// emitWatchEvent delivers event unless ctx is cancelled first
+func emitWatchEvent(ctx context.Context, events chan<- DiscoveryEvent, event DiscoveryEvent) bool {
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// watchPoll diffs the registry every poll interval until ctx is cancelled
+func (d *RedisDiscovery) watchPoll(ctx context.Context, known map[string]watchedService, events chan<- DiscoveryEvent) {
+	defer close(events)
+
+	ticker := time.NewTicker(d.pollInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current, err := d.watchSnapshot(ctx)
+		if err != nil {
+			if d.logger != nil && ctx.Err() == nil {
+				d.logger.WarnWithContext(ctx, "Discovery watch poll failed", map[string]interface{}{
+					"error":      err,
+					"error_type": fmt.Sprintf("%T", err),
+					"namespace":  d.namespace,
+				})
+			}
+			continue
+		}
+
+		for id, service := range current {
+			previous, existed := known[id]
+			switch {
+			case !existed:
+				if !emitWatchEvent(ctx, events, DiscoveryEvent{Type: DiscoveryEventAdded, ServiceID: id, Service: service.info}) {
+					return
+				}
+			case previous.fingerprint != service.fingerprint:
+				if !emitWatchEvent(ctx, events, DiscoveryEvent{Type: DiscoveryEventUpdated, ServiceID: id, Service: service.info}) {
+					return
+				}
+			}
+		}
+		for id, previous := range known {
+			if _, ok := current[id]; !ok {
+				if !emitWatchEvent(ctx, events, DiscoveryEvent{Type: DiscoveryEventRemoved, ServiceID: id, Service: previous.info}) {
+					return
+				}
+			}
+		}
+		known = current
+	}
+}
+
+// watchNotifications turns keyspace notifications for service keys into
+// events until ctx is cancelled
+func (d *RedisDiscovery) watchNotifications(ctx context.Context, pubsub *redis.PubSub, known map[string]watchedService, events chan<- DiscoveryEvent) {
+	defer close(events)
+	defer func() { _ = pubsub.Close() }()
+
+	prefix := fmt.Sprintf("%s:services:", d.namespace)
+	messages := pubsub.Channel()
+	for {
+		var msg *redis.Message
+		select {
+		case <-ctx.Done():
+			return
+		case m, ok := <-messages:
+			if !ok {
+				return
+			}
+			msg = m
+		}
+
+		idx := strings.Index(msg.Channel, prefix)
+		if idx < 0 {
+			continue
+		}
+		id := msg.Channel[idx+len(prefix):]
+
+		switch msg.Payload {
+		case "set":
+			data, err := d.client.Get(ctx, prefix+id).Result()
+			if err != nil {
+				continue
+			}
+			var info ServiceInfo
+			if err := json.Unmarshal([]byte(data), &info); err != nil {
+				continue
+			}
+			service := newWatchedService(&info)
+			previous, existed := known[id]
+			known[id] = service
+			if existed && previous.fingerprint == service.fingerprint {
+				continue
+			}
+			eventType := DiscoveryEventAdded
+			if existed {
+				eventType = DiscoveryEventUpdated
+			}
+			if !emitWatchEvent(ctx, events, DiscoveryEvent{Type: eventType, ServiceID: id, Service: service.info}) {
+				return
+			}
+		case "del", "expired", "evicted":
+			previous, existed := known[id]
+			if !existed {
+				continue
+			}
+			delete(known, id)
+			if !emitWatchEvent(ctx, events, DiscoveryEvent{Type: DiscoveryEventRemoved, ServiceID: id, Service: previous.info}) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func nextDiscoveryEvent(t *testing.T, events <-chan DiscoveryEvent) DiscoveryEvent {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("Watch channel closed unexpectedly")
+		}
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for discovery event")
+	}
+	return DiscoveryEvent{}
+}
+
+func TestRedisDiscoveryWatch_Polling(t *testing.T) {
+	mr := miniredis.RunT(t)
+	discovery, err := NewRedisDiscovery("redis://" + mr.Addr())
+	if err != nil {
+		t.Fatalf("Failed to create discovery: %v", err)
+	}
+	t.Cleanup(func() { _ = discovery.client.Close() })
+	discovery.SetWatchInterval(20 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	existing := &ServiceInfo{ID: "weather-1", Name: "weather", Type: ComponentTypeTool, Health: HealthHealthy}
+	if err := discovery.Register(ctx, existing); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	events, err := discovery.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	added := &ServiceInfo{ID: "summarizer-1", Name: "summarizer", Type: ComponentTypeAgent, Health: HealthHealthy}
+	if err := discovery.Register(ctx, added); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	event := nextDiscoveryEvent(t, events)
+	if event.Type != DiscoveryEventAdded || event.ServiceID != "summarizer-1" || event.Service.Name != "summarizer" {
+		t.Errorf("Expected summarizer-1 added, got %+v", event)
+	}
+
+	// A heartbeat only refreshes LastSeen and is not reported
+	if err := discovery.UpdateHealth(ctx, "weather-1", HealthHealthy); err != nil {
+		t.Fatalf("UpdateHealth failed: %v", err)
+	}
+	if err := discovery.UpdateHealth(ctx, "summarizer-1", HealthUnhealthy); err != nil {
+		t.Fatalf("UpdateHealth failed: %v", err)
+	}
+	event = nextDiscoveryEvent(t, events)
+	if event.Type != DiscoveryEventUpdated || event.ServiceID != "summarizer-1" || event.Service.Health != HealthUnhealthy {
+		t.Errorf("Expected summarizer-1 updated, got %+v", event)
+	}
+
+	mr.Del("gomind:services:weather-1")
+	event = nextDiscoveryEvent(t, events)
+	if event.Type != DiscoveryEventRemoved || event.ServiceID != "weather-1" || event.Service.Name != "weather" {
+		t.Errorf("Expected weather-1 removed, got %+v", event)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("Expected no further events after cancel")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Watch channel not closed after cancel")
+	}
+}
+
+func TestKeyspaceNotificationsDisabledOnMiniredis(t *testing.T) {
+	mr := miniredis.RunT(t)
+	discovery, err := NewRedisDiscovery("redis://" + mr.Addr())
+	if err != nil {
+		t.Fatalf("Failed to create discovery: %v", err)
+	}
+	t.Cleanup(func() { _ = discovery.client.Close() })
+
+	if discovery.keyspaceNotificationsEnabled(context.Background()) {
+		t.Error("Expected keyspace notifications to be reported unavailable")
+	}
+}
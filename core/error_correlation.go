@@ -0,0 +1,39 @@
+package core
+
+import (
+	"net/http"
+)
+
+// Response headers that carry correlation IDs on error responses. A user
+// reporting a failure can hand over either ID to look up the request in the
+// trace backend or the registry viewer's execution view.
+const (
+	TraceIDHeader   = "X-Trace-ID"
+	RequestIDHeader = "X-Request-ID"
+)
+
+// requestCorrelation returns the trace and request IDs for r. Both come from
+// the telemetry context when telemetry is enabled; otherwise the request ID
+// is the one set by RequestIDMiddleware, then the incoming X-Request-ID
+// header.
+func requestCorrelation(r *http.Request) (traceID, requestID string) {
+	traceID, requestID = CorrelationIDs(r.Context())
+	if requestID == "" {
+		requestID = r.Header.Get(RequestIDHeader)
+	}
+	return traceID, requestID
+}
+
+// withCorrelation sets the correlation headers on w and the matching fields
+// on response. It must be called before the status is written.
+func withCorrelation(w http.ResponseWriter, r *http.Request, response *ToolResponse) {
+	traceID, requestID := requestCorrelation(r)
+	if traceID != "" {
+		w.Header().Set(TraceIDHeader, traceID)
+	}
+	if requestID != "" {
+		w.Header().Set(RequestIDHeader, requestID)
+	}
+	response.TraceID = traceID
+	response.RequestID = requestID
+}
@@ -0,0 +1,219 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func withTraceBaggage(t *testing.T, baggage map[string]string) {
+	original := globalMetricsRegistry
+	globalMetricsRegistry = &mockMetricsRegistry{baggage: baggage}
+	t.Cleanup(func() { globalMetricsRegistry = original })
+}
+
+func decodeToolResponse(t *testing.T, rec *httptest.ResponseRecorder) *ToolResponse {
+	t.Helper()
+	var response ToolResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Expected a ToolResponse body, got %q: %v", rec.Body.String(), err)
+	}
+	return &response
+}
+
+func TestResultHandler_ErrorCarriesCorrelationIDs(t *testing.T) {
+	withTraceBaggage(t, map[string]string{"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736", "request_id": "req-42"})
+
+	handler := newResultHandler(Capability{
+		Name: "fail",
+		Execute: func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			return nil, errors.New("upstream down")
+		},
+	}, nil)
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest("POST", "/api/capabilities/fail", strings.NewReader(`{}`)))
+
+	if got := rec.Header().Get(TraceIDHeader); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("%s = %q", TraceIDHeader, got)
+	}
+	response := decodeToolResponse(t, rec)
+	if response.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || response.RequestID != "req-42" {
+		t.Errorf("Expected correlation IDs in the body, got trace=%q request=%q", response.TraceID, response.RequestID)
+	}
+}
+
+func TestResultHandler_InvalidJSONCarriesRequestHeaderID(t *testing.T) {
+	withTraceBaggage(t, map[string]string{})
+
+	handler := newResultHandler(Capability{
+		Name: "echo",
+		Execute: func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			return input, nil
+		},
+	}, nil)
+
+	req := httptest.NewRequest("POST", "/api/capabilities/echo", strings.NewReader(`{not json`))
+	req.Header.Set(RequestIDHeader, "client-7")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d", rec.Code)
+	}
+	if got := rec.Header().Get(RequestIDHeader); got != "client-7" {
+		t.Errorf("%s = %q, want client-7", RequestIDHeader, got)
+	}
+	if rec.Header().Get(TraceIDHeader) != "" {
+		t.Error("Expected no trace header without a trace")
+	}
+	if response := decodeToolResponse(t, rec); response.RequestID != "client-7" {
+		t.Errorf("RequestID = %q, want client-7", response.RequestID)
+	}
+}
+
+func TestResultHandler_SuccessOmitsCorrelationIDs(t *testing.T) {
+	withTraceBaggage(t, map[string]string{"trace_id": "abc", "request_id": "req-1"})
+
+	handler := newResultHandler(Capability{
+		Name: "ok",
+		Execute: func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			return "done", nil
+		},
+	}, nil)
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest("POST", "/api/capabilities/ok", strings.NewReader(`{}`)))
+
+	if response := decodeToolResponse(t, rec); response.TraceID != "" || rec.Header().Get(TraceIDHeader) != "" {
+		t.Error("Expected successful responses to be unchanged")
+	}
+}
+
+func TestRecoveryMiddleware_CarriesCorrelationIDs(t *testing.T) {
+	withTraceBaggage(t, map[string]string{"trace_id": "abc123", "request_id": "req-9"})
+
+	handler := RecoveryMiddleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/api/capabilities/x", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500, got %d", rec.Code)
+	}
+	if rec.Header().Get(TraceIDHeader) != "abc123" || rec.Header().Get(RequestIDHeader) != "req-9" {
+		t.Errorf("Unexpected correlation headers: %v", rec.Header())
+	}
+	response := decodeToolResponse(t, rec)
+	if response.Success || response.Error == nil || response.Error.Code != "INTERNAL_ERROR" {
+		t.Errorf("Unexpected envelope: %+v", response)
+	}
+	if response.TraceID != "abc123" || response.RequestID != "req-9" {
+		t.Errorf("Expected correlation IDs in the body, got %+v", response)
+	}
+}
+
+// panicMetricsRegistry records emitted metric names
+type panicMetricsRegistry struct {
+	mockMetricsRegistry
+	emitted []string
+}
+
+func (r *panicMetricsRegistry) EmitWithContext(ctx context.Context, name string, value float64, labels ...string) {
+	r.emitted = append(r.emitted, name)
+}
+
+func TestRecoveryMiddleware_RecordsPanic(t *testing.T) {
+	registry := &panicMetricsRegistry{mockMetricsRegistry: mockMetricsRegistry{baggage: map[string]string{"trace_id": "abc123"}}}
+	original := globalMetricsRegistry
+	globalMetricsRegistry = registry
+	t.Cleanup(func() { globalMetricsRegistry = original })
+
+	logger := &MockLogger{}
+	handler := RequestIDMiddleware()(RecoveryMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})))
+	req := httptest.NewRequest("GET", "/api/capabilities/x", nil)
+	req.Header.Set(RequestIDHeader, "client-7")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500, got %d", rec.Code)
+	}
+	if len(registry.emitted) != 1 || registry.emitted[0] != "http.handler.panics" {
+		t.Errorf("Expected the panic to be recorded to telemetry, got %v", registry.emitted)
+	}
+	if len(logger.entries) != 1 {
+		t.Fatalf("Expected one log entry, got %d", len(logger.entries))
+	}
+	fields := logger.entries[0].Fields
+	if fields["trace_id"] != "abc123" || fields["request_id"] != "client-7" || fields["stack"] == "" {
+		t.Errorf("Expected the log entry to carry the IDs and stack, got %v", fields)
+	}
+	response := decodeToolResponse(t, rec)
+	if response.RequestID != "client-7" {
+		t.Errorf("RequestID = %q, want client-7", response.RequestID)
+	}
+	if response.Error == nil || response.Error.Details[PanicDetail] != "true" {
+		t.Errorf("Expected the envelope to mark the panic, got %+v", response.Error)
+	}
+}
+
+func TestCapabilityPanic_KeepsServing(t *testing.T) {
+	tool := NewTool("maps")
+	tool.RegisterCapability(Capability{
+		Name: "broken",
+		Execute: func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			var cache map[string]*string
+			return *cache["missing"], nil
+		},
+	})
+	tool.RegisterCapability(Capability{
+		Name: "healthy",
+		Execute: func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			return "ok", nil
+		},
+	})
+	handler := tool.Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/api/capabilities/broken", strings.NewReader(`{}`)))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500 from the panicking capability, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/api/capabilities/healthy", strings.NewReader(`{}`)))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected other capabilities to keep serving, got %d", rec.Code)
+	}
+}
+
+func TestWithPanicRecovery_Disabled(t *testing.T) {
+	config, err := NewConfig(WithName("maps"), WithPanicRecovery(false))
+	if err != nil {
+		t.Fatalf("NewConfig failed: %v", err)
+	}
+	tool := NewTool("maps")
+	tool.Config = config
+	tool.RegisterCapability(Capability{
+		Name: "broken",
+		Execute: func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			panic("boom")
+		},
+	})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected the panic to propagate with recovery disabled")
+		}
+	}()
+	tool.Handler().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/api/capabilities/broken", strings.NewReader(`{}`)))
+}
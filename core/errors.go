@@ -18,6 +18,7 @@ var (
 	// Capability-related errors
 	ErrCapabilityNotFound   = errors.New("capability not found")
 	ErrCapabilityNotEnabled = errors.New("capability not enabled")
+	ErrInvalidCapability    = errors.New("invalid capability")
 
 	// Discovery-related errors
 	ErrServiceNotFound      = errors.New("service not found")
@@ -41,15 +42,22 @@ var (
 	// HTTP/Network errors
 	ErrConnectionFailed = errors.New("connection failed")
 	ErrRequestFailed    = errors.New("request failed")
+	ErrPoolExhausted    = errors.New("connection pool exhausted")
 
 	// Resilience errors
 	ErrCircuitBreakerOpen = errors.New("circuit breaker open")
 
+	// Memory errors
+	ErrExpiryNotSupported = errors.New("memory backend does not support key expiry")
+	ErrKeyLimitExceeded   = errors.New("too many matching keys")
+
 	// AI operation errors
 	ErrAIOperationFailed = errors.New("AI operation failed")
 
 	// Streaming errors
 	ErrStreamPartiallyCompleted = errors.New("stream partially completed before interruption")
+	ErrStreamTimeout            = errors.New("stream exceeded total timeout")
+	ErrStreamIdleTimeout        = errors.New("stream idle timeout: no data received")
 )
 
 // IsRetryable checks if an error is retryable.
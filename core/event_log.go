@@ -0,0 +1,225 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Event is a single entry in an append-only event stream
+type Event struct {
+	Stream    string    `json:"stream"`
+	Seq       int64     `json:"seq"`
+	Data      []byte    `json:"data"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// EventLog is implemented by Memory backends that support append-only event
+// streams for auditing and event sourcing. Sequence numbers start at 1 and
+// increase by one per appended event within a stream.
+type EventLog interface {
+	// AppendEvent adds an event to the end of stream and returns its sequence number
+	AppendEvent(ctx context.Context, stream string, event []byte) (int64, error)
+	// ReadEvents returns events with Seq >= fromSeq in order. Use fromSeq <= 1
+	// to replay the whole stream.
+	ReadEvents(ctx context.Context, stream string, fromSeq int64) ([]Event, error)
+}
+
+// EventConsumerGroups is implemented by EventLog backends that can share a
+// stream between replicas with at-least-once delivery. Each event is delivered
+// to one consumer of a group and stays pending until acknowledged; events left
+// pending by a crashed consumer can be claimed by another.
+type EventConsumerGroups interface {
+	// ReadGroupEvents delivers up to count events that no consumer of group has
+	// received yet. The group is created on first use and starts at the
+	// beginning of the stream.
+	ReadGroupEvents(ctx context.Context, stream, group, consumer string, count int) ([]Event, error)
+	// AckEvents marks events as processed so they are never redelivered
+	AckEvents(ctx context.Context, stream, group string, seqs ...int64) error
+	// ClaimStaleEvents transfers up to count events that have been pending
+	// longer than minIdle to consumer and returns them for reprocessing
+	ClaimStaleEvents(ctx context.Context, stream, group, consumer string, minIdle time.Duration, count int) ([]Event, error)
+}
+
+func validateEventStream(stream string) error {
+	if stream == "" {
+		return fmt.Errorf("event stream name is required")
+	}
+	return nil
+}
+
+func validateEventGroup(stream, group, consumer string) error {
+	if err := validateEventStream(stream); err != nil {
+		return err
+	}
+	if group == "" || consumer == "" {
+		return fmt.Errorf("consumer group and consumer name are required")
+	}
+	return nil
+}
+
+// memoryEventLog keeps event streams in process memory. The zero value is
+// ready to use.
+type memoryEventLog struct {
+	mu      sync.Mutex
+	streams map[string]*memoryEventStream
+}
+
+type memoryEventStream struct {
+	events []Event
+	groups map[string]*memoryConsumerGroup
+}
+
+type memoryConsumerGroup struct {
+	lastDelivered int64
+	pending       map[int64]*memoryPendingEvent
+}
+
+type memoryPendingEvent struct {
+	consumer    string
+	deliveredAt time.Time
+}
+
+func (l *memoryEventLog) stream(name string) *memoryEventStream {
+	if l.streams == nil {
+		l.streams = make(map[string]*memoryEventStream)
+	}
+	s, ok := l.streams[name]
+	if !ok {
+		s = &memoryEventStream{groups: make(map[string]*memoryConsumerGroup)}
+		l.streams[name] = s
+	}
+	return s
+}
+
+func (l *memoryEventLog) append(stream string, data []byte) (int64, error) {
+	if err := validateEventStream(stream); err != nil {
+		return 0, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s := l.stream(stream)
+	seq := int64(len(s.events)) + 1
+	s.events = append(s.events, Event{
+		Stream:    stream,
+		Seq:       seq,
+		Data:      append([]byte(nil), data...),
+		Timestamp: time.Now(),
+	})
+	return seq, nil
+}
+
+func (l *memoryEventLog) read(stream string, fromSeq int64) ([]Event, error) {
+	if err := validateEventStream(stream); err != nil {
+		return nil, err
+	}
+	if fromSeq < 1 {
+		fromSeq = 1
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s, ok := l.streams[stream]
+	if !ok || fromSeq > int64(len(s.events)) {
+		return []Event{}, nil
+	}
+	// Sequences are dense, so event N lives at index N-1
+	return copyEvents(s.events[fromSeq-1:]), nil
+}
+
+func (l *memoryEventLog) readGroup(stream, group, consumer string, count int) ([]Event, error) {
+	if err := validateEventGroup(stream, group, consumer); err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s := l.stream(stream)
+	g, ok := s.groups[group]
+	if !ok {
+		g = &memoryConsumerGroup{pending: make(map[int64]*memoryPendingEvent)}
+		s.groups[group] = g
+	}
+
+	remaining := s.events[g.lastDelivered:]
+	if count > 0 && len(remaining) > count {
+		remaining = remaining[:count]
+	}
+
+	now := time.Now()
+	for _, event := range remaining {
+		g.pending[event.Seq] = &memoryPendingEvent{consumer: consumer, deliveredAt: now}
+	}
+	g.lastDelivered += int64(len(remaining))
+	return copyEvents(remaining), nil
+}
+
+func (l *memoryEventLog) ack(stream, group string, seqs []int64) error {
+	if err := validateEventStream(stream); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s, ok := l.streams[stream]
+	if !ok || s.groups[group] == nil {
+		return fmt.Errorf("consumer group %s does not exist for stream %s", group, stream)
+	}
+	for _, seq := range seqs {
+		delete(s.groups[group].pending, seq)
+	}
+	return nil
+}
+
+func (l *memoryEventLog) claimStale(stream, group, consumer string, minIdle time.Duration, count int) ([]Event, error) {
+	if err := validateEventGroup(stream, group, consumer); err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s, ok := l.streams[stream]
+	if !ok || s.groups[group] == nil {
+		return []Event{}, nil
+	}
+	g := s.groups[group]
+
+	seqs := make([]int64, 0, len(g.pending))
+	for seq := range g.pending {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	now := time.Now()
+	claimed := []Event{}
+	for _, seq := range seqs {
+		if count > 0 && len(claimed) >= count {
+			break
+		}
+		p := g.pending[seq]
+		if now.Sub(p.deliveredAt) < minIdle {
+			continue
+		}
+		p.consumer = consumer
+		p.deliveredAt = now
+		claimed = append(claimed, s.events[seq-1])
+	}
+	return copyEvents(claimed), nil
+}
+
+func copyEvents(events []Event) []Event {
+	result := make([]Event, len(events))
+	for i, event := range events {
+		result[i] = event
+		result[i].Data = append([]byte(nil), event.Data...)
+	}
+	return result
+}
@@ -0,0 +1,187 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+type eventBackend interface {
+	EventLog
+	EventConsumerGroups
+}
+
+func eventBackends(t *testing.T) map[string]eventBackend {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	redisMemory, err := NewRedisMemory("redis://" + mr.Addr())
+	if err != nil {
+		t.Fatalf("NewRedisMemory() error = %v", err)
+	}
+	t.Cleanup(func() { _ = redisMemory.Close() })
+
+	return map[string]eventBackend{
+		"in_memory": NewMemoryStore(),
+		"redis":     redisMemory,
+	}
+}
+
+func TestEventLog_AppendAndRead(t *testing.T) {
+	for name, log := range eventBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			for i := 1; i <= 3; i++ {
+				seq, err := log.AppendEvent(ctx, "orders", []byte(fmt.Sprintf(`{"n":%d}`, i)))
+				if err != nil {
+					t.Fatalf("AppendEvent() error = %v", err)
+				}
+				if seq != int64(i) {
+					t.Errorf("AppendEvent() seq = %d, want %d", seq, i)
+				}
+			}
+
+			events, err := log.ReadEvents(ctx, "orders", 0)
+			if err != nil || len(events) != 3 {
+				t.Fatalf("ReadEvents(0) = %d events, %v; want 3", len(events), err)
+			}
+			if events[0].Seq != 1 || string(events[0].Data) != `{"n":1}` || events[0].Timestamp.IsZero() {
+				t.Errorf("Unexpected first event: %+v", events[0])
+			}
+
+			events, _ = log.ReadEvents(ctx, "orders", 2)
+			if len(events) != 2 || events[0].Seq != 2 || events[1].Seq != 3 {
+				t.Errorf("ReadEvents(2) = %+v", events)
+			}
+			if events, _ := log.ReadEvents(ctx, "orders", 10); len(events) != 0 {
+				t.Errorf("Expected no events past the end, got %d", len(events))
+			}
+			if events, _ := log.ReadEvents(ctx, "unknown", 0); len(events) != 0 {
+				t.Errorf("Expected empty unknown stream, got %d", len(events))
+			}
+			if _, err := log.AppendEvent(ctx, "", nil); err == nil {
+				t.Error("Expected error for empty stream name")
+			}
+		})
+	}
+}
+
+func TestRedisMemory_EventKeysShareHashSlot(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+	memory, err := NewRedisMemory("redis://" + mr.Addr())
+	if err != nil {
+		t.Fatalf("NewRedisMemory() error = %v", err)
+	}
+	defer memory.Close()
+
+	if _, err := memory.AppendEvent(context.Background(), "audit", []byte("created")); err != nil {
+		t.Fatalf("AppendEvent() error = %v", err)
+	}
+
+	// The script touches both keys, so on Redis Cluster they must hash alike
+	key := memory.eventStreamKey("audit")
+	db := mr.DB(RedisDBSessions)
+	for _, k := range []string{key, key + ":seq"} {
+		if !db.Exists(k) {
+			t.Errorf("Expected key %s, have %v", k, db.Keys())
+		}
+		if !strings.Contains(k, "{audit}") {
+			t.Errorf("Expected %s to carry the {audit} hash tag", k)
+		}
+	}
+}
+
+func TestEventLog_ConcurrentAppendsAreOrdered(t *testing.T) {
+	for name, log := range eventBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, err := log.AppendEvent(ctx, "audit", []byte("x")); err != nil {
+						t.Errorf("AppendEvent() error = %v", err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			events, _ := log.ReadEvents(ctx, "audit", 0)
+			if len(events) != 20 {
+				t.Fatalf("Expected 20 events, got %d", len(events))
+			}
+			for i, event := range events {
+				if event.Seq != int64(i+1) {
+					t.Fatalf("Event %d has seq %d", i, event.Seq)
+				}
+			}
+		})
+	}
+}
+
+func TestEventLog_ConsumerGroups(t *testing.T) {
+	for name, log := range eventBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			for i := 0; i < 3; i++ {
+				_, _ = log.AppendEvent(ctx, "jobs", []byte(fmt.Sprintf("job-%d", i)))
+			}
+
+			first, err := log.ReadGroupEvents(ctx, "jobs", "workers", "replica-a", 2)
+			if err != nil || len(first) != 2 {
+				t.Fatalf("ReadGroupEvents(a) = %d events, %v; want 2", len(first), err)
+			}
+			second, _ := log.ReadGroupEvents(ctx, "jobs", "workers", "replica-b", 10)
+			if len(second) != 1 || second[0].Seq != 3 {
+				t.Fatalf("Expected replica-b to get only event 3, got %+v", second)
+			}
+			if none, _ := log.ReadGroupEvents(ctx, "jobs", "workers", "replica-a", 10); len(none) != 0 {
+				t.Errorf("Expected no new events, got %d", len(none))
+			}
+
+			// A separate group sees the whole stream
+			if all, _ := log.ReadGroupEvents(ctx, "jobs", "auditors", "auditor-1", 0); len(all) != 3 {
+				t.Errorf("Expected new group to start at the beginning, got %d events", len(all))
+			}
+
+			// replica-a processes event 1 but crashes before acking event 2
+			if err := log.AckEvents(ctx, "jobs", "workers", first[0].Seq); err != nil {
+				t.Fatalf("AckEvents() error = %v", err)
+			}
+			if claimed, _ := log.ClaimStaleEvents(ctx, "jobs", "workers", "replica-b", time.Hour, 10); len(claimed) != 0 {
+				t.Errorf("Expected recently delivered events not to be claimed, got %d", len(claimed))
+			}
+
+			claimed, err := log.ClaimStaleEvents(ctx, "jobs", "workers", "replica-b", 0, 10)
+			if err != nil {
+				t.Fatalf("ClaimStaleEvents() error = %v", err)
+			}
+			seqs := map[int64]bool{}
+			for _, event := range claimed {
+				seqs[event.Seq] = true
+			}
+			if len(claimed) != 2 || !seqs[2] || !seqs[3] {
+				t.Errorf("Expected unacked events 2 and 3 to be claimed, got %+v", claimed)
+			}
+
+			_ = log.AckEvents(ctx, "jobs", "workers", 2, 3)
+			if claimed, _ := log.ClaimStaleEvents(ctx, "jobs", "workers", "replica-b", 0, 10); len(claimed) != 0 {
+				t.Errorf("Expected nothing pending after ack, got %d", len(claimed))
+			}
+		})
+	}
+}
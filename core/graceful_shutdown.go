@@ -0,0 +1,274 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultDeregisterTimeout bounds how long shutdown waits for discovery to
+// remove a component
+const DefaultDeregisterTimeout = 5 * time.Second
+
+// deregistration makes leaving discovery idempotent, so the shutdown hook,
+// Stop and Shutdown can all call it without unregistering twice
+type deregistration struct {
+	mu   sync.Mutex
+	done bool
+}
+
+// run stops the component's heartbeat, so it cannot re-register itself, and
+// unregisters it. It runs even if ctx is already cancelled, bounded by
+// timeout. After one success later calls do nothing; after a failure the
+// next call retries.
+func (d *deregistration) run(ctx context.Context, registry Registry, id string, timeout time.Duration, logger Logger) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.done || registry == nil {
+		return nil
+	}
+	if logger == nil {
+		logger = &NoOpLogger{}
+	}
+	if timeout <= 0 {
+		timeout = DefaultDeregisterTimeout
+	}
+
+	if heartbeat, ok := registry.(interface {
+		StopHeartbeat(ctx context.Context, serviceID string)
+	}); ok {
+		heartbeat.StopHeartbeat(ctx, id)
+	}
+
+	// Shutdown usually starts with ctx cancelled, so only its values are kept
+	unregisterCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := registry.Unregister(unregisterCtx, id)
+	if errors.Is(err, ErrServiceNotFound) {
+		err = nil // Already gone, for example after its TTL expired
+	}
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	if metrics := GetGlobalMetricsRegistry(); metrics != nil {
+		metrics.Counter("discovery.deregistrations", "status", status)
+	}
+
+	if err != nil {
+		logger.Warn("Failed to deregister from discovery", map[string]interface{}{
+			"operation":   "deregister",
+			"service_id":  id,
+			"error":       err.Error(),
+			"error_type":  fmt.Sprintf("%T", err),
+			"timeout":     timeout.String(),
+			"duration_ms": time.Since(start).Milliseconds(),
+		})
+		return fmt.Errorf("failed to deregister %s: %w", id, err)
+	}
+
+	d.done = true
+	logger.Info("Deregistered from discovery", map[string]interface{}{
+		"operation":   "deregister",
+		"service_id":  id,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+	return nil
+}
+
+// deregisterTimeout returns the configured deregistration bound
+func deregisterTimeout(config *Config) time.Duration {
+	if config != nil && config.Discovery.DeregisterTimeout > 0 {
+		return config.Discovery.DeregisterTimeout
+	}
+	return DefaultDeregisterTimeout
+}
+
+// Deregister removes the agent from discovery and stops its heartbeat. It
+// is safe to call more than once and is bounded by
+// Discovery.DeregisterTimeout even when ctx is already cancelled.
+func (b *BaseAgent) Deregister(ctx context.Context) error {
+	var registry Registry
+	if b.Discovery != nil {
+		registry = b.Discovery
+	}
+	return b.deregistration.run(ctx, registry, b.ID, deregisterTimeout(b.Config), b.Logger)
+}
+
+// Deregister removes the tool from its registry and stops its heartbeat. It
+// is safe to call more than once and is bounded by
+// Discovery.DeregisterTimeout even when ctx is already cancelled.
+func (t *BaseTool) Deregister(ctx context.Context) error {
+	return t.deregistration.run(ctx, t.Registry, t.ID, deregisterTimeout(t.Config), t.Logger)
+}
+
+// runUntilShutdown starts the component and, when ctx is cancelled, shuts it
+// down gracefully (see shutdownComponent). With Discovery.DeregisterOnShutdown
+// it also reacts to SIGTERM and SIGINT and leaves discovery before draining,
+// so Kubernetes rolling updates stop routing to the pod right away instead of
+// after the registration TTL.
+func (f *Framework) runUntilShutdown(ctx context.Context) error {
+	var signals chan os.Signal
+	if f.config.Discovery.DeregisterOnShutdown {
+		signals = make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+		defer signal.Stop(signals)
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- f.component.Start(ctx, f.config.Port) }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	case <-signals:
+	}
+	f.shutdownComponent(ctx)
+
+	// Start may not have created its server yet, so don't wait forever
+	wait := f.config.HTTP.ShutdownTimeout
+	if wait <= 0 {
+		wait = DefaultDeregisterTimeout
+	}
+	select {
+	case err := <-errCh:
+		return err
+	case <-time.After(wait):
+		return ctx.Err()
+	}
+}
+
+// shutdownComponent runs the shutdown sequence, logging each stage:
+//
+//  1. report not ready on /health/ready, and deregister from discovery if
+//     Discovery.DeregisterOnShutdown is set
+//  2. drain the HTTP server: stop accepting connections and wait up to
+//     HTTP.ShutdownTimeout for in-flight requests
+//  3. flush and shut down telemetry
+//
+// Deregistering first is deliberate: routers stop sending new requests while
+// the server is still able to answer the ones already on their way.
+func (f *Framework) shutdownComponent(ctx context.Context) {
+	ctx = context.WithoutCancel(ctx)
+	start := time.Now()
+	agent, tool := componentBases(f.component)
+	logger := f.shutdownLogger(agent, tool)
+	switch {
+	case agent != nil:
+		agent.readiness.draining.Store(true)
+	case tool != nil:
+		tool.readiness.draining.Store(true)
+	}
+
+	logger.Info("Shutdown started", map[string]interface{}{
+		"operation":     "shutdown",
+		"drain_timeout": f.config.HTTP.ShutdownTimeout.String(),
+	})
+
+	if f.config.Discovery.DeregisterOnShutdown {
+		logger.Info("Shutdown stage: deregistering", map[string]interface{}{
+			"operation": "shutdown",
+			"stage":     "deregister",
+		})
+		switch {
+		case agent != nil:
+			_ = agent.Deregister(ctx) // Logged by Deregister
+		case tool != nil:
+			_ = tool.Deregister(ctx)
+		}
+	}
+
+	logger.Info("Shutdown stage: draining HTTP server", map[string]interface{}{
+		"operation": "shutdown",
+		"stage":     "drain",
+	})
+	drainStart := time.Now()
+	var err error
+	switch {
+	case agent != nil:
+		err = agent.Stop(ctx) // Bounded by HTTP.ShutdownTimeout
+	case tool != nil:
+		drainCtx := ctx
+		if f.config.HTTP.ShutdownTimeout > 0 {
+			var cancel context.CancelFunc
+			drainCtx, cancel = context.WithTimeout(ctx, f.config.HTTP.ShutdownTimeout)
+			defer cancel()
+		}
+		err = tool.Shutdown(drainCtx)
+	}
+	if err != nil {
+		logger.Warn("HTTP server did not drain cleanly", map[string]interface{}{
+			"operation":   "shutdown",
+			"stage":       "drain",
+			"error":       err.Error(),
+			"duration_ms": time.Since(drainStart).Milliseconds(),
+		})
+	} else {
+		logger.Info("HTTP server drained", map[string]interface{}{
+			"operation":   "shutdown",
+			"stage":       "drain",
+			"duration_ms": time.Since(drainStart).Milliseconds(),
+		})
+	}
+
+	f.shutdownTelemetry(ctx, logger)
+
+	logger.Info("Shutdown complete", map[string]interface{}{
+		"operation":   "shutdown",
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+}
+
+// shutdownTelemetry flushes and stops the global metrics registry when it
+// supports shutting down, as the telemetry module's registry does
+func (f *Framework) shutdownTelemetry(ctx context.Context, logger Logger) {
+	registry, ok := GetGlobalMetricsRegistry().(interface {
+		Shutdown(ctx context.Context) error
+	})
+	if !ok {
+		return
+	}
+
+	logger.Info("Shutdown stage: flushing telemetry", map[string]interface{}{
+		"operation": "shutdown",
+		"stage":     "telemetry",
+	})
+	timeout := f.config.HTTP.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = DefaultDeregisterTimeout
+	}
+	telemetryCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := registry.Shutdown(telemetryCtx); err != nil {
+		logger.Warn("Telemetry shutdown failed", map[string]interface{}{
+			"operation": "shutdown",
+			"stage":     "telemetry",
+			"error":     err.Error(),
+		})
+	}
+}
+
+// shutdownLogger returns the component's logger, falling back to the
+// framework's
+func (f *Framework) shutdownLogger(agent *BaseAgent, tool *BaseTool) Logger {
+	switch {
+	case agent != nil && agent.Logger != nil:
+		return agent.Logger
+	case tool != nil && tool.Logger != nil:
+		return tool.Logger
+	case f.config.logger != nil:
+		return f.config.logger
+	}
+	return &NoOpLogger{}
+}
@@ -0,0 +1,245 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// shutdownRegistry counts Unregister calls and can fail or block them
+type shutdownRegistry struct {
+	*MockDiscovery
+	unregisters    atomic.Int32
+	heartbeatsStop atomic.Int32
+	failNext       atomic.Bool
+	block          bool
+}
+
+func (r *shutdownRegistry) Unregister(ctx context.Context, id string) error {
+	r.unregisters.Add(1)
+	if r.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if r.failNext.CompareAndSwap(true, false) {
+		return errors.New("redis unavailable")
+	}
+	return r.MockDiscovery.Unregister(ctx, id)
+}
+
+func (r *shutdownRegistry) StopHeartbeat(ctx context.Context, serviceID string) {
+	r.heartbeatsStop.Add(1)
+}
+
+func TestDeregister_Idempotent(t *testing.T) {
+	registry := &shutdownRegistry{MockDiscovery: NewMockDiscovery()}
+	tool := NewTool("weather")
+	tool.Registry = registry
+	_ = registry.Register(context.Background(), &ServiceInfo{ID: tool.ID, Name: tool.Name})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Shutdown usually starts with the context already cancelled
+
+	registry.failNext.Store(true)
+	if err := tool.Deregister(ctx); err == nil {
+		t.Fatal("Expected the first attempt to fail")
+	}
+	if err := tool.Deregister(ctx); err != nil {
+		t.Fatalf("Expected the retry to succeed, got %v", err)
+	}
+	if err := tool.Deregister(ctx); err != nil {
+		t.Fatalf("Expected repeated Deregister to succeed, got %v", err)
+	}
+	if err := tool.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if got := registry.unregisters.Load(); got != 2 {
+		t.Errorf("Expected 2 Unregister calls, got %d", got)
+	}
+	if registry.heartbeatsStop.Load() == 0 {
+		t.Error("Expected the heartbeat to be stopped before unregistering")
+	}
+}
+
+func TestDeregister_BoundedByTimeout(t *testing.T) {
+	agent := NewBaseAgent("slow-discovery")
+	agent.Discovery = &shutdownRegistry{MockDiscovery: NewMockDiscovery(), block: true}
+	agent.Config.Discovery.DeregisterTimeout = 50 * time.Millisecond
+
+	start := time.Now()
+	err := agent.Deregister(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Deregister took %v despite a 50ms timeout", elapsed)
+	}
+}
+
+func TestFrameworkRun_DeregistersOnCancel(t *testing.T) {
+	registry := &shutdownRegistry{MockDiscovery: NewMockDiscovery()}
+	tool := NewTool("weather")
+	tool.Registry = registry
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to find a free port: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+
+	framework, err := NewFramework(tool, WithPort(port), WithRegistrationStagger(0))
+	if err != nil {
+		t.Fatalf("NewFramework failed: %v", err)
+	}
+	framework.config.ReloadOnSignal = false
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- framework.Run(ctx) }()
+
+	// Wait for the registration before shutting down
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		services, _ := registry.Discover(context.Background(), DiscoveryFilter{})
+		if len(services) > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Tool never registered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancellation")
+	}
+	if got := registry.unregisters.Load(); got != 1 {
+		t.Errorf("Expected exactly 1 Unregister call, got %d", got)
+	}
+	services, _ := registry.Discover(context.Background(), DiscoveryFilter{})
+	if len(services) != 0 {
+		t.Errorf("Expected the tool to be deregistered, found %d", len(services))
+	}
+}
+
+// shutdownMetricsRegistry records whether the framework shut telemetry down
+type shutdownMetricsRegistry struct {
+	mockMetricsRegistry
+	shutdowns atomic.Int32
+}
+
+func (r *shutdownMetricsRegistry) Shutdown(ctx context.Context) error {
+	r.shutdowns.Add(1)
+	return nil
+}
+
+func TestFrameworkRun_DrainsInFlightRequests(t *testing.T) {
+	originalRegistry := globalMetricsRegistry
+	metrics := &shutdownMetricsRegistry{}
+	globalMetricsRegistry = metrics
+	defer func() { globalMetricsRegistry = originalRegistry }()
+
+	started := make(chan struct{})
+	tool := NewTool("slow")
+	tool.RegisterCapability(Capability{
+		Name:     "slow",
+		Endpoint: "/slow",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			time.Sleep(300 * time.Millisecond)
+			_, _ = w.Write([]byte("done"))
+		},
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to find a free port: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+
+	// Leaving discovery is not needed to drain on cancellation
+	framework, err := NewFramework(tool, WithPort(port), WithShutdownTimeout(2*time.Second),
+		WithDeregisterOnShutdown(false, 0))
+	if err != nil {
+		t.Fatalf("NewFramework failed: %v", err)
+	}
+	framework.config.ReloadOnSignal = false
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- framework.Run(ctx) }()
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/slow", port)
+	result := make(chan error, 1)
+	go func() {
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			resp, err := http.Post(url, "application/json", nil)
+			if err == nil {
+				_ = resp.Body.Close()
+				if resp.StatusCode != http.StatusOK {
+					err = fmt.Errorf("status %d", resp.StatusCode)
+				}
+				result <- err
+				return
+			}
+			if time.Now().After(deadline) {
+				result <- err
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(3 * time.Second):
+		t.Fatal("Request never reached the handler")
+	}
+	cancel()
+
+	if err := <-result; err != nil {
+		t.Errorf("In-flight request was cut off: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancellation")
+	}
+	if got := metrics.shutdowns.Load(); got != 1 {
+		t.Errorf("Expected telemetry to be shut down once, got %d", got)
+	}
+}
+
+func TestWithShutdownTimeout(t *testing.T) {
+	config, err := NewConfig(WithShutdownTimeout(30 * time.Second))
+	if err != nil {
+		t.Fatalf("NewConfig failed: %v", err)
+	}
+	if config.HTTP.ShutdownTimeout != 30*time.Second {
+		t.Errorf("Expected a 30s drain window, got %v", config.HTTP.ShutdownTimeout)
+	}
+	if _, err := NewConfig(WithShutdownTimeout(0)); !errors.Is(err, ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for a zero timeout, got %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// LivenessPath reports that the process is alive. The configured
+	// HTTP.HealthCheckPath (default /health) answers the same way.
+	LivenessPath = "/health/live"
+
+	// ReadinessPath reports whether the component should receive traffic
+	ReadinessPath = "/health/ready"
+
+	// ReadinessCheckTimeout bounds each readiness check
+	ReadinessCheckTimeout = 2 * time.Second
+)
+
+// ReadinessCheck is a named dependency check run by the readiness endpoint.
+// Check returns nil when the dependency is usable.
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// readiness tracks the lifecycle half of a component's readiness: it is not
+// ready until Initialize has finished and, when discovery is in use,
+// registration has succeeded, and stops being ready once shutdown starts
+type readiness struct {
+	initialized          atomic.Bool
+	registrationRequired atomic.Bool
+	registered           atomic.Bool // Also set by the background registry retry
+	draining             atomic.Bool
+}
+
+// markInitialized records the end of Initialize. requireRegistration is
+// true when the component uses discovery.
+func (r *readiness) markInitialized(requireRegistration bool) {
+	r.registrationRequired.Store(requireRegistration)
+	r.initialized.Store(true)
+}
+
+// reason explains why the component is not ready, or returns ""
+func (r *readiness) reason() string {
+	switch {
+	case r.draining.Load():
+		return "draining"
+	case !r.initialized.Load():
+		return "initializing"
+	case r.registrationRequired.Load() && !r.registered.Load():
+		return "awaiting_registration"
+	}
+	return ""
+}
+
+// readinessResponse is the body of the readiness endpoint
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Reason string            `json:"reason,omitempty"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// readinessHandler serves ReadinessPath: 200 when the lifecycle state and
+// every configured check are ready, 503 otherwise. Checks run concurrently
+// and only once the lifecycle state is ready.
+func readinessHandler(state *readiness, config func() *Config, logger Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response := readinessResponse{Status: "ready", Reason: state.reason()}
+
+		if response.Reason == "" {
+			var checks []ReadinessCheck
+			if cfg := config(); cfg != nil {
+				checks = cfg.HTTP.ReadinessChecks
+			}
+			if failed := runReadinessChecks(r.Context(), checks, &response); len(failed) > 0 {
+				sort.Strings(failed)
+				response.Reason = "check_failed"
+				if logger != nil {
+					logger.Warn("Readiness check failed", map[string]interface{}{
+						"operation": "readiness",
+						"checks":    failed,
+					})
+				}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if response.Reason != "" {
+			response.Status = "not_ready"
+			w.WriteHeader(http.StatusServiceUnavailable)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+		_ = json.NewEncoder(w).Encode(response) // Status already written; nothing to recover
+	}
+}
+
+// runReadinessChecks runs checks concurrently, records each result in
+// response and returns the names of the failed ones
+func runReadinessChecks(ctx context.Context, checks []ReadinessCheck, response *readinessResponse) []string {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ReadinessCheckTimeout)
+	defer cancel()
+
+	results := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check ReadinessCheck) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					results[i] = fmt.Errorf("panic: %v", r)
+				}
+			}()
+			results[i] = check.Check(ctx)
+		}(i, check)
+	}
+	wg.Wait()
+
+	response.Checks = make(map[string]string, len(checks))
+	var failed []string
+	for i, check := range checks {
+		if results[i] != nil {
+			response.Checks[check.Name] = results[i].Error()
+			failed = append(failed, check.Name)
+			continue
+		}
+		response.Checks[check.Name] = "ok"
+	}
+	return failed
+}
+
+// DependenciesReady returns a readiness check that fails until every named
+// service has at least one instance in discovery. Use it with
+// WithReadinessCheck. The agent's discovery is looked up on each call, so
+// the check can be created before Initialize connects it.
+func (b *BaseAgent) DependenciesReady(services ...string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		b.mu.RLock()
+		discovery := b.Discovery
+		b.mu.RUnlock()
+		if discovery == nil {
+			return fmt.Errorf("discovery not configured")
+		}
+
+		for _, service := range services {
+			instances, err := discovery.FindService(ctx, service)
+			if err != nil {
+				return fmt.Errorf("failed to discover %s: %w", service, err)
+			}
+			if len(instances) == 0 {
+				return fmt.Errorf("%s: %w", service, ErrServiceNotFound)
+			}
+		}
+		return nil
+	}
+}
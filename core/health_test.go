@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func getHealth(t *testing.T, handler http.Handler, path string) (int, readinessResponse) {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	var body readinessResponse
+	_ = json.Unmarshal(recorder.Body.Bytes(), &body)
+	return recorder.Code, body
+}
+
+func TestReadiness_ToolLifecycle(t *testing.T) {
+	tool := NewTool("weather")
+	tool.Registry = NewMockDiscovery()
+	tool.Config = DefaultConfig()
+	tool.Config.Discovery.RegistrationStagger = 0
+	handler := tool.Handler()
+
+	for _, path := range []string{"/health", LivenessPath} {
+		if code, _ := getHealth(t, handler, path); code != http.StatusOK {
+			t.Errorf("Expected %s to report alive before initialization, got %d", path, code)
+		}
+	}
+	if code, body := getHealth(t, handler, ReadinessPath); code != http.StatusServiceUnavailable || body.Reason != "initializing" {
+		t.Errorf("Expected not ready while initializing, got %d %+v", code, body)
+	}
+
+	if err := tool.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if code, body := getHealth(t, handler, ReadinessPath); code != http.StatusOK || body.Status != "ready" {
+		t.Errorf("Expected ready after registration, got %d %+v", code, body)
+	}
+
+	if err := tool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if code, body := getHealth(t, handler, ReadinessPath); code != http.StatusServiceUnavailable || body.Reason != "draining" {
+		t.Errorf("Expected not ready while draining, got %d %+v", code, body)
+	}
+	if code, _ := getHealth(t, handler, LivenessPath); code != http.StatusOK {
+		t.Errorf("Expected liveness to stay up while draining, got %d", code)
+	}
+}
+
+func TestReadiness_AwaitsRegistration(t *testing.T) {
+	state := &readiness{}
+	state.markInitialized(true)
+	if got := state.reason(); got != "awaiting_registration" {
+		t.Errorf("Expected awaiting_registration, got %q", got)
+	}
+	state.registered.Store(true) // As the background retry does
+	if got := state.reason(); got != "" {
+		t.Errorf("Expected ready, got %q", got)
+	}
+}
+
+func TestReadiness_Checks(t *testing.T) {
+	agent := NewBaseAgent("planner")
+	agent.Discovery = NewMockDiscovery()
+	config, err := NewConfig(
+		WithReadinessCheck("redis", func(ctx context.Context) error { return nil }),
+		WithReadinessCheck("dependencies", agent.DependenciesReady("weather")),
+		WithReadinessCheck("flaky", func(ctx context.Context) error { panic("boom") }),
+	)
+	if err != nil {
+		t.Fatalf("NewConfig failed: %v", err)
+	}
+	agent.Config = config
+	agent.readiness.markInitialized(false)
+	handler := agent.Handler()
+
+	code, body := getHealth(t, handler, ReadinessPath)
+	if code != http.StatusServiceUnavailable || body.Reason != "check_failed" {
+		t.Fatalf("Expected failing checks to make the agent not ready, got %d %+v", code, body)
+	}
+	if body.Checks["redis"] != "ok" || body.Checks["dependencies"] == "ok" || body.Checks["flaky"] == "" {
+		t.Errorf("Expected per-check results, got %v", body.Checks)
+	}
+
+	_ = agent.Discovery.Register(context.Background(), &ServiceInfo{ID: "weather-1", Name: "weather"})
+	agent.Config.HTTP.ReadinessChecks = agent.Config.HTTP.ReadinessChecks[:2]
+	if code, body := getHealth(t, handler, ReadinessPath); code != http.StatusOK {
+		t.Errorf("Expected ready once dependencies are discovered, got %d %+v", code, body)
+	}
+
+	if _, err := NewConfig(WithReadinessCheck("", nil)); !errors.Is(err, ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for an unnamed check, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"time"
+)
+
+const (
+	// DefaultHeartbeatJitter randomizes each heartbeat interval by ±10%
+	DefaultHeartbeatJitter = 0.1
+
+	// DefaultRegistrationStagger is the most a component waits before its
+	// first registration
+	DefaultRegistrationStagger = time.Second
+
+	// maxHeartbeatJitter keeps heartbeats well inside the registration TTL
+	maxHeartbeatJitter = 0.5
+)
+
+// SetHeartbeatJitter sets how much each heartbeat interval varies, as a
+// fraction of the interval: 0.1 means ±10%. Replicas deployed together
+// otherwise heartbeat in lockstep and hit Redis at the same moment.
+// Values are clamped to [0, 0.5]. Zero disables jitter.
+func (r *RedisRegistry) SetHeartbeatJitter(fraction float64) {
+	if fraction < 0 {
+		fraction = 0
+	}
+	if fraction > maxHeartbeatJitter {
+		fraction = maxHeartbeatJitter
+	}
+	r.heartbeatJitter = fraction
+}
+
+// SetRegistrationStagger sets the most StaggerRegistration waits. Zero
+// disables the delay.
+func (r *RedisRegistry) SetRegistrationStagger(maxDelay time.Duration) {
+	if maxDelay < 0 {
+		maxDelay = 0
+	}
+	r.registrationStagger = maxDelay
+}
+
+// StaggerRegistration waits a random delay up to the registration stagger so
+// replicas started together don't register at the same instant. It returns
+// early if ctx is cancelled.
+func (r *RedisRegistry) StaggerRegistration(ctx context.Context) {
+	delay := randomDuration(r.registrationStagger)
+	if delay <= 0 {
+		return
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// firstHeartbeatDelay spreads the first heartbeat uniformly over one
+// interval, so heartbeats of replicas that registered together start out of
+// phase
+func (r *RedisRegistry) firstHeartbeatDelay() time.Duration {
+	base := r.ttl / 2
+	if r.heartbeatJitter == 0 {
+		return base
+	}
+	return randomDuration(base) + 1
+}
+
+// nextHeartbeatInterval returns the heartbeat interval with a fresh random
+// jitter of up to ±heartbeatJitter
+func (r *RedisRegistry) nextHeartbeatInterval() time.Duration {
+	base := r.ttl / 2
+	spread := time.Duration(float64(base) * r.heartbeatJitter)
+	if spread <= 0 {
+		return base
+	}
+	return base - spread + randomDuration(2*spread)
+}
+
+// randomDuration returns a random duration in [0, max)
+func randomDuration(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		return 0
+	}
+	return time.Duration(n.Int64())
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestHeartbeatJitterBounds(t *testing.T) {
+	r := &RedisRegistry{ttl: 10 * time.Second, heartbeatJitter: DefaultHeartbeatJitter}
+	base := 5 * time.Second
+
+	distinct := map[time.Duration]bool{}
+	for i := 0; i < 1000; i++ {
+		interval := r.nextHeartbeatInterval()
+		if interval < base*9/10 || interval >= base*11/10 {
+			t.Fatalf("Interval %v outside ±10%% of %v", interval, base)
+		}
+		distinct[interval] = true
+
+		first := r.firstHeartbeatDelay()
+		if first <= 0 || first > base {
+			t.Fatalf("First heartbeat delay %v outside (0, %v]", first, base)
+		}
+	}
+	if len(distinct) < 100 {
+		t.Errorf("Expected intervals to vary, got %d distinct values", len(distinct))
+	}
+
+	r.SetHeartbeatJitter(0)
+	if r.nextHeartbeatInterval() != base || r.firstHeartbeatDelay() != base {
+		t.Error("Expected fixed intervals with jitter disabled")
+	}
+
+	r.SetHeartbeatJitter(3)
+	if r.heartbeatJitter != maxHeartbeatJitter {
+		t.Errorf("Expected jitter clamped to %v, got %v", maxHeartbeatJitter, r.heartbeatJitter)
+	}
+}
+
+func TestStaggerRegistration(t *testing.T) {
+	r := &RedisRegistry{}
+	r.SetRegistrationStagger(50 * time.Millisecond)
+
+	start := time.Now()
+	r.StaggerRegistration(context.Background())
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("Stagger took %v, expected at most about 50ms", elapsed)
+	}
+
+	r.SetRegistrationStagger(time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start = time.Now()
+	r.StaggerRegistration(ctx)
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Stagger ignored cancellation, took %v", elapsed)
+	}
+}
+
+// TestHeartbeatsSpreadAcrossReplicas starts 100 registrars at once and checks
+// their heartbeats are spread over the interval instead of clustered
+func TestHeartbeatsSpreadAcrossReplicas(t *testing.T) {
+	const replicas = 100
+	mr := miniredis.RunT(t)
+	ctx := context.Background()
+
+	registries := make([]*RedisRegistry, replicas)
+	for i := range registries {
+		registry, err := NewRedisRegistry("redis://" + mr.Addr())
+		if err != nil {
+			t.Fatalf("Failed to create registry: %v", err)
+		}
+		t.Cleanup(func() { _ = registry.client.Close() })
+		registry.ttl = 400 * time.Millisecond // 200ms heartbeat
+
+		id := fmt.Sprintf("replica-%d", i)
+		if err := registry.Register(ctx, &ServiceInfo{ID: id, Name: "replica", Type: ComponentTypeAgent}); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+		registries[i] = registry
+	}
+	for i, registry := range registries {
+		registry.StartHeartbeat(ctx, fmt.Sprintf("replica-%d", i))
+	}
+
+	time.Sleep(500 * time.Millisecond)
+
+	var beats []time.Time
+	for i, registry := range registries {
+		registry.heartbeatMutex.RLock()
+		if stats := registry.heartbeatStats[fmt.Sprintf("replica-%d", i)]; stats != nil && stats.SuccessCount > 0 {
+			beats = append(beats, stats.LastSuccess)
+		}
+		registry.heartbeatMutex.RUnlock()
+		registry.StopHeartbeat(ctx, fmt.Sprintf("replica-%d", i))
+	}
+	if len(beats) != replicas {
+		t.Fatalf("Expected every replica to heartbeat, got %d", len(beats))
+	}
+
+	sort.Slice(beats, func(i, j int) bool { return beats[i].Before(beats[j]) })
+	if spread := beats[len(beats)-1].Sub(beats[0]); spread < 100*time.Millisecond {
+		t.Errorf("Heartbeats spread over only %v", spread)
+	}
+
+	// Uniform over ~200ms puts about 5 heartbeats in any 10ms window
+	densest := 0
+	for i := range beats {
+		j := i
+		for j < len(beats) && beats[j].Sub(beats[i]) < 10*time.Millisecond {
+			j++
+		}
+		if j-i > densest {
+			densest = j - i
+		}
+	}
+	if densest > 25 {
+		t.Errorf("%d of %d heartbeats fell within 10ms", densest, replicas)
+	}
+}
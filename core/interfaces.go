@@ -3,6 +3,8 @@ package core
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"os"
 	"sync"
 	"time"
 )
@@ -46,6 +48,31 @@ type ComponentAwareLogger interface {
 	WithComponent(component string) Logger
 }
 
+// FatalLogger extends Logger with a FATAL level that exits the process
+// after the message is written. ProductionLogger implements it.
+type FatalLogger interface {
+	Logger
+	Fatal(msg string, fields map[string]interface{})
+	FatalWithContext(ctx context.Context, msg string, fields map[string]interface{})
+}
+
+// Fatal logs msg at FATAL level and exits. Loggers that do not implement
+// FatalLogger log the message as an error before the process exits with
+// status 1, so startup paths can use it with any Logger.
+func Fatal(logger Logger, msg string, fields map[string]interface{}) {
+	if fl, ok := logger.(FatalLogger); ok {
+		fl.Fatal(msg, fields)
+		return
+	}
+	if logger != nil {
+		logger.Error(msg, fields)
+	}
+	osExit(1)
+}
+
+// osExit is swapped in tests so the fallback path of Fatal can be exercised
+var osExit = os.Exit
+
 // Telemetry interface - optional telemetry support
 type Telemetry interface {
 	StartSpan(ctx context.Context, name string) (context.Context, Span)
@@ -64,12 +91,102 @@ type AIClient interface {
 	GenerateResponse(ctx context.Context, prompt string, options *AIOptions) (*AIResponse, error)
 }
 
+// Message roles for Message.Role
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+	RoleTool      = "tool"
+)
+
+// Message is one turn of a conversation
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+
+	// ToolCalls are the tools an assistant message invoked, replayed so the
+	// model sees its own calls before the RoleTool messages answering them
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+
+	// ToolCallID is the ToolCall.ID a RoleTool message answers
+	ToolCallID string `json:"tool_call_id,omitempty"`
+}
+
+// MessageAIClient is implemented by AI clients that accept a conversation
+// in the provider's native chat format. AIOptions.SystemPrompt, when set,
+// is sent before any RoleSystem messages. Use GenerateMessages to call any
+// AIClient with messages.
+type MessageAIClient interface {
+	AIClient
+	GenerateMessages(ctx context.Context, messages []Message, options *AIOptions) (*AIResponse, error)
+}
+
 // AIOptions for AI generation
 type AIOptions struct {
 	Model        string
 	Temperature  float32
 	MaxTokens    int
 	SystemPrompt string
+
+	// Timeout bounds this call when set. If ctx already has an earlier
+	// deadline, that one applies. A call that runs out of time returns an
+	// error wrapping context.DeadlineExceeded.
+	Timeout time.Duration
+
+	// Tools the model may call instead of answering in text. Providers
+	// without tool support ignore them. Tool calls are only returned by
+	// GenerateResponse, not StreamResponse.
+	Tools []ToolDefinition
+
+	// ResponseFormat is ResponseFormatText (the default when empty) or
+	// ResponseFormatJSON. In JSON mode, providers that support it constrain
+	// the model to emit a JSON object, and GenerateResponse strips markdown
+	// fences and surrounding prose from the content on every provider.
+	ResponseFormat string
+
+	// RetryPolicy retries this call according to the policy instead of the
+	// client's. Nil uses the client's policy. Streaming calls are never
+	// retried.
+	RetryPolicy *AIRetryPolicy
+}
+
+// AIRetryPolicy controls how transient AI provider failures are retried.
+// A Retry-After header on a failed response sets the delay before the next
+// attempt, up to MaxDelay.
+type AIRetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// RetryDelay is the base delay, doubled on every retry
+	RetryDelay time.Duration
+	// MaxDelay caps each delay, including one set by Retry-After. Zero
+	// means no cap.
+	MaxDelay time.Duration
+	// Jitter randomizes each delay by up to this fraction (0.2 = ±20%) so
+	// clients that failed together don't retry together
+	Jitter float64
+	// ShouldRetry decides whether a failed attempt is retried. resp is nil
+	// for network errors. Nil retries network errors, 429 and 5xx.
+	ShouldRetry func(resp *http.Response, err error) bool
+}
+
+// Response formats for AIOptions.ResponseFormat
+const (
+	ResponseFormatText = "text"
+	ResponseFormatJSON = "json_object"
+)
+
+// ToolDefinition describes a function the model may call
+type ToolDefinition struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters"` // JSON Schema for the arguments
+}
+
+// ToolCall is a model's request to invoke a tool with structured arguments
+type ToolCall struct {
+	ID        string                 `json:"id,omitempty"`
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments"`
 }
 
 // AIResponse from AI client
@@ -78,6 +195,13 @@ type AIResponse struct {
 	Model    string
 	Provider string // Provider identifier (e.g., "openai", "openai.groq", "anthropic", "gemini", "bedrock")
 	Usage    TokenUsage
+
+	// IdempotencyKey is the key sent to the provider for deduplication, if any
+	IdempotencyKey string
+
+	// ToolCalls holds the tools the model chose to invoke, if AIOptions.Tools
+	// was set. Content may be empty when ToolCalls is not.
+	ToolCalls []ToolCall
 }
 
 // TokenUsage for AI responses
@@ -192,6 +316,7 @@ func (n *NoOpSpan) RecordError(err error)                      {}
 
 // InMemoryStore provides a simple in-memory implementation of Memory
 type InMemoryStore struct {
+	mu   sync.RWMutex
 	data map[string]string
 }
 
@@ -202,6 +327,8 @@ func NewInMemoryStore() *InMemoryStore {
 }
 
 func (m *InMemoryStore) Get(ctx context.Context, key string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	value, exists := m.data[key]
 	if !exists {
 		return "", nil
@@ -210,20 +337,62 @@ func (m *InMemoryStore) Get(ctx context.Context, key string) (string, error) {
 }
 
 func (m *InMemoryStore) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.data[key] = value
 	return nil
 }
 
 func (m *InMemoryStore) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	delete(m.data, key)
 	return nil
 }
 
 func (m *InMemoryStore) Exists(ctx context.Context, key string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	_, exists := m.data[key]
 	return exists, nil
 }
 
+// Scan lists keys matching pattern. See MemoryStore.Scan for cursor semantics.
+func (m *InMemoryStore) Scan(ctx context.Context, cursor uint64, pattern string, count int) ([]string, uint64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	all := make([]string, 0, len(m.data))
+	for key := range m.data {
+		all = append(all, key)
+	}
+	page, next := scanPage(all, cursor, count)
+
+	match := globMatcher(pattern)
+	keys := make([]string, 0, len(page))
+	for _, key := range page {
+		if match(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, next, nil
+}
+
+// StorageStats reports key count and value sizes for keys matching pattern
+func (m *InMemoryStore) StorageStats(ctx context.Context, pattern string) (StorageStats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	match := globMatcher(pattern)
+	collector := newStorageStatsCollector(pattern, "value_length")
+	for key, value := range m.data {
+		if match(key) {
+			collector.add(key, int64(len(value)))
+		}
+	}
+	return collector.result(), nil
+}
+
 // ============================================================================
 // Global Registry Pattern for Telemetry Integration
 // ============================================================================
@@ -0,0 +1,202 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// RedactedValue replaces any value removed by a Redactor
+const RedactedValue = "[REDACTED]"
+
+// Redactor rewrites a structured field before it is written. It receives
+// the field key and value and returns the value to output; returning the
+// value unchanged keeps it. Nested maps and slices are walked by
+// RedactFields, so a Redactor only needs to handle leaf values.
+type Redactor func(key string, value interface{}) interface{}
+
+// sensitiveKeys are field names masked by the PII redactor. Matching is
+// case-insensitive and ignores "-" and "_" so "apiKey" and "API-KEY" match.
+var sensitiveKeys = map[string]bool{
+	"password":      true,
+	"passwd":        true,
+	"secret":        true,
+	"token":         true,
+	"accesstoken":   true,
+	"refreshtoken":  true,
+	"apikey":        true,
+	"authorization": true,
+	"email":         true,
+	"ssn":           true,
+}
+
+// defaultPIIPatterns match sensitive data inside string values
+var defaultPIIPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`), // email
+	regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),                            // US SSN
+}
+
+// cardNumberPattern matches card number candidates: 13-19 digits,
+// optionally grouped by spaces or dashes. Only candidates that pass the
+// Luhn check are redacted, so order numbers and timestamps are kept.
+var cardNumberPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+
+// RedactPIIText replaces email addresses, payment card numbers and US SSNs
+// in text with RedactedValue. It is the value-level part of NewPIIRedactor,
+// for free text such as prompts that has no field keys.
+func RedactPIIText(text string) string {
+	for _, re := range defaultPIIPatterns {
+		text = re.ReplaceAllString(text, RedactedValue)
+	}
+	return cardNumberPattern.ReplaceAllStringFunc(text, func(candidate string) string {
+		if luhnValid(candidate) {
+			return RedactedValue
+		}
+		return candidate
+	})
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum used by
+// payment card numbers. Separators are skipped.
+func luhnValid(s string) bool {
+	sum, digits := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if digits%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+	}
+	return digits > 0 && sum%10 == 0
+}
+
+// NewPIIRedactor returns the built-in Redactor. It masks fields whose key
+// names a credential or personal identifier (password, token, api_key,
+// email, ssn, ...) and replaces matches of RedactPIIText inside string
+// values. extraPatterns are additional regular expressions whose matches
+// are replaced; invalid patterns are ignored.
+func NewPIIRedactor(extraPatterns ...string) Redactor {
+	var patterns []*regexp.Regexp
+	for _, p := range extraPatterns {
+		if re, err := regexp.Compile(p); err == nil {
+			patterns = append(patterns, re)
+		}
+	}
+
+	return func(key string, value interface{}) interface{} {
+		if isSensitiveKey(key) {
+			return RedactedValue
+		}
+		s, ok := value.(string)
+		if !ok {
+			return value
+		}
+		s = RedactPIIText(s)
+		for _, re := range patterns {
+			s = re.ReplaceAllString(s, RedactedValue)
+		}
+		return s
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	return sensitiveKeys[normalized]
+}
+
+// RedactFields applies redactor to every field, descending into nested
+// maps and slices. It returns a new map and never modifies fields. With a
+// nil redactor, fields is returned unchanged.
+func RedactFields(fields map[string]interface{}, redactor Redactor) map[string]interface{} {
+	if redactor == nil || fields == nil {
+		return fields
+	}
+	out := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		out[k] = redactValue(k, v, redactor)
+	}
+	return out
+}
+
+func redactValue(key string, value interface{}, redactor Redactor) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if isSensitiveKey(key) {
+			return redactor(key, v)
+		}
+		return RedactFields(v, redactor)
+	case map[string]string:
+		if isSensitiveKey(key) {
+			return redactor(key, v)
+		}
+		out := make(map[string]string, len(v))
+		for k, s := range v {
+			if r, ok := redactValue(k, s, redactor).(string); ok {
+				out[k] = r
+			} else {
+				out[k] = RedactedValue
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = redactValue(key, item, redactor)
+		}
+		return out
+	case []string:
+		out := make([]string, len(v))
+		for i, item := range v {
+			if r, ok := redactValue(key, item, redactor).(string); ok {
+				out[i] = r
+			} else {
+				out[i] = RedactedValue
+			}
+		}
+		return out
+	default:
+		return redactor(key, value)
+	}
+}
+
+// globalRedactor holds the process-wide redaction policy
+var globalRedactor atomic.Value // redactorHolder
+
+type redactorHolder struct{ redactor Redactor }
+
+// SetRedactor installs the process-wide redaction policy. ProductionLogger
+// applies it to every structured log field, and the telemetry module applies
+// the same policy to metric labels, so logs and metrics share one policy.
+// Pass nil to disable redaction.
+//
+// Example:
+//
+//	core.SetRedactor(core.NewPIIRedactor(`\bacct-\d+\b`))
+func SetRedactor(redactor Redactor) {
+	globalRedactor.Store(redactorHolder{redactor: redactor})
+}
+
+// GetRedactor returns the process-wide redaction policy, or nil if none is set
+func GetRedactor() Redactor {
+	if h, ok := globalRedactor.Load().(redactorHolder); ok {
+		return h.redactor
+	}
+	return nil
+}
+
+// WithRedactor sets the process-wide redaction policy when the framework is
+// configured. See SetRedactor.
+func WithRedactor(redactor Redactor) Option {
+	return func(c *Config) error {
+		SetRedactor(redactor)
+		return nil
+	}
+}
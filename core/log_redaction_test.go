@@ -0,0 +1,96 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPIIRedactor_SensitiveKeys(t *testing.T) {
+	redact := NewPIIRedactor()
+
+	for _, key := range []string{"password", "token", "api_key", "apiKey", "API-KEY", "email", "ssn", "Authorization"} {
+		assert.Equal(t, RedactedValue, redact(key, "value"), key)
+	}
+	assert.Equal(t, "Tokyo", redact("city", "Tokyo"))
+	assert.Equal(t, 42, redact("count", 42))
+}
+
+func TestPIIRedactor_ValuePatterns(t *testing.T) {
+	redact := NewPIIRedactor(`acct-\d+`)
+
+	assert.Equal(t, "contact [REDACTED] today", redact("note", "contact jane.doe@example.com today"))
+	assert.Equal(t, "card [REDACTED]", redact("note", "card 4111 1111 1111 1111"))
+	assert.Equal(t, "ssn [REDACTED]", redact("note", "ssn 123-45-6789"))
+	assert.Equal(t, "account [REDACTED]", redact("note", "account acct-991"))
+	assert.Equal(t, "order 12345", redact("note", "order 12345"))
+	assert.Equal(t, "card [REDACTED]", redact("note", "card 5500-0000-0000-0004"))
+}
+
+func TestRedactPIIText_LuhnCheck(t *testing.T) {
+	assert.Equal(t, "paid with [REDACTED]", RedactPIIText("paid with 4111111111111111"))
+	// Long digit runs that aren't card numbers are kept
+	assert.Equal(t, "order 4111111111111112", RedactPIIText("order 4111111111111112"))
+	assert.Equal(t, "at 1700000000000000000", RedactPIIText("at 1700000000000000000"))
+}
+
+func TestRedactFields_Nested(t *testing.T) {
+	fields := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":     "Jane",
+			"password": "hunter2",
+			"contacts": []interface{}{"jane@example.com", map[string]interface{}{"token": "abc"}},
+		},
+		"headers": map[string]string{"Authorization": "Bearer xyz", "Accept": "json"},
+		"emails":  []string{"a@example.com"},
+	}
+
+	out := RedactFields(fields, NewPIIRedactor())
+
+	user := out["user"].(map[string]interface{})
+	assert.Equal(t, "Jane", user["name"])
+	assert.Equal(t, RedactedValue, user["password"])
+	contacts := user["contacts"].([]interface{})
+	assert.Equal(t, RedactedValue, contacts[0])
+	assert.Equal(t, RedactedValue, contacts[1].(map[string]interface{})["token"])
+	assert.Equal(t, map[string]string{"Authorization": RedactedValue, "Accept": "json"}, out["headers"])
+	assert.Equal(t, []string{RedactedValue}, out["emails"])
+
+	// The caller's map is left untouched
+	assert.Equal(t, "hunter2", fields["user"].(map[string]interface{})["password"])
+}
+
+func TestRedactFields_NilRedactor(t *testing.T) {
+	fields := map[string]interface{}{"password": "hunter2"}
+	assert.Equal(t, fields, RedactFields(fields, nil))
+}
+
+func TestProductionLogger_AppliesRedactor(t *testing.T) {
+	SetRedactor(NewPIIRedactor())
+	defer SetRedactor(nil)
+
+	var buf bytes.Buffer
+	logger := NewProductionLogger(LoggingConfig{Level: "info", Format: "json"}, DevelopmentConfig{}, "test-service").(*ProductionLogger)
+	logger.output = &buf
+
+	logger.Info("User signed in", map[string]interface{}{"email": "jane@example.com", "plan": "pro"})
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, RedactedValue, entry["email"])
+	assert.Equal(t, "pro", entry["plan"])
+}
+
+func TestWithRedactorOption(t *testing.T) {
+	defer SetRedactor(nil)
+
+	_, err := NewConfig(WithRedactor(NewPIIRedactor()))
+	require.NoError(t, err)
+	assert.NotNil(t, GetRedactor())
+
+	SetRedactor(nil)
+	assert.Nil(t, GetRedactor())
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// LogSampleKeyField is an optional log field that overrides the sampling key.
+// Lines sharing a key are sampled together, which lets messages that embed
+// variable text (IDs, counts) still be grouped:
+//
+//	logger.Debug(fmt.Sprintf("cache miss for %s", key), map[string]interface{}{
+//	    core.LogSampleKeyField: "cache_miss",
+//	})
+const LogSampleKeyField = "sample_key"
+
+// defaultLogSampleInterval is used when sampling is enabled without an interval
+const defaultLogSampleInterval = time.Second
+
+// logSampleKey identifies a group of similar log lines
+type logSampleKey struct {
+	level string
+	key   string
+}
+
+// logSuppression reports how many lines of a group were dropped in a window
+type logSuppression struct {
+	level      string
+	key        string
+	suppressed int
+}
+
+// logSampler lets the first `initial` lines of each key through per interval
+// and counts the rest. It is shared by a logger and its component children.
+type logSampler struct {
+	initial  int
+	interval time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	windowEnd time.Time
+	seen      map[logSampleKey]int
+}
+
+// newLogSampler returns nil when sampling is disabled (initial <= 0)
+func newLogSampler(initial int, interval time.Duration) *logSampler {
+	if initial <= 0 {
+		return nil
+	}
+	if interval <= 0 {
+		interval = defaultLogSampleInterval
+	}
+	return &logSampler{
+		initial:  initial,
+		interval: interval,
+		now:      time.Now,
+		seen:     make(map[logSampleKey]int),
+	}
+}
+
+// allow reports whether a line should be written. When the previous window
+// has closed, it also returns the groups that were suppressed during it so
+// the caller can summarize them.
+func (s *logSampler) allow(level, msg string, fields map[string]interface{}) (bool, []logSuppression) {
+	key := logSampleKey{level: level, key: msg}
+	if k, ok := fields[LogSampleKeyField].(string); ok && k != "" {
+		key.key = k
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var summary []logSuppression
+	now := s.now()
+	if !now.Before(s.windowEnd) {
+		summary = s.suppressedLocked()
+		s.seen = make(map[logSampleKey]int)
+		s.windowEnd = now.Add(s.interval)
+	}
+
+	s.seen[key]++
+	return s.seen[key] <= s.initial, summary
+}
+
+// suppressedLocked lists groups that exceeded the limit in the current window
+func (s *logSampler) suppressedLocked() []logSuppression {
+	var out []logSuppression
+	for k, n := range s.seen {
+		if n > s.initial {
+			out = append(out, logSuppression{level: k.level, key: k.key, suppressed: n - s.initial})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].level != out[j].level {
+			return out[i].level < out[j].level
+		}
+		return out[i].key < out[j].key
+	})
+	return out
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSampledLogger returns a JSON logger writing to buf with a controllable clock
+func newSampledLogger(t *testing.T, initial int, buf *bytes.Buffer, now *time.Time) *ProductionLogger {
+	t.Helper()
+	logger := NewProductionLogger(
+		LoggingConfig{Level: "debug", Format: "json", SampleInitial: initial, SampleInterval: time.Second},
+		DevelopmentConfig{},
+		"test-service",
+	).(*ProductionLogger)
+	logger.output = buf
+	if logger.sampler != nil {
+		logger.sampler.now = func() time.Time { return *now }
+	}
+	return logger
+}
+
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestLogSampling_DisabledByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Now()
+	logger := newSampledLogger(t, 0, &buf, &now)
+	assert.Nil(t, logger.sampler)
+
+	for i := 0; i < 10; i++ {
+		logger.Debug("cache miss", nil)
+	}
+	assert.Len(t, decodeLogLines(t, &buf), 10)
+}
+
+func TestLogSampling_DropsAndSummarizes(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Unix(1000, 0)
+	logger := newSampledLogger(t, 2, &buf, &now)
+
+	for i := 0; i < 5; i++ {
+		logger.Debug("cache miss", nil)
+	}
+	logger.Info("other message", nil)
+	assert.Len(t, decodeLogLines(t, &buf), 3, "first 2 repeats plus the distinct message")
+
+	buf.Reset()
+	now = now.Add(time.Second)
+	logger.Debug("cache miss", nil)
+
+	lines := decodeLogLines(t, &buf)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "Suppressed 3 similar log messages", lines[0]["message"])
+	assert.Equal(t, "DEBUG", lines[0]["level"])
+	assert.Equal(t, "cache miss", lines[0][LogSampleKeyField])
+	assert.Equal(t, float64(3), lines[0]["suppressed"])
+	assert.Equal(t, "cache miss", lines[1]["message"], "a new window starts fresh")
+}
+
+func TestLogSampling_ExplicitKeyGroupsMessages(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Unix(1000, 0)
+	logger := newSampledLogger(t, 1, &buf, &now)
+
+	logger.Debug("cache miss for user-1", map[string]interface{}{LogSampleKeyField: "cache_miss"})
+	logger.Debug("cache miss for user-2", map[string]interface{}{LogSampleKeyField: "cache_miss"})
+
+	assert.Len(t, decodeLogLines(t, &buf), 1)
+}
+
+func TestLogSampling_ErrorsAreNeverSampled(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Unix(1000, 0)
+	logger := newSampledLogger(t, 1, &buf, &now)
+
+	for i := 0; i < 3; i++ {
+		logger.Error("backend down", nil)
+	}
+	assert.Len(t, decodeLogLines(t, &buf), 3)
+}
+
+func TestLogSampling_SharedWithComponentLoggers(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Unix(1000, 0)
+	logger := newSampledLogger(t, 1, &buf, &now)
+	child := logger.WithComponent("agent/test")
+
+	logger.Debug("tick", nil)
+	child.Debug("tick", nil)
+
+	assert.Len(t, decodeLogLines(t, &buf), 1)
+}
+
+func TestLogSampling_LoadFromEnv(t *testing.T) {
+	_ = os.Setenv("GOMIND_LOG_SAMPLE_INITIAL", "100")
+	_ = os.Setenv("GOMIND_LOG_SAMPLE_INTERVAL", "5s")
+	defer func() {
+		_ = os.Unsetenv("GOMIND_LOG_SAMPLE_INITIAL")
+		_ = os.Unsetenv("GOMIND_LOG_SAMPLE_INTERVAL")
+	}()
+
+	cfg := DefaultConfig()
+	require.NoError(t, cfg.LoadFromEnv())
+	assert.Equal(t, 100, cfg.Logging.SampleInitial)
+	assert.Equal(t, 5*time.Second, cfg.Logging.SampleInterval)
+}
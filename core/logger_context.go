@@ -0,0 +1,138 @@
+package core
+
+import "context"
+
+// LoggerWithContext returns a Logger bound to ctx. Every log line written
+// through it, including the plain Info/Error/Warn/Debug methods, carries the
+// trace_id and span_id of the active span in ctx so logs link to traces.
+//
+// Trace IDs are read through the telemetry module when it is registered.
+// Without telemetry, or without an active span, no correlation fields are
+// added and the returned logger behaves like the one it wraps.
+//
+// Usage in a capability handler:
+//
+//	log := core.LoggerWithContext(agent.Logger, r.Context())
+//	log.Info("Fetching forecast", map[string]interface{}{"city": city})
+func LoggerWithContext(logger Logger, ctx context.Context) Logger {
+	if logger == nil {
+		logger = &NoOpLogger{}
+	}
+	if ctx == nil {
+		return logger
+	}
+	// Rebinding replaces the context rather than stacking wrappers
+	if cl, ok := logger.(*contextLogger); ok {
+		logger = cl.base
+	}
+	return &contextLogger{base: logger, ctx: ctx}
+}
+
+// contextLogger routes every call through the wrapped logger's
+// context-aware methods with the bound context and trace fields
+type contextLogger struct {
+	base Logger
+	ctx  context.Context
+}
+
+func (c *contextLogger) Info(msg string, fields map[string]interface{}) {
+	c.base.InfoWithContext(c.ctx, msg, withTraceFields(c.ctx, fields))
+}
+
+func (c *contextLogger) Error(msg string, fields map[string]interface{}) {
+	c.base.ErrorWithContext(c.ctx, msg, withTraceFields(c.ctx, fields))
+}
+
+func (c *contextLogger) Warn(msg string, fields map[string]interface{}) {
+	c.base.WarnWithContext(c.ctx, msg, withTraceFields(c.ctx, fields))
+}
+
+func (c *contextLogger) Debug(msg string, fields map[string]interface{}) {
+	c.base.DebugWithContext(c.ctx, msg, withTraceFields(c.ctx, fields))
+}
+
+// The *WithContext variants prefer the context passed to the call, which is
+// usually a child of the bound one carrying a more specific span.
+
+func (c *contextLogger) InfoWithContext(ctx context.Context, msg string, fields map[string]interface{}) {
+	ctx = c.resolve(ctx)
+	c.base.InfoWithContext(ctx, msg, withTraceFields(ctx, fields))
+}
+
+func (c *contextLogger) ErrorWithContext(ctx context.Context, msg string, fields map[string]interface{}) {
+	ctx = c.resolve(ctx)
+	c.base.ErrorWithContext(ctx, msg, withTraceFields(ctx, fields))
+}
+
+func (c *contextLogger) WarnWithContext(ctx context.Context, msg string, fields map[string]interface{}) {
+	ctx = c.resolve(ctx)
+	c.base.WarnWithContext(ctx, msg, withTraceFields(ctx, fields))
+}
+
+func (c *contextLogger) DebugWithContext(ctx context.Context, msg string, fields map[string]interface{}) {
+	ctx = c.resolve(ctx)
+	c.base.DebugWithContext(ctx, msg, withTraceFields(ctx, fields))
+}
+
+// Fatal writes through the wrapped logger's FATAL level (see core.Fatal)
+func (c *contextLogger) Fatal(msg string, fields map[string]interface{}) {
+	c.FatalWithContext(c.ctx, msg, fields)
+}
+
+func (c *contextLogger) FatalWithContext(ctx context.Context, msg string, fields map[string]interface{}) {
+	ctx = c.resolve(ctx)
+	fields = withTraceFields(ctx, fields)
+	if fl, ok := c.base.(FatalLogger); ok {
+		fl.FatalWithContext(ctx, msg, fields)
+		return
+	}
+	c.base.ErrorWithContext(ctx, msg, fields)
+	osExit(1)
+}
+
+// WithComponent keeps the bound context when the wrapped logger supports
+// component-specific child loggers
+func (c *contextLogger) WithComponent(component string) Logger {
+	if cal, ok := c.base.(ComponentAwareLogger); ok {
+		return &contextLogger{base: cal.WithComponent(component), ctx: c.ctx}
+	}
+	return c
+}
+
+func (c *contextLogger) resolve(ctx context.Context) context.Context {
+	if ctx == nil {
+		return c.ctx
+	}
+	return ctx
+}
+
+// withTraceFields returns fields with trace_id and span_id from ctx added,
+// along with the request metadata set by WithRequestMetadata. The caller's
+// map is never modified, and fields the caller set win; it is returned
+// as-is when there is nothing to add.
+func withTraceFields(ctx context.Context, fields map[string]interface{}) map[string]interface{} {
+	baggage := getContextBaggage(ctx)
+	traceID, spanID := baggage["trace_id"], baggage["span_id"]
+	if _, ok := fields["trace_id"]; ok {
+		traceID = ""
+	}
+	meta := RequestMetadataFromContext(ctx)
+	if traceID == "" && len(meta) == 0 {
+		return fields
+	}
+
+	out := make(map[string]interface{}, len(fields)+len(meta)+2)
+	for k, v := range meta {
+		out[k] = v
+	}
+	for k, v := range fields {
+		out[k] = v
+	}
+	if traceID != "" {
+		out["trace_id"] = traceID
+		if spanID != "" {
+			out["span_id"] = spanID
+		}
+	}
+	return out
+}
@@ -0,0 +1,137 @@
+package core
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type spanKey struct{}
+
+// spanBaggageRegistry reports trace IDs only for contexts carrying a span,
+// mirroring how the telemetry registry reads the active OpenTelemetry span
+type spanBaggageRegistry struct {
+	mockMetricsRegistry
+}
+
+func (s *spanBaggageRegistry) GetBaggage(ctx context.Context) map[string]string {
+	if span, ok := ctx.Value(spanKey{}).(string); ok {
+		return map[string]string{"trace_id": "trace-" + span, "span_id": span}
+	}
+	return map[string]string{}
+}
+
+func withSpanBaggage(t *testing.T) {
+	original := globalMetricsRegistry
+	globalMetricsRegistry = &spanBaggageRegistry{}
+	t.Cleanup(func() { globalMetricsRegistry = original })
+}
+
+func TestLoggerWithContext(t *testing.T) {
+	withSpanBaggage(t)
+	ctx := context.WithValue(context.Background(), spanKey{}, "abc")
+
+	t.Run("plain methods carry trace fields", func(t *testing.T) {
+		base := &MockLogger{}
+		logger := LoggerWithContext(base, ctx)
+		fields := map[string]interface{}{"city": "Tokyo"}
+
+		logger.Info("info", fields)
+		logger.Error("error", nil)
+		logger.Warn("warn", nil)
+		logger.Debug("debug", nil)
+
+		if len(base.entries) != 4 {
+			t.Fatalf("Expected 4 entries, got %d", len(base.entries))
+		}
+		for _, entry := range base.entries {
+			if entry.Fields["trace_id"] != "trace-abc" || entry.Fields["span_id"] != "abc" {
+				t.Errorf("%s: expected trace fields, got %v", entry.Level, entry.Fields)
+			}
+		}
+		if base.entries[0].Fields["city"] != "Tokyo" {
+			t.Errorf("Expected caller fields to be kept, got %v", base.entries[0].Fields)
+		}
+		if _, ok := fields["trace_id"]; ok {
+			t.Error("Expected caller's field map to be left unmodified")
+		}
+	})
+
+	t.Run("explicit context takes precedence", func(t *testing.T) {
+		base := &MockLogger{}
+		logger := LoggerWithContext(base, ctx)
+		child := context.WithValue(ctx, spanKey{}, "child")
+
+		logger.InfoWithContext(child, "child span", nil)
+
+		if got := base.entries[0].Fields["span_id"]; got != "child" {
+			t.Errorf("Expected span_id from call context, got %v", got)
+		}
+	})
+
+	t.Run("no active span adds no fields", func(t *testing.T) {
+		base := &MockLogger{}
+		logger := LoggerWithContext(base, context.Background())
+
+		logger.Info("no span", map[string]interface{}{"k": "v"})
+
+		if _, ok := base.entries[0].Fields["trace_id"]; ok {
+			t.Errorf("Expected no trace_id, got %v", base.entries[0].Fields)
+		}
+	})
+
+	t.Run("no telemetry registry adds no fields", func(t *testing.T) {
+		original := globalMetricsRegistry
+		globalMetricsRegistry = nil
+		defer func() { globalMetricsRegistry = original }()
+
+		base := &MockLogger{}
+		LoggerWithContext(base, ctx).Info("no telemetry", nil)
+
+		if base.entries[0].Fields != nil {
+			t.Errorf("Expected fields to pass through unchanged, got %v", base.entries[0].Fields)
+		}
+	})
+
+	t.Run("rebinding does not stack wrappers", func(t *testing.T) {
+		base := &MockLogger{}
+		logger := LoggerWithContext(LoggerWithContext(base, ctx), context.Background())
+
+		cl, ok := logger.(*contextLogger)
+		if !ok || cl.base != Logger(base) {
+			t.Fatalf("Expected a single wrapper around the base logger, got %#v", logger)
+		}
+	})
+
+	t.Run("nil logger is safe", func(t *testing.T) {
+		LoggerWithContext(nil, ctx).Info("dropped", nil)
+	})
+}
+
+func TestBaseAgentCapabilityLogsCarryTraceFields(t *testing.T) {
+	withSpanBaggage(t)
+
+	base := &MockLogger{}
+	agent := NewBaseAgent("trace-agent")
+	agent.Logger = base
+	agent.RegisterCapability(Capability{Name: "echo"})
+	base.entries = nil
+
+	req := httptest.NewRequest("POST", "/api/capabilities/echo", strings.NewReader(`{}`))
+	req = req.WithContext(context.WithValue(req.Context(), spanKey{}, "req"))
+	agent.Handler().ServeHTTP(httptest.NewRecorder(), req)
+
+	found := false
+	for _, entry := range base.entries {
+		if entry.Message == "Handling capability request" {
+			found = true
+			if entry.Fields["trace_id"] != "trace-req" {
+				t.Errorf("Expected trace_id on capability log, got %v", entry.Fields)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("Expected the capability request to be logged")
+	}
+}
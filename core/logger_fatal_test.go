@@ -0,0 +1,78 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogLevel_Fatal(t *testing.T) {
+	assert.Equal(t, LogLevelFatal, parseLogLevel("fatal"))
+	assert.Equal(t, LogLevelFatal, parseLogLevel(" FATAL "))
+	assert.True(t, LogLevelFatal > LogLevelError, "FATAL must rank above ERROR")
+}
+
+func TestProductionLogger_FatalLogsThenExits(t *testing.T) {
+	var buf bytes.Buffer
+	exitCode := -1
+	logger := NewProductionLogger(LoggingConfig{Level: "info", Format: "json"}, DevelopmentConfig{}, "svc",
+		WithLogWriter(&buf),
+		WithLogExitFunc(func(code int) { exitCode = code }),
+		WithLogFatalExitCode(3),
+	).(*ProductionLogger)
+
+	logger.Fatal("Cannot bind port", map[string]interface{}{"port": 8080})
+
+	assert.Equal(t, 3, exitCode)
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "FATAL", entry["level"])
+	assert.Equal(t, "Cannot bind port", entry["message"])
+	assert.Equal(t, float64(8080), entry["port"])
+}
+
+func TestProductionLogger_FatalLevelFiltersErrors(t *testing.T) {
+	var buf bytes.Buffer
+	exited := false
+	logger := NewProductionLogger(LoggingConfig{Level: "fatal", Format: "text"}, DevelopmentConfig{}, "svc",
+		WithLogWriter(&buf),
+		WithLogExitFunc(func(int) { exited = true }),
+	).(*ProductionLogger)
+
+	logger.Error("ignored", nil)
+	assert.Empty(t, buf.String(), "errors rank below the fatal level")
+
+	logger.FatalWithContext(context.Background(), "shutting down", nil)
+	assert.Contains(t, buf.String(), "[FATAL]")
+	assert.True(t, exited)
+}
+
+func TestProductionLogger_FatalDefaultsToExitCodeOne(t *testing.T) {
+	exitCode := -1
+	logger := NewProductionLogger(LoggingConfig{Level: "info"}, DevelopmentConfig{}, "svc",
+		WithLogWriter(&bytes.Buffer{}),
+		WithLogExitFunc(func(code int) { exitCode = code }),
+	).(*ProductionLogger)
+	child := logger.WithComponent("agent/test").(FatalLogger)
+
+	child.Fatal("boom", nil)
+	assert.Equal(t, 1, exitCode, "component loggers inherit the exit behavior")
+}
+
+func TestFatalHelper_FallsBackToError(t *testing.T) {
+	exitCode := -1
+	original := osExit
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = original }()
+
+	base := &MockLogger{}
+	Fatal(base, "config invalid", nil)
+
+	require.Len(t, base.entries, 1)
+	assert.Equal(t, "error", base.entries[0].Level)
+	assert.Equal(t, 1, exitCode)
+}
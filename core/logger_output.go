@@ -0,0 +1,115 @@
+package core
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// LoggerOption customizes a ProductionLogger created by NewProductionLogger
+type LoggerOption func(*ProductionLogger)
+
+// WithLogWriter sends log output to w instead of stdout/stderr.
+// Useful for writing to a file or capturing output in tests:
+//
+//	var buf bytes.Buffer
+//	logger := core.NewProductionLogger(cfg.Logging, cfg.Development, "svc", core.WithLogWriter(&buf))
+func WithLogWriter(w io.Writer) LoggerOption {
+	return func(p *ProductionLogger) {
+		if w != nil {
+			p.output = newSyncWriter(w)
+		}
+	}
+}
+
+// WithLogWriters fans log output out to every writer, for example a local
+// file and a network collector. Each line is written to all writers; a
+// failing writer does not stop the others from receiving the line.
+func WithLogWriters(writers ...io.Writer) LoggerOption {
+	return func(p *ProductionLogger) {
+		var sinks []io.Writer
+		for _, w := range writers {
+			if w != nil {
+				sinks = append(sinks, w)
+			}
+		}
+		switch len(sinks) {
+		case 0:
+			return
+		case 1:
+			p.output = newSyncWriter(sinks[0])
+		default:
+			p.output = newSyncWriter(fanoutWriter(sinks))
+		}
+	}
+}
+
+// WithLogTimeFunc injects the clock used for log timestamps and sampling
+// windows, making timestamp output deterministic in tests.
+func WithLogTimeFunc(now func() time.Time) LoggerOption {
+	return func(p *ProductionLogger) {
+		if now != nil {
+			p.now = now
+		}
+	}
+}
+
+// WithLoggerOptions applies LoggerOptions to the logger the framework
+// creates from Config. It has no effect when WithLogger supplies a logger.
+func WithLoggerOptions(opts ...LoggerOption) Option {
+	return func(c *Config) error {
+		c.loggerOptions = append(c.loggerOptions, opts...)
+		return nil
+	}
+}
+
+// syncWriter serializes writes so concurrent log calls, including those from
+// component loggers sharing the writer, never interleave within a line
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newSyncWriter(w io.Writer) io.Writer {
+	if sw, ok := w.(*syncWriter); ok {
+		return sw
+	}
+	return &syncWriter{w: w}
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// fanoutWriter writes each line to every sink. Unlike io.MultiWriter it
+// keeps going after a sink fails and reports the first error.
+type fanoutWriter []io.Writer
+
+func (f fanoutWriter) Write(p []byte) (int, error) {
+	var firstErr error
+	for _, w := range f {
+		if _, err := w.Write(p); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return len(p), firstErr
+}
+
+// WithLogExitFunc replaces os.Exit as the function Fatal calls after
+// logging. Tests use it to observe fatal paths without ending the binary.
+func WithLogExitFunc(exit func(code int)) LoggerOption {
+	return func(p *ProductionLogger) {
+		if exit != nil {
+			p.exit = exit
+		}
+	}
+}
+
+// WithLogFatalExitCode sets the process exit code used by Fatal (default 1)
+func WithLogFatalExitCode(code int) LoggerOption {
+	return func(p *ProductionLogger) {
+		p.fatalExitCode = code
+	}
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errors.New("collector unavailable") }
+
+func TestWithLogWriter_CapturesOutput(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewProductionLogger(LoggingConfig{Level: "info", Format: "json"}, DevelopmentConfig{}, "svc", WithLogWriter(&buf))
+
+	logger.Info("hello", map[string]interface{}{"k": "v"})
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "hello", entry["message"])
+	assert.Equal(t, "v", entry["k"])
+}
+
+func TestWithLogWriters_FansOutPastFailures(t *testing.T) {
+	var file, collector bytes.Buffer
+	logger := NewProductionLogger(LoggingConfig{Level: "info", Format: "text"}, DevelopmentConfig{}, "svc",
+		WithLogWriters(&file, failingWriter{}, &collector))
+
+	logger.Info("shipped", nil)
+
+	assert.Contains(t, file.String(), "shipped")
+	assert.Equal(t, file.String(), collector.String())
+}
+
+func TestWithLogWriter_ConcurrentUse(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewProductionLogger(LoggingConfig{Level: "info", Format: "json"}, DevelopmentConfig{}, "svc", WithLogWriter(&buf))
+	child := logger.(ComponentAwareLogger).WithComponent("agent/test")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() { defer wg.Done(); logger.Info("parent", nil) }()
+		go func() { defer wg.Done(); child.Info("child", nil) }()
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 40)
+	for _, line := range lines {
+		assert.True(t, json.Valid([]byte(line)), "interleaved line: %q", line)
+	}
+}
+
+func TestWithLogTimeFunc_DeterministicTimestamp(t *testing.T) {
+	fixed := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	var buf bytes.Buffer
+	logger := NewProductionLogger(LoggingConfig{Level: "info", Format: "text"}, DevelopmentConfig{}, "svc",
+		WithLogWriter(&buf), WithLogTimeFunc(func() time.Time { return fixed }))
+
+	logger.Info("tick", nil)
+
+	assert.Equal(t, "2025-03-14T15:09:26Z [INFO] [svc] tick\n", buf.String())
+}
+
+func TestWithLoggerOptions_AppliedByNewConfig(t *testing.T) {
+	var buf bytes.Buffer
+	cfg, err := NewConfig(WithName("svc"), WithLoggerOptions(WithLogWriter(&buf)))
+	require.NoError(t, err)
+
+	cfg.logger.Error("routed", nil)
+	assert.Contains(t, buf.String(), "routed")
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// AtomicMemory is implemented by Memory backends that can update a key
+// without a racy Get-then-Set, for counters, rate limits and leases shared
+// between agent replicas. Both operations keep the key's existing TTL.
+//
+// RedisMemory is atomic across every process sharing the Redis instance.
+// MemoryStore and InMemoryStore are only atomic within one process.
+type AtomicMemory interface {
+	Memory
+	// Increment adds delta to the integer stored at key and returns the new
+	// value. A missing key counts as 0. It fails if the value is not an
+	// integer.
+	Increment(ctx context.Context, key string, delta int64) (int64, error)
+	// CompareAndSwap sets key to new only if its current value is old and
+	// reports whether it did. An old value of "" matches a missing key,
+	// consistent with Get.
+	CompareAndSwap(ctx context.Context, key, old, new string) (bool, error)
+}
+
+// Increment adds delta to the integer at key, local to this process
+func (m *MemoryStore) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, exists := m.store[key]
+	if exists && !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		entry, exists = memoryEntry{}, false
+	}
+
+	value, err := incrementValue(key, entry.value, exists, delta)
+	if err != nil {
+		return 0, err
+	}
+	entry.value = strconv.FormatInt(value, 10)
+	m.store[key] = entry
+
+	if registry := GetGlobalMetricsRegistry(); registry != nil {
+		registry.Counter("memory.operations", "operation", "increment", "memory_type", "in_memory", "result", "success")
+	}
+	return value, nil
+}
+
+// CompareAndSwap swaps the value at key, local to this process
+func (m *MemoryStore) CompareAndSwap(ctx context.Context, key, old, new string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, exists := m.store[key]
+	if exists && !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		entry = memoryEntry{}
+	}
+
+	swapped := entry.value == old
+	if swapped {
+		entry.value = new
+		m.store[key] = entry
+	}
+
+	if registry := GetGlobalMetricsRegistry(); registry != nil {
+		registry.Counter("memory.operations", "operation", "compare_and_swap", "memory_type", "in_memory", "result", casResult(swapped))
+	}
+	return swapped, nil
+}
+
+// Increment adds delta to the integer at key, local to this process
+func (m *InMemoryStore) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, exists := m.data[key]
+	value, err := incrementValue(key, current, exists, delta)
+	if err != nil {
+		return 0, err
+	}
+	m.data[key] = strconv.FormatInt(value, 10)
+	return value, nil
+}
+
+// CompareAndSwap swaps the value at key, local to this process
+func (m *InMemoryStore) CompareAndSwap(ctx context.Context, key, old, new string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.data[key] != old {
+		return false, nil
+	}
+	m.data[key] = new
+	return true, nil
+}
+
+// incrementValue parses the current value of key and adds delta
+func incrementValue(key, current string, exists bool, delta int64) (int64, error) {
+	if !exists {
+		return delta, nil
+	}
+	n, err := strconv.ParseInt(current, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("value of key %s is not an integer", key)
+	}
+	return n + delta, nil
+}
+
+func casResult(swapped bool) string {
+	if swapped {
+		return "swapped"
+	}
+	return "conflict"
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func atomicBackends(t *testing.T) map[string]AtomicMemory {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	redisMemory, err := NewRedisMemory("redis://" + mr.Addr())
+	if err != nil {
+		t.Fatalf("NewRedisMemory() error = %v", err)
+	}
+	t.Cleanup(func() { _ = redisMemory.Close() })
+
+	return map[string]AtomicMemory{
+		"memory_store":    NewMemoryStore(),
+		"in_memory_store": NewInMemoryStore(),
+		"redis":           redisMemory,
+	}
+}
+
+func TestAtomicMemory_Increment(t *testing.T) {
+	for name, memory := range atomicBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if n, err := memory.Increment(ctx, "hits", 5); err != nil || n != 5 {
+				t.Fatalf("Increment() on missing key = %d, %v; want 5", n, err)
+			}
+			if n, err := memory.Increment(ctx, "hits", -2); err != nil || n != 3 {
+				t.Fatalf("Increment() = %d, %v; want 3", n, err)
+			}
+			if value, _ := memory.Get(ctx, "hits"); value != "3" {
+				t.Errorf("Get() = %q, want \"3\"", value)
+			}
+
+			_ = memory.Set(ctx, "name", "alice", 0)
+			if _, err := memory.Increment(ctx, "name", 1); err == nil {
+				t.Error("Expected an error incrementing a non-integer value")
+			}
+		})
+	}
+}
+
+func TestAtomicMemory_IncrementConcurrent(t *testing.T) {
+	for name, memory := range atomicBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			const workers, perWorker = 10, 20
+
+			var wg sync.WaitGroup
+			for i := 0; i < workers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < perWorker; j++ {
+						if _, err := memory.Increment(ctx, "counter", 1); err != nil {
+							t.Errorf("Increment() error = %v", err)
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			if value, _ := memory.Get(ctx, "counter"); value != fmt.Sprint(workers*perWorker) {
+				t.Errorf("counter = %s, want %d", value, workers*perWorker)
+			}
+		})
+	}
+}
+
+func TestAtomicMemory_CompareAndSwap(t *testing.T) {
+	for name, memory := range atomicBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// "" matches a missing key
+			if ok, err := memory.CompareAndSwap(ctx, "leader", "", "agent-1"); err != nil || !ok {
+				t.Fatalf("CompareAndSwap() on missing key = %v, %v; want true", ok, err)
+			}
+			if ok, err := memory.CompareAndSwap(ctx, "leader", "", "agent-2"); err != nil || ok {
+				t.Fatalf("CompareAndSwap() with stale old = %v, %v; want false", ok, err)
+			}
+			if ok, err := memory.CompareAndSwap(ctx, "leader", "agent-1", "agent-2"); err != nil || !ok {
+				t.Fatalf("CompareAndSwap() = %v, %v; want true", ok, err)
+			}
+			if value, _ := memory.Get(ctx, "leader"); value != "agent-2" {
+				t.Errorf("Get() = %q, want agent-2", value)
+			}
+		})
+	}
+}
+
+func TestAtomicMemory_CompareAndSwapConcurrent(t *testing.T) {
+	for name, memory := range atomicBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var wg sync.WaitGroup
+			var mu sync.Mutex
+			winners := 0
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					ok, err := memory.CompareAndSwap(ctx, "lock", "", fmt.Sprintf("worker-%d", i))
+					if err != nil {
+						t.Errorf("CompareAndSwap() error = %v", err)
+					}
+					if ok {
+						mu.Lock()
+						winners++
+						mu.Unlock()
+					}
+				}(i)
+			}
+			wg.Wait()
+
+			if winners != 1 {
+				t.Errorf("%d workers acquired the lock, want exactly 1", winners)
+			}
+		})
+	}
+}
+
+func TestAtomicMemory_KeepsTTL(t *testing.T) {
+	for name, memory := range atomicBackends(t) {
+		if name == "in_memory_store" {
+			continue // InMemoryStore ignores TTLs
+		}
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			reader := memory.(MemoryTTLReader)
+
+			_ = memory.Set(ctx, "window", "1", time.Minute)
+			if _, err := memory.Increment(ctx, "window", 1); err != nil {
+				t.Fatalf("Increment() error = %v", err)
+			}
+			if ttl, exists, _ := reader.TTL(ctx, "window"); !exists || ttl <= 0 {
+				t.Errorf("TTL after Increment = %v, %v; want it kept", ttl, exists)
+			}
+
+			if ok, _ := memory.CompareAndSwap(ctx, "window", "2", "10"); !ok {
+				t.Fatal("Expected CompareAndSwap to succeed")
+			}
+			if ttl, exists, _ := reader.TTL(ctx, "window"); !exists || ttl <= 0 {
+				t.Errorf("TTL after CompareAndSwap = %v, %v; want it kept", ttl, exists)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// BatchMemory is implemented by Memory backends that can read and write
+// many keys in one round-trip, such as an agent saving every fragment of a
+// conversation at once
+type BatchMemory interface {
+	Memory
+	// MGet returns the values of keys that exist. Missing and expired keys
+	// are omitted from the map rather than reported as errors.
+	MGet(ctx context.Context, keys []string) (map[string]string, error)
+	// MSet stores every item without expiry
+	MSet(ctx context.Context, items map[string]string) error
+	// MSetWithTTL stores every item with the same TTL
+	MSetWithTTL(ctx context.Context, items map[string]string, ttl time.Duration) error
+}
+
+// MGet returns the values of the keys that exist
+func (m *MemoryStore) MGet(ctx context.Context, keys []string) (map[string]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	values := make(map[string]string, len(keys))
+	for _, key := range keys {
+		entry, exists := m.store[key]
+		if !exists || (!entry.expiresAt.IsZero() && now.After(entry.expiresAt)) {
+			continue
+		}
+		values[key] = entry.value
+	}
+
+	if registry := GetGlobalMetricsRegistry(); registry != nil {
+		registry.Counter("memory.operations", "operation", "mget", "memory_type", "in_memory", "result", "success")
+	}
+	return values, nil
+}
+
+// MSet stores every item without expiry
+func (m *MemoryStore) MSet(ctx context.Context, items map[string]string) error {
+	return m.MSetWithTTL(ctx, items, 0)
+}
+
+// MSetWithTTL stores every item with the same TTL
+func (m *MemoryStore) MSetWithTTL(ctx context.Context, items map[string]string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+	for key, value := range items {
+		m.store[key] = memoryEntry{value: value, expiresAt: expiresAt}
+	}
+
+	if registry := GetGlobalMetricsRegistry(); registry != nil {
+		registry.Counter("memory.operations", "operation", "mset", "memory_type", "in_memory", "result", "success")
+	}
+	return nil
+}
+
+// MGet returns the values of the keys that exist
+func (m *InMemoryStore) MGet(ctx context.Context, keys []string) (map[string]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	values := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if value, exists := m.data[key]; exists {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+// MSet stores every item
+func (m *InMemoryStore) MSet(ctx context.Context, items map[string]string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, value := range items {
+		m.data[key] = value
+	}
+	return nil
+}
+
+// MSetWithTTL stores every item. Like Set, it ignores ttl.
+func (m *InMemoryStore) MSetWithTTL(ctx context.Context, items map[string]string, ttl time.Duration) error {
+	return m.MSet(ctx, items)
+}
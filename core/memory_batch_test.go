@@ -0,0 +1,106 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func batchBackends(t *testing.T) map[string]BatchMemory {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	redisMemory, err := NewRedisMemory("redis://" + mr.Addr())
+	if err != nil {
+		t.Fatalf("NewRedisMemory() error = %v", err)
+	}
+	t.Cleanup(func() { _ = redisMemory.Close() })
+
+	return map[string]BatchMemory{
+		"memory_store":    NewMemoryStore(),
+		"in_memory_store": NewInMemoryStore(),
+		"redis":           redisMemory,
+	}
+}
+
+func TestBatchMemory_MSetAndMGet(t *testing.T) {
+	for name, memory := range batchBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			items := map[string]string{"frag:1": "hello", "frag:2": "world", "frag:3": ""}
+
+			if err := memory.MSet(ctx, items); err != nil {
+				t.Fatalf("MSet() error = %v", err)
+			}
+
+			values, err := memory.MGet(ctx, []string{"frag:1", "frag:2", "frag:3", "frag:missing"})
+			if err != nil {
+				t.Fatalf("MGet() error = %v", err)
+			}
+			if len(values) != 3 || values["frag:1"] != "hello" || values["frag:2"] != "world" {
+				t.Errorf("MGet() = %v", values)
+			}
+			if _, ok := values["frag:missing"]; ok {
+				t.Error("Missing keys should be omitted from MGet")
+			}
+
+			// Batch writes are visible to single-key reads
+			if value, _ := memory.Get(ctx, "frag:2"); value != "world" {
+				t.Errorf("Get() = %q, want world", value)
+			}
+			if ttl, exists, _ := ttlOf(ctx, memory, "frag:1"); exists && ttl != 0 {
+				t.Errorf("MSet should not set an expiry, got TTL %v", ttl)
+			}
+		})
+	}
+}
+
+func TestBatchMemory_Empty(t *testing.T) {
+	for name, memory := range batchBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if err := memory.MSet(ctx, nil); err != nil {
+				t.Errorf("MSet(nil) error = %v", err)
+			}
+			values, err := memory.MGet(ctx, nil)
+			if err != nil || len(values) != 0 {
+				t.Errorf("MGet(nil) = %v, %v", values, err)
+			}
+		})
+	}
+}
+
+func TestBatchMemory_MSetWithTTL(t *testing.T) {
+	for name, memory := range batchBackends(t) {
+		if name == "in_memory_store" {
+			continue // InMemoryStore ignores TTLs
+		}
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if err := memory.MSetWithTTL(ctx, map[string]string{"a": "1", "b": "2"}, time.Minute); err != nil {
+				t.Fatalf("MSetWithTTL() error = %v", err)
+			}
+			for _, key := range []string{"a", "b"} {
+				ttl, exists, err := ttlOf(ctx, memory, key)
+				if err != nil || !exists || ttl <= 0 || ttl > time.Minute {
+					t.Errorf("TTL(%s) = %v, %v, %v; want up to 1m", key, ttl, exists, err)
+				}
+			}
+		})
+	}
+}
+
+// ttlOf reads the TTL of key from backends that report it
+func ttlOf(ctx context.Context, memory Memory, key string) (time.Duration, bool, error) {
+	reader, ok := memory.(MemoryTTLReader)
+	if !ok {
+		return 0, false, nil
+	}
+	return reader.TTL(ctx, key)
+}
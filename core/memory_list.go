@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultListKeysLimit is the most keys ListKeys returns before failing
+const DefaultListKeysLimit = 10000
+
+// ListOption customizes ListKeys
+type ListOption func(*listOptions)
+
+type listOptions struct {
+	batchSize int
+	limit     int
+}
+
+// WithListBatchSize sets how many keys are requested per scan
+func WithListBatchSize(size int) ListOption {
+	return func(o *listOptions) {
+		if size > 0 {
+			o.batchSize = size
+		}
+	}
+}
+
+// WithListLimit sets the most keys ListKeys may return. Use 0 for no limit.
+func WithListLimit(limit int) ListOption {
+	return func(o *listOptions) {
+		if limit >= 0 {
+			o.limit = limit
+		}
+	}
+}
+
+// ListKeys returns every key in memory matching pattern. It pages through
+// the keyspace with MemoryScanner.Scan, so Redis is never blocked by KEYS,
+// and fails with ErrKeyLimitExceeded instead of silently truncating when
+// more than the limit (DefaultListKeysLimit unless set with WithListLimit)
+// match. For keyspaces too large to hold in memory, call Scan directly and
+// process one page at a time.
+func ListKeys(ctx context.Context, memory Memory, pattern string, opts ...ListOption) ([]string, error) {
+	scanner, ok := memory.(MemoryScanner)
+	if !ok {
+		return nil, fmt.Errorf("memory %T does not implement MemoryScanner", memory)
+	}
+
+	options := &listOptions{batchSize: DefaultMigrationBatchSize, limit: DefaultListKeysLimit}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	// SCAN may return a key more than once
+	seen := make(map[string]struct{})
+	keys := []string{}
+	var cursor uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		page, next, err := scanner.Scan(ctx, cursor, pattern, options.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range page {
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			if options.limit > 0 && len(keys) >= options.limit {
+				return nil, fmt.Errorf("more than %d keys match %q: %w", options.limit, pattern, ErrKeyLimitExceeded)
+			}
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return keys, nil
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestListKeys(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	redisMemory, err := NewRedisMemory("redis://" + mr.Addr())
+	if err != nil {
+		t.Fatalf("NewRedisMemory() error = %v", err)
+	}
+	defer func() { _ = redisMemory.Close() }()
+
+	backends := map[string]Memory{
+		"memory_store":    NewMemoryStore(),
+		"in_memory_store": NewInMemoryStore(),
+		"redis":           redisMemory,
+		"namespaced":      NewNamespacedMemory(NewMemoryStore(), "agent"),
+	}
+
+	for name, memory := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			for i := 0; i < 250; i++ {
+				_ = memory.Set(ctx, fmt.Sprintf("conversation:%03d", i), "x", 0)
+			}
+			_ = memory.Set(ctx, "other", "x", 0)
+
+			keys, err := ListKeys(ctx, memory, "conversation:*", WithListBatchSize(40))
+			if err != nil {
+				t.Fatalf("ListKeys() error = %v", err)
+			}
+			sort.Strings(keys)
+			if len(keys) != 250 || keys[0] != "conversation:000" || keys[249] != "conversation:249" {
+				t.Errorf("ListKeys() returned %d keys, first %v", len(keys), keys[:min(3, len(keys))])
+			}
+
+			_, err = ListKeys(ctx, memory, "conversation:*", WithListLimit(100))
+			if !errors.Is(err, ErrKeyLimitExceeded) {
+				t.Errorf("ListKeys() over the limit error = %v, want ErrKeyLimitExceeded", err)
+			}
+
+			keys, err = ListKeys(ctx, memory, "*", WithListLimit(0))
+			if err != nil || len(keys) != 251 {
+				t.Errorf("ListKeys() without limit = %d keys, %v", len(keys), err)
+			}
+		})
+	}
+}
+
+func TestListKeys_RequiresScanner(t *testing.T) {
+	// Embedding only the Memory interface hides MemoryStore.Scan
+	memory := struct{ Memory }{NewMemoryStore()}
+	if _, err := ListKeys(context.Background(), memory, "*"); err == nil {
+		t.Error("Expected an error for a backend without Scan")
+	}
+}
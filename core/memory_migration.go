@@ -0,0 +1,193 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultMigrationBatchSize is the number of keys MigrateMemory requests per scan
+const DefaultMigrationBatchSize = 100
+
+// MemoryScanner is implemented by Memory backends that can enumerate keys.
+// Scan follows Redis SCAN semantics: start with cursor 0 and call again with
+// the returned cursor until it is 0. count is a hint for the page size.
+// Patterns use Redis glob syntax; an empty pattern matches all keys. Keys
+// added or removed during a scan may or may not be returned.
+type MemoryScanner interface {
+	Scan(ctx context.Context, cursor uint64, pattern string, count int) (keys []string, next uint64, err error)
+}
+
+// MemoryTTLReader is implemented by Memory backends that can report how long
+// a key has left to live. ttl is 0 for keys without an expiry; exists is
+// false for missing or expired keys.
+type MemoryTTLReader interface {
+	TTL(ctx context.Context, key string) (ttl time.Duration, exists bool, err error)
+}
+
+// MigrationProgress is reported after each scanned batch
+type MigrationProgress struct {
+	Copied  int `json:"copied"`
+	Skipped int `json:"skipped"`
+	// Cursor resumes the migration after this batch (0 when finished)
+	Cursor uint64 `json:"cursor"`
+}
+
+// MigrationError reports where a migration stopped. Pass Cursor to
+// WithMigrationCursor to resume; the failed batch is copied again, which is
+// safe because Set overwrites.
+type MigrationError struct {
+	Cursor uint64
+	Key    string
+	Copied int
+	Err    error
+}
+
+func (e *MigrationError) Error() string {
+	if e.Key != "" {
+		return fmt.Sprintf("memory migration failed at key %q after %d keys (resume from cursor %d): %v", e.Key, e.Copied, e.Cursor, e.Err)
+	}
+	return fmt.Sprintf("memory migration failed after %d keys (resume from cursor %d): %v", e.Copied, e.Cursor, e.Err)
+}
+
+func (e *MigrationError) Unwrap() error {
+	return e.Err
+}
+
+// MigrationOption customizes MigrateMemory
+type MigrationOption func(*migrationOptions)
+
+type migrationOptions struct {
+	cursor    uint64
+	batchSize int
+	progress  func(MigrationProgress)
+}
+
+// WithMigrationCursor resumes a migration from the cursor in a MigrationError
+func WithMigrationCursor(cursor uint64) MigrationOption {
+	return func(o *migrationOptions) {
+		o.cursor = cursor
+	}
+}
+
+// WithMigrationBatchSize sets how many keys are requested per scan
+func WithMigrationBatchSize(size int) MigrationOption {
+	return func(o *migrationOptions) {
+		if size > 0 {
+			o.batchSize = size
+		}
+	}
+}
+
+// WithMigrationProgress calls fn after every batch
+func WithMigrationProgress(fn func(MigrationProgress)) MigrationOption {
+	return func(o *migrationOptions) {
+		o.progress = fn
+	}
+}
+
+// MigrateMemory copies the keys matching pattern from src to dst, e.g. when
+// moving from the in-memory backend to Redis or between Redis instances, or
+// to seed test fixtures. src must implement MemoryScanner. When src also
+// implements MemoryTTLReader, remaining TTLs are preserved; otherwise copied
+// keys do not expire. Keys that expire during the migration are skipped.
+//
+// Keys are copied in batches, so a migration can run while both backends are
+// in use. On failure the returned error is a *MigrationError whose Cursor
+// resumes the migration with WithMigrationCursor.
+func MigrateMemory(ctx context.Context, src, dst Memory, pattern string, opts ...MigrationOption) (int, error) {
+	scanner, ok := src.(MemoryScanner)
+	if !ok {
+		return 0, fmt.Errorf("source memory %T does not implement MemoryScanner", src)
+	}
+	ttlReader, preserveTTL := src.(MemoryTTLReader)
+
+	options := &migrationOptions{batchSize: DefaultMigrationBatchSize}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	progress := MigrationProgress{}
+	cursor := options.cursor
+	fail := func(key string, err error) (int, error) {
+		if registry := GetGlobalMetricsRegistry(); registry != nil {
+			registry.Counter("memory.migrations", "result", "failed")
+		}
+		return progress.Copied, &MigrationError{Cursor: cursor, Key: key, Copied: progress.Copied, Err: err}
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return fail("", err)
+		}
+
+		keys, next, err := scanner.Scan(ctx, cursor, pattern, options.batchSize)
+		if err != nil {
+			return fail("", err)
+		}
+
+		for _, key := range keys {
+			var ttl time.Duration
+			if preserveTTL {
+				remaining, exists, err := ttlReader.TTL(ctx, key)
+				switch {
+				case errors.Is(err, ErrExpiryNotSupported):
+					preserveTTL = false // e.g. a NamespacedMemory over InMemoryStore
+				case err != nil:
+					return fail(key, err)
+				case !exists:
+					progress.Skipped++
+					continue
+				default:
+					ttl = remaining
+					if ttl > 0 && ttl < time.Millisecond {
+						ttl = time.Millisecond // Redis expiries have millisecond resolution
+					}
+				}
+			}
+
+			value, err := src.Get(ctx, key)
+			if err != nil {
+				return fail(key, err)
+			}
+			if err := dst.Set(ctx, key, value, ttl); err != nil {
+				return fail(key, err)
+			}
+			progress.Copied++
+		}
+
+		cursor = next
+		progress.Cursor = next
+		if options.progress != nil {
+			options.progress(progress)
+		}
+		if cursor == 0 {
+			break
+		}
+	}
+
+	if registry := GetGlobalMetricsRegistry(); registry != nil {
+		registry.Counter("memory.migrations", "result", "success")
+	}
+	return progress.Copied, nil
+}
+
+// scanPage implements cursor paging for in-process stores: keys are sorted
+// and the cursor is an offset into them
+func scanPage(keys []string, cursor uint64, count int) ([]string, uint64) {
+	if count <= 0 {
+		count = DefaultMigrationBatchSize
+	}
+	if cursor >= uint64(len(keys)) {
+		return nil, 0
+	}
+	sort.Strings(keys)
+
+	end := cursor + uint64(count)
+	if end >= uint64(len(keys)) {
+		return keys[cursor:], 0
+	}
+	return keys[cursor:end], end
+}
@@ -0,0 +1,270 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NamespacedMemory isolates one agent's keys in a Memory backend shared with
+// other agents. Every key is stored as "{namespace}:{key}", and keys returned
+// by Scan have the prefix stripped, so callers never see it. Event streams
+// are namespaced the same way.
+//
+// The optional backend interfaces (MemoryScanner, MemoryTTLReader,
+// MemoryPersister, AtomicMemory, BatchMemory, StorageStatsProvider, EventLog
+// and EventConsumerGroups) are passed through. When the wrapped backend lacks
+// one, the method returns an error.
+type NamespacedMemory struct {
+	inner     Memory
+	namespace string
+}
+
+// NewNamespacedMemory wraps inner so that all keys live under namespace
+func NewNamespacedMemory(inner Memory, namespace string) *NamespacedMemory {
+	return &NamespacedMemory{inner: inner, namespace: namespace}
+}
+
+// Namespace returns the key prefix, without the trailing colon
+func (m *NamespacedMemory) Namespace() string {
+	return m.namespace
+}
+
+// Unwrap returns the wrapped backend
+func (m *NamespacedMemory) Unwrap() Memory {
+	return m.inner
+}
+
+func (m *NamespacedMemory) key(key string) string {
+	return m.namespace + ":" + key
+}
+
+func (m *NamespacedMemory) stripKey(key string) string {
+	return strings.TrimPrefix(key, m.namespace+":")
+}
+
+// pattern prefixes a glob pattern, escaping glob characters in the namespace
+func (m *NamespacedMemory) pattern(pattern string) string {
+	if pattern == "" {
+		pattern = "*"
+	}
+	var b strings.Builder
+	for _, ch := range m.namespace {
+		if strings.ContainsRune(`*?[]\`, ch) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(ch)
+	}
+	return b.String() + ":" + pattern
+}
+
+func (m *NamespacedMemory) unsupported(capability string) error {
+	return fmt.Errorf("memory %T does not implement %s", m.inner, capability)
+}
+
+func (m *NamespacedMemory) Get(ctx context.Context, key string) (string, error) {
+	return m.inner.Get(ctx, m.key(key))
+}
+
+func (m *NamespacedMemory) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
+	return m.inner.Set(ctx, m.key(key), value, ttl)
+}
+
+func (m *NamespacedMemory) Delete(ctx context.Context, key string) error {
+	return m.inner.Delete(ctx, m.key(key))
+}
+
+func (m *NamespacedMemory) Exists(ctx context.Context, key string) (bool, error) {
+	return m.inner.Exists(ctx, m.key(key))
+}
+
+// Scan lists this namespace's keys matching pattern, without the prefix
+func (m *NamespacedMemory) Scan(ctx context.Context, cursor uint64, pattern string, count int) ([]string, uint64, error) {
+	scanner, ok := m.inner.(MemoryScanner)
+	if !ok {
+		return nil, 0, m.unsupported("MemoryScanner")
+	}
+	keys, next, err := scanner.Scan(ctx, cursor, m.pattern(pattern), count)
+	for i, key := range keys {
+		keys[i] = m.stripKey(key)
+	}
+	return keys, next, err
+}
+
+// TTL reports the remaining lifetime of key
+func (m *NamespacedMemory) TTL(ctx context.Context, key string) (time.Duration, bool, error) {
+	reader, ok := m.inner.(MemoryTTLReader)
+	if !ok {
+		return 0, false, fmt.Errorf("memory %T cannot report TTLs: %w", m.inner, ErrExpiryNotSupported)
+	}
+	return reader.TTL(ctx, m.key(key))
+}
+
+// Persist removes the expiry of key
+func (m *NamespacedMemory) Persist(ctx context.Context, key string) error {
+	persister, ok := m.inner.(MemoryPersister)
+	if !ok {
+		return fmt.Errorf("memory %T cannot remove TTLs: %w", m.inner, ErrExpiryNotSupported)
+	}
+	return persister.Persist(ctx, m.key(key))
+}
+
+// Increment adds delta to the integer at key
+func (m *NamespacedMemory) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	atomic, ok := m.inner.(AtomicMemory)
+	if !ok {
+		return 0, m.unsupported("AtomicMemory")
+	}
+	return atomic.Increment(ctx, m.key(key), delta)
+}
+
+// CompareAndSwap sets key to new if it holds old
+func (m *NamespacedMemory) CompareAndSwap(ctx context.Context, key, old, new string) (bool, error) {
+	atomic, ok := m.inner.(AtomicMemory)
+	if !ok {
+		return false, m.unsupported("AtomicMemory")
+	}
+	return atomic.CompareAndSwap(ctx, m.key(key), old, new)
+}
+
+// MGet returns the values of the keys that exist, keyed without the prefix
+func (m *NamespacedMemory) MGet(ctx context.Context, keys []string) (map[string]string, error) {
+	batch, ok := m.inner.(BatchMemory)
+	if !ok {
+		return nil, m.unsupported("BatchMemory")
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = m.key(key)
+	}
+	values, err := batch.MGet(ctx, prefixed)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		result[m.stripKey(key)] = value
+	}
+	return result, nil
+}
+
+// MSet stores every item without expiry
+func (m *NamespacedMemory) MSet(ctx context.Context, items map[string]string) error {
+	return m.MSetWithTTL(ctx, items, 0)
+}
+
+// MSetWithTTL stores every item with the same TTL
+func (m *NamespacedMemory) MSetWithTTL(ctx context.Context, items map[string]string, ttl time.Duration) error {
+	batch, ok := m.inner.(BatchMemory)
+	if !ok {
+		return m.unsupported("BatchMemory")
+	}
+	prefixed := make(map[string]string, len(items))
+	for key, value := range items {
+		prefixed[m.key(key)] = value
+	}
+	if ttl > 0 {
+		return batch.MSetWithTTL(ctx, prefixed, ttl)
+	}
+	return batch.MSet(ctx, prefixed)
+}
+
+// StorageStats reports storage used by this namespace's keys
+func (m *NamespacedMemory) StorageStats(ctx context.Context, pattern string) (StorageStats, error) {
+	provider, ok := m.inner.(StorageStatsProvider)
+	if !ok {
+		return StorageStats{}, m.unsupported("StorageStatsProvider")
+	}
+	stats, err := provider.StorageStats(ctx, m.pattern(pattern))
+	if err != nil {
+		return stats, err
+	}
+	stats.Pattern = pattern
+	for i := range stats.LargestKeys {
+		stats.LargestKeys[i].Key = m.stripKey(stats.LargestKeys[i].Key)
+	}
+	return stats, nil
+}
+
+// AppendEvent adds an event to this namespace's stream
+func (m *NamespacedMemory) AppendEvent(ctx context.Context, stream string, event []byte) (int64, error) {
+	log, ok := m.inner.(EventLog)
+	if !ok {
+		return 0, m.unsupported("EventLog")
+	}
+	if err := validateEventStream(stream); err != nil {
+		return 0, err
+	}
+	return log.AppendEvent(ctx, m.key(stream), event)
+}
+
+// ReadEvents returns events with Seq >= fromSeq
+func (m *NamespacedMemory) ReadEvents(ctx context.Context, stream string, fromSeq int64) ([]Event, error) {
+	log, ok := m.inner.(EventLog)
+	if !ok {
+		return nil, m.unsupported("EventLog")
+	}
+	if err := validateEventStream(stream); err != nil {
+		return nil, err
+	}
+	events, err := log.ReadEvents(ctx, m.key(stream), fromSeq)
+	return m.stripEvents(events), err
+}
+
+// ReadGroupEvents delivers events not yet received by any consumer of group
+func (m *NamespacedMemory) ReadGroupEvents(ctx context.Context, stream, group, consumer string, count int) ([]Event, error) {
+	groups, ok := m.inner.(EventConsumerGroups)
+	if !ok {
+		return nil, m.unsupported("EventConsumerGroups")
+	}
+	if err := validateEventGroup(stream, group, consumer); err != nil {
+		return nil, err
+	}
+	events, err := groups.ReadGroupEvents(ctx, m.key(stream), group, consumer, count)
+	return m.stripEvents(events), err
+}
+
+// AckEvents removes events from the group's pending list
+func (m *NamespacedMemory) AckEvents(ctx context.Context, stream, group string, seqs ...int64) error {
+	groups, ok := m.inner.(EventConsumerGroups)
+	if !ok {
+		return m.unsupported("EventConsumerGroups")
+	}
+	if err := validateEventStream(stream); err != nil {
+		return err
+	}
+	return groups.AckEvents(ctx, m.key(stream), group, seqs...)
+}
+
+// ClaimStaleEvents reassigns events pending longer than minIdle to consumer
+func (m *NamespacedMemory) ClaimStaleEvents(ctx context.Context, stream, group, consumer string, minIdle time.Duration, count int) ([]Event, error) {
+	groups, ok := m.inner.(EventConsumerGroups)
+	if !ok {
+		return nil, m.unsupported("EventConsumerGroups")
+	}
+	if err := validateEventGroup(stream, group, consumer); err != nil {
+		return nil, err
+	}
+	events, err := groups.ClaimStaleEvents(ctx, m.key(stream), group, consumer, minIdle, count)
+	return m.stripEvents(events), err
+}
+
+func (m *NamespacedMemory) stripEvents(events []Event) []Event {
+	for i := range events {
+		events[i].Stream = m.stripKey(events[i].Stream)
+	}
+	return events
+}
+
+// Compile-time interface compliance checks
+var (
+	_ AtomicMemory         = (*NamespacedMemory)(nil)
+	_ BatchMemory          = (*NamespacedMemory)(nil)
+	_ MemoryScanner        = (*NamespacedMemory)(nil)
+	_ MemoryTTLReader      = (*NamespacedMemory)(nil)
+	_ MemoryPersister      = (*NamespacedMemory)(nil)
+	_ StorageStatsProvider = (*NamespacedMemory)(nil)
+	_ EventLog             = (*NamespacedMemory)(nil)
+	_ EventConsumerGroups  = (*NamespacedMemory)(nil)
+)
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNamespacedMemory_Isolation(t *testing.T) {
+	ctx := context.Background()
+	shared := NewMemoryStore()
+	billing := NewNamespacedMemory(shared, "billing")
+	support := NewNamespacedMemory(shared, "support")
+
+	_ = billing.Set(ctx, "agent:state:1", "billing-state", 0)
+	_ = support.Set(ctx, "agent:state:1", "support-state", 0)
+
+	if value, _ := billing.Get(ctx, "agent:state:1"); value != "billing-state" {
+		t.Errorf("billing Get() = %q", value)
+	}
+	if value, _ := support.Get(ctx, "agent:state:1"); value != "support-state" {
+		t.Errorf("support Get() = %q", value)
+	}
+
+	_ = billing.Set(ctx, "agent:state:2", "only-billing", 0)
+	if exists, _ := support.Exists(ctx, "agent:state:2"); exists {
+		t.Error("support can see a billing key")
+	}
+
+	_ = support.Delete(ctx, "agent:state:1")
+	if value, _ := billing.Get(ctx, "agent:state:1"); value != "billing-state" {
+		t.Error("support deleted a billing key")
+	}
+
+	keys, _, err := billing.Scan(ctx, 0, "*", 100)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("billing Scan() = %v, want its 2 keys only", keys)
+	}
+	for _, key := range keys {
+		if key != "agent:state:1" && key != "agent:state:2" {
+			t.Errorf("Scan() returned %q; the prefix should be stripped", key)
+		}
+	}
+
+	// The backend holds the prefixed keys
+	if value, _ := shared.Get(ctx, "billing:agent:state:1"); value != "billing-state" {
+		t.Errorf("backend key billing:agent:state:1 = %q", value)
+	}
+}
+
+func TestNamespacedMemory_PassThrough(t *testing.T) {
+	ctx := context.Background()
+	shared := NewMemoryStore()
+	a := NewNamespacedMemory(shared, "a")
+	b := NewNamespacedMemory(shared, "b")
+
+	if n, _ := a.Increment(ctx, "hits", 3); n != 3 {
+		t.Errorf("Increment() = %d, want 3", n)
+	}
+	if n, _ := b.Increment(ctx, "hits", 1); n != 1 {
+		t.Errorf("Increment() in another namespace = %d, want 1", n)
+	}
+
+	_ = a.MSetWithTTL(ctx, map[string]string{"x": "1", "y": "2"}, time.Minute)
+	values, _ := a.MGet(ctx, []string{"x", "y"})
+	if len(values) != 2 || values["x"] != "1" {
+		t.Errorf("MGet() = %v", values)
+	}
+	if values, _ := b.MGet(ctx, []string{"x", "y"}); len(values) != 0 {
+		t.Errorf("MGet() in another namespace = %v", values)
+	}
+
+	if ttl, exists, err := KeyTTL(ctx, a, "x"); err != nil || !exists || ttl <= 0 {
+		t.Errorf("KeyTTL() = %v, %v, %v", ttl, exists, err)
+	}
+
+	_, _ = a.AppendEvent(ctx, "orders", []byte("a1"))
+	_, _ = b.AppendEvent(ctx, "orders", []byte("b1"))
+	events, _ := a.ReadEvents(ctx, "orders", 1)
+	if len(events) != 1 || string(events[0].Data) != "a1" || events[0].Stream != "orders" {
+		t.Errorf("ReadEvents() = %+v", events)
+	}
+}
+
+func TestNamespacedMemory_UnsupportedBackend(t *testing.T) {
+	ctx := context.Background()
+	memory := NewNamespacedMemory(NewInMemoryStore(), "agent")
+
+	if _, _, err := KeyTTL(ctx, memory, "k"); !errors.Is(err, ErrExpiryNotSupported) {
+		t.Errorf("KeyTTL() error = %v, want ErrExpiryNotSupported", err)
+	}
+	if _, err := memory.AppendEvent(ctx, "orders", []byte("x")); err == nil {
+		t.Error("Expected an error for an EventLog call on a backend without event logs")
+	}
+
+	// MigrateMemory copies without TTLs when the backend does not track them
+	_ = memory.Set(ctx, "k", "v", 0)
+	dst := NewMemoryStore()
+	if copied, err := MigrateMemory(ctx, memory, dst, "*"); err != nil || copied != 1 {
+		t.Errorf("MigrateMemory() = %d, %v", copied, err)
+	}
+}
+
+func TestBaseAgent_NamespacesMemory(t *testing.T) {
+	ctx := context.Background()
+
+	agent := NewBaseAgentWithConfig(DefaultConfig())
+	agent.Name = "weather-agent"
+	if err := agent.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	namespaced, ok := agent.Memory.(*NamespacedMemory)
+	if !ok || namespaced.Namespace() != "weather-agent" {
+		t.Fatalf("Memory = %T, want a NamespacedMemory for weather-agent", agent.Memory)
+	}
+
+	config := DefaultConfig()
+	config.Memory.Namespaced = false
+	agent = NewBaseAgentWithConfig(config)
+	if err := agent.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if _, ok := agent.Memory.(*NamespacedMemory); ok {
+		t.Error("Memory should not be namespaced when disabled")
+	}
+}
@@ -11,6 +11,7 @@ type MemoryStore struct {
 	mu     sync.RWMutex
 	store  map[string]memoryEntry
 	logger Logger
+	events memoryEventLog
 }
 
 type memoryEntry struct {
@@ -239,3 +240,112 @@ func (m *MemoryStore) Store(ctx context.Context, key string, value interface{})
 func (m *MemoryStore) Retrieve(ctx context.Context, key string) (interface{}, error) {
 	return m.Get(ctx, key)
 }
+
+// StorageStats reports key count and value sizes for keys matching pattern.
+// Expired entries that have not yet been cleaned up are excluded.
+func (m *MemoryStore) StorageStats(ctx context.Context, pattern string) (StorageStats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	match := globMatcher(pattern)
+	collector := newStorageStatsCollector(pattern, "value_length")
+	now := time.Now()
+	for key, entry := range m.store {
+		if !entry.expiresAt.IsZero() && now.After(entry.expiresAt) {
+			continue
+		}
+		if match(key) {
+			collector.add(key, int64(len(entry.value)))
+		}
+	}
+
+	stats := collector.result()
+	if m.logger != nil {
+		m.logger.DebugWithContext(ctx, "Storage stats computed", map[string]interface{}{
+			"operation":   "memory_storage_stats",
+			"pattern":     pattern,
+			"key_count":   stats.KeyCount,
+			"total_bytes": stats.TotalBytes,
+		})
+	}
+	return stats, nil
+}
+
+// Scan lists keys matching pattern. The cursor is a position in the sorted
+// key set, so keys added or removed between calls may shift pages.
+func (m *MemoryStore) Scan(ctx context.Context, cursor uint64, pattern string, count int) ([]string, uint64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	all := make([]string, 0, len(m.store))
+	for key := range m.store {
+		all = append(all, key)
+	}
+	page, next := scanPage(all, cursor, count)
+
+	match := globMatcher(pattern)
+	now := time.Now()
+	keys := make([]string, 0, len(page))
+	for _, key := range page {
+		entry := m.store[key]
+		if !entry.expiresAt.IsZero() && now.After(entry.expiresAt) {
+			continue
+		}
+		if match(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, next, nil
+}
+
+// TTL reports the remaining lifetime of key
+func (m *MemoryStore) TTL(ctx context.Context, key string) (time.Duration, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entry, exists := m.store[key]
+	if !exists {
+		return 0, false, nil
+	}
+	if entry.expiresAt.IsZero() {
+		return 0, true, nil
+	}
+	remaining := time.Until(entry.expiresAt)
+	if remaining <= 0 {
+		return 0, false, nil
+	}
+	return remaining, true, nil
+}
+
+// AppendEvent adds an event to an in-memory stream. Streams are independent of
+// keys and never expire.
+func (m *MemoryStore) AppendEvent(ctx context.Context, stream string, event []byte) (int64, error) {
+	seq, err := m.events.append(stream, event)
+	if err != nil {
+		return 0, err
+	}
+	if registry := GetGlobalMetricsRegistry(); registry != nil {
+		registry.Counter("memory.events.appended", "memory_type", "in_memory")
+	}
+	return seq, nil
+}
+
+// ReadEvents returns events with Seq >= fromSeq
+func (m *MemoryStore) ReadEvents(ctx context.Context, stream string, fromSeq int64) ([]Event, error) {
+	return m.events.read(stream, fromSeq)
+}
+
+// ReadGroupEvents delivers events not yet received by any consumer of group
+func (m *MemoryStore) ReadGroupEvents(ctx context.Context, stream, group, consumer string, count int) ([]Event, error) {
+	return m.events.readGroup(stream, group, consumer, count)
+}
+
+// AckEvents removes events from the group's pending list
+func (m *MemoryStore) AckEvents(ctx context.Context, stream, group string, seqs ...int64) error {
+	return m.events.ack(stream, group, seqs)
+}
+
+// ClaimStaleEvents reassigns events pending longer than minIdle to consumer
+func (m *MemoryStore) ClaimStaleEvents(ctx context.Context, stream, group, consumer string, minIdle time.Duration, count int) ([]Event, error) {
+	return m.events.claimStale(stream, group, consumer, minIdle, count)
+}
@@ -203,12 +203,8 @@ func TestInMemoryStore_Exists(t *testing.T) {
 	}
 }
 
-// Test concurrent operations (note: InMemoryStore is NOT thread-safe!)
+// Test concurrent operations
 func TestInMemoryStore_RaceCondition(t *testing.T) {
-	// This test demonstrates that InMemoryStore is NOT thread-safe
-	// In production, a mutex should be added
-	t.Skip("InMemoryStore is not thread-safe - skipping race condition test")
-
 	store := NewInMemoryStore()
 	ctx := context.Background()
 
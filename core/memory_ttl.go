@@ -0,0 +1,57 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// MemoryPersister is implemented by Memory backends that can remove a key's
+// expiry, pinning a hot entry until it is deleted or Set again with a TTL.
+// Persisting a missing key or a key without expiry does nothing.
+type MemoryPersister interface {
+	Persist(ctx context.Context, key string) error
+}
+
+// KeyTTL reports the remaining lifetime of key, so callers can refresh an
+// entry before it expires. ttl is 0 for keys without an expiry and exists is
+// false for missing keys. It returns ErrExpiryNotSupported if memory does
+// not implement MemoryTTLReader.
+func KeyTTL(ctx context.Context, memory Memory, key string) (ttl time.Duration, exists bool, err error) {
+	reader, ok := memory.(MemoryTTLReader)
+	if !ok {
+		return 0, false, fmt.Errorf("memory %T cannot report TTLs: %w", memory, ErrExpiryNotSupported)
+	}
+	return reader.TTL(ctx, key)
+}
+
+// PersistKey removes the expiry of key. It returns ErrExpiryNotSupported if
+// memory does not implement MemoryPersister.
+func PersistKey(ctx context.Context, memory Memory, key string) error {
+	persister, ok := memory.(MemoryPersister)
+	if !ok {
+		return fmt.Errorf("memory %T cannot remove TTLs: %w", memory, ErrExpiryNotSupported)
+	}
+	return persister.Persist(ctx, key)
+}
+
+// Persist removes the expiry of key
+func (m *MemoryStore) Persist(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, exists := m.store[key]
+	if !exists || entry.expiresAt.IsZero() || time.Now().After(entry.expiresAt) {
+		return nil
+	}
+	entry.expiresAt = time.Time{}
+	m.store[key] = entry
+
+	if m.logger != nil {
+		m.logger.DebugWithContext(ctx, "Cache entry persisted", map[string]interface{}{
+			"operation": "cache_persist",
+			"key":       key,
+		})
+	}
+	return nil
+}
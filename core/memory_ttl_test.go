@@ -0,0 +1,69 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestKeyTTLAndPersistKey(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	redisMemory, err := NewRedisMemory("redis://" + mr.Addr())
+	if err != nil {
+		t.Fatalf("NewRedisMemory() error = %v", err)
+	}
+	defer func() { _ = redisMemory.Close() }()
+
+	backends := map[string]Memory{
+		"memory_store": NewMemoryStore(),
+		"redis":        redisMemory,
+	}
+
+	for name, memory := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			_ = memory.Set(ctx, "snapshot", "v1", time.Minute)
+
+			ttl, exists, err := KeyTTL(ctx, memory, "snapshot")
+			if err != nil || !exists || ttl <= 0 || ttl > time.Minute {
+				t.Fatalf("KeyTTL() = %v, %v, %v; want up to 1m", ttl, exists, err)
+			}
+
+			if err := PersistKey(ctx, memory, "snapshot"); err != nil {
+				t.Fatalf("PersistKey() error = %v", err)
+			}
+			ttl, exists, err = KeyTTL(ctx, memory, "snapshot")
+			if err != nil || !exists || ttl != 0 {
+				t.Errorf("KeyTTL() after PersistKey = %v, %v, %v; want no expiry", ttl, exists, err)
+			}
+
+			if err := PersistKey(ctx, memory, "missing"); err != nil {
+				t.Errorf("PersistKey() on missing key error = %v", err)
+			}
+			if _, exists, _ := KeyTTL(ctx, memory, "missing"); exists {
+				t.Error("KeyTTL() reported a missing key as existing")
+			}
+		})
+	}
+}
+
+func TestKeyTTL_UnsupportedBackend(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryStore()
+	_ = store.Set(ctx, "k", "v", time.Minute)
+
+	if _, _, err := KeyTTL(ctx, store, "k"); !errors.Is(err, ErrExpiryNotSupported) {
+		t.Errorf("KeyTTL() error = %v, want ErrExpiryNotSupported", err)
+	}
+	if err := PersistKey(ctx, store, "k"); !errors.Is(err, ErrExpiryNotSupported) {
+		t.Errorf("PersistKey() error = %v, want ErrExpiryNotSupported", err)
+	}
+}
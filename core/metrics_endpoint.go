@@ -0,0 +1,26 @@
+package core
+
+import (
+	"net/http"
+)
+
+// DefaultPrometheusPath is where WithPrometheusEndpoint serves metrics when
+// no path is given
+const DefaultPrometheusPath = "/metrics"
+
+// metricsHandler serves the metrics of the global metrics registry when it
+// can expose them over HTTP, as the telemetry module's registry does when
+// its Prometheus exporter is active. The registry is looked up on every
+// request, so telemetry may be initialized after the server starts;
+// without one the endpoint responds 503 with a message saying why.
+func metricsHandler(w http.ResponseWriter, r *http.Request) {
+	registry, ok := GetGlobalMetricsRegistry().(interface {
+		MetricsHandler() http.Handler
+	})
+	if !ok {
+		http.Error(w, "Metrics are not available: initialize the telemetry module with the \"prometheus\" provider to expose them here",
+			http.StatusServiceUnavailable)
+		return
+	}
+	registry.MetricsHandler().ServeHTTP(w, r)
+}
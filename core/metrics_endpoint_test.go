@@ -0,0 +1,68 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scrapeMetricsRegistry serves a fixed scrape body, as the telemetry
+// module's registry does with its Prometheus exporter active
+type scrapeMetricsRegistry struct {
+	mockMetricsRegistry
+}
+
+func (r *scrapeMetricsRegistry) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("gomind_requests_total 3\n"))
+	})
+}
+
+func TestWithPrometheusEndpoint(t *testing.T) {
+	config := DefaultConfig()
+	assert.Empty(t, config.Telemetry.PrometheusPath, "no metrics endpoint unless asked for")
+
+	require.NoError(t, WithPrometheusEndpoint("")(config))
+	assert.Equal(t, DefaultPrometheusPath, config.Telemetry.PrometheusPath)
+
+	require.NoError(t, WithPrometheusEndpoint("/internal/metrics")(config))
+	assert.Equal(t, "/internal/metrics", config.Telemetry.PrometheusPath)
+
+	assert.Error(t, WithPrometheusEndpoint("metrics")(config))
+}
+
+func TestPrometheusEndpoint(t *testing.T) {
+	originalRegistry := globalMetricsRegistry
+	defer func() { globalMetricsRegistry = originalRegistry }()
+
+	config := DefaultConfig()
+	require.NoError(t, WithPrometheusEndpoint("")(config))
+	tool := NewToolWithConfig(config)
+	agent := NewBaseAgentWithConfig(config)
+
+	for name, handler := range map[string]http.Handler{"tool": tool.Handler(), "agent": agent.Handler()} {
+		t.Run(name, func(t *testing.T) {
+			// Without a registry that can serve metrics there is nothing to scrape
+			globalMetricsRegistry = &mockMetricsRegistry{}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DefaultPrometheusPath, nil))
+			assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+			assert.Contains(t, rec.Body.String(), "prometheus")
+
+			globalMetricsRegistry = &scrapeMetricsRegistry{}
+			rec = httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DefaultPrometheusPath, nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "gomind_requests_total 3\n", rec.Body.String())
+		})
+	}
+}
+
+func TestPrometheusEndpoint_NotConfigured(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewTool("plain-tool").Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DefaultPrometheusPath, nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -1,10 +1,52 @@
 package core
 
 import (
+	"context"
 	"net/http"
 	"time"
+
+	"github.com/google/uuid"
 )
 
+// maxRequestIDLength bounds client-supplied request IDs so they can't bloat
+// logs and headers
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns ctx carrying the request ID
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID set by RequestIDMiddleware,
+// or "" when there is none
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestIDMiddleware gives every request an ID: the incoming X-Request-ID
+// header when present, otherwise a new UUID. The ID is echoed in the
+// X-Request-ID response header and stored in the request context, where
+// RequestIDFromContext, error responses and the request log pick it up.
+// Agents and tools include it in their standard middleware stack.
+func RequestIDMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(RequestIDHeader)
+			if requestID == "" || len(requestID) > maxRequestIDLength {
+				requestID = uuid.New().String()
+			}
+			w.Header().Set(RequestIDHeader, requestID)
+			next.ServeHTTP(w, r.WithContext(ContextWithRequestID(r.Context(), requestID)))
+		})
+	}
+}
+
 // responseWriter wraps http.ResponseWriter to capture status code
 type responseWriter struct {
 	http.ResponseWriter
@@ -71,6 +113,10 @@ func LoggingMiddleware(logger Logger, devMode bool) func(http.Handler) http.Hand
 					"user_agent":  r.UserAgent(),
 				}
 
+				if requestID := RequestIDFromContext(r.Context()); requestID != "" {
+					logData["request_id"] = requestID
+				}
+
 				// Add query params if present
 				if r.URL.RawQuery != "" {
 					logData["query"] = r.URL.RawQuery
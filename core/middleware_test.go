@@ -0,0 +1,79 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var seen string
+	handler := RequestIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(RequestIDHeader, "client-7")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if seen != "client-7" || rec.Header().Get(RequestIDHeader) != "client-7" {
+		t.Errorf("Expected the client's request ID to be kept, got context=%q header=%q", seen, rec.Header().Get(RequestIDHeader))
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if seen == "" || rec.Header().Get(RequestIDHeader) != seen {
+		t.Errorf("Expected a generated request ID, got context=%q header=%q", seen, rec.Header().Get(RequestIDHeader))
+	}
+
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(RequestIDHeader, strings.Repeat("x", maxRequestIDLength+1))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if len(seen) > maxRequestIDLength {
+		t.Error("Expected an oversized request ID to be replaced")
+	}
+}
+
+func TestWithMiddleware_WrapsCapabilitiesInOrder(t *testing.T) {
+	var order []string
+	trace := func(name string) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if RequestIDFromContext(r.Context()) == "" {
+					t.Errorf("%s ran without a request ID", name)
+				}
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	config, err := NewConfig(WithName("weather"), WithMiddleware(trace("auth"), trace("rate-limit")))
+	if err != nil {
+		t.Fatalf("NewConfig failed: %v", err)
+	}
+	tool := NewTool("weather")
+	tool.Config = config
+	tool.RegisterCapability(Capability{
+		Name: "forecast",
+		Execute: func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			order = append(order, "handler")
+			return "sunny", nil
+		},
+	})
+
+	rec := httptest.NewRecorder()
+	tool.Handler().ServeHTTP(rec, httptest.NewRequest("POST", "/api/capabilities/forecast", strings.NewReader(`{}`)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if strings.Join(order, ",") != "auth,rate-limit,handler" {
+		t.Errorf("Expected middleware in the given order, got %v", order)
+	}
+	if rec.Header().Get(RequestIDHeader) == "" {
+		t.Error("Expected the response to carry a request ID")
+	}
+}
@@ -11,6 +11,9 @@ type MockDiscovery struct {
 	mu           sync.RWMutex
 	services     map[string]*ServiceInfo
 	capabilities map[string][]string // capability -> service IDs
+
+	// Callbacks run after Register or Unregister, see onRegistryChange
+	changeListeners changeListeners
 }
 
 // NewMockDiscovery creates a new mock discovery instance
@@ -25,6 +28,7 @@ func NewMockDiscovery() *MockDiscovery {
 func (m *MockDiscovery) Register(ctx context.Context, info *ServiceInfo) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	defer m.notifyRegistryChange()
 
 	m.services[info.ID] = info
 
@@ -51,7 +55,7 @@ func (m *MockDiscovery) UpdateHealth(ctx context.Context, id string, status Heal
 		return nil
 	}
 
-	return fmt.Errorf("service %s not found", id)
+	return fmt.Errorf("service %s: %w", id, ErrServiceNotFound)
 }
 
 // Unregister removes a service (implements Registry interface)
@@ -61,7 +65,7 @@ func (m *MockDiscovery) Unregister(ctx context.Context, id string) error {
 
 	service, exists := m.services[id]
 	if !exists {
-		return fmt.Errorf("service %s not found", id)
+		return fmt.Errorf("service %s: %w", id, ErrServiceNotFound)
 	}
 
 	// Remove from capability index
@@ -73,9 +77,21 @@ func (m *MockDiscovery) Unregister(ctx context.Context, id string) error {
 	}
 
 	delete(m.services, id)
+	m.notifyRegistryChange()
 	return nil
 }
 
+// onRegistryChange adds a callback that runs after Register or Unregister
+// (implements registryChangeNotifier)
+func (m *MockDiscovery) onRegistryChange(fn func()) func() {
+	return m.changeListeners.add(fn)
+}
+
+// notifyRegistryChange runs the change callbacks
+func (m *MockDiscovery) notifyRegistryChange() {
+	m.changeListeners.notify()
+}
+
 // Discover finds services based on filter (implements Discovery interface)
 func (m *MockDiscovery) Discover(ctx context.Context, filter DiscoveryFilter) ([]*ServiceInfo, error) {
 	m.mu.RLock()
@@ -0,0 +1,203 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OpenAPIPath is where components serve their OpenAPI capability catalog
+const OpenAPIPath = "/openapi.json"
+
+// genericSchemaNote is appended to descriptions of capabilities that
+// declare no field hints, so clients know the schema is a placeholder
+const genericSchemaNote = "No schema declared; accepts and returns any JSON object."
+
+// ExportOpenAPI produces an OpenAPI 3.1 document describing caps. Each
+// capability becomes a POST operation at its endpoint (by default
+// /api/capabilities/{name}). Request and response schemas come from
+// InputSummary and OutputSummary; capabilities without them fall back to a
+// generic object schema and say so in their description. Content types are
+// derived from InputTypes and OutputTypes. Internal capabilities are left
+// out, and when several capabilities share an endpoint only the first is
+// described.
+func ExportOpenAPI(caps []Capability) ([]byte, error) {
+	doc, _, err := exportOpenAPI("GoMind capabilities", caps)
+	return doc, err
+}
+
+// exportOpenAPI builds the document and also returns the names of the
+// capabilities skipped because an earlier one has the same endpoint
+func exportOpenAPI(title string, caps []Capability) ([]byte, []string, error) {
+	paths := make(map[string]interface{}, len(caps))
+	var duplicates []string
+	for _, cap := range caps {
+		if cap.Internal {
+			continue
+		}
+		endpoint := cap.Endpoint
+		if endpoint == "" {
+			endpoint = fmt.Sprintf("/api/capabilities/%s", cap.Name)
+		}
+		if _, exists := paths[endpoint]; exists {
+			duplicates = append(duplicates, cap.Name)
+			continue
+		}
+		paths[endpoint] = map[string]interface{}{
+			"post": openAPIOperation(cap),
+		}
+	}
+
+	doc := map[string]interface{}{
+		"openapi": "3.1.0",
+		"info": map[string]interface{}{
+			"title":   title,
+			"version": "1.0.0",
+		},
+		"paths": paths,
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	return data, duplicates, err
+}
+
+// openAPIOperation describes one capability as an OpenAPI operation
+func openAPIOperation(cap Capability) map[string]interface{} {
+	description := cap.Description
+	if cap.InputSummary == nil || cap.OutputSummary == nil {
+		description = strings.TrimSpace(description + " " + genericSchemaNote)
+	}
+
+	responseSchema := summaryToJSONSchema(cap.OutputSummary)
+	if cap.ResultEnvelope {
+		responseSchema = map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"success":     map[string]interface{}{"type": "boolean"},
+				"data":        responseSchema,
+				"error":       map[string]interface{}{"type": "object"},
+				"metadata":    map[string]interface{}{"type": "object"},
+				"duration_ms": map[string]interface{}{"type": "integer"},
+			},
+			"required": []string{"success"},
+		}
+	}
+
+	op := map[string]interface{}{
+		"operationId": cap.Name,
+		"summary":     cap.Name,
+		"description": description,
+		"requestBody": map[string]interface{}{
+			"required": true,
+			"content":  openAPIContent(cap.InputTypes, summaryToJSONSchema(cap.InputSummary)),
+		},
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "Capability result",
+				"content":     openAPIContent(cap.OutputTypes, responseSchema),
+			},
+		},
+	}
+	return op
+}
+
+// openAPIContent maps capability input/output types ("json", "text" or a
+// MIME type) to an OpenAPI content object. JSON is assumed when none are set.
+func openAPIContent(types []string, schema map[string]interface{}) map[string]interface{} {
+	content := make(map[string]interface{})
+	for _, t := range types {
+		mediaType := strings.ToLower(strings.TrimSpace(t))
+		switch mediaType {
+		case "", "json":
+			mediaType = "application/json"
+		case "text":
+			mediaType = "text/plain"
+		}
+		if !strings.Contains(mediaType, "/") {
+			continue
+		}
+		if mediaType == "application/json" {
+			content[mediaType] = map[string]interface{}{"schema": schema}
+		} else {
+			content[mediaType] = map[string]interface{}{"schema": map[string]interface{}{"type": "string"}}
+		}
+	}
+	if len(content) == 0 {
+		content["application/json"] = map[string]interface{}{"schema": schema}
+	}
+	return content
+}
+
+// summaryToJSONSchema converts field hints to a JSON Schema object, or a
+// generic object schema when summary is nil
+func summaryToJSONSchema(summary *SchemaSummary) map[string]interface{} {
+	if summary == nil {
+		return map[string]interface{}{"type": "object"}
+	}
+
+	properties := make(map[string]interface{})
+	required := []string{}
+	for _, field := range summary.RequiredFields {
+		properties[field.Name] = fieldHintSchema(field)
+		required = append(required, field.Name)
+	}
+	for _, field := range summary.OptionalFields {
+		properties[field.Name] = fieldHintSchema(field)
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func fieldHintSchema(field FieldHint) map[string]interface{} {
+	prop := map[string]interface{}{}
+	if field.Type != "" {
+		prop["type"] = field.Type
+	}
+	if field.Description != "" {
+		prop["description"] = field.Description
+	}
+	if field.Example != "" {
+		prop["examples"] = []string{field.Example}
+	}
+	return prop
+}
+
+// openAPIHandler serves the catalog for the capabilities returned by caps,
+// which is called per request so late registrations are included
+func openAPIHandler(title string, caps func() []Capability, logger Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		doc, duplicates, err := exportOpenAPI(title, caps())
+		if len(duplicates) > 0 && logger != nil {
+			logger.Warn("Capabilities left out of the OpenAPI document, their endpoint is already described", map[string]interface{}{
+				"capabilities": duplicates,
+				"component":    title,
+			})
+		}
+		if err != nil {
+			if logger != nil {
+				logger.Error("Failed to generate OpenAPI document", map[string]interface{}{
+					"error":      err,
+					"error_type": fmt.Sprintf("%T", err),
+					"component":  title,
+				})
+			}
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(doc)
+	}
+}
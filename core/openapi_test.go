@@ -0,0 +1,102 @@
+package core
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportOpenAPI(t *testing.T) {
+	caps := []Capability{
+		{
+			Name:        "current_weather",
+			Description: "Current weather for a city",
+			InputTypes:  []string{"json"},
+			InputSummary: &SchemaSummary{
+				RequiredFields: []FieldHint{{Name: "city", Type: "string", Example: "London"}},
+				OptionalFields: []FieldHint{{Name: "units", Type: "string"}},
+			},
+			OutputSummary: &SchemaSummary{
+				RequiredFields: []FieldHint{{Name: "temperature", Type: "number"}},
+			},
+			ResultEnvelope: true,
+		},
+		{
+			Name:        "echo",
+			Description: "Echoes the request",
+			Endpoint:    "/echo",
+			OutputTypes: []string{"text"},
+		},
+	}
+
+	raw, err := ExportOpenAPI(caps)
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &doc))
+	assert.Equal(t, "3.1.0", doc["openapi"])
+	paths := doc["paths"].(map[string]interface{})
+	require.Len(t, paths, 2)
+
+	weather := paths["/api/capabilities/current_weather"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, "Current weather for a city", weather["description"])
+	reqSchema := weather["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"city"}, reqSchema["required"])
+	assert.Contains(t, reqSchema["properties"], "units")
+	respSchema := weather["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	data := respSchema["properties"].(map[string]interface{})["data"].(map[string]interface{})
+	assert.Contains(t, data["properties"], "temperature", "envelope wraps the output schema")
+
+	echo := paths["/echo"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Contains(t, echo["description"], genericSchemaNote)
+	assert.Contains(t, echo["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"], "text/plain")
+}
+
+func TestExportOpenAPI_DuplicateEndpoint(t *testing.T) {
+	raw, err := ExportOpenAPI([]Capability{{Name: "a", Endpoint: "/x"}, {Name: "b", Endpoint: "/x"}, {Name: "c"}})
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &doc))
+	paths := doc["paths"].(map[string]interface{})
+	require.Len(t, paths, 2)
+	assert.Equal(t, "a", paths["/x"].(map[string]interface{})["post"].(map[string]interface{})["operationId"], "first capability wins")
+}
+
+func TestExportOpenAPI_SkipsInternalAndUntypedFields(t *testing.T) {
+	raw, err := ExportOpenAPI([]Capability{
+		{Name: "orchestrate", Internal: true},
+		{Name: "lookup", InputSummary: &SchemaSummary{RequiredFields: []FieldHint{{Name: "id"}}}},
+	})
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &doc))
+	paths := doc["paths"].(map[string]interface{})
+	assert.NotContains(t, paths, "/api/capabilities/orchestrate")
+	lookup := paths["/api/capabilities/lookup"].(map[string]interface{})["post"].(map[string]interface{})
+	reqSchema := lookup["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	assert.NotContains(t, reqSchema["properties"].(map[string]interface{})["id"], "type", "no empty type for untyped fields")
+}
+
+func TestOpenAPIEndpoint(t *testing.T) {
+	tool := NewTool("weather-tool")
+	tool.RegisterCapability(Capability{Name: "forecast", Description: "Weather forecast"})
+
+	rec := httptest.NewRecorder()
+	tool.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, OpenAPIPath, nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, "weather-tool", doc["info"].(map[string]interface{})["title"])
+	assert.Contains(t, doc["paths"], "/api/capabilities/forecast")
+
+	rec = httptest.NewRecorder()
+	tool.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, OpenAPIPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,179 @@
+package core
+
+import (
+	"container/list"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimitMaxKeys bounds how many callers a component tracks per
+// capability set; the least recently seen are forgotten first
+const DefaultRateLimitMaxKeys = 10000
+
+// RateLimit is a token-bucket limit: RPS requests per second on average,
+// with bursts of up to Burst requests. An RPS of 0 means unlimited.
+type RateLimit struct {
+	RPS   float64 `json:"rps"`
+	Burst int     `json:"burst"`
+}
+
+// enabled reports whether the limit throttles anything
+func (l RateLimit) enabled() bool {
+	return l.RPS > 0
+}
+
+// burst returns the bucket size, at least one request
+func (l RateLimit) burst() float64 {
+	if l.Burst < 1 {
+		return 1
+	}
+	return float64(l.Burst)
+}
+
+// tokenBucket is the limiter state for one capability and caller
+type tokenBucket struct {
+	key    string
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter holds token buckets keyed by capability and caller. Memory is
+// bounded by maxKeys: when full, the least recently used bucket is dropped,
+// which only forgets a caller that has been idle the longest. The zero
+// value is ready to use.
+type rateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*list.Element
+	lru     *list.List // Front is most recently used
+	maxKeys int
+	now     func() time.Time
+}
+
+// allow takes a token from key's bucket. When none is left it returns false
+// and how long until the next token.
+func (l *rateLimiter) allow(key string, limit RateLimit) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.buckets == nil {
+		l.buckets = make(map[string]*list.Element)
+		l.lru = list.New()
+	}
+	now := time.Now()
+	if l.now != nil {
+		now = l.now()
+	}
+
+	var bucket *tokenBucket
+	if element, ok := l.buckets[key]; ok {
+		l.lru.MoveToFront(element)
+		bucket = element.Value.(*tokenBucket)
+		elapsed := now.Sub(bucket.last).Seconds()
+		bucket.tokens = math.Min(limit.burst(), bucket.tokens+elapsed*limit.RPS)
+		bucket.last = now
+	} else {
+		bucket = &tokenBucket{key: key, tokens: limit.burst(), last: now}
+		l.buckets[key] = l.lru.PushFront(bucket)
+		l.evict()
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - bucket.tokens) / limit.RPS * float64(time.Second))
+	return false, wait
+}
+
+// evict drops the least recently used buckets beyond maxKeys. Callers must
+// hold l.mu.
+func (l *rateLimiter) evict() {
+	maxKeys := l.maxKeys
+	if maxKeys <= 0 {
+		maxKeys = DefaultRateLimitMaxKeys
+	}
+	for l.lru.Len() > maxKeys {
+		oldest := l.lru.Back()
+		l.lru.Remove(oldest)
+		delete(l.buckets, oldest.Value.(*tokenBucket).key)
+	}
+}
+
+// size returns the number of tracked buckets
+func (l *rateLimiter) size() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+// requestCaller identifies who made r for rate limiting: the remote IP.
+// Client-supplied headers such as X-From-Agent are not trusted, since a
+// caller could send a new value with each request to get a fresh bucket.
+// Behind a proxy, set the remote address from a trusted forwarding header
+// in HTTPConfig.Middleware.
+func requestCaller(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// rateLimitHandler applies the capability's rate limit, or the component's
+// HTTP.RateLimit, before next. The limit is read on every request so it
+// follows the configuration the framework applies after registration.
+// Throttled callers get a 429 RATE_LIMITED envelope with Retry-After.
+func rateLimitHandler(limiter *rateLimiter, cap Capability, config func() *Config, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := RateLimit{}
+		if cfg := config(); cfg != nil {
+			limit = cfg.HTTP.RateLimit
+		}
+		if cap.RateLimit != nil {
+			limit = *cap.RateLimit
+		}
+		if !limit.enabled() {
+			next(w, r)
+			return
+		}
+
+		caller := requestCaller(r)
+		allowed, retryAfter := limiter.allow(cap.Name+"|"+caller, limit)
+		if allowed {
+			next(w, r)
+			return
+		}
+
+		// caller is the remote IP, so its cardinality is bounded like the
+		// limiter's buckets rather than by what clients choose to send
+		if registry := GetGlobalMetricsRegistry(); registry != nil {
+			registry.Counter("http.rate_limited",
+				"capability", cap.Name,
+				"caller", caller,
+			)
+		}
+
+		seconds := int(math.Ceil(retryAfter.Seconds()))
+		if seconds < 1 {
+			seconds = 1
+		}
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		response := &ToolResponse{
+			Success: false,
+			Error: &ToolError{
+				Code:      "RATE_LIMITED",
+				Message:   fmt.Sprintf("rate limit of %g requests/s exceeded for %s", limit.RPS, cap.Name),
+				Category:  CategoryRateLimit,
+				Retryable: true,
+				Details:   map[string]string{"retry_after": strconv.Itoa(seconds)},
+			},
+		}
+		withCorrelation(w, r, response)
+		writeToolResponse(w, http.StatusTooManyRequests, response)
+	}
+}
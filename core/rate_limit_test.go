@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_TokenBucket(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := &rateLimiter{now: func() time.Time { return now }}
+	limit := RateLimit{RPS: 2, Burst: 3}
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := limiter.allow("k", limit); !ok {
+			t.Fatalf("Request %d within the burst was throttled", i+1)
+		}
+	}
+	ok, wait := limiter.allow("k", limit)
+	if ok {
+		t.Fatal("Expected the request after the burst to be throttled")
+	}
+	if wait != 500*time.Millisecond {
+		t.Errorf("Expected a 500ms wait at 2 rps, got %v", wait)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if ok, _ := limiter.allow("k", limit); !ok {
+		t.Error("Expected a token to refill after 500ms")
+	}
+	if ok, _ := limiter.allow("other", limit); !ok {
+		t.Error("Expected callers to have separate buckets")
+	}
+}
+
+func TestRateLimiter_EvictsLeastRecentlyUsed(t *testing.T) {
+	limiter := &rateLimiter{maxKeys: 2}
+	limit := RateLimit{RPS: 1, Burst: 1}
+
+	limiter.allow("a", limit)
+	limiter.allow("b", limit)
+	limiter.allow("a", limit) // a is now more recent than b
+	limiter.allow("c", limit)
+
+	if got := limiter.size(); got != 2 {
+		t.Fatalf("Expected 2 tracked callers, got %d", got)
+	}
+	if _, ok := limiter.buckets["b"]; ok {
+		t.Error("Expected the least recently used caller to be evicted")
+	}
+	if _, ok := limiter.buckets["a"]; !ok {
+		t.Error("Expected the recently used caller to be kept")
+	}
+}
+
+func TestWithRateLimit_ThrottlesCapabilities(t *testing.T) {
+	originalRegistry := globalMetricsRegistry
+	defer func() { globalMetricsRegistry = originalRegistry }()
+	recorder := &invocationRecorder{}
+	globalMetricsRegistry = recorder
+
+	config, err := NewConfig(WithName("quotes"), WithRateLimit(1, 2))
+	if err != nil {
+		t.Fatalf("NewConfig failed: %v", err)
+	}
+	tool := NewTool("quotes")
+	tool.Config = config
+	echo := func(ctx context.Context, input map[string]interface{}) (interface{}, error) { return "ok", nil }
+	tool.RegisterCapability(Capability{Name: "quote", Execute: echo})
+	tool.RegisterCapability(Capability{Name: "bulk", Execute: echo, RateLimit: &RateLimit{RPS: 1, Burst: 1}})
+	tool.RegisterCapability(Capability{Name: "status", Execute: echo, RateLimit: &RateLimit{}})
+	handler := tool.Handler()
+
+	calls := 0
+	call := func(capability, remoteIP string) *httptest.ResponseRecorder {
+		calls++
+		req := httptest.NewRequest("POST", "/api/capabilities/"+capability, strings.NewReader(`{}`))
+		req.RemoteAddr = "10.0.0.1:5000"
+		if remoteIP != "" {
+			req.RemoteAddr = remoteIP + ":5000"
+		}
+		// Client-supplied identity must not buy a fresh bucket
+		req.Header.Set("X-From-Agent", fmt.Sprintf("agent-%d", calls))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := 0; i < 2; i++ {
+		if rec := call("quote", ""); rec.Code != http.StatusOK {
+			t.Fatalf("Request %d within the burst got %d", i+1, rec.Code)
+		}
+	}
+	rec := call("quote", "")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "1" {
+		t.Fatalf("Expected 429 with Retry-After, got %d %v", rec.Code, rec.Header())
+	}
+	if response := decodeToolResponse(t, rec); response.Error == nil || response.Error.Code != "RATE_LIMITED" || !response.Error.Retryable {
+		t.Errorf("Expected a retryable RATE_LIMITED envelope, got %+v", response.Error)
+	}
+	throttled := "http.rate_limited{capability,quote,caller,10.0.0.1}"
+	if counters := strings.Join(recorder.counters, " "); !strings.Contains(counters, throttled) {
+		t.Errorf("Expected %s to be counted, got %s", throttled, counters)
+	}
+
+	if rec := call("quote", "10.0.0.2"); rec.Code != http.StatusOK {
+		t.Errorf("Expected another remote address to have its own bucket, got %d", rec.Code)
+	}
+
+	call("bulk", "")
+	if rec := call("bulk", ""); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected the per-capability limit to apply, got %d", rec.Code)
+	}
+	for i := 0; i < 5; i++ {
+		if rec := call("status", ""); rec.Code != http.StatusOK {
+			t.Fatalf("Expected a zero per-capability limit to exempt the capability, got %d", rec.Code)
+		}
+	}
+
+	if caps := tool.GetCapabilities(); caps[1].RateLimit == nil {
+		t.Error("Expected the per-capability limit to be advertised")
+	}
+	if _, err := NewConfig(WithRateLimit(-1, 0)); err == nil {
+		t.Error("Expected an error for a negative rate")
+	}
+}
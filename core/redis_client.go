@@ -69,6 +69,72 @@ type RedisClientOptions struct {
 	DB        int    // Redis DB number for isolation (0-15)
 	Namespace string // Key namespace for organization
 	Logger    Logger // Optional logger
+	Pool      RedisPoolOptions
+}
+
+// RedisPoolOptions tunes the connection pool. Zero fields keep the go-redis
+// defaults (10 connections per CPU, 5s dial timeout, 3s read timeout).
+type RedisPoolOptions struct {
+	PoolSize     int           // Maximum number of connections
+	MinIdleConns int           // Idle connections kept open for bursts
+	DialTimeout  time.Duration // Timeout for establishing a connection
+	ReadTimeout  time.Duration // Timeout for socket reads
+	// PoolTimeout is how long a command waits for a free connection when
+	// the pool is exhausted before failing with ErrPoolExhausted. The
+	// go-redis default is ReadTimeout + 1s.
+	PoolTimeout time.Duration
+}
+
+// apply copies the non-zero options onto go-redis options
+func (p RedisPoolOptions) apply(opt *redis.Options) {
+	if p.PoolSize > 0 {
+		opt.PoolSize = p.PoolSize
+	}
+	if p.MinIdleConns > 0 {
+		opt.MinIdleConns = p.MinIdleConns
+	}
+	if p.DialTimeout > 0 {
+		opt.DialTimeout = p.DialTimeout
+	}
+	if p.ReadTimeout > 0 {
+		opt.ReadTimeout = p.ReadTimeout
+	}
+	if p.PoolTimeout > 0 {
+		opt.PoolTimeout = p.PoolTimeout
+	}
+}
+
+// RedisPoolStats reports connection pool usage
+type RedisPoolStats struct {
+	Hits       uint32 `json:"hits"`        // Free connection found in the pool
+	Misses     uint32 `json:"misses"`      // No free connection; a new one was dialed
+	Timeouts   uint32 `json:"timeouts"`    // Waits for a connection that hit PoolTimeout
+	TotalConns uint32 `json:"total_conns"` // Open connections
+	IdleConns  uint32 `json:"idle_conns"`  // Idle connections
+	StaleConns uint32 `json:"stale_conns"` // Stale connections removed
+}
+
+func poolStats(client *redis.Client) RedisPoolStats {
+	stats := client.PoolStats()
+	return RedisPoolStats{
+		Hits:       stats.Hits,
+		Misses:     stats.Misses,
+		Timeouts:   stats.Timeouts,
+		TotalConns: stats.TotalConns,
+		IdleConns:  stats.IdleConns,
+		StaleConns: stats.StaleConns,
+	}
+}
+
+// redisPoolTimeoutMessage is the text of go-redis' internal pool.ErrPoolTimeout
+const redisPoolTimeoutMessage = "redis: connection pool timeout"
+
+// redisError marks go-redis pool timeouts with ErrPoolExhausted
+func redisError(err error) error {
+	if err != nil && err.Error() == redisPoolTimeoutMessage {
+		return fmt.Errorf("%w: %w", ErrPoolExhausted, err)
+	}
+	return err
 }
 
 // NewRedisClient creates a new Redis client with specified options
@@ -128,6 +194,7 @@ func NewRedisClient(opts RedisClientOptions) (*RedisClient, error) {
 		}
 	}
 
+	opts.Pool.apply(redisOpt)
 	client := redis.NewClient(redisOpt)
 
 	if opts.Logger != nil {
@@ -284,6 +351,11 @@ func (r *RedisClient) Pipeline() redis.Pipeliner {
 	return r.client.Pipeline()
 }
 
+// PoolStats returns connection pool counters
+func (r *RedisClient) PoolStats() RedisPoolStats {
+	return poolStats(r.client)
+}
+
 // --- Health Check ---
 
 // HealthCheck verifies Redis connectivity
@@ -14,6 +14,12 @@ import (
 type RedisDiscovery struct {
 	*RedisRegistry        // Embed for registration capabilities
 	logger         Logger // Optional logger for discovery operations
+
+	// watchInterval is how often Watch polls without keyspace notifications
+	watchInterval time.Duration
+
+	// selector keeps round-robin and LRU state for SelectService
+	selector *ServiceSelector
 }
 
 // NewRedisDiscovery creates a new Redis discovery client
@@ -28,9 +34,11 @@ func NewRedisDiscoveryWithNamespace(redisURL, namespace string) (*RedisDiscovery
 		return nil, err
 	}
 
-	return &RedisDiscovery{
+	d := &RedisDiscovery{
 		RedisRegistry: registry,
-	}, nil
+	}
+	d.selector = NewServiceSelector(d)
+	return d, nil
 }
 
 // SetLogger sets the logger for the discovery client
@@ -66,6 +74,8 @@ func (d *RedisDiscovery) Discover(ctx context.Context, filter DiscoveryFilter) (
 
 	var services []*ServiceInfo
 	var serviceIDs []string
+	// Index sets consulted, so expired IDs can be pruned from them
+	var indexKeys []string
 
 	// Filter by type if specified
 	if filter.Type != "" {
@@ -105,6 +115,7 @@ func (d *RedisDiscovery) Discover(ctx context.Context, filter DiscoveryFilter) (
 			return nil, fmt.Errorf("failed to find services by type %s: %w", filter.Type, err)
 		}
 		serviceIDs = append(serviceIDs, ids...)
+		indexKeys = append(indexKeys, typeKey)
 
 		if d.logger != nil {
 			d.logger.DebugWithContext(ctx, "Found services by type", map[string]interface{}{
@@ -151,6 +162,7 @@ func (d *RedisDiscovery) Discover(ctx context.Context, filter DiscoveryFilter) (
 			}
 			return nil, fmt.Errorf("failed to find services by name %s: %w", filter.Name, err)
 		}
+		indexKeys = append(indexKeys, nameKey)
 
 		if filter.Type != "" {
 			// Intersect with type filter
@@ -200,6 +212,7 @@ func (d *RedisDiscovery) Discover(ctx context.Context, filter DiscoveryFilter) (
 				continue
 			}
 			capIDs = append(capIDs, ids...)
+			indexKeys = append(indexKeys, capKey)
 
 			if d.logger != nil {
 				d.logger.DebugWithContext(ctx, "Found services by capability", map[string]interface{}{
@@ -296,8 +309,10 @@ func (d *RedisDiscovery) Discover(ctx context.Context, filter DiscoveryFilter) (
 		data, err := d.client.Get(ctx, key).Result()
 		if err != nil {
 			if err == redis.Nil {
-				// Service expired or deleted, skip
+				// Service expired or deleted: skip it and drop it from the
+				// index sets so later lookups don't fetch it again
 				skippedExpired++
+				d.pruneIndexes(ctx, id, indexKeys)
 				if d.logger != nil {
 					d.logger.DebugWithContext(ctx, "Service expired or deleted", map[string]interface{}{
 						"service_id": id,
@@ -422,11 +437,53 @@ func (d *RedisDiscovery) FindService(ctx context.Context, serviceName string) ([
 	return d.Discover(ctx, DiscoveryFilter{Name: serviceName})
 }
 
-// FindByCapability finds services by capability (backward compatibility)
+// FindByCapability finds services by capability. Only registrations in the
+// capability's index set are fetched, not the whole registry.
 func (d *RedisDiscovery) FindByCapability(ctx context.Context, capability string) ([]*ServiceInfo, error) {
 	return d.Discover(ctx, DiscoveryFilter{Capabilities: []string{capability}})
 }
 
+// SelectService picks one healthy, recently seen service with the
+// capability using strategy. Selection state is shared by all callers of
+// this discovery client.
+func (d *RedisDiscovery) SelectService(ctx context.Context, capability string, strategy SelectionStrategy) (*ServiceInfo, error) {
+	return d.selector.Select(ctx, capability, strategy)
+}
+
+// pruneIndexScript removes a service from index sets only while its
+// registration key is still missing, so a service that re-registers between
+// the lookup and the prune keeps its index entries
+var pruneIndexScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+  return 0
+end
+for i = 2, #KEYS do
+  redis.call('SREM', KEYS[i], ARGV[1])
+end
+return 1
+`)
+
+// pruneIndexes removes a service whose registration has expired from the
+// given index sets. Index sets outlive their members because every live
+// member's heartbeat refreshes the set TTL, so without pruning an expired
+// agent would be fetched by each lookup until the whole set goes idle.
+func (d *RedisDiscovery) pruneIndexes(ctx context.Context, serviceID string, indexKeys []string) {
+	if len(indexKeys) == 0 {
+		return
+	}
+	keys := append([]string{fmt.Sprintf("%s:services:%s", d.namespace, serviceID)}, indexKeys...)
+	if err := pruneIndexScript.Run(ctx, d.client, keys, serviceID).Err(); err != nil {
+		if d.logger != nil {
+			d.logger.DebugWithContext(ctx, "Failed to prune expired service from indexes", map[string]interface{}{
+				"service_id": serviceID,
+				"index_keys": indexKeys,
+				"error":      err,
+				"error_type": fmt.Sprintf("%T", err),
+			})
+		}
+	}
+}
+
 // intersect returns the intersection of two string slices
 func intersect(a, b []string) []string {
 	set := make(map[string]bool)
@@ -3,6 +3,8 @@ package core
 import (
 	"context"
 	"testing"
+
+	"github.com/alicebob/miniredis/v2"
 )
 
 // TestIntersect tests the intersect utility function comprehensively
@@ -308,6 +310,70 @@ func TestRedisDiscoveryBackwardCompatibilityWrappers(t *testing.T) {
 	})
 }
 
+// TestRedisDiscoveryFindByCapabilityPrunesExpired verifies capability lookups
+// use the index set and drop services whose registration has expired
+func TestRedisDiscoveryFindByCapabilityPrunesExpired(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+
+	registry, err := NewRedisRegistry("redis://" + mr.Addr())
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+	t.Cleanup(func() { _ = registry.client.Close() })
+	discovery, err := NewRedisDiscovery("redis://" + mr.Addr())
+	if err != nil {
+		t.Fatalf("Failed to create discovery: %v", err)
+	}
+	t.Cleanup(func() { _ = discovery.client.Close() })
+
+	for _, info := range []*ServiceInfo{
+		{ID: "summarizer-1", Name: "summarizer", Type: ComponentTypeAgent, Capabilities: []Capability{{Name: "summarize"}}},
+		{ID: "summarizer-2", Name: "summarizer", Type: ComponentTypeAgent, Capabilities: []Capability{{Name: "summarize"}}},
+		{ID: "weather-1", Name: "weather", Type: ComponentTypeTool, Capabilities: []Capability{{Name: "forecast"}}},
+	} {
+		if err := registry.Register(ctx, info); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	services, err := discovery.FindByCapability(ctx, "summarize")
+	if err != nil {
+		t.Fatalf("FindByCapability failed: %v", err)
+	}
+	if len(services) != 2 {
+		t.Fatalf("Expected 2 services, got %d", len(services))
+	}
+
+	// Simulate summarizer-2 missing its heartbeat until the key expired
+	mr.Del("gomind:services:summarizer-2")
+
+	services, err = discovery.FindByCapability(ctx, "summarize")
+	if err != nil {
+		t.Fatalf("FindByCapability failed: %v", err)
+	}
+	if len(services) != 1 || services[0].ID != "summarizer-1" {
+		t.Fatalf("Expected only summarizer-1, got %v", services)
+	}
+
+	members, err := mr.Members("gomind:capabilities:summarize")
+	if err != nil {
+		t.Fatalf("Failed to read capability index: %v", err)
+	}
+	if len(members) != 1 || members[0] != "summarizer-1" {
+		t.Errorf("Expected expired service pruned from index, got %v", members)
+	}
+
+	// Unfiltered discovery is unaffected
+	all, err := discovery.Discover(ctx, DiscoveryFilter{})
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("Expected 2 live services, got %d", len(all))
+	}
+}
+
 // Mock logger for testing
 type MockLogger struct {
 	entries []LogEntry
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestRedisMemory_PingAndPoolStats(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	memory, err := NewRedisMemoryWithPool("redis://"+mr.Addr(), RedisPoolOptions{
+		PoolSize:    4,
+		DialTimeout: time.Second,
+		ReadTimeout: time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewRedisMemoryWithPool() error = %v", err)
+	}
+	defer func() { _ = memory.Close() }()
+
+	if opt := memory.client.Options(); opt.PoolSize != 4 || opt.DialTimeout != time.Second || opt.ReadTimeout != time.Second {
+		t.Errorf("Pool options not applied: size=%d dial=%v read=%v", opt.PoolSize, opt.DialTimeout, opt.ReadTimeout)
+	}
+
+	ctx := context.Background()
+	if err := memory.Ping(ctx); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		_, _ = memory.Get(ctx, "k")
+	}
+	if stats := memory.PoolStats(); stats.Hits == 0 || stats.TotalConns == 0 {
+		t.Errorf("PoolStats() = %+v, want connection reuse", stats)
+	}
+
+	mr.Close()
+	if err := memory.Ping(ctx); err == nil {
+		t.Error("Ping() should fail once Redis is down")
+	}
+}
+
+func TestRedisMemory_PoolExhaustedFailsFast(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	memory, err := NewRedisMemoryWithPool("redis://"+mr.Addr(), RedisPoolOptions{
+		PoolSize:    1,
+		PoolTimeout: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewRedisMemoryWithPool() error = %v", err)
+	}
+	defer func() { _ = memory.Close() }()
+
+	// Occupy the only connection with a blocking pop
+	ctx := context.Background()
+	blocked := make(chan struct{})
+	go func() {
+		defer close(blocked)
+		_ = memory.client.BLPop(ctx, time.Second, "never-pushed").Err()
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	start := time.Now()
+	_, err = memory.Get(ctx, "k")
+	if !errors.Is(err, ErrPoolExhausted) {
+		t.Fatalf("Get() error = %v, want ErrPoolExhausted", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Get() waited %v for a connection, want it to fail after PoolTimeout", elapsed)
+	}
+	if stats := memory.PoolStats(); stats.Timeouts == 0 {
+		t.Errorf("PoolStats().Timeouts = 0, want the timeout counted")
+	}
+
+	mr.Lpush("never-pushed", "x")
+	<-blocked
+}
@@ -85,6 +85,20 @@ type RedisRegistry struct {
 	// Heartbeat cancel functions for cleanup
 	heartbeats   map[string]context.CancelFunc
 	heartbeatsMu sync.RWMutex
+
+	// Registration watchdog, started with the heartbeat when the interval is set.
+	// reregisterMu keeps the watchdog and heartbeat recovery from re-registering
+	// the same service concurrently.
+	registrationCheckInterval time.Duration
+	reregisterMu              sync.Mutex
+
+	// Randomization that keeps replicas from registering and heartbeating in
+	// lockstep, see SetHeartbeatJitter and SetRegistrationStagger
+	heartbeatJitter     float64
+	registrationStagger time.Duration
+
+	// Callbacks run after Register or Unregister succeeds, see onRegistryChange
+	changeListeners changeListeners
 }
 
 // NewRedisRegistry creates a new Redis registry client
@@ -142,6 +156,7 @@ func NewRedisRegistryWithNamespace(redisURL, namespace string) (*RedisRegistry,
 		heartbeatMutex:    sync.RWMutex{},
 		heartbeats:        make(map[string]context.CancelFunc), // Track cancel functions
 		heartbeatsMu:      sync.RWMutex{},
+		heartbeatJitter:   DefaultHeartbeatJitter,
 	}
 
 	// Note: Logger will be set later via SetLogger method if needed
@@ -264,6 +279,7 @@ func (r *RedisRegistry) Register(ctx context.Context, info *ServiceInfo) error {
 		})
 	}
 
+	r.notifyRegistryChange()
 	return nil
 }
 
@@ -561,9 +577,20 @@ func (r *RedisRegistry) Unregister(ctx context.Context, serviceID string) error
 		})
 	}
 
+	r.notifyRegistryChange()
 	return nil
 }
 
+// onRegistryChange adds a callback that runs after Register or Unregister
+// succeeds (implements registryChangeNotifier)
+func (r *RedisRegistry) onRegistryChange(fn func()) func() {
+	return r.changeListeners.add(fn)
+}
+
+func (r *RedisRegistry) notifyRegistryChange() {
+	r.changeListeners.notify()
+}
+
 // refreshIndexSetTTLs extends TTL for all index sets this service belongs to
 // This prevents healthy services from becoming undiscoverable when index sets expire
 // before the service keys. Called during heartbeat to keep index sets alive.
@@ -744,7 +771,10 @@ func (r *RedisRegistry) maintainRegistration(ctx context.Context, serviceID stri
 			jitter := time.Duration(jitterMs.Int64()) * time.Millisecond
 			time.Sleep(jitter)
 
-			if regErr := r.Register(ctx, serviceInfo); regErr != nil {
+			r.reregisterMu.Lock()
+			regErr := r.Register(ctx, serviceInfo)
+			r.reregisterMu.Unlock()
+			if regErr != nil {
 				if r.logger != nil {
 					r.logger.ErrorWithContext(ctx, "Failed to re-register service during recovery", map[string]interface{}{
 						"service_id":                serviceID,
@@ -932,16 +962,11 @@ func (r *RedisRegistry) StartHeartbeat(ctx context.Context, serviceID string) {
 	r.heartbeats[serviceID] = cancel
 	r.heartbeatsMu.Unlock()
 
-	// Base interval with jitter to distribute load
-	baseInterval := r.ttl / 2
-	maxJitter := int64(baseInterval.Milliseconds() / 4)
-	jitterMs, _ := rand.Int(rand.Reader, big.NewInt(maxJitter))
-	jitter := time.Duration(jitterMs.Int64()) * time.Millisecond
-	interval := baseInterval + jitter
-
-	ticker := time.NewTicker(interval)
+	// The first heartbeat lands at a random point in the interval and each
+	// later one is re-jittered, so replicas never settle into lockstep
+	timer := time.NewTimer(r.firstHeartbeatDelay())
 	go func() {
-		defer ticker.Stop()
+		defer timer.Stop()
 		for {
 			select {
 			case <-hbCtx.Done():
@@ -952,14 +977,19 @@ func (r *RedisRegistry) StartHeartbeat(ctx context.Context, serviceID string) {
 				delete(r.heartbeatStats, serviceID)
 				r.heartbeatMutex.Unlock()
 				return
-			case <-ticker.C:
+			case <-timer.C:
 				// Use enhanced maintenance logic with self-healing
 				r.maintainRegistration(hbCtx, serviceID)
 				// Check if it's time for periodic summary (every 5 minutes)
 				r.checkAndLogPeriodicSummary(serviceID)
+				timer.Reset(r.nextHeartbeatInterval())
 			}
 		}
 	}()
+
+	if r.registrationCheckInterval > 0 {
+		go r.runRegistrationWatchdog(hbCtx, serviceID)
+	}
 }
 
 // StartRegistryRetry initiates background reconnection attempts to Redis.
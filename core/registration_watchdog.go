@@ -0,0 +1,137 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// SetRegistrationCheckInterval enables the registration watchdog for services
+// whose heartbeat is started afterwards. The watchdog periodically verifies
+// that the service entry and its name, type and capability index entries
+// still exist, and re-registers the service if any were evicted (for example
+// by a Redis flush or an index TTL race). Intervals shorter than the heartbeat
+// are raised to the heartbeat interval. Zero disables the watchdog.
+func (r *RedisRegistry) SetRegistrationCheckInterval(interval time.Duration) {
+	r.registrationCheckInterval = interval
+}
+
+// runRegistrationWatchdog checks the registration until ctx is cancelled.
+// It shares the heartbeat's context, so StopHeartbeat stops it too.
+func (r *RedisRegistry) runRegistrationWatchdog(ctx context.Context, serviceID string) {
+	interval := r.registrationCheckInterval
+	if heartbeat := r.ttl / 2; interval < heartbeat {
+		interval = heartbeat
+	}
+	// Jitter so replicas started together don't check in lockstep
+	jitterMs, _ := rand.Int(rand.Reader, big.NewInt(interval.Milliseconds()/4+1))
+	interval += time.Duration(jitterMs.Int64()) * time.Millisecond
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.verifyRegistration(ctx, serviceID)
+		}
+	}
+}
+
+// verifyRegistration re-registers serviceID from its last known state if its
+// entry or any index membership is missing. It returns true if the service
+// was re-registered.
+func (r *RedisRegistry) verifyRegistration(ctx context.Context, serviceID string) bool {
+	info := r.getStoredRegistrationState(serviceID)
+	if info == nil {
+		return false
+	}
+
+	missing, err := r.missingRegistrationKeys(ctx, info)
+	if err != nil {
+		// Redis is unreachable - the heartbeat reports this, nothing to repair yet
+		if r.logger != nil {
+			r.logger.DebugWithContext(ctx, "Registration check failed", map[string]interface{}{
+				"operation":  "registration_watchdog",
+				"service_id": serviceID,
+				"error":      err.Error(),
+			})
+		}
+		return false
+	}
+	if len(missing) == 0 {
+		return false
+	}
+
+	r.reregisterMu.Lock()
+	defer r.reregisterMu.Unlock()
+
+	// The heartbeat may have repaired the registration while we waited
+	if missing, err = r.missingRegistrationKeys(ctx, info); err != nil || len(missing) == 0 {
+		return false
+	}
+
+	if r.logger != nil {
+		r.logger.WarnWithContext(ctx, "Service registration missing from discovery, re-registering", map[string]interface{}{
+			"operation":    "registration_watchdog",
+			"service_id":   serviceID,
+			"service_name": info.Name,
+			"missing_keys": missing,
+		})
+	}
+
+	if err := r.Register(ctx, info); err != nil {
+		if r.logger != nil {
+			r.logger.ErrorWithContext(ctx, "Failed to re-register service", map[string]interface{}{
+				"operation":  "registration_watchdog",
+				"service_id": serviceID,
+				"error":      err.Error(),
+			})
+		}
+		return false
+	}
+
+	if registry := GetGlobalMetricsRegistry(); registry != nil {
+		registry.Counter("discovery.reregistrations",
+			"service_type", string(info.Type),
+			"namespace", r.namespace,
+		)
+	}
+	return true
+}
+
+// missingRegistrationKeys returns the keys that should reference info but don't
+func (r *RedisRegistry) missingRegistrationKeys(ctx context.Context, info *ServiceInfo) ([]string, error) {
+	serviceKey := fmt.Sprintf("%s:services:%s", r.namespace, info.ID)
+	indexKeys := []string{
+		fmt.Sprintf("%s:names:%s", r.namespace, info.Name),
+		fmt.Sprintf("%s:types:%s", r.namespace, info.Type),
+	}
+	for _, capability := range info.Capabilities {
+		indexKeys = append(indexKeys, fmt.Sprintf("%s:capabilities:%s", r.namespace, capability.Name))
+	}
+
+	pipe := r.client.Pipeline()
+	exists := pipe.Exists(ctx, serviceKey)
+	members := make([]interface{ Val() bool }, len(indexKeys))
+	for i, key := range indexKeys {
+		members[i] = pipe.SIsMember(ctx, key, info.ID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	if exists.Val() == 0 {
+		missing = append(missing, serviceKey)
+	}
+	for i, member := range members {
+		if !member.Val() {
+			missing = append(missing, indexKeys[i])
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func newWatchdogTestRegistry(t *testing.T) (*RedisRegistry, *miniredis.Miniredis, *ServiceInfo) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	registry, err := NewRedisRegistry("redis://" + mr.Addr())
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+	t.Cleanup(func() { _ = registry.client.Close() })
+
+	info := &ServiceInfo{
+		ID:           "weather-1",
+		Name:         "weather",
+		Type:         ComponentTypeTool,
+		Capabilities: []Capability{{Name: "forecast"}},
+	}
+	if err := registry.Register(context.Background(), info); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	return registry, mr, info
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("leaves intact registration alone", func(t *testing.T) {
+		registry, _, _ := newWatchdogTestRegistry(t)
+		if registry.verifyRegistration(ctx, "weather-1") {
+			t.Error("Expected no re-registration for an intact entry")
+		}
+	})
+
+	t.Run("restores evicted index membership", func(t *testing.T) {
+		registry, mr, _ := newWatchdogTestRegistry(t)
+		if _, err := mr.SRem("gomind:capabilities:forecast", "weather-1"); err != nil {
+			t.Fatal(err)
+		}
+
+		if !registry.verifyRegistration(ctx, "weather-1") {
+			t.Fatal("Expected re-registration")
+		}
+		if ok, _ := mr.SIsMember("gomind:capabilities:forecast", "weather-1"); !ok {
+			t.Error("Expected capability index to be restored")
+		}
+	})
+
+	t.Run("restores registration after flush", func(t *testing.T) {
+		registry, mr, _ := newWatchdogTestRegistry(t)
+		mr.FlushAll()
+
+		if !registry.verifyRegistration(ctx, "weather-1") {
+			t.Fatal("Expected re-registration")
+		}
+		if !mr.Exists("gomind:services:weather-1") {
+			t.Error("Expected service key to be restored")
+		}
+	})
+
+	t.Run("ignores services it never registered", func(t *testing.T) {
+		registry, _, _ := newWatchdogTestRegistry(t)
+		if registry.verifyRegistration(ctx, "unknown") {
+			t.Error("Expected no re-registration for unknown service")
+		}
+	})
+}
+
+func TestRegistrationWatchdog_RunsWithHeartbeat(t *testing.T) {
+	registry, mr, _ := newWatchdogTestRegistry(t)
+	registry.ttl = 20 * time.Millisecond
+	registry.SetRegistrationCheckInterval(time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	registry.StartHeartbeat(ctx, "weather-1")
+	defer registry.StopHeartbeat(ctx, "weather-1")
+
+	// The heartbeat only refreshes TTLs, so a lost name index entry is the watchdog's job
+	if _, err := mr.SRem("gomind:names:weather", "weather-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if ok, _ := mr.SIsMember("gomind:names:weather", "weather-1"); ok {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("Watchdog did not restore the name index entry")
+}
@@ -0,0 +1,235 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// BaggageHeader is the W3C Baggage header that carries request metadata
+// between components
+const BaggageHeader = "baggage"
+
+// Request metadata limits, the same as the telemetry module's baggage
+// limits so metadata always fits in the baggage that carries it
+const (
+	maxRequestMetadataItems       = 64
+	maxRequestMetadataKeyLength   = 128
+	maxRequestMetadataValueLength = 512
+	maxRequestMetadataSize        = 8192
+)
+
+type requestMetadataKey struct{}
+
+// WithRequestMetadata returns ctx carrying meta, a set of request-scoped
+// values such as request_id, user_id and tenant_id, merged over any
+// metadata ctx already has. The metadata:
+//
+//   - is added to the fields of every line written by a logger bound with
+//     LoggerWithContext
+//   - is set as attributes on spans started with the context, once the
+//     telemetry module is initialized
+//   - travels to other components in the W3C baggage header, through the
+//     orchestration executors or InjectRequestMetadata, and is restored
+//     from it by agents and tools
+//
+// When the telemetry module is initialized the values are also set as
+// OpenTelemetry baggage, so they label context-aware metrics too.
+//
+// Keys must be valid HTTP header tokens; other keys are skipped. Keys are
+// cut to 128 bytes and values to 512, and values that would take the
+// metadata past 64 entries or 8KB in total are dropped.
+//
+// Example:
+//
+//	ctx = core.WithRequestMetadata(ctx, map[string]string{
+//	    "request_id": requestID,
+//	    "tenant_id":  tenantID,
+//	})
+func WithRequestMetadata(ctx context.Context, meta map[string]string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if len(meta) == 0 {
+		return ctx
+	}
+
+	existing, _ := ctx.Value(requestMetadataKey{}).(map[string]string)
+	merged := make(map[string]string, len(existing)+len(meta))
+	size := 0
+	for k, v := range existing {
+		merged[k] = v
+		size += len(k) + len(v)
+	}
+
+	// Sorted, so the same input always keeps the same entries
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var added []string
+	for _, key := range keys {
+		value := meta[key]
+		if len(key) > maxRequestMetadataKeyLength {
+			key = key[:maxRequestMetadataKeyLength]
+		}
+		if !isBaggageKey(key) {
+			continue
+		}
+		if len(value) > maxRequestMetadataValueLength {
+			value = value[:maxRequestMetadataValueLength]
+		}
+
+		newSize := size + len(key) + len(value)
+		old, replacing := merged[key]
+		if replacing {
+			newSize -= len(key) + len(old)
+		} else if len(merged) >= maxRequestMetadataItems {
+			continue
+		}
+		if newSize > maxRequestMetadataSize {
+			continue
+		}
+		merged[key] = value
+		size = newSize
+		added = append(added, key, value)
+	}
+	if len(added) == 0 {
+		return ctx
+	}
+
+	ctx = context.WithValue(ctx, requestMetadataKey{}, merged)
+	if registry, ok := GetGlobalMetricsRegistry().(interface {
+		WithBaggage(ctx context.Context, labels ...string) context.Context
+	}); ok {
+		ctx = registry.WithBaggage(ctx, added...)
+	}
+	return ctx
+}
+
+// RequestMetadataFromContext returns a copy of the metadata set on ctx with
+// WithRequestMetadata, or nil when there is none
+func RequestMetadataFromContext(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	meta, _ := ctx.Value(requestMetadataKey{}).(map[string]string)
+	if len(meta) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(meta))
+	for k, v := range meta {
+		copied[k] = v
+	}
+	return copied
+}
+
+// InjectRequestMetadata adds the request metadata in ctx to the baggage
+// header of an outgoing request, so the called component inherits it.
+// Entries already in the header are kept.
+func InjectRequestMetadata(ctx context.Context, header http.Header) {
+	meta := RequestMetadataFromContext(ctx)
+	if len(meta) == 0 {
+		return
+	}
+
+	current := header.Get(BaggageHeader)
+	present := parseBaggage(current)
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		if _, ok := present[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	sort.Strings(keys)
+
+	members := make([]string, 0, len(keys)+1)
+	if current != "" {
+		members = append(members, current)
+	}
+	for _, k := range keys {
+		members = append(members, k+"="+url.PathEscape(meta[k]))
+	}
+	header.Set(BaggageHeader, strings.Join(members, ","))
+}
+
+// RequestMetadataMiddleware restores the request metadata keys listed in
+// trusted from the baggage header sent by another component, so handlers,
+// their logs and their spans carry them. Other keys are ignored, and with no
+// keys the middleware does nothing. Agents and tools include it in their
+// standard middleware stack with HTTPConfig.TrustedMetadataKeys, which
+// trusts request_id, user_id and tenant_id by default (see
+// WithTrustedRequestMetadata).
+func RequestMetadataMiddleware(trusted ...string) func(http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(trusted))
+	for _, key := range trusted {
+		allowed[key] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		if len(allowed) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			meta := parseBaggage(r.Header.Get(BaggageHeader))
+			for key := range meta {
+				if !allowed[key] {
+					delete(meta, key)
+				}
+			}
+			if len(meta) > 0 {
+				r = r.WithContext(WithRequestMetadata(r.Context(), meta))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// parseBaggage reads the key=value members of a W3C baggage header,
+// ignoring member properties and malformed members
+func parseBaggage(header string) map[string]string {
+	if header == "" {
+		return nil
+	}
+	meta := make(map[string]string)
+	for _, member := range strings.Split(header, ",") {
+		if i := strings.IndexByte(member, ';'); i >= 0 {
+			member = member[:i]
+		}
+		key, value, ok := strings.Cut(member, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value, err := url.PathUnescape(strings.TrimSpace(value))
+		if err != nil || !isBaggageKey(key) {
+			continue
+		}
+		meta[key] = value
+	}
+	return meta
+}
+
+// isBaggageKey reports whether key is an HTTP token, as baggage keys must be
+func isBaggageKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// baggageRegistry records the baggage core mirrors into telemetry
+type baggageRegistry struct {
+	mockMetricsRegistry
+	labels []string
+}
+
+func (r *baggageRegistry) WithBaggage(ctx context.Context, labels ...string) context.Context {
+	r.labels = append(r.labels, labels...)
+	return ctx
+}
+
+func TestWithRequestMetadata(t *testing.T) {
+	ctx := WithRequestMetadata(context.Background(), map[string]string{"request_id": "r-1", "user_id": "u-1"})
+	ctx = WithRequestMetadata(ctx, map[string]string{"tenant_id": "acme", "user_id": "u-2", "bad key": "skipped"})
+
+	assert.Equal(t, map[string]string{"request_id": "r-1", "user_id": "u-2", "tenant_id": "acme"}, RequestMetadataFromContext(ctx))
+
+	meta := RequestMetadataFromContext(ctx)
+	meta["request_id"] = "changed"
+	assert.Equal(t, "r-1", RequestMetadataFromContext(ctx)["request_id"], "callers get a copy")
+
+	assert.Nil(t, RequestMetadataFromContext(context.Background()))
+	assert.Equal(t, context.Background(), WithRequestMetadata(context.Background(), nil))
+}
+
+func TestWithRequestMetadata_Limits(t *testing.T) {
+	ctx := WithRequestMetadata(context.Background(), map[string]string{
+		"long":                   strings.Repeat("v", 1000),
+		strings.Repeat("k", 200): "v",
+	})
+	meta := RequestMetadataFromContext(ctx)
+	assert.Len(t, meta["long"], maxRequestMetadataValueLength)
+	assert.Contains(t, meta, strings.Repeat("k", maxRequestMetadataKeyLength))
+
+	many := make(map[string]string, 100)
+	for i := 0; i < 100; i++ {
+		many["key"+strings.Repeat("x", i)] = "v"
+	}
+	assert.Len(t, RequestMetadataFromContext(WithRequestMetadata(context.Background(), many)), maxRequestMetadataItems)
+
+	big := make(map[string]string, 20)
+	for i := 0; i < 20; i++ {
+		big["k"+strings.Repeat("x", i)] = strings.Repeat("v", maxRequestMetadataValueLength)
+	}
+	size := 0
+	for k, v := range RequestMetadataFromContext(WithRequestMetadata(context.Background(), big)) {
+		size += len(k) + len(v)
+	}
+	assert.LessOrEqual(t, size, maxRequestMetadataSize)
+	assert.Greater(t, size, maxRequestMetadataSize-600)
+}
+
+func TestWithRequestMetadata_MirrorsBaggage(t *testing.T) {
+	original := globalMetricsRegistry
+	defer func() { globalMetricsRegistry = original }()
+	registry := &baggageRegistry{}
+	globalMetricsRegistry = registry
+
+	WithRequestMetadata(context.Background(), map[string]string{"tenant_id": "acme", "request_id": "r-1"})
+	assert.Equal(t, []string{"request_id", "r-1", "tenant_id", "acme"}, registry.labels)
+}
+
+func TestRequestMetadataLogging(t *testing.T) {
+	ctx := WithRequestMetadata(context.Background(), map[string]string{"tenant_id": "acme", "city": "ignored"})
+	base := &MockLogger{}
+	LoggerWithContext(base, ctx).Info("lookup", map[string]interface{}{"city": "Tokyo"})
+
+	require.Len(t, base.entries, 1)
+	assert.Equal(t, "acme", base.entries[0].Fields["tenant_id"])
+	assert.Equal(t, "Tokyo", base.entries[0].Fields["city"], "caller fields win")
+}
+
+func TestInjectRequestMetadata(t *testing.T) {
+	ctx := WithRequestMetadata(context.Background(), map[string]string{"tenant_id": "acme corp", "request_id": "r-1"})
+
+	header := http.Header{}
+	header.Set(BaggageHeader, "request_id=from-otel;prop")
+	InjectRequestMetadata(ctx, header)
+	assert.Equal(t, "request_id=from-otel;prop,tenant_id=acme%20corp", header.Get(BaggageHeader))
+
+	assert.Equal(t, map[string]string{"request_id": "from-otel", "tenant_id": "acme corp"}, parseBaggage(header.Get(BaggageHeader)))
+
+	empty := http.Header{}
+	InjectRequestMetadata(context.Background(), empty)
+	assert.Empty(t, empty.Get(BaggageHeader))
+}
+
+func TestRequestMetadataMiddleware(t *testing.T) {
+	upstream := WithRequestMetadata(context.Background(), map[string]string{"tenant_id": "acme", "user_id": "u-1"})
+
+	call := func(trusted ...string) map[string]string {
+		tool := NewTool("downstream")
+		require.NoError(t, WithTrustedRequestMetadata(trusted...)(tool.Config))
+		var got map[string]string
+		tool.RegisterCapability(Capability{Name: "whoami", Handler: func(w http.ResponseWriter, r *http.Request) {
+			got = RequestMetadataFromContext(r.Context())
+		}})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/capabilities/whoami", nil)
+		InjectRequestMetadata(upstream, req.Header)
+		req.Header.Set(BaggageHeader, req.Header.Get(BaggageHeader)+",trace_id=spoofed")
+		tool.Handler().ServeHTTP(httptest.NewRecorder(), req)
+		return got
+	}
+
+	assert.Nil(t, call(), "inbound baggage is ignored when no keys are trusted")
+	assert.Equal(t, map[string]string{"tenant_id": "acme"}, call("tenant_id", "request_id"), "only trusted keys are restored")
+}
+
+func TestRequestMetadataMiddleware_DefaultKeys(t *testing.T) {
+	// The end-to-end flow from the README: an upstream sets metadata, the
+	// executor sends it as baggage and a default tool restores it
+	upstream := WithRequestMetadata(context.Background(), map[string]string{
+		"request_id": "req-1", "user_id": "u-1", "tenant_id": "acme", "session": "s-1",
+	})
+
+	tool := NewTool("downstream")
+	var got map[string]string
+	tool.RegisterCapability(Capability{Name: "whoami", Handler: func(w http.ResponseWriter, r *http.Request) {
+		got = RequestMetadataFromContext(r.Context())
+	}})
+	req := httptest.NewRequest(http.MethodPost, "/api/capabilities/whoami", nil)
+	InjectRequestMetadata(upstream, req.Header)
+	tool.Handler().ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, map[string]string{"request_id": "req-1", "user_id": "u-1", "tenant_id": "acme"}, got)
+}
@@ -0,0 +1,165 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	contextType    = reflect.TypeOf((*context.Context)(nil)).Elem()
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// SchemaSummaryOf builds a SchemaSummary from the exported fields of a struct
+// (or pointer to struct), so typed request structs don't need hand-written
+// field hints:
+//
+//	type ForecastRequest struct {
+//	    City  string  `json:"city" desc:"City name" example:"London"`
+//	    Days  int     `json:"days,omitempty" desc:"Number of days"`
+//	    Units *string `json:"units"`
+//	}
+//
+//	cap.InputSummary = core.SchemaSummaryOf(ForecastRequest{})
+//
+// Field names follow encoding/json: the json tag name when set, otherwise the
+// Go field name; fields tagged json:"-" are skipped and embedded structs are
+// flattened. Pointer and omitempty fields are optional, all others required.
+// The desc and example tags fill the hint's Description and Example.
+// v may also be a reflect.Type. It returns nil when v is not a struct.
+func SchemaSummaryOf(v interface{}) *SchemaSummary {
+	if v == nil {
+		return nil
+	}
+	t := reflect.TypeOf(v)
+	if rt, ok := v.(reflect.Type); ok {
+		t = rt
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	summary := &SchemaSummary{}
+	collectFieldHints(t, summary)
+	return summary
+}
+
+// InputSummaryFromHandler inspects a typed handler function and builds a
+// SchemaSummary from its first parameter that is not a context.Context.
+// For example, a handler func(ctx context.Context, req ForecastRequest) ...
+// yields the hints of ForecastRequest.
+func InputSummaryFromHandler(handler interface{}) (*SchemaSummary, error) {
+	t := reflect.TypeOf(handler)
+	if t == nil || t.Kind() != reflect.Func {
+		return nil, fmt.Errorf("handler must be a function, got %T", handler)
+	}
+	for i := 0; i < t.NumIn(); i++ {
+		in := t.In(i)
+		if in.Implements(contextType) {
+			continue
+		}
+		summary := SchemaSummaryOf(in)
+		if summary == nil {
+			return nil, fmt.Errorf("handler input %s is not a struct", in)
+		}
+		return summary, nil
+	}
+	return nil, fmt.Errorf("handler %s has no input parameter", t)
+}
+
+func collectFieldHints(t reflect.Type, summary *SchemaSummary) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitempty, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		// Untagged embedded structs are flattened like encoding/json does
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				collectFieldHints(ft, summary)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		hint := FieldHint{
+			Name:        name,
+			Type:        jsonTypeOf(field.Type),
+			Example:     field.Tag.Get("example"),
+			Description: field.Tag.Get("desc"),
+		}
+		if omitempty || field.Type.Kind() == reflect.Ptr {
+			summary.OptionalFields = append(summary.OptionalFields, hint)
+		} else {
+			summary.RequiredFields = append(summary.RequiredFields, hint)
+		}
+	}
+}
+
+// jsonFieldName returns the encoded name of a field and whether it is
+// omitempty or skipped entirely
+func jsonFieldName(field reflect.StructField) (name string, omitempty, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" || opt == "omitzero" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, false
+}
+
+// jsonTypeOf maps a Go type to the JSON type used in FieldHint.Type
+func jsonTypeOf(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return "string"
+	case t == rawMessageType:
+		return "object"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "string" // []byte is base64-encoded
+		}
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type forecastPaging struct {
+	Page int `json:"page,omitempty"`
+}
+
+type forecastRequest struct {
+	City     string            `json:"city" desc:"City name" example:"London"`
+	Days     int               `json:"days,omitempty" desc:"Number of days"`
+	Units    *string           `json:"units"`
+	Verbose  bool              // no json tag: field name is used
+	Tags     []string          `json:"tags"`
+	Options  map[string]string `json:"options,omitempty"`
+	Since    time.Time         `json:"since"`
+	Ratio    float64           `json:"ratio"`
+	Internal string            `json:"-"`
+	secret   string
+	forecastPaging
+}
+
+func TestSchemaSummaryOf(t *testing.T) {
+	summary := SchemaSummaryOf(&forecastRequest{})
+	require.NotNil(t, summary)
+
+	assert.Equal(t, []FieldHint{
+		{Name: "city", Type: "string", Example: "London", Description: "City name"},
+		{Name: "Verbose", Type: "boolean"},
+		{Name: "tags", Type: "array"},
+		{Name: "since", Type: "string"},
+		{Name: "ratio", Type: "number"},
+	}, summary.RequiredFields)
+	assert.Equal(t, []FieldHint{
+		{Name: "days", Type: "integer", Description: "Number of days"},
+		{Name: "units", Type: "string"},
+		{Name: "options", Type: "object"},
+		{Name: "page", Type: "integer"},
+	}, summary.OptionalFields)
+
+	assert.Nil(t, SchemaSummaryOf("not a struct"))
+	assert.Nil(t, SchemaSummaryOf(nil))
+}
+
+func TestInputSummaryFromHandler(t *testing.T) {
+	handler := func(ctx context.Context, req forecastRequest) (interface{}, error) { return nil, nil }
+
+	summary, err := InputSummaryFromHandler(handler)
+	require.NoError(t, err)
+	assert.Equal(t, "city", summary.RequiredFields[0].Name)
+
+	_, err = InputSummaryFromHandler(func(ctx context.Context) {})
+	assert.Error(t, err)
+	_, err = InputSummaryFromHandler(func(ctx context.Context, n int) {})
+	assert.Error(t, err)
+	_, err = InputSummaryFromHandler("nope")
+	assert.Error(t, err)
+}
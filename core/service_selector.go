@@ -0,0 +1,129 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultServiceStaleAfter is how old a service's LastSeen may be before
+// selection skips it. Three heartbeats at the default registry TTL.
+const DefaultServiceStaleAfter = 45 * time.Second
+
+// SelectionStrategy decides which of several healthy services handles a call
+type SelectionStrategy string
+
+const (
+	// SelectRoundRobin cycles through the services for a capability
+	SelectRoundRobin SelectionStrategy = "round_robin"
+	// SelectRandom picks a service uniformly at random
+	SelectRandom SelectionStrategy = "random"
+	// SelectLeastRecentlyUsed picks the service this selector used longest ago
+	SelectLeastRecentlyUsed SelectionStrategy = "least_recently_used"
+)
+
+// ServiceSelector picks one service for a capability from the healthy,
+// recently seen registrations. It replaces taking the first search result,
+// which sends all traffic to one instance. Round-robin positions and
+// last-use times are kept per selector, so share one selector across
+// callers. It is safe for concurrent use.
+type ServiceSelector struct {
+	discovery  Discovery
+	staleAfter time.Duration
+
+	mu       sync.Mutex
+	next     map[string]uint64    // Round-robin position per capability
+	lastUsed map[string]time.Time // Last selection time per service ID
+	rand     *rand.Rand
+	now      func() time.Time
+}
+
+// ServiceSelectorOption configures a ServiceSelector
+type ServiceSelectorOption func(*ServiceSelector)
+
+// WithStaleAfter sets how old LastSeen may be before a service is skipped.
+// Default is DefaultServiceStaleAfter. Services that never reported
+// LastSeen are not considered stale.
+func WithStaleAfter(age time.Duration) ServiceSelectorOption {
+	return func(s *ServiceSelector) {
+		if age > 0 {
+			s.staleAfter = age
+		}
+	}
+}
+
+// NewServiceSelector creates a selector over the given discovery
+func NewServiceSelector(discovery Discovery, opts ...ServiceSelectorOption) *ServiceSelector {
+	s := &ServiceSelector{
+		discovery:  discovery,
+		staleAfter: DefaultServiceStaleAfter,
+		next:       make(map[string]uint64),
+		lastUsed:   make(map[string]time.Time),
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Select finds the services with a capability and picks one using strategy.
+// Unhealthy and stale services are never chosen. It returns an error
+// wrapping ErrCapabilityNotFound when no eligible service exists.
+func (s *ServiceSelector) Select(ctx context.Context, capability string, strategy SelectionStrategy) (*ServiceInfo, error) {
+	services, err := s.discovery.FindByCapability(ctx, capability)
+	if err != nil {
+		return nil, fmt.Errorf("finding capability %s: %w", capability, err)
+	}
+	return s.SelectFrom(capability, services, strategy)
+}
+
+// SelectFrom picks one of services using strategy. Use it when the services
+// were already discovered. key groups round-robin state, normally the
+// capability name.
+func (s *ServiceSelector) SelectFrom(key string, services []*ServiceInfo, strategy SelectionStrategy) (*ServiceInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	eligible := make([]*ServiceInfo, 0, len(services))
+	for _, svc := range services {
+		if svc == nil || svc.Health != HealthHealthy {
+			continue
+		}
+		if !svc.LastSeen.IsZero() && now.Sub(svc.LastSeen) > s.staleAfter {
+			continue
+		}
+		eligible = append(eligible, svc)
+	}
+	if len(eligible) == 0 {
+		return nil, fmt.Errorf("no healthy service with capability %s: %w", key, ErrCapabilityNotFound)
+	}
+	// Discovery order is not stable, so order by ID for round-robin
+	sort.Slice(eligible, func(i, j int) bool { return eligible[i].ID < eligible[j].ID })
+
+	var selected *ServiceInfo
+	switch strategy {
+	case SelectRoundRobin, "":
+		selected = eligible[s.next[key]%uint64(len(eligible))]
+		s.next[key]++
+	case SelectRandom:
+		selected = eligible[s.rand.Intn(len(eligible))]
+	case SelectLeastRecentlyUsed:
+		selected = eligible[0]
+		for _, svc := range eligible[1:] {
+			if s.lastUsed[svc.ID].Before(s.lastUsed[selected.ID]) {
+				selected = svc
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown selection strategy %q: %w", strategy, ErrInvalidConfiguration)
+	}
+
+	s.lastUsed[selected.ID] = now
+	return selected, nil
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newSelectorTestDiscovery(t *testing.T, now time.Time) *MockDiscovery {
+	t.Helper()
+	discovery := NewMockDiscovery()
+	for _, info := range []*ServiceInfo{
+		{ID: "translator-a", Health: HealthHealthy, LastSeen: now},
+		{ID: "translator-b", Health: HealthHealthy, LastSeen: now},
+		{ID: "translator-c", Health: HealthHealthy, LastSeen: now},
+		{ID: "translator-sick", Health: HealthUnhealthy, LastSeen: now},
+		{ID: "translator-stale", Health: HealthHealthy, LastSeen: now.Add(-time.Hour)},
+	} {
+		info.Name = "translator"
+		info.Capabilities = []Capability{{Name: "translate"}}
+		if err := discovery.Register(context.Background(), info); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	return discovery
+}
+
+func TestServiceSelector_Strategies(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("round robin cycles healthy services", func(t *testing.T) {
+		selector := NewServiceSelector(newSelectorTestDiscovery(t, now))
+		var got []string
+		for i := 0; i < 6; i++ {
+			svc, err := selector.Select(ctx, "translate", SelectRoundRobin)
+			if err != nil {
+				t.Fatalf("Select failed: %v", err)
+			}
+			got = append(got, svc.ID)
+		}
+		want := []string{"translator-a", "translator-b", "translator-c", "translator-a", "translator-b", "translator-c"}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("Expected %v, got %v", want, got)
+			}
+		}
+	})
+
+	t.Run("least recently used prefers unused services", func(t *testing.T) {
+		selector := NewServiceSelector(newSelectorTestDiscovery(t, now))
+		clock := now
+		selector.now = func() time.Time { clock = clock.Add(time.Millisecond); return clock }
+
+		seen := map[string]int{}
+		for i := 0; i < 3; i++ {
+			svc, err := selector.Select(ctx, "translate", SelectLeastRecentlyUsed)
+			if err != nil {
+				t.Fatalf("Select failed: %v", err)
+			}
+			seen[svc.ID]++
+		}
+		if len(seen) != 3 {
+			t.Errorf("Expected each healthy service once, got %v", seen)
+		}
+	})
+
+	t.Run("random never picks excluded services", func(t *testing.T) {
+		selector := NewServiceSelector(newSelectorTestDiscovery(t, now))
+		for i := 0; i < 50; i++ {
+			svc, err := selector.Select(ctx, "translate", SelectRandom)
+			if err != nil {
+				t.Fatalf("Select failed: %v", err)
+			}
+			if svc.ID == "translator-sick" || svc.ID == "translator-stale" {
+				t.Fatalf("Selected excluded service %s", svc.ID)
+			}
+		}
+	})
+
+	t.Run("stale threshold is configurable", func(t *testing.T) {
+		selector := NewServiceSelector(newSelectorTestDiscovery(t, now), WithStaleAfter(2*time.Hour))
+		seen := map[string]bool{}
+		for i := 0; i < 4; i++ {
+			svc, _ := selector.Select(ctx, "translate", SelectRoundRobin)
+			seen[svc.ID] = true
+		}
+		if !seen["translator-stale"] {
+			t.Error("Expected translator-stale to be eligible with a 2h threshold")
+		}
+	})
+}
+
+func TestServiceSelector_Errors(t *testing.T) {
+	ctx := context.Background()
+	selector := NewServiceSelector(newSelectorTestDiscovery(t, time.Now()))
+
+	if _, err := selector.Select(ctx, "summarize", SelectRoundRobin); !errors.Is(err, ErrCapabilityNotFound) {
+		t.Errorf("Expected ErrCapabilityNotFound, got %v", err)
+	}
+	if _, err := selector.Select(ctx, "translate", SelectionStrategy("fastest")); !errors.Is(err, ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+	}
+}
+
+func TestServiceSelector_Concurrent(t *testing.T) {
+	selector := NewServiceSelector(newSelectorTestDiscovery(t, time.Now()))
+	done := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for j := 0; j < 50; j++ {
+				_, _ = selector.Select(context.Background(), "translate", SelectLeastRecentlyUsed)
+				_, _ = selector.Select(context.Background(), "translate", SelectRandom)
+			}
+		}()
+	}
+	for i := 0; i < 8; i++ {
+		<-done
+	}
+}
@@ -0,0 +1,203 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// CapabilityEndpoint is a capability name and the HTTP path that serves it
+type CapabilityEndpoint struct {
+	Name     string `json:"name"`
+	Endpoint string `json:"endpoint"`
+}
+
+// StartupInventory describes a component as it is about to start serving.
+// Framework.Run logs it as a single structured entry so deployments can check
+// what a component exposes without scraping free-form output.
+type StartupInventory struct {
+	Name         string               `json:"name"`
+	ID           string               `json:"id"`
+	Type         ComponentType        `json:"type"`
+	Address      string               `json:"address"`
+	Capabilities []CapabilityEndpoint `json:"capabilities"`
+	// Endpoints lists the framework's own endpoints (health, capability listing)
+	Endpoints []string `json:"endpoints"`
+	// Discovery is "enabled (<provider>)", "mock", "pending" while the
+	// background connection retry runs, or "disabled"
+	Discovery string `json:"discovery"`
+	// AIProvider is the configured provider, "mock", the client type for
+	// clients created outside the config, or "disabled"
+	AIProvider string `json:"ai_provider"`
+	// Telemetry is "enabled" once a metrics registry is installed, else "disabled"
+	Telemetry       string `json:"telemetry"`
+	DevelopmentMode bool   `json:"development_mode"`
+}
+
+// StartupInventory returns the inventory of the framework's component. Call it
+// after the component is initialized so capability endpoints are assigned.
+func (f *Framework) StartupInventory() *StartupInventory {
+	inventory := &StartupInventory{
+		Name:            f.component.GetName(),
+		ID:              f.component.GetID(),
+		Type:            f.component.GetType(),
+		Address:         listenAddress(f.config),
+		Capabilities:    []CapabilityEndpoint{},
+		Endpoints:       []string{},
+		DevelopmentMode: f.config.Development.Enabled,
+	}
+
+	for _, cap := range f.component.GetCapabilities() {
+		inventory.Capabilities = append(inventory.Capabilities, CapabilityEndpoint{
+			Name:     cap.Name,
+			Endpoint: cap.Endpoint,
+		})
+	}
+
+	if f.config.HTTP.EnableHealthCheck {
+		inventory.Endpoints = append(inventory.Endpoints, f.config.HTTP.HealthCheckPath, LivenessPath, ReadinessPath)
+	}
+	inventory.Endpoints = append(inventory.Endpoints, "/api/capabilities", OpenAPIPath)
+
+	var registry Registry
+	var ai AIClient
+	switch agent, tool := componentBases(f.component); {
+	case agent != nil:
+		// Discovery may be swapped in by the background connection retry
+		agent.mu.RLock()
+		if agent.Discovery != nil {
+			registry = agent.Discovery
+		}
+		ai = agent.AI
+		agent.mu.RUnlock()
+	case tool != nil:
+		tool.mu.RLock()
+		registry = tool.Registry
+		ai = tool.AI
+		tool.mu.RUnlock()
+	}
+
+	switch {
+	case registry != nil && f.config.Development.MockDiscovery:
+		inventory.Discovery = "mock"
+	case registry != nil:
+		inventory.Discovery = fmt.Sprintf("enabled (%s)", f.config.Discovery.Provider)
+	case f.config.Discovery.Enabled:
+		inventory.Discovery = "pending"
+	default:
+		inventory.Discovery = "disabled"
+	}
+
+	switch {
+	case ai == nil:
+		inventory.AIProvider = "disabled"
+	case f.config.Development.MockAI:
+		inventory.AIProvider = "mock"
+	case f.config.AI.Enabled:
+		inventory.AIProvider = f.config.AI.Provider
+	default:
+		inventory.AIProvider = fmt.Sprintf("%T", ai)
+	}
+
+	inventory.Telemetry = "disabled"
+	if GetGlobalMetricsRegistry() != nil {
+		inventory.Telemetry = "enabled"
+	}
+
+	return inventory
+}
+
+// logStartupInventory logs the inventory as structured fields and, in
+// development mode, also prints a human-readable banner to out
+func (f *Framework) logStartupInventory(ctx context.Context, out io.Writer) {
+	inventory := f.StartupInventory()
+
+	if f.config.logger != nil {
+		capabilities := make([]string, 0, len(inventory.Capabilities))
+		for _, cap := range inventory.Capabilities {
+			capabilities = append(capabilities, cap.Name+" "+cap.Endpoint)
+		}
+		f.config.logger.InfoWithContext(ctx, "Component starting", map[string]interface{}{
+			"operation":        "startup_inventory",
+			"name":             inventory.Name,
+			"id":               inventory.ID,
+			"type":             string(inventory.Type),
+			"address":          inventory.Address,
+			"capabilities":     capabilities,
+			"endpoints":        inventory.Endpoints,
+			"discovery":        inventory.Discovery,
+			"ai_provider":      inventory.AIProvider,
+			"telemetry":        inventory.Telemetry,
+			"development_mode": inventory.DevelopmentMode,
+		})
+	}
+
+	if inventory.DevelopmentMode && out != nil {
+		_, _ = fmt.Fprint(out, inventory.Banner())
+	}
+}
+
+// Banner formats the inventory for a terminal
+func (i *StartupInventory) Banner() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "\n🚀 %s %s (%s)\n", i.Type, i.Name, i.ID)
+	fmt.Fprintf(&b, "   Listening on %s\n", i.Address)
+	fmt.Fprintf(&b, "\n📍 Endpoints available:\n")
+	for _, cap := range i.Capabilities {
+		fmt.Fprintf(&b, "   POST %-30s %s\n", cap.Endpoint, cap.Name)
+	}
+	for _, endpoint := range i.Endpoints {
+		fmt.Fprintf(&b, "   GET  %s\n", endpoint)
+	}
+	fmt.Fprintf(&b, "\n   Discovery: %s\n", i.Discovery)
+	fmt.Fprintf(&b, "   AI:        %s\n", i.AIProvider)
+	fmt.Fprintf(&b, "   Telemetry: %s\n\n", i.Telemetry)
+
+	return b.String()
+}
+
+// listenAddress mirrors the address the component's Start binds to
+func listenAddress(config *Config) string {
+	host := config.Address
+	if host == "" {
+		host = "0.0.0.0"
+	}
+	return fmt.Sprintf("%s:%d", host, config.Port)
+}
+
+// componentBases returns the BaseAgent or BaseTool behind component, looking
+// through embedded fields the same way applyConfigToComponent does
+func componentBases(component HTTPComponent) (*BaseAgent, *BaseTool) {
+	switch base := component.(type) {
+	case *BaseAgent:
+		return base, nil
+	case *BaseTool:
+		return nil, base
+	}
+
+	v := reflect.ValueOf(component)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, nil
+	}
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !field.CanInterface() {
+			continue
+		}
+		switch base := field.Interface().(type) {
+		case *BaseAgent:
+			if base != nil {
+				return base, nil
+			}
+		case *BaseTool:
+			if base != nil {
+				return nil, base
+			}
+		}
+	}
+	return nil, nil
+}
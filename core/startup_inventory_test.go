@@ -0,0 +1,98 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFramework_StartupInventory(t *testing.T) {
+	t.Setenv("GOMIND_DEV_MODE", "false")
+
+	tool := NewTool("inventory-tool")
+	tool.RegisterCapability(Capability{Name: "lookup", Description: "Looks things up"})
+
+	logger := &MockLogger{}
+	framework, err := NewFramework(tool,
+		WithName("inventory-tool"),
+		WithAddress("127.0.0.1"),
+		WithPort(9191),
+		WithMockDiscovery(true),
+		WithLogger(logger),
+	)
+	if err != nil {
+		t.Fatalf("NewFramework() error = %v", err)
+	}
+	if err := tool.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	inventory := framework.StartupInventory()
+	if inventory.Name != "inventory-tool" || inventory.Type != ComponentTypeTool {
+		t.Errorf("Unexpected identity: %s (%s)", inventory.Name, inventory.Type)
+	}
+	if inventory.Address != "127.0.0.1:9191" {
+		t.Errorf("Address = %q, want 127.0.0.1:9191", inventory.Address)
+	}
+	if len(inventory.Capabilities) != 1 || inventory.Capabilities[0].Endpoint != "/api/capabilities/lookup" {
+		t.Errorf("Unexpected capabilities: %+v", inventory.Capabilities)
+	}
+	if inventory.Discovery != "mock" {
+		t.Errorf("Discovery = %q, want mock", inventory.Discovery)
+	}
+	if inventory.AIProvider != "disabled" {
+		t.Errorf("AIProvider = %q, want disabled", inventory.AIProvider)
+	}
+
+	var banner bytes.Buffer
+	framework.logStartupInventory(context.Background(), &banner)
+
+	if banner.Len() != 0 {
+		t.Errorf("Expected no banner outside development mode, got %q", banner.String())
+	}
+
+	var entry *LogEntry
+	for i := range logger.entries {
+		if logger.entries[i].Fields["operation"] == "startup_inventory" {
+			entry = &logger.entries[i]
+		}
+	}
+	if entry == nil {
+		t.Fatal("Expected a startup_inventory log entry")
+	}
+	if entry.Fields["address"] != "127.0.0.1:9191" || entry.Fields["discovery"] != "mock" {
+		t.Errorf("Unexpected log fields: %v", entry.Fields)
+	}
+	if caps, ok := entry.Fields["capabilities"].([]string); !ok || len(caps) != 1 || caps[0] != "lookup /api/capabilities/lookup" {
+		t.Errorf("Unexpected logged capabilities: %v", entry.Fields["capabilities"])
+	}
+}
+
+func TestFramework_StartupBannerInDevelopmentMode(t *testing.T) {
+	agent := NewBaseAgent("banner-agent")
+	agent.RegisterCapability(Capability{Name: "plan", Description: "Plans things"})
+
+	framework, err := NewFramework(agent,
+		WithName("banner-agent"),
+		WithPort(9292),
+		WithDevelopmentMode(true),
+		WithLogger(&MockLogger{}),
+	)
+	if err != nil {
+		t.Fatalf("NewFramework() error = %v", err)
+	}
+	if err := agent.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	var banner bytes.Buffer
+	framework.logStartupInventory(context.Background(), &banner)
+
+	output := banner.String()
+	for _, want := range []string{"agent banner-agent", "localhost:9292", "/api/capabilities/plan", "/health", "Discovery: disabled"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Banner missing %q:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultStorageStatsTopN is the number of largest keys reported by StorageStats
+const DefaultStorageStatsTopN = 10
+
+// StorageStats summarizes how much storage the keys matching a pattern consume.
+// Operators use it to find which agent's data is bloating the backend.
+type StorageStats struct {
+	Pattern      string    `json:"pattern"`
+	KeyCount     int64     `json:"key_count"`
+	TotalBytes   int64     `json:"total_bytes"`
+	AverageBytes int64     `json:"average_bytes"`
+	MaxBytes     int64     `json:"max_bytes"`
+	LargestKeys  []KeySize `json:"largest_keys,omitempty"`
+
+	// SizeSource describes how sizes were measured:
+	//   - "memory_usage": Redis MEMORY USAGE (includes Redis overhead)
+	//   - "value_length": serialized value length in bytes
+	SizeSource string `json:"size_source"`
+}
+
+// KeySize is the measured size of a single key
+type KeySize struct {
+	Key   string `json:"key"`
+	Bytes int64  `json:"bytes"`
+}
+
+// StorageStatsProvider is implemented by Memory backends that can report
+// storage consumption for capacity planning. Patterns use Redis glob syntax
+// (*, ?, [abc]); an empty pattern matches all keys.
+type StorageStatsProvider interface {
+	StorageStats(ctx context.Context, pattern string) (StorageStats, error)
+}
+
+// storageStatsCollector accumulates key sizes and tracks the top-N largest keys
+type storageStatsCollector struct {
+	stats StorageStats
+	topN  int
+}
+
+func newStorageStatsCollector(pattern, source string) *storageStatsCollector {
+	return &storageStatsCollector{
+		stats: StorageStats{Pattern: pattern, SizeSource: source},
+		topN:  DefaultStorageStatsTopN,
+	}
+}
+
+func (c *storageStatsCollector) add(key string, bytes int64) {
+	c.stats.KeyCount++
+	c.stats.TotalBytes += bytes
+	if bytes > c.stats.MaxBytes {
+		c.stats.MaxBytes = bytes
+	}
+
+	largest := c.stats.LargestKeys
+	if len(largest) < c.topN {
+		largest = append(largest, KeySize{Key: key, Bytes: bytes})
+	} else if bytes > largest[len(largest)-1].Bytes {
+		largest[len(largest)-1] = KeySize{Key: key, Bytes: bytes}
+	} else {
+		return
+	}
+	sort.SliceStable(largest, func(i, j int) bool {
+		return largest[i].Bytes > largest[j].Bytes
+	})
+	c.stats.LargestKeys = largest
+}
+
+func (c *storageStatsCollector) result() StorageStats {
+	if c.stats.KeyCount > 0 {
+		c.stats.AverageBytes = c.stats.TotalBytes / c.stats.KeyCount
+	}
+	return c.stats
+}
+
+// globMatcher compiles a Redis-style glob pattern into a matcher function
+func globMatcher(pattern string) func(string) bool {
+	if pattern == "" || pattern == "*" {
+		return func(string) bool { return true }
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; ch {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta(pattern[i:]))
+				i = len(pattern)
+				continue
+			}
+			class := pattern[i+1 : i+end]
+			if strings.HasPrefix(class, "^") {
+				class = "^" + regexp.QuoteMeta(class[1:])
+			} else {
+				class = regexp.QuoteMeta(class)
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\-`, "-") + "]")
+			i += end
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(pattern[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return func(key string) bool { return key == pattern }
+	}
+	return re.MatchString
+}
@@ -0,0 +1,156 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestGlobMatcher(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		want    bool
+	}{
+		{"", "anything", true},
+		{"*", "anything", true},
+		{"agent:*", "agent:conv:1", true},
+		{"agent:*", "tool:conv:1", false},
+		{"agent:?", "agent:1", true},
+		{"agent:?", "agent:12", false},
+		{"agent:[ab]", "agent:a", true},
+		{"agent:[ab]", "agent:c", false},
+		{"agent:[^ab]", "agent:c", true},
+		{"a.b", "a.b", true},
+		{"a.b", "axb", false},
+		{`a\*`, "a*", true},
+		{`a\*`, "ab", false},
+	}
+	for _, tt := range tests {
+		if got := globMatcher(tt.pattern)(tt.key); got != tt.want {
+			t.Errorf("globMatcher(%q)(%q) = %v, want %v", tt.pattern, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestStorageStatsCollector_TopN(t *testing.T) {
+	c := newStorageStatsCollector("*", "value_length")
+	for i := 1; i <= DefaultStorageStatsTopN+5; i++ {
+		c.add(fmt.Sprintf("key-%d", i), int64(i*10))
+	}
+	stats := c.result()
+
+	if stats.KeyCount != int64(DefaultStorageStatsTopN+5) {
+		t.Errorf("KeyCount = %d", stats.KeyCount)
+	}
+	if len(stats.LargestKeys) != DefaultStorageStatsTopN {
+		t.Fatalf("Expected %d largest keys, got %d", DefaultStorageStatsTopN, len(stats.LargestKeys))
+	}
+	if stats.LargestKeys[0].Key != "key-15" || stats.MaxBytes != 150 {
+		t.Errorf("Expected key-15 (150 bytes) first, got %+v", stats.LargestKeys[0])
+	}
+	if stats.AverageBytes != stats.TotalBytes/stats.KeyCount {
+		t.Errorf("AverageBytes = %d", stats.AverageBytes)
+	}
+}
+
+func TestMemoryStore_StorageStats(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	_ = store.Set(ctx, "research:conv:1", strings.Repeat("a", 100), 0)
+	_ = store.Set(ctx, "research:conv:2", strings.Repeat("b", 300), 0)
+	_ = store.Set(ctx, "weather:cache:1", strings.Repeat("c", 50), 0)
+	_ = store.Set(ctx, "research:expired", strings.Repeat("d", 999), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	stats, err := store.StorageStats(ctx, "research:*")
+	if err != nil {
+		t.Fatalf("StorageStats() error = %v", err)
+	}
+	if stats.KeyCount != 2 || stats.TotalBytes != 400 || stats.AverageBytes != 200 || stats.MaxBytes != 300 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if stats.LargestKeys[0].Key != "research:conv:2" {
+		t.Errorf("Expected largest key research:conv:2, got %s", stats.LargestKeys[0].Key)
+	}
+}
+
+func TestInMemoryStore_StorageStats(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryStore()
+	_ = store.Set(ctx, "a", "12345", 0)
+	_ = store.Set(ctx, "b", "123", 0)
+
+	stats, err := store.StorageStats(ctx, "")
+	if err != nil {
+		t.Fatalf("StorageStats() error = %v", err)
+	}
+	if stats.KeyCount != 2 || stats.TotalBytes != 8 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestRedisMemory(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	memory, err := NewRedisMemory("redis://" + mr.Addr())
+	if err != nil {
+		t.Fatalf("NewRedisMemory() error = %v", err)
+	}
+	defer func() { _ = memory.Close() }()
+
+	ctx := context.Background()
+
+	t.Run("basic operations", func(t *testing.T) {
+		value, err := memory.Get(ctx, "missing")
+		if err != nil || value != "" {
+			t.Errorf("Get(missing) = %q, %v; want empty, nil", value, err)
+		}
+		if err := memory.Set(ctx, "k", "v", time.Minute); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		if value, _ := memory.Get(ctx, "k"); value != "v" {
+			t.Errorf("Get(k) = %q, want v", value)
+		}
+		if exists, _ := memory.Exists(ctx, "k"); !exists {
+			t.Error("Expected key to exist")
+		}
+		if err := memory.Delete(ctx, "k"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if exists, _ := memory.Exists(ctx, "k"); exists {
+			t.Error("Expected key to be deleted")
+		}
+	})
+
+	t.Run("storage stats", func(t *testing.T) {
+		_ = memory.Set(ctx, "research:conv:1", strings.Repeat("a", 100), 0)
+		_ = memory.Set(ctx, "research:conv:2", strings.Repeat("b", 300), 0)
+		_ = memory.Set(ctx, "weather:cache:1", strings.Repeat("c", 50), 0)
+
+		stats, err := memory.StorageStats(ctx, "research:*")
+		if err != nil {
+			t.Fatalf("StorageStats() error = %v", err)
+		}
+		if stats.KeyCount != 2 {
+			t.Errorf("KeyCount = %d, want 2", stats.KeyCount)
+		}
+		if len(stats.LargestKeys) == 0 || stats.LargestKeys[0].Key != "research:conv:2" {
+			t.Errorf("Expected research:conv:2 to be largest (namespace stripped), got %+v", stats.LargestKeys)
+		}
+		if stats.TotalBytes < 400 {
+			t.Errorf("TotalBytes = %d, want >= 400", stats.TotalBytes)
+		}
+		if stats.SizeSource != "memory_usage" && stats.SizeSource != "value_length" {
+			t.Errorf("Unexpected size source %q", stats.SizeSource)
+		}
+	})
+}
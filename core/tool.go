@@ -51,6 +51,15 @@ type BaseTool struct {
 
 	// Mutex for thread-safe Registry access during background retry
 	mu sync.RWMutex
+
+	// Makes Deregister idempotent across Shutdown and the shutdown hook
+	deregistration deregistration
+
+	// Lifecycle state reported by /health/ready
+	readiness readiness
+
+	// Per-caller token buckets for capability rate limits
+	rateLimiter rateLimiter
 }
 
 // NewTool creates a new tool with default implementations
@@ -168,6 +177,7 @@ func (t *BaseTool) Initialize(ctx context.Context) error {
 
 							// Update to new registry
 							t.Registry = newRegistry
+							t.readiness.registered.Store(true)
 							t.Logger.Info("Registry reference updated", map[string]interface{}{
 								"tool_id": t.ID,
 							})
@@ -215,12 +225,22 @@ func (t *BaseTool) Initialize(ctx context.Context) error {
 			Health:       HealthHealthy,
 			Metadata:     BuildServiceMetadata(t.Config),
 		}
+		if redisRegistry, ok := t.Registry.(*RedisRegistry); ok && t.Config != nil {
+			redisRegistry.SetHeartbeatJitter(t.Config.Discovery.HeartbeatJitter)
+			redisRegistry.SetRegistrationStagger(t.Config.Discovery.RegistrationStagger)
+			redisRegistry.StaggerRegistration(ctx)
+		}
+
 		if err := t.Registry.Register(ctx, info); err != nil {
 			return fmt.Errorf("failed to register tool: %w", err)
 		}
+		t.readiness.registered.Store(true)
 
 		// Start heartbeat to keep registration alive (Redis-specific)
 		if redisRegistry, ok := t.Registry.(*RedisRegistry); ok {
+			if t.Config != nil {
+				redisRegistry.SetRegistrationCheckInterval(t.Config.Discovery.RegistrationCheckInterval)
+			}
 			redisRegistry.StartHeartbeat(ctx, t.ID)
 			t.Logger.Info("Started heartbeat for tool registration", map[string]interface{}{
 				"tool_id":      t.ID,
@@ -228,6 +248,13 @@ func (t *BaseTool) Initialize(ctx context.Context) error {
 				"interval_sec": int(redisRegistry.ttl.Seconds() / 2),
 				"ttl_sec":      int(redisRegistry.ttl.Seconds()),
 			})
+		} else if composite, ok := t.Registry.(*CompositeDiscovery); ok {
+			composite.StartHeartbeat(ctx, t.ID)
+			t.Logger.Info("Started heartbeat for tool registration", map[string]interface{}{
+				"tool_id":   t.ID,
+				"tool_name": t.Name,
+				"backends":  len(composite.Backends()),
+			})
 		}
 	} else {
 		t.Logger.Warn("Tool running without service registry", map[string]interface{}{
@@ -237,6 +264,9 @@ func (t *BaseTool) Initialize(ctx context.Context) error {
 		})
 	}
 
+	// Ready once registered, when discovery is in use
+	t.readiness.markInitialized(t.Registry != nil || (t.Config != nil && t.Config.Discovery.Enabled))
+
 	t.Logger.Info("Tool initialization completed", map[string]interface{}{
 		"id":                 t.ID,
 		"name":               t.Name,
@@ -302,16 +332,27 @@ func (t *BaseTool) RegisterCapability(cap Capability) {
 		})
 	}
 
+	// Typed capabilities always respond with the result envelope
+	if cap.Handler == nil && cap.Execute != nil {
+		cap.ResultEnvelope = true
+	}
+
 	t.Capabilities = append(t.Capabilities, cap)
 
 	// Register HTTP endpoint (same pattern as Agent)
+	var handler http.HandlerFunc
 	if cap.Handler != nil {
 		// Use custom handler if provided
-		t.mux.HandleFunc(cap.Endpoint, cap.Handler)
+		handler = cap.Handler
+	} else if cap.Execute != nil {
+		// Typed handler: wrap the returned data in a ToolResponse envelope
+		handler = newResultHandler(cap, t.Logger)
 	} else {
-		// Use generic handler with telemetry and logging
-		t.mux.HandleFunc(cap.Endpoint, t.handleCapabilityRequest(cap))
+		// Use generic handler with logging
+		handler = t.handleCapabilityRequest(cap)
 	}
+	t.mux.HandleFunc(cap.Endpoint, instrumentCapability(cap, "tool", func() Telemetry { return t.Telemetry },
+		rateLimitHandler(&t.rateLimiter, cap, func() *Config { return t.Config }, handler)))
 
 	// Track this pattern to prevent duplicates
 	t.registeredPatterns[cap.Endpoint] = true
@@ -324,23 +365,23 @@ func (t *BaseTool) RegisterCapability(cap Capability) {
 	})
 }
 
+// ContextLogger returns the tool's logger bound to ctx, so every line it
+// writes carries the trace_id and span_id of the active request span.
+func (t *BaseTool) ContextLogger(ctx context.Context) Logger {
+	return LoggerWithContext(t.Logger, ctx)
+}
+
 // handleCapabilityRequest creates an HTTP handler for a capability.
 // This provides a generic handler for capabilities without custom handlers.
 func (t *BaseTool) handleCapabilityRequest(cap Capability) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		// Start telemetry span if available
-		if t.Telemetry != nil {
-			var span Span
-			_, span = t.Telemetry.StartSpan(ctx, fmt.Sprintf("capability.%s", cap.Name))
-			defer span.End()
-			span.SetAttribute("capability.name", cap.Name)
-			span.SetAttribute("component.type", "tool")
-		}
+		// The capability span is started by instrumentCapability
+		logger := t.ContextLogger(ctx)
 
 		// Log request
-		t.Logger.Info("Handling capability request", map[string]interface{}{
+		logger.Info("Handling capability request", map[string]interface{}{
 			"capability": cap.Name,
 			"method":     r.Method,
 			"tool":       t.Name,
@@ -359,7 +400,7 @@ func (t *BaseTool) handleCapabilityRequest(cap Capability) http.HandlerFunc {
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(response); err != nil {
 			// Log error but response is already partially written
-			t.Logger.Error("Failed to encode response", map[string]interface{}{
+			logger.Error("Failed to encode response", map[string]interface{}{
 				"error":      err,
 				"error_type": fmt.Sprintf("%T", err),
 				"tool_id":    t.ID,
@@ -382,7 +423,7 @@ func (t *BaseTool) handleSchemaRequest(cap Capability) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Only support GET requests for schemas
 		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			WriteError(w, r, &APIError{Code: "METHOD_NOT_ALLOWED", Message: "schema endpoints only support GET", HTTPStatus: http.StatusMethodNotAllowed})
 			return
 		}
 
@@ -488,40 +529,115 @@ func (t *BaseTool) setupStandardEndpoints() {
 		t.registeredPatterns[capabilitiesPath] = true
 	}
 
-	// Add health endpoint if enabled (same as Agent)
+	// Add OpenAPI catalog of the registered capabilities
+	if !t.registeredPatterns[OpenAPIPath] {
+		t.mux.HandleFunc(OpenAPIPath, openAPIHandler(t.Name, t.GetCapabilities, t.Logger))
+		t.registeredPatterns[OpenAPIPath] = true
+	}
+
+	// Add Prometheus metrics endpoint if configured
+	if t.Config != nil && t.Config.Telemetry.PrometheusPath != "" && !t.registeredPatterns[t.Config.Telemetry.PrometheusPath] {
+		t.mux.HandleFunc(t.Config.Telemetry.PrometheusPath, metricsHandler)
+		t.registeredPatterns[t.Config.Telemetry.PrometheusPath] = true
+	}
+
+	// Add liveness and readiness endpoints if enabled (same as Agent)
 	if t.Config != nil && t.Config.HTTP.EnableHealthCheck {
 		healthPath := t.Config.HTTP.HealthCheckPath
 		if healthPath == "" {
 			healthPath = "/health"
 		}
-		if !t.registeredPatterns[healthPath] {
-			t.mux.HandleFunc(healthPath, func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusOK)
-				if err := json.NewEncoder(w).Encode(map[string]string{
-					"status": "healthy",
-					"type":   "tool",
-					"name":   t.Name,
-					"id":     t.ID,
-				}); err != nil {
-					// Log error but response is already partially written
-					t.Logger.Error("Failed to encode health response", map[string]interface{}{
-						"error":              err,
-						"error_type":         fmt.Sprintf("%T", err),
-						"tool_id":            t.ID,
-						"request_method":     r.Method,
-						"request_path":       r.URL.Path,
-						"request_remote":     r.RemoteAddr,
-						"capabilities_count": len(t.Capabilities),
-						"user_agent":         r.Header.Get("User-Agent"),
-						"content_length":     r.ContentLength,
-					})
-				}
-			})
-			t.registeredPatterns[healthPath] = true
+		for _, path := range []string{healthPath, LivenessPath} {
+			if !t.registeredPatterns[path] {
+				t.mux.HandleFunc(path, t.handleLiveness)
+				t.registeredPatterns[path] = true
+			}
+		}
+		if !t.registeredPatterns[ReadinessPath] {
+			t.mux.HandleFunc(ReadinessPath, readinessHandler(&t.readiness, func() *Config { return t.Config }, t.Logger))
+			t.registeredPatterns[ReadinessPath] = true
 		}
 	}
 }
 
+// handleLiveness reports that the tool process is alive
+func (t *BaseTool) handleLiveness(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]string{
+		"status": "healthy",
+		"type":   "tool",
+		"name":   t.Name,
+		"id":     t.ID,
+	}); err != nil {
+		// Log error but response is already partially written
+		t.Logger.Error("Failed to encode health response", map[string]interface{}{
+			"error":              err,
+			"error_type":         fmt.Sprintf("%T", err),
+			"tool_id":            t.ID,
+			"request_method":     r.Method,
+			"request_path":       r.URL.Path,
+			"request_remote":     r.RemoteAddr,
+			"capabilities_count": len(t.Capabilities),
+			"user_agent":         r.Header.Get("User-Agent"),
+			"content_length":     r.ContentLength,
+		})
+	}
+}
+
+// buildHandler wraps the mux with the standard middleware stack
+func (t *BaseTool) buildHandler() http.Handler {
+	// Create handler with middleware stack
+	// Order (innermost to outermost): Handler -> Recovery -> Logging -> CORS -> Custom Middleware -> Request ID
+	var handler http.Handler = t.mux
+
+	// Wrap with panic recovery middleware (innermost - catches panics from handler)
+	// unless disabled for debugging
+	if !t.Config.HTTP.DisablePanicRecovery {
+		handler = RecoveryMiddleware(t.Logger)(handler)
+	}
+
+	// Add request/response logging middleware
+	handler = LoggingMiddleware(t.Logger, t.Config.Development.Enabled)(handler)
+
+	// Restore request metadata sent by the calling component
+	handler = RequestMetadataMiddleware(t.Config.HTTP.TrustedMetadataKeys...)(handler)
+
+	// Add CORS middleware if enabled
+	if t.Config.HTTP.CORS.Enabled {
+		handler = CORSMiddleware(&t.Config.HTTP.CORS)(handler)
+	}
+
+	// Apply custom middleware (outermost - applied last, executed first)
+	// This enables application-level injection of telemetry middleware (e.g., tracing)
+	// without core importing telemetry - following framework design principles.
+	// Middleware is applied in reverse order so first middleware in the list is outermost.
+	for i := len(t.Config.HTTP.Middleware) - 1; i >= 0; i-- {
+		handler = t.Config.HTTP.Middleware[i](handler)
+	}
+
+	if len(t.Config.HTTP.Middleware) > 0 {
+		t.Logger.Info("Custom middleware applied", map[string]interface{}{
+			"middleware_count": len(t.Config.HTTP.Middleware),
+		})
+	}
+
+	// Assign the request ID first so every layer can log it
+	handler = RequestIDMiddleware()(handler)
+
+	return handler
+}
+
+// Handler returns the tool's HTTP handler with standard endpoints and
+// middleware, as served by Start. It lets tests serve the tool with
+// httptest.Server without binding a port.
+func (t *BaseTool) Handler() http.Handler {
+	if t.Config == nil {
+		t.Config = DefaultConfig()
+	}
+	t.setupStandardEndpoints()
+	return t.buildHandler()
+}
+
 // Start starts the HTTP server for the tool
 func (t *BaseTool) Start(ctx context.Context, port int) error {
 	// Apply configuration precedence: explicit parameter > config > default
@@ -571,34 +687,7 @@ func (t *BaseTool) Start(ctx context.Context, port int) error {
 		})
 	}
 
-	// Create handler with middleware stack
-	// Order (innermost to outermost): Handler -> Recovery -> Logging -> CORS -> Custom Middleware
-	var handler http.Handler = t.mux
-
-	// Always wrap with panic recovery middleware (innermost - catches panics from handler)
-	handler = RecoveryMiddleware(t.Logger)(handler)
-
-	// Add request/response logging middleware
-	handler = LoggingMiddleware(t.Logger, t.Config.Development.Enabled)(handler)
-
-	// Add CORS middleware if enabled
-	if t.Config.HTTP.CORS.Enabled {
-		handler = CORSMiddleware(&t.Config.HTTP.CORS)(handler)
-	}
-
-	// Apply custom middleware (outermost - applied last, executed first)
-	// This enables application-level injection of telemetry middleware (e.g., tracing)
-	// without core importing telemetry - following framework design principles.
-	// Middleware is applied in reverse order so first middleware in the list is outermost.
-	for i := len(t.Config.HTTP.Middleware) - 1; i >= 0; i-- {
-		handler = t.Config.HTTP.Middleware[i](handler)
-	}
-
-	if len(t.Config.HTTP.Middleware) > 0 {
-		t.Logger.Info("Custom middleware applied", map[string]interface{}{
-			"middleware_count": len(t.Config.HTTP.Middleware),
-		})
-	}
+	handler := t.buildHandler()
 
 	t.server = &http.Server{
 		Addr:              addr,
@@ -659,18 +748,13 @@ func (t *BaseTool) Start(ctx context.Context, port int) error {
 
 // Shutdown gracefully shuts down the tool
 func (t *BaseTool) Shutdown(ctx context.Context) error {
+	t.readiness.draining.Store(true)
 	t.Logger.Info("Shutting down tool", map[string]interface{}{
 		"name": t.Name,
 	})
 
-	// Unregister from registry
-	if t.Registry != nil {
-		if err := t.Registry.Unregister(ctx, t.ID); err != nil {
-			t.Logger.Error("Failed to unregister", map[string]interface{}{
-				"error": err.Error(),
-			})
-		}
-	}
+	// Unregister from registry (logged by Deregister)
+	_ = t.Deregister(ctx)
 
 	// Shutdown HTTP server
 	if t.server != nil {
@@ -114,6 +114,17 @@ type ToolResponse struct {
 
 	// Error contains structured error information when Success is false
 	Error *ToolError `json:"error,omitempty"`
+
+	// Metadata carries optional response annotations (source, cache status, ...)
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// DurationMs is how long the capability took to execute
+	DurationMs int64 `json:"duration_ms,omitempty"`
+
+	// TraceID and RequestID identify the failed request in traces and the
+	// debug stores. The framework sets them on error responses.
+	TraceID   string `json:"trace_id,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // HTTPStatusForCategory returns the appropriate HTTP status code for an error category.
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// TypedCapabilityFunc implements a capability with typed request and
+// response values. See RegisterTypedCapability.
+type TypedCapabilityFunc[In, Out any] func(ctx context.Context, input In) (Out, error)
+
+// BadRequestError reports that a capability rejected its input. The caller
+// gets a 400 with an INVALID_REQUEST envelope.
+type BadRequestError struct {
+	Message string
+}
+
+// BadRequest returns a BadRequestError with a formatted message
+func BadRequest(format string, args ...interface{}) error {
+	return &BadRequestError{Message: fmt.Sprintf(format, args...)}
+}
+
+// Error implements the error interface
+func (e *BadRequestError) Error() string {
+	return e.Message
+}
+
+// CapabilityRegistrar is implemented by BaseAgent and BaseTool
+type CapabilityRegistrar interface {
+	RegisterCapability(cap Capability)
+}
+
+// RegisterTypedCapability registers fn as the capability name on an agent or
+// tool, without hand-written JSON handling:
+//
+//	type ForecastRequest struct {
+//	    City string `json:"city" desc:"City name"`
+//	    Days int    `json:"days,omitempty"`
+//	}
+//
+//	core.RegisterTypedCapability(agent, "forecast",
+//	    func(ctx context.Context, req ForecastRequest) (Forecast, error) {
+//	        if req.City == "" {
+//	            return Forecast{}, core.BadRequest("city is required")
+//	        }
+//	        return lookup(ctx, req)
+//	    })
+//
+// See TypedCapability for the request and response handling. Use
+// TypedCapability directly to set a description or endpoint.
+func RegisterTypedCapability[In, Out any](component CapabilityRegistrar, name string, fn TypedCapabilityFunc[In, Out]) {
+	component.RegisterCapability(TypedCapability(Capability{Name: name}, fn))
+}
+
+// TypedCapability returns cap with a Handler that decodes the JSON request
+// body into In, calls fn and writes Out as the data of a ToolResponse
+// envelope. An empty body leaves In at its zero value.
+//
+// Errors map to HTTP statuses as follows:
+//   - an undecodable body or a *BadRequestError: 400, INVALID_REQUEST
+//   - an *APIError: its Status and Code
+//   - a *ToolError: the status for its Category (see HTTPStatusForCategory)
+//   - a context deadline: 504, TIMEOUT
+//   - any other error: 500, INTERNAL_ERROR
+//
+// InputSummary and OutputSummary are filled from In and Out when they are
+// structs and cap does not set them, so the capability gets a schema
+// endpoint and AI planners get field hints.
+func TypedCapability[In, Out any](cap Capability, fn TypedCapabilityFunc[In, Out]) Capability {
+	if cap.InputSummary == nil {
+		cap.InputSummary = SchemaSummaryOf(new(In))
+	}
+	if cap.OutputSummary == nil {
+		cap.OutputSummary = SchemaSummaryOf(new(Out))
+	}
+	cap.ResultEnvelope = true
+	cap.Handler = typedCapabilityHandler(fn)
+	return cap
+}
+
+// typedCapabilityHandler adapts fn into an HTTP handler
+func typedCapabilityHandler[In, Out any](fn TypedCapabilityFunc[In, Out]) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		metadata := make(map[string]interface{})
+		ctx := context.WithValue(r.Context(), resultMetadataKey{}, metadata)
+
+		var input In
+		if r.Body != nil && r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+				writeTypedCapabilityError(w, r, start, BadRequest("invalid JSON request body: %v", err))
+				return
+			}
+		}
+
+		output, err := fn(ctx, input)
+		if err != nil {
+			writeTypedCapabilityError(w, r, start, err)
+			return
+		}
+
+		response := &ToolResponse{
+			Success:    true,
+			Data:       output,
+			DurationMs: time.Since(start).Milliseconds(),
+		}
+		if len(metadata) > 0 {
+			response.Metadata = metadata
+		}
+		writeToolResponse(w, http.StatusOK, response)
+	}
+}
+
+// writeTypedCapabilityError writes err as an error envelope
+func writeTypedCapabilityError(w http.ResponseWriter, r *http.Request, start time.Time, err error) {
+	writeErrorResponse(w, r, &ToolResponse{DurationMs: time.Since(start).Milliseconds()}, err)
+}
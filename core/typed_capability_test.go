@@ -0,0 +1,113 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type typedForecastRequest struct {
+	City string `json:"city" desc:"City name"`
+	Days int    `json:"days,omitempty"`
+}
+
+type typedForecastResponse struct {
+	City    string  `json:"city"`
+	HighC   float64 `json:"high_c"`
+	Summary string  `json:"summary"`
+}
+
+func forecast(ctx context.Context, req typedForecastRequest) (typedForecastResponse, error) {
+	switch req.City {
+	case "":
+		return typedForecastResponse{}, BadRequest("city is required")
+	case "Atlantis":
+		return typedForecastResponse{}, &ToolError{Code: "CITY_NOT_FOUND", Message: "unknown city", Category: CategoryNotFound}
+	case "Tokyo":
+		return typedForecastResponse{}, errors.New("upstream timeout")
+	}
+	return typedForecastResponse{City: req.City, HighC: 21.5, Summary: "sunny"}, nil
+}
+
+func TestRegisterTypedCapability(t *testing.T) {
+	agent := NewBaseAgent("weather-agent")
+	RegisterTypedCapability(agent, "forecast", forecast)
+
+	if len(agent.Capabilities) != 1 {
+		t.Fatalf("Expected one capability, got %d", len(agent.Capabilities))
+	}
+	cap := agent.Capabilities[0]
+	if cap.Endpoint != "/api/capabilities/forecast" || !cap.ResultEnvelope {
+		t.Errorf("Unexpected capability: %+v", cap)
+	}
+	if cap.InputSummary == nil || len(cap.InputSummary.RequiredFields) != 1 || cap.InputSummary.RequiredFields[0].Name != "city" {
+		t.Errorf("Expected the input schema from the request type, got %+v", cap.InputSummary)
+	}
+	if cap.OutputSummary == nil || len(cap.OutputSummary.RequiredFields) != 3 {
+		t.Errorf("Expected the output schema from the response type, got %+v", cap.OutputSummary)
+	}
+	if cap.SchemaEndpoint == "" {
+		t.Error("Expected a schema endpoint for the typed input")
+	}
+
+	handler := agent.Handler()
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		code   string
+	}{
+		{"success", `{"city": "London"}`, http.StatusOK, ""},
+		{"bad request", `{}`, http.StatusBadRequest, "INVALID_REQUEST"},
+		{"invalid JSON", `{"city":`, http.StatusBadRequest, "INVALID_REQUEST"},
+		{"tool error", `{"city": "Atlantis"}`, http.StatusNotFound, "CITY_NOT_FOUND"},
+		{"other error", `{"city": "Tokyo"}`, http.StatusInternalServerError, "INTERNAL_ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest("POST", "/api/capabilities/forecast", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.status {
+				t.Fatalf("Expected %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			response := decodeToolResponse(t, rec)
+			if tt.code == "" {
+				data, _ := response.Data.(map[string]interface{})
+				if !response.Success || data["city"] != "London" || data["high_c"] != 21.5 {
+					t.Errorf("Expected the typed result as data, got %+v", response)
+				}
+				return
+			}
+			if response.Success || response.Error == nil || response.Error.Code != tt.code {
+				t.Errorf("Expected error code %s, got %+v", tt.code, response.Error)
+			}
+			if response.RequestID == "" {
+				t.Error("Expected error envelopes to carry the request ID")
+			}
+		})
+	}
+}
+
+func TestTypedCapability_KeepsExplicitFields(t *testing.T) {
+	tool := NewTool("weather")
+	tool.RegisterCapability(TypedCapability(Capability{
+		Name:        "forecast",
+		Description: "Daily forecast",
+		Endpoint:    "/forecast",
+	}, forecast))
+
+	caps := tool.GetCapabilities()
+	if caps[0].Description != "Daily forecast" || caps[0].Endpoint != "/forecast" {
+		t.Errorf("Expected explicit fields to be kept, got %+v", caps[0])
+	}
+
+	rec := httptest.NewRecorder()
+	tool.Handler().ServeHTTP(rec, httptest.NewRequest("POST", "/forecast", strings.NewReader(`{"city": "Paris"}`)))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
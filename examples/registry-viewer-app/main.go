@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"io/fs"
 	"log"
 	"net/http"
@@ -186,6 +187,7 @@ type StepResult struct {
 	StartTime    *time.Time             `json:"start_time,omitempty"`
 	EndTime      *time.Time             `json:"end_time,omitempty"`
 	Skipped      bool                   `json:"skipped,omitempty"`
+	Cancelled    bool                   `json:"cancelled,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
 }
 
@@ -223,6 +225,24 @@ const (
 	executionKeyPrefix   = "gomind:execution:debug:"
 	executionIndexKey    = "gomind:execution:debug:index"
 	executionTracePrefix = "gomind:execution:debug:trace:"
+	executionEventsKey   = "gomind:execution:debug:events" // Pub/sub channel for ExecutionEvent
+	executionCancelKey   = "gomind:execution:cancel"       // Pub/sub channel of orchestration.RedisCancellationBroker
+
+	// Search index: a sorted set of request IDs per word, scored by
+	// created-at time, and the created-at time of the first indexed
+	// execution (see orchestration.ExecutionSearchTokens)
+	executionSearchTokenPrefix   = "gomind:execution:debug:search:words:"
+	executionSearchIndexedSince  = "gomind:execution:debug:search:indexed_since"
+	executionSearchScratchPrefix = "gomind:execution:debug:search:scratch:"
+
+	// searchMaxCandidates caps how many indexed matches a search reads,
+	// newest first, so common words don't load every execution
+	searchMaxCandidates = 500
+
+	// cancelRequestHeader must be set on cancel requests. It is not a
+	// CORS-safelisted header, so a browser on another origin has to send a
+	// preflight first, which the viewer never approves.
+	cancelRequestHeader = "X-Registry-Viewer"
 )
 
 // StoredExecution contains everything needed for DAG visualization
@@ -235,6 +255,7 @@ type StoredExecution struct {
 	Plan              *RoutingPlan      `json:"plan"`
 	Result            *ExecutionResult  `json:"result"`
 	Interrupted       bool              `json:"interrupted,omitempty"` // True if execution was interrupted for HITL
+	InProgress        bool              `json:"in_progress,omitempty"` // True while steps are still being checkpointed
 	Checkpoint        *HITLCheckpoint   `json:"checkpoint,omitempty"`  // Checkpoint data if interrupted
 	CreatedAt         time.Time         `json:"created_at"`
 	Metadata          map[string]string `json:"metadata,omitempty"`
@@ -258,6 +279,7 @@ type ExecutionSummary struct {
 	OriginalRequest   string    `json:"original_request"`
 	Success           bool      `json:"success"`
 	Interrupted       bool      `json:"interrupted,omitempty"` // True if execution was interrupted for HITL
+	InProgress        bool      `json:"in_progress,omitempty"` // True while steps are still being checkpointed
 	StepCount         int       `json:"step_count"`
 	FailedSteps       int       `json:"failed_steps"`
 	TotalDurationMs   int64     `json:"total_duration_ms"`
@@ -280,12 +302,15 @@ type DAGNode struct {
 	Status      string `json:"status"`
 	DurationMs  int64  `json:"duration_ms"`
 	Level       int    `json:"level"`
+	Reason      string `json:"reason,omitempty"`   // Why a skipped or failed step did not complete
+	Critical    bool   `json:"critical,omitempty"` // On the critical path
 }
 
 // DAGEdge represents an edge in the DAG visualization
 type DAGEdge struct {
-	Source string `json:"source"`
-	Target string `json:"target"`
+	Source  string `json:"source"`
+	Target  string `json:"target"`
+	Skipped bool   `json:"skipped,omitempty"` // Target was skipped (false condition or skipped dependency)
 }
 
 // DAGStatistics contains computed statistics for the DAG
@@ -294,16 +319,19 @@ type DAGStatistics struct {
 	CompletedNodes int `json:"completed_nodes"`
 	FailedNodes    int `json:"failed_nodes"`
 	SkippedNodes   int `json:"skipped_nodes"`
+	CancelledNodes int `json:"cancelled_nodes"`
 	MaxParallelism int `json:"max_parallelism"`
 	Depth          int `json:"depth"`
 }
 
 // DAGResponse is the computed DAG structure for visualization
 type DAGResponse struct {
-	Nodes      []DAGNode     `json:"nodes"`
-	Edges      []DAGEdge     `json:"edges"`
-	Levels     [][]string    `json:"levels"`
-	Statistics DAGStatistics `json:"statistics"`
+	Nodes          []DAGNode     `json:"nodes"`
+	Edges          []DAGEdge     `json:"edges"`
+	Levels         [][]string    `json:"levels"`
+	CriticalPath   []string      `json:"critical_path"`    // Step IDs of the longest-duration dependency chain, first to last
+	CriticalPathMs int64         `json:"critical_path_ms"` // Total duration of the critical path
+	Statistics     DAGStatistics `json:"statistics"`
 }
 
 // UnifiedExecutionView combines all related data for a single request view
@@ -321,6 +349,7 @@ type UnifiedExecutionView struct {
 	Plan              *RoutingPlan     `json:"plan,omitempty"`
 	Result            *ExecutionResult `json:"result,omitempty"`
 	Interrupted       bool             `json:"interrupted,omitempty"` // True if execution was interrupted for HITL
+	InProgress        bool             `json:"in_progress,omitempty"` // True while steps are still being checkpointed
 	Checkpoint        *HITLCheckpoint  `json:"checkpoint,omitempty"`  // Checkpoint data if interrupted (includes completed_steps, step_results)
 
 	// Computed DAG structure
@@ -348,10 +377,11 @@ type LLMDebugSummary struct {
 }
 
 var (
-	useMock   bool
-	redisURL  string
-	namespace string
-	port      int
+	useMock          bool
+	redisURL         string
+	namespace        string
+	port             int
+	searchMaxResults int
 )
 
 func init() {
@@ -359,6 +389,7 @@ func init() {
 	flag.StringVar(&redisURL, "redis-url", "", "Redis/Valkey URL (required when -mock=false, or set REDIS_URL env var)")
 	flag.StringVar(&namespace, "namespace", "gomind", "Redis key namespace")
 	flag.IntVar(&port, "port", 8100, "HTTP server port")
+	flag.IntVar(&searchMaxResults, "search-max-results", 100, "Maximum results an execution search may return")
 }
 
 // getEnvOrDefault returns environment variable value or default
@@ -411,6 +442,9 @@ func main() {
 	if envPort := getEnvInt("PORT", 0); envPort != 0 {
 		port = envPort
 	}
+	if envMax := getEnvInt("SEARCH_MAX_RESULTS", 0); envMax > 0 {
+		searchMaxResults = envMax
+	}
 	// USE_MOCK env var: "false" or "0" disables mock mode
 	if envMock := os.Getenv("USE_MOCK"); envMock != "" {
 		useMock = getEnvBool("USE_MOCK", useMock)
@@ -423,16 +457,19 @@ func main() {
 
 	mux := http.NewServeMux()
 
-	// API endpoints
-	mux.HandleFunc("/api/services", handleServices)
+	// API endpoints. JSON responses get ETags and gzip (withResponseCaching);
+	// the streaming export and SSE endpoints are served directly.
+	mux.HandleFunc("/api/services", withResponseCaching(handleServices))
 	mux.HandleFunc("/api/health", handleHealth)
-	mux.HandleFunc("/api/llm-debug", handleLLMDebugList)
-	mux.HandleFunc("/api/llm-debug/", handleLLMDebugRecord)
-	mux.HandleFunc("/api/hitl/checkpoints", handleHITLCheckpointList)
-	mux.HandleFunc("/api/hitl/checkpoints/", handleHITLCheckpoint)
-	mux.HandleFunc("/api/executions", handleExecutionList)
-	mux.HandleFunc("/api/executions/search", handleExecutionSearch)
-	mux.HandleFunc("/api/executions/", handleExecution) // Handles both /{id} and /{id}/dag
+	mux.HandleFunc("/api/llm-debug", withResponseCaching(handleLLMDebugList))
+	mux.HandleFunc("/api/llm-debug/export", handleLLMDebugExport)
+	mux.HandleFunc("/api/llm-debug/", withResponseCaching(handleLLMDebugRecord))
+	mux.HandleFunc("/api/hitl/checkpoints", withResponseCaching(handleHITLCheckpointList))
+	mux.HandleFunc("/api/hitl/checkpoints/", withResponseCaching(handleHITLCheckpoint))
+	mux.HandleFunc("/api/executions", withResponseCaching(handleExecutionList))
+	mux.HandleFunc("/api/executions/search", withResponseCaching(handleExecutionSearch))
+	mux.HandleFunc("/api/executions/stream", handleExecutionStream)
+	mux.HandleFunc("/api/executions/", withResponseCaching(handleExecution)) // Handles both /{id} and /{id}/dag
 
 	// Static files - use fs.Sub to strip "static/" prefix from embedded FS
 	staticContent, err := fs.Sub(staticFiles, "static")
@@ -874,6 +911,86 @@ func handleLLMDebugRecord(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(record)
 }
 
+// handleLLMDebugExport streams debug records as NDJSON for offline analysis.
+// ?since= takes an RFC3339 timestamp or a duration such as 24h (meaning that
+// long ago); without it every record is exported. The last line is a
+// {"summary": {...}} object with the record count.
+func handleLLMDebugExport(w http.ResponseWriter, r *http.Request) {
+	since, err := parseTimeParam(r.URL.Query().Get("since"))
+	if err != nil {
+		http.Error(w, "since must be an RFC3339 timestamp or a duration", http.StatusBadRequest)
+		return
+	}
+
+	var client *redis.Client
+	if !useMock {
+		var err error
+		if client, err = getLLMDebugClient(); err != nil {
+			http.Error(w, fmt.Sprintf("Redis error: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", `attachment; filename="llm-debug-export.ndjson"`)
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	out := &flushWriter{w: w}
+	if f, ok := w.(http.Flusher); ok {
+		out.flusher = f
+	}
+
+	if useMock {
+		encoder := json.NewEncoder(out)
+		count := 0
+		for _, summary := range getMockLLMDebugSummaries() {
+			if record := getMockLLMDebugRecord(summary.RequestID); record != nil && !record.CreatedAt.Before(since) {
+				encoder.Encode(record)
+				count++
+			}
+		}
+		encoder.Encode(map[string]orchestration.LLMDebugExportSummary{
+			"summary": {Count: count, Since: since, ExportedAt: time.Now()},
+		})
+		return
+	}
+
+	// Headers are already sent; an error can only end the stream early
+	if err := orchestration.ExportLLMDebugRecords(r.Context(), client, since, out); err != nil {
+		log.Printf("Warning: LLM debug export stopped: %v", err)
+	}
+}
+
+// parseTimeParam reads a time query parameter given as an RFC3339 timestamp
+// or as a duration such as 24h, meaning that long ago. An empty value gives
+// the zero time.
+func parseTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q", value)
+	}
+	return time.Now().Add(-d), nil
+}
+
+// flushWriter flushes after every write so exports stream to the client
+type flushWriter struct {
+	w       io.Writer
+	flusher http.Flusher
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if f.flusher != nil {
+		f.flusher.Flush()
+	}
+	return n, err
+}
+
 // getRedisLLMDebugSummaries fetches recent debug record summaries from Redis
 func getRedisLLMDebugSummaries(limit int) ([]orchestration.LLMDebugRecordSummary, error) {
 	client, err := getLLMDebugClient()
@@ -1132,13 +1249,19 @@ func handleExecutionList(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	filter, err := parseExecutionFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	var summaries []ExecutionSummary
-	var err error
+	var hasMore bool
 
 	if useMock {
-		summaries = getMockExecutionSummaries()
+		summaries, hasMore = filterMockExecutionSummaries(filter, limit)
 	} else {
-		summaries, err = getRedisExecutionSummaries(limit)
+		summaries, hasMore, err = queryRedisExecutionSummaries(filter, limit)
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Redis error: %v", err), http.StatusInternalServerError)
 			return
@@ -1148,7 +1271,7 @@ func handleExecutionList(w http.ResponseWriter, r *http.Request) {
 	response := ExecutionListResponse{
 		Executions: summaries,
 		Total:      len(summaries),
-		HasMore:    len(summaries) >= limit,
+		HasMore:    hasMore,
 		Timestamp:  time.Now(),
 	}
 
@@ -1163,6 +1286,67 @@ type ExecutionSearchResponse struct {
 	Timestamp  time.Time          `json:"timestamp"`
 }
 
+// executionFilter narrows the execution list. From and To bound the
+// created-at time and are pushed down to the Redis index; the rest are
+// applied while scanning.
+type executionFilter struct {
+	From          time.Time // Zero for no lower bound
+	To            time.Time // Zero for no upper bound
+	Success       *bool     // Nil for any outcome
+	AgentName     string
+	MinDurationMs int64
+}
+
+// parseExecutionFilter reads the filter query parameters of /api/executions:
+// from and to (RFC3339 or a duration ago, as in ?from=168h), success
+// (true or false), agent_name and min_duration_ms
+func parseExecutionFilter(r *http.Request) (executionFilter, error) {
+	query := r.URL.Query()
+	var filter executionFilter
+	var err error
+
+	if filter.From, err = parseTimeParam(query.Get("from")); err != nil {
+		return filter, fmt.Errorf("from must be an RFC3339 timestamp or a duration")
+	}
+	if filter.To, err = parseTimeParam(query.Get("to")); err != nil {
+		return filter, fmt.Errorf("to must be an RFC3339 timestamp or a duration")
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return filter, fmt.Errorf("to must not be before from")
+	}
+	if successStr := query.Get("success"); successStr != "" {
+		success, err := strconv.ParseBool(successStr)
+		if err != nil {
+			return filter, fmt.Errorf("success must be true or false")
+		}
+		filter.Success = &success
+	}
+	filter.AgentName = query.Get("agent_name")
+	if minStr := query.Get("min_duration_ms"); minStr != "" {
+		if filter.MinDurationMs, err = strconv.ParseInt(minStr, 10, 64); err != nil || filter.MinDurationMs < 0 {
+			return filter, fmt.Errorf("min_duration_ms must be a non-negative integer")
+		}
+	}
+	return filter, nil
+}
+
+// matches reports whether summary passes the filter
+func (f executionFilter) matches(summary ExecutionSummary) bool {
+	if !f.From.IsZero() && summary.CreatedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && summary.CreatedAt.After(f.To) {
+		return false
+	}
+	if f.Success != nil && summary.Success != *f.Success {
+		return false
+	}
+	if f.AgentName != "" && summary.AgentName != f.AgentName {
+		return false
+	}
+	return summary.TotalDurationMs >= f.MinDurationMs
+}
+
 // handleExecutionSearch searches executions by original request content
 func handleExecutionSearch(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -1178,10 +1362,13 @@ func handleExecutionSearch(w http.ResponseWriter, r *http.Request) {
 	limitStr := r.URL.Query().Get("limit")
 	limit := 50
 	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
 			limit = l
 		}
 	}
+	if limit > searchMaxResults {
+		limit = searchMaxResults
+	}
 
 	var summaries []ExecutionSummary
 	var err error
@@ -1208,12 +1395,11 @@ func handleExecutionSearch(w http.ResponseWriter, r *http.Request) {
 
 // searchMockExecutions searches mock executions by original request content
 func searchMockExecutions(query string, limit int) []ExecutionSummary {
-	allSummaries := getMockExecutionSummaries()
-	queryLower := strings.ToLower(query)
+	tokens := orchestration.ExecutionSearchTokens(query)
 
 	var results []ExecutionSummary
-	for _, summary := range allSummaries {
-		if strings.Contains(strings.ToLower(summary.OriginalRequest), queryLower) {
+	for _, summary := range getMockExecutionSummaries() {
+		if matchesExecutionSearch(summary.OriginalRequest, query, tokens) {
 			results = append(results, summary)
 			if len(results) >= limit {
 				break
@@ -1223,19 +1409,106 @@ func searchMockExecutions(query string, limit int) []ExecutionSummary {
 	return results
 }
 
-// searchRedisExecutions searches Redis executions by original request content
+// matchesExecutionSearch reports whether an original request contains every
+// word of the query, as the search index matches, or contains the query as
+// a substring when it has no indexable words
+func matchesExecutionSearch(originalRequest, query string, tokens []string) bool {
+	if len(tokens) == 0 {
+		return strings.Contains(strings.ToLower(originalRequest), strings.ToLower(query))
+	}
+	words := make(map[string]bool)
+	for _, word := range orchestration.ExecutionSearchTokens(originalRequest) {
+		words[word] = true
+	}
+	for _, token := range tokens {
+		if !words[token] {
+			return false
+		}
+	}
+	return true
+}
+
+// searchRedisExecutions searches Redis executions by original request
+// content, newest first. Executions must contain every word of the query.
+// It reads at most searchMaxCandidates matches from the execution store's
+// search index, then scans executions stored before the index existed. It
+// scans recent executions instead when there is no index or the query has
+// no indexable words.
 func searchRedisExecutions(query string, limit int) ([]ExecutionSummary, error) {
-	// Get recent executions and filter by query
-	// Note: For production, consider using Redis Search or a dedicated search index
-	allSummaries, err := getRedisExecutionSummaries(1000) // Fetch more to search through
+	client, err := getExecutionDebugClient() // Uses Redis DB 8 for Execution Debug
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tokens := orchestration.ExecutionSearchTokens(query)
+	since, err := client.Get(ctx, executionSearchIndexedSince).Int64()
+	if err == redis.Nil || len(tokens) == 0 {
+		return scanRedisExecutions(query, tokens, limit, time.Time{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check search index: %w", err)
+	}
+
+	key := executionSearchTokenPrefix + tokens[0]
+	if len(tokens) > 1 {
+		keys := make([]string, len(tokens))
+		for i, token := range tokens {
+			keys[i] = executionSearchTokenPrefix + token
+		}
+		// Intersect into a short-lived scratch key so only the newest
+		// candidates are read back
+		key = executionSearchScratchPrefix + strconv.FormatInt(time.Now().UnixNano(), 36)
+		pipe := client.TxPipeline()
+		pipe.ZInterStore(ctx, key, &redis.ZStore{Keys: keys, Aggregate: "MAX"})
+		pipe.Expire(ctx, key, time.Minute)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, fmt.Errorf("search index query failed: %w", err)
+		}
+		defer client.Del(context.Background(), key)
+	}
+	requestIDs, err := client.ZRevRange(ctx, key, 0, searchMaxCandidates-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("search index query failed: %w", err)
+	}
+
+	results := make([]ExecutionSummary, 0, limit)
+	for _, requestID := range requestIDs {
+		execution, err := getRedisExecution(requestID)
+		if err != nil {
+			continue // Expired since it was indexed
+		}
+		results = append(results, summarizeExecution(execution))
+		if len(results) >= limit {
+			return results, nil
+		}
+	}
+	if len(requestIDs) == searchMaxCandidates {
+		return results, nil // Older matches are past the candidate cap
+	}
+
+	// Executions stored before the index existed are only found by scanning
+	older, err := scanRedisExecutions(query, tokens, limit-len(results), time.Unix(0, since-1))
+	if err != nil {
+		return nil, err
+	}
+	return append(results, older...), nil
+}
+
+// scanRedisExecutions searches the most recent executions one by one, for
+// data the search index doesn't cover. A non-zero before limits the scan to
+// executions created up to that time.
+func scanRedisExecutions(query string, tokens []string, limit int, before time.Time) ([]ExecutionSummary, error) {
+	allSummaries, _, err := queryRedisExecutionSummaries(executionFilter{To: before}, 1000) // Fetch more to search through
 	if err != nil {
 		return nil, err
 	}
 
-	queryLower := strings.ToLower(query)
 	var results []ExecutionSummary
 	for _, summary := range allSummaries {
-		if strings.Contains(strings.ToLower(summary.OriginalRequest), queryLower) {
+		if matchesExecutionSearch(summary.OriginalRequest, query, tokens) {
 			results = append(results, summary)
 			if len(results) >= limit {
 				break
@@ -1245,7 +1518,233 @@ func searchRedisExecutions(query string, limit int) ([]ExecutionSummary, error)
 	return results, nil
 }
 
-// handleExecution handles GET /api/executions/{id}, /{id}/dag, and /{id}/unified
+// Execution stream timing
+const (
+	executionStreamKeepalive = 15 * time.Second // SSE comment to keep proxies from closing the stream
+	executionPollInterval    = 5 * time.Second  // Fallback when pub/sub is unavailable
+	mockExecutionInterval    = 8 * time.Second  // Synthetic events in mock mode
+)
+
+// executionStreamEvent is one Server-Sent Event on /api/executions/stream
+type executionStreamEvent struct {
+	Type    string // orchestration.ExecutionEventStored or ExecutionEventUpdated
+	Summary ExecutionSummary
+}
+
+// handleExecutionStream streams execution changes as Server-Sent Events.
+// Each event is named "stored" for a new execution or "updated" for a status
+// change, with the ExecutionSummary as JSON data. In Redis mode it follows
+// the execution store's pub/sub channel, falling back to polling when
+// subscribing fails; in mock mode it emits synthetic executions.
+func handleExecutionStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// The request context is cancelled when the client disconnects, which
+	// stops the event source and releases its subscription
+	ctx := r.Context()
+	events := make(chan executionStreamEvent)
+	if useMock {
+		go mockExecutionEvents(ctx, events)
+	} else if err := subscribeExecutionEvents(ctx, events); err != nil {
+		log.Printf("Warning: execution events unavailable, polling instead: %v", err)
+		go pollExecutionEvents(ctx, events)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepalive := time.NewTicker(executionStreamKeepalive)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepalive.C:
+			if _, err := io.WriteString(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case event := <-events:
+			data, err := json.Marshal(event.Summary)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// subscribeExecutionEvents forwards the events the execution store publishes
+// until ctx is done. It returns an error, without starting, when the
+// subscription cannot be made.
+func subscribeExecutionEvents(ctx context.Context, events chan<- executionStreamEvent) error {
+	client, err := getExecutionDebugClient() // Uses Redis DB 8 for Execution Debug
+	if err != nil {
+		return err
+	}
+
+	pubsub := client.Subscribe(ctx, executionEventsKey)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return fmt.Errorf("subscribe failed: %w", err)
+	}
+
+	go func() {
+		defer pubsub.Close()
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				var event orchestration.ExecutionEvent
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					log.Printf("Warning: skipping malformed execution event: %v", err)
+					continue
+				}
+				select {
+				case events <- executionStreamEvent{Type: event.Type, Summary: summaryFromEvent(event.Summary)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// summaryFromEvent converts the orchestration summary carried by an event
+func summaryFromEvent(summary orchestration.ExecutionSummary) ExecutionSummary {
+	return ExecutionSummary{
+		RequestID:         summary.RequestID,
+		OriginalRequestID: summary.OriginalRequestID,
+		TraceID:           summary.TraceID,
+		AgentName:         summary.AgentName,
+		OriginalRequest:   summary.OriginalRequest,
+		Success:           summary.Success,
+		Interrupted:       summary.Interrupted,
+		InProgress:        summary.InProgress,
+		StepCount:         summary.StepCount,
+		FailedSteps:       summary.FailedSteps,
+		TotalDurationMs:   summary.TotalDuration.Milliseconds(),
+		CreatedAt:         summary.CreatedAt,
+	}
+}
+
+// pollExecutionEvents polls recent executions until ctx is done, reporting
+// new ones as stored and changed ones as updated. The first poll only sets
+// the baseline, since the client has just loaded the list.
+func pollExecutionEvents(ctx context.Context, events chan<- executionStreamEvent) {
+	ticker := time.NewTicker(executionPollInterval)
+	defer ticker.Stop()
+
+	var seen map[string]ExecutionSummary
+	for {
+		summaries, err := getRedisExecutionSummaries(50)
+		if err != nil {
+			log.Printf("Warning: execution poll failed: %v", err)
+		} else {
+			current := make(map[string]ExecutionSummary, len(summaries))
+			for _, summary := range summaries {
+				current[summary.RequestID] = summary
+				if seen == nil {
+					continue
+				}
+				eventType := ""
+				if previous, ok := seen[summary.RequestID]; !ok {
+					eventType = orchestration.ExecutionEventStored
+				} else if previous != summary {
+					eventType = orchestration.ExecutionEventUpdated
+				}
+				if eventType == "" {
+					continue
+				}
+				select {
+				case events <- executionStreamEvent{Type: eventType, Summary: summary}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			seen = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// mockExecutionEvents emits synthetic executions until ctx is done: each one
+// is stored in progress and completes on the following tick
+func mockExecutionEvents(ctx context.Context, events chan<- executionStreamEvent) {
+	requests := []string{
+		"What's the weather in Paris?",
+		"Get the stock price for NVDA",
+		"Convert 100 USD to EUR and JPY",
+	}
+	ticker := time.NewTicker(mockExecutionInterval)
+	defer ticker.Stop()
+
+	var pending *ExecutionSummary
+	for i := 0; ; i++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var event executionStreamEvent
+		if pending == nil {
+			now := time.Now()
+			requestID := fmt.Sprintf("orch-mock-%d", now.UnixNano())
+			pending = &ExecutionSummary{
+				RequestID:         requestID,
+				OriginalRequestID: requestID,
+				TraceID:           fmt.Sprintf("mock%012x", now.UnixNano()&0xffffffffffff),
+				OriginalRequest:   requests[i%len(requests)],
+				InProgress:        true,
+				StepCount:         1,
+				CreatedAt:         now,
+			}
+			event = executionStreamEvent{Type: orchestration.ExecutionEventStored, Summary: *pending}
+		} else {
+			pending.InProgress = false
+			pending.Success = i%3 != 0
+			pending.StepCount = 2
+			if !pending.Success {
+				pending.FailedSteps = 1
+			}
+			pending.TotalDurationMs = time.Since(pending.CreatedAt).Milliseconds()
+			event = executionStreamEvent{Type: orchestration.ExecutionEventUpdated, Summary: *pending}
+			pending = nil
+		}
+
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// handleExecution handles GET /api/executions/{id}, /{id}/dag, and /{id}/unified,
+// and POST /api/executions/{id}/cancel
 func handleExecution(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -1260,6 +1759,12 @@ func handleExecution(w http.ResponseWriter, r *http.Request) {
 	}
 
 	requestID := parts[0]
+	if len(parts) > 1 && parts[1] == "cancel" {
+		// Cancelling changes state, so only the viewer's own page may call it
+		w.Header().Del("Access-Control-Allow-Origin")
+		handleExecutionCancel(w, r, requestID)
+		return
+	}
 	isDAGRequest := len(parts) > 1 && parts[1] == "dag"
 	isUnifiedRequest := len(parts) > 1 && parts[1] == "unified"
 
@@ -1284,6 +1789,11 @@ func handleExecution(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Finished executions no longer change; running and interrupted ones do
+	if execution.Result != nil && !execution.InProgress && !execution.Interrupted {
+		w.Header().Set("Cache-Control", completedExecutionCacheControl)
+	}
+
 	if isUnifiedRequest {
 		// Return unified view combining execution, LLM debug, and HITL data
 		unified := buildUnifiedView(execution)
@@ -1298,13 +1808,54 @@ func handleExecution(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleExecutionCancel handles POST /api/executions/{id}/cancel by publishing
+// the request on the cancellation channel, where the orchestrator running the
+// execution picks it up (see orchestration.RedisCancellationBroker). The
+// request must carry cancelRequestHeader, so another site can't make a
+// visitor's browser send it without a preflight, which is refused.
+func handleExecutionCancel(w http.ResponseWriter, r *http.Request, requestID string) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Header.Get(cancelRequestHeader) == "" {
+		http.Error(w, fmt.Sprintf("%s header is required", cancelRequestHeader), http.StatusForbidden)
+		return
+	}
+
+	receivers := int64(0)
+	if !useMock {
+		client, err := getExecutionDebugClient() // Uses Redis DB 8 for Execution Debug
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Redis error: %v", err), http.StatusInternalServerError)
+			return
+		}
+		receivers, err = client.Publish(r.Context(), executionCancelKey, requestID).Result()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Redis error: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Publishing only reaches orchestrators with a cancellation broker; the
+	// execution's status updates once its partial result is stored
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"request_id": requestID,
+		"status":     "cancel_requested",
+		"receivers":  receivers,
+	})
+}
+
 // computeDAG builds the DAG structure from a stored execution
 func computeDAG(execution *StoredExecution) *DAGResponse {
 	if execution == nil || execution.Plan == nil {
 		return &DAGResponse{
-			Nodes:  []DAGNode{},
-			Edges:  []DAGEdge{},
-			Levels: [][]string{},
+			Nodes:        []DAGNode{},
+			Edges:        []DAGEdge{},
+			Levels:       [][]string{},
+			CriticalPath: []string{},
 		}
 	}
 
@@ -1372,17 +1923,25 @@ func computeDAG(execution *StoredExecution) *DAGResponse {
 	for _, step := range execution.Plan.Steps {
 		status := "pending"
 		var durationMs int64
+		var reason string
 
 		if result, ok := stepResults[step.StepID]; ok {
+			// Skipped steps carry their skip reason in Error, so check Skipped first
 			if result.Success {
 				status = "completed"
 				statistics.CompletedNodes++
-			} else if result.Error != "" {
-				status = "failed"
-				statistics.FailedNodes++
 			} else if result.Skipped {
 				status = "skipped"
 				statistics.SkippedNodes++
+				reason = result.Error
+			} else if result.Cancelled {
+				status = "cancelled"
+				statistics.CancelledNodes++
+				reason = result.Error
+			} else if result.Error != "" {
+				status = "failed"
+				statistics.FailedNodes++
+				reason = result.Error
 			}
 			durationMs = result.DurationMs
 		}
@@ -1394,16 +1953,30 @@ func computeDAG(execution *StoredExecution) *DAGResponse {
 			Status:      status,
 			DurationMs:  durationMs,
 			Level:       levelMap[step.StepID],
+			Reason:      reason,
 		})
 	}
 
+	// Mark the critical path, the chain that determined total latency
+	criticalPath, criticalPathMs := computeCriticalPath(execution.Plan.Steps, levels, nodes)
+	onCriticalPath := make(map[string]bool, len(criticalPath))
+	for _, stepID := range criticalPath {
+		onCriticalPath[stepID] = true
+	}
+	for i := range nodes {
+		nodes[i].Critical = onCriticalPath[nodes[i].ID]
+	}
+
 	// Build edges
 	edges := make([]DAGEdge, 0)
 	for _, step := range execution.Plan.Steps {
+		result, ok := stepResults[step.StepID]
+		skipped := ok && result.Skipped
 		for _, dep := range step.DependsOn {
 			edges = append(edges, DAGEdge{
-				Source: dep,
-				Target: step.StepID,
+				Source:  dep,
+				Target:  step.StepID,
+				Skipped: skipped,
 			})
 		}
 	}
@@ -1416,11 +1989,66 @@ func computeDAG(execution *StoredExecution) *DAGResponse {
 	}
 
 	return &DAGResponse{
-		Nodes:      nodes,
-		Edges:      edges,
-		Levels:     levels,
-		Statistics: statistics,
+		Nodes:          nodes,
+		Edges:          edges,
+		Levels:         levels,
+		CriticalPath:   criticalPath,
+		CriticalPathMs: criticalPathMs,
+		Statistics:     statistics,
+	}
+}
+
+// computeCriticalPath finds the dependency chain with the largest total
+// DurationMs. Walking the levels in order settles every step's dependencies
+// before the step, so each step's longest chain is its duration plus the
+// longest chain among its dependencies. Ties go to the step listed first.
+// Steps outside the levels (in a dependency cycle) are ignored, and the path
+// is empty while no step has a duration.
+func computeCriticalPath(steps []RoutingStep, levels [][]string, nodes []DAGNode) ([]string, int64) {
+	dependsOn := make(map[string][]string, len(steps))
+	for _, step := range steps {
+		dependsOn[step.StepID] = step.DependsOn
+	}
+	durationMs := make(map[string]int64, len(nodes))
+	for _, node := range nodes {
+		durationMs[node.ID] = node.DurationMs
+	}
+
+	chainMs := make(map[string]int64)   // Longest chain ending at each step
+	previous := make(map[string]string) // The dependency that chain comes through
+	var end string
+	var endMs int64
+	for _, level := range levels {
+		for _, stepID := range level {
+			var longest int64
+			for _, dep := range dependsOn[stepID] {
+				ms, ok := chainMs[dep]
+				if !ok {
+					continue
+				}
+				if _, found := previous[stepID]; !found || ms > longest {
+					longest = ms
+					previous[stepID] = dep
+				}
+			}
+			chainMs[stepID] = longest + durationMs[stepID]
+			if chainMs[stepID] > endMs {
+				end, endMs = stepID, chainMs[stepID]
+			}
+		}
+	}
+
+	path := []string{}
+	if end == "" {
+		return path, 0
+	}
+	for stepID, ok := end, true; ok; stepID, ok = previous[stepID] {
+		path = append(path, stepID)
 	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, endMs
 }
 
 // buildUnifiedView combines execution data with LLM debug and HITL checkpoint data
@@ -1439,6 +2067,7 @@ func buildUnifiedView(execution *StoredExecution) *UnifiedExecutionView {
 		Plan:              execution.Plan,
 		Result:            execution.Result,
 		Interrupted:       execution.Interrupted,
+		InProgress:        execution.InProgress,
 		Checkpoint:        execution.Checkpoint,
 	}
 
@@ -1574,58 +2203,90 @@ func extractAgentNameFromKey(key string) string {
 
 // getRedisExecutionSummaries fetches recent execution summaries from Redis
 func getRedisExecutionSummaries(limit int) ([]ExecutionSummary, error) {
+	summaries, _, err := queryRedisExecutionSummaries(executionFilter{}, limit)
+	return summaries, err
+}
+
+// executionScanBatch is how many index entries a filtered query reads at a time
+const executionScanBatch = 100
+
+// queryRedisExecutionSummaries fetches up to limit summaries matching filter,
+// newest first, and reports whether more match. The time range is queried
+// on the index, whose scores are created-at times in Unix nanoseconds; the
+// other filters are applied while scanning it in batches.
+func queryRedisExecutionSummaries(filter executionFilter, limit int) ([]ExecutionSummary, bool, error) {
 	client, err := getExecutionDebugClient() // Uses Redis DB 8 for Execution Debug
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Get recent request IDs from sorted set (newest first)
-	requestIDs, err := client.ZRevRangeByScore(ctx, executionIndexKey, &redis.ZRangeBy{
-		Min:    "-inf",
-		Max:    "+inf",
-		Offset: 0,
-		Count:  int64(limit),
-	}).Result()
-	if err != nil {
-		return nil, fmt.Errorf("failed to list executions: %w", err)
+	scoreRange := &redis.ZRangeBy{Min: "-inf", Max: "+inf", Count: executionScanBatch}
+	if !filter.From.IsZero() {
+		scoreRange.Min = strconv.FormatInt(filter.From.UnixNano(), 10)
+	}
+	if !filter.To.IsZero() {
+		scoreRange.Max = strconv.FormatInt(filter.To.UnixNano(), 10)
 	}
 
-	summaries := make([]ExecutionSummary, 0, len(requestIDs))
-	for _, requestID := range requestIDs {
-		execution, err := getRedisExecution(requestID)
+	summaries := make([]ExecutionSummary, 0, limit)
+	for {
+		// Get the next batch of request IDs in range (newest first)
+		requestIDs, err := client.ZRevRangeByScore(ctx, executionIndexKey, scoreRange).Result()
 		if err != nil {
-			log.Printf("Warning: skipping execution %s: %v", requestID, err)
-			continue
+			return nil, false, fmt.Errorf("failed to list executions: %w", err)
 		}
 
-		summary := ExecutionSummary{
-			RequestID:         execution.RequestID,
-			OriginalRequestID: execution.OriginalRequestID,
-			TraceID:           execution.TraceID,
-			AgentName:         execution.AgentName,
-			OriginalRequest:   execution.OriginalRequest,
-			Interrupted:       execution.Interrupted,
-			CreatedAt:         execution.CreatedAt,
-		}
+		for _, requestID := range requestIDs {
+			execution, err := getRedisExecution(requestID)
+			if err != nil {
+				log.Printf("Warning: skipping execution %s: %v", requestID, err)
+				continue
+			}
 
-		if execution.Result != nil {
-			summary.Success = execution.Result.Success
-			summary.TotalDurationMs = execution.Result.TotalDuration / 1_000_000 // ns to ms
-			summary.StepCount = len(execution.Result.Steps)
-			for _, step := range execution.Result.Steps {
-				if !step.Success {
-					summary.FailedSteps++
-				}
+			summary := summarizeExecution(execution)
+			if !filter.matches(summary) {
+				continue
 			}
+			if len(summaries) == limit {
+				return summaries, true, nil
+			}
+			summaries = append(summaries, summary)
 		}
 
-		summaries = append(summaries, summary)
+		if len(requestIDs) < executionScanBatch {
+			return summaries, false, nil
+		}
+		scoreRange.Offset += executionScanBatch
 	}
+}
 
-	return summaries, nil
+// summarizeExecution builds the listing summary of an execution
+func summarizeExecution(execution *StoredExecution) ExecutionSummary {
+	summary := ExecutionSummary{
+		RequestID:         execution.RequestID,
+		OriginalRequestID: execution.OriginalRequestID,
+		TraceID:           execution.TraceID,
+		AgentName:         execution.AgentName,
+		OriginalRequest:   execution.OriginalRequest,
+		Interrupted:       execution.Interrupted,
+		InProgress:        execution.InProgress,
+		CreatedAt:         execution.CreatedAt,
+	}
+
+	if execution.Result != nil {
+		summary.Success = execution.Result.Success
+		summary.TotalDurationMs = execution.Result.TotalDuration / 1_000_000 // ns to ms
+		summary.StepCount = len(execution.Result.Steps)
+		for _, step := range execution.Result.Steps {
+			if !step.Success {
+				summary.FailedSteps++
+			}
+		}
+	}
+	return summary
 }
 
 // getRedisExecution fetches a single execution from Redis
@@ -1689,6 +2350,21 @@ func deserializeExecution(data []byte) (*StoredExecution, error) {
 	return &execution, nil
 }
 
+// filterMockExecutionSummaries applies filter to the mock summaries, as
+// queryRedisExecutionSummaries does to Redis
+func filterMockExecutionSummaries(filter executionFilter, limit int) ([]ExecutionSummary, bool) {
+	var summaries []ExecutionSummary
+	for _, summary := range getMockExecutionSummaries() {
+		if filter.matches(summary) {
+			summaries = append(summaries, summary)
+		}
+	}
+	if len(summaries) > limit {
+		return summaries[:limit], true
+	}
+	return summaries, false
+}
+
 // getMockExecutionSummaries returns mock execution summaries for development
 func getMockExecutionSummaries() []ExecutionSummary {
 	now := time.Now()
@@ -1697,6 +2373,7 @@ func getMockExecutionSummaries() []ExecutionSummary {
 			RequestID:         "orch-1705312800123456789",
 			OriginalRequestID: "orch-1705312800123456789",
 			TraceID:           "abc123def456",
+			AgentName:         "travel-agent",
 			OriginalRequest:   "What's the weather in Tokyo and convert to Celsius?",
 			Success:           true,
 			StepCount:         2,
@@ -1708,6 +2385,7 @@ func getMockExecutionSummaries() []ExecutionSummary {
 			RequestID:         "orch-1705312700987654321",
 			OriginalRequestID: "orch-1705312700987654321",
 			TraceID:           "xyz789abc012",
+			AgentName:         "travel-agent",
 			OriginalRequest:   "Book a flight from NYC to London and check the weather",
 			Success:           false,
 			StepCount:         3,
@@ -1719,6 +2397,7 @@ func getMockExecutionSummaries() []ExecutionSummary {
 			RequestID:         "orch-1705312600111222333",
 			OriginalRequestID: "orch-1705312600111222333",
 			TraceID:           "mno345pqr678",
+			AgentName:         "trading-bot",
 			OriginalRequest:   "Get stock prices for AAPL, GOOGL, and MSFT",
 			Success:           true,
 			StepCount:         3,
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+const (
+	// gzipMinBytes is the smallest response worth compressing
+	gzipMinBytes = 1024
+
+	// completedExecutionCacheControl is sent for executions that have
+	// finished, which no longer change. It matches the default TTL of
+	// successful execution records.
+	completedExecutionCacheControl = "private, max-age=86400"
+)
+
+// bufferedResponse collects a handler's response so it can be hashed for an
+// ETag and compressed before it is sent
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header { return b.header }
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+	return b.body.Write(p)
+}
+
+// withResponseCaching wraps a JSON API handler's GET responses:
+//
+//   - successful responses get an ETag hashed from their body, and a request
+//     whose If-None-Match matches it gets 304 Not Modified without the body
+//   - responses without their own Cache-Control get "no-cache", so clients
+//     revalidate with the ETag instead of downloading again
+//   - bodies of at least gzipMinBytes are gzip-compressed for clients that
+//     accept it
+//
+// Streaming handlers must not be wrapped, since the response is buffered.
+func withResponseCaching(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next(w, r)
+			return
+		}
+
+		buf := &bufferedResponse{header: w.Header()}
+		next(buf, r)
+
+		status := buf.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		body := buf.body.Bytes()
+		header := w.Header()
+		header.Add("Vary", "Accept-Encoding")
+
+		if status == http.StatusOK {
+			if header.Get("Cache-Control") == "" {
+				header.Set("Cache-Control", "no-cache")
+			}
+			sum := sha256.Sum256(body)
+			// Weak, since the same ETag covers the gzip and identity encodings
+			etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+			header.Set("ETag", etag)
+			if etagMatches(r.Header.Get("If-None-Match"), etag) {
+				header.Del("Content-Type")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+
+		if len(body) < gzipMinBytes || !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			w.WriteHeader(status)
+			w.Write(body)
+			return
+		}
+
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		w.WriteHeader(status)
+		gz := gzip.NewWriter(w)
+		gz.Write(body)
+		gz.Close()
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip
+func acceptsGzip(acceptEncoding string) bool {
+	wildcardOK := false
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		q := strings.ReplaceAll(strings.TrimSpace(params), " ", "")
+		accepted := q != "q=0" && !(strings.HasPrefix(q, "q=0.") && strings.Trim(q[4:], "0") == "")
+
+		switch strings.ToLower(strings.TrimSpace(coding)) {
+		case "gzip":
+			// An explicit gzip entry overrides the wildcard
+			return accepted
+		case "*":
+			wildcardOK = accepted
+		}
+	}
+	return wildcardOK
+}
+
+// etagMatches reports whether an If-None-Match header matches etag, using
+// the weak comparison If-None-Match calls for
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
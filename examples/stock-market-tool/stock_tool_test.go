@@ -0,0 +1,28 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/itsneelabh/gomind/core/coretest"
+)
+
+func TestStockTool(t *testing.T) {
+	// Without an API key the tool answers from mock data, so no network is needed
+	t.Setenv("FINNHUB_API_KEY", "")
+
+	h := coretest.New(t, NewStockTool())
+	h.AssertRegistered("stock_quote", "company_profile", "company_news", "market_news")
+
+	var quote StockQuoteResponse
+	h.Invoke("stock_quote", StockQuoteRequest{Symbol: " aapl "}).AssertStatus(http.StatusOK).JSON(&quote)
+	if quote.Symbol != "AAPL" || quote.CurrentPrice <= 0 {
+		t.Errorf("Unexpected quote: %+v", quote)
+	}
+
+	if cached, _ := h.Memory.Get(t.Context(), "quote:AAPL"); cached == "" {
+		t.Error("Expected quote to be cached in memory")
+	}
+
+	h.Invoke("stock_quote", "not json").AssertStatus(http.StatusBadRequest)
+}
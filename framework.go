@@ -95,6 +95,7 @@ var (
 	WithLogLevel              = core.WithLogLevel
 	WithLogFormat             = core.WithLogFormat
 	WithMemoryProvider        = core.WithMemoryProvider
+	WithMemoryNamespacing     = core.WithMemoryNamespacing
 	WithCircuitBreaker        = core.WithCircuitBreaker
 	WithRetry                 = core.WithRetry
 	WithKubernetes            = core.WithKubernetes
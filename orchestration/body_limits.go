@@ -0,0 +1,105 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var (
+	// ErrRequestTooLarge is returned before sending a request whose body is
+	// over the executor's body limit
+	ErrRequestTooLarge = errors.New("request body too large")
+
+	// ErrResponseTooLarge is returned when a response body is over the
+	// executor's body limit. Reading stops at the limit.
+	ErrResponseTooLarge = errors.New("response body too large")
+)
+
+// SetMaxBodyBytes limits the size of request and response bodies. Oversized
+// requests are rejected before they are sent, and reading a response stops
+// at the limit with a *ServiceCallError wrapping ErrResponseTooLarge, so a
+// misbehaving service cannot exhaust memory. Zero, the default, means no
+// limit.
+func (e *WorkflowExecutor) SetMaxBodyBytes(maxBytes int64) {
+	if maxBytes < 0 {
+		maxBytes = 0
+	}
+	e.maxBodyBytes = maxBytes
+}
+
+// CallAgentStream is like CallAgentJSON but returns the response body
+// unread, for responses too large to hold in memory. The caller must close
+// it. The body limit still applies: reads past it fail with
+// ErrResponseTooLarge.
+func (e *WorkflowExecutor) CallAgentStream(ctx context.Context, agentName string, action string, payload interface{}, opts ...CallOption) (io.ReadCloser, error) {
+	target, err := e.resolveAgent(ctx, agentName)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := e.send(ctx, target, action, payload, opts)
+	if err != nil {
+		return nil, err
+	}
+	if e.maxBodyBytes <= 0 {
+		return resp.Body, nil
+	}
+	return &limitedBody{ReadCloser: resp.Body, remaining: e.maxBodyBytes, err: responseTooLarge(resp)}, nil
+}
+
+// readBody reads resp's body up to the body limit. Over the limit it
+// returns the bytes within it and an error wrapping ErrResponseTooLarge.
+func (e *WorkflowExecutor) readBody(resp *http.Response) ([]byte, error) {
+	if e.maxBodyBytes <= 0 {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("reading response: %w", err)
+		}
+		return body, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, e.maxBodyBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if int64(len(body)) > e.maxBodyBytes {
+		return body[:e.maxBodyBytes], responseTooLarge(resp)
+	}
+	return body, nil
+}
+
+// responseTooLarge builds the error for an oversized response
+func responseTooLarge(resp *http.Response) error {
+	callErr := &ServiceCallError{StatusCode: resp.StatusCode, Err: ErrResponseTooLarge}
+	if resp.Request != nil {
+		callErr.URL = resp.Request.URL.String()
+	}
+	return callErr
+}
+
+// limitedBody fails reads once more than remaining bytes have been read
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	err       error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, b.err
+	}
+	// Read one byte past the limit to tell "exactly at" from "over"
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.remaining {
+		n = int(b.remaining)
+		b.remaining = -1
+		return n, b.err
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
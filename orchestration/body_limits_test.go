@@ -0,0 +1,82 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// bigResponder writes size bytes of JSON string content
+func bigResponder(size int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`"` + strings.Repeat("x", size-2) + `"`))
+	})
+}
+
+func TestWorkflowExecutorResponseLimit(t *testing.T) {
+	discovery := registerTestAgent(t, "big", bigResponder(1024))
+	executor := NewWorkflowExecutor(discovery, nil)
+	executor.SetMaxBodyBytes(100)
+
+	var out string
+	err := executor.CallAgentJSON(context.Background(), "big", "dump", nil, &out)
+	var callErr *ServiceCallError
+	if !errors.As(err, &callErr) || !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("CallAgentJSON() error = %v, want *ServiceCallError wrapping ErrResponseTooLarge", err)
+	}
+
+	executor.SetMaxBodyBytes(1024)
+	if err := executor.CallAgentJSON(context.Background(), "big", "dump", nil, &out); err != nil {
+		t.Errorf("CallAgentJSON() at exactly the limit error = %v", err)
+	}
+}
+
+func TestWorkflowExecutorRequestLimit(t *testing.T) {
+	var calls atomic.Int32
+	discovery := registerTestAgent(t, "echo", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	executor := NewWorkflowExecutor(discovery, nil)
+	executor.SetMaxBodyBytes(10)
+
+	err := executor.CallAgentJSON(context.Background(), "echo", "say", strings.Repeat("x", 100), nil)
+	if !errors.Is(err, ErrRequestTooLarge) {
+		t.Fatalf("CallAgentJSON() error = %v, want ErrRequestTooLarge", err)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("oversized request was sent")
+	}
+}
+
+func TestWorkflowExecutorCallAgentStream(t *testing.T) {
+	discovery := registerTestAgent(t, "big", bigResponder(1024))
+	executor := NewWorkflowExecutor(discovery, nil)
+
+	body, err := executor.CallAgentStream(context.Background(), "big", "dump", nil)
+	if err != nil {
+		t.Fatalf("CallAgentStream() error = %v", err)
+	}
+	data, err := io.ReadAll(body)
+	_ = body.Close()
+	if err != nil || len(data) != 1024 {
+		t.Fatalf("read %d bytes, err = %v; want 1024", len(data), err)
+	}
+
+	executor.SetMaxBodyBytes(100)
+	body, err = executor.CallAgentStream(context.Background(), "big", "dump", nil)
+	if err != nil {
+		t.Fatalf("CallAgentStream() error = %v", err)
+	}
+	defer func() { _ = body.Close() }()
+	data, err = io.ReadAll(body)
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("reading past the limit error = %v, want ErrResponseTooLarge", err)
+	}
+	if len(data) != 100 {
+		t.Errorf("read %d bytes before the error, want 100", len(data))
+	}
+}
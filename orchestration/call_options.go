@@ -0,0 +1,60 @@
+package orchestration
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// CallOption customizes a single WorkflowExecutor call
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	timeout time.Duration
+}
+
+// WithCallTimeout bounds one call, overriding the executor's default
+// timeout (GOMIND_ORCHESTRATION_TIMEOUT). An earlier context deadline still
+// applies. A timed out call returns a *ServiceCallError wrapping
+// core.ErrTimeout.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// callContext returns the context a call runs under. Without
+// WithCallTimeout, a deadline already on ctx is kept and only calls without
+// one get the executor's default timeout.
+func (e *WorkflowExecutor) callContext(ctx context.Context, opts []CallOption) (context.Context, context.CancelFunc) {
+	var options callOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	timeout := options.timeout
+	if timeout <= 0 {
+		if _, ok := ctx.Deadline(); ok {
+			return ctx, func() {}
+		}
+		if e.client != nil {
+			timeout = e.client.timeout
+		}
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// cancelOnClose releases a call's timeout once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
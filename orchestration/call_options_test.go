@@ -0,0 +1,71 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// slowAgent responds after delay, or when the request is abandoned
+func slowAgent(t *testing.T, delay time.Duration) core.Discovery {
+	return registerTestAgent(t, "slow", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+			_, _ = w.Write([]byte(`{"done": true}`))
+		case <-r.Context().Done():
+		}
+	}))
+}
+
+func TestWorkflowExecutorCallTimeout(t *testing.T) {
+	executor := NewWorkflowExecutor(slowAgent(t, 200*time.Millisecond), nil)
+
+	_, err := executor.CallAgent(context.Background(), "slow", "work", nil, WithCallTimeout(20*time.Millisecond))
+	var callErr *ServiceCallError
+	if !errors.As(err, &callErr) || !errors.Is(err, core.ErrTimeout) {
+		t.Fatalf("CallAgent() error = %v, want *ServiceCallError wrapping ErrTimeout", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CallAgent() error = %v, want it to wrap context.DeadlineExceeded", err)
+	}
+}
+
+func TestWorkflowExecutorDefaultTimeout(t *testing.T) {
+	executor := NewWorkflowExecutor(slowAgent(t, 200*time.Millisecond), nil)
+	executor.client.timeout = 20 * time.Millisecond
+
+	if _, err := executor.CallAgent(context.Background(), "slow", "work", nil); !errors.Is(err, core.ErrTimeout) {
+		t.Errorf("CallAgent() without deadline error = %v, want ErrTimeout from the default timeout", err)
+	}
+}
+
+func TestWorkflowExecutorContextDeadlineOverridesDefault(t *testing.T) {
+	executor := NewWorkflowExecutor(slowAgent(t, 50*time.Millisecond), nil)
+	executor.client.timeout = 10 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := executor.CallAgent(ctx, "slow", "work", nil); err != nil {
+		t.Errorf("CallAgent() with a longer context deadline error = %v", err)
+	}
+}
+
+func TestWorkflowExecutorServerErrorIsNotTimeout(t *testing.T) {
+	discovery := registerTestAgent(t, "broken", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	executor := NewWorkflowExecutor(discovery, nil)
+
+	_, err := executor.CallAgent(context.Background(), "broken", "work", nil, WithCallTimeout(time.Second))
+	var callErr *ServiceCallError
+	if !errors.As(err, &callErr) || callErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("CallAgent() error = %v, want a 500 *ServiceCallError", err)
+	}
+	if errors.Is(err, core.ErrTimeout) {
+		t.Error("server error reported as a timeout")
+	}
+}
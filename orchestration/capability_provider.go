@@ -52,11 +52,14 @@ func NewDefaultCapabilityProvider(catalog *AgentCatalog) *DefaultCapabilityProvi
 // This is critical for hallucination validation - agents with only internal capabilities
 // are excluded from both the formatted info AND the agent names list.
 func (d *DefaultCapabilityProvider) GetCapabilities(ctx context.Context, request string, metadata map[string]interface{}) (*CapabilityResult, error) {
+	// Only capabilities allowed by the request's DiscoveryFilter are offered
+	catalog := d.catalog.Scoped(GetDiscoveryFilter(ctx))
+
 	// Get agent names using the same filtering as FormatForLLM (excludes internal-only agents)
-	agentNames := d.catalog.GetPublicAgentNames()
+	agentNames := catalog.GetPublicAgentNames()
 
 	return &CapabilityResult{
-		FormattedInfo: d.catalog.FormatForLLM(),
+		FormattedInfo: catalog.FormatForLLM(),
 		AgentNames:    agentNames,
 	}, nil
 }
@@ -285,6 +288,7 @@ func (s *ServiceCapabilityProvider) queryExternalService(ctx context.Context, re
 		Metadata:  metadata,
 		TopK:      s.topK,      // Use configured value
 		Threshold: s.threshold, // Use configured value
+		Filter:    GetDiscoveryFilter(ctx),
 	}
 
 	// Marshal request to JSON
@@ -434,6 +438,10 @@ type CapabilityRequest struct {
 	Metadata  map[string]interface{} `json:"metadata"`  // Optional metadata
 	TopK      int                    `json:"top_k"`     // Number of results to return
 	Threshold float64                `json:"threshold"` // Minimum similarity threshold
+
+	// Filter is the request's DiscoveryFilter. Services should only return
+	// capabilities it allows; the orchestrator rejects plans outside it.
+	Filter *DiscoveryFilter `json:"filter,omitempty"`
 }
 
 // CapabilityResponse defines the response from external capability service
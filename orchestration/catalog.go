@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -48,6 +49,15 @@ type EnhancedCapability struct {
 	Tags        []string    `json:"tags"`
 	Examples    []Example   `json:"examples,omitempty"`
 
+	// OutputTypes and OutputSummary declare the capability's output contract.
+	// Step responses are validated against them before synthesis.
+	OutputTypes   []string            `json:"output_types,omitempty"`
+	OutputSummary *core.SchemaSummary `json:"output_summary,omitempty"`
+
+	// ResultEnvelope means responses are core.ToolResponse envelopes that the
+	// executor unwraps into StepResult fields
+	ResultEnvelope bool `json:"result_envelope,omitempty"`
+
 	// Internal marks capabilities that should not be exposed to LLM planning.
 	// Internal capabilities are still callable via HTTP but are excluded from
 	// the service catalog used for AI orchestration decisions.
@@ -341,6 +351,11 @@ func (c *AgentCatalog) convertBasicCapabilities(caps []core.Capability) []Enhanc
 			Description: cap.Description,
 			Endpoint:    cap.Endpoint,
 			Internal:    cap.Internal, // Preserve internal flag for LLM filtering
+
+			// Preserve the output contract for step response validation
+			OutputTypes:    cap.OutputTypes,
+			OutputSummary:  cap.OutputSummary,
+			ResultEnvelope: cap.ResultEnvelope,
 		}
 		// Use defaults if not set
 		if enhanced[i].Endpoint == "" {
@@ -404,6 +419,17 @@ func (c *AgentCatalog) enrichCapabilitiesWithInputSummary(httpCaps []EnhancedCap
 				httpCaps[i].Internal = true
 			}
 
+			// Fill in the output contract when the HTTP endpoint omits it
+			if len(httpCaps[i].OutputTypes) == 0 {
+				httpCaps[i].OutputTypes = regCap.OutputTypes
+			}
+			if httpCaps[i].OutputSummary == nil {
+				httpCaps[i].OutputSummary = regCap.OutputSummary
+			}
+			if regCap.ResultEnvelope {
+				httpCaps[i].ResultEnvelope = true
+			}
+
 			// Add parameters from InputSummary if not present in HTTP capability
 			if len(httpCaps[i].Parameters) == 0 && regCap.InputSummary != nil {
 				params := make([]Parameter, 0)
@@ -526,7 +552,16 @@ func (c *AgentCatalog) FormatForLLM() string {
 	var output string
 	output = "Available Agents and Capabilities:\n\n"
 
-	for id, agent := range c.agents {
+	// Sorted so the same catalog always renders the same text, which keeps
+	// capability fingerprints stable across requests
+	ids := make([]string, 0, len(c.agents))
+	for id := range c.agents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		agent := c.agents[id]
 		// Filter out internal capabilities - only include public ones for LLM planning
 		publicCaps := make([]EnhancedCapability, 0, len(agent.Capabilities))
 		for _, cap := range agent.Capabilities {
@@ -0,0 +1,512 @@
+package orchestration
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StepCondition expressions gate whether a step runs based on earlier results.
+//
+// The grammar is intentionally small and side-effect free:
+//
+//	expr       := or
+//	or         := and ("||" and)*
+//	and        := unary ("&&" unary)*
+//	unary      := "!" unary | comparison
+//	comparison := operand (("==" | "!=" | "<" | "<=" | ">" | ">=") operand)?
+//	operand    := literal | reference | "(" expr ")"
+//	literal    := number | 'string' | "string" | true | false | null
+//	reference  := segment ("." segment)* | "${" segment ("." segment)* "}"
+//
+// A reference is a dotted path such as "step-1.available" or
+// "step-2.flights.0.price". How the path is resolved is up to the caller
+// (see ConditionResolver). Missing values resolve to null, ordering
+// comparisons between mismatched types are false, and a bare operand is
+// evaluated for truthiness, so evaluation never fails once an expression
+// has compiled.
+
+// ConditionResolver looks up the value of a reference path. It returns false
+// when the path does not exist.
+type ConditionResolver func(path []string) (interface{}, bool)
+
+// Condition is a compiled step condition expression
+type Condition struct {
+	source string
+	root   conditionNode
+	refs   [][]string
+}
+
+// CompileCondition parses a condition expression. Plans and workflows compile
+// their conditions when they are loaded so syntax errors surface before
+// anything executes.
+func CompileCondition(expression string) (*Condition, error) {
+	tokens, err := tokenizeCondition(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition %q: %w", expression, err)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("invalid condition %q: expression is empty", expression)
+	}
+
+	p := &conditionParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q at position %d", p.tokens[p.pos].text, p.tokens[p.pos].pos)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition %q: %w", expression, err)
+	}
+
+	return &Condition{source: expression, root: root, refs: p.refs}, nil
+}
+
+// String returns the original expression
+func (c *Condition) String() string {
+	return c.source
+}
+
+// References returns every reference path used by the expression
+func (c *Condition) References() [][]string {
+	refs := make([][]string, len(c.refs))
+	for i, ref := range c.refs {
+		refs[i] = append([]string(nil), ref...)
+	}
+	return refs
+}
+
+// Evaluate runs the expression against the values returned by resolve
+func (c *Condition) Evaluate(resolve ConditionResolver) bool {
+	return truthy(c.root.eval(resolve))
+}
+
+// ResolvePath walks a decoded JSON value (maps, slices) along path.
+// Numeric segments index into slices.
+func ResolvePath(value interface{}, path []string) (interface{}, bool) {
+	current := value
+	for _, segment := range path {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			next, ok := v[segment]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, false
+			}
+			current = v[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// stepResultValue returns the decoded output of a step for condition
+// evaluation, preferring the contract-validated Output over the raw Response
+func stepResultValue(result *StepResult) interface{} {
+	if result.Output != nil {
+		return result.Output
+	}
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(result.Response), &decoded); err == nil {
+		return decoded
+	}
+	if result.Response == "" {
+		return nil
+	}
+	return result.Response
+}
+
+// --- AST ---
+
+type conditionNode interface {
+	eval(resolve ConditionResolver) interface{}
+}
+
+type literalNode struct{ value interface{} }
+
+func (n literalNode) eval(ConditionResolver) interface{} { return n.value }
+
+type referenceNode struct{ path []string }
+
+func (n referenceNode) eval(resolve ConditionResolver) interface{} {
+	if resolve == nil {
+		return nil
+	}
+	value, ok := resolve(n.path)
+	if !ok {
+		return nil
+	}
+	return normalizeConditionValue(value)
+}
+
+type notNode struct{ operand conditionNode }
+
+func (n notNode) eval(resolve ConditionResolver) interface{} {
+	return !truthy(n.operand.eval(resolve))
+}
+
+type logicalNode struct {
+	and         bool
+	left, right conditionNode
+}
+
+func (n logicalNode) eval(resolve ConditionResolver) interface{} {
+	left := truthy(n.left.eval(resolve))
+	if n.and && !left {
+		return false
+	}
+	if !n.and && left {
+		return true
+	}
+	return truthy(n.right.eval(resolve))
+}
+
+type compareNode struct {
+	op          string
+	left, right conditionNode
+}
+
+func (n compareNode) eval(resolve ConditionResolver) interface{} {
+	left := n.left.eval(resolve)
+	right := n.right.eval(resolve)
+
+	switch n.op {
+	case "==":
+		return conditionEqual(left, right)
+	case "!=":
+		return !conditionEqual(left, right)
+	}
+
+	var cmp int
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return false
+		}
+		switch {
+		case l < r:
+			cmp = -1
+		case l > r:
+			cmp = 1
+		}
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(l, r)
+	default:
+		return false
+	}
+
+	switch n.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default: // ">="
+		return cmp >= 0
+	}
+}
+
+func conditionEqual(left, right interface{}) bool {
+	switch l := left.(type) {
+	case nil:
+		return right == nil
+	case float64, string, bool:
+		return l == right
+	default:
+		// Objects and arrays compare by their JSON encoding
+		leftJSON, errL := json.Marshal(left)
+		rightJSON, errR := json.Marshal(right)
+		return errL == nil && errR == nil && string(leftJSON) == string(rightJSON)
+	}
+}
+
+// normalizeConditionValue converts Go numeric types to float64 so values from
+// typed outputs compare the same way as values decoded from JSON
+func normalizeConditionValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	}
+	return value
+}
+
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+// --- Tokenizer ---
+
+type conditionTokenKind int
+
+const (
+	tokenLiteral conditionTokenKind = iota
+	tokenReference
+	tokenOperator
+	tokenLParen
+	tokenRParen
+)
+
+type conditionToken struct {
+	kind  conditionTokenKind
+	text  string
+	value interface{}
+	path  []string
+	pos   int
+}
+
+func tokenizeCondition(expression string) ([]conditionToken, error) {
+	var tokens []conditionToken
+	i := 0
+	for i < len(expression) {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, conditionToken{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, conditionToken{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case strings.HasPrefix(expression[i:], "&&"), strings.HasPrefix(expression[i:], "||"),
+			strings.HasPrefix(expression[i:], "=="), strings.HasPrefix(expression[i:], "!="),
+			strings.HasPrefix(expression[i:], "<="), strings.HasPrefix(expression[i:], ">="):
+			tokens = append(tokens, conditionToken{kind: tokenOperator, text: expression[i : i+2], pos: i})
+			i += 2
+		case c == '<' || c == '>' || c == '!':
+			tokens = append(tokens, conditionToken{kind: tokenOperator, text: string(c), pos: i})
+			i++
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(expression[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			text := expression[i : i+end+2]
+			tokens = append(tokens, conditionToken{kind: tokenLiteral, text: text, value: expression[i+1 : i+end+1], pos: i})
+			i += end + 2
+		case c == '$':
+			if !strings.HasPrefix(expression[i:], "${") {
+				return nil, fmt.Errorf("unexpected '$' at position %d", i)
+			}
+			end := strings.IndexByte(expression[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated reference at position %d", i)
+			}
+			inner := strings.TrimSpace(expression[i+2 : i+end])
+			path, err := parseReferencePath(inner)
+			if err != nil {
+				return nil, fmt.Errorf("%w at position %d", err, i)
+			}
+			tokens = append(tokens, conditionToken{kind: tokenReference, text: expression[i : i+end+1], path: path, pos: i})
+			i += end + 1
+		case isDigit(c) || (c == '-' && i+1 < len(expression) && isDigit(expression[i+1])):
+			start := i
+			i++
+			for i < len(expression) && (isDigit(expression[i]) || expression[i] == '.') {
+				i++
+			}
+			number, err := strconv.ParseFloat(expression[start:i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", expression[start:i], start)
+			}
+			tokens = append(tokens, conditionToken{kind: tokenLiteral, text: expression[start:i], value: number, pos: start})
+		case isIdentStart(c):
+			start := i
+			for i < len(expression) && (isIdentPart(expression[i]) || expression[i] == '.') {
+				i++
+			}
+			text := expression[start:i]
+			switch text {
+			case "true":
+				tokens = append(tokens, conditionToken{kind: tokenLiteral, text: text, value: true, pos: start})
+			case "false":
+				tokens = append(tokens, conditionToken{kind: tokenLiteral, text: text, value: false, pos: start})
+			case "null", "nil":
+				tokens = append(tokens, conditionToken{kind: tokenLiteral, text: text, value: nil, pos: start})
+			default:
+				path, err := parseReferencePath(text)
+				if err != nil {
+					return nil, fmt.Errorf("%w at position %d", err, start)
+				}
+				tokens = append(tokens, conditionToken{kind: tokenReference, text: text, path: path, pos: start})
+			}
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+	return tokens, nil
+}
+
+func parseReferencePath(text string) ([]string, error) {
+	segments := strings.Split(text, ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("invalid reference %q", text)
+		}
+		for j := 0; j < len(segment); j++ {
+			if !isIdentPart(segment[j]) {
+				return nil, fmt.Errorf("invalid reference %q", text)
+			}
+		}
+	}
+	return segments, nil
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '-'
+}
+
+// --- Parser ---
+
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+	refs   [][]string
+}
+
+func (p *conditionParser) peekOperator(ops ...string) (string, bool) {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenOperator {
+		return "", false
+	}
+	for _, op := range ops {
+		if p.tokens[p.pos].text == op {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *conditionParser) parseOr() (conditionNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.peekOperator("||"); !ok {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{and: false, left: left, right: right}
+	}
+}
+
+func (p *conditionParser) parseAnd() (conditionNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.peekOperator("&&"); !ok {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{and: true, left: left, right: right}
+	}
+}
+
+func (p *conditionParser) parseUnary() (conditionNode, error) {
+	if _, ok := p.peekOperator("!"); ok {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *conditionParser) parseComparison() (conditionNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.peekOperator("==", "!=", "<", "<=", ">", ">=")
+	if !ok {
+		return left, nil
+	}
+	p.pos++
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return compareNode{op: op, left: left, right: right}, nil
+}
+
+func (p *conditionParser) parseOperand() (conditionNode, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+
+	switch token.kind {
+	case tokenLiteral:
+		return literalNode{value: token.value}, nil
+	case tokenReference:
+		p.refs = append(p.refs, token.path)
+		return referenceNode{path: token.path}, nil
+	case tokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenRParen {
+			return nil, fmt.Errorf("missing ')' for '(' at position %d", token.pos)
+		}
+		p.pos++
+		return inner, nil
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", token.text, token.pos)
+}
@@ -0,0 +1,244 @@
+package orchestration
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+func TestCondition_Evaluate(t *testing.T) {
+	values := map[string]interface{}{
+		"step-1": map[string]interface{}{
+			"available": true,
+			"seats":     float64(3),
+			"status":    "open",
+			"flights":   []interface{}{map[string]interface{}{"price": 420.5}},
+		},
+		"step-2": map[string]interface{}{"available": false},
+	}
+	resolve := func(path []string) (interface{}, bool) {
+		return ResolvePath(values, path)
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"step-1.available == true", true},
+		{"step-1.available", true},
+		{"!step-2.available", true},
+		{"step-1.seats >= 3 && step-1.status == 'open'", true},
+		{`step-1.status != "closed"`, true},
+		{"step-1.flights.0.price < 500", true},
+		{"step-2.available || step-1.seats > 5", false},
+		{"!(step-1.available && step-2.available)", true},
+		{"step-1.missing == null", true},
+		{"step-1.missing", false},
+		{"step-1.status > 3", false},
+		{"step-1.seats == -1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			condition, err := CompileCondition(tt.expr)
+			if err != nil {
+				t.Fatalf("CompileCondition() error = %v", err)
+			}
+			if got := condition.Evaluate(resolve); got != tt.want {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileCondition_Errors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"step-1.available ==",
+		"(step-1.available",
+		"step-1.available == 'open",
+		"step-1..available",
+		"step-1.available = true",
+		"step-1.available true",
+		"len(step-1.flights) > 0",
+	} {
+		if _, err := CompileCondition(expr); err == nil {
+			t.Errorf("CompileCondition(%q) expected error", expr)
+		}
+	}
+}
+
+func TestCompilePlanConditions(t *testing.T) {
+	plan := &RoutingPlan{Steps: []RoutingStep{
+		{StepID: "step-1"},
+		{StepID: "step-2", DependsOn: []string{"step-1"}, Condition: "step-1.available == true"},
+	}}
+	if _, err := compilePlanConditions(plan); err != nil {
+		t.Fatalf("compilePlanConditions() error = %v", err)
+	}
+
+	plan.Steps[1].DependsOn = nil
+	_, err := compilePlanConditions(plan)
+	if err == nil || !strings.Contains(err.Error(), "not in depends_on") {
+		t.Errorf("Expected depends_on error, got %v", err)
+	}
+}
+
+func TestSmartExecutor_ConditionalSteps(t *testing.T) {
+	catalog := &AgentCatalog{
+		agents: map[string]*AgentInfo{
+			"agent-1": {
+				Registration: &core.ServiceRegistration{ID: "agent-1", Name: "travel", Address: "localhost", Port: 8080},
+				Capabilities: []EnhancedCapability{
+					{Name: "check_seats", Endpoint: "/api/check_seats"},
+					{Name: "book", Endpoint: "/api/book"},
+					{Name: "notify", Endpoint: "/api/notify"},
+				},
+			},
+		},
+	}
+
+	executor := NewSmartExecutor(catalog)
+	mockRT := NewMockRoundTripper()
+	mockRT.SetResponse("http://localhost:8080/api/check_seats", http.StatusOK, `{"available": false}`)
+	mockRT.SetResponse("http://localhost:8080/api/book", http.StatusOK, `{"booked": true}`)
+	mockRT.SetResponse("http://localhost:8080/api/notify", http.StatusOK, `{"sent": true}`)
+	executor.httpClient = &http.Client{Transport: mockRT}
+	executor.SetMaxAttempts(1)
+
+	var callbackSteps []string
+	executor.SetOnStepComplete(func(stepIndex, totalSteps int, step RoutingStep, result StepResult) {
+		if result.Skipped {
+			callbackSteps = append(callbackSteps, step.StepID)
+		}
+	})
+
+	plan := &RoutingPlan{
+		PlanID: "conditional-plan",
+		Steps: []RoutingStep{
+			{StepID: "step-1", AgentName: "travel", Metadata: map[string]interface{}{"capability": "check_seats"}},
+			{StepID: "step-2", AgentName: "travel", DependsOn: []string{"step-1"}, Condition: "step-1.available == true",
+				Metadata: map[string]interface{}{"capability": "book"}},
+			{StepID: "step-3", AgentName: "travel", DependsOn: []string{"step-2"},
+				Metadata: map[string]interface{}{"capability": "notify"}},
+			{StepID: "step-4", AgentName: "travel", DependsOn: []string{"step-1"}, Condition: "!step-1.available",
+				Metadata: map[string]interface{}{"capability": "notify"}},
+		},
+	}
+
+	result, err := executor.Execute(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !result.Success {
+		t.Error("Skipped steps should not fail the plan")
+	}
+
+	byID := make(map[string]StepResult)
+	for _, step := range result.Steps {
+		byID[step.StepID] = step
+	}
+	if !byID["step-1"].Success || !byID["step-4"].Success {
+		t.Errorf("Expected step-1 and step-4 to run, got %+v", result.Steps)
+	}
+	if !byID["step-2"].Skipped || !strings.Contains(byID["step-2"].Error, "condition") {
+		t.Errorf("Expected step-2 to be skipped by its condition, got %+v", byID["step-2"])
+	}
+	if !byID["step-3"].Skipped || !strings.Contains(byID["step-3"].Error, "step-2") {
+		t.Errorf("Expected step-3 skip to cascade from step-2, got %+v", byID["step-3"])
+	}
+	if len(callbackSteps) != 2 {
+		t.Errorf("Expected step callbacks for both skipped steps, got %v", callbackSteps)
+	}
+}
+
+func TestStepConditionResolver_ResponsePrefix(t *testing.T) {
+	stepResults := map[string]*StepResult{
+		"step-1": {StepID: "step-1", Success: true, Response: `{"status": "ok"}`},
+		"step-2": {StepID: "step-2", Success: true, Response: `{"response": {"status": "nested"}}`},
+		"step-3": {StepID: "step-3", Success: false, Response: `{"status": "ok"}`},
+	}
+	resolve := stepConditionResolver(stepResults)
+
+	for expr, want := range map[string]bool{
+		"step-1.response.status == 'ok'":     true,
+		"step-1.status == 'ok'":              true,
+		"step-2.response.status == 'nested'": true,
+		"step-3.response.status == 'ok'":     false,
+	} {
+		condition, err := CompileCondition(expr)
+		if err != nil {
+			t.Fatalf("CompileCondition(%q) error = %v", expr, err)
+		}
+		if got := condition.Evaluate(resolve); got != want {
+			t.Errorf("%s = %v, want %v", expr, got, want)
+		}
+	}
+}
+
+func TestSmartExecutor_InvalidConditionRejected(t *testing.T) {
+	executor := NewSmartExecutor(&AgentCatalog{agents: map[string]*AgentInfo{}})
+	plan := &RoutingPlan{Steps: []RoutingStep{{StepID: "step-1", Condition: "step-0.ok =="}}}
+
+	if _, err := executor.Execute(context.Background(), plan); err == nil {
+		t.Error("Expected invalid condition to be rejected before execution")
+	}
+}
+
+func TestWorkflowDAG_MarkNodeSkipped(t *testing.T) {
+	dag := NewWorkflowDAG()
+	dag.AddNode("check", nil)
+	dag.AddNode("book", []string{"check"})
+	dag.AddNode("notify", []string{"book"})
+	dag.AddNode("report", []string{"check"})
+	if err := dag.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	dag.MarkNodeCompleted("check")
+	skipped := dag.MarkNodeSkipped("book")
+	if len(skipped) != 2 || skipped[0] != "book" || skipped[1] != "notify" {
+		t.Errorf("MarkNodeSkipped() = %v, want [book notify]", skipped)
+	}
+
+	stats := dag.GetStatistics()
+	if stats.SkippedNodes != 2 || stats.CompletedNodes != 1 {
+		t.Errorf("Unexpected statistics: %+v", stats)
+	}
+	if ready := dag.GetReadyNodes(); len(ready) != 1 || ready[0] != "report" {
+		t.Errorf("GetReadyNodes() = %v, want [report]", ready)
+	}
+}
+
+func TestCompileWorkflowConditions(t *testing.T) {
+	workflow := &WorkflowDefinition{Steps: []WorkflowStepDefinition{
+		{Name: "check"},
+		{Name: "book", DependsOn: []string{"check"},
+			Condition: &StepCondition{If: "${steps.check.output.available} == true && inputs.confirm"}},
+	}}
+	conditions, err := compileWorkflowConditions(workflow)
+	if err != nil {
+		t.Fatalf("compileWorkflowConditions() error = %v", err)
+	}
+
+	execution := &WorkflowExecution{
+		Inputs: map[string]interface{}{"confirm": true},
+		Steps: map[string]*StepExecution{
+			"check": {Status: StepCompleted, Output: map[string]interface{}{"available": true}},
+		},
+	}
+	if !conditions["book"].Evaluate(workflowConditionResolver(execution)) {
+		t.Error("Expected condition to be true")
+	}
+
+	workflow.Steps[1].Condition.If = "steps.report.status == 'completed'"
+	if _, err := compileWorkflowConditions(workflow); err == nil {
+		t.Error("Expected error for reference outside depends_on")
+	}
+	workflow.Steps[1].Condition.If = "check.available"
+	if _, err := compileWorkflowConditions(workflow); err == nil {
+		t.Error("Expected error for unsupported reference")
+	}
+}
@@ -0,0 +1,223 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/itsneelabh/gomind/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// compilePlanConditions compiles every step condition in the plan and checks
+// that each reference names a step the conditional step depends on, so the
+// referenced result is guaranteed to exist when the condition is evaluated.
+func compilePlanConditions(plan *RoutingPlan) (map[string]*Condition, error) {
+	conditions := make(map[string]*Condition)
+	for _, step := range plan.Steps {
+		if step.Condition == "" {
+			continue
+		}
+
+		condition, err := CompileCondition(step.Condition)
+		if err != nil {
+			return nil, fmt.Errorf("step %s: %w", step.StepID, err)
+		}
+
+		dependsOn := make(map[string]bool, len(step.DependsOn))
+		for _, dep := range step.DependsOn {
+			dependsOn[dep] = true
+		}
+		for _, ref := range condition.References() {
+			if !dependsOn[ref[0]] {
+				return nil, fmt.Errorf("step %s: condition references %s, which is not in depends_on", step.StepID, ref[0])
+			}
+		}
+		conditions[step.StepID] = condition
+	}
+	return conditions, nil
+}
+
+// applyStepConditions records skipped results for ready steps whose condition
+// is false or that depend on a skipped step, and returns the steps that
+// should run. It must be called between batches, while no step goroutines
+// are writing to stepResults.
+func (e *SmartExecutor) applyStepConditions(ctx context.Context, plan *RoutingPlan, ready []RoutingStep, conditions map[string]*Condition, stepResults map[string]*StepResult, executed map[string]bool, result *ExecutionResult) []RoutingStep {
+	runnable := ready[:0:0]
+	for _, step := range ready {
+		reason := ""
+		for _, dep := range step.DependsOn {
+			if depResult, ok := stepResults[dep]; ok && depResult.Skipped {
+				reason = fmt.Sprintf("skipped because dependency %s was skipped", dep)
+				break
+			}
+		}
+		if condition := conditions[step.StepID]; reason == "" && condition != nil {
+			if !condition.Evaluate(stepConditionResolver(stepResults)) {
+				reason = fmt.Sprintf("skipped because condition %q was false", condition.String())
+			}
+		}
+
+		if reason == "" {
+			runnable = append(runnable, step)
+			continue
+		}
+
+		skippedResult := StepResult{
+			StepID:      step.StepID,
+			AgentName:   step.AgentName,
+			Namespace:   step.Namespace,
+			Instruction: step.Instruction,
+			Success:     false,
+			Skipped:     true,
+			Error:       reason,
+			SubQuestion: step.SubQuestion,
+			StartTime:   time.Now(),
+		}
+		stepResults[step.StepID] = &skippedResult
+		result.Steps = append(result.Steps, skippedResult)
+		skippedStepIndex := len(result.Steps) - 1
+		executed[step.StepID] = true
+
+		if e.logger != nil {
+			e.logger.InfoWithContext(ctx, "Step skipped", map[string]interface{}{
+				"operation":  "conditional_step",
+				"plan_id":    plan.PlanID,
+				"step_id":    step.StepID,
+				"agent_name": step.AgentName,
+				"condition":  step.Condition,
+				"reason":     reason,
+			})
+		}
+		telemetry.AddSpanEvent(ctx, "step_skipped",
+			attribute.String("step_id", step.StepID),
+			attribute.String("reason", reason),
+		)
+		telemetry.Counter("orchestration.step.skipped",
+			"module", telemetry.ModuleOrchestration,
+		)
+
+		// Skipped steps still report through callbacks so progress UIs see every step
+		e.safeInvokeStepCallback(e.onStepComplete, skippedStepIndex, len(plan.Steps), step, skippedResult)
+		if ctxCallback := GetStepCallback(ctx); ctxCallback != nil {
+			e.safeInvokeStepCallback(ctxCallback, skippedStepIndex, len(plan.Steps), step, skippedResult)
+		}
+	}
+	return runnable
+}
+
+// stepConditionResolver resolves "<step_id>.<field>..." references against
+// completed step results. "<step_id>.response.<field>" is accepted as an
+// explicit form of the same path unless the response has its own
+// "response" field.
+func stepConditionResolver(stepResults map[string]*StepResult) ConditionResolver {
+	return func(path []string) (interface{}, bool) {
+		stepResult, ok := stepResults[path[0]]
+		if !ok || !stepResult.Success {
+			return nil, false
+		}
+		value := stepResultValue(stepResult)
+		if len(path) > 1 && path[1] == "response" {
+			if v, ok := ResolvePath(value, path[1:]); ok {
+				return v, true
+			}
+			return ResolvePath(value, path[2:])
+		}
+		return ResolvePath(value, path[1:])
+	}
+}
+
+// compileWorkflowConditions compiles the "if" expression of every workflow
+// step. References must be "steps.<name>.output[.field...]",
+// "steps.<name>.status" for a step listed in depends_on, or
+// "inputs.<name>[.field...]".
+func compileWorkflowConditions(workflow *WorkflowDefinition) (map[string]*Condition, error) {
+	conditions := make(map[string]*Condition)
+	for _, step := range workflow.Steps {
+		if step.Condition == nil || step.Condition.If == "" {
+			continue
+		}
+
+		condition, err := CompileCondition(step.Condition.If)
+		if err != nil {
+			return nil, fmt.Errorf("step %s: %w", step.Name, err)
+		}
+
+		dependsOn := make(map[string]bool, len(step.DependsOn))
+		for _, dep := range step.DependsOn {
+			dependsOn[dep] = true
+		}
+		for _, ref := range condition.References() {
+			switch {
+			case ref[0] == "inputs" && len(ref) > 1:
+			case ref[0] == "steps" && len(ref) > 2 && (ref[2] == "output" || (ref[2] == "status" && len(ref) == 3)):
+				if !dependsOn[ref[1]] {
+					return nil, fmt.Errorf("step %s: condition references step %s, which is not in depends_on", step.Name, ref[1])
+				}
+			default:
+				return nil, fmt.Errorf("step %s: unsupported condition reference %q", step.Name, strings.Join(ref, "."))
+			}
+		}
+		conditions[step.Name] = condition
+	}
+	return conditions, nil
+}
+
+// workflowConditionResolver resolves workflow condition references against
+// the execution's inputs and completed step outputs
+func workflowConditionResolver(execution *WorkflowExecution) ConditionResolver {
+	return func(path []string) (interface{}, bool) {
+		if path[0] == "inputs" {
+			return ResolvePath(execution.Inputs, path[1:])
+		}
+
+		stepExec, ok := execution.Steps[path[1]]
+		if !ok {
+			return nil, false
+		}
+		if path[2] == "status" {
+			return string(stepExec.Status), true
+		}
+		if stepExec.Status != StepCompleted {
+			return nil, false
+		}
+		return ResolvePath(stepExec.Output, path[3:])
+	}
+}
+
+// skipWorkflowStep marks a step whose condition was false, and every step
+// depending on it, as skipped
+func (e *WorkflowEngine) skipWorkflowStep(ctx context.Context, execution *WorkflowExecution, stepID string, condition *Condition) {
+	for _, skippedID := range execution.DAG.MarkNodeSkipped(stepID) {
+		stepExec, ok := execution.Steps[skippedID]
+		if !ok {
+			continue
+		}
+		stepExec.Status = StepSkipped
+		if skippedID == stepID {
+			stepExec.Error = fmt.Sprintf("skipped because condition %q was false", condition.String())
+		} else {
+			stepExec.Error = fmt.Sprintf("skipped because dependency %s was skipped", stepID)
+		}
+
+		if err := e.stateStore.UpdateStepExecution(ctx, execution.ID, stepExec); err != nil {
+			e.logger.ErrorWithContext(ctx, "Failed to update step state", map[string]interface{}{
+				"execution_id": execution.ID,
+				"step_id":      skippedID,
+				"error":        err.Error(),
+			})
+		}
+	}
+
+	e.logger.InfoWithContext(ctx, "Workflow step skipped", map[string]interface{}{
+		"operation":    "conditional_step",
+		"execution_id": execution.ID,
+		"step_id":      stepID,
+		"condition":    condition.String(),
+	})
+	telemetry.AddSpanEvent(ctx, "workflow_step_skipped",
+		attribute.String("step_id", stepID),
+		attribute.String("workflow_id", execution.ID),
+	)
+}
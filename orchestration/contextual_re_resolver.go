@@ -186,6 +186,7 @@ func (r *ContextualReResolver) ReResolve(
 		MaxTokens:        1000,
 		Model:            response.Model,
 		Provider:         response.Provider,
+		IdempotencyKey:   response.IdempotencyKey,
 		Response:         response.Content,
 		PromptTokens:     response.Usage.PromptTokens,
 		CompletionTokens: response.Usage.CompletionTokens,
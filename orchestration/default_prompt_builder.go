@@ -272,6 +272,8 @@ Important:
 6. Include all necessary steps to fulfill the request
 7. Be specific in instructions - what should each step accomplish?
 8. For coordinates (lat/lon), use numeric values like 35.6897 not "35.6897"
+9. To run a step only if an earlier result allows it, add "condition": "step-1.available == true" (fields of step-1's response; step-1 must be in depends_on). If the condition is false, the step and its dependents are skipped
+10. If the request asks several independent things (e.g. "weather in Tokyo and convert USD to JPY"), set "sub_question" on each step to the part of the request it answers, using the same text for steps that answer the same part
 
 CRITICAL FORMAT RULES (applies to all LLM providers):
 - You are a JSON API. Your ONLY output is a raw JSON object.
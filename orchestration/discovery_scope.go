@@ -0,0 +1,178 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DiscoveryFilter limits which capabilities a request may plan over, e.g. to
+// the agents a tenant is allowed to use. All non-empty fields must match:
+//   - Namespaces: the agent's registration namespace (metadata "namespace")
+//   - Tags: at least one of the capability's tags
+//   - Capabilities: the capability name or "agent/capability"
+//
+// A nil or empty filter allows everything. Unlike core.DiscoveryFilter, which
+// queries the registry, this filter scopes the catalog the LLM sees.
+type DiscoveryFilter struct {
+	Namespaces   []string `json:"namespaces,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// discoveryFilterKey is the context key for the per-request DiscoveryFilter
+type discoveryFilterKey struct{}
+
+// WithDiscoveryFilter scopes plan generation for requests using ctx to the
+// capabilities filter allows. Set it from the authenticated principal before
+// calling ProcessRequest; the LLM then only sees permitted capabilities, and
+// plans that step outside the filter are rejected before execution.
+func WithDiscoveryFilter(ctx context.Context, filter *DiscoveryFilter) context.Context {
+	if filter == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, discoveryFilterKey{}, filter)
+}
+
+// GetDiscoveryFilter returns the DiscoveryFilter attached to ctx, or nil
+func GetDiscoveryFilter(ctx context.Context) *DiscoveryFilter {
+	if ctx == nil {
+		return nil
+	}
+	if filter, ok := ctx.Value(discoveryFilterKey{}).(*DiscoveryFilter); ok {
+		return filter
+	}
+	return nil
+}
+
+// IsEmpty reports whether the filter allows every capability
+func (f *DiscoveryFilter) IsEmpty() bool {
+	return f == nil || (len(f.Namespaces) == 0 && len(f.Tags) == 0 && len(f.Capabilities) == 0)
+}
+
+// allowsAgent checks the namespace condition
+func (f *DiscoveryFilter) allowsAgent(agent *AgentInfo) bool {
+	if len(f.Namespaces) == 0 {
+		return true
+	}
+	if agent == nil || agent.Registration == nil {
+		return false
+	}
+	namespace, _ := agent.Registration.Metadata["namespace"].(string)
+	return containsFold(f.Namespaces, namespace)
+}
+
+// allowsCapability checks the tag and allowlist conditions for a capability
+// of an agent that already passed allowsAgent
+func (f *DiscoveryFilter) allowsCapability(agentName string, capability EnhancedCapability) bool {
+	if len(f.Tags) > 0 {
+		tagged := false
+		for _, tag := range capability.Tags {
+			if containsFold(f.Tags, tag) {
+				tagged = true
+				break
+			}
+		}
+		if !tagged {
+			return false
+		}
+	}
+	if len(f.Capabilities) > 0 {
+		return containsFold(f.Capabilities, capability.Name) ||
+			containsFold(f.Capabilities, agentName+"/"+capability.Name)
+	}
+	return true
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Scoped returns a snapshot of the catalog holding only the agents and
+// capabilities filter allows. An empty filter returns the catalog itself.
+// The snapshot is read-only; refresh the original catalog instead.
+func (c *AgentCatalog) Scoped(filter *DiscoveryFilter) *AgentCatalog {
+	if filter.IsEmpty() {
+		return c
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	scoped := &AgentCatalog{
+		agents:          make(map[string]*AgentInfo),
+		capabilityIndex: make(map[string][]string),
+		discovery:       c.discovery,
+		httpClient:      c.httpClient,
+		logger:          c.logger,
+	}
+	for id, agent := range c.agents {
+		if !filter.allowsAgent(agent) {
+			continue
+		}
+		var capabilities []EnhancedCapability
+		for _, capability := range agent.Capabilities {
+			if filter.allowsCapability(agent.Registration.Name, capability) {
+				capabilities = append(capabilities, capability)
+				scoped.capabilityIndex[capability.Name] = append(scoped.capabilityIndex[capability.Name], id)
+			}
+		}
+		if len(capabilities) == 0 {
+			continue
+		}
+		scoped.agents[id] = &AgentInfo{
+			Registration: agent.Registration,
+			Capabilities: capabilities,
+			LastUpdated:  agent.LastUpdated,
+		}
+	}
+	return scoped
+}
+
+// checkDiscoveryScope rejects plans with steps outside the request's
+// DiscoveryFilter. Capability providers already hide those capabilities from
+// the LLM; this stops a plan that names them anyway from executing.
+func (o *AIOrchestrator) checkDiscoveryScope(ctx context.Context, plan *RoutingPlan) error {
+	filter := GetDiscoveryFilter(ctx)
+	if filter.IsEmpty() || plan == nil {
+		return nil
+	}
+	if o.catalog == nil {
+		return fmt.Errorf("discovery filter set but no agent catalog is available to enforce it")
+	}
+
+	scoped := o.catalog.Scoped(filter)
+	for _, step := range plan.Steps {
+		var agent *AgentInfo
+		for _, candidate := range scoped.GetAgents() {
+			if candidate.Registration != nil && strings.EqualFold(candidate.Registration.Name, step.AgentName) {
+				agent = candidate
+				break
+			}
+		}
+		if agent == nil {
+			return fmt.Errorf("step %s: agent %s is not available to this request", step.StepID, step.AgentName)
+		}
+
+		capName, _ := step.Metadata["capability"].(string)
+		if capName == "" {
+			continue
+		}
+		allowed := false
+		for _, capability := range agent.Capabilities {
+			if capability.Name == capName {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("step %s: capability %s/%s is not available to this request", step.StepID, step.AgentName, capName)
+		}
+	}
+	return nil
+}
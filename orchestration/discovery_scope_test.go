@@ -0,0 +1,152 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// greedyPlannerAI plans one step for every agent it finds in the prompt, like
+// an LLM that uses everything it is shown
+type greedyPlannerAI struct {
+	agents map[string]string // agent name -> capability
+}
+
+func (g *greedyPlannerAI) GenerateResponse(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+	var steps []map[string]interface{}
+	for agent, capability := range g.agents {
+		if strings.Contains(prompt, "Agent: "+agent+" ") {
+			steps = append(steps, map[string]interface{}{
+				"step_id":     "step-" + agent,
+				"agent_name":  agent,
+				"instruction": "Use " + capability,
+				"metadata":    map[string]interface{}{"capability": capability},
+			})
+		}
+	}
+	plan, _ := json.Marshal(map[string]interface{}{"plan_id": "scoped-plan", "steps": steps})
+	return &core.AIResponse{Content: string(plan)}, nil
+}
+
+func newScopedTestOrchestrator(aiClient core.AIClient) *AIOrchestrator {
+	orchestrator := NewAIOrchestrator(nil, NewMockDiscovery(), aiClient)
+	orchestrator.catalog.agents = map[string]*AgentInfo{
+		"billing-1": {
+			Registration: &core.ServiceRegistration{ID: "billing-1", Name: "billing-service", Metadata: map[string]interface{}{"namespace": "tenant-a"}},
+			Capabilities: []EnhancedCapability{{Name: "create_invoice", Tags: []string{"finance"}}},
+		},
+		"payroll-1": {
+			Registration: &core.ServiceRegistration{ID: "payroll-1", Name: "payroll-service", Metadata: map[string]interface{}{"namespace": "tenant-b"}},
+			Capabilities: []EnhancedCapability{{Name: "run_payroll", Tags: []string{"finance", "hr"}}},
+		},
+		"weather-1": {
+			Registration: &core.ServiceRegistration{ID: "weather-1", Name: "weather-service", Metadata: map[string]interface{}{"namespace": "tenant-a"}},
+			Capabilities: []EnhancedCapability{
+				{Name: "current_weather", Tags: []string{"weather"}},
+				{Name: "forecast", Tags: []string{"weather"}},
+			},
+		},
+	}
+	return orchestrator
+}
+
+func TestAgentCatalog_Scoped(t *testing.T) {
+	catalog := newScopedTestOrchestrator(nil).catalog
+
+	tests := []struct {
+		name   string
+		filter *DiscoveryFilter
+		want   []string // agent/capability
+	}{
+		{"nil filter", nil, []string{"billing-service/create_invoice", "payroll-service/run_payroll", "weather-service/current_weather", "weather-service/forecast"}},
+		{"namespace", &DiscoveryFilter{Namespaces: []string{"tenant-b"}}, []string{"payroll-service/run_payroll"}},
+		{"tag", &DiscoveryFilter{Tags: []string{"HR"}}, []string{"payroll-service/run_payroll"}},
+		{"capability allowlist", &DiscoveryFilter{Capabilities: []string{"forecast", "billing-service/create_invoice"}}, []string{"billing-service/create_invoice", "weather-service/forecast"}},
+		{"combined", &DiscoveryFilter{Namespaces: []string{"tenant-a"}, Tags: []string{"finance"}}, []string{"billing-service/create_invoice"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, summary := range catalog.Scoped(tt.filter).GetCapabilitySummaries() {
+				got = append(got, summary.AgentName+"/"+summary.CapabilityName)
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Scoped() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if len(catalog.GetAgents()) != 3 {
+		t.Error("Scoped() must not modify the original catalog")
+	}
+}
+
+func TestGenerateExecutionPlan_FilteredCapabilityNeverPlanned(t *testing.T) {
+	planner := &greedyPlannerAI{agents: map[string]string{
+		"billing-service": "create_invoice",
+		"payroll-service": "run_payroll",
+		"weather-service": "current_weather",
+	}}
+	orchestrator := newScopedTestOrchestrator(planner)
+
+	ctx := WithDiscoveryFilter(context.Background(), &DiscoveryFilter{Namespaces: []string{"tenant-a"}})
+	plan, err := orchestrator.generateExecutionPlan(ctx, "Invoice the customer and check the weather", "scope-req-1")
+	if err != nil {
+		t.Fatalf("generateExecutionPlan() error = %v", err)
+	}
+
+	for _, step := range plan.Steps {
+		if step.AgentName == "payroll-service" {
+			t.Fatalf("Filtered-out capability appeared in the plan: %+v", step)
+		}
+	}
+	if len(plan.Steps) != 2 {
+		t.Errorf("Expected steps for the 2 permitted agents, got %d", len(plan.Steps))
+	}
+	if err := orchestrator.checkDiscoveryScope(ctx, plan); err != nil {
+		t.Errorf("checkDiscoveryScope() error = %v", err)
+	}
+
+	// Without a filter the same planner uses every agent
+	unscoped, err := orchestrator.generateExecutionPlan(context.Background(), "Invoice the customer and check the weather", "scope-req-2")
+	if err != nil {
+		t.Fatalf("generateExecutionPlan() error = %v", err)
+	}
+	if len(unscoped.Steps) != 3 {
+		t.Errorf("Expected 3 steps without a filter, got %d", len(unscoped.Steps))
+	}
+}
+
+func TestCheckDiscoveryScope_RejectsOutOfScopeSteps(t *testing.T) {
+	orchestrator := newScopedTestOrchestrator(nil)
+	ctx := WithDiscoveryFilter(context.Background(), &DiscoveryFilter{Capabilities: []string{"current_weather"}})
+
+	tests := []struct {
+		name    string
+		step    RoutingStep
+		wantErr bool
+	}{
+		{"allowed", RoutingStep{StepID: "s1", AgentName: "weather-service", Metadata: map[string]interface{}{"capability": "current_weather"}}, false},
+		{"other capability of allowed agent", RoutingStep{StepID: "s2", AgentName: "weather-service", Metadata: map[string]interface{}{"capability": "forecast"}}, true},
+		{"filtered agent", RoutingStep{StepID: "s3", AgentName: "payroll-service", Metadata: map[string]interface{}{"capability": "run_payroll"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := orchestrator.checkDiscoveryScope(ctx, &RoutingPlan{Steps: []RoutingStep{tt.step}})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkDiscoveryScope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := orchestrator.checkDiscoveryScope(context.Background(), &RoutingPlan{Steps: []RoutingStep{tests[2].step}}); err != nil {
+		t.Errorf("Expected no scope check without a filter, got %v", err)
+	}
+}
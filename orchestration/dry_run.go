@@ -0,0 +1,80 @@
+package orchestration
+
+import (
+	"context"
+	"time"
+)
+
+// dryRunContextKey marks a request as a dry run
+const dryRunContextKey orchestratorContextKey = "orchestrator_dry_run"
+
+// WithDryRun makes ProcessRequest and ProcessRequestStreaming stop once the
+// plan is generated and validated. The response carries the RoutingPlan
+// (steps, parameters and dependencies) and the synthesis strategy, with
+// DryRun set; no step is executed and nothing is synthesized.
+//
+// Unlike HITL plan approval, a dry run never pauses or creates a checkpoint.
+// The plan-generation LLM interaction is still recorded in the LLM debug
+// store.
+//
+// Usage:
+//
+//	resp, err := orchestrator.ProcessRequest(orchestration.WithDryRun(ctx), request, nil)
+//	for _, step := range resp.Plan.Steps { ... }
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunContextKey, true)
+}
+
+// IsDryRun reports whether ctx was marked with WithDryRun
+func IsDryRun(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	dryRun, _ := ctx.Value(dryRunContextKey).(bool)
+	return dryRun
+}
+
+// dryRunResponse builds the response returned instead of executing plan
+func (o *AIOrchestrator) dryRunResponse(
+	ctx context.Context,
+	request, requestID string,
+	plan *RoutingPlan,
+	violations []PlanViolation,
+	metadata map[string]interface{},
+	startTime time.Time,
+) *OrchestratorResponse {
+	responseMetadata := make(map[string]interface{}, len(metadata)+1)
+	for k, v := range metadata {
+		responseMetadata[k] = v
+	}
+	if len(violations) > 0 {
+		responseMetadata["plan_violations"] = violations
+	}
+
+	if o.logger != nil {
+		o.logger.InfoWithContext(ctx, "Dry run: returning plan without execution", map[string]interface{}{
+			"operation":   "dry_run",
+			"request_id":  requestID,
+			"plan_id":     plan.PlanID,
+			"step_count":  len(plan.Steps),
+			"duration_ms": time.Since(startTime).Milliseconds(),
+		})
+	}
+	if o.telemetry != nil {
+		o.telemetry.RecordMetric("orchestrator.requests.dry_run", 1, map[string]string{
+			"mode": string(o.config.RoutingMode),
+		})
+	}
+
+	return &OrchestratorResponse{
+		RequestID:         requestID,
+		OriginalRequest:   request,
+		RoutingMode:       o.config.RoutingMode,
+		ExecutionTime:     time.Since(startTime),
+		AgentsInvolved:    o.extractAgentsFromPlan(plan),
+		Metadata:          responseMetadata,
+		DryRun:            true,
+		Plan:              plan,
+		SynthesisStrategy: o.config.SynthesisStrategy,
+	}
+}
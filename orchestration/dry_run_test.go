@@ -0,0 +1,105 @@
+package orchestration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+func newDryRunOrchestrator(aiClient core.AIClient) *AIOrchestrator {
+	orchestrator := NewAIOrchestrator(DefaultConfig(), NewMockDiscovery(), aiClient)
+	orchestrator.catalog.agents = map[string]*AgentInfo{
+		"stock-1": {
+			Registration: &core.ServiceRegistration{
+				ID:           "stock-1",
+				Name:         "stock-analyzer",
+				Address:      "localhost",
+				Port:         8080,
+				Capabilities: []core.Capability{{Name: "analyze_stock"}},
+			},
+			Capabilities: []EnhancedCapability{
+				{Name: "analyze_stock", Description: "Analyzes stocks"},
+			},
+		},
+	}
+	orchestrator.executor = NewSmartExecutor(orchestrator.catalog)
+	return orchestrator
+}
+
+func TestProcessRequest_DryRun(t *testing.T) {
+	aiClient := NewMockAIClient()
+	orchestrator := newDryRunOrchestrator(aiClient)
+	debugStore := NewMemoryLLMDebugStore()
+	orchestrator.SetLLMDebugStore(debugStore)
+
+	metadata := map[string]interface{}{"user_id": "u1"}
+	response, err := orchestrator.ProcessRequest(WithDryRun(context.Background()), "Analyze Apple stock", metadata)
+	if err != nil {
+		t.Fatalf("ProcessRequest failed: %v", err)
+	}
+
+	if !response.DryRun {
+		t.Error("Expected response to be marked as dry run")
+	}
+	if response.Plan == nil || len(response.Plan.Steps) == 0 {
+		t.Fatal("Expected the generated plan in the response")
+	}
+	if response.Response != "" {
+		t.Errorf("Expected no synthesized response, got %q", response.Response)
+	}
+	if response.SynthesisStrategy != StrategyLLM {
+		t.Errorf("Expected synthesis strategy %q, got %q", StrategyLLM, response.SynthesisStrategy)
+	}
+	if response.Metadata["user_id"] != "u1" {
+		t.Error("Expected request metadata in the response")
+	}
+	for _, prompt := range aiClient.calls {
+		if strings.Contains(prompt, "Synthesize") {
+			t.Error("Dry run must not synthesize a response")
+		}
+	}
+	if got := orchestrator.GetMetrics().TotalRequests; got != 0 {
+		t.Errorf("Dry runs should not count as processed requests, got %d", got)
+	}
+
+	// The plan-generation interaction is still recorded
+	if err := orchestrator.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	record, err := debugStore.GetRecord(context.Background(), response.RequestID)
+	if err != nil || record == nil || len(record.Interactions) == 0 {
+		t.Errorf("Expected plan generation debug record, got %v (err %v)", record, err)
+	}
+}
+
+func TestProcessRequestStreaming_DryRun(t *testing.T) {
+	aiClient := NewMockAIClient()
+	orchestrator := newDryRunOrchestrator(aiClient)
+
+	chunks := 0
+	response, err := orchestrator.ProcessRequestStreaming(WithDryRun(context.Background()), "Analyze Apple stock", nil,
+		func(chunk core.StreamChunk) error {
+			chunks++
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("ProcessRequestStreaming failed: %v", err)
+	}
+	if !response.DryRun || response.Plan == nil {
+		t.Error("Expected a dry-run response carrying the plan")
+	}
+	if chunks != 0 {
+		t.Errorf("Expected nothing streamed, got %d chunks", chunks)
+	}
+}
+
+func TestIsDryRun(t *testing.T) {
+	if IsDryRun(context.Background()) {
+		t.Error("Plain context should not be a dry run")
+	}
+	if !IsDryRun(WithDryRun(context.Background())) {
+		t.Error("Expected WithDryRun to mark the context")
+	}
+}
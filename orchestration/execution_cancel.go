@@ -0,0 +1,135 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/itsneelabh/gomind/telemetry"
+)
+
+// ErrExecutionCancelled is the cancellation cause of an execution stopped by
+// CancelExecution. The error returned for the request wraps it.
+var ErrExecutionCancelled = errors.New("execution cancelled")
+
+// ErrExecutionNotRunning is returned by CancelExecution when no execution
+// with the request ID runs on this orchestrator and there is no
+// CancellationBroker to reach the other replicas
+var ErrExecutionNotRunning = errors.New("execution not running")
+
+// CancellationBroker carries cancellation requests between processes, so
+// CancelExecution on one orchestrator replica, or a tool such as the registry
+// viewer, reaches the replica running the execution.
+// RedisCancellationBroker is the framework's implementation.
+type CancellationBroker interface {
+	// PublishCancel asks every subscribed orchestrator to cancel requestID
+	PublishCancel(ctx context.Context, requestID string) error
+
+	// SubscribeCancels calls handler with each request ID published until
+	// ctx is done. It returns once the subscription is established.
+	SubscribeCancels(ctx context.Context, handler func(requestID string)) error
+}
+
+// cancellationRegistry tracks the cancel functions of running executions by
+// request ID. The zero value is ready to use.
+type cancellationRegistry struct {
+	mu      sync.Mutex
+	running map[string]context.CancelCauseFunc
+}
+
+// track derives a cancellable context for the execution of requestID. The
+// returned function removes the execution from the registry and must be
+// called when the execution ends.
+func (r *cancellationRegistry) track(ctx context.Context, requestID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	r.mu.Lock()
+	if r.running == nil {
+		r.running = make(map[string]context.CancelCauseFunc)
+	}
+	r.running[requestID] = cancel
+	r.mu.Unlock()
+
+	return ctx, func() {
+		r.mu.Lock()
+		delete(r.running, requestID)
+		r.mu.Unlock()
+		cancel(nil)
+	}
+}
+
+// cancel cancels the execution of requestID with ErrExecutionCancelled,
+// reporting whether it was running
+func (r *cancellationRegistry) cancel(requestID string) bool {
+	r.mu.Lock()
+	cancel, ok := r.running[requestID]
+	r.mu.Unlock()
+
+	if ok {
+		cancel(ErrExecutionCancelled)
+	}
+	return ok
+}
+
+// CancelExecution stops a running execution. Its context is cancelled with
+// ErrExecutionCancelled, which cancels the in-flight steps' calls; steps
+// that had not started are recorded as cancelled, and the partial result is
+// stored in the execution store. The request returns an error wrapping
+// ErrExecutionCancelled.
+//
+// When the execution runs on this orchestrator it is cancelled directly.
+// Otherwise, with a CancellationBroker set, the request is published for the
+// replica running it, and CancelExecution returns once it is published.
+// Without a broker it returns ErrExecutionNotRunning.
+func (o *AIOrchestrator) CancelExecution(ctx context.Context, requestID string) error {
+	if requestID == "" {
+		return fmt.Errorf("request_id is required")
+	}
+
+	if o.executions.cancel(requestID) {
+		o.recordCancellation(requestID, "local")
+		return nil
+	}
+
+	if o.cancelBroker == nil {
+		return fmt.Errorf("%w: %s", ErrExecutionNotRunning, requestID)
+	}
+	if err := o.cancelBroker.PublishCancel(ctx, requestID); err != nil {
+		return fmt.Errorf("failed to publish cancellation for %s: %w", requestID, err)
+	}
+	return nil
+}
+
+// SetCancellationBroker connects the orchestrator to other replicas for
+// CancelExecution: cancellations published by any of them, or by tools such
+// as the registry viewer, cancel the matching execution here. The
+// subscription lasts until Stop or Shutdown.
+func (o *AIOrchestrator) SetCancellationBroker(broker CancellationBroker) error {
+	o.cancelBroker = broker
+	if broker == nil {
+		return nil
+	}
+
+	return broker.SubscribeCancels(o.ctx, func(requestID string) {
+		if o.executions.cancel(requestID) {
+			o.recordCancellation(requestID, "broker")
+		}
+	})
+}
+
+// recordCancellation logs and counts an execution cancelled on this
+// orchestrator
+func (o *AIOrchestrator) recordCancellation(requestID, source string) {
+	telemetry.Counter("orchestration.execution.cancelled_total",
+		"module", telemetry.ModuleOrchestration,
+		"source", source,
+	)
+	if o.logger != nil {
+		o.logger.Info("Execution cancelled", map[string]interface{}{
+			"operation":  "execution_cancel",
+			"request_id": requestID,
+			"source":     source,
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package orchestration
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingBroker is an in-memory CancellationBroker
+type recordingBroker struct {
+	mu        sync.Mutex
+	published []string
+	handler   func(string)
+}
+
+func (b *recordingBroker) PublishCancel(ctx context.Context, requestID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.published = append(b.published, requestID)
+	return nil
+}
+
+func (b *recordingBroker) SubscribeCancels(ctx context.Context, handler func(string)) error {
+	b.handler = handler
+	return nil
+}
+
+// blockingRoundTripper holds every request until its context is cancelled
+type blockingRoundTripper struct {
+	started chan struct{}
+	once    sync.Once
+}
+
+func (rt *blockingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.once.Do(func() { close(rt.started) })
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestCancellationRegistry(t *testing.T) {
+	var registry cancellationRegistry
+
+	ctx, untrack := registry.track(context.Background(), "req-1")
+	assert.False(t, registry.cancel("req-2"))
+	assert.True(t, registry.cancel("req-1"))
+	<-ctx.Done()
+	assert.ErrorIs(t, context.Cause(ctx), ErrExecutionCancelled)
+
+	untrack()
+	assert.False(t, registry.cancel("req-1"), "finished executions are no longer tracked")
+
+	ctx, untrack = registry.track(context.Background(), "req-3")
+	untrack()
+	assert.ErrorIs(t, context.Cause(ctx), context.Canceled, "untracking releases the context")
+}
+
+func TestCancelExecution(t *testing.T) {
+	o := &AIOrchestrator{ctx: context.Background()}
+
+	err := o.CancelExecution(context.Background(), "req-1")
+	assert.ErrorIs(t, err, ErrExecutionNotRunning)
+	assert.Error(t, o.CancelExecution(context.Background(), ""))
+
+	ctx, untrack := o.executions.track(context.Background(), "req-1")
+	defer untrack()
+	require.NoError(t, o.CancelExecution(context.Background(), "req-1"))
+	assert.ErrorIs(t, context.Cause(ctx), ErrExecutionCancelled)
+}
+
+func TestCancelExecution_Broker(t *testing.T) {
+	o := &AIOrchestrator{ctx: context.Background()}
+	broker := &recordingBroker{}
+	require.NoError(t, o.SetCancellationBroker(broker))
+
+	// Not running here: the request goes to the other replicas
+	require.NoError(t, o.CancelExecution(context.Background(), "remote-1"))
+	assert.Equal(t, []string{"remote-1"}, broker.published)
+
+	// Published by another replica: cancels the local execution
+	ctx, untrack := o.executions.track(context.Background(), "local-1")
+	defer untrack()
+	require.NotNil(t, broker.handler)
+	broker.handler("unknown")
+	assert.NoError(t, ctx.Err())
+	broker.handler("local-1")
+	assert.ErrorIs(t, context.Cause(ctx), ErrExecutionCancelled)
+}
+
+func TestSmartExecutor_CancelledExecution(t *testing.T) {
+	catalog := &AgentCatalog{
+		agents: map[string]*AgentInfo{
+			"agent-1": {
+				Registration: &core.ServiceRegistration{
+					ID:      "agent-1",
+					Name:    "test-agent",
+					Address: "localhost",
+					Port:    8080,
+				},
+				Capabilities: []EnhancedCapability{
+					{Name: "capability1", Endpoint: "/api/capability1"},
+				},
+			},
+		},
+	}
+	executor := NewSmartExecutor(catalog)
+	rt := &blockingRoundTripper{started: make(chan struct{})}
+	executor.httpClient = &http.Client{Transport: rt}
+
+	plan := &RoutingPlan{
+		PlanID: "cancel-plan",
+		Steps: []RoutingStep{
+			{StepID: "step-1", AgentName: "test-agent", Metadata: map[string]interface{}{"capability": "capability1"}},
+			{StepID: "step-2", AgentName: "test-agent", DependsOn: []string{"step-1"}, Metadata: map[string]interface{}{"capability": "capability1"}},
+		},
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	go func() {
+		<-rt.started
+		cancel(ErrExecutionCancelled)
+	}()
+
+	done := make(chan struct{})
+	var result *ExecutionResult
+	var err error
+	go func() {
+		result, err = executor.Execute(ctx, plan)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("execution did not stop after cancellation")
+	}
+
+	assert.ErrorIs(t, err, ErrExecutionCancelled)
+	require.NotNil(t, result, "the partial result is returned")
+	assert.False(t, result.Success)
+	require.Len(t, result.Steps, 2)
+
+	assert.Equal(t, "step-1", result.Steps[0].StepID)
+	assert.True(t, result.Steps[0].Cancelled, "the in-flight step was cancelled")
+	assert.Equal(t, "step-2", result.Steps[1].StepID)
+	assert.True(t, result.Steps[1].Cancelled)
+	assert.Contains(t, result.Steps[1].Error, "cancelled before it started")
+}
+
+func TestRedisCancellationBroker(t *testing.T) {
+	_, client := setupCheckpointTestRedis(t)
+	broker := &RedisCancellationBroker{
+		client:  client,
+		channel: defaultCancellationChannel,
+		logger:  &core.NoOpLogger{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := make(chan string, 1)
+	require.NoError(t, broker.SubscribeCancels(ctx, func(requestID string) {
+		received <- requestID
+	}))
+
+	require.NoError(t, broker.PublishCancel(context.Background(), "req-42"))
+	select {
+	case id := <-received:
+		assert.Equal(t, "req-42", id)
+	case <-time.After(5 * time.Second):
+		t.Fatal("cancellation not received")
+	}
+}
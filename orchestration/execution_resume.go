@@ -0,0 +1,192 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/itsneelabh/gomind/telemetry"
+)
+
+// withExecutionProgress checkpoints the execution record in the execution
+// store each time a step completes, so the steps finished so far survive a
+// crash of this orchestrator. The record is marked InProgress until the
+// final record replaces it. Any step callback already on ctx still runs.
+//
+// Checkpoints are written in the background and coalesced: while one write
+// is in flight, only the latest progress is kept for the next one, so slow
+// stores never hold up steps. The returned function waits for outstanding
+// checkpoints; call it before storing the final record so a late checkpoint
+// cannot overwrite it.
+func (o *AIOrchestrator) withExecutionProgress(ctx context.Context, request, requestID string, plan *RoutingPlan) (context.Context, func()) {
+	store := o.executionStore
+	if store == nil || plan == nil {
+		return ctx, func() {}
+	}
+
+	bag := telemetry.GetBaggage(ctx)
+	agentName := o.getAgentName()
+	createdAt := time.Now()
+	next := GetStepCallback(ctx)
+
+	var (
+		mu      sync.Mutex
+		steps   []StepResult
+		pending *StoredExecution // Latest progress not yet written
+		writing bool             // A writer goroutine is draining pending
+		done    sync.WaitGroup
+	)
+	completedSteps := GetCompletedSteps(ctx)
+	for _, step := range plan.Steps {
+		if completed := completedSteps[step.StepID]; completed != nil {
+			steps = append(steps, *completed)
+		}
+	}
+
+	drain := func() {
+		defer o.executionWg.Done()
+		defer done.Done()
+		for {
+			mu.Lock()
+			stored := pending
+			pending = nil
+			if stored == nil {
+				writing = false
+				mu.Unlock()
+				return
+			}
+			mu.Unlock()
+
+			storeCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			err := store.Store(storeCtx, stored)
+			cancel()
+			if err != nil {
+				telemetry.Counter("orchestration.execution.checkpoint_errors",
+					"module", telemetry.ModuleOrchestration,
+				)
+				if o.logger != nil {
+					o.logger.Warn("Failed to checkpoint execution progress", map[string]interface{}{
+						"operation":       "execution_checkpoint",
+						"request_id":      requestID,
+						"completed_steps": len(stored.Result.Steps),
+						"error":           err.Error(),
+					})
+				}
+			}
+		}
+	}
+
+	ctx = WithStepCallback(ctx, func(stepIndex, totalSteps int, step RoutingStep, result StepResult) {
+		mu.Lock()
+		steps = append(steps, result)
+		pending = newStoredExecution(bag, agentName, request, requestID, plan, &ExecutionResult{
+			PlanID:        plan.PlanID,
+			Steps:         append([]StepResult(nil), steps...),
+			TotalDuration: time.Since(createdAt),
+		}, createdAt)
+		pending.InProgress = true
+		if !writing {
+			writing = true
+			done.Add(1)
+			o.executionWg.Add(1)
+			go drain()
+		}
+		mu.Unlock()
+
+		if next != nil {
+			next(stepIndex, totalSteps, step, result)
+		}
+	})
+	return ctx, done.Wait
+}
+
+// ResumeExecution continues an execution whose orchestrator stopped before
+// finishing, for example because its pod was evicted. It loads the stored
+// record for requestID, reuses its plan, keeps the results of steps that
+// already succeeded and runs the remaining steps before synthesizing the
+// response. No new plan is generated.
+//
+// The resumed run gets a new request ID; its record links back to the
+// original through OriginalRequestID, and the original record is marked
+// SupersededBy the new one so it no longer shows as in progress. Records
+// that completed successfully, were already resumed or are waiting on HITL
+// approval cannot be resumed. Requires an execution store (see
+// SetExecutionStore).
+func (o *AIOrchestrator) ResumeExecution(ctx context.Context, requestID string, metadata map[string]interface{}) (*OrchestratorResponse, error) {
+	if o.executionStore == nil {
+		return nil, fmt.Errorf("cannot resume execution %s: no execution store configured", requestID)
+	}
+
+	stored, err := o.executionStore.Get(ctx, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load execution %s: %w", requestID, err)
+	}
+	if stored.Plan == nil {
+		return nil, fmt.Errorf("execution %s has no stored plan", requestID)
+	}
+	if stored.Interrupted {
+		return nil, fmt.Errorf("execution %s is waiting on HITL approval; resume it through the HITL API", requestID)
+	}
+	if !stored.InProgress && stored.Result != nil && stored.Result.Success {
+		return nil, fmt.Errorf("execution %s already completed", requestID)
+	}
+	if stored.SupersededBy != "" {
+		return nil, fmt.Errorf("execution %s was already resumed as %s", requestID, stored.SupersededBy)
+	}
+
+	completed := make(map[string]*StepResult)
+	if stored.Result != nil {
+		for i := range stored.Result.Steps {
+			step := stored.Result.Steps[i]
+			if step.Success {
+				completed[step.StepID] = &step
+			}
+		}
+	}
+
+	originalRequestID := stored.OriginalRequestID
+	if originalRequestID == "" {
+		originalRequestID = stored.RequestID
+	}
+
+	if o.logger != nil {
+		o.logger.InfoWithContext(ctx, "Resuming execution from stored progress", map[string]interface{}{
+			"operation":       "execution_resume",
+			"request_id":      requestID,
+			"plan_id":         stored.Plan.PlanID,
+			"completed_count": len(completed),
+			"remaining_count": len(stored.Plan.Steps) - len(completed),
+		})
+	}
+	telemetry.Counter("orchestration.execution.resumed",
+		"module", telemetry.ModuleOrchestration,
+	)
+
+	ctx = telemetry.WithBaggage(ctx, "original_request_id", originalRequestID)
+	ctx = WithPlanOverride(ctx, stored.Plan)
+	ctx = WithCompletedSteps(ctx, completed)
+	response, err := o.ProcessRequest(ctx, stored.OriginalRequest, metadata)
+	if response != nil && response.RequestID != "" {
+		o.markSuperseded(ctx, stored, response.RequestID)
+	}
+	return response, err
+}
+
+// markSuperseded rewrites a resumed record so it points at the run that
+// continued it instead of staying in progress
+func (o *AIOrchestrator) markSuperseded(ctx context.Context, stored *StoredExecution, resumedRequestID string) {
+	stored.InProgress = false
+	stored.SupersededBy = resumedRequestID
+
+	storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 1*time.Second)
+	defer cancel()
+	if err := o.executionStore.Store(storeCtx, stored); err != nil && o.logger != nil {
+		o.logger.Warn("Failed to mark resumed execution as superseded", map[string]interface{}{
+			"operation":     "execution_resume",
+			"request_id":    stored.RequestID,
+			"superseded_by": resumedRequestID,
+			"error":         err.Error(),
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package orchestration
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+func newResumeOrchestrator(mockRT *MockRoundTripper) (*AIOrchestrator, ExecutionStore) {
+	registration := &core.ServiceRegistration{ID: "agent-1", Name: "travel", Address: "localhost", Port: 8080}
+	discovery := NewMockDiscovery()
+	_ = discovery.Register(context.Background(), registration)
+
+	orchestrator := NewAIOrchestrator(DefaultConfig(), discovery, NewMockAIClient())
+	orchestrator.catalog.agents = map[string]*AgentInfo{
+		"agent-1": {
+			Registration: registration,
+			Capabilities: []EnhancedCapability{
+				{Name: "search", Endpoint: "/api/search"},
+				{Name: "book", Endpoint: "/api/book"},
+			},
+		},
+	}
+	orchestrator.executor = NewSmartExecutor(orchestrator.catalog)
+	orchestrator.executor.httpClient = &http.Client{Transport: mockRT}
+	orchestrator.executor.SetMaxAttempts(1)
+
+	store := NewExecutionStoreWithProvider(newMockStorageProvider(), DefaultExecutionStoreConfig(), nil)
+	orchestrator.SetExecutionStore(store)
+	return orchestrator, store
+}
+
+func resumePlan() *RoutingPlan {
+	return &RoutingPlan{
+		PlanID:          "travel-plan",
+		OriginalRequest: "Book a flight to Tokyo",
+		Steps: []RoutingStep{
+			{StepID: "step-1", AgentName: "travel", Metadata: map[string]interface{}{"capability": "search"}},
+			{StepID: "step-2", AgentName: "travel", DependsOn: []string{"step-1"},
+				Metadata: map[string]interface{}{"capability": "book"}},
+		},
+	}
+}
+
+func TestWithExecutionProgress(t *testing.T) {
+	orchestrator, store := newResumeOrchestrator(NewMockRoundTripper())
+	plan := resumePlan()
+
+	var forwarded []string
+	ctx := WithStepCallback(context.Background(), func(stepIndex, totalSteps int, step RoutingStep, result StepResult) {
+		forwarded = append(forwarded, step.StepID)
+	})
+	ctx, wait := orchestrator.withExecutionProgress(ctx, plan.OriginalRequest, "req-progress", plan)
+
+	GetStepCallback(ctx)(0, 2, plan.Steps[0], StepResult{StepID: "step-1", Success: true, Response: `{"flight":"NH1"}`})
+	wait()
+
+	stored, err := store.Get(context.Background(), "req-progress")
+	if err != nil {
+		t.Fatalf("Expected checkpointed record: %v", err)
+	}
+	if !stored.InProgress {
+		t.Error("Checkpointed record should be marked in progress")
+	}
+	if stored.Plan == nil || stored.Plan.PlanID != "travel-plan" {
+		t.Errorf("Expected the plan in the checkpoint, got %+v", stored.Plan)
+	}
+	if stored.Result == nil || len(stored.Result.Steps) != 1 || stored.Result.Steps[0].StepID != "step-1" {
+		t.Errorf("Expected step-1 in the checkpoint, got %+v", stored.Result)
+	}
+	if len(forwarded) != 1 {
+		t.Errorf("Expected the existing step callback to still run, got %v", forwarded)
+	}
+}
+
+func TestResumeExecution(t *testing.T) {
+	mockRT := NewMockRoundTripper()
+	mockRT.SetResponse("http://localhost:8080/api/book", http.StatusOK, `{"booked": true}`)
+	orchestrator, store := newResumeOrchestrator(mockRT)
+
+	// The record a crashed orchestrator left behind after step-1 completed
+	plan := resumePlan()
+	err := store.Store(context.Background(), &StoredExecution{
+		RequestID:       "req-crashed",
+		OriginalRequest: plan.OriginalRequest,
+		Plan:            plan,
+		Result: &ExecutionResult{
+			PlanID: plan.PlanID,
+			Steps:  []StepResult{{StepID: "step-1", AgentName: "travel", Success: true, Response: `{"flight":"NH1"}`}},
+		},
+		InProgress: true,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	response, err := orchestrator.ResumeExecution(context.Background(), "req-crashed", nil)
+	if err != nil {
+		t.Fatalf("ResumeExecution failed: %v", err)
+	}
+	if response.Response == "" {
+		t.Error("Expected a synthesized response")
+	}
+	if got := mockRT.callCount["http://localhost:8080/api/search"]; got != 0 {
+		t.Errorf("Completed step-1 should not run again, got %d calls", got)
+	}
+	if got := mockRT.callCount["http://localhost:8080/api/book"]; got != 1 {
+		t.Errorf("Expected step-2 to run once, got %d calls", got)
+	}
+
+	if err := orchestrator.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	resumed, err := store.Get(context.Background(), response.RequestID)
+	if err != nil {
+		t.Fatalf("Expected the resumed execution to be stored: %v", err)
+	}
+	if resumed.InProgress || resumed.OriginalRequestID != "req-crashed" {
+		t.Errorf("Expected a final record linked to req-crashed, got in_progress=%v original=%q",
+			resumed.InProgress, resumed.OriginalRequestID)
+	}
+	if resumed.Result == nil || !resumed.Result.Success || len(resumed.Result.Steps) != 2 {
+		t.Errorf("Expected both steps in the resumed result, got %+v", resumed.Result)
+	}
+
+	original, err := store.Get(context.Background(), "req-crashed")
+	if err != nil {
+		t.Fatalf("Expected the original record to remain: %v", err)
+	}
+	if original.InProgress || original.SupersededBy != response.RequestID {
+		t.Errorf("Expected the original record superseded by %s, got in_progress=%v superseded_by=%q",
+			response.RequestID, original.InProgress, original.SupersededBy)
+	}
+	if _, err := orchestrator.ResumeExecution(context.Background(), "req-crashed", nil); err == nil ||
+		!strings.Contains(err.Error(), "already resumed") {
+		t.Errorf("Expected a superseded record not to be resumable again, got %v", err)
+	}
+}
+
+// slowExecutionStore blocks every Store until release is closed
+type slowExecutionStore struct {
+	ExecutionStore
+	release chan struct{}
+	mu      sync.Mutex
+	stored  []int
+}
+
+func (s *slowExecutionStore) Store(ctx context.Context, execution *StoredExecution) error {
+	<-s.release
+	s.mu.Lock()
+	s.stored = append(s.stored, len(execution.Result.Steps))
+	s.mu.Unlock()
+	return nil
+}
+
+func TestWithExecutionProgress_CoalescesCheckpoints(t *testing.T) {
+	orchestrator, _ := newResumeOrchestrator(NewMockRoundTripper())
+	store := &slowExecutionStore{release: make(chan struct{})}
+	orchestrator.executionStore = store
+	plan := resumePlan()
+	plan.Steps = append(plan.Steps, RoutingStep{StepID: "step-3", AgentName: "travel"})
+
+	ctx, wait := orchestrator.withExecutionProgress(context.Background(), plan.OriginalRequest, "req-slow", plan)
+	callback := GetStepCallback(ctx)
+
+	// Steps complete without waiting for the blocked store
+	finished := make(chan struct{})
+	go func() {
+		for i, step := range plan.Steps {
+			callback(i, len(plan.Steps), step, StepResult{StepID: step.StepID, Success: true})
+		}
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("step callbacks blocked on the execution store")
+	}
+
+	close(store.release)
+	wait()
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.stored) == 0 || len(store.stored) > 2 || store.stored[len(store.stored)-1] != 3 {
+		t.Errorf("Expected at most two writes ending with all three steps, got %v", store.stored)
+	}
+}
+
+func TestResumeExecution_NotResumable(t *testing.T) {
+	orchestrator, store := newResumeOrchestrator(NewMockRoundTripper())
+	plan := resumePlan()
+	records := []*StoredExecution{
+		{RequestID: "req-done", Plan: plan, Result: &ExecutionResult{Success: true}},
+		{RequestID: "req-hitl", Plan: plan, Interrupted: true},
+		{RequestID: "req-no-plan", InProgress: true},
+	}
+	for _, record := range records {
+		if err := store.Store(context.Background(), record); err != nil {
+			t.Fatalf("Store failed: %v", err)
+		}
+	}
+
+	tests := map[string]string{
+		"req-done":    "already completed",
+		"req-hitl":    "HITL",
+		"req-no-plan": "no stored plan",
+		"req-missing": "failed to load",
+	}
+	for requestID, want := range tests {
+		_, err := orchestrator.ResumeExecution(context.Background(), requestID, nil)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected error containing %q, got %v", requestID, want, err)
+		}
+	}
+
+	noStore := NewAIOrchestrator(DefaultConfig(), NewMockDiscovery(), NewMockAIClient())
+	if _, err := noStore.ResumeExecution(context.Background(), "req-done", nil); err == nil {
+		t.Error("Expected an error without an execution store")
+	}
+}
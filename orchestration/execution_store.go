@@ -16,7 +16,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/itsneelabh/gomind/core"
 )
@@ -84,6 +87,15 @@ type StoredExecution struct {
 	Interrupted bool                 `json:"interrupted,omitempty"` // True if execution was interrupted for human approval
 	Checkpoint  *ExecutionCheckpoint `json:"checkpoint,omitempty"`  // Checkpoint data if interrupted
 
+	// InProgress marks a partial record saved as steps complete. A record
+	// still in progress after its orchestrator died can be continued with
+	// AIOrchestrator.ResumeExecution.
+	InProgress bool `json:"in_progress,omitempty"`
+
+	// SupersededBy is the request ID of the run that resumed this record
+	// (see AIOrchestrator.ResumeExecution)
+	SupersededBy string `json:"superseded_by,omitempty"`
+
 	// Optional metadata for investigation notes
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
@@ -99,13 +111,97 @@ type ExecutionSummary struct {
 	AgentName         string        `json:"agent_name,omitempty"`
 	OriginalRequest   string        `json:"original_request"`
 	Success           bool          `json:"success"`
-	Interrupted       bool          `json:"interrupted,omitempty"` // True if execution was interrupted for HITL
+	Interrupted       bool          `json:"interrupted,omitempty"`   // True if execution was interrupted for HITL
+	InProgress        bool          `json:"in_progress,omitempty"`   // True while steps are still being checkpointed
+	SupersededBy      string        `json:"superseded_by,omitempty"` // Request ID of the run that resumed this one
 	StepCount         int           `json:"step_count"`
 	FailedSteps       int           `json:"failed_steps"`
+	TimedOutSteps     int           `json:"timed_out_steps,omitempty"` // Subset of FailedSteps cancelled by a step timeout
 	TotalDuration     time.Duration `json:"total_duration"`
 	CreatedAt         time.Time     `json:"created_at"`
 }
 
+// Execution event types (see ExecutionEvent)
+const (
+	// ExecutionEventStored is published when Store writes a record
+	ExecutionEventStored = "stored"
+	// ExecutionEventUpdated is published when Update or SetMetadata changes one
+	ExecutionEventUpdated = "updated"
+)
+
+// ExecutionEvent announces a write to the execution store, so a viewer can
+// follow executions as they happen instead of polling ListRecent. Status
+// transitions, such as an in-progress execution completing, show up as
+// successive events for the same request ID.
+//
+// RedisExecutionDebugStore publishes events as JSON on the Redis pub/sub
+// channel named by its key prefix followed by "events"
+// (gomind:execution:debug:events by default).
+type ExecutionEvent struct {
+	Type      string           `json:"type"`
+	Summary   ExecutionSummary `json:"summary"`
+	Timestamp time.Time        `json:"timestamp"`
+}
+
+// summarizeExecution builds the listing summary of an execution
+func summarizeExecution(execution *StoredExecution) ExecutionSummary {
+	summary := ExecutionSummary{
+		RequestID:         execution.RequestID,
+		OriginalRequestID: execution.OriginalRequestID,
+		TraceID:           execution.TraceID,
+		AgentName:         execution.AgentName,
+		OriginalRequest:   execution.OriginalRequest,
+		Interrupted:       execution.Interrupted,
+		InProgress:        execution.InProgress,
+		SupersededBy:      execution.SupersededBy,
+		CreatedAt:         execution.CreatedAt,
+	}
+
+	// Extract step count and success from result
+	if execution.Result != nil {
+		summary.Success = execution.Result.Success
+		summary.TotalDuration = execution.Result.TotalDuration
+		summary.StepCount = len(execution.Result.Steps)
+		for _, step := range execution.Result.Steps {
+			if !step.Success {
+				summary.FailedSteps++
+			}
+			if step.TimedOut {
+				summary.TimedOutSteps++
+			}
+		}
+	}
+	return summary
+}
+
+// maxExecutionSearchTokens bounds the search index entries of one execution
+const maxExecutionSearchTokens = 64
+
+// ExecutionSearchTokens splits text into the lowercase words the execution
+// search index is keyed by: runs of letters and digits at least two
+// characters long, without duplicates. RedisExecutionDebugStore indexes an
+// execution's OriginalRequest with it, and searches tokenize queries with it
+// so both sides agree. At most 64 tokens are returned.
+func ExecutionSearchTokens(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	var tokens []string
+	for _, word := range words {
+		if utf8.RuneCountInString(word) < 2 || seen[word] {
+			continue
+		}
+		seen[word] = true
+		tokens = append(tokens, word)
+		if len(tokens) == maxExecutionSearchTokens {
+			break
+		}
+	}
+	return tokens
+}
+
 // StorageProvider abstracts the underlying storage backend.
 // Implementations can be Redis, PostgreSQL, S3, etc.
 //
@@ -442,6 +538,8 @@ func (s *executionStoreImpl) ListRecent(ctx context.Context, limit int) ([]Execu
 			OriginalRequestID: execution.OriginalRequestID,
 			TraceID:           execution.TraceID,
 			OriginalRequest:   execution.OriginalRequest,
+			InProgress:        execution.InProgress,
+			SupersededBy:      execution.SupersededBy,
 			CreatedAt:         execution.CreatedAt,
 		}
 
@@ -453,6 +551,9 @@ func (s *executionStoreImpl) ListRecent(ctx context.Context, limit int) ([]Execu
 				if !step.Success {
 					summary.FailedSteps++
 				}
+				if step.TimedOut {
+					summary.TimedOutSteps++
+				}
 			}
 		}
 
@@ -501,3 +501,26 @@ func TestExecutionStore_ConcurrentAccess(t *testing.T) {
 		t.Errorf("Expected 10 records, got %d", len(list))
 	}
 }
+
+func TestExecutionSearchTokens(t *testing.T) {
+	tokens := ExecutionSearchTokens("What's the weather in Tokyo? Weather, then convert to °C (Celsius) - a 2-day forecast")
+	want := []string{"what", "the", "weather", "in", "tokyo", "then", "convert", "to", "celsius", "day", "forecast"}
+	if fmt.Sprint(tokens) != fmt.Sprint(want) {
+		t.Errorf("ExecutionSearchTokens() = %v, want %v", tokens, want)
+	}
+
+	if tokens := ExecutionSearchTokens("東京 の 天気"); fmt.Sprint(tokens) != "[東京 天気]" {
+		t.Errorf("Expected multi-byte words to be kept, got %v", tokens)
+	}
+	if tokens := ExecutionSearchTokens("a ? !"); len(tokens) != 0 {
+		t.Errorf("Expected no tokens, got %v", tokens)
+	}
+
+	long := ""
+	for i := 0; i < 100; i++ {
+		long += fmt.Sprintf("word%d ", i)
+	}
+	if tokens := ExecutionSearchTokens(long); len(tokens) != maxExecutionSearchTokens {
+		t.Errorf("Expected %d tokens, got %d", maxExecutionSearchTokens, len(tokens))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -14,6 +15,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/itsneelabh/gomind/core"
@@ -59,12 +61,32 @@ type CorrectionCallback func(
 	schema *EnhancedCapability,
 ) (map[string]interface{}, error)
 
+// correctionAttemptContextKey carries the 1-based correction attempt number
+// to the CorrectionCallback
+const correctionAttemptContextKey orchestratorContextKey = "orchestrator_correction_attempt"
+
+func withCorrectionAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, correctionAttemptContextKey, attempt)
+}
+
+// GetCorrectionAttempt returns which correction attempt for the current step
+// a CorrectionCallback is serving (1 for the first). Returns 0 outside a
+// correction.
+func GetCorrectionAttempt(ctx context.Context) int {
+	if ctx == nil {
+		return 0
+	}
+	attempt, _ := ctx.Value(correctionAttemptContextKey).(int)
+	return attempt
+}
+
 // SmartExecutor handles intelligent execution of routing plans
 type SmartExecutor struct {
 	catalog        *AgentCatalog
 	httpClient     *http.Client
 	maxConcurrency int
 	semaphore      chan struct{}
+	inFlightSteps  atomic.Int64 // Steps currently holding a semaphore slot
 
 	// Observability (follows framework design principles)
 	logger core.Logger // For structured logging
@@ -168,6 +190,19 @@ func (e *SmartExecutor) SetValidationFeedback(enabled bool, maxRetries int) {
 	}
 }
 
+// correctionLimit returns how many LLM corrections a step may use: its own
+// MaxCorrections when set, otherwise the executor-wide limit
+func (e *SmartExecutor) correctionLimit(step RoutingStep) int {
+	switch {
+	case step.MaxCorrections < 0:
+		return 0
+	case step.MaxCorrections > 0:
+		return step.MaxCorrections
+	default:
+		return e.maxValidationRetries
+	}
+}
+
 // SetHybridResolver configures the hybrid parameter resolver.
 // When set, the executor uses intelligent auto-wiring and optional LLM micro-resolution
 // instead of brittle template substitution for parameter binding between steps.
@@ -338,6 +373,10 @@ func (e *SmartExecutor) collectSourceDataFromDependencies(ctx context.Context, d
 func (e *SmartExecutor) Execute(ctx context.Context, plan *RoutingPlan) (*ExecutionResult, error) {
 	startTime := time.Now()
 
+	// Every step of this plan shares one semaphore, even if
+	// SetMaxConcurrency swaps it mid-execution
+	semaphore := e.semaphore
+
 	// Add span event for plan execution start
 	telemetry.AddSpanEvent(ctx, "plan_execution_started",
 		attribute.String("plan_id", plan.PlanID),
@@ -353,6 +392,13 @@ func (e *SmartExecutor) Execute(ctx context.Context, plan *RoutingPlan) (*Execut
 		})
 	}
 
+	// Compile step conditions up front so a malformed expression fails the
+	// plan before any step has run
+	conditions, err := compilePlanConditions(plan)
+	if err != nil {
+		return nil, err
+	}
+
 	result := &ExecutionResult{
 		PlanID:        plan.PlanID,
 		Steps:         make([]StepResult, 0, len(plan.Steps)),
@@ -408,9 +454,13 @@ func (e *SmartExecutor) Execute(ctx context.Context, plan *RoutingPlan) (*Execut
 				}
 				// Check if this step is blocked by failed dependencies
 				blockedByFailure := false
+				skipReason := "skipped due to failed dependency"
 				for _, dep := range step.DependsOn {
-					if result, ok := stepResults[dep]; ok && !result.Success {
+					if result, ok := stepResults[dep]; ok && !result.Success && !result.Skipped {
 						blockedByFailure = true
+						if result.TimedOut {
+							skipReason = fmt.Sprintf("skipped because dependency %s timed out", dep)
+						}
 						break
 					}
 				}
@@ -421,7 +471,7 @@ func (e *SmartExecutor) Execute(ctx context.Context, plan *RoutingPlan) (*Execut
 						AgentName: step.AgentName,
 						Namespace: step.Namespace,
 						Success:   false,
-						Error:     "skipped due to failed dependency",
+						Error:     skipReason,
 						StartTime: time.Now(),
 						Duration:  0,
 					}
@@ -451,6 +501,12 @@ func (e *SmartExecutor) Execute(ctx context.Context, plan *RoutingPlan) (*Execut
 			return nil, fmt.Errorf("no executable steps found - check for circular dependencies")
 		}
 
+		// Skip ready steps whose condition is false or whose dependency was skipped
+		readySteps = e.applyStepConditions(ctx, plan, readySteps, conditions, stepResults, executed, result)
+		if len(readySteps) == 0 {
+			continue
+		}
+
 		if e.logger != nil {
 			stepIDs := make([]string, len(readySteps))
 			for i, step := range readySteps {
@@ -473,12 +529,15 @@ func (e *SmartExecutor) Execute(ctx context.Context, plan *RoutingPlan) (*Execut
 				stepStartTime := time.Now()
 
 				// Acquire semaphore for concurrency control BEFORE setting up defer
-				// This ensures the semaphore is always released even if panic occurs
-				e.semaphore <- struct{}{}
+				// This ensures the semaphore is always released even if panic occurs.
+				// Steps beyond maxConcurrency queue here until a slot frees up.
+				semaphore <- struct{}{}
+				e.stepStarted(s, time.Since(stepStartTime))
 
 				defer func() {
 					// Always release semaphore first
-					<-e.semaphore
+					e.stepFinished()
+					<-semaphore
 
 					if r := recover(); r != nil {
 						// Panic recovery mechanism for step execution.
@@ -538,7 +597,10 @@ func (e *SmartExecutor) Execute(ctx context.Context, plan *RoutingPlan) (*Execut
 				stepCtx = e.buildStepContext(stepCtx, s, stepResults)
 
 				// Execute the step
-				stepResult := e.executeStep(stepCtx, s)
+				stepResult := e.executeStepWithTimeout(stepCtx, s)
+				if !stepResult.Success && ctx.Err() != nil {
+					stepResult.Cancelled = true
+				}
 
 				// Store result
 				resultsMutex.Lock()
@@ -685,10 +747,14 @@ func (e *SmartExecutor) Execute(ctx context.Context, plan *RoutingPlan) (*Execut
 			}
 		}
 
-		// Check for context cancellation
+		// Check for context cancellation. In-flight steps were cancelled
+		// through their contexts; record the rest so the partial result
+		// shows where the execution stopped.
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			e.cancelRemainingSteps(ctx, plan, executed, stepResults, result)
+			result.TotalDuration = time.Since(startTime)
+			return result, context.Cause(ctx)
 		default:
 		}
 	}
@@ -696,8 +762,11 @@ func (e *SmartExecutor) Execute(ctx context.Context, plan *RoutingPlan) (*Execut
 	result.TotalDuration = time.Since(startTime)
 
 	failedSteps := 0
+	skippedSteps := 0
 	for _, step := range result.Steps {
-		if !step.Success {
+		if step.Skipped {
+			skippedSteps++
+		} else if !step.Success {
 			failedSteps++
 		}
 	}
@@ -707,24 +776,67 @@ func (e *SmartExecutor) Execute(ctx context.Context, plan *RoutingPlan) (*Execut
 		attribute.String("plan_id", plan.PlanID),
 		attribute.Bool("success", result.Success),
 		attribute.Int("failed_steps", failedSteps),
+		attribute.Int("skipped_steps", skippedSteps),
 		attribute.Int("total_steps", len(plan.Steps)),
 		attribute.Int64("duration_ms", result.TotalDuration.Milliseconds()),
 	)
 
 	if e.logger != nil {
 		e.logger.InfoWithContext(ctx, "Plan execution finished", map[string]interface{}{
-			"operation":    "execute_plan_complete",
-			"plan_id":      plan.PlanID,
-			"success":      result.Success,
-			"failed_steps": failedSteps,
-			"total_steps":  len(plan.Steps),
-			"duration_ms":  result.TotalDuration.Milliseconds(),
+			"operation":     "execute_plan_complete",
+			"plan_id":       plan.PlanID,
+			"success":       result.Success,
+			"failed_steps":  failedSteps,
+			"skipped_steps": skippedSteps,
+			"total_steps":   len(plan.Steps),
+			"duration_ms":   result.TotalDuration.Milliseconds(),
 		})
 	}
 
 	return result, nil
 }
 
+// cancelRemainingSteps records every step that has not run as cancelled
+func (e *SmartExecutor) cancelRemainingSteps(ctx context.Context, plan *RoutingPlan, executed map[string]bool, stepResults map[string]*StepResult, result *ExecutionResult) {
+	reason := fmt.Sprintf("cancelled before it started: %v", context.Cause(ctx))
+	cancelled := 0
+	for _, step := range plan.Steps {
+		if executed[step.StepID] {
+			continue
+		}
+		cancelledResult := StepResult{
+			StepID:    step.StepID,
+			AgentName: step.AgentName,
+			Namespace: step.Namespace,
+			Success:   false,
+			Error:     reason,
+			StartTime: time.Now(),
+			Cancelled: true,
+		}
+		stepResults[step.StepID] = &cancelledResult
+		result.Steps = append(result.Steps, cancelledResult)
+		executed[step.StepID] = true
+		cancelled++
+
+		stepIndex := len(result.Steps) - 1
+		e.safeInvokeStepCallback(e.onStepComplete, stepIndex, len(plan.Steps), step, cancelledResult)
+		if ctxCallback := GetStepCallback(ctx); ctxCallback != nil {
+			e.safeInvokeStepCallback(ctxCallback, stepIndex, len(plan.Steps), step, cancelledResult)
+		}
+	}
+	result.Success = false
+
+	if e.logger != nil {
+		e.logger.InfoWithContext(ctx, "Plan execution cancelled", map[string]interface{}{
+			"operation":       "execute_plan_cancelled",
+			"plan_id":         plan.PlanID,
+			"cancelled_steps": cancelled,
+			"total_steps":     len(plan.Steps),
+			"cause":           context.Cause(ctx).Error(),
+		})
+	}
+}
+
 // findReadySteps identifies steps that can be executed.
 // A step is ready when all its dependencies have been successfully executed.
 // This enables parallel execution of independent steps.
@@ -748,8 +860,9 @@ func (e *SmartExecutor) findReadySteps(plan *RoutingPlan, executed map[string]bo
 				blockReason = "not_executed"
 				break
 			}
-			// Check if dependency was successful
-			if result, ok := results[dep]; ok && !result.Success {
+			// Check if dependency was successful. Skipped dependencies don't
+			// block; applyStepConditions cascades the skip instead.
+			if result, ok := results[dep]; ok && !result.Success && !result.Skipped {
 				// Skip steps whose dependencies failed
 				allDepsReady = false
 				blockedBy = dep
@@ -1401,6 +1514,46 @@ func normalizeFieldPath(fieldPath string) (string, bool) {
 	return "response." + fieldPath, true
 }
 
+// MinStepTimeout is the shortest RoutingStep.Timeout the executor applies;
+// shorter timeouts are raised to it so a mistyped plan cannot cancel its
+// steps before they start
+const MinStepTimeout = time.Second
+
+// executeStepWithTimeout runs step under a deadline of step.Timeout when one
+// is set. A step cut short by its own deadline (not by the caller's context)
+// is reported as failed with TimedOut set.
+func (e *SmartExecutor) executeStepWithTimeout(ctx context.Context, step RoutingStep) StepResult {
+	if step.Timeout <= 0 {
+		return e.executeStep(ctx, step)
+	}
+	timeout := step.Timeout
+	if timeout < MinStepTimeout {
+		timeout = MinStepTimeout
+	}
+
+	stepCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := e.executeStep(stepCtx, step)
+	if result.Success || ctx.Err() != nil || !errors.Is(stepCtx.Err(), context.DeadlineExceeded) {
+		return result
+	}
+
+	result.TimedOut = true
+	result.Error = fmt.Sprintf("step timed out after %s: %s", timeout, result.Error)
+	telemetry.Counter("orchestration.step.timeouts", "agent_name", step.AgentName)
+	if e.logger != nil {
+		e.logger.WarnWithContext(ctx, "Step cancelled by timeout", map[string]interface{}{
+			"operation":   "step_timeout",
+			"step_id":     step.StepID,
+			"agent_name":  step.AgentName,
+			"timeout_ms":  timeout.Milliseconds(),
+			"duration_ms": result.Duration.Milliseconds(),
+		})
+	}
+	return result
+}
+
 // executeStep executes a single routing step
 func (e *SmartExecutor) executeStep(ctx context.Context, step RoutingStep) StepResult {
 	startTime := time.Now()
@@ -1424,6 +1577,7 @@ func (e *SmartExecutor) executeStep(ctx context.Context, step RoutingStep) StepR
 		AgentName:   step.AgentName,
 		Namespace:   step.Namespace,
 		Instruction: step.Instruction,
+		SubQuestion: step.SubQuestion,
 		StartTime:   startTime,
 		Attempts:    0,
 	}
@@ -1544,7 +1698,7 @@ func (e *SmartExecutor) executeStep(ctx context.Context, step RoutingStep) StepR
 				stepResultsMap := e.collectDependencyResults(ctx, step.DependsOn)
 
 				// Pass step instruction for ordinal resolution context (e.g., "first", "second", "third")
-				resolved, err := e.hybridResolver.ResolveParameters(ctx, stepResultsMap, capabilityForResolution, step.StepID, step.Instruction)
+				resolved, resolution, err := e.hybridResolver.ResolveParametersWithReport(ctx, stepResultsMap, capabilityForResolution, step.StepID, step.Instruction)
 				if err != nil {
 					if e.logger != nil {
 						e.logger.WarnWithContext(ctx, "Hybrid resolution failed, falling back to template interpolation", map[string]interface{}{
@@ -1587,12 +1741,25 @@ func (e *SmartExecutor) executeStep(ctx context.Context, step RoutingStep) StepR
 						attribute.String("step_id", step.StepID),
 						attribute.String("capability", capability),
 						attribute.Int("resolved_count", len(resolved)),
+						attribute.String("resolution_method", resolution.Method),
 					)
 					telemetry.Counter("orchestration.hybrid_resolution.success",
 						"capability", capability,
 						"module", telemetry.ModuleOrchestration,
 					)
 				}
+
+				// Record how parameters were obtained so callers can audit LLM use
+				if err == nil && resolution != nil {
+					if result.Metadata == nil {
+						result.Metadata = make(map[string]interface{})
+					}
+					result.Metadata["parameter_resolution"] = resolution
+					telemetry.Counter("orchestration.parameter_resolution",
+						"method", resolution.Method,
+						"module", telemetry.ModuleOrchestration,
+					)
+				}
 			}
 		}
 
@@ -1783,7 +1950,9 @@ func (e *SmartExecutor) executeStep(ctx context.Context, step RoutingStep) StepR
 		maxAttempts = 2 // Fallback default if not set
 	}
 	validationRetries := 0
-	previousErrors := []string{} // Layer 4: tracks error history for semantic retry
+	maxCorrections := e.correctionLimit(step)
+	previousErrors := []string{}   // Layer 4: tracks error history for semantic retry
+	var failure *core.ToolResponse // Envelope of the last failed attempt, if the capability sent one
 
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		result.Attempts = attempt
@@ -1824,9 +1993,50 @@ func (e *SmartExecutor) executeStep(ctx context.Context, step RoutingStep) StepR
 			}
 			result.Success = true
 			result.Response = response
+			e.unwrapResultEnvelope(ctx, step, capabilitySchema, &result)
+			if result.Success {
+				e.enforceOutputContract(ctx, step, capabilitySchema, &result)
+			}
 			break
 		}
 
+		// A recovered handler panic is a bug in the capability, not something
+		// a retry or corrected parameters will fix: surface it and stop
+		if panicErr, ok := recoveredPanicError(responseBody); ok {
+			result.Error = fmt.Sprintf("capability %s panicked: %s", capability, panicErr)
+			if result.Metadata == nil {
+				result.Metadata = make(map[string]interface{})
+			}
+			result.Metadata["panic_recovered"] = true
+
+			telemetry.AddSpanEvent(ctx, "capability_panic",
+				attribute.String("step_id", step.StepID),
+				attribute.String("capability", capability),
+			)
+			telemetry.Counter("orchestration.capability_panics",
+				"capability", capability,
+				"module", telemetry.ModuleOrchestration,
+			)
+			if e.logger != nil {
+				e.logger.ErrorWithContext(ctx, "Capability panicked", map[string]interface{}{
+					"operation":  "capability_panic",
+					"step_id":    step.StepID,
+					"agent_name": step.AgentName,
+					"capability": capability,
+					"error":      panicErr,
+				})
+			}
+			break
+		}
+
+		// Enveloped capabilities report failures in the envelope, whatever the
+		// status code: keep its error, timing and metadata for the step result
+		failure = nil
+		if envelope, ok := failureEnvelope(capabilitySchema, responseBody); ok {
+			failure = envelope
+			applyResultEnvelope(&result, envelope)
+		}
+
 		// Layer 3: LLM-based Error Analysis (Phase 4 Enhancement)
 		// When ErrorAnalyzer is configured, use LLM to determine if error can be fixed
 		// with different parameters. This replaces the need for tools to set Retryable flags.
@@ -1834,7 +2044,7 @@ func (e *SmartExecutor) executeStep(ctx context.Context, step RoutingStep) StepR
 		//   - 400, 404, 409, 422 → LLM Error Analyzer (might be fixable with different input)
 		//   - 408, 429, 5xx      → Resilience module (same payload + exponential backoff)
 		//   - 401, 403, 405      → Fail immediately (auth/permission issues)
-		if e.errorAnalyzer != nil && e.errorAnalyzer.IsEnabled() && validationRetries < e.maxValidationRetries {
+		if e.errorAnalyzer != nil && e.errorAnalyzer.IsEnabled() && validationRetries < maxCorrections {
 			httpStatus := extractHTTPStatusFromError(err)
 
 			// Build error analysis context
@@ -2100,7 +2310,7 @@ func (e *SmartExecutor) executeStep(ctx context.Context, step RoutingStep) StepR
 		// 2. Tool errors with Retryable: true (structured ToolResponse.Error.Retryable)
 		// This ensures AI correction for ALL retryable errors per INTELLIGENT_ERROR_HANDLING.md
 		if e.errorAnalyzer == nil && e.validationFeedbackEnabled && e.correctionCallback != nil &&
-			validationRetries < e.maxValidationRetries && shouldAttemptAICorrection(err, responseBody) {
+			validationRetries < maxCorrections && shouldAttemptAICorrection(err, responseBody) {
 
 			validationRetries++
 
@@ -2127,13 +2337,13 @@ func (e *SmartExecutor) executeStep(ctx context.Context, step RoutingStep) StepR
 					"step_id":          step.StepID,
 					"capability":       capability,
 					"validation_retry": validationRetries,
-					"max_retries":      e.maxValidationRetries,
+					"max_retries":      maxCorrections,
 					"error":            err.Error(),
 				})
 			}
 
 			// Request correction from LLM via callback
-			correctedParams, corrErr := e.correctionCallback(ctx, step, parameters, err.Error(), capabilitySchema)
+			correctedParams, corrErr := e.correctionCallback(withCorrectionAttempt(ctx, validationRetries), step, parameters, err.Error(), capabilitySchema)
 			if corrErr == nil && correctedParams != nil {
 				// Telemetry: Record successful correction with corrected parameters
 				// Serialize corrected params for visibility in distributed traces
@@ -2212,6 +2422,7 @@ func (e *SmartExecutor) executeStep(ctx context.Context, step RoutingStep) StepR
 			}
 
 			// Break out of retry loop - no point retrying with same parameters
+			result.Error = err.Error()
 			break
 		}
 
@@ -2258,9 +2469,16 @@ func (e *SmartExecutor) executeStep(ctx context.Context, step RoutingStep) StepR
 					"delay_seconds": retryDelay.Seconds(),
 				})
 			}
-			time.Sleep(retryDelay)
+			select {
+			case <-ctx.Done():
+			case <-time.After(retryDelay):
+			}
 		}
 	}
+	if !result.Success && failure != nil {
+		// Report the capability's own error rather than the raw status error
+		result.Error = envelopeErrorMessage(failure)
+	}
 
 	result.EndTime = time.Now()
 	result.Duration = time.Since(startTime)
@@ -2683,6 +2901,29 @@ func isRetryableToolError(responseBody string) bool {
 	return false
 }
 
+// recoveredPanicError reports whether the response body is the 500 envelope
+// core.RecoveryMiddleware writes for a panicking handler, and if so returns
+// its message with the request ID, which finds the stack trace in the
+// component's logs.
+func recoveredPanicError(responseBody string) (string, bool) {
+	if responseBody == "" {
+		return "", false
+	}
+
+	var response core.ToolResponse
+	if err := json.Unmarshal([]byte(responseBody), &response); err != nil {
+		return "", false
+	}
+	if response.Success || response.Error == nil || response.Error.Details[core.PanicDetail] != "true" {
+		return "", false
+	}
+
+	if response.RequestID != "" {
+		return fmt.Sprintf("%s (request_id: %s)", response.Error.Message, response.RequestID), true
+	}
+	return response.Error.Message, true
+}
+
 // isNonRetryableToolError parses the response body as a ToolResponse and checks
 // if the error is explicitly marked as non-retryable (retryable: false).
 // This is used to prevent blind retries when a tool indicates the error cannot
@@ -2780,6 +3021,8 @@ func (e *SmartExecutor) callComponentWithBody(ctx context.Context, url string, b
 		return "", "", fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	// Forward request metadata so the component inherits it
+	core.InjectRequestMetadata(ctx, req.Header)
 
 	// Make the request
 	resp, err := e.httpClient.Do(req)
@@ -2876,13 +3119,34 @@ func (e *SmartExecutor) ExecuteStep(ctx context.Context, step RoutingStep) (*Ste
 	return &result, nil
 }
 
-// SetMaxConcurrency sets the maximum number of parallel executions
+// SetMaxConcurrency sets the maximum number of steps that run at once.
+// Ready steps beyond the limit wait for a free slot, so a DAG level with
+// more independent steps than the limit takes several rounds. Values below
+// 1 are ignored. Plans already executing keep their previous limit.
 func (e *SmartExecutor) SetMaxConcurrency(max int) {
+	if max < 1 {
+		return
+	}
 	e.maxConcurrency = max
 	// Recreate semaphore with new size
 	e.semaphore = make(chan struct{}, max)
 }
 
+// stepStarted records a step taking a concurrency slot after waiting queued
+// for the given duration
+func (e *SmartExecutor) stepStarted(step RoutingStep, queued time.Duration) {
+	inFlight := e.inFlightSteps.Add(1)
+	telemetry.Gauge("orchestration.steps.in_flight", float64(inFlight))
+	telemetry.Histogram("orchestration.step.queue_wait_ms", float64(queued.Milliseconds()),
+		"agent_name", step.AgentName)
+}
+
+// stepFinished records a step releasing its concurrency slot
+func (e *SmartExecutor) stepFinished() {
+	inFlight := e.inFlightSteps.Add(-1)
+	telemetry.Gauge("orchestration.steps.in_flight", float64(inFlight))
+}
+
 // SimpleExecutor is kept for backward compatibility
 type SimpleExecutor struct {
 	*SmartExecutor
@@ -5,9 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/itsneelabh/gomind/core"
 )
@@ -333,6 +338,220 @@ func TestSmartExecutor_SetMaxConcurrency(t *testing.T) {
 	}
 }
 
+func TestSmartExecutor_SetMaxConcurrencyIgnoresInvalid(t *testing.T) {
+	executor := NewSmartExecutor(&AgentCatalog{agents: make(map[string]*AgentInfo)})
+
+	executor.SetMaxConcurrency(0)
+	executor.SetMaxConcurrency(-3)
+
+	if executor.maxConcurrency != 5 || cap(executor.semaphore) != 5 {
+		t.Errorf("Expected default limit to be kept, got %d (semaphore %d)", executor.maxConcurrency, cap(executor.semaphore))
+	}
+}
+
+// concurrencyTrackingTransport records the peak number of simultaneous requests
+type concurrencyTrackingTransport struct {
+	mu      sync.Mutex
+	current int
+	peak    int
+	delay   time.Duration
+}
+
+func (c *concurrencyTrackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.current++
+	if c.current > c.peak {
+		c.peak = c.current
+	}
+	c.mu.Unlock()
+
+	time.Sleep(c.delay)
+
+	c.mu.Lock()
+	c.current--
+	c.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"result": "ok"}`)),
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+func TestSmartExecutor_MaxConcurrencyBoundsLevel(t *testing.T) {
+	catalog := &AgentCatalog{
+		agents: map[string]*AgentInfo{
+			"agent-1": {
+				Registration: &core.ServiceRegistration{
+					ID:      "agent-1",
+					Name:    "agent-1",
+					Address: "localhost",
+					Port:    8081,
+				},
+				Capabilities: []EnhancedCapability{
+					{Name: "cap1", Endpoint: "/api/cap1"},
+				},
+			},
+		},
+	}
+	executor := NewSmartExecutor(catalog)
+	executor.SetMaxConcurrency(2)
+	transport := &concurrencyTrackingTransport{delay: 50 * time.Millisecond}
+	executor.httpClient = &http.Client{Transport: transport}
+
+	// Six independent steps form a single DAG level
+	plan := &RoutingPlan{PlanID: "wide-plan"}
+	for i := 1; i <= 6; i++ {
+		plan.Steps = append(plan.Steps, RoutingStep{
+			StepID:    fmt.Sprintf("step-%d", i),
+			AgentName: "agent-1",
+			Metadata: map[string]interface{}{
+				"capability": "cap1",
+				"parameters": map[string]interface{}{},
+			},
+		})
+	}
+
+	start := time.Now()
+	result, err := executor.Execute(context.Background(), plan)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("Execution failed: %v", err)
+	}
+	if len(result.Steps) != 6 || !result.Success {
+		t.Fatalf("Expected 6 successful steps, got %d (success=%v)", len(result.Steps), result.Success)
+	}
+	if transport.peak > 2 {
+		t.Errorf("Expected at most 2 steps in flight, saw %d", transport.peak)
+	}
+	// Three rounds of two steps each
+	if elapsed < 150*time.Millisecond {
+		t.Errorf("Expected throttled level to take at least 150ms, took %v", elapsed)
+	}
+	if inFlight := executor.inFlightSteps.Load(); inFlight != 0 {
+		t.Errorf("Expected no steps in flight after execution, got %d", inFlight)
+	}
+}
+
+// hangingTransport never answers requests to hangURL until they are cancelled
+type hangingTransport struct {
+	hangURL string
+}
+
+func (h *hangingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.String() == h.hangURL {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"result": "ok"}`)),
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+func TestSmartExecutor_StepTimeout(t *testing.T) {
+	catalog := &AgentCatalog{
+		agents: map[string]*AgentInfo{
+			"slow": {
+				Registration: &core.ServiceRegistration{ID: "slow", Name: "slow-agent", Address: "localhost", Port: 8081},
+				Capabilities: []EnhancedCapability{{Name: "hang", Endpoint: "/api/hang"}},
+			},
+			"fast": {
+				Registration: &core.ServiceRegistration{ID: "fast", Name: "fast-agent", Address: "localhost", Port: 8082},
+				Capabilities: []EnhancedCapability{{Name: "quick", Endpoint: "/api/quick"}},
+			},
+		},
+	}
+	executor := NewSmartExecutor(catalog)
+	executor.httpClient = &http.Client{Transport: &hangingTransport{hangURL: "http://localhost:8081/api/hang"}}
+
+	hang := map[string]interface{}{"capability": "hang", "parameters": map[string]interface{}{}}
+	quick := map[string]interface{}{"capability": "quick", "parameters": map[string]interface{}{}}
+	plan := &RoutingPlan{
+		PlanID: "timeout-plan",
+		Steps: []RoutingStep{
+			{StepID: "step-1", AgentName: "slow-agent", Metadata: hang, Timeout: 50 * time.Millisecond}, // raised to MinStepTimeout
+			{StepID: "step-2", AgentName: "fast-agent", Metadata: quick, DependsOn: []string{"step-1"}},
+			{StepID: "step-3", AgentName: "fast-agent", Metadata: quick},
+		},
+	}
+
+	start := time.Now()
+	result, err := executor.Execute(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Execution should not hang on the slow step, took %v", elapsed)
+	}
+
+	steps := make(map[string]StepResult)
+	for _, step := range result.Steps {
+		steps[step.StepID] = step
+	}
+	if s := steps["step-1"]; s.Success || !s.TimedOut || !strings.Contains(s.Error, "timed out after 1s") {
+		t.Errorf("Expected step-1 to time out, got success=%v timedOut=%v error=%q", s.Success, s.TimedOut, s.Error)
+	}
+	if s := steps["step-2"]; s.Success || s.TimedOut || !strings.Contains(s.Error, "dependency step-1 timed out") {
+		t.Errorf("Expected step-2 to be skipped after the timeout, got success=%v timedOut=%v error=%q", s.Success, s.TimedOut, s.Error)
+	}
+	if s := steps["step-3"]; !s.Success || s.TimedOut {
+		t.Errorf("Expected independent step-3 to succeed, got error %q", s.Error)
+	}
+	if result.Success {
+		t.Error("Expected plan to fail when a step times out")
+	}
+}
+
+func TestRoutingStep_TimeoutJSON(t *testing.T) {
+	cases := map[string]time.Duration{
+		`{"step_id": "s", "timeout": "30s"}`:   30 * time.Second,
+		`{"step_id": "s", "timeout": 30}`:      30 * time.Second,
+		`{"step_id": "s", "timeout": "1.5"}`:   1500 * time.Millisecond,
+		`{"step_id": "s", "timeout": "soon"}`:  0,
+		`{"step_id": "s", "timeout": -5}`:      0,
+		`{"step_id": "s", "timeout": null}`:    0,
+		`{"step_id": "s"}`:                     0,
+		`{"step_id": "s", "timeout": "250ms"}`: 250 * time.Millisecond,
+	}
+	for input, want := range cases {
+		var step RoutingStep
+		if err := json.Unmarshal([]byte(input), &step); err != nil {
+			t.Errorf("%s: unexpected error %v", input, err)
+			continue
+		}
+		if step.StepID != "s" || step.Timeout != want {
+			t.Errorf("%s: expected step s with timeout %v, got %q %v", input, want, step.StepID, step.Timeout)
+		}
+	}
+
+	data, err := json.Marshal(RoutingStep{StepID: "s", Timeout: 90 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"timeout":"1m30s"`) {
+		t.Errorf("Expected timeout as a duration string, got %s", data)
+	}
+	var roundTrip RoutingStep
+	if err := json.Unmarshal(data, &roundTrip); err != nil || roundTrip.Timeout != 90*time.Second {
+		t.Errorf("Expected round trip to keep the timeout, got %v (err %v)", roundTrip.Timeout, err)
+	}
+}
+
+func TestNewAIOrchestrator_AppliesMaxConcurrency(t *testing.T) {
+	config := DefaultConfig()
+	config.ExecutionOptions.MaxConcurrency = 3
+
+	orchestrator := NewAIOrchestrator(config, NewMockDiscovery(), NewMockAIClient())
+
+	if got := orchestrator.executor.maxConcurrency; got != 3 {
+		t.Errorf("Expected max concurrency 3 from config, got %d", got)
+	}
+}
+
 func TestSmartExecutor_ContextCancellation(t *testing.T) {
 	catalog := &AgentCatalog{
 		agents: make(map[string]*AgentInfo),
@@ -1506,6 +1725,77 @@ func TestSmartExecutor_ValidationFeedbackDisabled(t *testing.T) {
 	}
 }
 
+// TestSmartExecutor_StepMaxCorrections tests the per-step correction limit
+// and the attempt number passed to the correction callback
+func TestSmartExecutor_StepMaxCorrections(t *testing.T) {
+	catalog := &AgentCatalog{
+		agents: map[string]*AgentInfo{
+			"weather-tool": {
+				Registration: &core.ServiceRegistration{
+					ID:      "weather-tool",
+					Name:    "weather-tool",
+					Address: "localhost",
+					Port:    8080,
+				},
+				Capabilities: []EnhancedCapability{
+					{Name: "get_weather", Endpoint: "/api/weather"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		maxCorrections int
+		wantAttempts   []int
+	}{
+		{"executor default", 0, []int{1}},
+		{"step override", 3, []int{1, 2, 3}},
+		{"disabled for step", -1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := NewSmartExecutor(catalog)
+			executor.httpClient = &http.Client{Transport: &validationFeedbackRoundTripper{
+				getResponse: func(callNum int, params map[string]interface{}) (int, string) {
+					return http.StatusBadRequest, `{"error": "json: cannot unmarshal string into float64"}`
+				},
+			}}
+			executor.SetMaxAttempts(1)
+			executor.SetValidationFeedback(true, 1)
+
+			var attempts []int
+			executor.SetCorrectionCallback(func(ctx context.Context, step RoutingStep, params map[string]interface{}, errMsg string, schema *EnhancedCapability) (map[string]interface{}, error) {
+				attempts = append(attempts, GetCorrectionAttempt(ctx))
+				return params, nil
+			})
+
+			step := RoutingStep{
+				StepID:         "weather-step",
+				AgentName:      "weather-tool",
+				MaxCorrections: tt.maxCorrections,
+				Metadata: map[string]interface{}{
+					"capability": "get_weather",
+					"parameters": map[string]interface{}{"lat": "35.6897"},
+				},
+			}
+
+			result := executor.executeStep(context.Background(), step)
+			if result.Success {
+				t.Error("Step should fail when the tool keeps rejecting parameters")
+			}
+			if fmt.Sprint(attempts) != fmt.Sprint(tt.wantAttempts) {
+				t.Errorf("Expected correction attempts %v, got %v", tt.wantAttempts, attempts)
+			}
+		})
+	}
+
+	if got := GetCorrectionAttempt(context.Background()); got != 0 {
+		t.Errorf("Expected no correction attempt outside a correction, got %d", got)
+	}
+}
+
 // ============================================================================
 // Template Substitution Tests (Response Wrapper Fix)
 // ============================================================================
@@ -2091,3 +2381,47 @@ func (rt *dependencyVerifyingRoundTripper) RoundTrip(req *http.Request) (*http.R
 		Header:     make(http.Header),
 	}, nil
 }
+
+func TestSmartExecutor_SurfacesCapabilityPanic(t *testing.T) {
+	tool := core.NewTool("maps-tool")
+	tool.RegisterCapability(core.Capability{
+		Name: "lookup",
+		Execute: func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			var cache map[string]*string
+			return *cache["missing"], nil
+		},
+	})
+	server := httptest.NewServer(tool.Handler())
+	defer server.Close()
+	host, portStr, _ := net.SplitHostPort(server.Listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+
+	catalog := &AgentCatalog{
+		agents: map[string]*AgentInfo{
+			"maps-1": {
+				Registration: &core.ServiceRegistration{ID: "maps-1", Name: "maps-tool", Address: host, Port: port},
+				Capabilities: []EnhancedCapability{{Name: "lookup", Endpoint: "/api/capabilities/lookup"}},
+			},
+		},
+	}
+	executor := NewSmartExecutor(catalog)
+
+	result := executor.executeStep(context.Background(), RoutingStep{
+		StepID:    "step-1",
+		AgentName: "maps-tool",
+		Metadata:  map[string]interface{}{"capability": "lookup"},
+	})
+
+	if result.Success {
+		t.Fatal("Expected the step to fail")
+	}
+	if result.Attempts != 1 {
+		t.Errorf("Expected no retries after a panic, got %d attempts", result.Attempts)
+	}
+	if !strings.Contains(result.Error, "capability lookup panicked") || !strings.Contains(result.Error, "request_id:") {
+		t.Errorf("Expected the recovered panic in the step error, got %q", result.Error)
+	}
+	if result.Metadata["panic_recovered"] != true {
+		t.Errorf("Expected the step to be marked as a recovered panic, got %+v", result.Metadata)
+	}
+}
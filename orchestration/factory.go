@@ -201,12 +201,19 @@ func CreateOrchestrator(config *OrchestratorConfig, deps OrchestratorDependencie
 	if config.LLMDebug.Enabled {
 		if config.LLMDebugStore == nil {
 			// Auto-configure Redis store from environment
-			store, err := NewRedisLLMDebugStore(
+			storeOpts := []RedisLLMDebugStoreOption{
 				WithDebugRedisDB(config.LLMDebug.RedisDB),
 				WithDebugLogger(deps.Logger),
 				WithDebugTTL(config.LLMDebug.TTL),
 				WithDebugErrorTTL(config.LLMDebug.ErrorTTL),
-			)
+			}
+			if config.LLMDebug.Redact {
+				storeOpts = append(storeOpts, WithDebugRedactor(DefaultLLMDebugRedactor()))
+			}
+			if config.LLMDebug.HashOnly {
+				storeOpts = append(storeOpts, WithDebugContentHashOnly())
+			}
+			store, err := NewRedisLLMDebugStore(storeOpts...)
 			if err != nil {
 				// Resilient behavior - use NoOp store if Redis unavailable
 				factoryLogger.Warn("Failed to initialize Redis LLM debug store, using NoOp", map[string]interface{}{
@@ -222,11 +229,21 @@ func CreateOrchestrator(config *OrchestratorConfig, deps OrchestratorDependencie
 					"redis_db":  config.LLMDebug.RedisDB,
 					"ttl":       config.LLMDebug.TTL.String(),
 					"error_ttl": config.LLMDebug.ErrorTTL.String(),
+					"redact":    config.LLMDebug.Redact,
+					"hash_only": config.LLMDebug.HashOnly,
 				})
 			}
 		} else {
+			// Redaction settings apply to custom stores too
+			var redactor LLMDebugRedactor
+			if config.LLMDebug.Redact {
+				redactor = DefaultLLMDebugRedactor()
+			}
+			config.LLMDebugStore = NewRedactingLLMDebugStore(config.LLMDebugStore, redactor, config.LLMDebug.HashOnly)
 			factoryLogger.Info("Using custom LLM debug store", map[string]interface{}{
 				"operation": "llm_debug_store_initialization",
+				"redact":    config.LLMDebug.Redact,
+				"hash_only": config.LLMDebug.HashOnly,
 			})
 		}
 		orchestrator.SetLLMDebugStore(config.LLMDebugStore)
@@ -335,6 +352,15 @@ func WithPlanParseRetry(enabled bool, maxRetries int) OrchestratorOption {
 	}
 }
 
+// WithPlanCoalescing enables or disables sharing one in-flight
+// plan-generation LLM call between concurrent identical requests.
+// Enabled by default.
+func WithPlanCoalescing(enabled bool) OrchestratorOption {
+	return func(c *OrchestratorConfig) {
+		c.PlanCoalescingEnabled = enabled
+	}
+}
+
 // WithHallucinationRetry creates an option for configuring hallucination retry behavior.
 // When enabled, the orchestrator will retry LLM plan generation if the LLM hallucinates
 // agent names that were not in the allowed list provided in the prompt.
@@ -79,6 +79,9 @@ type mockCheckpointStore struct {
 	loadErr     error
 	listErr     error
 	deleteErr   error
+
+	expiryCallback ExpiryCallback
+	expiryConfig   *ExpiryProcessorConfig
 }
 
 func newMockCheckpointStore() *mockCheckpointStore {
@@ -130,8 +133,9 @@ func (m *mockCheckpointStore) DeleteCheckpoint(ctx context.Context, checkpointID
 	return nil
 }
 
-// Expiry processor methods (no-op for mock)
+// Expiry processor methods (record their arguments, never run)
 func (m *mockCheckpointStore) StartExpiryProcessor(ctx context.Context, config ExpiryProcessorConfig) error {
+	m.expiryConfig = &config
 	return nil
 }
 
@@ -140,6 +144,7 @@ func (m *mockCheckpointStore) StopExpiryProcessor(ctx context.Context) error {
 }
 
 func (m *mockCheckpointStore) SetExpiryCallback(callback ExpiryCallback) error {
+	m.expiryCallback = callback
 	return nil
 }
 
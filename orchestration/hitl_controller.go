@@ -3,6 +3,7 @@ package orchestration
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -111,29 +112,39 @@ func (c *DefaultInterruptController) CheckPlanApproval(ctx context.Context, plan
 		return nil, nil
 	}
 
-	if c.policy == nil {
+	violations := GetPlanViolations(ctx)
+	if c.policy == nil && len(violations) == 0 {
 		return nil, nil
 	}
 
 	// Get request_id from context (set by orchestrator via WithRequestID)
 	requestID := GetRequestID(ctx)
 
-	decision, err := c.policy.ShouldApprovePlan(ctx, plan)
-	if err != nil {
-		// Record error on span (per gold standard in executor.go)
-		telemetry.RecordSpanError(ctx, err)
-		// Log with operation field (per gold standard pattern)
-		if c.logger != nil {
-			c.logger.ErrorWithContext(ctx, "Policy check failed", map[string]interface{}{
-				"operation":  "hitl_plan_approval",
-				"request_id": requestID,
-				"error":      err.Error(),
-			})
+	decision := &InterruptDecision{}
+	if c.policy != nil {
+		var err error
+		decision, err = c.policy.ShouldApprovePlan(ctx, plan)
+		if err != nil {
+			// Record error on span (per gold standard in executor.go)
+			telemetry.RecordSpanError(ctx, err)
+			// Log with operation field (per gold standard pattern)
+			if c.logger != nil {
+				c.logger.ErrorWithContext(ctx, "Policy check failed", map[string]interface{}{
+					"operation":  "hitl_plan_approval",
+					"request_id": requestID,
+					"error":      err.Error(),
+				})
+			}
+			return nil, fmt.Errorf("policy check failed: %w", err)
 		}
-		return nil, fmt.Errorf("policy check failed: %w", err)
 	}
 
-	if !decision.ShouldInterrupt {
+	// Plan validator violations force approval even when the policy would not interrupt
+	if (decision == nil || !decision.ShouldInterrupt) && len(violations) > 0 {
+		decision = planViolationDecision(violations)
+	}
+
+	if decision == nil || !decision.ShouldInterrupt {
 		return nil, nil
 	}
 
@@ -820,6 +831,7 @@ func (c *DefaultInterruptController) createCheckpoint(
 		CheckpointID:      fmt.Sprintf("cp-%s", uuid.New().String()[:16]),
 		RequestID:         requestID,
 		OriginalRequestID: originalRequestID,
+		AgentName:         getEnvOrDefault("GOMIND_AGENT_NAME", ""),
 		InterruptPoint:    point,
 		Decision:          decision,
 		Plan:              plan,
@@ -879,5 +891,23 @@ func (c *DefaultInterruptController) createCheckpoint(
 	return checkpoint
 }
 
+// planViolationDecision builds an interrupt decision from PlanValidator violations
+func planViolationDecision(violations []PlanViolation) *InterruptDecision {
+	messages := make([]string, len(violations))
+	for i, v := range violations {
+		messages[i] = v.Message
+	}
+	return &InterruptDecision{
+		ShouldInterrupt: true,
+		Reason:          ReasonPolicyViolation,
+		Message:         fmt.Sprintf("Plan requires approval: %s", strings.Join(messages, "; ")),
+		Priority:        PriorityHigh,
+		DefaultAction:   CommandReject,
+		Metadata: map[string]interface{}{
+			"plan_violations": violations,
+		},
+	}
+}
+
 // Compile-time interface compliance check
 var _ InterruptController = (*DefaultInterruptController)(nil)
@@ -283,6 +283,7 @@ const (
 	ReasonOutputValidation   InterruptReason = "output_validation"
 	ReasonEscalation         InterruptReason = "escalation"
 	ReasonContextGathering   InterruptReason = "context_gathering"
+	ReasonPolicyViolation    InterruptReason = "policy_violation"
 	ReasonCustom             InterruptReason = "custom"
 )
 
@@ -312,6 +313,9 @@ type ExecutionCheckpoint struct {
 	// distributed traces using the original_request_id tag.
 	OriginalRequestID string `json:"original_request_id,omitempty"`
 
+	// AgentName is the agent that paused its execution (GOMIND_AGENT_NAME)
+	AgentName string `json:"agent_name,omitempty"`
+
 	// Interrupt context
 	InterruptPoint InterruptPoint     `json:"interrupt_point"`
 	Decision       *InterruptDecision `json:"decision"`
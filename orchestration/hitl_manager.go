@@ -0,0 +1,448 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/itsneelabh/gomind/core"
+	"github.com/itsneelabh/gomind/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// =============================================================================
+// HITLManager - Programmatic API for Human-in-the-Loop Checkpoints
+// =============================================================================
+//
+// HITLManager is the Go counterpart of HITLHandler: it lets applications build
+// their own approval UI on top of the checkpoint store without knowing its
+// key schema.
+//
+//   - CreateCheckpoint: pause an execution for human review
+//   - ListPending: checkpoints awaiting a decision
+//   - ResolveCheckpoint: apply a human decision and continue the execution
+//
+// Usage:
+//
+//	manager := orchestration.NewHITLManager(controller, store,
+//	    orchestration.WithHITLOrchestrator(orchestrator),
+//	)
+//
+//	pending, _ := manager.ListPending(ctx, "travel-agent")
+//	err := manager.ResolveCheckpoint(ctx, pending[0].CheckpointID, orchestration.ApprovalDecision{
+//	    Action: orchestration.CommandApprove,
+//	    UserID: "alice",
+//	})
+//
+// =============================================================================
+
+// ApprovalDecision is a human's decision on a pending checkpoint
+type ApprovalDecision struct {
+	// Action is the decision: CommandApprove, CommandReject, CommandEdit, etc.
+	Action CommandType `json:"action"`
+
+	// EditedPlan replaces the checkpoint's plan (CommandEdit on a plan checkpoint)
+	EditedPlan *RoutingPlan `json:"edited_plan,omitempty"`
+
+	// EditedParams are merged over the paused step's resolved parameters
+	EditedParams map[string]interface{} `json:"edited_params,omitempty"`
+
+	Feedback string `json:"feedback,omitempty"` // Rejection reason
+	Response string `json:"response,omitempty"` // Context gathering response
+	UserID   string `json:"user_id,omitempty"`
+}
+
+// CheckpointSummary is a lightweight view of a checkpoint for approval UIs
+type CheckpointSummary struct {
+	CheckpointID      string            `json:"checkpoint_id"`
+	RequestID         string            `json:"request_id"`
+	OriginalRequestID string            `json:"original_request_id,omitempty"`
+	AgentName         string            `json:"agent_name,omitempty"`
+	OriginalRequest   string            `json:"original_request"`
+	InterruptPoint    InterruptPoint    `json:"interrupt_point"`
+	Reason            InterruptReason   `json:"reason,omitempty"`
+	Message           string            `json:"message,omitempty"`
+	Priority          InterruptPriority `json:"priority,omitempty"`
+	StepID            string            `json:"step_id,omitempty"`
+	StepAgent         string            `json:"step_agent,omitempty"`
+	Status            CheckpointStatus  `json:"status"`
+	CreatedAt         time.Time         `json:"created_at"`
+	ExpiresAt         time.Time         `json:"expires_at"`
+}
+
+// ResumeFunc continues a paused execution. ctx is prepared by
+// BuildResumeContext, so the approved plan, completed steps and edited
+// parameters are already in place.
+type ResumeFunc func(ctx context.Context, checkpoint *ExecutionCheckpoint) error
+
+// HITLManager creates, lists and resolves HITL checkpoints.
+type HITLManager struct {
+	controller InterruptController
+	store      CheckpointStore
+	resume     ResumeFunc
+	agentName  string
+	logger     core.Logger
+
+	// Optional: expiry handling
+	executionStore ExecutionStore
+	expiryHandler  ExpiryCallback
+}
+
+// HITLManagerOption configures a HITLManager
+type HITLManagerOption func(*HITLManager)
+
+// WithHITLManagerLogger sets the logger for the manager
+func WithHITLManagerLogger(logger core.Logger) HITLManagerOption {
+	return func(m *HITLManager) {
+		if logger == nil {
+			return
+		}
+		if cal, ok := logger.(core.ComponentAwareLogger); ok {
+			m.logger = cal.WithComponent("framework/orchestration")
+		} else {
+			m.logger = logger
+		}
+	}
+}
+
+// WithHITLResumeFunc sets how ResolveCheckpoint continues an approved execution
+func WithHITLResumeFunc(fn ResumeFunc) HITLManagerOption {
+	return func(m *HITLManager) {
+		m.resume = fn
+	}
+}
+
+// WithHITLOrchestrator makes ResolveCheckpoint continue approved executions
+// by re-running the checkpoint's request on orchestrator in resume mode
+func WithHITLOrchestrator(orchestrator Orchestrator) HITLManagerOption {
+	return WithHITLResumeFunc(func(ctx context.Context, checkpoint *ExecutionCheckpoint) error {
+		_, err := orchestrator.ProcessRequest(ctx, checkpoint.OriginalRequest, checkpoint.UserContext)
+		return err
+	})
+}
+
+// WithHITLExecutionStore records how each checkpoint was resolved in the
+// metadata of its execution record
+func WithHITLExecutionStore(store ExecutionStore) HITLManagerOption {
+	return func(m *HITLManager) {
+		m.executionStore = store
+	}
+}
+
+// WithHITLExpiryHandler replaces the expiry reaper's default handling
+// (resuming checkpoints whose default action approved them) with handler.
+// The checkpoint status and the execution record are still updated.
+func WithHITLExpiryHandler(handler ExpiryCallback) HITLManagerOption {
+	return func(m *HITLManager) {
+		m.expiryHandler = handler
+	}
+}
+
+// WithHITLAgentName sets the agent name stamped on checkpoints created
+// without one. Defaults to GOMIND_AGENT_NAME.
+func WithHITLAgentName(name string) HITLManagerOption {
+	return func(m *HITLManager) {
+		m.agentName = name
+	}
+}
+
+// NewHITLManager creates a manager over controller and store. Without a
+// resume option, ResolveCheckpoint records the decision and leaves resuming
+// to the caller.
+func NewHITLManager(controller InterruptController, store CheckpointStore, opts ...HITLManagerOption) *HITLManager {
+	m := &HITLManager{
+		controller: controller,
+		store:      store,
+		agentName:  getEnvOrDefault("GOMIND_AGENT_NAME", ""),
+		logger:     &core.NoOpLogger{},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// CreateCheckpoint saves a new pending checkpoint. A missing CheckpointID,
+// CreatedAt, ExpiresAt (24h) or AgentName is filled in.
+func (m *HITLManager) CreateCheckpoint(ctx context.Context, checkpoint *ExecutionCheckpoint) error {
+	if checkpoint == nil {
+		return fmt.Errorf("checkpoint cannot be nil")
+	}
+
+	now := time.Now()
+	if checkpoint.CheckpointID == "" {
+		checkpoint.CheckpointID = fmt.Sprintf("cp-%s", uuid.New().String()[:16])
+	}
+	if checkpoint.CreatedAt.IsZero() {
+		checkpoint.CreatedAt = now
+	}
+	if checkpoint.ExpiresAt.IsZero() {
+		checkpoint.ExpiresAt = checkpoint.CreatedAt.Add(24 * time.Hour)
+	}
+	if checkpoint.AgentName == "" {
+		checkpoint.AgentName = m.agentName
+	}
+	if checkpoint.OriginalRequestID == "" {
+		checkpoint.OriginalRequestID = checkpoint.RequestID
+	}
+	checkpoint.Status = CheckpointStatusPending
+
+	if err := m.store.SaveCheckpoint(ctx, checkpoint); err != nil {
+		return err
+	}
+
+	reason := ReasonCustom
+	if checkpoint.Decision != nil && checkpoint.Decision.Reason != "" {
+		reason = checkpoint.Decision.Reason
+	}
+	RecordCheckpointCreated(checkpoint.InterruptPoint, reason)
+
+	telemetry.AddSpanEvent(ctx, "hitl.manager.checkpoint_created",
+		attribute.String("checkpoint_id", checkpoint.CheckpointID),
+		attribute.String("request_id", checkpoint.RequestID),
+		attribute.String("interrupt_point", string(checkpoint.InterruptPoint)),
+	)
+	m.logger.InfoWithContext(ctx, "Checkpoint created", map[string]interface{}{
+		"operation":     "hitl_manager_create",
+		"checkpoint_id": checkpoint.CheckpointID,
+		"request_id":    checkpoint.RequestID,
+		"agent_name":    checkpoint.AgentName,
+	})
+	return nil
+}
+
+// ListPending returns the checkpoints awaiting a decision, oldest first.
+// A non-empty agentName limits the list to checkpoints created by that agent.
+func (m *HITLManager) ListPending(ctx context.Context, agentName string) ([]CheckpointSummary, error) {
+	checkpoints, err := m.store.ListPendingCheckpoints(ctx, CheckpointFilter{Status: CheckpointStatusPending})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]CheckpointSummary, 0, len(checkpoints))
+	for _, cp := range checkpoints {
+		if agentName != "" && cp.AgentName != agentName {
+			continue
+		}
+		summaries = append(summaries, summarizeCheckpoint(cp))
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].CreatedAt.Before(summaries[j].CreatedAt)
+	})
+	return summaries, nil
+}
+
+// ResolveCheckpoint applies a human decision to a pending checkpoint. It
+// fails with ErrCheckpointExpired once the checkpoint's ExpiresAt has passed.
+// When the decision lets execution continue (approve, edit, skip, retry,
+// respond), the edited plan or parameters are saved on the checkpoint and
+// the configured resume function runs; the checkpoint is then marked
+// completed.
+func (m *HITLManager) ResolveCheckpoint(ctx context.Context, checkpointID string, decision ApprovalDecision) error {
+	checkpoint, err := m.store.LoadCheckpoint(ctx, checkpointID)
+	if err != nil {
+		return err
+	}
+	if !checkpoint.ExpiresAt.IsZero() && time.Now().After(checkpoint.ExpiresAt) {
+		return &ErrCheckpointExpired{CheckpointID: checkpointID}
+	}
+	if !isValidCommandType(decision.Action) {
+		return &ErrInvalidCommand{CommandType: decision.Action, Reason: "unknown command type"}
+	}
+
+	result, err := m.controller.ProcessCommand(ctx, &Command{
+		CommandID:    fmt.Sprintf("cmd-%s", uuid.New().String()[:16]),
+		CheckpointID: checkpointID,
+		Type:         decision.Action,
+		EditedPlan:   decision.EditedPlan,
+		EditedParams: decision.EditedParams,
+		Feedback:     decision.Feedback,
+		Response:     decision.Response,
+		UserID:       decision.UserID,
+		Timestamp:    time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	m.logger.InfoWithContext(ctx, "Checkpoint resolved", map[string]interface{}{
+		"operation":     "hitl_manager_resolve",
+		"checkpoint_id": checkpointID,
+		"action":        decision.Action,
+		"user_id":       decision.UserID,
+		"should_resume": result.ShouldResume,
+	})
+
+	// Reload for the status set by ProcessCommand
+	checkpoint, err = m.store.LoadCheckpoint(ctx, checkpointID)
+	if err != nil {
+		return err
+	}
+	resolvedBy := decision.UserID
+	if resolvedBy == "" {
+		resolvedBy = "api"
+	}
+	m.recordResolution(ctx, checkpoint, string(decision.Action), resolvedBy)
+
+	if !result.ShouldResume || m.resume == nil {
+		return nil
+	}
+
+	// Keep the human's edits on the checkpoint
+	if result.ModifiedPlan != nil {
+		checkpoint.Plan = result.ModifiedPlan
+	}
+	if len(decision.EditedParams) > 0 {
+		params := make(map[string]interface{}, len(checkpoint.ResolvedParameters)+len(decision.EditedParams))
+		for k, v := range checkpoint.ResolvedParameters {
+			params[k] = v
+		}
+		for k, v := range decision.EditedParams {
+			params[k] = v
+		}
+		checkpoint.ResolvedParameters = params
+	}
+	if err := m.store.SaveCheckpoint(ctx, checkpoint); err != nil {
+		return err
+	}
+
+	resumeCtx, err := BuildResumeContext(ctx, checkpoint)
+	if err != nil {
+		return err
+	}
+	if checkpoint.OriginalRequestID != "" {
+		resumeCtx = telemetry.WithBaggage(resumeCtx, "original_request_id", checkpoint.OriginalRequestID)
+	}
+	if err := m.resume(resumeCtx, checkpoint); err != nil {
+		return fmt.Errorf("failed to resume checkpoint %s: %w", checkpointID, err)
+	}
+
+	if err := m.store.UpdateCheckpointStatus(ctx, checkpointID, CheckpointStatusCompleted); err != nil {
+		m.logger.WarnWithContext(ctx, "Failed to mark checkpoint completed", map[string]interface{}{
+			"operation":     "hitl_manager_resolve",
+			"checkpoint_id": checkpointID,
+			"error":         err.Error(),
+		})
+	}
+	return nil
+}
+
+// StartExpiryReaper starts the checkpoint store's expiry processor with the
+// manager as its callback. Checkpoints past ExpiresAt get their decision's
+// DefaultAction and an expired_* status; those expired as approved are then
+// resumed through the configured resume function, unless a custom handler
+// was set with WithHITLExpiryHandler. Each resolution is recorded on the
+// execution record when WithHITLExecutionStore is set.
+//
+// The store claims each expired checkpoint before processing it, so only one
+// replica acts on it, and recovers from handler panics.
+func (m *HITLManager) StartExpiryReaper(ctx context.Context, config ExpiryProcessorConfig) error {
+	if err := m.store.SetExpiryCallback(m.handleExpiredCheckpoint); err != nil {
+		return err
+	}
+	config.Enabled = true
+	return m.store.StartExpiryProcessor(ctx, config)
+}
+
+// StopExpiryReaper stops the expiry processor started by StartExpiryReaper
+func (m *HITLManager) StopExpiryReaper(ctx context.Context) error {
+	return m.store.StopExpiryProcessor(ctx)
+}
+
+// handleExpiredCheckpoint is the expiry callback: it records the automated
+// resolution, then resumes the execution or hands it to the custom handler
+func (m *HITLManager) handleExpiredCheckpoint(ctx context.Context, checkpoint *ExecutionCheckpoint, appliedAction CommandType) {
+	resolution := "implicit_deny"
+	if appliedAction != "" {
+		resolution = string(appliedAction)
+	}
+	m.recordResolution(ctx, checkpoint, "expired:"+resolution, "expiry_reaper")
+
+	if m.expiryHandler != nil {
+		m.expiryHandler(ctx, checkpoint, appliedAction)
+		return
+	}
+	if m.resume == nil || !IsResumableStatus(checkpoint.Status) {
+		return
+	}
+
+	resumeCtx, err := BuildResumeContext(ctx, checkpoint)
+	if err == nil {
+		if checkpoint.OriginalRequestID != "" {
+			resumeCtx = telemetry.WithBaggage(resumeCtx, "original_request_id", checkpoint.OriginalRequestID)
+		}
+		err = m.resume(resumeCtx, checkpoint)
+	}
+	if err != nil {
+		telemetry.RecordSpanError(ctx, err)
+		m.logger.ErrorWithContext(ctx, "Failed to resume expired checkpoint", map[string]interface{}{
+			"operation":     "hitl_manager_expiry",
+			"checkpoint_id": checkpoint.CheckpointID,
+			"request_id":    checkpoint.RequestID,
+			"error":         err.Error(),
+		})
+		return
+	}
+
+	m.logger.InfoWithContext(ctx, "Resumed checkpoint approved on expiry", map[string]interface{}{
+		"operation":     "hitl_manager_expiry",
+		"checkpoint_id": checkpoint.CheckpointID,
+		"request_id":    checkpoint.RequestID,
+	})
+}
+
+// recordResolution notes how a checkpoint was resolved on its execution
+// record. Failures are logged; the checkpoint itself is already updated.
+func (m *HITLManager) recordResolution(ctx context.Context, checkpoint *ExecutionCheckpoint, resolution, resolvedBy string) {
+	if m.executionStore == nil || checkpoint.RequestID == "" {
+		return
+	}
+
+	stored, err := m.executionStore.Get(ctx, checkpoint.RequestID)
+	if err == nil {
+		if stored.Metadata == nil {
+			stored.Metadata = make(map[string]string)
+		}
+		stored.Metadata["hitl_checkpoint_id"] = checkpoint.CheckpointID
+		stored.Metadata["hitl_resolution"] = resolution
+		stored.Metadata["hitl_resolved_by"] = resolvedBy
+		stored.Metadata["hitl_resolved_at"] = time.Now().UTC().Format(time.RFC3339)
+		stored.Checkpoint = checkpoint
+		err = m.executionStore.Store(ctx, stored)
+	}
+	if err != nil {
+		m.logger.WarnWithContext(ctx, "Failed to record checkpoint resolution", map[string]interface{}{
+			"operation":     "hitl_manager_record",
+			"checkpoint_id": checkpoint.CheckpointID,
+			"request_id":    checkpoint.RequestID,
+			"error":         err.Error(),
+		})
+	}
+}
+
+// summarizeCheckpoint builds the list view of a checkpoint
+func summarizeCheckpoint(cp *ExecutionCheckpoint) CheckpointSummary {
+	summary := CheckpointSummary{
+		CheckpointID:      cp.CheckpointID,
+		RequestID:         cp.RequestID,
+		OriginalRequestID: cp.OriginalRequestID,
+		AgentName:         cp.AgentName,
+		OriginalRequest:   cp.OriginalRequest,
+		InterruptPoint:    cp.InterruptPoint,
+		Status:            cp.Status,
+		CreatedAt:         cp.CreatedAt,
+		ExpiresAt:         cp.ExpiresAt,
+	}
+	if cp.Decision != nil {
+		summary.Reason = cp.Decision.Reason
+		summary.Message = cp.Decision.Message
+		summary.Priority = cp.Decision.Priority
+	}
+	if cp.CurrentStep != nil {
+		summary.StepID = cp.CurrentStep.StepID
+		summary.StepAgent = cp.CurrentStep.AgentName
+	}
+	return summary
+}
@@ -0,0 +1,282 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestHITLManager(store *mockCheckpointStore, opts ...HITLManagerOption) *HITLManager {
+	controller := NewInterruptController(nil, store, nil)
+	return NewHITLManager(controller, store, append([]HITLManagerOption{WithHITLAgentName("travel-agent")}, opts...)...)
+}
+
+func TestHITLManager_CreateCheckpoint(t *testing.T) {
+	store := newMockCheckpointStore()
+	manager := newTestHITLManager(store)
+
+	cp := &ExecutionCheckpoint{RequestID: "req-1", InterruptPoint: InterruptPointPlanGenerated}
+	if err := manager.CreateCheckpoint(context.Background(), cp); err != nil {
+		t.Fatalf("CreateCheckpoint failed: %v", err)
+	}
+
+	if cp.CheckpointID == "" {
+		t.Error("Expected a generated checkpoint ID")
+	}
+	if cp.Status != CheckpointStatusPending {
+		t.Errorf("Expected pending status, got %q", cp.Status)
+	}
+	if cp.AgentName != "travel-agent" {
+		t.Errorf("Expected the manager's agent name, got %q", cp.AgentName)
+	}
+	if cp.OriginalRequestID != "req-1" {
+		t.Errorf("Expected OriginalRequestID to default to the request ID, got %q", cp.OriginalRequestID)
+	}
+	if got := cp.ExpiresAt.Sub(cp.CreatedAt); got != 24*time.Hour {
+		t.Errorf("Expected a 24h expiry, got %v", got)
+	}
+	if _, ok := store.checkpoints[cp.CheckpointID]; !ok {
+		t.Error("Expected the checkpoint to be saved")
+	}
+
+	if err := manager.CreateCheckpoint(context.Background(), nil); err == nil {
+		t.Error("Expected an error for a nil checkpoint")
+	}
+}
+
+func TestHITLManager_ListPending(t *testing.T) {
+	store := newMockCheckpointStore()
+	now := time.Now()
+	store.pendingList = []*ExecutionCheckpoint{
+		{CheckpointID: "cp-new", AgentName: "travel-agent", CreatedAt: now},
+		{CheckpointID: "cp-other", AgentName: "stock-agent", CreatedAt: now.Add(-time.Hour)},
+		{CheckpointID: "cp-old", AgentName: "travel-agent", CreatedAt: now.Add(-2 * time.Hour),
+			Decision:    &InterruptDecision{Reason: ReasonSensitiveOperation, Message: "Booking needs approval"},
+			CurrentStep: &RoutingStep{StepID: "step-2", AgentName: "booking"}},
+	}
+	manager := newTestHITLManager(store)
+
+	pending, err := manager.ListPending(context.Background(), "travel-agent")
+	if err != nil {
+		t.Fatalf("ListPending failed: %v", err)
+	}
+	if len(pending) != 2 || pending[0].CheckpointID != "cp-old" || pending[1].CheckpointID != "cp-new" {
+		t.Fatalf("Expected travel-agent checkpoints oldest first, got %+v", pending)
+	}
+	if pending[0].Reason != ReasonSensitiveOperation || pending[0].StepID != "step-2" || pending[0].StepAgent != "booking" {
+		t.Errorf("Expected decision and step details in the summary, got %+v", pending[0])
+	}
+
+	all, err := manager.ListPending(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListPending failed: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("Expected all checkpoints without an agent filter, got %d", len(all))
+	}
+}
+
+func TestHITLManager_ResolveCheckpoint(t *testing.T) {
+	newCheckpoint := func(id string) *ExecutionCheckpoint {
+		return &ExecutionCheckpoint{
+			CheckpointID:       id,
+			RequestID:          "req-1",
+			OriginalRequest:    "Book a flight to Tokyo",
+			InterruptPoint:     InterruptPointBeforeStep,
+			Plan:               resumePlan(),
+			CurrentStep:        &RoutingStep{StepID: "step-2", AgentName: "travel"},
+			ResolvedParameters: map[string]interface{}{"seat": "economy", "flight": "NH1"},
+			Status:             CheckpointStatusPending,
+			CreatedAt:          time.Now(),
+			ExpiresAt:          time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("approve resumes with edited params", func(t *testing.T) {
+		store := newMockCheckpointStore()
+		store.checkpoints["cp-1"] = newCheckpoint("cp-1")
+
+		var resumedParams map[string]interface{}
+		var resumedID string
+		manager := newTestHITLManager(store, WithHITLResumeFunc(func(ctx context.Context, cp *ExecutionCheckpoint) error {
+			resumedParams = GetPreResolvedParams(ctx)
+			resumedID, _ = IsResumeMode(ctx)
+			return nil
+		}))
+
+		err := manager.ResolveCheckpoint(context.Background(), "cp-1", ApprovalDecision{
+			Action:       CommandApprove,
+			EditedParams: map[string]interface{}{"seat": "business"},
+			UserID:       "alice",
+		})
+		if err != nil {
+			t.Fatalf("ResolveCheckpoint failed: %v", err)
+		}
+		if resumedID != "cp-1" {
+			t.Errorf("Expected resume mode for cp-1, got %q", resumedID)
+		}
+		if resumedParams["seat"] != "business" || resumedParams["flight"] != "NH1" {
+			t.Errorf("Expected edited params merged over resolved params, got %v", resumedParams)
+		}
+		if got := store.checkpoints["cp-1"].Status; got != CheckpointStatusCompleted {
+			t.Errorf("Expected completed status after resume, got %q", got)
+		}
+	})
+
+	t.Run("reject does not resume", func(t *testing.T) {
+		store := newMockCheckpointStore()
+		store.checkpoints["cp-1"] = newCheckpoint("cp-1")
+
+		resumed := false
+		manager := newTestHITLManager(store, WithHITLResumeFunc(func(ctx context.Context, cp *ExecutionCheckpoint) error {
+			resumed = true
+			return nil
+		}))
+
+		err := manager.ResolveCheckpoint(context.Background(), "cp-1", ApprovalDecision{Action: CommandReject, Feedback: "too expensive"})
+		if err != nil {
+			t.Fatalf("ResolveCheckpoint failed: %v", err)
+		}
+		if resumed {
+			t.Error("Rejected checkpoint must not resume")
+		}
+		if got := store.checkpoints["cp-1"].Status; got != CheckpointStatusRejected {
+			t.Errorf("Expected rejected status, got %q", got)
+		}
+	})
+
+	t.Run("expired checkpoint", func(t *testing.T) {
+		store := newMockCheckpointStore()
+		cp := newCheckpoint("cp-1")
+		cp.ExpiresAt = time.Now().Add(-time.Minute)
+		store.checkpoints["cp-1"] = cp
+		manager := newTestHITLManager(store)
+
+		err := manager.ResolveCheckpoint(context.Background(), "cp-1", ApprovalDecision{Action: CommandApprove})
+		var expired *ErrCheckpointExpired
+		if !errors.As(err, &expired) {
+			t.Errorf("Expected ErrCheckpointExpired, got %v", err)
+		}
+	})
+
+	t.Run("invalid action", func(t *testing.T) {
+		store := newMockCheckpointStore()
+		store.checkpoints["cp-1"] = newCheckpoint("cp-1")
+		manager := newTestHITLManager(store)
+
+		if err := manager.ResolveCheckpoint(context.Background(), "cp-1", ApprovalDecision{Action: "maybe"}); err == nil {
+			t.Error("Expected an error for an unknown action")
+		}
+	})
+}
+
+func TestHITLManager_ExpiryReaper(t *testing.T) {
+	newExpired := func(status CheckpointStatus) *ExecutionCheckpoint {
+		return &ExecutionCheckpoint{
+			CheckpointID:    "cp-expired",
+			RequestID:       "req-1",
+			OriginalRequest: "Book a flight to Tokyo",
+			InterruptPoint:  InterruptPointPlanGenerated,
+			Plan:            resumePlan(),
+			Status:          status,
+		}
+	}
+	newExecutionStore := func(t *testing.T) ExecutionStore {
+		store := NewExecutionStoreWithProvider(newMockStorageProvider(), DefaultExecutionStoreConfig(), nil)
+		if err := store.Store(context.Background(), &StoredExecution{RequestID: "req-1", Interrupted: true}); err != nil {
+			t.Fatalf("Store failed: %v", err)
+		}
+		return store
+	}
+
+	t.Run("start wires the callback", func(t *testing.T) {
+		store := newMockCheckpointStore()
+		manager := newTestHITLManager(store)
+		if err := manager.StartExpiryReaper(context.Background(), ExpiryProcessorConfig{ScanInterval: time.Second}); err != nil {
+			t.Fatalf("StartExpiryReaper failed: %v", err)
+		}
+		if store.expiryCallback == nil {
+			t.Error("Expected the manager to register an expiry callback")
+		}
+		if store.expiryConfig == nil || !store.expiryConfig.Enabled || store.expiryConfig.ScanInterval != time.Second {
+			t.Errorf("Expected an enabled processor with the given interval, got %+v", store.expiryConfig)
+		}
+	})
+
+	t.Run("approved on expiry resumes and is recorded", func(t *testing.T) {
+		executions := newExecutionStore(t)
+		var resumedID string
+		manager := newTestHITLManager(newMockCheckpointStore(),
+			WithHITLExecutionStore(executions),
+			WithHITLResumeFunc(func(ctx context.Context, cp *ExecutionCheckpoint) error {
+				resumedID, _ = IsResumeMode(ctx)
+				return nil
+			}),
+		)
+
+		manager.handleExpiredCheckpoint(context.Background(), newExpired(CheckpointStatusExpiredApproved), CommandApprove)
+
+		if resumedID != "cp-expired" {
+			t.Errorf("Expected the expired checkpoint to resume, got %q", resumedID)
+		}
+		stored, err := executions.Get(context.Background(), "req-1")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if stored.Metadata["hitl_resolution"] != "expired:approve" || stored.Metadata["hitl_resolved_by"] != "expiry_reaper" {
+			t.Errorf("Expected the automated resolution on the record, got %v", stored.Metadata)
+		}
+		if stored.Checkpoint == nil || stored.Checkpoint.Status != CheckpointStatusExpiredApproved {
+			t.Errorf("Expected the expired checkpoint on the record, got %+v", stored.Checkpoint)
+		}
+	})
+
+	t.Run("rejected on expiry does not resume", func(t *testing.T) {
+		resumed := false
+		manager := newTestHITLManager(newMockCheckpointStore(),
+			WithHITLResumeFunc(func(ctx context.Context, cp *ExecutionCheckpoint) error {
+				resumed = true
+				return nil
+			}),
+		)
+		manager.handleExpiredCheckpoint(context.Background(), newExpired(CheckpointStatusExpiredRejected), CommandReject)
+		if resumed {
+			t.Error("Rejected checkpoint must not resume")
+		}
+	})
+
+	t.Run("custom handler replaces resume", func(t *testing.T) {
+		executions := newExecutionStore(t)
+		resumed := false
+		called := false
+		var handled CommandType
+		var handledID string
+		manager := newTestHITLManager(newMockCheckpointStore(),
+			WithHITLExecutionStore(executions),
+			WithHITLResumeFunc(func(ctx context.Context, cp *ExecutionCheckpoint) error {
+				resumed = true
+				return nil
+			}),
+			WithHITLExpiryHandler(func(ctx context.Context, cp *ExecutionCheckpoint, action CommandType) {
+				called = true
+				handled = action
+				handledID = cp.CheckpointID
+			}),
+		)
+
+		// Approved on expiry, which the default handling would resume
+		manager.handleExpiredCheckpoint(context.Background(), newExpired(CheckpointStatusExpiredApproved), CommandApprove)
+
+		if !called || handled != CommandApprove || handledID != "cp-expired" {
+			t.Errorf("Expected the custom handler to get the approved checkpoint, called=%v action=%q checkpoint=%q", called, handled, handledID)
+		}
+		if resumed {
+			t.Error("Expected the custom handler to replace the default resume")
+		}
+		stored, _ := executions.Get(context.Background(), "req-1")
+		if stored == nil || stored.Metadata["hitl_resolution"] != "expired:approve" {
+			t.Errorf("Expected the resolution on the record, got %+v", stored)
+		}
+	})
+}
@@ -0,0 +1,360 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Composable Interrupt Policies
+// =============================================================================
+//
+// The policies in this file each cover one rule and are meant to be combined
+// with CompositePolicy, alongside RuleBasedPolicy or custom policies built
+// from PolicyFuncs:
+//
+//	policy := NewCompositePolicy(
+//	    NewCostThresholdPolicy("amount", 10000, WithPolicyCapabilities("execute_trade")),
+//	    NewRiskProfilePolicy(catalog),
+//	    PolicyFuncs{Plan: func(ctx context.Context, plan *RoutingPlan) (*InterruptDecision, error) {
+//	        if isNewUser(ctx) {
+//	            return &InterruptDecision{ShouldInterrupt: true, Reason: ReasonPlanApproval,
+//	                Message: "Plans for new users need approval"}, nil
+//	        }
+//	        return &InterruptDecision{ShouldInterrupt: false}, nil
+//	    }},
+//	)
+//	controller := NewInterruptController(policy, store, handler)
+//
+// The built-in policies decide before each step; plan-level approval stays
+// with RuleBasedPolicy or a PolicyFuncs.Plan hook.
+//
+// =============================================================================
+
+// CompositePolicy combines several policies. At each interrupt point the
+// policies are consulted in order and the first decision that interrupts
+// wins. A policy error stops the evaluation and is returned.
+type CompositePolicy struct {
+	policies []InterruptPolicy
+}
+
+// NewCompositePolicy creates a policy that interrupts when any of policies does
+func NewCompositePolicy(policies ...InterruptPolicy) *CompositePolicy {
+	return &CompositePolicy{policies: policies}
+}
+
+// ShouldApprovePlan returns the first plan-approval decision that interrupts
+func (p *CompositePolicy) ShouldApprovePlan(ctx context.Context, plan *RoutingPlan) (*InterruptDecision, error) {
+	return firstInterrupt(p.policies, func(policy InterruptPolicy) (*InterruptDecision, error) {
+		return policy.ShouldApprovePlan(ctx, plan)
+	})
+}
+
+// ShouldApproveBeforeStep returns the first pre-step decision that interrupts
+func (p *CompositePolicy) ShouldApproveBeforeStep(ctx context.Context, step RoutingStep, plan *RoutingPlan) (*InterruptDecision, error) {
+	return firstInterrupt(p.policies, func(policy InterruptPolicy) (*InterruptDecision, error) {
+		return policy.ShouldApproveBeforeStep(ctx, step, plan)
+	})
+}
+
+// ShouldApproveAfterStep returns the first post-step decision that interrupts
+func (p *CompositePolicy) ShouldApproveAfterStep(ctx context.Context, step RoutingStep, result *StepResult) (*InterruptDecision, error) {
+	return firstInterrupt(p.policies, func(policy InterruptPolicy) (*InterruptDecision, error) {
+		return policy.ShouldApproveAfterStep(ctx, step, result)
+	})
+}
+
+// ShouldEscalateError returns the first escalation decision that interrupts
+func (p *CompositePolicy) ShouldEscalateError(ctx context.Context, step RoutingStep, err error, attempts int) (*InterruptDecision, error) {
+	return firstInterrupt(p.policies, func(policy InterruptPolicy) (*InterruptDecision, error) {
+		return policy.ShouldEscalateError(ctx, step, err, attempts)
+	})
+}
+
+// firstInterrupt runs check against each policy until one interrupts
+func firstInterrupt(policies []InterruptPolicy, check func(InterruptPolicy) (*InterruptDecision, error)) (*InterruptDecision, error) {
+	for _, policy := range policies {
+		if policy == nil {
+			continue
+		}
+		decision, err := check(policy)
+		if err != nil {
+			return nil, err
+		}
+		if decision != nil && decision.ShouldInterrupt {
+			return decision, nil
+		}
+	}
+	return &InterruptDecision{ShouldInterrupt: false}, nil
+}
+
+// PolicyFuncs implements InterruptPolicy from optional functions, for
+// policies that only care about some interrupt points. Unset hooks never
+// interrupt.
+type PolicyFuncs struct {
+	Plan       func(ctx context.Context, plan *RoutingPlan) (*InterruptDecision, error)
+	BeforeStep func(ctx context.Context, step RoutingStep, plan *RoutingPlan) (*InterruptDecision, error)
+	AfterStep  func(ctx context.Context, step RoutingStep, result *StepResult) (*InterruptDecision, error)
+	Error      func(ctx context.Context, step RoutingStep, err error, attempts int) (*InterruptDecision, error)
+}
+
+// ShouldApprovePlan calls Plan if set
+func (f PolicyFuncs) ShouldApprovePlan(ctx context.Context, plan *RoutingPlan) (*InterruptDecision, error) {
+	if f.Plan == nil {
+		return &InterruptDecision{ShouldInterrupt: false}, nil
+	}
+	return f.Plan(ctx, plan)
+}
+
+// ShouldApproveBeforeStep calls BeforeStep if set
+func (f PolicyFuncs) ShouldApproveBeforeStep(ctx context.Context, step RoutingStep, plan *RoutingPlan) (*InterruptDecision, error) {
+	if f.BeforeStep == nil {
+		return &InterruptDecision{ShouldInterrupt: false}, nil
+	}
+	return f.BeforeStep(ctx, step, plan)
+}
+
+// ShouldApproveAfterStep calls AfterStep if set
+func (f PolicyFuncs) ShouldApproveAfterStep(ctx context.Context, step RoutingStep, result *StepResult) (*InterruptDecision, error) {
+	if f.AfterStep == nil {
+		return &InterruptDecision{ShouldInterrupt: false}, nil
+	}
+	return f.AfterStep(ctx, step, result)
+}
+
+// ShouldEscalateError calls Error if set
+func (f PolicyFuncs) ShouldEscalateError(ctx context.Context, step RoutingStep, err error, attempts int) (*InterruptDecision, error) {
+	if f.Error == nil {
+		return &InterruptDecision{ShouldInterrupt: false}, nil
+	}
+	return f.Error(ctx, step, err, attempts)
+}
+
+// -----------------------------------------------------------------------------
+// Built-in Step Policies
+// -----------------------------------------------------------------------------
+
+// StepPolicyOption configures the built-in step policies
+type StepPolicyOption func(*stepPolicyBase)
+
+// WithPolicyTimeout sets how long the interrupt waits for a human
+func WithPolicyTimeout(timeout time.Duration) StepPolicyOption {
+	return func(b *stepPolicyBase) {
+		b.timeout = timeout
+	}
+}
+
+// WithPolicyDefaultAction sets the action applied when the interrupt times
+// out. Defaults to CommandReject.
+func WithPolicyDefaultAction(action CommandType) StepPolicyOption {
+	return func(b *stepPolicyBase) {
+		b.defaultAction = action
+	}
+}
+
+// WithPolicyCapabilities limits the policy to steps calling one of the
+// named capabilities
+func WithPolicyCapabilities(capabilities ...string) StepPolicyOption {
+	return func(b *stepPolicyBase) {
+		b.capabilities = capabilities
+	}
+}
+
+// stepPolicyBase holds the settings shared by the built-in step policies
+type stepPolicyBase struct {
+	timeout       time.Duration
+	defaultAction CommandType
+	capabilities  []string
+}
+
+func newStepPolicyBase(opts []StepPolicyOption) stepPolicyBase {
+	b := stepPolicyBase{defaultAction: CommandReject}
+	for _, opt := range opts {
+		opt(&b)
+	}
+	return b
+}
+
+// applies reports whether the policy covers step's capability
+func (b *stepPolicyBase) applies(step RoutingStep) bool {
+	if len(b.capabilities) == 0 {
+		return true
+	}
+	return slices.Contains(b.capabilities, stepCapability(step))
+}
+
+// decision builds an interrupting decision for step
+func (b *stepPolicyBase) decision(step RoutingStep, trigger, message string, metadata map[string]interface{}) *InterruptDecision {
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata["step_id"] = step.StepID
+	metadata["agent_name"] = step.AgentName
+	metadata["trigger"] = trigger
+	if capability := stepCapability(step); capability != "" {
+		metadata["capability"] = capability
+	}
+	return &InterruptDecision{
+		ShouldInterrupt: true,
+		Reason:          ReasonSensitiveOperation,
+		Message:         message,
+		Priority:        PriorityHigh,
+		Timeout:         b.timeout,
+		DefaultAction:   b.defaultAction,
+		Metadata:        metadata,
+	}
+}
+
+// CostThresholdPolicy interrupts before steps whose numeric parameter
+// exceeds a threshold, e.g. trades over $10k:
+//
+//	NewCostThresholdPolicy("amount", 10000, WithPolicyCapabilities("execute_trade"))
+type CostThresholdPolicy struct {
+	NoOpPolicy
+	stepPolicyBase
+	param     string
+	threshold float64
+}
+
+// NewCostThresholdPolicy creates a policy that interrupts when the step
+// parameter param is greater than threshold
+func NewCostThresholdPolicy(param string, threshold float64, opts ...StepPolicyOption) *CostThresholdPolicy {
+	return &CostThresholdPolicy{
+		stepPolicyBase: newStepPolicyBase(opts),
+		param:          param,
+		threshold:      threshold,
+	}
+}
+
+// ShouldApproveBeforeStep interrupts when the step's parameter exceeds the threshold
+func (p *CostThresholdPolicy) ShouldApproveBeforeStep(ctx context.Context, step RoutingStep, plan *RoutingPlan) (*InterruptDecision, error) {
+	if !p.applies(step) {
+		return &InterruptDecision{ShouldInterrupt: false}, nil
+	}
+	params, _ := step.Metadata["parameters"].(map[string]interface{})
+	raw, ok := params[p.param]
+	if !ok {
+		return &InterruptDecision{ShouldInterrupt: false}, nil
+	}
+	value := toFloat64(raw)
+	if value <= p.threshold {
+		return &InterruptDecision{ShouldInterrupt: false}, nil
+	}
+
+	return p.decision(step, "cost_threshold",
+		fmt.Sprintf("Step %s has %s %v, above the approval threshold of %v", step.StepID, p.param, value, p.threshold),
+		map[string]interface{}{
+			"parameter": p.param,
+			"value":     value,
+			"threshold": p.threshold,
+		}), nil
+}
+
+// RiskProfileTag marks high-risk capabilities in EnhancedCapability.Tags
+const RiskProfileTag = "risk_profile:high"
+
+// RiskProfilePolicy interrupts before steps that call a high-risk
+// capability: one tagged RiskProfileTag in the catalog, or a step whose
+// metadata has risk_profile "high".
+type RiskProfilePolicy struct {
+	NoOpPolicy
+	stepPolicyBase
+	catalog *AgentCatalog
+}
+
+// NewRiskProfilePolicy creates a risk profile policy. catalog may be nil,
+// in which case only step metadata is checked.
+func NewRiskProfilePolicy(catalog *AgentCatalog, opts ...StepPolicyOption) *RiskProfilePolicy {
+	return &RiskProfilePolicy{
+		stepPolicyBase: newStepPolicyBase(opts),
+		catalog:        catalog,
+	}
+}
+
+// ShouldApproveBeforeStep interrupts when the step's capability is high risk
+func (p *RiskProfilePolicy) ShouldApproveBeforeStep(ctx context.Context, step RoutingStep, plan *RoutingPlan) (*InterruptDecision, error) {
+	if !p.applies(step) || !p.isHighRisk(step) {
+		return &InterruptDecision{ShouldInterrupt: false}, nil
+	}
+	return p.decision(step, "risk_profile",
+		fmt.Sprintf("Step approval required for high-risk operation: %s.%s", step.AgentName, stepCapability(step)),
+		map[string]interface{}{"risk_profile": "high"}), nil
+}
+
+// isHighRisk checks the step metadata, then the capability's catalog tags
+func (p *RiskProfilePolicy) isHighRisk(step RoutingStep) bool {
+	if profile, ok := step.Metadata["risk_profile"].(string); ok && strings.EqualFold(profile, "high") {
+		return true
+	}
+	if p.catalog == nil {
+		return false
+	}
+
+	capability := stepCapability(step)
+	for _, agent := range p.catalog.GetAgents() {
+		if agent.Registration == nil || agent.Registration.Name != step.AgentName {
+			continue
+		}
+		for _, cap := range agent.Capabilities {
+			if cap.Name == capability && slices.Contains(cap.Tags, RiskProfileTag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CapabilityListPolicy interrupts before steps by capability name. A
+// capability on the denylist always interrupts; when the allowlist is
+// non-empty, any capability not on it interrupts too.
+type CapabilityListPolicy struct {
+	NoOpPolicy
+	stepPolicyBase
+	allow []string
+	deny  []string
+}
+
+// NewCapabilityListPolicy creates a capability allowlist/denylist policy
+func NewCapabilityListPolicy(allow, deny []string, opts ...StepPolicyOption) *CapabilityListPolicy {
+	return &CapabilityListPolicy{
+		stepPolicyBase: newStepPolicyBase(opts),
+		allow:          allow,
+		deny:           deny,
+	}
+}
+
+// ShouldApproveBeforeStep interrupts for denied or unlisted capabilities
+func (p *CapabilityListPolicy) ShouldApproveBeforeStep(ctx context.Context, step RoutingStep, plan *RoutingPlan) (*InterruptDecision, error) {
+	if !p.applies(step) {
+		return &InterruptDecision{ShouldInterrupt: false}, nil
+	}
+
+	capability := stepCapability(step)
+	if slices.Contains(p.deny, capability) {
+		return p.decision(step, "capability_denylist",
+			fmt.Sprintf("Step approval required for denylisted capability: %s.%s", step.AgentName, capability), nil), nil
+	}
+	if len(p.allow) > 0 && !slices.Contains(p.allow, capability) {
+		return p.decision(step, "capability_not_allowlisted",
+			fmt.Sprintf("Step approval required for capability outside the allowlist: %s.%s", step.AgentName, capability), nil), nil
+	}
+	return &InterruptDecision{ShouldInterrupt: false}, nil
+}
+
+// stepCapability returns the capability a step calls
+func stepCapability(step RoutingStep) string {
+	capability, _ := step.Metadata["capability"].(string)
+	return capability
+}
+
+// Compile-time interface compliance checks
+var (
+	_ InterruptPolicy = (*CompositePolicy)(nil)
+	_ InterruptPolicy = PolicyFuncs{}
+	_ InterruptPolicy = (*CostThresholdPolicy)(nil)
+	_ InterruptPolicy = (*RiskProfilePolicy)(nil)
+	_ InterruptPolicy = (*CapabilityListPolicy)(nil)
+)
@@ -0,0 +1,152 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+func tradeStep(amount interface{}) RoutingStep {
+	return RoutingStep{
+		StepID:    "step-1",
+		AgentName: "broker",
+		Metadata: map[string]interface{}{
+			"capability": "execute_trade",
+			"parameters": map[string]interface{}{"symbol": "AAPL", "amount": amount},
+		},
+	}
+}
+
+func TestCostThresholdPolicy(t *testing.T) {
+	policy := NewCostThresholdPolicy("amount", 10000, WithPolicyCapabilities("execute_trade"))
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		step      RoutingStep
+		interrupt bool
+	}{
+		{"over threshold", tradeStep(25000.0), true},
+		{"string amount over threshold", tradeStep("12000"), true},
+		{"at threshold", tradeStep(10000), false},
+		{"under threshold", tradeStep(500.0), false},
+		{"other capability", RoutingStep{StepID: "step-2", Metadata: map[string]interface{}{
+			"capability": "get_quote",
+			"parameters": map[string]interface{}{"amount": 50000.0},
+		}}, false},
+		{"no parameters", RoutingStep{StepID: "step-3", Metadata: map[string]interface{}{"capability": "execute_trade"}}, false},
+	}
+	for _, tt := range tests {
+		decision, err := policy.ShouldApproveBeforeStep(ctx, tt.step, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if decision.ShouldInterrupt != tt.interrupt {
+			t.Errorf("%s: expected interrupt=%v, got %v", tt.name, tt.interrupt, decision.ShouldInterrupt)
+		}
+	}
+
+	decision, _ := policy.ShouldApproveBeforeStep(ctx, tradeStep(25000.0), nil)
+	if decision.DefaultAction != CommandReject || decision.Metadata["trigger"] != "cost_threshold" {
+		t.Errorf("Expected a reject-by-default cost_threshold decision, got %+v", decision)
+	}
+	if planDecision, _ := policy.ShouldApprovePlan(ctx, &RoutingPlan{Steps: []RoutingStep{tradeStep(25000.0)}}); planDecision.ShouldInterrupt {
+		t.Error("Cost threshold policy should only decide before steps")
+	}
+}
+
+func TestRiskProfilePolicy(t *testing.T) {
+	catalog := NewAgentCatalog(NewMockDiscovery())
+	catalog.agents = map[string]*AgentInfo{
+		"broker-1": {
+			Registration: &core.ServiceRegistration{ID: "broker-1", Name: "broker"},
+			Capabilities: []EnhancedCapability{
+				{Name: "execute_trade", Tags: []string{"trading", RiskProfileTag}},
+				{Name: "get_quote", Tags: []string{"trading"}},
+			},
+		},
+	}
+	policy := NewRiskProfilePolicy(catalog)
+	ctx := context.Background()
+
+	decision, err := policy.ShouldApproveBeforeStep(ctx, tradeStep(1.0), nil)
+	if err != nil || !decision.ShouldInterrupt {
+		t.Errorf("Expected high-risk capability to interrupt, got %+v (err %v)", decision, err)
+	}
+
+	quote := RoutingStep{StepID: "step-2", AgentName: "broker", Metadata: map[string]interface{}{"capability": "get_quote"}}
+	if decision, _ := policy.ShouldApproveBeforeStep(ctx, quote, nil); decision.ShouldInterrupt {
+		t.Error("Untagged capability should not interrupt")
+	}
+
+	tagged := RoutingStep{StepID: "step-3", AgentName: "unknown", Metadata: map[string]interface{}{
+		"capability":   "wipe",
+		"risk_profile": "high",
+	}}
+	if decision, _ := NewRiskProfilePolicy(nil).ShouldApproveBeforeStep(ctx, tagged, nil); !decision.ShouldInterrupt {
+		t.Error("Expected risk_profile step metadata to interrupt without a catalog")
+	}
+}
+
+func TestCapabilityListPolicy(t *testing.T) {
+	ctx := context.Background()
+	step := func(capability string) RoutingStep {
+		return RoutingStep{StepID: "s", AgentName: "broker", Metadata: map[string]interface{}{"capability": capability}}
+	}
+
+	deny := NewCapabilityListPolicy(nil, []string{"execute_trade"})
+	if decision, _ := deny.ShouldApproveBeforeStep(ctx, step("execute_trade"), nil); !decision.ShouldInterrupt {
+		t.Error("Expected denylisted capability to interrupt")
+	}
+	if decision, _ := deny.ShouldApproveBeforeStep(ctx, step("get_quote"), nil); decision.ShouldInterrupt {
+		t.Error("Capability not on the denylist should not interrupt")
+	}
+
+	allow := NewCapabilityListPolicy([]string{"get_quote"}, nil)
+	if decision, _ := allow.ShouldApproveBeforeStep(ctx, step("get_quote"), nil); decision.ShouldInterrupt {
+		t.Error("Allowlisted capability should not interrupt")
+	}
+	decision, _ := allow.ShouldApproveBeforeStep(ctx, step("execute_trade"), nil)
+	if !decision.ShouldInterrupt || decision.Metadata["trigger"] != "capability_not_allowlisted" {
+		t.Errorf("Expected capability outside the allowlist to interrupt, got %+v", decision)
+	}
+}
+
+func TestCompositePolicy(t *testing.T) {
+	type userKey struct{}
+	newUserPlans := PolicyFuncs{Plan: func(ctx context.Context, plan *RoutingPlan) (*InterruptDecision, error) {
+		if ctx.Value(userKey{}) == "new" {
+			return &InterruptDecision{ShouldInterrupt: true, Reason: ReasonPlanApproval, Message: "new user"}, nil
+		}
+		return &InterruptDecision{ShouldInterrupt: false}, nil
+	}}
+	policy := NewCompositePolicy(
+		NewCostThresholdPolicy("amount", 10000, WithPolicyCapabilities("execute_trade")),
+		newUserPlans,
+		nil,
+	)
+
+	plan := &RoutingPlan{Steps: []RoutingStep{tradeStep(100.0)}}
+	newUserCtx := context.WithValue(context.Background(), userKey{}, "new")
+	if decision, _ := policy.ShouldApprovePlan(newUserCtx, plan); !decision.ShouldInterrupt || decision.Reason != ReasonPlanApproval {
+		t.Errorf("Expected plan approval for a new user, got %+v", decision)
+	}
+	if decision, _ := policy.ShouldApprovePlan(context.Background(), plan); decision.ShouldInterrupt {
+		t.Error("Expected no plan approval for an existing user")
+	}
+	if decision, _ := policy.ShouldApproveBeforeStep(context.Background(), tradeStep(20000.0), plan); !decision.ShouldInterrupt {
+		t.Error("Expected the cost threshold to interrupt through the composite")
+	}
+	if decision, _ := policy.ShouldEscalateError(context.Background(), tradeStep(1.0), errors.New("boom"), 5); decision.ShouldInterrupt {
+		t.Error("Expected no escalation when no policy escalates")
+	}
+
+	failing := NewCompositePolicy(PolicyFuncs{BeforeStep: func(ctx context.Context, step RoutingStep, plan *RoutingPlan) (*InterruptDecision, error) {
+		return nil, errors.New("policy service down")
+	}})
+	if _, err := failing.ShouldApproveBeforeStep(context.Background(), tradeStep(1.0), plan); err == nil {
+		t.Error("Expected the policy error to be returned")
+	}
+}
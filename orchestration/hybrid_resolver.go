@@ -32,6 +32,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/itsneelabh/gomind/core"
 )
@@ -79,6 +82,46 @@ func NewHybridResolver(aiClient core.AIClient, logger core.Logger, opts ...Hybri
 	return h
 }
 
+// Parameter resolution methods reported in ParameterResolution.Method
+const (
+	ResolutionDeterministic = "deterministic"
+	ResolutionLLM           = "llm"
+	ResolutionMixed         = "mixed"
+	ResolutionNone          = "none"
+)
+
+// ParameterResolution records how a step's parameters were resolved
+type ParameterResolution struct {
+	// Method is ResolutionDeterministic when every resolved parameter came from
+	// schema name matching, ResolutionLLM when all came from micro-resolution,
+	// ResolutionMixed for both and ResolutionNone when nothing was resolved
+	Method string `json:"method"`
+	// Deterministic lists parameters matched by name without an LLM call
+	Deterministic []string `json:"deterministic,omitempty"`
+	// LLM lists parameters resolved by micro-resolution
+	LLM []string `json:"llm,omitempty"`
+	// Ambiguous lists parameters that several dependencies supplied with
+	// different values. They are left to micro-resolution.
+	Ambiguous []string `json:"ambiguous,omitempty"`
+}
+
+func (r *ParameterResolution) finalize() *ParameterResolution {
+	sort.Strings(r.Deterministic)
+	sort.Strings(r.LLM)
+	sort.Strings(r.Ambiguous)
+	switch {
+	case len(r.Deterministic) > 0 && len(r.LLM) > 0:
+		r.Method = ResolutionMixed
+	case len(r.LLM) > 0:
+		r.Method = ResolutionLLM
+	case len(r.Deterministic) > 0:
+		r.Method = ResolutionDeterministic
+	default:
+		r.Method = ResolutionNone
+	}
+	return r
+}
+
 // ResolveParameters resolves parameters from dependency results to target capability.
 // This is the main entry point for parameter resolution in the executor.
 //
@@ -104,23 +147,54 @@ func (h *HybridResolver) ResolveParameters(
 	stepID string,
 	stepInstruction string,
 ) (map[string]interface{}, error) {
+	params, _, err := h.ResolveParametersWithReport(ctx, dependencyResults, targetCapability, stepID, stepInstruction)
+	return params, err
+}
+
+// ResolveParametersWithReport works like ResolveParameters and also reports
+// which parameters were matched deterministically and which needed the LLM.
+// A parameter that several dependencies supply with different values is
+// ambiguous: it is not auto-wired, and counts as unmapped.
+func (h *HybridResolver) ResolveParametersWithReport(
+	ctx context.Context,
+	dependencyResults map[string]*StepResult,
+	targetCapability *EnhancedCapability,
+	stepID string,
+	stepInstruction string,
+) (map[string]interface{}, *ParameterResolution, error) {
+	report := &ParameterResolution{}
+
 	// Collect source data from all dependencies
-	sourceData := h.collectSourceData(dependencyResults)
+	sourcesByStep := h.collectSourceDataByStep(dependencyResults)
+	sourceData := mergeSourceData(sourcesByStep)
 
 	if len(sourceData) == 0 {
 		h.logDebug("No source data available for parameter resolution", map[string]interface{}{
 			"capability": targetCapability.Name,
 		})
-		return nil, nil // No dependencies have data
+		return nil, report.finalize(), nil // No dependencies have data
 	}
 
 	// Phase 1: Try auto-wiring (fast, no LLM cost)
 	params, unmapped := h.autoWirer.AutoWireParameters(sourceData, targetCapability.Parameters)
 
+	// Matches that differ between dependencies depend on which step was meant
+	for _, name := range h.ambiguousParameters(sourcesByStep, targetCapability.Parameters) {
+		if _, wired := params[name]; wired {
+			delete(params, name)
+			unmapped = append(unmapped, name)
+			report.Ambiguous = append(report.Ambiguous, name)
+		}
+	}
+	for name := range params {
+		report.Deterministic = append(report.Deterministic, name)
+	}
+
 	h.logDebug("Auto-wiring result", map[string]interface{}{
 		"capability":  targetCapability.Name,
 		"wired_count": len(params),
 		"unmapped":    unmapped,
+		"ambiguous":   report.Ambiguous,
 		"source_keys": getMapKeys(sourceData),
 	})
 
@@ -130,7 +204,7 @@ func (h *HybridResolver) ResolveParameters(
 			"capability": targetCapability.Name,
 			"params":     params,
 		})
-		return params, nil
+		return params, report.finalize(), nil
 	}
 
 	// Check if all unmapped are optional
@@ -144,13 +218,13 @@ func (h *HybridResolver) ResolveParameters(
 		}
 	}
 
-	if allUnmappedOptional {
+	if allUnmappedOptional && len(report.Ambiguous) == 0 {
 		h.logInfo("All required parameters auto-wired, optional params unmapped", map[string]interface{}{
 			"capability":        targetCapability.Name,
 			"params":            params,
 			"optional_unmapped": unmapped,
 		})
-		return params, nil
+		return params, report.finalize(), nil
 	}
 
 	// Phase 3: Use micro-resolution for remaining required parameters
@@ -160,12 +234,13 @@ func (h *HybridResolver) ResolveParameters(
 			"params":     params,
 			"unmapped":   unmapped,
 		})
-		return params, nil
+		return params, report.finalize(), nil
 	}
 
 	h.logInfo("Using micro-resolution for unmapped parameters", map[string]interface{}{
 		"capability":  targetCapability.Name,
 		"unmapped":    unmapped,
+		"ambiguous":   report.Ambiguous,
 		"instruction": stepInstruction,
 	})
 
@@ -185,6 +260,9 @@ Pay close attention to which specific item the instruction is referring to.`, st
 	} else {
 		hint = fmt.Sprintf("Need to extract values for required parameters: %v", unmapped)
 	}
+	if len(report.Ambiguous) > 0 {
+		hint += fmt.Sprintf("\n\nSeveral earlier steps returned different values for: %v. Choose the value this step needs.", report.Ambiguous)
+	}
 	resolved, err := h.microResolver.ResolveParameters(ctx, sourceData, targetCapability, hint, stepID)
 	if err != nil {
 		// Micro-resolution failed, return what we have
@@ -193,29 +271,35 @@ Pay close attention to which specific item the instruction is referring to.`, st
 			"capability": targetCapability.Name,
 			"params":     params,
 		})
-		return params, nil
+		return params, report.finalize(), nil
 	}
 
 	// Merge results (auto-wired takes priority to avoid overwriting with LLM guesses)
 	for k, v := range resolved {
 		if _, exists := params[k]; !exists {
 			params[k] = v
+			report.LLM = append(report.LLM, k)
 		}
 	}
 
 	h.logInfo("Hybrid resolution completed", map[string]interface{}{
 		"capability":     targetCapability.Name,
 		"final_params":   params,
-		"auto_wired":     len(params) - len(resolved),
-		"micro_resolved": len(resolved),
+		"auto_wired":     len(report.Deterministic),
+		"micro_resolved": len(report.LLM),
 	})
 
-	return params, nil
+	return params, report.finalize(), nil
 }
 
 // collectSourceData merges all dependency results into a single map
 func (h *HybridResolver) collectSourceData(dependencyResults map[string]*StepResult) map[string]interface{} {
-	sourceData := make(map[string]interface{})
+	return mergeSourceData(h.collectSourceDataByStep(dependencyResults))
+}
+
+// collectSourceDataByStep parses the JSON response of each successful dependency
+func (h *HybridResolver) collectSourceDataByStep(dependencyResults map[string]*StepResult) map[string]map[string]interface{} {
+	sources := make(map[string]map[string]interface{})
 
 	for stepID, result := range dependencyResults {
 		if result == nil || result.Response == "" {
@@ -236,16 +320,92 @@ func (h *HybridResolver) collectSourceData(dependencyResults map[string]*StepRes
 			})
 			continue
 		}
+		sources[stepID] = parsed
+	}
+
+	return sources
+}
+
+// mergeSourceData merges per-step data in step ID order, so later steps
+// override earlier ones for the same key. Numbers in IDs compare by value,
+// so step-2 comes before step-10.
+func mergeSourceData(sources map[string]map[string]interface{}) map[string]interface{} {
+	stepIDs := make([]string, 0, len(sources))
+	for stepID := range sources {
+		stepIDs = append(stepIDs, stepID)
+	}
+	sort.Slice(stepIDs, func(i, j int) bool { return naturalLess(stepIDs[i], stepIDs[j]) })
 
-		// Merge into sourceData (later steps may override earlier for same keys)
-		for k, v := range parsed {
+	sourceData := make(map[string]interface{})
+	for _, stepID := range stepIDs {
+		for k, v := range sources[stepID] {
 			sourceData[k] = v
 		}
 	}
-
 	return sourceData
 }
 
+// naturalLess orders strings with runs of digits compared as numbers
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		aDigits, bDigits := leadingDigits(a), leadingDigits(b)
+		if aDigits > 0 && bDigits > 0 {
+			// Compare by value: drop leading zeros, then a longer run is larger
+			an := strings.TrimLeft(a[:aDigits], "0")
+			bn := strings.TrimLeft(b[:bDigits], "0")
+			if len(an) != len(bn) {
+				return len(an) < len(bn)
+			}
+			if an != bn {
+				return an < bn
+			}
+			a, b = a[aDigits:], b[bDigits:]
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+// leadingDigits returns the length of the run of ASCII digits at the start of s
+func leadingDigits(s string) int {
+	n := 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	return n
+}
+
+// ambiguousParameters returns the parameters that more than one dependency
+// matches with different values
+func (h *HybridResolver) ambiguousParameters(sources map[string]map[string]interface{}, targetParams []Parameter) []string {
+	if len(sources) < 2 {
+		return nil
+	}
+
+	var ambiguous []string
+	for _, param := range targetParams {
+		var first interface{}
+		found := false
+		for _, data := range sources {
+			value, ok := h.autoWirer.findMatchingValue(data, param.Name, param.Type)
+			if !ok {
+				continue
+			}
+			if !found {
+				first, found = value, true
+			} else if !reflect.DeepEqual(first, value) {
+				ambiguous = append(ambiguous, param.Name)
+				break
+			}
+		}
+	}
+	return ambiguous
+}
+
 // SetLogger sets the logger for the hybrid resolver and its sub-components
 // The component is always set to "framework/orchestration" to ensure proper log attribution
 // regardless of which agent or tool is using the orchestration module.
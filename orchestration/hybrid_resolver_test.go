@@ -3,6 +3,7 @@ package orchestration
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/itsneelabh/gomind/core"
@@ -167,6 +168,72 @@ func TestHybridResolver_RequiredParamsMissingTriggersMicroResolution(t *testing.
 	}
 }
 
+// TestHybridResolver_MicroResolutionUsesToolCall tests that micro-resolution
+// offers the parameters as a tool and takes structured arguments from the
+// tool call instead of parsing the text.
+func TestHybridResolver_MicroResolutionUsesToolCall(t *testing.T) {
+	aiClient := &mockAIClient{
+		generateFunc: func(ctx context.Context, prompt string, opts *core.AIOptions) (*core.AIResponse, error) {
+			if len(opts.Tools) != 1 || opts.Tools[0].Name != "provide_parameters" {
+				t.Errorf("Expected the provide_parameters tool, got %+v", opts.Tools)
+			}
+			var schema map[string]interface{}
+			if err := json.Unmarshal(opts.Tools[0].Parameters, &schema); err != nil || schema["properties"] == nil {
+				t.Errorf("Expected a JSON schema for the parameters, got %s", opts.Tools[0].Parameters)
+			}
+			return &core.AIResponse{
+				Content: "Sure! Here you go.",
+				ToolCalls: []core.ToolCall{{
+					Name:      "provide_parameters",
+					Arguments: map[string]interface{}{"lat": "48.85", "lon": 2.35},
+				}},
+			}, nil
+		},
+	}
+	resolver := NewHybridResolver(aiClient, nil)
+
+	depResults := map[string]*StepResult{
+		"step-1": {StepID: "step-1", Success: true, Response: `{"latitude": 48.85, "longitude": 2.35}`},
+	}
+	targetCap := &EnhancedCapability{
+		Name: "get_weather",
+		Parameters: []Parameter{
+			{Name: "lat", Type: "number", Required: true},
+			{Name: "lon", Type: "number", Required: true},
+		},
+	}
+
+	params, err := resolver.ResolveParameters(context.Background(), depResults, targetCap, "test-step", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if params["lat"] != 48.85 || params["lon"] != 2.35 {
+		t.Errorf("Expected coerced tool call arguments, got %v", params)
+	}
+}
+
+// TestMicroResolver_NoToolWithoutParameters tests that the provide_parameters
+// tool is only offered when the capability declares parameters.
+func TestMicroResolver_NoToolWithoutParameters(t *testing.T) {
+	aiClient := &mockAIClient{
+		generateFunc: func(ctx context.Context, prompt string, opts *core.AIOptions) (*core.AIResponse, error) {
+			if len(opts.Tools) != 0 {
+				t.Errorf("Expected no tools for a capability without parameters, got %+v", opts.Tools)
+			}
+			return &core.AIResponse{Content: "{}"}, nil
+		},
+	}
+	resolver := NewMicroResolver(aiClient, nil)
+
+	params, err := resolver.ResolveParameters(context.Background(), map[string]interface{}{"status": "ok"}, &EnhancedCapability{Name: "ping"}, "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(params) != 0 {
+		t.Errorf("Expected no parameters, got %v", params)
+	}
+}
+
 // TestHybridResolver_MicroResolutionDisabled tests that when micro-resolution
 // is disabled, only auto-wired params are returned.
 func TestHybridResolver_MicroResolutionDisabled(t *testing.T) {
@@ -599,3 +666,130 @@ func TestHybridResolver_InvalidJSONResponse(t *testing.T) {
 		t.Error("Expected warning about invalid JSON, got none")
 	}
 }
+
+// TestHybridResolver_ReportDeterministic tests that a fully auto-wired step is
+// reported as deterministic and makes no LLM call.
+func TestHybridResolver_ReportDeterministic(t *testing.T) {
+	aiClient := &mockAIClient{}
+	resolver := NewHybridResolver(aiClient, nil)
+
+	depResults := map[string]*StepResult{
+		"geocoding": {StepID: "geocoding", Success: true, Response: `{"lat": 48.85, "lon": 2.35}`},
+	}
+	targetCap := &EnhancedCapability{
+		Name: "get_weather",
+		Parameters: []Parameter{
+			{Name: "lat", Type: "number", Required: true},
+			{Name: "lon", Type: "number", Required: true},
+		},
+	}
+
+	params, report, err := resolver.ResolveParametersWithReport(context.Background(), depResults, targetCap, "test-step", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(params) != 2 {
+		t.Errorf("Expected 2 params, got %v", params)
+	}
+	if report.Method != ResolutionDeterministic {
+		t.Errorf("Expected method %q, got %q", ResolutionDeterministic, report.Method)
+	}
+	if len(report.Deterministic) != 2 || len(report.LLM) != 0 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if len(aiClient.calls) != 0 {
+		t.Errorf("Expected no AI calls, got %d", len(aiClient.calls))
+	}
+}
+
+// TestHybridResolver_ReportMixed tests that a step resolved partly by
+// auto-wiring and partly by micro-resolution is reported as mixed.
+func TestHybridResolver_ReportMixed(t *testing.T) {
+	aiClient := newMockAIClientWithResponse(map[string]interface{}{"lon": 2.35})
+	resolver := NewHybridResolver(aiClient, nil)
+
+	depResults := map[string]*StepResult{
+		"geocoding": {StepID: "geocoding", Success: true, Response: `{"lat": 48.85, "longitude": 2.35}`},
+	}
+	targetCap := &EnhancedCapability{
+		Name: "get_weather",
+		Parameters: []Parameter{
+			{Name: "lat", Type: "number", Required: true},
+			{Name: "lon", Type: "number", Required: true},
+		},
+	}
+
+	_, report, err := resolver.ResolveParametersWithReport(context.Background(), depResults, targetCap, "test-step", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.Method != ResolutionMixed {
+		t.Errorf("Expected method %q, got %q", ResolutionMixed, report.Method)
+	}
+	if len(report.Deterministic) != 1 || report.Deterministic[0] != "lat" {
+		t.Errorf("Expected lat to be deterministic, got %v", report.Deterministic)
+	}
+	if len(report.LLM) != 1 || report.LLM[0] != "lon" {
+		t.Errorf("Expected lon to be LLM-resolved, got %v", report.LLM)
+	}
+}
+
+// TestHybridResolver_ConflictingDependenciesUseLLM tests that a parameter two
+// dependencies supply with different values is not auto-wired.
+func TestHybridResolver_ConflictingDependenciesUseLLM(t *testing.T) {
+	aiClient := newMockAIClientWithResponse(map[string]interface{}{"symbol": "NVDA"})
+	resolver := NewHybridResolver(aiClient, nil)
+
+	depResults := map[string]*StepResult{
+		"step-1": {StepID: "step-1", Success: true, Response: `{"symbol": "AMD", "exchange": "NASDAQ"}`},
+		"step-2": {StepID: "step-2", Success: true, Response: `{"symbol": "NVDA", "exchange": "NASDAQ"}`},
+	}
+	targetCap := &EnhancedCapability{
+		Name: "get_quote",
+		Parameters: []Parameter{
+			{Name: "symbol", Type: "string", Required: true},
+			{Name: "exchange", Type: "string", Required: false},
+		},
+	}
+
+	params, report, err := resolver.ResolveParametersWithReport(context.Background(), depResults, targetCap, "test-step", "quote the second company")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if params["symbol"] != "NVDA" {
+		t.Errorf("Expected symbol from micro-resolution, got %v", params["symbol"])
+	}
+	if params["exchange"] != "NASDAQ" {
+		t.Errorf("Expected agreeing exchange to be auto-wired, got %v", params["exchange"])
+	}
+	if len(report.Ambiguous) != 1 || report.Ambiguous[0] != "symbol" {
+		t.Errorf("Expected symbol to be ambiguous, got %v", report.Ambiguous)
+	}
+	if report.Method != ResolutionMixed {
+		t.Errorf("Expected method %q, got %q", ResolutionMixed, report.Method)
+	}
+	if len(aiClient.calls) != 1 {
+		t.Fatalf("Expected 1 AI call, got %d", len(aiClient.calls))
+	}
+	if !strings.Contains(aiClient.calls[0], "different values for: [symbol]") {
+		t.Errorf("Expected the prompt to mention the conflict, got: %s", aiClient.calls[0])
+	}
+}
+
+func TestMergeSourceData_NumericStepOrder(t *testing.T) {
+	merged := mergeSourceData(map[string]map[string]interface{}{
+		"step-10": {"price": 10.0},
+		"step-2":  {"price": 2.0, "symbol": "ACME"},
+		"step-9":  {"price": 9.0},
+	})
+	if merged["price"] != 10.0 || merged["symbol"] != "ACME" {
+		t.Errorf("Expected step-10 to override step-2 and step-9, got %v", merged)
+	}
+
+	ordered := []string{"step", "step-1", "step-02", "step-3", "step-10", "step-10a", "step-b"}
+	for i := 1; i < len(ordered); i++ {
+		if !naturalLess(ordered[i-1], ordered[i]) || naturalLess(ordered[i], ordered[i-1]) {
+			t.Errorf("Expected %q before %q", ordered[i-1], ordered[i])
+		}
+	}
+}
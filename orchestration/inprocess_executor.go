@@ -0,0 +1,77 @@
+package orchestration
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/itsneelabh/gomind/core"
+	"github.com/itsneelabh/gomind/telemetry"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// NewInProcessExecutor creates a WorkflowExecutor that calls the given
+// handlers in memory instead of over the network, keyed by agent name. It is
+// meant for tests of multi-agent flows: register fake agents as plain
+// http.Handlers and assert on what they receive. Requests go through the same
+// client stack as real calls, so headers, trace propagation, timeouts, body
+// limits and circuit breakers behave the same. Agents without a handler fail
+// with core.ErrAgentNotFound.
+func NewInProcessExecutor(handlers map[string]http.Handler) *WorkflowExecutor {
+	client := NewWorkflowHTTPClient()
+	// Same propagator setup as NewWorkflowHTTPClient, so the executor's trace
+	// headers reach the handlers as they would a real agent
+	client.httpClient = telemetry.NewTracedHTTPClientWithPropagator(&inProcessTransport{handlers: handlers},
+		propagation.NewCompositeTextMapPropagator())
+
+	resolver := make(StaticResolver, len(handlers))
+	for name := range handlers {
+		resolver[name] = "http://" + name
+	}
+
+	return &WorkflowExecutor{
+		client:   client,
+		logger:   &core.NoOpLogger{},
+		breakers: newTargetBreakers(DefaultTargetFailureThreshold, DefaultTargetCooldown),
+		resolver: resolver,
+	}
+}
+
+// inProcessTransport serves requests with the handler registered for the
+// request's host
+type inProcessTransport struct {
+	handlers map[string]http.Handler
+}
+
+func (t *inProcessTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	handler, ok := t.handlers[req.URL.Hostname()]
+	if !ok {
+		return nil, fmt.Errorf("no in-process handler for %s: %w", req.URL.Host, core.ErrConnectionFailed)
+	}
+
+	// Present the request as a server would receive it
+	serverReq := req.Clone(req.Context())
+	serverReq.RequestURI = req.URL.RequestURI()
+	serverReq.RemoteAddr = "in-process"
+	if serverReq.Body == nil {
+		serverReq.Body = http.NoBody
+	}
+
+	recorder := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(recorder, serverReq)
+	}()
+
+	// Like a real connection, give up when the caller's context ends
+	select {
+	case <-done:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp := recorder.Result()
+	resp.Request = req
+	return resp, nil
+}
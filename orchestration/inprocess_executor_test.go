@@ -0,0 +1,63 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+	"github.com/itsneelabh/gomind/telemetry"
+)
+
+func TestInProcessExecutorDispatchesToHandler(t *testing.T) {
+	var received http.Header
+	executor := NewInProcessExecutor(map[string]http.Handler{
+		"stock-agent": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r.Header.Clone()
+			if r.URL.Path != "/quote" || r.Method != http.MethodPost {
+				t.Errorf("request = %s %s, want POST /quote", r.Method, r.URL.Path)
+			}
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_ = json.NewEncoder(w).Encode(map[string]string{"symbol": body["symbol"]})
+		}),
+	})
+
+	ctx := telemetry.WithBaggage(context.Background(), "trace_id", "trace-42")
+	var out map[string]string
+	if err := executor.CallAgentJSON(ctx, "stock-agent", "quote", map[string]string{"symbol": "ACME"}, &out); err != nil {
+		t.Fatalf("CallAgentJSON() error = %v", err)
+	}
+	if out["symbol"] != "ACME" {
+		t.Errorf("CallAgentJSON() out = %v", out)
+	}
+	if received.Get("Content-Type") != "application/json" || received.Get(core.TraceIDHeader) != "trace-42" {
+		t.Errorf("handler headers = %v, want JSON content type and trace ID", received)
+	}
+}
+
+func TestInProcessExecutorErrors(t *testing.T) {
+	executor := NewInProcessExecutor(map[string]http.Handler{
+		"broken": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}),
+		"slow": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}),
+	})
+	ctx := context.Background()
+
+	var callErr *ServiceCallError
+	if _, err := executor.CallAgent(ctx, "broken", "work", nil); !errors.As(err, &callErr) || callErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("CallAgent(broken) error = %v, want a 502 *ServiceCallError", err)
+	}
+	if _, err := executor.CallAgent(ctx, "slow", "work", nil, WithCallTimeout(20*time.Millisecond)); !errors.Is(err, core.ErrTimeout) {
+		t.Errorf("CallAgent(slow) error = %v, want ErrTimeout", err)
+	}
+	if _, err := executor.CallAgent(ctx, "missing", "work", nil); !errors.Is(err, core.ErrAgentNotFound) {
+		t.Errorf("CallAgent(missing) error = %v, want ErrAgentNotFound", err)
+	}
+}
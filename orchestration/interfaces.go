@@ -2,6 +2,7 @@ package orchestration
 
 import (
 	"context"
+	"encoding/json"
 	"log"
 	"os"
 	"strconv"
@@ -28,6 +29,82 @@ type RoutingStep struct {
 	Instruction string                 `json:"instruction"`
 	DependsOn   []string               `json:"depends_on,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	// Condition gates the step on earlier results, e.g. "step-1.available == true".
+	// References must name steps listed in DependsOn. When it evaluates to
+	// false the step and its dependents are skipped. See CompileCondition.
+	Condition string `json:"condition,omitempty"`
+	// SubQuestion is the part of the user's request this step helps answer.
+	// Steps with the same SubQuestion are synthesized together by StrategyParallel.
+	SubQuestion string `json:"sub_question,omitempty"`
+	// Timeout bounds the step, including retries. A step that runs past it is
+	// cancelled and fails with TimedOut set; its dependents are skipped.
+	// Zero means no per-step limit, and shorter limits are raised to
+	// MinStepTimeout. In JSON it is a duration string such as "30s"; a plain
+	// number, as LLM-generated plans may use, is read as seconds.
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// MaxCorrections overrides how many times a failure caused by bad
+	// parameters is sent back to the LLM for corrected parameters before the
+	// step is retried. Zero uses the executor's limit (see
+	// SetValidationFeedback); a negative value disables correction.
+	MaxCorrections int `json:"max_corrections,omitempty"`
+}
+
+// MarshalJSON writes Timeout as a duration string
+func (s RoutingStep) MarshalJSON() ([]byte, error) {
+	type plainStep RoutingStep
+	aux := struct {
+		plainStep
+		Timeout string `json:"timeout,omitempty"`
+	}{plainStep: plainStep(s)}
+	if s.Timeout > 0 {
+		aux.Timeout = s.Timeout.String()
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON reads Timeout from a duration string or a number of
+// seconds. A timeout that cannot be read is ignored rather than failing the
+// whole plan.
+func (s *RoutingStep) UnmarshalJSON(data []byte) error {
+	type plainStep RoutingStep
+	aux := struct {
+		*plainStep
+		Timeout json.RawMessage `json:"timeout,omitempty"`
+	}{plainStep: (*plainStep)(s)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	s.Timeout = parseStepTimeout(aux.Timeout)
+	return nil
+}
+
+// parseStepTimeout converts a JSON timeout value to a duration, returning 0
+// for missing, negative or malformed values
+func parseStepTimeout(raw json.RawMessage) time.Duration {
+	if len(raw) == 0 {
+		return 0
+	}
+
+	var timeout time.Duration
+	var text string
+	var seconds float64
+	if err := json.Unmarshal(raw, &text); err == nil {
+		parsed, err := time.ParseDuration(strings.TrimSpace(text))
+		if err != nil {
+			// "30" rather than "30s"
+			if n, numErr := strconv.ParseFloat(strings.TrimSpace(text), 64); numErr == nil {
+				parsed = time.Duration(n * float64(time.Second))
+			}
+		}
+		timeout = parsed
+	} else if err := json.Unmarshal(raw, &seconds); err == nil {
+		timeout = time.Duration(seconds * float64(time.Second))
+	}
+
+	if timeout < 0 {
+		return 0
+	}
+	return timeout
 }
 
 // RoutingPlan represents a complete execution plan
@@ -78,6 +155,12 @@ type OrchestratorResponse struct {
 	Confidence      float64                `json:"confidence"`
 	// Steps contains individual step results (populated by ExecutePlanWithSynthesis)
 	Steps []StepResult `json:"steps,omitempty"`
+
+	// DryRun is set when the request ran under WithDryRun: the plan below was
+	// generated but not executed, and Response is empty
+	DryRun            bool              `json:"dry_run,omitempty"`
+	Plan              *RoutingPlan      `json:"plan,omitempty"`
+	SynthesisStrategy SynthesisStrategy `json:"synthesis_strategy,omitempty"`
 }
 
 // StreamingOrchestratorResponse extends OrchestratorResponse for streaming scenarios
@@ -130,8 +213,30 @@ type StepResult struct {
 	Attempts    int           `json:"attempts"`
 	StartTime   time.Time     `json:"start_time"`
 	EndTime     time.Time     `json:"end_time"`
+	// HandlerDuration is the duration_ms the capability reported in its
+	// result envelope: the handler's own time, without network overhead
+	HandlerDuration time.Duration `json:"handler_duration,omitempty"`
 	// Metadata holds optional step-level data (e.g., HITL checkpoint info)
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// Skipped is set when the step's Condition was false or a dependency was
+	// skipped. Skipped steps are not successful but don't fail the plan.
+	Skipped bool `json:"skipped,omitempty"`
+	// SubQuestion is copied from the RoutingStep
+	SubQuestion string `json:"sub_question,omitempty"`
+	// TimedOut is set when the step was cancelled by its RoutingStep.Timeout,
+	// as opposed to failing on its own
+	TimedOut bool `json:"timed_out,omitempty"`
+	// Cancelled is set when the execution was cancelled, by CancelExecution
+	// or its caller's context, while the step ran or before it started
+	Cancelled bool `json:"cancelled,omitempty"`
+
+	// Output is the response decoded according to the capability's declared
+	// output contract. Synthesis prefers it over the raw Response string.
+	Output interface{} `json:"output,omitempty"`
+	// ContractViolated is set when Response does not match the declared
+	// OutputTypes/OutputSummary. The step still counts as successful.
+	ContractViolated   bool                      `json:"contract_violated,omitempty"`
+	ContractViolations []OutputContractViolation `json:"contract_violations,omitempty"`
 }
 
 // Synthesizer combines multiple agent responses into a coherent result
@@ -156,8 +261,14 @@ const (
 	// StrategySimple concatenates responses
 	StrategySimple SynthesisStrategy = "simple"
 
-	// StrategyCustom uses a custom synthesis function
+	// StrategyCustom is a conventional name for an application strategy
+	// registered with AISynthesizer.RegisterStrategy
 	StrategyCustom SynthesisStrategy = "custom"
+
+	// StrategyParallel synthesizes the results for each sub-question in
+	// parallel and merges the answers. Plans without at least two
+	// sub-questions fall back to StrategyLLM.
+	StrategyParallel SynthesisStrategy = "parallel"
 )
 
 // ExecutionRecord represents a historical execution
@@ -312,6 +423,13 @@ type OrchestratorConfig struct {
 	PlanParseRetryEnabled bool `json:"plan_parse_retry_enabled"`
 	PlanParseMaxRetries   int  `json:"plan_parse_max_retries"` // Default: 2
 
+	// PlanCoalescingEnabled makes concurrent identical requests (ones whose
+	// built planning prompt and system prompt match exactly) share one
+	// plan-generation LLM call while it is in flight. Callers that join
+	// receive the same plan with zero token usage.
+	// Default: true | Env: GOMIND_PLAN_COALESCING_ENABLED
+	PlanCoalescingEnabled bool `json:"plan_coalescing_enabled"`
+
 	// Hallucination Detection configuration
 	// When HallucinationValidationEnabled is true, validates that LLM-generated plans
 	// only reference agents that were included in the prompt's capability info.
@@ -434,6 +552,9 @@ func DefaultConfig() *OrchestratorConfig {
 		PlanParseRetryEnabled: true, // Enable by default for production reliability
 		PlanParseMaxRetries:   2,    // Up to 2 retry attempts after initial failure
 
+		// Share in-flight plan generation between identical concurrent requests
+		PlanCoalescingEnabled: true,
+
 		// Hallucination Detection defaults
 		// Validates that LLM plans only use agents from the allowed list.
 		// See orchestration/bugs/BUG_LLM_HALLUCINATED_TOOL.md for detailed analysis.
@@ -456,6 +577,13 @@ func DefaultConfig() *OrchestratorConfig {
 		config.CapabilityService.Endpoint = serviceURL
 	}
 
+	// Step concurrency limit from environment
+	if maxConcurrency := os.Getenv("GOMIND_ORCHESTRATION_MAX_CONCURRENCY"); maxConcurrency != "" {
+		if val, err := strconv.Atoi(maxConcurrency); err == nil && val > 0 {
+			config.ExecutionOptions.MaxConcurrency = val
+		}
+	}
+
 	// Plan Parse Retry configuration from environment
 	if retryEnabled := os.Getenv("GOMIND_PLAN_RETRY_ENABLED"); retryEnabled != "" {
 		config.PlanParseRetryEnabled = strings.ToLower(retryEnabled) == "true"
@@ -466,6 +594,10 @@ func DefaultConfig() *OrchestratorConfig {
 		}
 	}
 
+	if coalescing := os.Getenv("GOMIND_PLAN_COALESCING_ENABLED"); coalescing != "" {
+		config.PlanCoalescingEnabled = strings.ToLower(coalescing) == "true"
+	}
+
 	// Hallucination Detection configuration from environment
 	// GOMIND_HALLUCINATION_VALIDATION_ENABLED=false completely disables validation
 	if hallValidation := os.Getenv("GOMIND_HALLUCINATION_VALIDATION_ENABLED"); hallValidation != "" {
@@ -533,6 +665,12 @@ func DefaultConfig() *OrchestratorConfig {
 			config.LLMDebug.RedisDB = val
 		}
 	}
+	if redact := os.Getenv("GOMIND_LLM_DEBUG_REDACT"); redact != "" {
+		config.LLMDebug.Redact = strings.ToLower(redact) == "true"
+	}
+	if content := os.Getenv("GOMIND_LLM_DEBUG_CONTENT"); content != "" {
+		config.LLMDebug.HashOnly = strings.ToLower(content) == "hash"
+	}
 
 	// HITL (Human-in-the-Loop) defaults (disabled by default for backward compatibility)
 	config.HITL = DefaultHITLConfig()
@@ -0,0 +1,100 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// llmDebugExportBatchSize is how many records an export loads per Redis round trip
+const llmDebugExportBatchSize = 100
+
+// LLMDebugExportSummary is the last line of an NDJSON export, wrapped as
+// {"summary": {...}} so it can be told apart from the records
+type LLMDebugExportSummary struct {
+	Count      int       `json:"count"`             // Records written
+	Skipped    int       `json:"skipped,omitempty"` // Indexed records that expired or failed to decode
+	Since      time.Time `json:"since"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+// ExportDebugRecords streams every record created at or after since to w as
+// newline-delimited JSON, oldest first. See ExportLLMDebugRecords.
+func (s *RedisLLMDebugStore) ExportDebugRecords(ctx context.Context, since time.Time, w io.Writer) error {
+	return ExportLLMDebugRecords(ctx, s.client, since, w)
+}
+
+// ExportLLMDebugRecords streams the LLM debug records in the Redis database
+// behind client to w as newline-delimited JSON, one LLMDebugRecord per line,
+// oldest first. Records are selected through the debug store's sorted-set
+// index, loaded in batches and written as they are decoded, so memory use
+// does not grow with the export. Compressed records are decompressed. A zero
+// since exports everything.
+//
+// The final line is {"summary": LLMDebugExportSummary} with the record count.
+func ExportLLMDebugRecords(ctx context.Context, client *redis.Client, since time.Time, w io.Writer) error {
+	minScore := "-inf"
+	if !since.IsZero() {
+		minScore = strconv.FormatInt(since.Unix(), 10)
+	}
+
+	encoder := json.NewEncoder(w)
+	summary := LLMDebugExportSummary{Since: since}
+
+	for offset := int64(0); ; offset += llmDebugExportBatchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		ids, err := client.ZRangeByScore(ctx, llmDebugIndexKey, &redis.ZRangeBy{
+			Min:    minScore,
+			Max:    "+inf",
+			Offset: offset,
+			Count:  llmDebugExportBatchSize,
+		}).Result()
+		if err != nil {
+			return fmt.Errorf("failed to read debug index: %w", err)
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		keys := make([]string, len(ids))
+		for i, id := range ids {
+			keys[i] = llmDebugKeyPrefix + id
+		}
+		values, err := client.MGet(ctx, keys...).Result()
+		if err != nil {
+			return fmt.Errorf("failed to load debug records: %w", err)
+		}
+
+		for _, value := range values {
+			data, ok := value.(string)
+			if !ok {
+				summary.Skipped++ // Expired since it was indexed
+				continue
+			}
+			record, err := decodeLLMDebugRecord([]byte(data))
+			if err != nil {
+				summary.Skipped++
+				continue
+			}
+			if err := encoder.Encode(record); err != nil {
+				return fmt.Errorf("failed to write debug record: %w", err)
+			}
+			summary.Count++
+		}
+
+		if len(ids) < llmDebugExportBatchSize {
+			break
+		}
+	}
+
+	summary.ExportedAt = time.Now()
+	return encoder.Encode(map[string]LLMDebugExportSummary{"summary": summary})
+}
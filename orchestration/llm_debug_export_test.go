@@ -0,0 +1,80 @@
+package orchestration
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestExportDebugRecords(t *testing.T) {
+	mr := miniredis.RunT(t)
+	store, err := NewRedisLLMDebugStore(WithDebugRedisURL("redis://"+mr.Addr()), WithDebugRedisDB(0))
+	if err != nil {
+		t.Fatalf("NewRedisLLMDebugStore failed: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	record := func(requestID, response string) {
+		if err := store.RecordInteraction(ctx, requestID, LLMInteraction{Type: "synthesis", Response: response, Success: true}); err != nil {
+			t.Fatalf("RecordInteraction failed: %v", err)
+		}
+	}
+	record("req-old", "old")
+	record("req-new", "new")
+	record("req-large", strings.Repeat("x", compressionThreshold+1)) // Stored gzip-compressed
+
+	// Place req-old before the cut-off and index a record that has expired
+	cutoff := time.Now().Add(-time.Hour)
+	mr.ZAdd(llmDebugIndexKey, float64(cutoff.Add(-time.Hour).Unix()), "req-old")
+	mr.ZAdd(llmDebugIndexKey, float64(time.Now().Unix()), "req-expired")
+
+	var buf bytes.Buffer
+	if err := store.ExportDebugRecords(ctx, cutoff, &buf); err != nil {
+		t.Fatalf("ExportDebugRecords failed: %v", err)
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(&buf)
+	scanner.Buffer(make([]byte, 0, 1024), 2*compressionThreshold)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if len(lines) != 3 {
+		t.Fatalf("Expected 2 records and a summary line, got %d lines", len(lines))
+	}
+
+	exported := map[string]*LLMDebugRecord{}
+	for _, line := range lines[:2] {
+		var rec LLMDebugRecord
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("Invalid record line: %v", err)
+		}
+		exported[rec.RequestID] = &rec
+	}
+	if exported["req-old"] != nil {
+		t.Error("Records before since must not be exported")
+	}
+	if rec := exported["req-large"]; rec == nil || len(rec.Interactions[0].Response) != compressionThreshold+1 {
+		t.Error("Expected the compressed record to be exported decompressed")
+	}
+	if exported["req-new"] == nil {
+		t.Error("Expected req-new in the export")
+	}
+
+	var trailer struct {
+		Summary LLMDebugExportSummary `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(lines[2]), &trailer); err != nil {
+		t.Fatalf("Invalid summary line: %v", err)
+	}
+	if trailer.Summary.Count != 2 || trailer.Summary.Skipped != 1 {
+		t.Errorf("Expected count 2 and 1 skipped, got %+v", trailer.Summary)
+	}
+}
@@ -0,0 +1,59 @@
+package orchestration
+
+import (
+	"context"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// llmDebugRecorder adapts an LLMDebugStore to core.AIInteractionRecorder
+type llmDebugRecorder struct {
+	store LLMDebugStore
+
+	// orchestrator, when set, redacts interactions the way its own are
+	orchestrator *AIOrchestrator
+}
+
+// NewLLMDebugRecorder returns a recorder that appends AI client calls to
+// store, for use with core.WithAIDebugRecorder or ai.WithDebugRecorder.
+// Direct calls made while handling an orchestrated request land in the same
+// record as the orchestrator's, as the request ID travels in the baggage.
+func NewLLMDebugRecorder(store LLMDebugStore) core.AIInteractionRecorder {
+	return &llmDebugRecorder{store: store}
+}
+
+// DebugRecorder returns a recorder for the orchestrator's LLM debug store
+// that applies the orchestrator's redaction (see SetLLMDebugRedactor), or nil
+// when no store is configured.
+func (o *AIOrchestrator) DebugRecorder() core.AIInteractionRecorder {
+	if o.debugStore == nil {
+		return nil
+	}
+	return &llmDebugRecorder{store: o.debugStore, orchestrator: o}
+}
+
+// RecordAIInteraction implements core.AIInteractionRecorder
+func (r *llmDebugRecorder) RecordAIInteraction(ctx context.Context, requestID string, interaction core.AIInteraction) error {
+	recorded := LLMInteraction{
+		Type:             interaction.Type,
+		Timestamp:        interaction.Timestamp,
+		DurationMs:       interaction.DurationMs,
+		Prompt:           interaction.Prompt,
+		SystemPrompt:     interaction.SystemPrompt,
+		Temperature:      interaction.Temperature,
+		MaxTokens:        interaction.MaxTokens,
+		Model:            interaction.Model,
+		Provider:         interaction.Provider,
+		Response:         interaction.Response,
+		PromptTokens:     interaction.PromptTokens,
+		CompletionTokens: interaction.CompletionTokens,
+		TotalTokens:      interaction.TotalTokens,
+		Success:          interaction.Success,
+		Error:            interaction.Error,
+		Attempt:          1,
+	}
+	if r.orchestrator != nil {
+		recorded = r.orchestrator.redactInteraction(recorded)
+	}
+	return r.store.RecordInteraction(ctx, requestID, recorded)
+}
@@ -0,0 +1,69 @@
+package orchestration
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+func TestLLMDebugRecorder(t *testing.T) {
+	store := NewMemoryLLMDebugStore()
+	recorder := NewLLMDebugRecorder(store)
+
+	err := recorder.RecordAIInteraction(context.Background(), "req-1", core.AIInteraction{
+		Type:        core.AIInteractionTypeDirect,
+		Timestamp:   time.Now(),
+		DurationMs:  42,
+		Prompt:      "Summarize AAPL news",
+		Response:    "Apple shares rose",
+		Model:       "gpt-4o-mini",
+		TotalTokens: 30,
+		Success:     true,
+	})
+	if err != nil {
+		t.Fatalf("RecordAIInteraction() error = %v", err)
+	}
+
+	record, err := store.GetRecord(context.Background(), "req-1")
+	if err != nil {
+		t.Fatalf("GetRecord() error = %v", err)
+	}
+	if len(record.Interactions) != 1 {
+		t.Fatalf("Expected one interaction, got %d", len(record.Interactions))
+	}
+	interaction := record.Interactions[0]
+	if interaction.Type != "direct_call" || interaction.Prompt != "Summarize AAPL news" || interaction.Response != "Apple shares rose" ||
+		interaction.DurationMs != 42 || interaction.TotalTokens != 30 || !interaction.Success {
+		t.Errorf("Unexpected interaction: %+v", interaction)
+	}
+}
+
+func TestAIOrchestrator_DebugRecorderRedacts(t *testing.T) {
+	orchestrator := NewAIOrchestrator(nil, NewMockDiscovery(), NewMockAIClient())
+	if orchestrator.DebugRecorder() != nil {
+		t.Error("Expected no recorder without a debug store")
+	}
+
+	store := NewMemoryLLMDebugStore()
+	orchestrator.SetLLMDebugStore(store)
+	orchestrator.SetLLMDebugRedactor(func(text string) string {
+		return strings.ReplaceAll(text, "jane@example.com", "[email]")
+	})
+
+	err := orchestrator.DebugRecorder().RecordAIInteraction(context.Background(), "req-2", core.AIInteraction{
+		Prompt: "Email jane@example.com the report",
+	})
+	if err != nil {
+		t.Fatalf("RecordAIInteraction() error = %v", err)
+	}
+	record, err := store.GetRecord(context.Background(), "req-2")
+	if err != nil {
+		t.Fatalf("GetRecord() error = %v", err)
+	}
+	if prompt := record.Interactions[0].Prompt; prompt != "Email [email] the report" {
+		t.Errorf("Expected the orchestrator's redaction, got %q", prompt)
+	}
+}
@@ -0,0 +1,89 @@
+package orchestration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// RedactedPlaceholder replaces redacted content in LLM debug records. It is
+// the same placeholder core's log redaction uses.
+const RedactedPlaceholder = core.RedactedValue
+
+// ChainRedactors applies redactors in order
+func ChainRedactors(redactors ...LLMDebugRedactor) LLMDebugRedactor {
+	return func(text string) string {
+		for _, redactor := range redactors {
+			if redactor != nil {
+				text = redactor(text)
+			}
+		}
+		return text
+	}
+}
+
+// DefaultLLMDebugRedactor masks e-mail addresses, SSNs and card numbers
+// with core.RedactPIIText, so debug records follow the same rules as logs
+func DefaultLLMDebugRedactor() LLMDebugRedactor {
+	return core.RedactPIIText
+}
+
+// redactingLLMDebugStore applies redaction to interactions before passing
+// them to the wrapped store
+type redactingLLMDebugStore struct {
+	LLMDebugStore
+	redactor LLMDebugRedactor
+	hashOnly bool
+}
+
+// NewRedactingLLMDebugStore wraps store so every interaction recorded
+// through it is redacted first, whatever the store's backend: with redactor
+// applied to the prompt, system prompt and response, or with hashOnly
+// reduced to token counts and content hashes. The factory wraps custom
+// stores this way when GOMIND_LLM_DEBUG_REDACT or GOMIND_LLM_DEBUG_CONTENT
+// is set. With neither, store is returned unchanged.
+func NewRedactingLLMDebugStore(store LLMDebugStore, redactor LLMDebugRedactor, hashOnly bool) LLMDebugStore {
+	if store == nil || (redactor == nil && !hashOnly) {
+		return store
+	}
+	return &redactingLLMDebugStore{LLMDebugStore: store, redactor: redactor, hashOnly: hashOnly}
+}
+
+// RecordInteraction redacts interaction and records it in the wrapped store
+func (s *redactingLLMDebugStore) RecordInteraction(ctx context.Context, requestID string, interaction LLMInteraction) error {
+	return s.LLMDebugStore.RecordInteraction(ctx, requestID, redactLLMInteraction(interaction, s.redactor, s.hashOnly))
+}
+
+// redactLLMInteraction applies a debug store's redaction settings to an
+// interaction before it is serialized. With hashOnly the prompt, system
+// prompt and response are dropped and only their SHA-256 hashes are kept,
+// alongside the token counts.
+func redactLLMInteraction(interaction LLMInteraction, redactor LLMDebugRedactor, hashOnly bool) LLMInteraction {
+	if hashOnly {
+		interaction.PromptHash = contentHash(interaction.Prompt)
+		interaction.SystemPromptHash = contentHash(interaction.SystemPrompt)
+		interaction.ResponseHash = contentHash(interaction.Response)
+		interaction.Prompt = ""
+		interaction.SystemPrompt = ""
+		interaction.Response = ""
+		interaction.ContentRedacted = true
+		return interaction
+	}
+	if redactor != nil {
+		interaction.Prompt = redactor(interaction.Prompt)
+		interaction.SystemPrompt = redactor(interaction.SystemPrompt)
+		interaction.Response = redactor(interaction.Response)
+	}
+	return interaction
+}
+
+// contentHash returns the hex SHA-256 of text, or "" for empty text
+func contentHash(text string) string {
+	if text == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
@@ -75,7 +75,8 @@ type LLMDebugRecord struct {
 // This includes the complete prompt and response without truncation.
 type LLMInteraction struct {
 	// Type identifies the interaction purpose
-	// Values: "plan_generation", "synthesis", "micro_resolution", "correction", "error_analysis"
+	// Values: "plan_generation", "synthesis", "synthesis_group", "micro_resolution", "correction", "error_analysis",
+	// and "direct_call" for calls components make through their own AI client (see NewLLMDebugRecorder)
 	Type string `json:"type"`
 
 	// Timestamp is when the interaction started
@@ -92,6 +93,9 @@ type LLMInteraction struct {
 	Model        string  `json:"model,omitempty"`    // Model identifier (e.g., "gpt-4o-mini")
 	Provider     string  `json:"provider,omitempty"` // Provider (e.g., "openai", "anthropic")
 
+	// IdempotencyKey is the key the provider received for deduplication, if any
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+
 	// Response fields
 	Response         string `json:"response"` // Complete LLM response
 	PromptTokens     int    `json:"prompt_tokens"`
@@ -107,6 +111,20 @@ type LLMInteraction struct {
 	// Populated for: micro_resolution, semantic_retry (step-specific calls)
 	// Empty for: plan_generation, correction, synthesis, tiered_selection (orchestrator-level)
 	StepID string `json:"step_id,omitempty"`
+
+	// SubQuestion is the part of the request a "synthesis_group" call answered
+	SubQuestion string `json:"sub_question,omitempty"`
+
+	// CapabilityFingerprint identifies the capability set a "plan_generation"
+	// prompt was built from. See CapabilityFingerprint.
+	CapabilityFingerprint string `json:"capability_fingerprint,omitempty"`
+
+	// ContentRedacted means the store kept only hashes of the prompt, system
+	// prompt and response (see WithDebugContentHashOnly)
+	ContentRedacted  bool   `json:"content_redacted,omitempty"`
+	PromptHash       string `json:"prompt_hash,omitempty"`
+	SystemPromptHash string `json:"system_prompt_hash,omitempty"`
+	ResponseHash     string `json:"response_hash,omitempty"`
 }
 
 // LLMDebugRecordSummary is a lightweight version for listing.
@@ -139,6 +157,14 @@ type LLMDebugConfig struct {
 	// RedisDB is the Redis database number for storage.
 	// Default: 7 (core.RedisDBLLMDebug). Override via GOMIND_LLM_DEBUG_REDIS_DB
 	RedisDB int `json:"redis_db"`
+
+	// Redact masks e-mail addresses, SSNs and card numbers in stored prompts
+	// and responses. Default: false. Enable via GOMIND_LLM_DEBUG_REDACT=true
+	Redact bool `json:"redact"`
+
+	// HashOnly stores token counts and content hashes instead of prompt and
+	// response text. Default: false. Enable via GOMIND_LLM_DEBUG_CONTENT=hash
+	HashOnly bool `json:"hash_only"`
 }
 
 // DefaultLLMDebugConfig returns the default configuration for LLM debug storage.
@@ -3,10 +3,12 @@ package orchestration
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/itsneelabh/gomind/core"
 )
 
@@ -270,6 +272,118 @@ func TestMemoryLLMDebugStore_ConcurrentAccess(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Redaction Tests
+// =============================================================================
+
+func TestDefaultLLMDebugRedactor(t *testing.T) {
+	redact := DefaultLLMDebugRedactor()
+	input := "Customer jane.doe@example.com, SSN 123-45-6789, card 4111 1111 1111 1111, order 42"
+	got := redact(input)
+
+	for _, secret := range []string{"jane.doe@example.com", "123-45-6789", "4111 1111 1111 1111"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("Expected %q to be redacted, got %q", secret, got)
+		}
+	}
+	if strings.Count(got, RedactedPlaceholder) != 3 || !strings.Contains(got, "order 42") {
+		t.Errorf("Expected three placeholders and the rest untouched, got %q", got)
+	}
+}
+
+func TestRedactingLLMDebugStore(t *testing.T) {
+	memory := NewMemoryLLMDebugStore()
+	if NewRedactingLLMDebugStore(memory, nil, false) != LLMDebugStore(memory) {
+		t.Error("Expected the store unchanged without redaction settings")
+	}
+
+	store := NewRedactingLLMDebugStore(memory, DefaultLLMDebugRedactor(), false)
+	err := store.RecordInteraction(context.Background(), "req-mem", LLMInteraction{
+		Type:     "plan_generation",
+		Prompt:   "Refund jane.doe@example.com, card 4111 1111 1111 1111",
+		Response: "ok",
+	})
+	if err != nil {
+		t.Fatalf("RecordInteraction failed: %v", err)
+	}
+	record, err := memory.GetRecord(context.Background(), "req-mem")
+	if err != nil {
+		t.Fatalf("GetRecord failed: %v", err)
+	}
+	if got := record.Interactions[0].Prompt; got != "Refund [REDACTED], card [REDACTED]" {
+		t.Errorf("Expected the memory store to receive redacted content, got %q", got)
+	}
+
+	hashed := NewRedactingLLMDebugStore(NewMemoryLLMDebugStore(), nil, true)
+	_ = hashed.RecordInteraction(context.Background(), "req-hash", LLMInteraction{Prompt: "secret", PromptTokens: 3})
+	record, _ = hashed.GetRecord(context.Background(), "req-hash")
+	if stored := record.Interactions[0]; stored.Prompt != "" || stored.PromptHash == "" || !stored.ContentRedacted {
+		t.Errorf("Expected only the hash to be kept, got %+v", stored)
+	}
+}
+
+func TestRedisLLMDebugStore_Redaction(t *testing.T) {
+	mr := miniredis.RunT(t)
+	interaction := LLMInteraction{
+		Type:             "plan_generation",
+		Prompt:           "Refund jane.doe@example.com",
+		SystemPrompt:     "You are an orchestrator",
+		Response:         `{"email":"jane.doe@example.com"}`,
+		PromptTokens:     12,
+		CompletionTokens: 8,
+		Success:          true,
+	}
+
+	t.Run("redactor", func(t *testing.T) {
+		store, err := NewRedisLLMDebugStore(WithDebugRedisURL("redis://"+mr.Addr()), WithDebugRedactor(DefaultLLMDebugRedactor()))
+		if err != nil {
+			t.Fatalf("NewRedisLLMDebugStore failed: %v", err)
+		}
+		defer store.Close()
+
+		if err := store.RecordInteraction(context.Background(), "req-redact", interaction); err != nil {
+			t.Fatalf("RecordInteraction failed: %v", err)
+		}
+		record, err := store.GetRecord(context.Background(), "req-redact")
+		if err != nil {
+			t.Fatalf("GetRecord failed: %v", err)
+		}
+		stored := record.Interactions[0]
+		if stored.Prompt != "Refund "+RedactedPlaceholder || strings.Contains(stored.Response, "jane.doe") {
+			t.Errorf("Expected redacted content, got prompt %q response %q", stored.Prompt, stored.Response)
+		}
+		if stored.SystemPrompt != interaction.SystemPrompt {
+			t.Errorf("Expected text without PII untouched, got %q", stored.SystemPrompt)
+		}
+	})
+
+	t.Run("hash only", func(t *testing.T) {
+		store, err := NewRedisLLMDebugStore(WithDebugRedisURL("redis://"+mr.Addr()), WithDebugContentHashOnly())
+		if err != nil {
+			t.Fatalf("NewRedisLLMDebugStore failed: %v", err)
+		}
+		defer store.Close()
+
+		if err := store.RecordInteraction(context.Background(), "req-hash", interaction); err != nil {
+			t.Fatalf("RecordInteraction failed: %v", err)
+		}
+		record, err := store.GetRecord(context.Background(), "req-hash")
+		if err != nil {
+			t.Fatalf("GetRecord failed: %v", err)
+		}
+		stored := record.Interactions[0]
+		if !stored.ContentRedacted || stored.Prompt != "" || stored.SystemPrompt != "" || stored.Response != "" {
+			t.Errorf("Expected content dropped, got %+v", stored)
+		}
+		if stored.PromptHash != contentHash(interaction.Prompt) || len(stored.ResponseHash) != 64 {
+			t.Errorf("Expected SHA-256 content hashes, got %q / %q", stored.PromptHash, stored.ResponseHash)
+		}
+		if stored.PromptTokens != 12 || stored.CompletionTokens != 8 {
+			t.Error("Expected token counts to be kept")
+		}
+	})
+}
+
 // =============================================================================
 // NoOpLLMDebugStore Tests
 // =============================================================================
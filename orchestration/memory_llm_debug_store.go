@@ -11,7 +11,8 @@ import (
 )
 
 // MemoryLLMDebugStore is an in-memory implementation for testing and development.
-// Not suitable for production use as data is lost on restart.
+// Not suitable for production use as data is lost on restart. Wrap it with
+// NewRedactingLLMDebugStore to redact what it keeps.
 type MemoryLLMDebugStore struct {
 	mu      sync.RWMutex
 	records map[string]*LLMDebugRecord
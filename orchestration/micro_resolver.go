@@ -84,7 +84,8 @@ func NewMicroResolver(aiClient core.AIClient, logger core.Logger) *MicroResolver
 
 // ResolveParameters extracts parameters from source data for a target capability.
 // If function calling is available, uses it for guaranteed type safety.
-// Otherwise, falls back to text-based extraction with JSON parsing.
+// Otherwise, offers the parameters as a tool via AIOptions.Tools and falls
+// back to JSON parsing of the text when the provider returns no tool call.
 //
 // The stepID parameter associates any LLM calls with the execution step for
 // DAG visualization. Pass empty string if not step-specific.
@@ -226,12 +227,25 @@ RESPONSE FORMAT (JSON only):
 		requestID = m.generateFallbackRequestID()
 	}
 
-	// Make the LLM call
-	llmStartTime := time.Now()
-	resp, err := m.aiClient.GenerateResponse(ctx, prompt, &core.AIOptions{
+	// Offer the parameters as a tool so providers with tool support return
+	// structured arguments instead of free text. A capability that declares
+	// no parameters gets no tool, since there is nothing to provide.
+	options := &core.AIOptions{
 		Temperature: 0.0, // Deterministic for extraction
 		MaxTokens:   500,
-	})
+	}
+	tool := core.ToolDefinition{
+		Name:        "provide_parameters",
+		Description: fmt.Sprintf("Provide parameters for %s", targetCapability.Name),
+		Parameters:  m.buildParameterSchema(targetCapability),
+	}
+	if len(targetCapability.Parameters) > 0 {
+		options.Tools = []core.ToolDefinition{tool}
+	}
+
+	// Make the LLM call
+	llmStartTime := time.Now()
+	resp, err := m.aiClient.GenerateResponse(ctx, prompt, options)
 	llmDuration := time.Since(llmStartTime)
 
 	if err != nil {
@@ -276,6 +290,7 @@ RESPONSE FORMAT (JSON only):
 		MaxTokens:        500,
 		Model:            resp.Model,
 		Provider:         resp.Provider,
+		IdempotencyKey:   resp.IdempotencyKey,
 		Response:         resp.Content,
 		PromptTokens:     resp.Usage.PromptTokens,
 		CompletionTokens: resp.Usage.CompletionTokens,
@@ -285,21 +300,32 @@ RESPONSE FORMAT (JSON only):
 		StepID:           stepID,
 	})
 
-	// Parse the JSON response
-	content := strings.TrimSpace(resp.Content)
-	// Remove markdown code blocks if present
-	content = strings.TrimPrefix(content, "```json")
-	content = strings.TrimPrefix(content, "```")
-	content = strings.TrimSuffix(content, "```")
-	content = strings.TrimSpace(content)
-
 	var result map[string]interface{}
-	if err := json.Unmarshal([]byte(content), &result); err != nil {
-		m.logWarn("Failed to parse micro-resolution response", map[string]interface{}{
-			"error":    err.Error(),
-			"response": content,
-		})
-		return nil, fmt.Errorf("failed to parse LLM response as JSON: %w", err)
+	method := "text extraction"
+	for _, call := range resp.ToolCalls {
+		if len(options.Tools) > 0 && call.Name == tool.Name {
+			result = call.Arguments
+			method = "tool call"
+			break
+		}
+	}
+
+	if result == nil {
+		// Parse the JSON response
+		content := strings.TrimSpace(resp.Content)
+		// Remove markdown code blocks if present
+		content = strings.TrimPrefix(content, "```json")
+		content = strings.TrimPrefix(content, "```")
+		content = strings.TrimSuffix(content, "```")
+		content = strings.TrimSpace(content)
+
+		if err := json.Unmarshal([]byte(content), &result); err != nil {
+			m.logWarn("Failed to parse micro-resolution response", map[string]interface{}{
+				"error":    err.Error(),
+				"response": content,
+			})
+			return nil, fmt.Errorf("failed to parse LLM response as JSON: %w", err)
+		}
 	}
 
 	// Apply type coercion to match target types
@@ -309,7 +335,7 @@ RESPONSE FORMAT (JSON only):
 		}
 	}
 
-	m.logInfo("Micro-resolution completed via text extraction", map[string]interface{}{
+	m.logInfo("Micro-resolution completed via "+method, map[string]interface{}{
 		"capability":      targetCapability.Name,
 		"resolved_params": result,
 	})
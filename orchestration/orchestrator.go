@@ -185,6 +185,9 @@ type PlanningPromptResult struct {
 	// AllowedAgents contains agent names that were included in the prompt.
 	// Used to validate that the LLM didn't hallucinate non-existent agents.
 	AllowedAgents map[string]bool
+	// CapabilityFingerprint identifies the capability set in the prompt.
+	// See CapabilityFingerprint.
+	CapabilityFingerprint string
 }
 
 // HallucinationContext captures context about a hallucinated agent for enhanced retry.
@@ -309,8 +312,9 @@ func (o *AIOrchestrator) validatePlanAgainstAllowedAgents(ctx context.Context, p
 			// This handles the case where tiered selection missed a tool that the LLM
 			// correctly identified as needed for the task.
 			if o.catalog != nil {
-				// Get all agents from the catalog and check if this agent exists
-				agents := o.catalog.GetAgents()
+				// Get all agents from the catalog and check if this agent exists.
+				// Agents outside the request's DiscoveryFilter stay disallowed.
+				agents := o.catalog.Scoped(GetDiscoveryFilter(ctx)).GetAgents()
 
 				// Diagnostic logging: what agents are in the catalog?
 				if o.logger != nil {
@@ -427,6 +431,8 @@ type AIOrchestrator struct {
 	debugWg sync.WaitGroup
 	// debugSeqID provides fallback correlation IDs when TraceID is not available
 	debugSeqID atomic.Uint64
+	// debugRedactor, when set, scrubs prompts and responses before they are stored
+	debugRedactor LLMDebugRedactor
 
 	// Execution Store for DAG visualization
 	// When enabled, stores plan + execution results for debugging
@@ -451,6 +457,17 @@ type AIOrchestrator struct {
 	// HITL (Human-in-the-Loop) support
 	// When set, enables human oversight at plan/step execution points
 	interruptController InterruptController
+
+	// Domain policy validation applied after structural plan validation
+	planValidator PlanValidator
+
+	// planCalls coalesces concurrent identical plan-generation LLM calls
+	planCalls planCallGroup
+
+	// executions tracks running executions for CancelExecution, and
+	// cancelBroker reaches executions on other replicas
+	executions   cancellationRegistry
+	cancelBroker CancellationBroker
 }
 
 // NewAIOrchestrator creates a new AI-powered orchestrator
@@ -480,6 +497,15 @@ func NewAIOrchestrator(config *OrchestratorConfig, discovery core.Discovery, aiC
 		telemetry: &core.NoOpTelemetry{},
 	}
 
+	if config.SynthesisStrategy != "" {
+		o.synthesizer.SetStrategy(config.SynthesisStrategy)
+	}
+
+	// Bound how many steps of one DAG level run at once
+	if config.ExecutionOptions.MaxConcurrency > 0 {
+		o.executor.SetMaxConcurrency(config.ExecutionOptions.MaxConcurrency)
+	}
+
 	// Initialize capability provider based on configuration
 	switch config.CapabilityProviderType {
 	case "service":
@@ -625,6 +651,16 @@ func (o *AIOrchestrator) SetErrorAnalyzer(analyzer *ErrorAnalyzer) {
 	}
 }
 
+// RegisterSynthesisStrategy registers a custom synthesis strategy on the
+// orchestrator's synthesizer. Select it by name with
+// OrchestratorConfig.SynthesisStrategy. See AISynthesizer.RegisterStrategy.
+func (o *AIOrchestrator) RegisterSynthesisStrategy(name SynthesisStrategy, strategy StrategySynthesizer) error {
+	if o.synthesizer == nil {
+		return fmt.Errorf("synthesizer not configured")
+	}
+	return o.synthesizer.RegisterStrategy(name, strategy)
+}
+
 // SetLLMDebugStore sets the LLM debug store for full payload visibility.
 // When configured, complete LLM prompts and responses are stored for debugging.
 // This enables operators to see exactly what was sent to and received from the LLM.
@@ -741,30 +777,10 @@ func (o *AIOrchestrator) storeExecutionAsync(
 		storeCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 		defer cancel()
 
-		// Extract trace correlation from baggage
-		traceID := ""
-		originalRequestID := requestID
-		if bag != nil {
-			if tid, ok := bag["trace_id"]; ok {
-				traceID = tid
-			}
-			if origID, ok := bag["original_request_id"]; ok && origID != "" {
-				originalRequestID = origID
-			}
-		}
-
-		stored := &StoredExecution{
-			RequestID:         requestID,
-			OriginalRequestID: originalRequestID,
-			TraceID:           traceID,
-			AgentName:         agentName,
-			OriginalRequest:   request,
-			Plan:              plan,
-			Result:            result,
-			Interrupted:       checkpoint != nil,
-			Checkpoint:        checkpoint,
-			CreatedAt:         createdAt,
-		}
+		stored := newStoredExecution(bag, agentName, request, requestID, plan, result, createdAt)
+		stored.Interrupted = checkpoint != nil
+		stored.Checkpoint = checkpoint
+		traceID := stored.TraceID
 
 		if storeErr := store.Store(storeCtx, stored); storeErr != nil {
 			if o.logger != nil {
@@ -786,6 +802,38 @@ func (o *AIOrchestrator) storeExecutionAsync(
 	}()
 }
 
+// newStoredExecution builds an execution record, taking trace correlation
+// from the request baggage
+func newStoredExecution(
+	bag map[string]string,
+	agentName, request, requestID string,
+	plan *RoutingPlan,
+	result *ExecutionResult,
+	createdAt time.Time,
+) *StoredExecution {
+	traceID := ""
+	originalRequestID := requestID
+	if bag != nil {
+		if tid, ok := bag["trace_id"]; ok {
+			traceID = tid
+		}
+		if origID, ok := bag["original_request_id"]; ok && origID != "" {
+			originalRequestID = origID
+		}
+	}
+
+	return &StoredExecution{
+		RequestID:         requestID,
+		OriginalRequestID: originalRequestID,
+		TraceID:           traceID,
+		AgentName:         agentName,
+		OriginalRequest:   request,
+		Plan:              plan,
+		Result:            result,
+		CreatedAt:         createdAt,
+	}
+}
+
 // SetInterruptController sets the HITL interrupt controller.
 // When set, enables human oversight at plan/step execution points.
 // The controller is propagated to the executor for step-level checks.
@@ -813,6 +861,79 @@ func (o *AIOrchestrator) GetInterruptController() InterruptController {
 	return o.interruptController
 }
 
+// SetPlanValidator sets the domain policy validator evaluated after plan generation.
+// Use NewPlanRules() to build common policies, or CombinePlanValidators() to mix
+// rule sets with custom implementations. Pass nil to disable policy validation.
+func (o *AIOrchestrator) SetPlanValidator(validator PlanValidator) {
+	o.planValidator = validator
+}
+
+// applyPlanValidator evaluates domain policies against a plan.
+// Returns the (possibly enriched) context, all violations for recording, and an
+// *ErrPlanRejected if any violation requires rejection. Interrupt-level violations
+// are attached to the context for the HITL plan approval check; when HITL is not
+// available they are treated as rejections so policies cannot be silently bypassed.
+func (o *AIOrchestrator) applyPlanValidator(ctx context.Context, plan *RoutingPlan, requestID string) (context.Context, []PlanViolation, error) {
+	if o.planValidator == nil || plan == nil {
+		return ctx, nil, nil
+	}
+
+	violations := o.planValidator.Validate(plan)
+	if len(violations) == 0 {
+		return ctx, nil, nil
+	}
+
+	for _, v := range violations {
+		telemetry.Counter("plan_validation.violations",
+			"rule", v.Rule,
+			"action", string(v.Action),
+			"module", telemetry.ModuleOrchestration,
+		)
+		telemetry.AddSpanEvent(ctx, "plan_validation.violation",
+			attribute.String("rule", v.Rule),
+			attribute.String("step_id", v.StepID),
+			attribute.String("action", string(v.Action)),
+		)
+		if o.logger != nil {
+			o.logger.WarnWithContext(ctx, "Plan policy violation", map[string]interface{}{
+				"operation":  "plan_policy_validation",
+				"request_id": requestID,
+				"plan_id":    plan.PlanID,
+				"rule":       v.Rule,
+				"step_id":    v.StepID,
+				"action":     string(v.Action),
+				"message":    v.Message,
+			})
+		}
+	}
+
+	rejected := filterViolations(violations, PlanViolationReject)
+	interrupts := filterViolations(violations, PlanViolationInterrupt)
+	if len(interrupts) > 0 {
+		if o.config.HITL.Enabled && o.interruptController != nil {
+			ctx = WithPlanViolations(ctx, interrupts)
+		} else {
+			rejected = append(rejected, interrupts...)
+		}
+	}
+
+	if len(rejected) > 0 {
+		return ctx, violations, &ErrPlanRejected{PlanID: plan.PlanID, Violations: rejected}
+	}
+	return ctx, violations, nil
+}
+
+// recordPlanViolations stores policy violations in the execution metadata
+func recordPlanViolations(result *ExecutionResult, violations []PlanViolation) {
+	if result == nil || len(violations) == 0 {
+		return
+	}
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]interface{})
+	}
+	result.Metadata["plan_violations"] = violations
+}
+
 // recordDebugInteraction stores an LLM interaction for debugging.
 // Runs asynchronously to avoid blocking orchestration. Errors are logged, not propagated.
 // Uses WaitGroup to track in-flight recordings for graceful shutdown.
@@ -821,6 +942,7 @@ func (o *AIOrchestrator) recordDebugInteraction(ctx context.Context, requestID s
 	if o.debugStore == nil {
 		return
 	}
+	interaction = o.redactInteraction(interaction)
 
 	// Extract baggage BEFORE spawning goroutine to preserve correlation data.
 	// This is needed because the parent context may be canceled after the HTTP
@@ -983,6 +1105,11 @@ Respond with ONLY the corrected JSON parameters object. No explanation, no markd
 		requestID = o.generateFallbackRequestID()
 	}
 
+	attempt := GetCorrectionAttempt(ctx)
+	if attempt < 1 {
+		attempt = 1
+	}
+
 	// Call LLM for correction
 	llmStartTime := time.Now()
 	response, err := o.aiClient.GenerateResponse(ctx, correctionPrompt, nil)
@@ -997,7 +1124,7 @@ Respond with ONLY the corrected JSON parameters object. No explanation, no markd
 			Prompt:     correctionPrompt,
 			Success:    false,
 			Error:      err.Error(),
-			Attempt:    1,
+			Attempt:    attempt,
 		})
 		return nil, fmt.Errorf("LLM correction request failed: %w", err)
 	}
@@ -1011,11 +1138,12 @@ Respond with ONLY the corrected JSON parameters object. No explanation, no markd
 		Response:         response.Content,
 		Model:            response.Model,
 		Provider:         response.Provider,
+		IdempotencyKey:   response.IdempotencyKey,
 		PromptTokens:     response.Usage.PromptTokens,
 		CompletionTokens: response.Usage.CompletionTokens,
 		TotalTokens:      response.Usage.TotalTokens,
 		Success:          true,
-		Attempt:          1,
+		Attempt:          attempt,
 	})
 
 	// Extract JSON from response (handle potential markdown wrapping)
@@ -1107,6 +1235,10 @@ func (o *AIOrchestrator) ProcessRequest(ctx context.Context, request string, met
 	// This preserves session_id, user_id, etc. when creating checkpoints
 	ctx = WithMetadata(ctx, metadata)
 
+	// Make the request cancellable with CancelExecution
+	ctx, untrack := o.executions.track(ctx, requestID)
+	defer untrack()
+
 	// CRITICAL: Add request_id to the PARENT span (HTTP span) for trace searchability
 	// This must be done BEFORE creating the child orchestrator span, while the HTTP span
 	// is still the current span in context. This enables searching by request_id in distributed
@@ -1228,7 +1360,7 @@ func (o *AIOrchestrator) ProcessRequest(ctx context.Context, request string, met
 	}
 
 	// Step 2: Validate the plan
-	if err := o.validatePlan(plan); err != nil {
+	if err := o.validatePlanInScope(ctx, plan); err != nil {
 		// Try to regenerate with error feedback
 		plan, err = o.regeneratePlan(ctx, request, requestID, err)
 		if err != nil {
@@ -1237,6 +1369,24 @@ func (o *AIOrchestrator) ProcessRequest(ctx context.Context, request string, met
 		}
 	}
 
+	// Step 2.1: Apply domain policies (PlanValidator)
+	ctx, planViolations, err := o.applyPlanValidator(ctx, plan, requestID)
+	if err != nil {
+		if span != nil {
+			span.RecordError(err)
+		}
+		o.updateMetrics(time.Since(startTime), false)
+		return nil, err
+	}
+
+	// Dry run: return the validated plan before any approval or execution
+	if IsDryRun(ctx) {
+		if span != nil {
+			span.SetAttribute("dry_run", true)
+		}
+		return o.dryRunResponse(ctx, request, requestID, plan, planViolations, metadata, startTime), nil
+	}
+
 	// Step 2.5: HITL Plan Approval Check
 	// If HITL is enabled and interrupt controller is set, check if plan needs approval
 	if o.config.HITL.Enabled && o.interruptController != nil {
@@ -1285,8 +1435,12 @@ func (o *AIOrchestrator) ProcessRequest(ctx context.Context, request string, met
 		}
 	}
 
-	// Step 3: Execute the plan
+	// Step 3: Execute the plan, checkpointing progress as steps complete
+	ctx, waitForCheckpoints := o.withExecutionProgress(ctx, request, requestID, plan)
 	result, err := o.executor.Execute(ctx, plan)
+	waitForCheckpoints()
+	recordPlanViolations(result, planViolations)
+	recordContractViolations(result)
 
 	if err != nil {
 		// Check for step-level HITL interrupt - propagate directly without wrapping
@@ -1450,6 +1604,10 @@ func (o *AIOrchestrator) ProcessRequestStreaming(
 	// This preserves session_id, user_id, etc. when creating checkpoints
 	ctx = WithMetadata(ctx, metadata)
 
+	// Make the request cancellable with CancelExecution
+	ctx, untrack := o.executions.track(ctx, requestID)
+	defer untrack()
+
 	// CRITICAL: Add request_id to the PARENT span (HTTP span) for trace searchability
 	// This must be done BEFORE creating the child orchestrator span, while the HTTP span
 	// is still the current span in context. This enables searching by request_id in distributed
@@ -1497,6 +1655,9 @@ func (o *AIOrchestrator) ProcessRequestStreaming(
 		if err != nil {
 			return nil, err
 		}
+		if response.DryRun {
+			return &StreamingOrchestratorResponse{OrchestratorResponse: *response}, nil
+		}
 
 		// Simulate streaming by chunking the response
 		chunkSize := 50
@@ -1581,7 +1742,7 @@ func (o *AIOrchestrator) ProcessRequestStreaming(
 		}
 
 		// Validate the plan (same as ProcessRequest)
-		if err := o.validatePlan(plan); err != nil {
+		if err := o.validatePlanInScope(ctx, plan); err != nil {
 			// Try to regenerate with error feedback
 			plan, err = o.regeneratePlan(ctx, request, requestID, err)
 			if err != nil {
@@ -1597,6 +1758,21 @@ func (o *AIOrchestrator) ProcessRequestStreaming(
 		}
 	}
 
+	// Apply domain policies (PlanValidator) - same as ProcessRequest
+	ctx, planViolations, err := o.applyPlanValidator(ctx, plan, requestID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Dry run - same as ProcessRequest; nothing is streamed
+	if IsDryRun(ctx) {
+		span.SetAttribute("dry_run", true)
+		return &StreamingOrchestratorResponse{
+			OrchestratorResponse: *o.dryRunResponse(ctx, request, requestID, plan, planViolations, metadata, startTime),
+		}, nil
+	}
+
 	// HITL Plan Approval Check (streaming mode)
 	// Mirror of ProcessRequest HITL check - ensures streaming requests also respect human oversight
 	if o.config.HITL.Enabled && o.interruptController != nil {
@@ -1640,8 +1816,12 @@ func (o *AIOrchestrator) ProcessRequestStreaming(
 		}
 	}
 
-	// Execute the plan
+	// Execute the plan, checkpointing progress as steps complete
+	ctx, waitForCheckpoints := o.withExecutionProgress(ctx, request, requestID, plan)
 	result, err := o.executor.Execute(ctx, plan)
+	waitForCheckpoints()
+	recordPlanViolations(result, planViolations)
+	recordContractViolations(result)
 
 	if err != nil {
 		// Check for step-level HITL interrupt - propagate directly without wrapping
@@ -1736,6 +1916,7 @@ func (o *AIOrchestrator) ProcessRequestStreaming(
 				MaxTokens:        2000,
 				Model:            aiResponse.Model,
 				Provider:         aiResponse.Provider,
+				IdempotencyKey:   aiResponse.IdempotencyKey,
 				Response:         aiResponse.Content,
 				PromptTokens:     aiResponse.Usage.PromptTokens,     // May be partial
 				CompletionTokens: aiResponse.Usage.CompletionTokens, // May be partial
@@ -1821,6 +2002,7 @@ func (o *AIOrchestrator) ProcessRequestStreaming(
 		MaxTokens:        2000,
 		Model:            aiResponse.Model,
 		Provider:         aiResponse.Provider,
+		IdempotencyKey:   aiResponse.IdempotencyKey,
 		Response:         aiResponse.Content,
 		PromptTokens:     aiResponse.Usage.PromptTokens,
 		CompletionTokens: aiResponse.Usage.CompletionTokens,
@@ -1914,13 +2096,39 @@ func (o *AIOrchestrator) generateExecutionPlan(ctx context.Context, request stri
 			attribute.Int("attempt", attempt),
 		)
 
-		// Call LLM
+		// Call LLM. Concurrent identical requests share the first attempt's
+		// call; parse retries use a request-specific prompt.
 		llmStartTime := time.Now()
-		aiResponse, err := o.aiClient.GenerateResponse(ctx, promptResult.Prompt, &core.AIOptions{
-			Temperature:  0.3, // Lower temperature for more deterministic planning
-			MaxTokens:    2000,
-			SystemPrompt: "You are an intelligent orchestrator that creates execution plans for multi-agent systems.",
-		})
+		generate := func(ctx context.Context) (*core.AIResponse, error) {
+			return o.aiClient.GenerateResponse(ctx, promptResult.Prompt, &core.AIOptions{
+				Temperature:  0.3, // Lower temperature for more deterministic planning
+				MaxTokens:    2000,
+				SystemPrompt: planGenerationSystemPrompt,
+			})
+		}
+		var aiResponse *core.AIResponse
+		if attempt == 1 && o.config != nil && o.config.PlanCoalescingEnabled {
+			var shared bool
+			key := planCoalescingKey(promptResult.Prompt, planGenerationSystemPrompt)
+			aiResponse, shared, err = o.planCalls.do(ctx, key, generate)
+			if shared {
+				telemetry.AddSpanEvent(ctx, "llm.plan_generation.coalesced",
+					attribute.String("request_id", requestID),
+					attribute.String("capability_fingerprint", promptResult.CapabilityFingerprint),
+				)
+				telemetry.Counter("plan_generation.coalesced",
+					"module", telemetry.ModuleOrchestration)
+				if o.logger != nil {
+					o.logger.DebugWithContext(ctx, "Joined in-flight plan generation for identical request", map[string]interface{}{
+						"operation":              "plan_coalescing",
+						"request_id":             requestID,
+						"capability_fingerprint": promptResult.CapabilityFingerprint,
+					})
+				}
+			}
+		} else {
+			aiResponse, err = generate(ctx)
+		}
 		llmDuration := time.Since(llmStartTime)
 
 		if err != nil {
@@ -1941,16 +2149,17 @@ func (o *AIOrchestrator) generateExecutionPlan(ctx context.Context, request stri
 
 			// LLM Debug: Record failed interaction (includes prompt for debugging)
 			o.recordDebugInteraction(ctx, requestID, LLMInteraction{
-				Type:         "plan_generation",
-				Timestamp:    llmStartTime,
-				DurationMs:   llmDuration.Milliseconds(),
-				Prompt:       promptResult.Prompt,
-				SystemPrompt: "You are an intelligent orchestrator that creates execution plans for multi-agent systems.",
-				Temperature:  0.3,
-				MaxTokens:    2000,
-				Success:      false,
-				Error:        err.Error(),
-				Attempt:      attempt,
+				Type:                  "plan_generation",
+				Timestamp:             llmStartTime,
+				DurationMs:            llmDuration.Milliseconds(),
+				Prompt:                promptResult.Prompt,
+				SystemPrompt:          planGenerationSystemPrompt,
+				Temperature:           0.3,
+				MaxTokens:             2000,
+				CapabilityFingerprint: promptResult.CapabilityFingerprint,
+				Success:               false,
+				Error:                 err.Error(),
+				Attempt:               attempt,
 			})
 
 			return nil, err
@@ -1985,17 +2194,20 @@ func (o *AIOrchestrator) generateExecutionPlan(ctx context.Context, request stri
 			Timestamp:        llmStartTime,
 			DurationMs:       llmDuration.Milliseconds(),
 			Prompt:           promptResult.Prompt,
-			SystemPrompt:     "You are an intelligent orchestrator that creates execution plans for multi-agent systems.",
+			SystemPrompt:     planGenerationSystemPrompt,
 			Temperature:      0.3,
 			MaxTokens:        2000,
 			Model:            aiResponse.Model,
 			Provider:         aiResponse.Provider,
+			IdempotencyKey:   aiResponse.IdempotencyKey,
 			Response:         aiResponse.Content,
 			PromptTokens:     aiResponse.Usage.PromptTokens,
 			CompletionTokens: aiResponse.Usage.CompletionTokens,
 			TotalTokens:      aiResponse.Usage.TotalTokens,
 			Success:          true,
 			Attempt:          attempt,
+
+			CapabilityFingerprint: promptResult.CapabilityFingerprint,
 		})
 
 		if o.logger != nil {
@@ -2214,16 +2426,16 @@ STRICT RULES FOR THIS RETRY:
 
 						// LLM Debug: Record failed hallucination retry plan generation
 						o.recordDebugInteraction(ctx, requestID, LLMInteraction{
-							Type:         "plan_generation",
-							Timestamp:    retryLLMStartTime,
-							DurationMs:   retryLLMDuration.Milliseconds(),
-							Prompt:       hallucinationFeedback,
-							SystemPrompt: "You are an intelligent orchestrator that creates execution plans for multi-agent systems.",
-							Temperature:  0.2,
-							MaxTokens:    2000,
-							Success:      false,
-							Error:        fmt.Sprintf("hallucination_retry (attempt %d): %s", hallRetry+1, retryErr.Error()),
-							Attempt:      attempt, // Keep original attempt, error indicates it's a hallucination retry
+							Type:                  "plan_generation",
+							Timestamp:             retryLLMStartTime,
+							DurationMs:            retryLLMDuration.Milliseconds(),
+							Prompt:                hallucinationFeedback,
+							Temperature:           0.2,
+							MaxTokens:             2000,
+							CapabilityFingerprint: retryPromptResult.CapabilityFingerprint,
+							Success:               false,
+							Error:                 fmt.Sprintf("hallucination_retry (attempt %d): %s", hallRetry+1, retryErr.Error()),
+							Attempt:               attempt, // Keep original attempt, error indicates it's a hallucination retry
 						})
 
 						return nil, fmt.Errorf("plan regeneration failed: %w", retryErr)
@@ -2235,17 +2447,19 @@ STRICT RULES FOR THIS RETRY:
 						Timestamp:        retryLLMStartTime,
 						DurationMs:       retryLLMDuration.Milliseconds(),
 						Prompt:           hallucinationFeedback,
-						SystemPrompt:     "You are an intelligent orchestrator that creates execution plans for multi-agent systems.",
 						Temperature:      0.2,
 						MaxTokens:        2000,
 						Model:            retryResponse.Model,
 						Provider:         retryResponse.Provider,
+						IdempotencyKey:   retryResponse.IdempotencyKey,
 						Response:         retryResponse.Content,
 						PromptTokens:     retryResponse.Usage.PromptTokens,
 						CompletionTokens: retryResponse.Usage.CompletionTokens,
 						TotalTokens:      retryResponse.Usage.TotalTokens,
 						Success:          true,
 						Attempt:          attempt, // Original attempt number; hallRetry context in prompt
+
+						CapabilityFingerprint: retryPromptResult.CapabilityFingerprint,
 					})
 
 					// Parse the retry response
@@ -2505,8 +2719,9 @@ func (o *AIOrchestrator) buildPlanningPrompt(ctx context.Context, request string
 		} else {
 			telemetry.SetSpanAttributes(ctx, attribute.Bool("prompt_builder_used", true))
 			return &PlanningPromptResult{
-				Prompt:        prompt,
-				AllowedAgents: allowedAgents,
+				Prompt:                prompt,
+				AllowedAgents:         allowedAgents,
+				CapabilityFingerprint: CapabilityFingerprint(capabilityResult.FormattedInfo),
 			}, nil
 		}
 	}
@@ -2571,8 +2786,9 @@ CRITICAL FORMAT RULES:
 Response (JSON only):`, capabilityResult.FormattedInfo, request)
 
 	return &PlanningPromptResult{
-		Prompt:        prompt,
-		AllowedAgents: allowedAgents,
+		Prompt:                prompt,
+		AllowedAgents:         allowedAgents,
+		CapabilityFingerprint: CapabilityFingerprint(capabilityResult.FormattedInfo),
 	}, nil
 }
 
@@ -2606,8 +2822,9 @@ Please regenerate a VALID JSON execution plan. Start with { and end with }.`,
 
 	// Insert error feedback before the base prompt's final instruction
 	return &PlanningPromptResult{
-		Prompt:        errorFeedback + "\n\n" + basePromptResult.Prompt,
-		AllowedAgents: basePromptResult.AllowedAgents, // Preserve for hallucination validation
+		Prompt:                errorFeedback + "\n\n" + basePromptResult.Prompt,
+		AllowedAgents:         basePromptResult.AllowedAgents, // Preserve for hallucination validation
+		CapabilityFingerprint: basePromptResult.CapabilityFingerprint,
 	}, nil
 }
 
@@ -2755,6 +2972,11 @@ func (o *AIOrchestrator) validatePlan(plan *RoutingPlan) error {
 		}
 	}
 
+	// Check step conditions parse and only reference their dependencies
+	if _, err := compilePlanConditions(plan); err != nil {
+		return err
+	}
+
 	if o.logger != nil {
 		o.logger.Info("Plan validation successful", map[string]interface{}{
 			"operation":  "plan_validation",
@@ -2767,6 +2989,15 @@ func (o *AIOrchestrator) validatePlan(plan *RoutingPlan) error {
 	return nil
 }
 
+// validatePlanInScope runs validatePlan and then rejects steps outside the
+// request's DiscoveryFilter
+func (o *AIOrchestrator) validatePlanInScope(ctx context.Context, plan *RoutingPlan) error {
+	if err := o.validatePlan(plan); err != nil {
+		return err
+	}
+	return o.checkDiscoveryScope(ctx, plan)
+}
+
 // regeneratePlan attempts to fix a plan based on validation errors
 func (o *AIOrchestrator) regeneratePlan(ctx context.Context, request string, requestID string, validationErr error) (*RoutingPlan, error) {
 	// Check if AI client is available
@@ -2797,7 +3028,14 @@ Please generate a corrected plan that addresses this error.`,
 		return nil, err
 	}
 
-	return o.parsePlan(aiResponse.Content)
+	plan, err := o.parsePlan(aiResponse.Content)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.checkDiscoveryScope(ctx, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
 }
 
 // extractAgentsFromPlan gets list of agents involved in a plan
@@ -2883,6 +3121,10 @@ func (o *AIOrchestrator) ExecutePlanWithSynthesis(
 	// Add request_id to context for GetRequestID() - used by HITL controller
 	ctx = WithRequestID(ctx, requestID)
 
+	// Make the request cancellable with CancelExecution
+	ctx, untrack := o.executions.track(ctx, requestID)
+	defer untrack()
+
 	// Start telemetry span if telemetry is available (nil-safe per FRAMEWORK_DESIGN_PRINCIPLES.md)
 	var span core.Span
 	if o.telemetry != nil {
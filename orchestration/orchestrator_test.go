@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/itsneelabh/gomind/core"
+	"github.com/itsneelabh/gomind/telemetry"
 )
 
 // findJSONEnd finds the end of JSON in a string (simple version, doesn't handle strings).
@@ -580,6 +582,45 @@ func TestRequestParameterCorrectionNoAIClient(t *testing.T) {
 	}
 }
 
+// TestRequestParameterCorrectionRecordsAttempt verifies the correction attempt
+// number from the executor is recorded in the LLM debug store
+func TestRequestParameterCorrectionRecordsAttempt(t *testing.T) {
+	orchestrator := NewAIOrchestrator(DefaultConfig(), nil, &mockAIClientForCorrection{
+		response: `{"lat": 35.6897}`,
+	})
+	debugStore := NewMemoryLLMDebugStore()
+	orchestrator.SetLLMDebugStore(debugStore)
+
+	ctx := telemetry.WithBaggage(context.Background(), "request_id", "req-correction")
+	step := RoutingStep{StepID: "test", Metadata: map[string]interface{}{"capability": "get_weather"}}
+	params := map[string]interface{}{"lat": "35.6897"}
+	for attempt := 1; attempt <= 2; attempt++ {
+		if _, err := orchestrator.requestParameterCorrection(withCorrectionAttempt(ctx, attempt), step, params, "type error", nil); err != nil {
+			t.Fatalf("requestParameterCorrection failed: %v", err)
+		}
+	}
+
+	if err := orchestrator.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	record, err := debugStore.GetRecord(context.Background(), "req-correction")
+	if err != nil || record == nil {
+		t.Fatalf("Expected debug record, got %v (err %v)", record, err)
+	}
+	if len(record.Interactions) != 2 {
+		t.Fatalf("Expected 2 interactions, got %d", len(record.Interactions))
+	}
+	// Recording is asynchronous, so the interactions may arrive in any order
+	interactions := append([]LLMInteraction(nil), record.Interactions...)
+	sort.Slice(interactions, func(i, j int) bool { return interactions[i].Attempt < interactions[j].Attempt })
+	for i, interaction := range interactions {
+		if interaction.Type != "correction" || interaction.Attempt != i+1 {
+			t.Errorf("Interaction %d: expected correction attempt %d, got %s attempt %d",
+				i, i+1, interaction.Type, interaction.Attempt)
+		}
+	}
+}
+
 // TestRequestParameterCorrectionInvalidJSON tests error handling for invalid LLM response
 func TestRequestParameterCorrectionInvalidJSON(t *testing.T) {
 	mockAIClient := &mockAIClientForCorrection{
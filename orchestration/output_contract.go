@@ -0,0 +1,223 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/itsneelabh/gomind/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// OutputContractViolation describes one way a step response differs from the
+// output contract declared in capability metadata (OutputTypes/OutputSummary)
+type OutputContractViolation struct {
+	Field    string `json:"field,omitempty"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Message  string `json:"message"`
+}
+
+// ValidateOutputContract checks a raw response against a capability's declared
+// output contract. It returns the response decoded as JSON when possible (nil
+// for non-JSON responses) and any contract violations found.
+//
+// Rules:
+//   - OutputTypes containing "json" (or "application/json", "object", "array")
+//     require the response to be valid JSON
+//   - OutputSummary required fields must be present in the top-level object
+//   - Fields listed in OutputSummary must have the declared JSON type
+//
+// A nil capability or one without a declared contract never produces violations.
+func ValidateOutputContract(response string, capability *EnhancedCapability) (interface{}, []OutputContractViolation) {
+	var decoded interface{}
+	isJSON := json.Unmarshal([]byte(response), &decoded) == nil
+	if !isJSON {
+		decoded = nil
+	}
+
+	if capability == nil {
+		return decoded, nil
+	}
+
+	var violations []OutputContractViolation
+	expectsJSON := capability.OutputSummary != nil
+	for _, outputType := range capability.OutputTypes {
+		switch strings.ToLower(strings.TrimSpace(outputType)) {
+		case "json", "application/json", "object", "array":
+			expectsJSON = true
+		}
+	}
+
+	if !expectsJSON {
+		return decoded, nil
+	}
+	if !isJSON {
+		return nil, []OutputContractViolation{{
+			Expected: "json",
+			Actual:   "text",
+			Message:  "response is not valid JSON",
+		}}
+	}
+	if capability.OutputSummary == nil {
+		return decoded, nil
+	}
+
+	object, ok := decoded.(map[string]interface{})
+	if !ok {
+		return decoded, []OutputContractViolation{{
+			Expected: "object",
+			Actual:   jsonTypeOf(decoded),
+			Message:  "response is not a JSON object",
+		}}
+	}
+
+	for _, field := range capability.OutputSummary.RequiredFields {
+		value, present := object[field.Name]
+		if !present {
+			violations = append(violations, OutputContractViolation{
+				Field:    field.Name,
+				Expected: defaultString(field.Type, "present"),
+				Actual:   "missing",
+				Message:  fmt.Sprintf("required field %q is missing", field.Name),
+			})
+			continue
+		}
+		if v := checkFieldType(field.Name, field.Type, value); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	for _, field := range capability.OutputSummary.OptionalFields {
+		if value, present := object[field.Name]; present && value != nil {
+			if v := checkFieldType(field.Name, field.Type, value); v != nil {
+				violations = append(violations, *v)
+			}
+		}
+	}
+
+	return decoded, violations
+}
+
+// checkFieldType compares a decoded value to a declared FieldHint type.
+// Unknown declared types are not checked.
+func checkFieldType(name, expected string, value interface{}) *OutputContractViolation {
+	expected = strings.ToLower(strings.TrimSpace(expected))
+	actual := jsonTypeOf(value)
+
+	var ok bool
+	switch expected {
+	case "", "any":
+		return nil
+	case "string", "boolean", "object", "array":
+		ok = actual == expected
+	case "number", "float":
+		ok = actual == "number"
+	case "integer", "int":
+		n, isNumber := value.(float64)
+		ok = isNumber && n == math.Trunc(n)
+	default:
+		return nil
+	}
+	if ok {
+		return nil
+	}
+	return &OutputContractViolation{
+		Field:    name,
+		Expected: expected,
+		Actual:   actual,
+		Message:  fmt.Sprintf("field %q is %s, expected %s", name, actual, expected),
+	}
+}
+
+// jsonTypeOf names the JSON type of a value produced by encoding/json
+func jsonTypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func defaultString(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+// enforceOutputContract validates a successful step response against the
+// capability's output contract and records the outcome on the step result.
+// Violations are flagged for synthesis and debugging; they do not fail the step.
+func (e *SmartExecutor) enforceOutputContract(ctx context.Context, step RoutingStep, capability *EnhancedCapability, result *StepResult) {
+	output, violations := ValidateOutputContract(result.Response, capability)
+	result.Output = output
+	if len(violations) == 0 {
+		return
+	}
+
+	result.ContractViolated = true
+	result.ContractViolations = violations
+
+	capabilityName := ""
+	if capability != nil {
+		capabilityName = capability.Name
+	}
+
+	messages := make([]string, len(violations))
+	for i, v := range violations {
+		messages[i] = v.Message
+	}
+
+	if e.logger != nil {
+		e.logger.WarnWithContext(ctx, "Step response violates capability output contract", map[string]interface{}{
+			"operation":  "output_contract_validation",
+			"step_id":    step.StepID,
+			"agent_name": step.AgentName,
+			"capability": capabilityName,
+			"violations": messages,
+		})
+	}
+	telemetry.AddSpanEvent(ctx, "output_contract_violation",
+		attribute.String("step_id", step.StepID),
+		attribute.String("capability", capabilityName),
+		attribute.Int("violation_count", len(violations)),
+	)
+	telemetry.Counter("orchestration.step.contract_violations",
+		"capability", capabilityName,
+		"module", telemetry.ModuleOrchestration,
+	)
+}
+
+// recordContractViolations stores per-step output contract violations in the
+// execution metadata so they show up alongside the execution for debugging
+func recordContractViolations(result *ExecutionResult) {
+	if result == nil {
+		return
+	}
+	violations := make(map[string][]OutputContractViolation)
+	for _, step := range result.Steps {
+		if step.ContractViolated {
+			violations[step.StepID] = step.ContractViolations
+		}
+	}
+	if len(violations) == 0 {
+		return
+	}
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]interface{})
+	}
+	result.Metadata["contract_violations"] = violations
+}
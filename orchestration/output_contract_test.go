@@ -0,0 +1,101 @@
+package orchestration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+func TestValidateOutputContract(t *testing.T) {
+	weather := &EnhancedCapability{
+		Name:        "current_weather",
+		OutputTypes: []string{"json"},
+		OutputSummary: &core.SchemaSummary{
+			RequiredFields: []core.FieldHint{
+				{Name: "temperature", Type: "number"},
+				{Name: "condition", Type: "string"},
+			},
+			OptionalFields: []core.FieldHint{
+				{Name: "humidity", Type: "integer"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		response   string
+		capability *EnhancedCapability
+		wantFields []string
+		wantOutput bool
+	}{
+		{"valid", `{"temperature": 21.5, "condition": "sunny", "humidity": 40}`, weather, nil, true},
+		{"missing required", `{"temperature": 21.5}`, weather, []string{"condition"}, true},
+		{"wrong type", `{"temperature": "warm", "condition": "sunny"}`, weather, []string{"temperature"}, true},
+		{"non-integer optional", `{"temperature": 1, "condition": "x", "humidity": 40.5}`, weather, []string{"humidity"}, true},
+		{"not json", `It is sunny`, weather, []string{""}, false},
+		{"not an object", `[1, 2]`, weather, []string{""}, true},
+		{"text capability", `It is sunny`, &EnhancedCapability{OutputTypes: []string{"text"}}, nil, false},
+		{"no capability", `{"a": 1}`, nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, violations := ValidateOutputContract(tt.response, tt.capability)
+			if (output != nil) != tt.wantOutput {
+				t.Errorf("output = %v, want decoded: %v", output, tt.wantOutput)
+			}
+			if len(violations) != len(tt.wantFields) {
+				t.Fatalf("violations = %+v, want fields %v", violations, tt.wantFields)
+			}
+			for i, field := range tt.wantFields {
+				if violations[i].Field != field {
+					t.Errorf("violation[%d].Field = %q, want %q", i, violations[i].Field, field)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordContractViolations(t *testing.T) {
+	result := &ExecutionResult{Steps: []StepResult{
+		{StepID: "step-1", Success: true},
+		{StepID: "step-2", Success: true, ContractViolated: true,
+			ContractViolations: []OutputContractViolation{{Field: "condition", Message: "missing"}}},
+	}}
+	recordContractViolations(result)
+
+	recorded, ok := result.Metadata["contract_violations"].(map[string][]OutputContractViolation)
+	if !ok || len(recorded) != 1 || len(recorded["step-2"]) != 1 {
+		t.Errorf("Unexpected recorded violations: %+v", result.Metadata)
+	}
+
+	clean := &ExecutionResult{Steps: []StepResult{{StepID: "step-1", Success: true}}}
+	recordContractViolations(clean)
+	if clean.Metadata != nil {
+		t.Error("Expected no metadata when there are no violations")
+	}
+}
+
+func TestSynthesisPrompt_UsesTypedOutputAndContractHints(t *testing.T) {
+	s := NewAISynthesizer(nil)
+	results := &ExecutionResult{Steps: []StepResult{{
+		StepID:             "step-1",
+		AgentName:          "weather-tool",
+		Instruction:        "Get weather",
+		Response:           `{"temperature":21}`,
+		Output:             map[string]interface{}{"temperature": 21.0},
+		Success:            true,
+		ContractViolated:   true,
+		ContractViolations: []OutputContractViolation{{Field: "condition", Message: `required field "condition" is missing`}},
+	}}}
+
+	prompt := s.buildSynthesisPrompt("What's the weather?", results)
+	if !strings.Contains(prompt, `"temperature": 21`) {
+		t.Errorf("Expected formatted typed output in prompt:\n%s", prompt)
+	}
+	if !strings.Contains(prompt, "does not match the agent's declared output format") ||
+		!strings.Contains(prompt, `required field "condition" is missing`) {
+		t.Errorf("Expected contract violation hint in prompt:\n%s", prompt)
+	}
+}
@@ -0,0 +1,128 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/itsneelabh/gomind/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// synthesisGroup holds the step results that answer one sub-question
+type synthesisGroup struct {
+	subQuestion string
+	steps       []StepResult
+}
+
+// groupBySubQuestion groups step results by RoutingStep.SubQuestion in the
+// order each sub-question first appears. Steps without a sub-question are
+// shared context and are added to every group.
+func groupBySubQuestion(steps []StepResult) []synthesisGroup {
+	var groups []synthesisGroup
+	var shared []StepResult
+	index := make(map[string]int)
+
+	for _, step := range steps {
+		if step.SubQuestion == "" {
+			shared = append(shared, step)
+			continue
+		}
+		i, ok := index[step.SubQuestion]
+		if !ok {
+			i = len(groups)
+			index[step.SubQuestion] = i
+			groups = append(groups, synthesisGroup{subQuestion: step.SubQuestion})
+		}
+		groups[i].steps = append(groups[i].steps, step)
+	}
+
+	for i := range groups {
+		groups[i].steps = append(groups[i].steps, shared...)
+	}
+	return groups
+}
+
+// synthesizeInParallel answers each sub-question with its own LLM call and
+// joins the answers in plan order. Smaller prompts keep each answer focused
+// and the calls overlap, so multi-topic requests finish sooner. Falls back to
+// a single synthesis when the plan has fewer than two sub-questions or any
+// group fails.
+func (s *AISynthesizer) synthesizeInParallel(ctx context.Context, request string, results *ExecutionResult) (string, error) {
+	groups := groupBySubQuestion(results.Steps)
+	if len(groups) < 2 {
+		return s.synthesizeWithLLM(ctx, request, results)
+	}
+
+	requestID := s.synthesisRequestID(ctx)
+	startTime := time.Now()
+
+	answers := make([]string, len(groups))
+	errs := make([]error, len(groups))
+	var wg sync.WaitGroup
+	for i, group := range groups {
+		wg.Add(1)
+		go func(i int, group synthesisGroup) {
+			defer wg.Done()
+			prompt := buildGroupSynthesisPrompt(request, group)
+			answers[i], errs[i] = s.generateSynthesis(ctx, requestID, request, prompt, len(group.steps), LLMInteraction{
+				Type:        "synthesis_group",
+				SubQuestion: group.subQuestion,
+			})
+		}(i, group)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if s.logger != nil {
+			s.logger.WarnWithContext(ctx, "Sub-question synthesis failed, falling back to single synthesis", map[string]interface{}{
+				"operation":    "parallel_synthesis",
+				"sub_question": groups[i].subQuestion,
+				"error":        err.Error(),
+			})
+		}
+		return s.synthesizeWithLLM(ctx, request, results)
+	}
+
+	telemetry.AddSpanEvent(ctx, "synthesis.parallel.completed",
+		attribute.Int("group_count", len(groups)),
+		attribute.Int64("duration_ms", time.Since(startTime).Milliseconds()),
+	)
+	if s.logger != nil {
+		s.logger.InfoWithContext(ctx, "Parallel synthesis completed", map[string]interface{}{
+			"operation":   "parallel_synthesis",
+			"group_count": len(groups),
+			"duration_ms": time.Since(startTime).Milliseconds(),
+		})
+	}
+
+	for i := range answers {
+		answers[i] = strings.TrimSpace(answers[i])
+	}
+	return strings.Join(answers, "\n\n"), nil
+}
+
+// buildGroupSynthesisPrompt creates the prompt that answers one sub-question
+func buildGroupSynthesisPrompt(request string, group synthesisGroup) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("User Request: %s\n\n", request))
+	builder.WriteString(fmt.Sprintf("Answer only this part of the request: %s\n\n", group.subQuestion))
+	builder.WriteString("Agent Responses:\n\n")
+	writeStepResults(&builder, group.steps)
+
+	builder.WriteString("\nInstructions:\n")
+	builder.WriteString("1. Answer only the part of the request given above\n")
+	builder.WriteString("2. Do not mention the other parts of the request - they are answered separately\n")
+	builder.WriteString("3. Combine information from multiple agents where relevant\n")
+	builder.WriteString("4. Be concise but thorough\n")
+	builder.WriteString("5. If some agents failed, work with available information\n\n")
+	builder.WriteString("Answer:")
+
+	return builder.String()
+}
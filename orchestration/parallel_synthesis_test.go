@@ -0,0 +1,129 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// subQuestionAIClient answers group prompts with the sub-question they ask about
+type subQuestionAIClient struct {
+	mu      sync.Mutex
+	prompts []string
+	failOn  string
+}
+
+func (c *subQuestionAIClient) GenerateResponse(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+	c.mu.Lock()
+	c.prompts = append(c.prompts, prompt)
+	c.mu.Unlock()
+
+	for _, line := range strings.Split(prompt, "\n") {
+		if subQuestion, ok := strings.CutPrefix(line, "Answer only this part of the request: "); ok {
+			if subQuestion == c.failOn {
+				return nil, errors.New("provider unavailable")
+			}
+			return &core.AIResponse{Content: "Answer for " + subQuestion + "\n"}, nil
+		}
+	}
+	return &core.AIResponse{Content: "Combined answer"}, nil
+}
+
+func multiTopicResult() *ExecutionResult {
+	return &ExecutionResult{
+		Steps: []StepResult{
+			{StepID: "step-1", AgentName: "geocoder", Response: `{"city":"Tokyo"}`, Success: true, SubQuestion: "weather in Tokyo"},
+			{StepID: "step-2", AgentName: "currency", Response: `{"rate":150.2}`, Success: true, SubQuestion: "convert USD to JPY"},
+			{StepID: "step-3", AgentName: "weather", Response: `{"temp":21}`, Success: true, SubQuestion: "weather in Tokyo"},
+			{StepID: "step-4", AgentName: "clock", Response: `{"time":"09:00"}`, Success: true},
+		},
+	}
+}
+
+func TestGroupBySubQuestion(t *testing.T) {
+	groups := groupBySubQuestion(multiTopicResult().Steps)
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(groups))
+	}
+
+	stepIDs := func(group synthesisGroup) string {
+		ids := make([]string, len(group.steps))
+		for i, step := range group.steps {
+			ids[i] = step.StepID
+		}
+		return strings.Join(ids, ",")
+	}
+	if groups[0].subQuestion != "weather in Tokyo" || stepIDs(groups[0]) != "step-1,step-3,step-4" {
+		t.Errorf("Unexpected first group: %s %s", groups[0].subQuestion, stepIDs(groups[0]))
+	}
+	if groups[1].subQuestion != "convert USD to JPY" || stepIDs(groups[1]) != "step-2,step-4" {
+		t.Errorf("Unexpected second group: %s %s", groups[1].subQuestion, stepIDs(groups[1]))
+	}
+}
+
+func TestAISynthesizer_ParallelStrategy(t *testing.T) {
+	t.Run("synthesizes each sub-question and merges in plan order", func(t *testing.T) {
+		aiClient := &subQuestionAIClient{}
+		debugStore := &tieredTestDebugStore{}
+		synthesizer := NewAISynthesizer(aiClient)
+		synthesizer.SetStrategy(StrategyParallel)
+		synthesizer.SetLLMDebugStore(debugStore)
+
+		response, err := synthesizer.Synthesize(context.Background(), "weather in Tokyo and convert USD to JPY", multiTopicResult())
+		if err != nil {
+			t.Fatalf("Synthesize failed: %v", err)
+		}
+		if response != "Answer for weather in Tokyo\n\nAnswer for convert USD to JPY" {
+			t.Errorf("Unexpected merged response: %q", response)
+		}
+
+		for _, prompt := range aiClient.prompts {
+			if strings.Contains(prompt, "of the request: convert USD to JPY") && strings.Contains(prompt, "Agent: weather") {
+				t.Errorf("Currency prompt should not include weather results:\n%s", prompt)
+			}
+		}
+
+		if err := synthesizer.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		recorded := map[string]bool{}
+		for _, interaction := range debugStore.interactions {
+			if interaction.Type != "synthesis_group" || !interaction.Success {
+				t.Errorf("Unexpected interaction: %+v", interaction)
+			}
+			recorded[interaction.SubQuestion] = true
+		}
+		if len(debugStore.interactions) != 2 || !recorded["weather in Tokyo"] || !recorded["convert USD to JPY"] {
+			t.Errorf("Expected one debug record per sub-question, got %+v", debugStore.interactions)
+		}
+	})
+
+	t.Run("falls back to single synthesis without sub-questions", func(t *testing.T) {
+		aiClient := &subQuestionAIClient{}
+		synthesizer := NewAISynthesizer(aiClient)
+		synthesizer.SetStrategy(StrategyParallel)
+
+		results := &ExecutionResult{Steps: []StepResult{
+			{StepID: "step-1", AgentName: "weather", Response: "sunny", Success: true, SubQuestion: "weather in Tokyo"},
+		}}
+		response, err := synthesizer.Synthesize(context.Background(), "weather in Tokyo", results)
+		if err != nil || response != "Combined answer" || len(aiClient.prompts) != 1 {
+			t.Errorf("Expected a single synthesis call, got %q (%d calls, err: %v)", response, len(aiClient.prompts), err)
+		}
+	})
+
+	t.Run("falls back to single synthesis when a group fails", func(t *testing.T) {
+		aiClient := &subQuestionAIClient{failOn: "convert USD to JPY"}
+		synthesizer := NewAISynthesizer(aiClient)
+		synthesizer.SetStrategy(StrategyParallel)
+
+		response, err := synthesizer.Synthesize(context.Background(), "weather in Tokyo and convert USD to JPY", multiTopicResult())
+		if err != nil || response != "Combined answer" {
+			t.Errorf("Expected fallback synthesis, got %q (err: %v)", response, err)
+		}
+	})
+}
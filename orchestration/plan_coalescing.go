@@ -0,0 +1,95 @@
+package orchestration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// planCallGroup coalesces concurrent identical plan-generation LLM calls:
+// while a call for a key is in flight, further callers with the same key
+// wait for it instead of sending their own. Nothing is kept once the call
+// returns, so it is not a cache.
+//
+// The shared call runs detached from any one caller's context. A caller
+// that gives up stops waiting without cancelling the call for the others;
+// the call is cancelled only when every caller has given up.
+type planCallGroup struct {
+	mu    sync.Mutex
+	calls map[string]*planCall
+}
+
+// planCall is one in-flight shared call
+type planCall struct {
+	done     chan struct{}
+	response *core.AIResponse
+	err      error
+	waiters  int
+	cancel   context.CancelFunc
+}
+
+// planCoalescingKey identifies identical plan requests by the exact prompt
+// sent to the LLM. Keying on the built prompt rather than the request keeps
+// callers whose prompts differ in anything else (capabilities, conversation
+// history, prompt customisation) from sharing a plan.
+func planCoalescingKey(prompt, systemPrompt string) string {
+	sum := sha256.Sum256([]byte(systemPrompt + "\x00" + prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+// do returns the result of the in-flight call for key, starting one with
+// call if there is none. shared is true when the caller joined a call
+// started by another; its response is a copy with zero Usage, as the tokens
+// were spent by the first caller.
+func (g *planCallGroup) do(ctx context.Context, key string, call func(context.Context) (*core.AIResponse, error)) (response *core.AIResponse, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*planCall)
+	}
+	c, shared := g.calls[key]
+	if !shared {
+		// Keep the first caller's values (trace baggage, request ID) but not
+		// its cancellation
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &planCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = c
+		go func() {
+			c.response, c.err = call(callCtx)
+			g.mu.Lock()
+			if g.calls[key] == c {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+			cancel()
+			close(c.done)
+		}()
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		if c.err != nil || !shared {
+			return c.response, shared, c.err
+		}
+		copied := *c.response
+		copied.Usage = core.TokenUsage{}
+		return &copied, true, nil
+	case <-ctx.Done():
+		g.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			// Nobody is waiting: cancel the call, and let the next caller
+			// start a fresh one rather than join a cancelled call
+			c.cancel()
+			if g.calls[key] == c {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, shared, ctx.Err()
+	}
+}
@@ -0,0 +1,210 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// blockingPlannerAI returns a fixed plan once release is closed, counting
+// the calls that reach it
+type blockingPlannerAI struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (b *blockingPlannerAI) GenerateResponse(ctx context.Context, prompt string, options *core.AIOptions) (*core.AIResponse, error) {
+	b.calls.Add(1)
+	select {
+	case <-b.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &core.AIResponse{
+		Content: `{"plan_id": "coalesced-plan", "steps": [{"step_id": "step-1", "agent_name": "weather-service", "instruction": "Get the weather", "metadata": {"capability": "current_weather"}}]}`,
+		Usage:   core.TokenUsage{TotalTokens: 500},
+	}, nil
+}
+
+// waitForWaiters blocks until key's in-flight call has n waiters
+func waitForWaiters(t *testing.T, g *planCallGroup, key string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		c := g.calls[key]
+		waiting := c != nil && c.waiters == n
+		g.mu.Unlock()
+		if waiting {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for %d callers on %s", n, key)
+}
+
+func TestPlanCoalescingKey(t *testing.T) {
+	key := planCoalescingKey("Plan: Weather in Paris", "system")
+	if planCoalescingKey("Plan: Weather in Paris", "system") != key {
+		t.Error("Expected identical prompts to share a key")
+	}
+	if planCoalescingKey("Plan: weather in paris", "system") == key {
+		t.Error("Expected a different prompt to change the key")
+	}
+	if planCoalescingKey("Plan: Weather in Paris", "other system") == key {
+		t.Error("Expected a different system prompt to change the key")
+	}
+}
+
+func TestPlanCallGroup_SharesInFlightCall(t *testing.T) {
+	var group planCallGroup
+	release := make(chan struct{})
+	var calls atomic.Int32
+	call := func(ctx context.Context) (*core.AIResponse, error) {
+		calls.Add(1)
+		<-release
+		return &core.AIResponse{Content: "plan", Usage: core.TokenUsage{TotalTokens: 500}}, nil
+	}
+
+	const callers = 5
+	var wg sync.WaitGroup
+	var sharedCount atomic.Int32
+	var usage atomic.Int32
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, shared, err := group.do(context.Background(), "key", call)
+			if err != nil || response.Content != "plan" {
+				t.Errorf("do() = %v, %v", response, err)
+				return
+			}
+			if shared {
+				sharedCount.Add(1)
+			}
+			usage.Add(int32(response.Usage.TotalTokens))
+		}()
+	}
+	waitForWaiters(t, &group, "key", callers)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 call, got %d", calls.Load())
+	}
+	if sharedCount.Load() != callers-1 {
+		t.Errorf("Expected %d shared results, got %d", callers-1, sharedCount.Load())
+	}
+	if usage.Load() != 500 {
+		t.Errorf("Expected the tokens to be counted once, got %d", usage.Load())
+	}
+	if len(group.calls) != 0 {
+		t.Error("Expected the finished call to be forgotten")
+	}
+}
+
+func TestPlanCallGroup_Cancellation(t *testing.T) {
+	var group planCallGroup
+	release := make(chan struct{})
+	callCancelled := make(chan struct{})
+	call := func(ctx context.Context) (*core.AIResponse, error) {
+		select {
+		case <-release:
+			return &core.AIResponse{Content: "plan"}, nil
+		case <-ctx.Done():
+			close(callCancelled)
+			return nil, ctx.Err()
+		}
+	}
+
+	// The first caller giving up must not fail the call for the second
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, _, err := group.do(leaderCtx, "key", call)
+		leaderErr <- err
+	}()
+	waitForWaiters(t, &group, "key", 1)
+	followerResult := make(chan *core.AIResponse, 1)
+	go func() {
+		response, _, _ := group.do(context.Background(), "key", call)
+		followerResult <- response
+	}()
+	waitForWaiters(t, &group, "key", 2)
+
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the leader to stop waiting, got %v", err)
+	}
+	close(release)
+	if response := <-followerResult; response == nil || response.Content != "plan" {
+		t.Fatalf("Expected the follower to get the plan, got %v", response)
+	}
+
+	// When every caller gives up, the call itself is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	release = make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		group.do(ctx, "other", call)
+		close(done)
+	}()
+	waitForWaiters(t, &group, "other", 1)
+	cancel()
+	<-done
+	select {
+	case <-callCancelled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the abandoned call to be cancelled")
+	}
+}
+
+func TestGenerateExecutionPlan_CoalescesIdenticalRequests(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		planner := &blockingPlannerAI{release: make(chan struct{})}
+		orchestrator := newScopedTestOrchestrator(planner)
+		orchestrator.config.PlanCoalescingEnabled = enabled
+
+		const callers = 3
+		var wg sync.WaitGroup
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				plan, err := orchestrator.generateExecutionPlan(context.Background(), "What is the weather?", "coalesce-req")
+				if err != nil || plan.PlanID != "coalesced-plan" {
+					t.Errorf("generateExecutionPlan() = %v, %v", plan, err)
+				}
+			}()
+		}
+
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			orchestrator.planCalls.mu.Lock()
+			waiting := 0
+			for _, c := range orchestrator.planCalls.calls {
+				waiting = c.waiters
+			}
+			orchestrator.planCalls.mu.Unlock()
+			if (enabled && waiting == callers) || (!enabled && planner.calls.Load() == callers) {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		close(planner.release)
+		wg.Wait()
+
+		want := int32(1)
+		if !enabled {
+			want = callers
+		}
+		if planner.calls.Load() != want {
+			t.Errorf("enabled=%v: expected %d LLM calls, got %d", enabled, want, planner.calls.Load())
+		}
+	}
+}
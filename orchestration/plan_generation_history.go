@@ -0,0 +1,110 @@
+package orchestration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// planGenerationSystemPrompt is the system prompt sent with every initial
+// plan-generation call
+const planGenerationSystemPrompt = "You are an intelligent orchestrator that creates execution plans for multi-agent systems."
+
+// planGenerationScanLimit bounds how many recent debug records
+// RecentPlanGenerations reads to find matching interactions
+const planGenerationScanLimit = 500
+
+// CapabilityFingerprint returns a short, stable identifier for the capability
+// information included in a planning prompt. Two plan-generation calls share a
+// fingerprint exactly when the LLM saw the same capability set, so their
+// prompts and responses can be compared when tuning the planning prompt.
+func CapabilityFingerprint(capabilityInfo string) string {
+	sum := sha256.Sum256([]byte(capabilityInfo))
+	return hex.EncodeToString(sum[:8])
+}
+
+// LLMDebugRedactor rewrites text before it is stored in the LLM debug store,
+// e.g. to mask e-mail addresses or account numbers in user requests
+type LLMDebugRedactor func(text string) string
+
+// SetLLMDebugRedactor installs a redactor applied to the prompt, system prompt
+// and response of every interaction the orchestrator records, and to the
+// interactions returned by RecentPlanGenerations. Nil disables redaction.
+func (o *AIOrchestrator) SetLLMDebugRedactor(redactor LLMDebugRedactor) {
+	o.debugRedactor = redactor
+}
+
+// redactInteraction applies the configured redactor, if any
+func (o *AIOrchestrator) redactInteraction(interaction LLMInteraction) LLMInteraction {
+	if o.debugRedactor == nil {
+		return interaction
+	}
+	interaction.Prompt = o.debugRedactor(interaction.Prompt)
+	interaction.SystemPrompt = o.debugRedactor(interaction.SystemPrompt)
+	interaction.Response = o.debugRedactor(interaction.Response)
+	return interaction
+}
+
+// PlanGenerationSample is one recorded plan-generation call
+type PlanGenerationSample struct {
+	RequestID   string         `json:"request_id"`
+	TraceID     string         `json:"trace_id"`
+	Interaction LLMInteraction `json:"interaction"`
+}
+
+// RecentPlanGenerations returns up to limit recorded plan-generation calls,
+// newest first, whose capability set matches fingerprint. An empty
+// fingerprint matches every call. Each sample holds the exact prompt, system
+// prompt, model and raw response, so prompt changes can be evaluated against
+// real traffic.
+//
+// Only the most recent debug records are searched, and only while the LLM
+// debug store is enabled (GOMIND_LLM_DEBUG_ENABLED=true).
+func (o *AIOrchestrator) RecentPlanGenerations(ctx context.Context, fingerprint string, limit int) ([]PlanGenerationSample, error) {
+	if o.debugStore == nil {
+		return nil, fmt.Errorf("LLM debug store not configured")
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive, got %d", limit)
+	}
+
+	summaries, err := o.debugStore.ListRecent(ctx, planGenerationScanLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list debug records: %w", err)
+	}
+
+	samples := make([]PlanGenerationSample, 0, limit)
+	for _, summary := range summaries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		record, err := o.debugStore.GetRecord(ctx, summary.RequestID)
+		if err != nil {
+			// Records can expire between listing and fetching
+			continue
+		}
+
+		// Interactions are appended in call order; walk back for newest first
+		for i := len(record.Interactions) - 1; i >= 0; i-- {
+			interaction := record.Interactions[i]
+			if interaction.Type != "plan_generation" {
+				continue
+			}
+			if fingerprint != "" && interaction.CapabilityFingerprint != fingerprint {
+				continue
+			}
+			samples = append(samples, PlanGenerationSample{
+				RequestID:   record.RequestID,
+				TraceID:     record.TraceID,
+				Interaction: o.redactInteraction(interaction),
+			})
+			if len(samples) == limit {
+				return samples, nil
+			}
+		}
+	}
+
+	return samples, nil
+}
@@ -0,0 +1,124 @@
+package orchestration
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// staticCapabilityProvider returns the same capability set for every request
+type staticCapabilityProvider struct {
+	info   string
+	agents []string
+}
+
+func (p *staticCapabilityProvider) GetCapabilities(ctx context.Context, request string, metadata map[string]interface{}) (*CapabilityResult, error) {
+	return &CapabilityResult{FormattedInfo: p.info, AgentNames: p.agents}, nil
+}
+
+func TestCapabilityFingerprint(t *testing.T) {
+	a := CapabilityFingerprint("stock-analyzer: analyze_stock")
+	if a != CapabilityFingerprint("stock-analyzer: analyze_stock") {
+		t.Error("Expected the same capability info to produce the same fingerprint")
+	}
+	if a == CapabilityFingerprint("weather-tool: current_weather") {
+		t.Error("Expected different capability info to produce different fingerprints")
+	}
+	if len(a) != 16 {
+		t.Errorf("Expected a 16 character fingerprint, got %q", a)
+	}
+}
+
+func TestAIOrchestrator_RecentPlanGenerations_RecordsLivePlanning(t *testing.T) {
+	orchestrator := NewAIOrchestrator(nil, NewMockDiscovery(), NewMockAIClient())
+	provider := &staticCapabilityProvider{
+		info:   "Available Agents:\n- stock-analyzer: analyze_stock(symbol)",
+		agents: []string{"stock-analyzer"},
+	}
+	orchestrator.capabilityProvider = provider
+
+	store := NewMemoryLLMDebugStore()
+	orchestrator.SetLLMDebugStore(store)
+	orchestrator.SetLLMDebugRedactor(func(text string) string {
+		return strings.ReplaceAll(text, "ACCT-1234", "[account]")
+	})
+
+	ctx := context.Background()
+	if _, err := orchestrator.generateExecutionPlan(ctx, "Create an execution plan for ACCT-1234", "plan-req-1"); err != nil {
+		t.Fatalf("generateExecutionPlan() error = %v", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := orchestrator.Shutdown(shutdownCtx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	fingerprint := CapabilityFingerprint(provider.info)
+	samples, err := orchestrator.RecentPlanGenerations(ctx, fingerprint, 10)
+	if err != nil {
+		t.Fatalf("RecentPlanGenerations() error = %v", err)
+	}
+	if len(samples) != 1 {
+		t.Fatalf("Expected 1 sample, got %d", len(samples))
+	}
+
+	interaction := samples[0].Interaction
+	if samples[0].RequestID != "plan-req-1" {
+		t.Errorf("RequestID = %q, want plan-req-1", samples[0].RequestID)
+	}
+	if interaction.CapabilityFingerprint != fingerprint {
+		t.Errorf("CapabilityFingerprint = %q, want %q", interaction.CapabilityFingerprint, fingerprint)
+	}
+	if interaction.SystemPrompt != planGenerationSystemPrompt {
+		t.Errorf("SystemPrompt = %q", interaction.SystemPrompt)
+	}
+	if !strings.Contains(interaction.Response, "stock-analyzer") {
+		t.Errorf("Expected the raw plan response, got %q", interaction.Response)
+	}
+	if strings.Contains(interaction.Prompt, "ACCT-1234") || !strings.Contains(interaction.Prompt, "[account]") {
+		t.Error("Expected the stored prompt to be redacted")
+	}
+
+	other, err := orchestrator.RecentPlanGenerations(ctx, CapabilityFingerprint("something else"), 10)
+	if err != nil {
+		t.Fatalf("RecentPlanGenerations() error = %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("Expected no samples for another capability set, got %d", len(other))
+	}
+}
+
+func TestAIOrchestrator_RecentPlanGenerations_NewestFirstAndLimit(t *testing.T) {
+	orchestrator := NewAIOrchestrator(nil, NewMockDiscovery(), NewMockAIClient())
+	store := NewMemoryLLMDebugStore()
+	orchestrator.SetLLMDebugStore(store)
+
+	ctx := context.Background()
+	_ = store.RecordInteraction(ctx, "req-old", LLMInteraction{Type: "plan_generation", Prompt: "old", CapabilityFingerprint: "fp"})
+	time.Sleep(5 * time.Millisecond)
+	_ = store.RecordInteraction(ctx, "req-new", LLMInteraction{Type: "plan_generation", Prompt: "first", CapabilityFingerprint: "fp"})
+	_ = store.RecordInteraction(ctx, "req-new", LLMInteraction{Type: "synthesis", Prompt: "synth"})
+	_ = store.RecordInteraction(ctx, "req-new", LLMInteraction{Type: "plan_generation", Prompt: "retry", CapabilityFingerprint: "fp"})
+
+	samples, err := orchestrator.RecentPlanGenerations(ctx, "fp", 2)
+	if err != nil {
+		t.Fatalf("RecentPlanGenerations() error = %v", err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("Expected 2 samples, got %d", len(samples))
+	}
+	if samples[0].Interaction.Prompt != "retry" || samples[1].Interaction.Prompt != "first" {
+		t.Errorf("Expected newest first, got %q then %q", samples[0].Interaction.Prompt, samples[1].Interaction.Prompt)
+	}
+
+	all, _ := orchestrator.RecentPlanGenerations(ctx, "", 10)
+	if len(all) != 3 {
+		t.Errorf("Expected an empty fingerprint to match all 3 plan generations, got %d", len(all))
+	}
+
+	if _, err := NewAIOrchestrator(nil, NewMockDiscovery(), NewMockAIClient()).RecentPlanGenerations(ctx, "fp", 1); err == nil {
+		t.Error("Expected an error without a debug store")
+	}
+}
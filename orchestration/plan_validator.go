@@ -0,0 +1,301 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// =============================================================================
+// Plan Validators - Domain Policy Enforcement
+// =============================================================================
+//
+// validatePlan() only checks that a plan is structurally executable (agents
+// exist, capabilities exist, dependencies resolve). PlanValidator lets
+// applications layer domain rules on top, for example:
+//
+//   - "never schedule a trade step without a preceding quote step"
+//   - "refunds require human approval"
+//
+// Validators run after plan generation and structural validation. Each
+// violation carries an action:
+//
+//   - PlanViolationReject:    the request fails with *ErrPlanRejected
+//   - PlanViolationWarn:      the violation is logged and recorded only
+//   - PlanViolationInterrupt: a HITL plan-approval checkpoint is forced
+//
+// Usage:
+//
+//	rules := NewPlanRules().
+//	    RequireBefore("place_trade", "get_quote").
+//	    InterruptOnCapability("issue_refund", "Refunds require approval").
+//	    MaxSteps(10)
+//	orchestrator.SetPlanValidator(rules.Build())
+//
+// Violations are recorded in ExecutionResult.Metadata["plan_violations"].
+// =============================================================================
+
+// PlanViolationAction determines how the orchestrator reacts to a violation
+type PlanViolationAction string
+
+const (
+	// PlanViolationReject fails the request before any step executes
+	PlanViolationReject PlanViolationAction = "reject"
+
+	// PlanViolationWarn logs the violation and continues execution
+	PlanViolationWarn PlanViolationAction = "warn"
+
+	// PlanViolationInterrupt forces a HITL plan approval checkpoint.
+	// If HITL is not enabled, the violation is treated as a rejection.
+	PlanViolationInterrupt PlanViolationAction = "interrupt"
+)
+
+// PlanViolation describes a single domain rule broken by a plan
+type PlanViolation struct {
+	Rule    string              `json:"rule"`
+	StepID  string              `json:"step_id,omitempty"`
+	Message string              `json:"message"`
+	Action  PlanViolationAction `json:"action"`
+}
+
+// PlanValidator evaluates domain policies against a generated plan.
+// Implementations must be safe for concurrent use.
+type PlanValidator interface {
+	Validate(plan *RoutingPlan) []PlanViolation
+}
+
+// PlanValidatorFunc adapts an ordinary function to the PlanValidator interface
+type PlanValidatorFunc func(plan *RoutingPlan) []PlanViolation
+
+// Validate calls f(plan)
+func (f PlanValidatorFunc) Validate(plan *RoutingPlan) []PlanViolation {
+	return f(plan)
+}
+
+// CombinePlanValidators returns a validator that runs all validators in order
+// and concatenates their violations. Nil validators are skipped.
+func CombinePlanValidators(validators ...PlanValidator) PlanValidator {
+	return PlanValidatorFunc(func(plan *RoutingPlan) []PlanViolation {
+		var violations []PlanViolation
+		for _, v := range validators {
+			if v == nil {
+				continue
+			}
+			violations = append(violations, v.Validate(plan)...)
+		}
+		return violations
+	})
+}
+
+// ErrPlanRejected is returned when a plan violates a reject-level policy
+type ErrPlanRejected struct {
+	PlanID     string
+	Violations []PlanViolation
+}
+
+func (e *ErrPlanRejected) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = fmt.Sprintf("%s: %s", v.Rule, v.Message)
+	}
+	return fmt.Sprintf("plan %s rejected by policy: %s", e.PlanID, strings.Join(messages, "; "))
+}
+
+// IsPlanRejected checks if an error is a policy rejection
+func IsPlanRejected(err error) bool {
+	if err == nil {
+		return false
+	}
+	var rejected *ErrPlanRejected
+	return errors.As(err, &rejected)
+}
+
+// filterViolations returns the violations that carry the given action
+func filterViolations(violations []PlanViolation, action PlanViolationAction) []PlanViolation {
+	var filtered []PlanViolation
+	for _, v := range violations {
+		if v.Action == action {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// planViolationsKey is the context key for violations awaiting HITL approval
+type planViolationsKey struct{}
+
+// WithPlanViolations attaches interrupt-level violations to the context so the
+// interrupt controller can force a plan approval checkpoint.
+func WithPlanViolations(ctx context.Context, violations []PlanViolation) context.Context {
+	return context.WithValue(ctx, planViolationsKey{}, violations)
+}
+
+// GetPlanViolations retrieves plan violations from context, if present.
+func GetPlanViolations(ctx context.Context) []PlanViolation {
+	if v, ok := ctx.Value(planViolationsKey{}).([]PlanViolation); ok {
+		return v
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Rule Builder
+// -----------------------------------------------------------------------------
+
+// PlanRules is a declarative builder for common plan policies.
+// Steps are matched by capability (step.Metadata["capability"]) or agent name.
+type PlanRules struct {
+	rules []PlanValidator
+}
+
+// NewPlanRules creates an empty rule set
+func NewPlanRules() *PlanRules {
+	return &PlanRules{}
+}
+
+// RequireBefore rejects plans where a step matching target is not preceded
+// (via DependsOn, directly or transitively) by a step matching prerequisite.
+func (r *PlanRules) RequireBefore(target, prerequisite string) *PlanRules {
+	rule := fmt.Sprintf("require_before:%s<-%s", target, prerequisite)
+	r.rules = append(r.rules, PlanValidatorFunc(func(plan *RoutingPlan) []PlanViolation {
+		var violations []PlanViolation
+		for _, step := range plan.Steps {
+			if !stepMatches(step, target) {
+				continue
+			}
+			if !hasAncestorMatching(plan, step, prerequisite) {
+				violations = append(violations, PlanViolation{
+					Rule:    rule,
+					StepID:  step.StepID,
+					Message: fmt.Sprintf("step %s (%s) must depend on a %s step", step.StepID, target, prerequisite),
+					Action:  PlanViolationReject,
+				})
+			}
+		}
+		return violations
+	}))
+	return r
+}
+
+// Forbid rejects plans containing any step matching the capability or agent name
+func (r *PlanRules) Forbid(name, reason string) *PlanRules {
+	return r.matchRule("forbid:"+name, name, reason, PlanViolationReject)
+}
+
+// WarnOn records a warning for every step matching the capability or agent name
+func (r *PlanRules) WarnOn(name, reason string) *PlanRules {
+	return r.matchRule("warn:"+name, name, reason, PlanViolationWarn)
+}
+
+// InterruptOnCapability forces human approval for plans containing a step
+// matching the capability or agent name
+func (r *PlanRules) InterruptOnCapability(name, reason string) *PlanRules {
+	return r.matchRule("interrupt:"+name, name, reason, PlanViolationInterrupt)
+}
+
+// InterruptWhen forces human approval for every step where the predicate
+// returns true. Useful for parameter thresholds, e.g. refunds over $X:
+//
+//	rules.InterruptWhen("refund_limit", "Refund exceeds $500", func(s RoutingStep) bool {
+//	    params, ok := s.Metadata["parameters"].(map[string]interface{})
+//	    if !ok {
+//	        return false
+//	    }
+//	    amount, _ := params["amount"].(float64)
+//	    return s.Metadata["capability"] == "issue_refund" && amount > 500
+//	})
+func (r *PlanRules) InterruptWhen(rule, reason string, predicate func(step RoutingStep) bool) *PlanRules {
+	return r.Custom(rule, reason, PlanViolationInterrupt, predicate)
+}
+
+// Custom adds a per-step rule with the given action
+func (r *PlanRules) Custom(rule, reason string, action PlanViolationAction, predicate func(step RoutingStep) bool) *PlanRules {
+	r.rules = append(r.rules, PlanValidatorFunc(func(plan *RoutingPlan) []PlanViolation {
+		var violations []PlanViolation
+		for _, step := range plan.Steps {
+			if predicate(step) {
+				violations = append(violations, PlanViolation{
+					Rule:    rule,
+					StepID:  step.StepID,
+					Message: reason,
+					Action:  action,
+				})
+			}
+		}
+		return violations
+	}))
+	return r
+}
+
+// MaxSteps rejects plans with more than max steps
+func (r *PlanRules) MaxSteps(max int) *PlanRules {
+	r.rules = append(r.rules, PlanValidatorFunc(func(plan *RoutingPlan) []PlanViolation {
+		if len(plan.Steps) <= max {
+			return nil
+		}
+		return []PlanViolation{{
+			Rule:    "max_steps",
+			Message: fmt.Sprintf("plan has %d steps, maximum allowed is %d", len(plan.Steps), max),
+			Action:  PlanViolationReject,
+		}}
+	}))
+	return r
+}
+
+// Add appends an arbitrary validator to the rule set
+func (r *PlanRules) Add(validator PlanValidator) *PlanRules {
+	r.rules = append(r.rules, validator)
+	return r
+}
+
+// Build returns a PlanValidator evaluating all configured rules
+func (r *PlanRules) Build() PlanValidator {
+	rules := make([]PlanValidator, len(r.rules))
+	copy(rules, r.rules)
+	return CombinePlanValidators(rules...)
+}
+
+func (r *PlanRules) matchRule(rule, name, reason string, action PlanViolationAction) *PlanRules {
+	return r.Custom(rule, reason, action, func(step RoutingStep) bool {
+		return stepMatches(step, name)
+	})
+}
+
+// stepMatches reports whether a step targets the given capability or agent
+func stepMatches(step RoutingStep, name string) bool {
+	if step.AgentName == name {
+		return true
+	}
+	capability, _ := step.Metadata["capability"].(string)
+	return capability == name
+}
+
+// hasAncestorMatching walks the DependsOn graph looking for a matching step
+func hasAncestorMatching(plan *RoutingPlan, step RoutingStep, name string) bool {
+	byID := make(map[string]RoutingStep, len(plan.Steps))
+	for _, s := range plan.Steps {
+		byID[s.StepID] = s
+	}
+
+	visited := make(map[string]bool)
+	queue := append([]string(nil), step.DependsOn...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		dep, ok := byID[id]
+		if !ok {
+			continue
+		}
+		if stepMatches(dep, name) {
+			return true
+		}
+		queue = append(queue, dep.DependsOn...)
+	}
+	return false
+}
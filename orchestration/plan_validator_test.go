@@ -0,0 +1,184 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func tradePlan(withQuote bool) *RoutingPlan {
+	plan := &RoutingPlan{PlanID: "plan-1"}
+	tradeStep := RoutingStep{
+		StepID:    "step-2",
+		AgentName: "broker",
+		Metadata:  map[string]interface{}{"capability": "place_trade"},
+	}
+	if withQuote {
+		plan.Steps = append(plan.Steps, RoutingStep{
+			StepID:    "step-1",
+			AgentName: "market-data",
+			Metadata:  map[string]interface{}{"capability": "get_quote"},
+		})
+		tradeStep.DependsOn = []string{"step-1"}
+	}
+	plan.Steps = append(plan.Steps, tradeStep)
+	return plan
+}
+
+func TestPlanRules_RequireBefore(t *testing.T) {
+	validator := NewPlanRules().RequireBefore("place_trade", "get_quote").Build()
+
+	if violations := validator.Validate(tradePlan(true)); len(violations) != 0 {
+		t.Errorf("Expected no violations, got %v", violations)
+	}
+
+	violations := validator.Validate(tradePlan(false))
+	if len(violations) != 1 {
+		t.Fatalf("Expected 1 violation, got %d", len(violations))
+	}
+	if violations[0].Action != PlanViolationReject || violations[0].StepID != "step-2" {
+		t.Errorf("Unexpected violation: %+v", violations[0])
+	}
+}
+
+func TestPlanRules_RequireBeforeTransitive(t *testing.T) {
+	plan := &RoutingPlan{Steps: []RoutingStep{
+		{StepID: "a", AgentName: "quotes", Metadata: map[string]interface{}{"capability": "get_quote"}},
+		{StepID: "b", AgentName: "risk", DependsOn: []string{"a"}},
+		{StepID: "c", AgentName: "broker", DependsOn: []string{"b"}, Metadata: map[string]interface{}{"capability": "place_trade"}},
+	}}
+
+	validator := NewPlanRules().RequireBefore("place_trade", "get_quote").Build()
+	if violations := validator.Validate(plan); len(violations) != 0 {
+		t.Errorf("Expected transitive dependency to satisfy rule, got %v", violations)
+	}
+}
+
+func TestPlanRules_Actions(t *testing.T) {
+	plan := &RoutingPlan{Steps: []RoutingStep{
+		{StepID: "s1", AgentName: "payments", Metadata: map[string]interface{}{"capability": "issue_refund", "parameters": map[string]interface{}{"amount": 900.0}}},
+		{StepID: "s2", AgentName: "legacy-crm"},
+		{StepID: "s3", AgentName: "admin"},
+	}}
+
+	validator := NewPlanRules().
+		InterruptWhen("refund_limit", "Refund exceeds $500", func(s RoutingStep) bool {
+			params, _ := s.Metadata["parameters"].(map[string]interface{})
+			amount, _ := params["amount"].(float64)
+			return s.Metadata["capability"] == "issue_refund" && amount > 500
+		}).
+		WarnOn("legacy-crm", "deprecated agent").
+		Forbid("admin", "admin agent is not allowed").
+		MaxSteps(2).
+		Build()
+
+	violations := validator.Validate(plan)
+	counts := map[PlanViolationAction]int{}
+	for _, v := range violations {
+		counts[v.Action]++
+	}
+	if counts[PlanViolationInterrupt] != 1 || counts[PlanViolationWarn] != 1 || counts[PlanViolationReject] != 2 {
+		t.Errorf("Unexpected violation counts: %v", counts)
+	}
+}
+
+func TestCombinePlanValidators_SkipsNil(t *testing.T) {
+	custom := PlanValidatorFunc(func(plan *RoutingPlan) []PlanViolation {
+		return []PlanViolation{{Rule: "custom", Action: PlanViolationWarn}}
+	})
+	violations := CombinePlanValidators(nil, custom, nil).Validate(&RoutingPlan{})
+	if len(violations) != 1 {
+		t.Errorf("Expected 1 violation, got %d", len(violations))
+	}
+}
+
+func TestApplyPlanValidator(t *testing.T) {
+	newOrch := func(hitl bool) *AIOrchestrator {
+		config := DefaultConfig()
+		config.HITL.Enabled = hitl
+		o := NewAIOrchestrator(config, NewMockDiscovery(), nil)
+		if hitl {
+			o.SetInterruptController(newMockInterruptController())
+		}
+		return o
+	}
+
+	t.Run("reject", func(t *testing.T) {
+		o := newOrch(false)
+		o.SetPlanValidator(NewPlanRules().RequireBefore("place_trade", "get_quote").Build())
+
+		_, violations, err := o.applyPlanValidator(context.Background(), tradePlan(false), "req-1")
+		if !IsPlanRejected(err) {
+			t.Fatalf("Expected ErrPlanRejected, got %v", err)
+		}
+		if len(violations) != 1 {
+			t.Errorf("Expected 1 recorded violation, got %d", len(violations))
+		}
+		if !IsPlanRejected(fmt.Errorf("wrapped: %w", err)) {
+			t.Error("Expected wrapped error to be detected")
+		}
+	})
+
+	t.Run("warn continues", func(t *testing.T) {
+		o := newOrch(false)
+		o.SetPlanValidator(NewPlanRules().WarnOn("broker", "heads up").Build())
+
+		_, violations, err := o.applyPlanValidator(context.Background(), tradePlan(true), "req-1")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		result := &ExecutionResult{}
+		recordPlanViolations(result, violations)
+		if recorded, ok := result.Metadata["plan_violations"].([]PlanViolation); !ok || len(recorded) != 1 {
+			t.Errorf("Expected violations recorded in metadata, got %v", result.Metadata)
+		}
+	})
+
+	t.Run("interrupt without HITL rejects", func(t *testing.T) {
+		o := newOrch(false)
+		o.SetPlanValidator(NewPlanRules().InterruptOnCapability("place_trade", "needs approval").Build())
+
+		_, _, err := o.applyPlanValidator(context.Background(), tradePlan(true), "req-1")
+		if !IsPlanRejected(err) {
+			t.Fatalf("Expected rejection when HITL unavailable, got %v", err)
+		}
+	})
+
+	t.Run("interrupt with HITL attaches to context", func(t *testing.T) {
+		o := newOrch(true)
+		o.SetPlanValidator(NewPlanRules().InterruptOnCapability("place_trade", "needs approval").Build())
+
+		ctx, _, err := o.applyPlanValidator(context.Background(), tradePlan(true), "req-1")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(GetPlanViolations(ctx)) != 1 {
+			t.Error("Expected interrupt violation in context")
+		}
+	})
+}
+
+func TestInterruptController_ForcesApprovalOnPlanViolations(t *testing.T) {
+	store := newMockCheckpointStore()
+	controller := NewInterruptController(&mockPolicy{}, store, nil)
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	ctx = WithPlanViolations(ctx, []PlanViolation{{Rule: "refund_limit", Message: "Refund exceeds $500", Action: PlanViolationInterrupt}})
+
+	checkpoint, err := controller.CheckPlanApproval(ctx, tradePlan(true))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if checkpoint == nil {
+		t.Fatal("Expected checkpoint to be created")
+	}
+	if checkpoint.Decision.Reason != ReasonPolicyViolation {
+		t.Errorf("Expected reason %s, got %s", ReasonPolicyViolation, checkpoint.Decision.Reason)
+	}
+
+	// No violations and a permissive policy should not interrupt
+	checkpoint, err = controller.CheckPlanApproval(WithRequestID(context.Background(), "req-2"), tradePlan(true))
+	if err != nil || checkpoint != nil {
+		t.Errorf("Expected no checkpoint, got %v, %v", checkpoint, err)
+	}
+}
@@ -0,0 +1,167 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/itsneelabh/gomind/core"
+)
+
+// defaultCancellationChannel is the Redis pub/sub channel cancellation
+// requests are published on
+const defaultCancellationChannel = "gomind:execution:cancel"
+
+// RedisCancellationBrokerOption configures the Redis cancellation broker
+type RedisCancellationBrokerOption func(*redisCancellationBrokerConfig)
+
+type redisCancellationBrokerConfig struct {
+	redisURL string
+	redisDB  int
+	channel  string
+	logger   core.Logger
+}
+
+// WithCancellationRedisURL sets the Redis connection URL
+func WithCancellationRedisURL(url string) RedisCancellationBrokerOption {
+	return func(c *redisCancellationBrokerConfig) {
+		c.redisURL = url
+	}
+}
+
+// WithCancellationChannel sets the pub/sub channel cancellations are sent on.
+// Every orchestrator and tool cancelling executions must use the same one.
+func WithCancellationChannel(channel string) RedisCancellationBrokerOption {
+	return func(c *redisCancellationBrokerConfig) {
+		c.channel = channel
+	}
+}
+
+// WithCancellationLogger sets the logger for the cancellation broker
+func WithCancellationLogger(logger core.Logger) RedisCancellationBrokerOption {
+	return func(c *redisCancellationBrokerConfig) {
+		if logger == nil {
+			return
+		}
+		if cal, ok := logger.(core.ComponentAwareLogger); ok {
+			c.logger = cal.WithComponent("framework/orchestration")
+		} else {
+			c.logger = logger
+		}
+	}
+}
+
+// RedisCancellationBroker implements CancellationBroker with Redis pub/sub,
+// so CancelExecution reaches whichever orchestrator replica runs the
+// execution.
+//
+// Usage:
+//
+//	broker, err := orchestration.NewRedisCancellationBroker(
+//	    orchestration.WithCancellationLogger(logger),
+//	)
+//	if err != nil {
+//	    return err
+//	}
+//	if err := orchestrator.SetCancellationBroker(broker); err != nil {
+//	    return err
+//	}
+type RedisCancellationBroker struct {
+	client   *redis.Client
+	channel  string
+	redisURL string // For error messages
+	logger   core.Logger
+}
+
+// NewRedisCancellationBroker creates a Redis-backed cancellation broker.
+//
+// Environment variable precedence:
+//   - REDIS_URL or GOMIND_REDIS_URL: Redis connection URL (default: localhost:6379)
+//   - GOMIND_EXECUTION_CANCEL_CHANNEL: pub/sub channel (default: gomind:execution:cancel)
+func NewRedisCancellationBroker(opts ...RedisCancellationBrokerOption) (*RedisCancellationBroker, error) {
+	cfg := &redisCancellationBrokerConfig{
+		redisURL: getRedisURLWithFallback(),
+		redisDB:  core.RedisDBExecutionDebug,
+		channel:  getEnvString("GOMIND_EXECUTION_CANCEL_CHANNEL", defaultCancellationChannel),
+		logger:   &core.NoOpLogger{},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	redisOpt, err := redis.ParseURL(cfg.redisURL)
+	if err != nil {
+		// Try treating it as a simple address if URL parsing fails
+		redisOpt = &redis.Options{
+			Addr: cfg.redisURL,
+		}
+	}
+	redisOpt.DB = cfg.redisDB
+
+	client := redis.NewClient(redisOpt)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("redis connection failed at %s: %w\n"+
+			"Hint: Check REDIS_URL or GOMIND_REDIS_URL environment variables, "+
+			"or use WithCancellationRedisURL() option", cfg.redisURL, err)
+	}
+
+	return &RedisCancellationBroker{
+		client:   client,
+		channel:  cfg.channel,
+		redisURL: cfg.redisURL,
+		logger:   cfg.logger,
+	}, nil
+}
+
+// PublishCancel publishes a cancellation request for requestID
+func (b *RedisCancellationBroker) PublishCancel(ctx context.Context, requestID string) error {
+	if err := b.client.Publish(ctx, b.channel, requestID).Err(); err != nil {
+		return fmt.Errorf("failed to publish cancellation to Redis: %w (check REDIS_URL=%s)", err, b.redisURL)
+	}
+
+	b.logger.DebugWithContext(ctx, "Execution cancellation published", map[string]interface{}{
+		"operation":  "execution_cancel_publish",
+		"request_id": requestID,
+		"channel":    b.channel,
+	})
+	return nil
+}
+
+// SubscribeCancels calls handler with each cancellation request published
+// until ctx is done
+func (b *RedisCancellationBroker) SubscribeCancels(ctx context.Context, handler func(requestID string)) error {
+	pubsub := b.client.Subscribe(ctx, b.channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return fmt.Errorf("failed to subscribe to cancellation channel: %w (check REDIS_URL=%s)", err, b.redisURL)
+	}
+
+	go func() {
+		defer func() {
+			_ = pubsub.Close() // Error intentionally ignored in cleanup
+		}()
+
+		ch := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				handler(msg.Payload)
+			}
+		}
+	}()
+	return nil
+}
+
+// Close closes the Redis connection
+func (b *RedisCancellationBroker) Close() error {
+	return b.client.Close()
+}
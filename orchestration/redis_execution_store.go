@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"sync"
 	"time"
 
@@ -237,6 +238,17 @@ func (s *RedisExecutionDebugStore) Store(ctx context.Context, execution *StoredE
 			// Don't fail - index is for convenience, not critical
 		}
 
+		// Update search index (word -> request IDs) - best effort
+		if tokens := ExecutionSearchTokens(execution.OriginalRequest); len(tokens) > 0 {
+			if err := s.indexSearchTokens(ctx, execution.RequestID, execution.CreatedAt, tokens); err != nil {
+				s.logger.Warn("Failed to update execution search index", map[string]interface{}{
+					"request_id": execution.RequestID,
+					"error":      err.Error(),
+				})
+				// Don't fail - searches fall back to scanning recent executions
+			}
+		}
+
 		// Store trace ID mapping if available - best effort
 		if execution.TraceID != "" {
 			traceKey := s.traceKey(execution.TraceID)
@@ -253,13 +265,11 @@ func (s *RedisExecutionDebugStore) Store(ctx context.Context, execution *StoredE
 		return nil
 	}
 
-	// Layer 2: Use injected circuit breaker if available
-	if s.circuitBreaker != nil {
-		return s.circuitBreaker.Execute(ctx, operation)
+	if err := s.execute(ctx, operation); err != nil {
+		return err
 	}
-
-	// Layer 1: Built-in simple retry with exponential backoff
-	return s.executeWithRetry(ctx, operation)
+	s.publishEvent(ctx, ExecutionEventStored, execution)
+	return nil
 }
 
 // Get retrieves the complete execution record by request ID.
@@ -335,13 +345,11 @@ func (s *RedisExecutionDebugStore) Update(ctx context.Context, requestID string,
 		return s.client.Set(ctx, key, data, ttl).Err()
 	}
 
-	// Layer 2: Use injected circuit breaker if available
-	if s.circuitBreaker != nil {
-		return s.circuitBreaker.Execute(ctx, operation)
+	if err := s.execute(ctx, operation); err != nil {
+		return err
 	}
-
-	// Layer 1: Built-in simple retry with exponential backoff
-	return s.executeWithRetry(ctx, operation)
+	s.publishEvent(ctx, ExecutionEventUpdated, execution)
+	return nil
 }
 
 // ExtendTTL extends retention for investigation.
@@ -377,12 +385,14 @@ func (s *RedisExecutionDebugStore) SetMetadata(ctx context.Context, requestID st
 		return fmt.Errorf("request_id is required")
 	}
 
+	var updated *StoredExecution
 	operation := func() error {
 		// Get existing record
 		execution, err := s.Get(ctx, requestID)
 		if err != nil {
 			return err
 		}
+		updated = execution
 
 		// Update metadata
 		if execution.Metadata == nil {
@@ -406,13 +416,11 @@ func (s *RedisExecutionDebugStore) SetMetadata(ctx context.Context, requestID st
 		return s.client.Set(ctx, redisKey, data, ttl).Err()
 	}
 
-	// Layer 2: Use injected circuit breaker if available
-	if s.circuitBreaker != nil {
-		return s.circuitBreaker.Execute(ctx, operation)
+	if err := s.execute(ctx, operation); err != nil {
+		return err
 	}
-
-	// Layer 1: Built-in simple retry with exponential backoff
-	return s.executeWithRetry(ctx, operation)
+	s.publishEvent(ctx, ExecutionEventUpdated, updated)
+	return nil
 }
 
 // ListRecent returns recent executions ordered by creation time.
@@ -440,30 +448,7 @@ func (s *RedisExecutionDebugStore) ListRecent(ctx context.Context, limit int) ([
 			continue // Skip missing records (TTL expired)
 		}
 
-		// Build summary
-		summary := ExecutionSummary{
-			RequestID:         execution.RequestID,
-			OriginalRequestID: execution.OriginalRequestID,
-			TraceID:           execution.TraceID,
-			AgentName:         execution.AgentName,
-			OriginalRequest:   execution.OriginalRequest,
-			Interrupted:       execution.Interrupted,
-			CreatedAt:         execution.CreatedAt,
-		}
-
-		// Extract step count and success from result
-		if execution.Result != nil {
-			summary.Success = execution.Result.Success
-			summary.TotalDuration = execution.Result.TotalDuration
-			summary.StepCount = len(execution.Result.Steps)
-			for _, step := range execution.Result.Steps {
-				if !step.Success {
-					summary.FailedSteps++
-				}
-			}
-		}
-
-		summaries = append(summaries, summary)
+		summaries = append(summaries, summarizeExecution(execution))
 	}
 
 	return summaries, nil
@@ -474,6 +459,64 @@ func (s *RedisExecutionDebugStore) Close() error {
 	return s.client.Close()
 }
 
+// execute runs operation with the injected circuit breaker (Layer 2) if
+// available, otherwise with the built-in simple retry (Layer 1)
+func (s *RedisExecutionDebugStore) execute(ctx context.Context, operation func() error) error {
+	if s.circuitBreaker != nil {
+		return s.circuitBreaker.Execute(ctx, operation)
+	}
+	return s.executeWithRetry(ctx, operation)
+}
+
+// indexSearchTokens adds requestID to the search index of each token: a
+// sorted set scored by created-at time in Unix nanoseconds, like the listing
+// index. Entries older than the longest record TTL are trimmed on every
+// write, and sets of words nobody uses any more expire with their last
+// record. The indexed-since marker keeps the created-at time of the first
+// indexed execution, so searches know which older window to scan instead.
+func (s *RedisExecutionDebugStore) indexSearchTokens(ctx context.Context, requestID string, createdAt time.Time, tokens []string) error {
+	ttl := s.ttl
+	if s.errorTTL > ttl {
+		ttl = s.errorTTL
+	}
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	score := float64(createdAt.UnixNano())
+	expired := strconv.FormatInt(time.Now().Add(-ttl).UnixNano(), 10)
+
+	pipe := s.client.Pipeline()
+	for _, token := range tokens {
+		key := s.searchTokenKey(token)
+		pipe.ZAdd(ctx, key, &redis.Z{Score: score, Member: requestID})
+		pipe.ZRemRangeByScore(ctx, key, "-inf", "("+expired)
+		pipe.Expire(ctx, key, ttl)
+	}
+	pipe.SetNX(ctx, s.searchIndexedSinceKey(), strconv.FormatInt(createdAt.UnixNano(), 10), 0)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// publishEvent announces a write on the events channel. It is best effort:
+// the record is already saved, and viewers fall back to polling.
+func (s *RedisExecutionDebugStore) publishEvent(ctx context.Context, eventType string, execution *StoredExecution) {
+	data, err := json.Marshal(ExecutionEvent{
+		Type:      eventType,
+		Summary:   summarizeExecution(execution),
+		Timestamp: time.Now(),
+	})
+	if err == nil {
+		err = s.client.Publish(ctx, s.eventsChannel(), data).Err()
+	}
+	if err != nil {
+		s.logger.Warn("Failed to publish execution event", map[string]interface{}{
+			"request_id": execution.RequestID,
+			"event_type": eventType,
+			"error":      err.Error(),
+		})
+	}
+}
+
 // Key building helper methods using configurable keyPrefix
 
 func (s *RedisExecutionDebugStore) recordKey(requestID string) string {
@@ -488,6 +531,18 @@ func (s *RedisExecutionDebugStore) traceKey(traceID string) string {
 	return s.keyPrefix + "trace:" + traceID
 }
 
+func (s *RedisExecutionDebugStore) searchTokenKey(token string) string {
+	return s.keyPrefix + "search:words:" + token
+}
+
+func (s *RedisExecutionDebugStore) searchIndexedSinceKey() string {
+	return s.keyPrefix + "search:indexed_since"
+}
+
+func (s *RedisExecutionDebugStore) eventsChannel() string {
+	return s.keyPrefix + "events"
+}
+
 // Layer 1 Resilience Constants (same as LLM Debug Store)
 const (
 	execLayer1MaxRetries     = 3
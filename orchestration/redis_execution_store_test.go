@@ -0,0 +1,131 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+func TestRedisExecutionDebugStore_PublishesEvents(t *testing.T) {
+	mr, client := setupCheckpointTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	store := &RedisExecutionDebugStore{
+		client:    client,
+		logger:    &core.NoOpLogger{},
+		keyPrefix: "test:execution:",
+		ttl:       time.Hour,
+		errorTTL:  time.Hour,
+	}
+	ctx := context.Background()
+
+	pubsub := client.Subscribe(ctx, store.eventsChannel())
+	defer pubsub.Close()
+	if _, err := pubsub.Receive(ctx); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	if store.eventsChannel() != "test:execution:events" {
+		t.Errorf("Unexpected events channel %q", store.eventsChannel())
+	}
+
+	next := func() ExecutionEvent {
+		t.Helper()
+		select {
+		case msg := <-pubsub.Channel():
+			var event ExecutionEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				t.Fatalf("Invalid event payload: %v", err)
+			}
+			return event
+		case <-time.After(2 * time.Second):
+			t.Fatal("Timed out waiting for execution event")
+			return ExecutionEvent{}
+		}
+	}
+
+	execution := sampleExecution("req-events", false)
+	execution.InProgress = true
+	if err := store.Store(ctx, execution); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	event := next()
+	if event.Type != ExecutionEventStored || event.Summary.RequestID != "req-events" || !event.Summary.InProgress {
+		t.Errorf("Unexpected stored event: %+v", event)
+	}
+	if event.Summary.StepCount != len(execution.Result.Steps) {
+		t.Errorf("Expected %d steps in summary, got %d", len(execution.Result.Steps), event.Summary.StepCount)
+	}
+
+	execution.InProgress = false
+	if err := store.Update(ctx, "req-events", execution); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	event = next()
+	if event.Type != ExecutionEventUpdated || event.Summary.InProgress {
+		t.Errorf("Unexpected updated event: %+v", event)
+	}
+
+	if err := store.SetMetadata(ctx, "req-events", "note", "reviewed"); err != nil {
+		t.Fatalf("SetMetadata failed: %v", err)
+	}
+	if event = next(); event.Type != ExecutionEventUpdated || event.Summary.RequestID != "req-events" {
+		t.Errorf("Unexpected metadata event: %+v", event)
+	}
+}
+
+func TestRedisExecutionDebugStore_SearchIndex(t *testing.T) {
+	mr, client := setupCheckpointTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	store := &RedisExecutionDebugStore{
+		client:    client,
+		logger:    &core.NoOpLogger{},
+		keyPrefix: "test:execution:",
+		ttl:       time.Hour,
+		errorTTL:  2 * time.Hour,
+	}
+	ctx := context.Background()
+
+	if mr.Exists("test:execution:search:indexed_since") {
+		t.Fatal("Expected no index before the first store")
+	}
+
+	stale := sampleExecution("req-stale", true)
+	stale.OriginalRequest = "Weather in Oslo"
+	stale.CreatedAt = time.Now().Add(-3 * time.Hour)
+	tokyo := sampleExecution("req-tokyo", true)
+	tokyo.OriginalRequest = "Weather in Tokyo"
+	tokyo.CreatedAt = time.Now().Add(-time.Minute)
+	paris := sampleExecution("req-paris", true)
+	paris.OriginalRequest = "Weather in Paris"
+	for _, execution := range []*StoredExecution{stale, tokyo, paris} {
+		if err := store.Store(ctx, execution); err != nil {
+			t.Fatalf("Store failed: %v", err)
+		}
+	}
+
+	// Newest first, scored by created-at; entries older than the longest
+	// record TTL are trimmed
+	weather, err := client.ZRevRange(ctx, "test:execution:search:words:weather", 0, -1).Result()
+	if err != nil || len(weather) != 2 || weather[0] != "req-paris" || weather[1] != "req-tokyo" {
+		t.Errorf("Expected [req-paris req-tokyo] under 'weather', got %v (%v)", weather, err)
+	}
+	score, err := client.ZScore(ctx, "test:execution:search:words:tokyo", "req-tokyo").Result()
+	if err != nil || score != float64(tokyo.CreatedAt.UnixNano()) {
+		t.Errorf("Expected req-tokyo scored by its created-at time, got %v (%v)", score, err)
+	}
+
+	// Token sets last as long as the longest-lived record
+	if ttl := mr.TTL("test:execution:search:words:tokyo"); ttl != 2*time.Hour {
+		t.Errorf("Expected token TTL of 2h, got %v", ttl)
+	}
+	since, err := client.Get(ctx, "test:execution:search:indexed_since").Int64()
+	if err != nil || since != stale.CreatedAt.UnixNano() {
+		t.Errorf("Expected the marker to keep the first indexed created-at time, got %v (%v)", since, err)
+	}
+}
@@ -40,6 +40,8 @@ type redisDebugStoreConfig struct {
 	circuitBreaker core.CircuitBreaker // Interface - injected by application (optional)
 	ttl            time.Duration
 	errorTTL       time.Duration
+	redactor       LLMDebugRedactor
+	hashOnly       bool
 }
 
 // WithDebugRedisURL sets the Redis connection URL
@@ -87,6 +89,23 @@ func WithDebugErrorTTL(ttl time.Duration) RedisLLMDebugStoreOption {
 	}
 }
 
+// WithDebugRedactor sets a redactor applied to the prompt, system prompt and
+// response of every interaction before it is written, whichever component
+// recorded it. See DefaultLLMDebugRedactor for masking common PII.
+func WithDebugRedactor(redactor LLMDebugRedactor) RedisLLMDebugStoreOption {
+	return func(c *redisDebugStoreConfig) {
+		c.redactor = redactor
+	}
+}
+
+// WithDebugContentHashOnly stores token counts and SHA-256 hashes of the
+// prompt, system prompt and response instead of their text
+func WithDebugContentHashOnly() RedisLLMDebugStoreOption {
+	return func(c *redisDebugStoreConfig) {
+		c.hashOnly = true
+	}
+}
+
 // RedisLLMDebugStore is a Redis-backed implementation of LLMDebugStore.
 // It provides persistent storage with TTL-based cleanup, compression for large payloads,
 // and resilience protection.
@@ -101,6 +120,8 @@ type RedisLLMDebugStore struct {
 	circuitBreaker core.CircuitBreaker // Optional - injected by application
 	ttl            time.Duration
 	errorTTL       time.Duration
+	redactor       LLMDebugRedactor
+	hashOnly       bool
 
 	// Layer 1 resilience state (simple failure tracking)
 	failureCount int
@@ -156,6 +177,8 @@ func NewRedisLLMDebugStore(opts ...RedisLLMDebugStoreOption) (*RedisLLMDebugStor
 		"error_ttl":       cfg.errorTTL.String(),
 		"circuit_breaker": cfg.circuitBreaker != nil,
 		"resilience":      "layer1_builtin", // Always has Layer 1
+		"redaction":       cfg.redactor != nil,
+		"hash_only":       cfg.hashOnly,
 	})
 
 	return &RedisLLMDebugStore{
@@ -164,12 +187,16 @@ func NewRedisLLMDebugStore(opts ...RedisLLMDebugStoreOption) (*RedisLLMDebugStor
 		circuitBreaker: cfg.circuitBreaker,
 		ttl:            cfg.ttl,
 		errorTTL:       cfg.errorTTL,
+		redactor:       cfg.redactor,
+		hashOnly:       cfg.hashOnly,
 	}, nil
 }
 
 // RecordInteraction appends an LLM interaction to the debug record.
 // Uses Layer 2 circuit breaker if injected, otherwise falls back to Layer 1 simple retry.
 func (s *RedisLLMDebugStore) RecordInteraction(ctx context.Context, requestID string, interaction LLMInteraction) error {
+	interaction = redactLLMInteraction(interaction, s.redactor, s.hashOnly)
+
 	operation := func() error {
 		key := llmDebugKeyPrefix + requestID
 
@@ -452,6 +479,12 @@ func (s *RedisLLMDebugStore) serialize(record *LLMDebugRecord) ([]byte, error) {
 
 // deserialize with optional gzip decompression
 func (s *RedisLLMDebugStore) deserialize(data []byte) (*LLMDebugRecord, error) {
+	return decodeLLMDebugRecord(data)
+}
+
+// decodeLLMDebugRecord decodes a stored record: a compression flag byte
+// (0=raw, 1=gzip) followed by the JSON
+func decodeLLMDebugRecord(data []byte) (*LLMDebugRecord, error) {
 	if len(data) == 0 {
 		return nil, fmt.Errorf("empty data")
 	}
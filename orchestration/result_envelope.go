@@ -0,0 +1,87 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// unwrapResultEnvelope replaces an enveloped response with its data and copies
+// the envelope's success, error and metadata onto the step result. It only
+// applies to capabilities that advertise ResultEnvelope; raw responses from
+// other capabilities are left untouched.
+func (e *SmartExecutor) unwrapResultEnvelope(ctx context.Context, step RoutingStep, capability *EnhancedCapability, result *StepResult) {
+	if capability == nil || !capability.ResultEnvelope {
+		return
+	}
+
+	envelope, ok := core.ParseToolResponse([]byte(result.Response))
+	if !ok {
+		if e.logger != nil {
+			e.logger.WarnWithContext(ctx, "Capability advertises result envelope but response is not one", map[string]interface{}{
+				"operation":  "result_envelope_unwrap",
+				"step_id":    step.StepID,
+				"capability": capability.Name,
+			})
+		}
+		return
+	}
+
+	applyResultEnvelope(result, envelope)
+}
+
+// failureEnvelope returns the envelope of a failed call to a capability that
+// advertises ResultEnvelope. Such capabilities describe failures in the
+// envelope whatever the HTTP status, so the executor reads it before treating
+// a non-200 response as a plain status error.
+func failureEnvelope(capability *EnhancedCapability, responseBody string) (*core.ToolResponse, bool) {
+	if capability == nil || !capability.ResultEnvelope || responseBody == "" {
+		return nil, false
+	}
+	envelope, ok := core.ParseToolResponse([]byte(responseBody))
+	if !ok || envelope.Success {
+		return nil, false
+	}
+	return envelope, true
+}
+
+// applyResultEnvelope copies an envelope's outcome, timing and metadata onto
+// the step result
+func applyResultEnvelope(result *StepResult, envelope *core.ToolResponse) {
+	result.HandlerDuration = time.Duration(envelope.DurationMs) * time.Millisecond
+	if len(envelope.Metadata) > 0 {
+		if result.Metadata == nil {
+			result.Metadata = make(map[string]interface{})
+		}
+		// Nested so capability metadata can't clobber executor keys (e.g., HITL)
+		result.Metadata["result_metadata"] = envelope.Metadata
+	}
+
+	result.Success = envelope.Success
+	if !envelope.Success {
+		result.Response = ""
+		result.Error = envelopeErrorMessage(envelope)
+		return
+	}
+
+	switch data := envelope.Data.(type) {
+	case nil:
+		result.Response = ""
+	case string:
+		result.Response = data
+	default:
+		if encoded, err := json.Marshal(data); err == nil {
+			result.Response = string(encoded)
+		}
+	}
+}
+
+// envelopeErrorMessage describes a failure envelope's error
+func envelopeErrorMessage(envelope *core.ToolResponse) string {
+	if envelope.Error != nil {
+		return envelope.Error.Error()
+	}
+	return "capability reported failure"
+}
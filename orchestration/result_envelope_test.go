@@ -0,0 +1,103 @@
+package orchestration
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+func TestUnwrapResultEnvelope(t *testing.T) {
+	executor := NewSmartExecutor(nil)
+	ctx := context.Background()
+	step := RoutingStep{StepID: "step-1"}
+	enveloped := &EnhancedCapability{Name: "add", ResultEnvelope: true}
+
+	t.Run("success unwraps data and metadata", func(t *testing.T) {
+		result := StepResult{Success: true, Response: `{"success":true,"data":{"sum":5},"metadata":{"source":"cache"},"duration_ms":12}`}
+		executor.unwrapResultEnvelope(ctx, step, enveloped, &result)
+
+		if !result.Success || result.Response != `{"sum":5}` {
+			t.Errorf("Expected unwrapped data, got success=%v response=%q", result.Success, result.Response)
+		}
+		if result.HandlerDuration != 12*time.Millisecond {
+			t.Errorf("Expected handler duration 12ms, got %v", result.HandlerDuration)
+		}
+		if meta, _ := result.Metadata["result_metadata"].(map[string]interface{}); meta["source"] != "cache" {
+			t.Errorf("Expected nested result metadata, got %+v", result.Metadata)
+		}
+	})
+
+	t.Run("failure envelope marks step failed", func(t *testing.T) {
+		result := StepResult{Success: true, Response: `{"success":false,"error":{"code":"NOT_FOUND","message":"no such key","category":"NOT_FOUND"}}`}
+		executor.unwrapResultEnvelope(ctx, step, enveloped, &result)
+
+		if result.Success || result.Response != "" || result.Error == "" {
+			t.Errorf("Expected failed step with error, got %+v", result)
+		}
+	})
+
+	t.Run("capabilities without envelope are untouched", func(t *testing.T) {
+		raw := `{"success":true,"data":{"sum":5}}`
+		result := StepResult{Success: true, Response: raw}
+		executor.unwrapResultEnvelope(ctx, step, &EnhancedCapability{Name: "legacy"}, &result)
+
+		if result.Response != raw || result.Metadata != nil {
+			t.Errorf("Expected raw response to be preserved, got %+v", result)
+		}
+	})
+
+	t.Run("non-envelope response is kept as is", func(t *testing.T) {
+		result := StepResult{Success: true, Response: `{"sum":5}`}
+		executor.unwrapResultEnvelope(ctx, step, enveloped, &result)
+
+		if !result.Success || result.Response != `{"sum":5}` {
+			t.Errorf("Expected response to be preserved, got %+v", result)
+		}
+	})
+}
+
+func TestSmartExecutor_FailureEnvelope(t *testing.T) {
+	tool := core.NewTool("kv-tool")
+	tool.RegisterCapability(core.Capability{
+		Name: "get",
+		Execute: func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			core.SetResultMetadata(ctx, "shard", "eu-1")
+			return nil, &core.ToolError{Code: "KEY_NOT_FOUND", Message: "no such key", Category: core.CategoryNotFound}
+		},
+	})
+	server := httptest.NewServer(tool.Handler())
+	defer server.Close()
+	host, portStr, _ := net.SplitHostPort(server.Listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+
+	catalog := &AgentCatalog{
+		agents: map[string]*AgentInfo{
+			"kv-1": {
+				Registration: &core.ServiceRegistration{ID: "kv-1", Name: "kv-tool", Address: host, Port: port},
+				Capabilities: []EnhancedCapability{{Name: "get", Endpoint: "/api/capabilities/get", ResultEnvelope: true}},
+			},
+		},
+	}
+	executor := NewSmartExecutor(catalog)
+
+	result := executor.executeStep(context.Background(), RoutingStep{
+		StepID:    "step-1",
+		AgentName: "kv-tool",
+		Metadata:  map[string]interface{}{"capability": "get"},
+	})
+
+	if result.Success {
+		t.Fatal("Expected the step to fail")
+	}
+	if result.Error != "[KEY_NOT_FOUND] no such key" {
+		t.Errorf("Expected the envelope error instead of the status error, got %q", result.Error)
+	}
+	if meta, _ := result.Metadata["result_metadata"].(map[string]interface{}); meta["shard"] != "eu-1" {
+		t.Errorf("Expected the envelope metadata on a failed step, got %+v", result.Metadata)
+	}
+}
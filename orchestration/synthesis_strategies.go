@@ -0,0 +1,92 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// StrategySynthesizer implements one synthesis strategy: it turns the step
+// results of a plan into the final response. Register it on an AISynthesizer
+// under a strategy name, then select it with SetStrategy or
+// OrchestratorConfig.SynthesisStrategy.
+type StrategySynthesizer interface {
+	Synthesize(ctx context.Context, request string, results []StepResult) (string, error)
+}
+
+// StrategySynthesizerFunc adapts a function to StrategySynthesizer
+type StrategySynthesizerFunc func(ctx context.Context, request string, results []StepResult) (string, error)
+
+// Synthesize calls f
+func (f StrategySynthesizerFunc) Synthesize(ctx context.Context, request string, results []StepResult) (string, error) {
+	return f(ctx, request, results)
+}
+
+// RegisterStrategy makes strategy available under name. The built-in
+// strategies (llm, template, parallel, simple) are registered by default;
+// registering one of their names replaces it.
+//
+//	synthesizer.RegisterStrategy("markdown", orchestration.StrategySynthesizerFunc(
+//	    func(ctx context.Context, request string, results []orchestration.StepResult) (string, error) {
+//	        return renderMarkdownReport(request, results), nil
+//	    }))
+func (s *AISynthesizer) RegisterStrategy(name SynthesisStrategy, strategy StrategySynthesizer) error {
+	if name == "" {
+		return fmt.Errorf("synthesis strategy name is required")
+	}
+	if strategy == nil {
+		return fmt.Errorf("synthesis strategy %q is nil", name)
+	}
+
+	s.strategiesMu.Lock()
+	defer s.strategiesMu.Unlock()
+	s.initStrategiesLocked()
+	s.strategies[name] = strategy
+	return nil
+}
+
+// Strategies returns the names of all registered strategies, sorted
+func (s *AISynthesizer) Strategies() []SynthesisStrategy {
+	s.strategiesMu.Lock()
+	defer s.strategiesMu.Unlock()
+	s.initStrategiesLocked()
+
+	names := make([]SynthesisStrategy, 0, len(s.strategies))
+	for name := range s.strategies {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// lookupStrategy returns the strategy registered under name
+func (s *AISynthesizer) lookupStrategy(name SynthesisStrategy) (StrategySynthesizer, bool) {
+	s.strategiesMu.Lock()
+	defer s.strategiesMu.Unlock()
+	s.initStrategiesLocked()
+
+	strategy, ok := s.strategies[name]
+	return strategy, ok
+}
+
+// initStrategiesLocked registers the built-in strategies on first use, so a
+// zero-value AISynthesizer works too
+func (s *AISynthesizer) initStrategiesLocked() {
+	if s.strategies != nil {
+		return
+	}
+	s.strategies = map[SynthesisStrategy]StrategySynthesizer{
+		StrategyLLM: StrategySynthesizerFunc(func(ctx context.Context, request string, results []StepResult) (string, error) {
+			return s.synthesizeWithLLM(ctx, request, &ExecutionResult{Steps: results})
+		}),
+		StrategyTemplate: StrategySynthesizerFunc(func(ctx context.Context, request string, results []StepResult) (string, error) {
+			return s.synthesizeWithTemplate(request, &ExecutionResult{Steps: results})
+		}),
+		StrategyParallel: StrategySynthesizerFunc(func(ctx context.Context, request string, results []StepResult) (string, error) {
+			return s.synthesizeInParallel(ctx, request, &ExecutionResult{Steps: results})
+		}),
+		StrategySimple: StrategySynthesizerFunc(func(ctx context.Context, request string, results []StepResult) (string, error) {
+			return s.synthesizeSimple(&ExecutionResult{Steps: results})
+		}),
+	}
+}
@@ -24,6 +24,10 @@ type AISynthesizer struct {
 	debugStore LLMDebugStore
 	debugWg    sync.WaitGroup
 	debugSeqID atomic.Uint64
+
+	// Registered synthesis strategies, keyed by name (see RegisterStrategy)
+	strategies   map[SynthesisStrategy]StrategySynthesizer
+	strategiesMu sync.Mutex
 }
 
 // NewAISynthesizer creates a new AI-powered synthesizer
@@ -34,24 +38,50 @@ func NewAISynthesizer(aiClient core.AIClient) *AISynthesizer {
 	}
 }
 
-// Synthesize combines agent responses into a final response using AI
+// Synthesize combines agent responses into a final response using the
+// strategy set with SetStrategy. Unknown strategies fall back to StrategySimple.
 func (s *AISynthesizer) Synthesize(ctx context.Context, request string, results *ExecutionResult) (string, error) {
-	switch s.strategy {
-	case StrategyLLM:
-		return s.synthesizeWithLLM(ctx, request, results)
-	case StrategyTemplate:
-		return s.synthesizeWithTemplate(request, results)
-	case StrategySimple:
-		return s.synthesizeSimple(results)
-	default:
-		return s.synthesizeWithLLM(ctx, request, results)
+	strategy, ok := s.lookupStrategy(s.strategy)
+	if !ok {
+		if s.logger != nil {
+			s.logger.WarnWithContext(ctx, "Unknown synthesis strategy, falling back to simple", map[string]interface{}{
+				"operation": "synthesis",
+				"strategy":  string(s.strategy),
+			})
+		}
+		strategy, _ = s.lookupStrategy(StrategySimple)
 	}
+	return strategy.Synthesize(ctx, request, results.Steps)
 }
 
 // synthesizeWithLLM uses the LLM to create a coherent response
 func (s *AISynthesizer) synthesizeWithLLM(ctx context.Context, request string, results *ExecutionResult) (string, error) {
 	// Build prompt with all agent responses
 	prompt := s.buildSynthesisPrompt(request, results)
+
+	content, err := s.generateSynthesis(ctx, s.synthesisRequestID(ctx), request, prompt, len(results.Steps), LLMInteraction{Type: "synthesis"})
+	if err != nil {
+		return "", fmt.Errorf("synthesis failed: %w", err)
+	}
+	return content, nil
+}
+
+// synthesisRequestID returns the request ID from context baggage for debug
+// correlation, generating one when it is missing
+func (s *AISynthesizer) synthesisRequestID(ctx context.Context) string {
+	requestID := ""
+	if baggage := telemetry.GetBaggage(ctx); baggage != nil {
+		requestID = baggage["request_id"]
+	}
+	if requestID == "" {
+		requestID = s.generateFallbackRequestID()
+	}
+	return requestID
+}
+
+// generateSynthesis sends a synthesis prompt to the LLM and records the call.
+// interaction supplies the debug record's Type and any call-specific fields.
+func (s *AISynthesizer) generateSynthesis(ctx context.Context, requestID, request, prompt string, stepCount int, interaction LLMInteraction) (string, error) {
 	systemPrompt := "You are an AI that synthesizes multiple agent responses into coherent, helpful answers."
 
 	// Telemetry: Record LLM prompt for synthesis
@@ -59,19 +89,16 @@ func (s *AISynthesizer) synthesizeWithLLM(ctx context.Context, request string, r
 		attribute.String("original_request", truncateString(request, 500)),
 		attribute.String("prompt", truncateString(prompt, 2000)),
 		attribute.Int("prompt_length", len(prompt)),
-		attribute.Int("step_count", len(results.Steps)),
+		attribute.Int("step_count", stepCount),
 		attribute.Float64("temperature", 0.5),
 		attribute.Int("max_tokens", 1500),
 	)
 
-	// Get request ID from context baggage for debug correlation
-	requestID := ""
-	if baggage := telemetry.GetBaggage(ctx); baggage != nil {
-		requestID = baggage["request_id"]
-	}
-	if requestID == "" {
-		requestID = s.generateFallbackRequestID()
-	}
+	interaction.Prompt = prompt
+	interaction.SystemPrompt = systemPrompt
+	interaction.Temperature = 0.5
+	interaction.MaxTokens = 1500
+	interaction.Attempt = 1
 
 	// Call LLM for synthesis
 	llmStartTime := time.Now()
@@ -81,6 +108,8 @@ func (s *AISynthesizer) synthesizeWithLLM(ctx context.Context, request string, r
 		SystemPrompt: systemPrompt,
 	})
 	llmDuration := time.Since(llmStartTime)
+	interaction.Timestamp = llmStartTime
+	interaction.DurationMs = llmDuration.Milliseconds()
 
 	if err != nil {
 		telemetry.AddSpanEvent(ctx, "llm.synthesis.error",
@@ -89,20 +118,11 @@ func (s *AISynthesizer) synthesizeWithLLM(ctx context.Context, request string, r
 		)
 
 		// LLM Debug: Record failed synthesis attempt
-		s.recordDebugInteraction(ctx, requestID, LLMInteraction{
-			Type:         "synthesis",
-			Timestamp:    llmStartTime,
-			DurationMs:   llmDuration.Milliseconds(),
-			Prompt:       prompt,
-			SystemPrompt: systemPrompt,
-			Temperature:  0.5,
-			MaxTokens:    1500,
-			Success:      false,
-			Error:        err.Error(),
-			Attempt:      1,
-		})
+		interaction.Success = false
+		interaction.Error = err.Error()
+		s.recordDebugInteraction(ctx, requestID, interaction)
 
-		return "", fmt.Errorf("synthesis failed: %w", err)
+		return "", err
 	}
 
 	// Telemetry: Record LLM response for synthesis
@@ -116,23 +136,15 @@ func (s *AISynthesizer) synthesizeWithLLM(ctx context.Context, request string, r
 	)
 
 	// LLM Debug: Record successful synthesis
-	s.recordDebugInteraction(ctx, requestID, LLMInteraction{
-		Type:             "synthesis",
-		Timestamp:        llmStartTime,
-		DurationMs:       llmDuration.Milliseconds(),
-		Prompt:           prompt,
-		SystemPrompt:     systemPrompt,
-		Temperature:      0.5,
-		MaxTokens:        1500,
-		Model:            aiResponse.Model,
-		Provider:         aiResponse.Provider,
-		Response:         aiResponse.Content,
-		PromptTokens:     aiResponse.Usage.PromptTokens,
-		CompletionTokens: aiResponse.Usage.CompletionTokens,
-		TotalTokens:      aiResponse.Usage.TotalTokens,
-		Success:          true,
-		Attempt:          1,
-	})
+	interaction.Model = aiResponse.Model
+	interaction.Provider = aiResponse.Provider
+	interaction.IdempotencyKey = aiResponse.IdempotencyKey
+	interaction.Response = aiResponse.Content
+	interaction.PromptTokens = aiResponse.Usage.PromptTokens
+	interaction.CompletionTokens = aiResponse.Usage.CompletionTokens
+	interaction.TotalTokens = aiResponse.Usage.TotalTokens
+	interaction.Success = true
+	s.recordDebugInteraction(ctx, requestID, interaction)
 
 	return aiResponse.Content, nil
 }
@@ -143,40 +155,73 @@ func (s *AISynthesizer) buildSynthesisPrompt(request string, results *ExecutionR
 
 	builder.WriteString(fmt.Sprintf("User Request: %s\n\n", request))
 	builder.WriteString("Agent Responses:\n\n")
+	writeStepResults(&builder, results.Steps)
 
+	builder.WriteString("\nInstructions:\n")
+	builder.WriteString("1. Synthesize the above agent responses into a comprehensive answer\n")
+	builder.WriteString("2. Address the user's original request directly\n")
+	builder.WriteString("3. Combine information from multiple agents where relevant\n")
+	builder.WriteString("4. Highlight any important findings or recommendations\n")
+	builder.WriteString("5. Be concise but thorough\n")
+	builder.WriteString("6. If some agents failed, work with available information\n\n")
+	builder.WriteString("Synthesized Response:")
+
+	return builder.String()
+}
+
+// writeStepResults writes each step's response, skip reason or error for a
+// synthesis prompt
+func writeStepResults(builder *strings.Builder, steps []StepResult) {
 	// Include all successful step results
-	for _, step := range results.Steps {
+	for _, step := range steps {
 		if step.Success {
 			builder.WriteString(fmt.Sprintf("Agent: %s\n", step.AgentName))
 			builder.WriteString(fmt.Sprintf("Task: %s\n", step.Instruction))
 
-			// Try to parse and format the response
-			var responseData interface{}
-			if err := json.Unmarshal([]byte(step.Response), &responseData); err == nil {
-				// Successfully parsed as JSON
+			// Prefer the typed output decoded against the capability contract
+			if responseData, ok := stepOutputData(step); ok {
 				formatted, _ := json.MarshalIndent(responseData, "", "  ")
-				builder.WriteString(fmt.Sprintf("Response:\n%s\n\n", string(formatted)))
+				builder.WriteString(fmt.Sprintf("Response:\n%s\n", string(formatted)))
 			} else {
 				// Plain text response
-				builder.WriteString(fmt.Sprintf("Response: %s\n\n", step.Response))
+				builder.WriteString(fmt.Sprintf("Response: %s\n", step.Response))
+			}
+
+			// Hint synthesis about contract drift so it doesn't trust missing or mistyped fields
+			if step.ContractViolated {
+				builder.WriteString("Note: this response does not match the agent's declared output format (")
+				for i, v := range step.ContractViolations {
+					if i > 0 {
+						builder.WriteString("; ")
+					}
+					builder.WriteString(v.Message)
+				}
+				builder.WriteString("). Use it with caution.\n")
 			}
+			builder.WriteString("\n")
+		} else if step.Skipped {
+			// Skipped by a condition - not an error, but tell the LLM why it's missing
+			builder.WriteString(fmt.Sprintf("Agent: %s (SKIPPED)\n", step.AgentName))
+			builder.WriteString(fmt.Sprintf("Reason: %s\n\n", step.Error))
 		} else {
 			// Include error information
 			builder.WriteString(fmt.Sprintf("Agent: %s (FAILED)\n", step.AgentName))
 			builder.WriteString(fmt.Sprintf("Error: %s\n\n", step.Error))
 		}
 	}
+}
 
-	builder.WriteString("\nInstructions:\n")
-	builder.WriteString("1. Synthesize the above agent responses into a comprehensive answer\n")
-	builder.WriteString("2. Address the user's original request directly\n")
-	builder.WriteString("3. Combine information from multiple agents where relevant\n")
-	builder.WriteString("4. Highlight any important findings or recommendations\n")
-	builder.WriteString("5. Be concise but thorough\n")
-	builder.WriteString("6. If some agents failed, work with available information\n\n")
-	builder.WriteString("Synthesized Response:")
-
-	return builder.String()
+// stepOutputData returns the structured output of a step: the contract-decoded
+// Output when the executor set it, otherwise the response parsed as JSON
+func stepOutputData(step StepResult) (interface{}, bool) {
+	if step.Output != nil {
+		return step.Output, true
+	}
+	var data interface{}
+	if err := json.Unmarshal([]byte(step.Response), &data); err == nil {
+		return data, true
+	}
+	return nil, false
 }
 
 // synthesizeWithTemplate uses predefined templates for synthesis
@@ -201,9 +246,8 @@ func (s *AISynthesizer) synthesizeWithTemplate(request string, results *Executio
 		for _, step := range successful {
 			builder.WriteString(fmt.Sprintf("\n%s:\n", step.AgentName))
 
-			// Try to parse and present JSON nicely
-			var data interface{}
-			if err := json.Unmarshal([]byte(step.Response), &data); err == nil {
+			// Present typed output nicely when available
+			if data, ok := stepOutputData(step); ok {
 				formatted, _ := json.MarshalIndent(data, "  ", "  ")
 				builder.WriteString(string(formatted))
 			} else {
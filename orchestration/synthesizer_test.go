@@ -2,6 +2,7 @@ package orchestration
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -310,6 +311,60 @@ func TestSynthesisStrategies(t *testing.T) {
 	}
 }
 
+func TestAISynthesizer_RegisterStrategy(t *testing.T) {
+	aiClient := NewMockAIClient()
+	synthesizer := NewAISynthesizer(aiClient)
+
+	markdown := StrategySynthesizerFunc(func(ctx context.Context, request string, results []StepResult) (string, error) {
+		var builder strings.Builder
+		builder.WriteString("# " + request + "\n")
+		for _, step := range results {
+			builder.WriteString("- " + step.AgentName + "\n")
+		}
+		return builder.String(), nil
+	})
+	if err := synthesizer.RegisterStrategy("markdown", markdown); err != nil {
+		t.Fatalf("RegisterStrategy failed: %v", err)
+	}
+	if err := synthesizer.RegisterStrategy("", markdown); err == nil {
+		t.Error("Expected an error for an empty strategy name")
+	}
+	if err := synthesizer.RegisterStrategy("broken", nil); err == nil {
+		t.Error("Expected an error for a nil strategy")
+	}
+
+	want := []SynthesisStrategy{StrategyLLM, "markdown", StrategyParallel, StrategySimple, StrategyTemplate}
+	if got := synthesizer.Strategies(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected strategies %v, got %v", want, got)
+	}
+
+	results := &ExecutionResult{Steps: []StepResult{
+		{AgentName: "weather", Response: "sunny", Success: true},
+	}}
+
+	synthesizer.SetStrategy("markdown")
+	response, err := synthesizer.Synthesize(context.Background(), "Trip report", results)
+	if err != nil {
+		t.Fatalf("Custom synthesis failed: %v", err)
+	}
+	if response != "# Trip report\n- weather\n" {
+		t.Errorf("Unexpected custom synthesis output: %q", response)
+	}
+
+	// Unknown strategies fall back to simple, without calling the LLM
+	synthesizer.SetStrategy("unknown")
+	response, err = synthesizer.Synthesize(context.Background(), "Trip report", results)
+	if err != nil {
+		t.Fatalf("Fallback synthesis failed: %v", err)
+	}
+	if response != "weather: sunny" {
+		t.Errorf("Expected simple synthesis fallback, got %q", response)
+	}
+	if len(aiClient.calls) != 0 {
+		t.Errorf("Expected no LLM calls, got %d", len(aiClient.calls))
+	}
+}
+
 func BenchmarkSynthesizer_Simple(b *testing.B) {
 	synthesizer := &AISynthesizer{}
 	results := &ExecutionResult{
@@ -0,0 +1,195 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+const (
+	// DefaultTargetFailureThreshold is how many consecutive failures open a
+	// target's circuit
+	DefaultTargetFailureThreshold = 5
+
+	// DefaultTargetCooldown is how long a circuit stays open before a probe
+	DefaultTargetCooldown = 30 * time.Second
+
+	// DefaultMaxTargetCircuits caps how many service URLs have a circuit.
+	// When it is reached the least recently used circuit is dropped,
+	// preferring closed ones.
+	DefaultMaxTargetCircuits = 1000
+)
+
+// Circuit states reported by TargetCircuitState, matching core.CircuitBreaker
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// TargetCircuitState describes the circuit of one service URL
+type TargetCircuitState struct {
+	Target              string    `json:"target"`
+	State               string    `json:"state"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	OpenedAt            time.Time `json:"opened_at,omitempty"`
+}
+
+// targetCircuit is the circuit of one target
+type targetCircuit struct {
+	failures int
+	open     bool
+	openedAt time.Time
+	probing  bool
+	lastUsed time.Time
+}
+
+// targetBreakers keeps a circuit per service URL, so one failing instance
+// fails fast instead of making every caller wait for its timeout. mu guards
+// every field, so configure can run while calls are in flight.
+type targetBreakers struct {
+	mu          sync.Mutex
+	threshold   int
+	cooldown    time.Duration
+	maxCircuits int
+	circuits    map[string]*targetCircuit
+}
+
+func newTargetBreakers(threshold int, cooldown time.Duration) *targetBreakers {
+	b := &targetBreakers{maxCircuits: DefaultMaxTargetCircuits}
+	b.configure(threshold, cooldown)
+	return b
+}
+
+// configure replaces the limits and resets every circuit
+func (b *targetBreakers) configure(threshold int, cooldown time.Duration) {
+	if cooldown <= 0 {
+		cooldown = DefaultTargetCooldown
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.threshold = threshold
+	b.cooldown = cooldown
+	b.circuits = make(map[string]*targetCircuit)
+}
+
+// allow returns an error wrapping core.ErrCircuitBreakerOpen while target's
+// circuit is open. Once the cooldown has passed it lets a single probe
+// through.
+func (b *targetBreakers) allow(target string) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	circuit, ok := b.circuits[target]
+	if !ok || !circuit.open {
+		return nil
+	}
+	if circuit.probing || time.Since(circuit.openedAt) < b.cooldown {
+		return core.ErrCircuitBreakerOpen
+	}
+	circuit.probing = true
+	return nil
+}
+
+// record updates target's circuit with the outcome of a call. Network
+// errors and 5xx responses count as failures. Calls abandoned because the
+// caller's context ended say nothing about the target and are ignored.
+func (b *targetBreakers) record(ctx context.Context, target string, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.threshold <= 0 {
+		return
+	}
+
+	circuit, ok := b.circuits[target]
+	if !ok {
+		if len(b.circuits) >= b.maxCircuits {
+			b.evictLocked()
+		}
+		circuit = &targetCircuit{}
+		b.circuits[target] = circuit
+	}
+	circuit.lastUsed = time.Now()
+	probe := circuit.probing
+	circuit.probing = false
+
+	switch {
+	case ctx.Err() != nil:
+		return
+	case !isTargetFailure(err):
+		circuit.failures = 0
+		circuit.open = false
+		return
+	}
+
+	circuit.failures++
+	if probe || circuit.failures >= b.threshold {
+		circuit.open = true
+		circuit.openedAt = time.Now()
+	}
+}
+
+// evictLocked drops the least recently used circuit, preferring closed
+// ones so a failing target is not forgotten while its circuit is open
+func (b *targetBreakers) evictLocked() {
+	var victim string
+	var victimCircuit *targetCircuit
+	for target, circuit := range b.circuits {
+		if victimCircuit == nil ||
+			(victimCircuit.open && !circuit.open) ||
+			(victimCircuit.open == circuit.open && circuit.lastUsed.Before(victimCircuit.lastUsed)) {
+			victim, victimCircuit = target, circuit
+		}
+	}
+	delete(b.circuits, victim)
+}
+
+// states returns a snapshot of every circuit, sorted by target
+func (b *targetBreakers) states() []TargetCircuitState {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	states := make([]TargetCircuitState, 0, len(b.circuits))
+	for target, circuit := range b.circuits {
+		state := TargetCircuitState{Target: target, State: CircuitClosed, ConsecutiveFailures: circuit.failures}
+		if circuit.open {
+			state.State = CircuitOpen
+			state.OpenedAt = circuit.openedAt
+			if circuit.probing || time.Since(circuit.openedAt) >= b.cooldown {
+				state.State = CircuitHalfOpen
+			}
+		}
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Target < states[j].Target })
+	return states
+}
+
+// isTargetFailure reports whether err means the target itself is unhealthy
+func isTargetFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var callErr *ServiceCallError
+	if errors.As(err, &callErr) && callErr.StatusCode != 0 {
+		return callErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
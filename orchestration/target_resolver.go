@@ -0,0 +1,73 @@
+package orchestration
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrTargetNotResolved is returned by a TargetResolver that doesn't know a
+// target. The executor then falls back to discovery.
+var ErrTargetNotResolved = errors.New("target not resolved")
+
+// TargetResolver maps an agent name to the base URL it is called at, such as
+// "http://stock-agent:8080". Use it when agents are reachable under names
+// discovery doesn't know: mesh hostnames, or localhost ports in local
+// development.
+type TargetResolver interface {
+	ResolveTarget(name, namespace string) (string, error)
+}
+
+// KubernetesResolver resolves agents to Kubernetes Service DNS names:
+// http://<name>.<namespace>.svc.cluster.local:<port>
+type KubernetesResolver struct {
+	Port          int    // Service port, 80 if zero
+	ClusterDomain string // "cluster.local" if empty
+}
+
+// ResolveTarget implements TargetResolver. An empty namespace means
+// "default".
+func (r KubernetesResolver) ResolveTarget(name, namespace string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("empty agent name: %w", ErrTargetNotResolved)
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	port := r.Port
+	if port <= 0 {
+		port = 80
+	}
+	domain := r.ClusterDomain
+	if domain == "" {
+		domain = "cluster.local"
+	}
+	return fmt.Sprintf("http://%s.%s.svc.%s:%d", name, namespace, domain, port), nil
+}
+
+// StaticResolver resolves agents from a fixed name to URL map, for example
+// {"stock-agent": "http://localhost:8081"}. Unknown names fall through to
+// discovery.
+type StaticResolver map[string]string
+
+// ResolveTarget implements TargetResolver. A "<name>.<namespace>" entry
+// takes precedence over a plain "<name>" entry.
+func (r StaticResolver) ResolveTarget(name, namespace string) (string, error) {
+	if namespace != "" {
+		if url, ok := r[name+"."+namespace]; ok {
+			return strings.TrimSuffix(url, "/"), nil
+		}
+	}
+	if url, ok := r[name]; ok {
+		return strings.TrimSuffix(url, "/"), nil
+	}
+	return "", fmt.Errorf("agent %s: %w", name, ErrTargetNotResolved)
+}
+
+// SetTargetResolver makes CallAgent and CallAgentJSON ask resolver for an
+// agent's URL before discovery. namespace is passed to the resolver as is.
+// A nil resolver restores discovery-only lookups.
+func (e *WorkflowExecutor) SetTargetResolver(resolver TargetResolver, namespace string) {
+	e.resolver = resolver
+	e.namespace = namespace
+}
@@ -0,0 +1,92 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+func TestKubernetesResolver(t *testing.T) {
+	tests := []struct {
+		name      string
+		resolver  KubernetesResolver
+		namespace string
+		want      string
+	}{
+		{"defaults", KubernetesResolver{}, "", "http://stock-agent.default.svc.cluster.local:80"},
+		{"namespace and port", KubernetesResolver{Port: 8080}, "prod", "http://stock-agent.prod.svc.cluster.local:8080"},
+		{"custom domain", KubernetesResolver{ClusterDomain: "mesh.internal"}, "prod", "http://stock-agent.prod.svc.mesh.internal:80"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.resolver.ResolveTarget("stock-agent", tt.namespace)
+			if err != nil {
+				t.Fatalf("ResolveTarget() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveTarget() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStaticResolver(t *testing.T) {
+	resolver := StaticResolver{
+		"stock-agent":      "http://localhost:8081/",
+		"stock-agent.prod": "http://stock.prod.mesh:80",
+	}
+
+	if got, _ := resolver.ResolveTarget("stock-agent", "dev"); got != "http://localhost:8081" {
+		t.Errorf("ResolveTarget(dev) = %q, want the plain entry", got)
+	}
+	if got, _ := resolver.ResolveTarget("stock-agent", "prod"); got != "http://stock.prod.mesh:80" {
+		t.Errorf("ResolveTarget(prod) = %q, want the namespaced entry", got)
+	}
+	if _, err := resolver.ResolveTarget("news-agent", ""); !errors.Is(err, ErrTargetNotResolved) {
+		t.Errorf("ResolveTarget(unknown) error = %v, want ErrTargetNotResolved", err)
+	}
+}
+
+func TestWorkflowExecutorUsesTargetResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"agent": "local"}`))
+	}))
+	defer server.Close()
+
+	// No discovery: the resolver alone must be enough for local development
+	executor := NewWorkflowExecutor(nil, nil)
+	executor.SetTargetResolver(StaticResolver{"stock-agent": server.URL}, "")
+
+	result, err := executor.CallAgent(context.Background(), "stock-agent", "quote", nil)
+	if err != nil {
+		t.Fatalf("CallAgent() error = %v", err)
+	}
+	if result["agent"] != "local" {
+		t.Errorf("CallAgent() = %v, want the local server's response", result)
+	}
+
+	if _, err := executor.CallAgent(context.Background(), "news-agent", "latest", nil); !errors.Is(err, core.ErrAgentNotFound) {
+		t.Errorf("CallAgent(unresolved) error = %v, want ErrAgentNotFound", err)
+	}
+}
+
+func TestWorkflowExecutorResolverFallsBackToDiscovery(t *testing.T) {
+	discovery := registerTestAgent(t, "stock-agent", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"agent": "discovered"}`))
+	}))
+
+	executor := NewWorkflowExecutor(discovery, nil)
+	executor.SetTargetResolver(StaticResolver{}, "")
+
+	result, err := executor.CallAgent(context.Background(), "stock-agent", "quote", nil)
+	if err != nil {
+		t.Fatalf("CallAgent() error = %v", err)
+	}
+	if result["agent"] != "discovered" {
+		t.Errorf("CallAgent() = %v, want the discovered agent's response", result)
+	}
+}
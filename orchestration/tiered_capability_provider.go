@@ -274,8 +274,11 @@ func (t *TieredCapabilityProvider) GetCapabilities(
 	request string,
 	metadata map[string]interface{},
 ) (*CapabilityResult, error) {
+	// Only capabilities allowed by the request's DiscoveryFilter are offered
+	catalog := t.catalog.Scoped(GetDiscoveryFilter(ctx))
+
 	// Get all capability summaries
-	summaries := t.catalog.GetCapabilitySummaries()
+	summaries := catalog.GetCapabilitySummaries()
 
 	// Check if tiering is beneficial
 	if len(summaries) < t.MinToolsForTiering {
@@ -285,7 +288,7 @@ func (t *TieredCapabilityProvider) GetCapabilities(
 			"threshold":  t.MinToolsForTiering,
 		})
 		// Get all agent names from catalog
-		return t.buildResultFromAllAgents(), nil
+		return buildResultFromAllAgents(catalog), nil
 	}
 
 	// Tier 1: Select relevant tools using lightweight summaries
@@ -300,7 +303,7 @@ func (t *TieredCapabilityProvider) GetCapabilities(
 			"error":       err.Error(),
 			"duration_ms": tier1Duration.Milliseconds(),
 		})
-		return t.buildResultFromAllAgents(), nil
+		return buildResultFromAllAgents(catalog), nil
 	}
 
 	t.logInfoWithContext(ctx, "Tier 1 tool selection complete", map[string]interface{}{
@@ -328,7 +331,7 @@ func (t *TieredCapabilityProvider) GetCapabilities(
 
 	// Tier 2: Get full schemas for selected tools only
 	return &CapabilityResult{
-		FormattedInfo: t.catalog.FormatToolsForLLM(selectedTools),
+		FormattedInfo: catalog.FormatToolsForLLM(selectedTools),
 		AgentNames:    agentNames,
 	}, nil
 }
@@ -336,12 +339,12 @@ func (t *TieredCapabilityProvider) GetCapabilities(
 // buildResultFromAllAgents creates a CapabilityResult with all public agents from the catalog.
 // Used when tiering is not beneficial or as a fallback.
 // Uses GetPublicAgentNames() to ensure AgentNames matches the agents in FormattedInfo.
-func (t *TieredCapabilityProvider) buildResultFromAllAgents() *CapabilityResult {
+func buildResultFromAllAgents(catalog *AgentCatalog) *CapabilityResult {
 	// Use GetPublicAgentNames to match FormatForLLM filtering (excludes internal-only agents)
-	agentNames := t.catalog.GetPublicAgentNames()
+	agentNames := catalog.GetPublicAgentNames()
 
 	return &CapabilityResult{
-		FormattedInfo: t.catalog.FormatForLLM(),
+		FormattedInfo: catalog.FormatForLLM(),
 		AgentNames:    agentNames,
 	}
 }
@@ -488,6 +491,7 @@ func (t *TieredCapabilityProvider) selectRelevantTools(
 		MaxTokens:        500,
 		Model:            response.Model,
 		Provider:         response.Provider,
+		IdempotencyKey:   response.IdempotencyKey,
 		Response:         response.Content,
 		PromptTokens:     response.Usage.PromptTokens,
 		CompletionTokens: response.Usage.CompletionTokens,
@@ -0,0 +1,39 @@
+package orchestration
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/itsneelabh/gomind/core"
+	"github.com/itsneelabh/gomind/telemetry"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// SetPropagator sets how trace context is written into outgoing requests.
+// The default writes the W3C traceparent and tracestate headers. To also
+// send baggage, use
+//
+//	propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+//
+// A nil propagator restores the default.
+func (e *WorkflowExecutor) SetPropagator(propagator propagation.TextMapPropagator) {
+	e.propagator = propagator
+}
+
+// injectTraceHeaders writes the active span into header so the called
+// service continues the trace. Without an active span it falls back to the
+// X-Trace-ID header when a trace ID is in the baggage.
+func (e *WorkflowExecutor) injectTraceHeaders(ctx context.Context, header http.Header) {
+	if telemetry.GetTraceContext(ctx).TraceID == "" {
+		if traceID := telemetry.GetBaggage(ctx)["trace_id"]; traceID != "" {
+			header.Set(core.TraceIDHeader, traceID)
+		}
+		return
+	}
+
+	propagator := e.propagator
+	if propagator == nil {
+		propagator = propagation.TraceContext{}
+	}
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
@@ -0,0 +1,134 @@
+package orchestration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/itsneelabh/gomind/core"
+	"github.com/itsneelabh/gomind/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanContext returns ctx carrying a remote span, as after extracting
+// traceparent from an incoming request
+func spanContext(t *testing.T, ctx context.Context) context.Context {
+	t.Helper()
+	traceID, _ := trace.TraceIDFromHex("ad941a390c5c6d4d0f878eec73bdc478")
+	spanID, _ := trace.SpanIDFromHex("84834e2917631e82")
+	return trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+}
+
+// captureHeaders calls an agent with ctx and returns the headers it received
+func captureHeaders(t *testing.T, ctx context.Context, configure func(*WorkflowExecutor)) http.Header {
+	t.Helper()
+	headers := make(chan http.Header, 1)
+	discovery := registerTestAgent(t, "traced", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+
+	executor := NewWorkflowExecutor(discovery, nil)
+	if configure != nil {
+		configure(executor)
+	}
+	if _, err := executor.CallAgent(ctx, "traced", "work", nil); err != nil {
+		t.Fatalf("CallAgent() error = %v", err)
+	}
+	return <-headers
+}
+
+func TestWorkflowExecutorPropagatesTraceparent(t *testing.T) {
+	headers := captureHeaders(t, spanContext(t, context.Background()), nil)
+
+	want := "00-ad941a390c5c6d4d0f878eec73bdc478-84834e2917631e82-01"
+	if got := headers.Get("traceparent"); got != want {
+		t.Errorf("traceparent = %q, want %q", got, want)
+	}
+	if got := headers.Get(core.TraceIDHeader); got != "" {
+		t.Errorf("%s = %q, want no fallback header with an active span", core.TraceIDHeader, got)
+	}
+}
+
+func TestWorkflowExecutorFallsBackToTraceIDHeader(t *testing.T) {
+	ctx := telemetry.WithBaggage(context.Background(), "trace_id", "abc123")
+	headers := captureHeaders(t, ctx, nil)
+
+	if got := headers.Get(core.TraceIDHeader); got != "abc123" {
+		t.Errorf("%s = %q, want abc123", core.TraceIDHeader, got)
+	}
+	if got := headers.Get("traceparent"); got != "" {
+		t.Errorf("traceparent = %q, want none without an active span", got)
+	}
+}
+
+func TestWorkflowExecutorIgnoresGlobalPropagator(t *testing.T) {
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	member, _ := baggage.NewMember("tenant", "acme")
+	bag, _ := baggage.New(member)
+	ctx := baggage.ContextWithBaggage(spanContext(t, context.Background()), bag)
+	headers := captureHeaders(t, ctx, nil)
+
+	if got := headers.Get("baggage"); got != "" {
+		t.Errorf("baggage = %q, want none from the default propagator", got)
+	}
+	want := "00-ad941a390c5c6d4d0f878eec73bdc478-84834e2917631e82-01"
+	if got := headers.Get("traceparent"); got != want {
+		t.Errorf("traceparent = %q, want %q", got, want)
+	}
+}
+
+func TestWorkflowExecutorCustomPropagator(t *testing.T) {
+	member, _ := baggage.NewMember("tenant", "acme")
+	bag, _ := baggage.New(member)
+	ctx := baggage.ContextWithBaggage(spanContext(t, context.Background()), bag)
+
+	headers := captureHeaders(t, ctx, func(e *WorkflowExecutor) {
+		e.SetPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	})
+
+	if headers.Get("traceparent") == "" {
+		t.Error("traceparent missing with custom propagator")
+	}
+	if got := headers.Get("baggage"); got != "tenant=acme" {
+		t.Errorf("baggage = %q, want tenant=acme", got)
+	}
+}
+
+func TestWorkflowExecutorForwardsRequestMetadata(t *testing.T) {
+	ctx := core.WithRequestMetadata(context.Background(), map[string]string{"tenant_id": "acme", "user_id": "u-1"})
+	headers := captureHeaders(t, ctx, nil)
+
+	if got := headers.Get(core.BaggageHeader); got != "tenant_id=acme,user_id=u-1" {
+		t.Errorf("baggage = %q, want the request metadata", got)
+	}
+}
+
+func TestSmartExecutorForwardsRequestMetadata(t *testing.T) {
+	headers := make(chan http.Header, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	ctx := core.WithRequestMetadata(context.Background(), map[string]string{"request_id": "r-1"})
+	if _, _, err := NewSmartExecutor(nil).callComponentWithBody(ctx, server.URL, []byte(`{}`)); err != nil {
+		t.Fatalf("callComponentWithBody() error = %v", err)
+	}
+	if got := (<-headers).Get(core.BaggageHeader); got != "request_id=r-1" {
+		t.Errorf("baggage = %q, want request_id=r-1", got)
+	}
+}
@@ -201,17 +201,37 @@ func (d *WorkflowDAG) MarkNodeFailed(nodeID string) {
 	}
 }
 
-// markDependentsSkipped marks all dependents of a failed node as skipped
-func (d *WorkflowDAG) markDependentsSkipped(nodeID string) {
+// MarkNodeSkipped marks a pending node and all of its pending dependents as
+// skipped, e.g. when a step's condition evaluates to false. It returns the
+// IDs of every node that was skipped, starting with nodeID.
+func (d *WorkflowDAG) MarkNodeSkipped(nodeID string) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	node, exists := d.nodes[nodeID]
+	if !exists || node.Status != NodePending {
+		return nil
+	}
+	node.Status = NodeSkipped
+
+	return append([]string{nodeID}, d.markDependentsSkipped(nodeID)...)
+}
+
+// markDependentsSkipped marks all dependents of a failed or skipped node as
+// skipped and returns their IDs
+func (d *WorkflowDAG) markDependentsSkipped(nodeID string) []string {
 	node := d.nodes[nodeID]
+	var skipped []string
 
 	for _, dependent := range node.Dependents {
 		if depNode := d.nodes[dependent]; depNode != nil && depNode.Status == NodePending {
 			depNode.Status = NodeSkipped
+			skipped = append(skipped, dependent)
 			// Recursively skip their dependents
-			d.markDependentsSkipped(dependent)
+			skipped = append(skipped, d.markDependentsSkipped(dependent)...)
 		}
 	}
+	return skipped
 }
 
 // IsComplete checks if all nodes are in a terminal state
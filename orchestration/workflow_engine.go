@@ -119,7 +119,7 @@ const (
 
 // StepCondition defines conditional execution
 type StepCondition struct {
-	If   string `yaml:"if" json:"if"`     // Expression like ${steps.step1.output.success}
+	If   string `yaml:"if" json:"if"`     // Expression like ${steps.step1.output.success}; the step and its dependents are skipped when false
 	Then string `yaml:"then" json:"then"` // Step to execute if true
 	Else string `yaml:"else" json:"else"` // Step to execute if false
 }
@@ -184,12 +184,8 @@ func NewWorkflowEngine(discovery core.Discovery, stateStore StateStore, logger c
 		logger = &core.NoOpLogger{}
 	}
 	return &WorkflowEngine{
-		discovery: discovery,
-		executor: &WorkflowExecutor{
-			discovery: discovery,
-			client:    NewWorkflowHTTPClient(),
-			logger:    logger,
-		},
+		discovery:  discovery,
+		executor:   NewWorkflowExecutor(discovery, logger),
 		stateStore: stateStore,
 		metrics:    NewWorkflowMetrics(),
 		logger:     logger,
@@ -340,6 +336,11 @@ func (e *WorkflowEngine) ExecuteWorkflow(ctx context.Context, workflow *Workflow
 
 // executeDAG executes the workflow DAG with parallel support
 func (e *WorkflowEngine) executeDAG(ctx context.Context, execution *WorkflowExecution, workflow *WorkflowDefinition) error {
+	conditions, err := compileWorkflowConditions(workflow)
+	if err != nil {
+		return err
+	}
+
 	// Create channels for coordination
 	taskQueue := make(chan *WorkflowTask, 100)
 	results := make(chan *TaskResult, 100)
@@ -459,6 +460,11 @@ func (e *WorkflowEngine) executeDAG(ctx context.Context, execution *WorkflowExec
 
 			// Submit ready nodes
 			for _, nodeID := range readyNodes {
+				if condition := conditions[nodeID]; condition != nil && !condition.Evaluate(workflowConditionResolver(execution)) {
+					e.skipWorkflowStep(ctx, execution, nodeID, condition)
+					continue
+				}
+
 				stepDef := e.findStepDefinition(workflow, nodeID)
 
 				task := &WorkflowTask{
@@ -661,14 +667,14 @@ func (e *WorkflowEngine) executeStep(ctx context.Context, task *WorkflowTask) *T
 		// Find by specific agent name
 		services, err := e.discovery.FindService(ctx, stepDef.Agent)
 		if err == nil && len(services) > 0 {
-			service = e.selectBestService(services)
+			service = e.selectBestService(stepDef.Agent, services)
 			stepExec.AgentUsed = service.Name
 		}
 	} else if stepDef.Capability != "" {
 		// Find by capability
 		services, err := e.discovery.FindByCapability(ctx, stepDef.Capability)
 		if err == nil && len(services) > 0 {
-			service = e.selectBestService(services)
+			service = e.selectBestService(stepDef.Capability, services)
 			stepExec.AgentUsed = service.Name
 		}
 	}
@@ -686,14 +692,6 @@ func (e *WorkflowEngine) executeStep(ctx context.Context, task *WorkflowTask) *T
 		}
 	}
 
-	// Apply timeout
-	stepCtx := ctx
-	if stepDef.Timeout > 0 {
-		var cancel context.CancelFunc
-		stepCtx, cancel = context.WithTimeout(ctx, stepDef.Timeout)
-		defer cancel()
-	}
-
 	// Execute with retry if configured
 	var output map[string]interface{}
 	maxAttempts := 1
@@ -701,6 +699,19 @@ func (e *WorkflowEngine) executeStep(ctx context.Context, task *WorkflowTask) *T
 		maxAttempts = stepDef.Retry.MaxAttempts
 	}
 
+	// Apply timeout. It covers all attempts together, so retries share one
+	// budget instead of each getting the executor's full default timeout.
+	stepCtx := ctx
+	stepTimeout := stepDef.Timeout
+	if stepTimeout <= 0 && maxAttempts > 1 && e.executor != nil && e.executor.client != nil {
+		stepTimeout = e.executor.client.timeout
+	}
+	if stepTimeout > 0 {
+		var cancel context.CancelFunc
+		stepCtx, cancel = context.WithTimeout(ctx, stepTimeout)
+		defer cancel()
+	}
+
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		stepExec.Attempts = attempt
 
@@ -719,7 +730,7 @@ func (e *WorkflowEngine) executeStep(ctx context.Context, task *WorkflowTask) *T
 			case <-stepCtx.Done():
 				return &TaskResult{
 					StepID: task.StepID,
-					Error:  stepCtx.Err(),
+					Error:  fmt.Errorf("step %s: %w after %d attempts: %w", task.StepID, core.ErrTimeout, attempt, stepCtx.Err()),
 				}
 			}
 		}
@@ -917,6 +928,11 @@ func (e *WorkflowEngine) validateWorkflow(workflow *WorkflowDefinition) error {
 		}
 	}
 
+	// Validate step conditions
+	if _, err := compileWorkflowConditions(workflow); err != nil {
+		return err
+	}
+
 	// Validate HITL configuration
 	if err := e.validateHITLConfig(workflow); err != nil {
 		return fmt.Errorf("HITL configuration error: %w", err)
@@ -966,18 +982,14 @@ func (e *WorkflowEngine) validateHITLConfig(workflow *WorkflowDefinition) error
 
 // Helper methods
 
-func (e *WorkflowEngine) selectBestService(services []*core.ServiceRegistration) *core.ServiceRegistration {
-	// Simple selection - pick first healthy service
-	for _, svc := range services {
-		if svc.Health == core.HealthHealthy {
-			return svc
-		}
+// selectBestService spreads steps across healthy instances using the
+// executor's selector
+func (e *WorkflowEngine) selectBestService(key string, services []*core.ServiceRegistration) *core.ServiceRegistration {
+	executor := e.executor
+	if executor == nil {
+		executor = &WorkflowExecutor{}
 	}
-	// If no healthy service, return first
-	if len(services) > 0 {
-		return services[0]
-	}
-	return nil
+	return executor.selectService(key, services)
 }
 
 func (e *WorkflowEngine) findStepDefinition(workflow *WorkflowDefinition, stepID string) *WorkflowStepDefinition {
@@ -4,15 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"runtime/debug"
+	"strings"
 	"time"
 
 	"github.com/itsneelabh/gomind/core"
 	"github.com/itsneelabh/gomind/telemetry"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 // WorkflowExecutor handles service calls for workflow steps
@@ -20,17 +22,38 @@ type WorkflowExecutor struct {
 	discovery core.Discovery
 	client    *WorkflowHTTPClient
 	logger    core.Logger // For structured logging
+
+	// selector spreads calls across healthy instances
+	selector *core.ServiceSelector
+
+	// breakers fail calls fast to services that keep failing
+	breakers *targetBreakers
+
+	// resolver, if set, maps agent names to URLs before discovery is asked
+	resolver  TargetResolver
+	namespace string
+
+	// propagator writes trace context into requests; nil means W3C traceparent
+	propagator propagation.TextMapPropagator
+
+	// maxBodyBytes limits request and response bodies; 0 means no limit
+	maxBodyBytes int64
 }
 
 // WorkflowHTTPClient wraps HTTP client for service calls
 type WorkflowHTTPClient struct {
 	httpClient *http.Client
+
+	// timeout bounds calls whose context has no deadline
+	timeout time.Duration
 }
 
 // NewWorkflowHTTPClient creates a new HTTP client for workflows.
-// Uses TracedHTTPClient for distributed tracing context propagation.
+// Uses TracedHTTPClient for client spans. Trace headers are written by the
+// executor's own propagator, so the transport is given an empty one and does
+// not overwrite them with the global propagator.
 func NewWorkflowHTTPClient() *WorkflowHTTPClient {
-	tracedClient := telemetry.NewTracedHTTPClient(nil)
+	tracedClient := telemetry.NewTracedHTTPClientWithPropagator(nil, propagation.NewCompositeTextMapPropagator())
 
 	// Configurable timeout: GOMIND_ORCHESTRATION_TIMEOUT (default: 60s)
 	// For long-running AI workflows, set to higher values (e.g., "5m", "10m")
@@ -40,24 +63,143 @@ func NewWorkflowHTTPClient() *WorkflowHTTPClient {
 			timeout = parsed
 		}
 	}
-	tracedClient.Timeout = timeout
 
+	// The timeout is applied per call rather than as Client.Timeout, so a
+	// caller's context deadline or WithCallTimeout can override it
 	return &WorkflowHTTPClient{
 		httpClient: tracedClient,
+		timeout:    timeout,
+	}
+}
+
+// NewWorkflowExecutor creates an executor that finds services through
+// discovery and calls them over HTTP. It can be used on its own to call
+// agents and tools outside a workflow.
+func NewWorkflowExecutor(discovery core.Discovery, logger core.Logger) *WorkflowExecutor {
+	if logger == nil {
+		logger = &core.NoOpLogger{}
+	}
+	return &WorkflowExecutor{
+		discovery: discovery,
+		client:    NewWorkflowHTTPClient(),
+		logger:    logger,
+		selector:  core.NewServiceSelector(discovery),
+		breakers:  newTargetBreakers(DefaultTargetFailureThreshold, DefaultTargetCooldown),
+	}
+}
+
+// ServiceCallError is returned when a service responds with a non-2xx
+// status, or with StatusCode 0 when the call was refused before it was sent
+type ServiceCallError struct {
+	URL        string
+	StatusCode int
+	Body       string          // Raw response body
+	ToolError  *core.ToolError // Decoded error when the body is a ToolResponse envelope
+	Err        error           // Why the call was not sent, e.g. core.ErrCircuitBreakerOpen
+}
+
+func (e *ServiceCallError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("calling %s: %v", e.URL, e.Err)
 	}
+	return fmt.Sprintf("service returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// Unwrap returns the reason the call was not sent
+func (e *ServiceCallError) Unwrap() error {
+	return e.Err
+}
+
+// SetCircuitBreaker configures the per-target circuit breakers. After
+// threshold consecutive network errors or 5xx responses from a service URL,
+// calls to it fail immediately with a *ServiceCallError wrapping
+// core.ErrCircuitBreakerOpen. After cooldown a single probe is let through;
+// its success closes the circuit. A threshold of 0 disables the breakers.
+// Existing circuits are reset. It is safe to call while calls are in flight.
+func (e *WorkflowExecutor) SetCircuitBreaker(threshold int, cooldown time.Duration) {
+	e.breakers.configure(threshold, cooldown)
+}
+
+// CircuitStates returns the circuit of every service URL called so far, up
+// to DefaultMaxTargetCircuits of the most recently used
+func (e *WorkflowExecutor) CircuitStates() []TargetCircuitState {
+	return e.breakers.states()
 }
 
 // CallService calls a service endpoint with the given action and inputs
-func (e *WorkflowExecutor) CallService(ctx context.Context, service *core.ServiceRegistration, action string, inputs map[string]interface{}) (map[string]interface{}, error) {
-	// Construct service URL
-	url := fmt.Sprintf("http://%s:%d/%s", service.Address, service.Port, action)
+func (e *WorkflowExecutor) CallService(ctx context.Context, service *core.ServiceRegistration, action string, inputs map[string]interface{}, opts ...CallOption) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := e.CallServiceJSON(ctx, service, action, inputs, &result, opts...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CallServiceJSON posts payload as JSON to a service endpoint and decodes
+// the response into out. out may be nil to discard the response. A non-2xx
+// response returns a *ServiceCallError.
+func (e *WorkflowExecutor) CallServiceJSON(ctx context.Context, service *core.ServiceRegistration, action string, payload interface{}, out interface{}, opts ...CallOption) error {
+	return e.callTargetJSON(ctx, fmt.Sprintf("http://%s:%d", service.Address, service.Port), action, payload, out, opts)
+}
+
+// callTargetJSON posts payload to action on the service at the target base
+// URL and decodes the response into out
+func (e *WorkflowExecutor) callTargetJSON(ctx context.Context, target string, action string, payload interface{}, out interface{}, opts []CallOption) error {
+	resp, err := e.send(ctx, target, action, payload, opts)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Read response
+	responseBody, err := e.readBody(resp)
+	if err != nil {
+		return err
+	}
+
+	// Parse response
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.Unmarshal(responseBody, out); err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+
+	return nil
+}
+
+// send posts payload as JSON to action on the service at the target base
+// URL and returns the 2xx response for the caller to read and close. The
+// call's timeout ends when the body is closed. Circuits are kept per
+// target, not per action.
+func (e *WorkflowExecutor) send(ctx context.Context, target string, action string, payload interface{}, opts []CallOption) (*http.Response, error) {
+	url := strings.TrimSuffix(target, "/") + "/" + action
 
 	// Prepare request body
-	requestBody, err := json.Marshal(inputs)
+	requestBody, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("marshaling request: %w", err)
 	}
+	if e.maxBodyBytes > 0 && int64(len(requestBody)) > e.maxBodyBytes {
+		return nil, &ServiceCallError{URL: url, Err: ErrRequestTooLarge}
+	}
+
+	if err := e.breakers.allow(target); err != nil {
+		return nil, &ServiceCallError{URL: url, Err: err}
+	}
+	callCtx, cancel := e.callContext(ctx, opts)
+	resp, err := e.post(callCtx, url, requestBody)
+	e.breakers.record(ctx, target, err)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
 
+// post sends a JSON request and returns the response if its status is 2xx
+func (e *WorkflowExecutor) post(ctx context.Context, url string, requestBody []byte) (*http.Response, error) {
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(requestBody))
 	if err != nil {
@@ -65,71 +207,93 @@ func (e *WorkflowExecutor) CallService(ctx context.Context, service *core.Servic
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	if workflowID := ctx.Value("workflow_id"); workflowID != nil {
-		req.Header.Set("X-Workflow-ID", workflowID.(string))
+	req.Header.Set("Accept", "application/json")
+	if workflowID, ok := ctx.Value("workflow_id").(string); ok {
+		req.Header.Set("X-Workflow-ID", workflowID)
 	}
-	if stepID := ctx.Value("step_id"); stepID != nil {
-		req.Header.Set("X-Step-ID", stepID.(string))
+	if stepID, ok := ctx.Value("step_id").(string); ok {
+		req.Header.Set("X-Step-ID", stepID)
 	}
+	e.injectTraceHeaders(ctx, req.Header)
+	core.InjectRequestMetadata(ctx, req.Header)
 
 	// Execute request
 	resp, err := e.client.httpClient.Do(req)
 	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, &ServiceCallError{URL: url, Err: fmt.Errorf("%w: %w", core.ErrTimeout, err)}
+		}
 		return nil, fmt.Errorf("calling service: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	// Read response
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
-	}
 
 	// Check status code
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("service returned status %d: %s", resp.StatusCode, string(responseBody))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer func() { _ = resp.Body.Close() }()
+		responseBody, err := e.readBody(resp)
+		if err != nil && !errors.Is(err, ErrResponseTooLarge) {
+			return nil, err
+		}
+		callErr := &ServiceCallError{URL: url, StatusCode: resp.StatusCode, Body: string(responseBody)}
+		if envelope, ok := core.ParseToolResponse(responseBody); ok {
+			callErr.ToolError = envelope.Error
+		}
+		return nil, callErr
 	}
 
-	// Parse response
-	var result map[string]interface{}
-	if err := json.Unmarshal(responseBody, &result); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
+	return resp, nil
+}
 
-	return result, nil
+// CallAgentJSON finds an agent through the target resolver or discovery,
+// posts payload to its action endpoint as JSON and decodes the response
+// into out
+func (e *WorkflowExecutor) CallAgentJSON(ctx context.Context, agentName string, action string, payload interface{}, out interface{}, opts ...CallOption) error {
+	target, err := e.resolveAgent(ctx, agentName)
+	if err != nil {
+		return err
+	}
+	return e.callTargetJSON(ctx, target, action, payload, out, opts)
 }
 
-// CallAgent calls an agent with discovery lookup
-func (e *WorkflowExecutor) CallAgent(ctx context.Context, agentName string, action string, inputs map[string]interface{}) (map[string]interface{}, error) {
-	// Find agent using discovery
+// resolveAgent returns the base URL of an agent: from the target resolver
+// if one is set and knows the agent, otherwise from discovery
+func (e *WorkflowExecutor) resolveAgent(ctx context.Context, agentName string) (string, error) {
+	if e.resolver != nil {
+		target, err := e.resolver.ResolveTarget(agentName, e.namespace)
+		if err == nil {
+			return target, nil
+		}
+		if !errors.Is(err, ErrTargetNotResolved) {
+			return "", fmt.Errorf("resolving agent %s: %w", agentName, err)
+		}
+	}
+	if e.discovery == nil {
+		return "", fmt.Errorf("agent %s: %w", agentName, core.ErrAgentNotFound)
+	}
+
 	services, err := e.discovery.FindService(ctx, agentName)
 	if err != nil {
-		return nil, fmt.Errorf("finding agent %s: %w", agentName, err)
+		return "", fmt.Errorf("finding agent %s: %w", agentName, err)
 	}
 
 	if len(services) == 0 {
-		return nil, fmt.Errorf("agent %s: %w", agentName, core.ErrAgentNotFound)
+		return "", fmt.Errorf("agent %s: %w", agentName, core.ErrAgentNotFound)
 	}
 
-	// Select best service (first healthy one)
-	var service *core.ServiceRegistration
-	for _, svc := range services {
-		if svc.Health == core.HealthHealthy {
-			service = svc
-			break
-		}
-	}
+	service := e.selectService(agentName, services)
+	return fmt.Sprintf("http://%s:%d", service.Address, service.Port), nil
+}
 
-	if service == nil {
-		// No healthy service, use first one
-		service = services[0]
+// CallAgent calls an agent with discovery lookup
+func (e *WorkflowExecutor) CallAgent(ctx context.Context, agentName string, action string, inputs map[string]interface{}, opts ...CallOption) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := e.CallAgentJSON(ctx, agentName, action, inputs, &result, opts...); err != nil {
+		return nil, err
 	}
-
-	return e.CallService(ctx, service, action, inputs)
+	return result, nil
 }
 
 // CallCapability calls any service with the specified capability
-func (e *WorkflowExecutor) CallCapability(ctx context.Context, capability string, action string, inputs map[string]interface{}) (map[string]interface{}, error) {
+func (e *WorkflowExecutor) CallCapability(ctx context.Context, capability string, action string, inputs map[string]interface{}, opts ...CallOption) (map[string]interface{}, error) {
 	// Find services by capability
 	services, err := e.discovery.FindByCapability(ctx, capability)
 	if err != nil {
@@ -140,20 +304,27 @@ func (e *WorkflowExecutor) CallCapability(ctx context.Context, capability string
 		return nil, fmt.Errorf("no services with capability %s: %w", capability, core.ErrCapabilityNotFound)
 	}
 
-	// Select best service
-	var service *core.ServiceRegistration
+	return e.CallService(ctx, e.selectService(capability, services), action, inputs, opts...)
+}
+
+// selectService picks a healthy service round-robin. If none is healthy
+// and recently seen, it falls back to the first healthy one, then to the
+// first one.
+func (e *WorkflowExecutor) selectService(key string, services []*core.ServiceRegistration) *core.ServiceRegistration {
+	if len(services) == 0 {
+		return nil
+	}
+	if e.selector != nil {
+		if service, err := e.selector.SelectFrom(key, services, core.SelectRoundRobin); err == nil {
+			return service
+		}
+	}
 	for _, svc := range services {
 		if svc.Health == core.HealthHealthy {
-			service = svc
-			break
+			return svc
 		}
 	}
-
-	if service == nil {
-		service = services[0]
-	}
-
-	return e.CallService(ctx, service, action, inputs)
+	return services[0]
 }
 
 // BatchCall executes multiple service calls in parallel
@@ -270,6 +441,9 @@ type ServiceCallResult struct {
 func (e *WorkflowExecutor) HealthCheck(ctx context.Context, service *core.ServiceRegistration) bool {
 	url := fmt.Sprintf("http://%s:%d/health", service.Address, service.Port)
 
+	ctx, cancel := e.callContext(ctx, nil)
+	defer cancel()
+
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return false
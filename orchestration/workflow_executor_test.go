@@ -0,0 +1,226 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itsneelabh/gomind/core"
+)
+
+// registerTestAgent registers an httptest server under name in a mock discovery
+func registerTestAgent(t *testing.T, name string, handler http.Handler) core.Discovery {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("parsing server address: %v", err)
+	}
+	port, _ := strconv.Atoi(portStr)
+
+	discovery := core.NewMockDiscovery()
+	if err := discovery.Register(context.Background(), &core.ServiceInfo{
+		ID: name + "-1", Name: name, Address: host, Port: port, Health: core.HealthHealthy,
+	}); err != nil {
+		t.Fatalf("registering agent: %v", err)
+	}
+	return discovery
+}
+
+func TestWorkflowExecutorCallAgentJSON(t *testing.T) {
+	type quoteRequest struct {
+		Symbol string `json:"symbol"`
+	}
+	type quoteResponse struct {
+		Symbol string  `json:"symbol"`
+		Price  float64 `json:"price"`
+	}
+
+	discovery := registerTestAgent(t, "stocks", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/quote" {
+			t.Errorf("path = %q, want /quote", r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		var req quoteRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(quoteResponse{Symbol: req.Symbol, Price: 42.5})
+	}))
+
+	executor := NewWorkflowExecutor(discovery, nil)
+	var out quoteResponse
+	if err := executor.CallAgentJSON(context.Background(), "stocks", "quote", quoteRequest{Symbol: "ACME"}, &out); err != nil {
+		t.Fatalf("CallAgentJSON() error = %v", err)
+	}
+	if out.Symbol != "ACME" || out.Price != 42.5 {
+		t.Errorf("CallAgentJSON() out = %+v", out)
+	}
+}
+
+func TestWorkflowExecutorCallAgentJSONErrorStatus(t *testing.T) {
+	discovery := registerTestAgent(t, "stocks", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(core.ToolResponse{
+			Success: false,
+			Error:   &core.ToolError{Code: "SYMBOL_NOT_FOUND", Message: "unknown symbol", Category: core.CategoryNotFound},
+		})
+	}))
+
+	executor := NewWorkflowExecutor(discovery, nil)
+	err := executor.CallAgentJSON(context.Background(), "stocks", "quote", map[string]string{"symbol": "NOPE"}, nil)
+
+	var callErr *ServiceCallError
+	if !errors.As(err, &callErr) {
+		t.Fatalf("CallAgentJSON() error = %v, want *ServiceCallError", err)
+	}
+	if callErr.StatusCode != http.StatusNotFound {
+		t.Errorf("StatusCode = %d, want 404", callErr.StatusCode)
+	}
+	if callErr.ToolError == nil || callErr.ToolError.Code != "SYMBOL_NOT_FOUND" {
+		t.Errorf("ToolError = %+v, want SYMBOL_NOT_FOUND", callErr.ToolError)
+	}
+}
+
+func TestWorkflowExecutorCallAgentJSONUnknownAgent(t *testing.T) {
+	executor := NewWorkflowExecutor(core.NewMockDiscovery(), nil)
+	err := executor.CallAgentJSON(context.Background(), "missing", "quote", nil, nil)
+	if !errors.Is(err, core.ErrAgentNotFound) {
+		t.Errorf("CallAgentJSON() error = %v, want ErrAgentNotFound", err)
+	}
+}
+
+func TestWorkflowExecutorCircuitBreaker(t *testing.T) {
+	var calls, failing atomic.Int32
+	failing.Store(1)
+	discovery := registerTestAgent(t, "flaky", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if failing.Load() == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+
+	executor := NewWorkflowExecutor(discovery, nil)
+	executor.SetCircuitBreaker(2, 50*time.Millisecond)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := executor.CallAgent(ctx, "flaky", "work", nil); err == nil {
+			t.Fatalf("call %d succeeded, want 503 error", i)
+		}
+	}
+
+	_, err := executor.CallAgent(ctx, "flaky", "work", nil)
+	var callErr *ServiceCallError
+	if !errors.As(err, &callErr) || !errors.Is(err, core.ErrCircuitBreakerOpen) {
+		t.Fatalf("call with open circuit error = %v, want *ServiceCallError wrapping ErrCircuitBreakerOpen", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("server calls = %d, want 2 while the circuit is open", calls.Load())
+	}
+	states := executor.CircuitStates()
+	if len(states) != 1 || states[0].State != CircuitOpen || states[0].ConsecutiveFailures != 2 {
+		t.Errorf("CircuitStates() = %+v, want one open circuit", states)
+	}
+
+	// After the cooldown a single probe closes the circuit again
+	time.Sleep(60 * time.Millisecond)
+	failing.Store(0)
+	if _, err := executor.CallAgent(ctx, "flaky", "work", nil); err != nil {
+		t.Fatalf("probe call error = %v", err)
+	}
+	if states := executor.CircuitStates(); states[0].State != CircuitClosed {
+		t.Errorf("state after probe = %s, want closed", states[0].State)
+	}
+}
+
+func TestWorkflowExecutorCircuitIgnoresClientErrors(t *testing.T) {
+	discovery := registerTestAgent(t, "strict", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+
+	executor := NewWorkflowExecutor(discovery, nil)
+	executor.SetCircuitBreaker(1, time.Minute)
+	for i := 0; i < 3; i++ {
+		_, err := executor.CallAgent(context.Background(), "strict", "work", nil)
+		if errors.Is(err, core.ErrCircuitBreakerOpen) {
+			t.Fatalf("call %d: circuit opened on 4xx responses", i)
+		}
+	}
+}
+
+func TestTargetBreakersEvictsLeastRecentlyUsed(t *testing.T) {
+	breakers := newTargetBreakers(1, time.Minute)
+	breakers.maxCircuits = 2
+	ctx := context.Background()
+
+	breakers.record(ctx, "http://open:80", errors.New("connection refused"))
+	breakers.record(ctx, "http://old:80", nil)
+	breakers.record(ctx, "http://new:80", nil)
+
+	states := breakers.states()
+	if len(states) != 2 || states[0].Target != "http://new:80" || states[1].Target != "http://open:80" {
+		t.Errorf("states() = %+v, want the closed circuit evicted before the open one", states)
+	}
+}
+
+func TestTargetBreakersConfigureWhileCalling(t *testing.T) {
+	breakers := newTargetBreakers(1, time.Minute)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = breakers.allow("http://a:80")
+				breakers.record(ctx, "http://a:80", errors.New("connection refused"))
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		breakers.configure(i%3, time.Minute)
+	}
+	wg.Wait()
+}
+
+func TestTargetBreakersFailedProbeReopens(t *testing.T) {
+	breakers := newTargetBreakers(1, 10*time.Millisecond)
+	ctx := context.Background()
+	failure := errors.New("connection refused")
+
+	breakers.record(ctx, "http://a:80", failure)
+	if err := breakers.allow("http://a:80"); !errors.Is(err, core.ErrCircuitBreakerOpen) {
+		t.Fatalf("allow() = %v, want open", err)
+	}
+
+	time.Sleep(15 * time.Millisecond)
+	if err := breakers.allow("http://a:80"); err != nil {
+		t.Fatalf("probe allow() = %v, want nil", err)
+	}
+	if err := breakers.allow("http://a:80"); !errors.Is(err, core.ErrCircuitBreakerOpen) {
+		t.Errorf("second request during probe allowed, want only one probe")
+	}
+
+	breakers.record(ctx, "http://a:80", failure)
+	if err := breakers.allow("http://a:80"); !errors.Is(err, core.ErrCircuitBreakerOpen) {
+		t.Errorf("allow() after failed probe = %v, want open", err)
+	}
+}
@@ -29,6 +29,15 @@ func Histogram(name string, value float64, labels ...string) {
 	Emit(name, value, labels...)
 }
 
+// HistogramWithContext records a value in a distribution like Histogram.
+// When exemplars are enabled in Config, the active span in ctx is attached
+// to the recording so a latency spike can be traced back to its request.
+// Baggage labels from ctx are included, as with EmitWithContext.
+// Example: HistogramWithContext(ctx, "latency.ms", 125.3, "endpoint", "/api/users")
+func HistogramWithContext(ctx context.Context, name string, value float64, labels ...string) {
+	EmitWithContext(ctx, name, value, labels...)
+}
+
 // Gauge sets a gauge value (current value metrics).
 // Use for values that go up and down: active connections, memory usage, queue size.
 // For increment/decrement, use positive/negative values.
@@ -37,13 +46,12 @@ func Gauge(name string, value float64, labels ...string) {
 	// Implementation note: We record gauges as histograms internally
 	// because OpenTelemetry gauges require callbacks. This gives us
 	// similar functionality without the complexity.
-	registry := globalRegistry.Load()
-	if registry != nil {
-		r := registry.(*Registry)
+	if r := GetRegistry(); r != nil {
 		// Mark this as a gauge internally for proper handling
 		_ = r.metrics.RecordHistogram(context.Background(), name, value)
 	}
-	Emit(name, value, labels...)
+	recordForTesting(name, value, labels, true)
+	emitToRegistry(context.Background(), name, value, labels...)
 }
 
 // Duration records elapsed time since startTime in milliseconds.
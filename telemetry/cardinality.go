@@ -2,9 +2,70 @@ package telemetry
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// CardinalityDropMetric is the self-metric incremented whenever the
+// cardinality limiter rejects a label combination. It carries a single
+// "metric" label naming the metric that exceeded its limit.
+const CardinalityDropMetric = "telemetry.dropped_series.total"
+
+// CardinalityDropFunc is called with the metric name and the labels as
+// they were before limiting, so the offending values are visible.
+type CardinalityDropFunc func(metricName string, labels map[string]string)
+
+// cardinalityDropHandler holds the CardinalityDropFunc set by OnCardinalityDrop
+var cardinalityDropHandler atomic.Value
+
+// OnCardinalityDrop registers a callback invoked whenever the cardinality
+// limiter rejects a new series. The callback runs synchronously on the
+// emitting goroutine, so it should be fast; panics are recovered.
+// Pass nil to remove a previously registered callback.
+//
+// Example:
+//
+//	telemetry.OnCardinalityDrop(func(metric string, labels map[string]string) {
+//	    log.Printf("cardinality limit hit for %s: %v", metric, labels)
+//	})
+func OnCardinalityDrop(fn CardinalityDropFunc) {
+	cardinalityDropHandler.Store(fn)
+}
+
+// recordCardinalityDrop reports a rejected series through the self-metric,
+// the internal counter, and the registered callback
+func (r *Registry) recordCardinalityDrop(name string, labels map[string]string) {
+	telemetrySeriesDropped.Add(1)
+
+	// Record directly on the provider so the self-metric bypasses the limiter
+	if r.provider != nil && name != CardinalityDropMetric {
+		r.provider.RecordMetric(CardinalityDropMetric, 1, map[string]string{"metric": name})
+	}
+
+	fn, _ := cardinalityDropHandler.Load().(CardinalityDropFunc)
+	if fn == nil {
+		return
+	}
+	defer func() {
+		if rec := recover(); rec != nil && r.logger != nil && r.errorLimiter != nil && r.errorLimiter.Allow() {
+			r.logger.Error("Cardinality drop callback panicked", map[string]interface{}{
+				"metric": name,
+				"panic":  rec,
+			})
+		}
+	}()
+	fn(name, labels)
+}
+
+// copyLabels returns a shallow copy of a label map
+func copyLabels(labels map[string]string) map[string]string {
+	out := make(map[string]string, len(labels))
+	for k, v := range labels {
+		out[k] = v
+	}
+	return out
+}
+
 // CardinalityLimiter prevents unbounded metric cardinality
 type CardinalityLimiter struct {
 	limits map[string]int
@@ -11,8 +11,12 @@ type Config struct {
 	Endpoint    string
 	Provider    string // "otel", "prometheus", "statsd"
 
-	// Sampling configuration
+	// Sampling configuration. Traces are sampled only when Sampler (or
+	// OTEL_TRACES_SAMPLER) says so; by default every trace is kept.
+	// SamplingRate is the profile's suggested rate and is not applied to
+	// trace sampling; copy it into Sampler.Ratio to use it.
 	SamplingRate float64
+	Sampler      SamplerConfig
 
 	// Cardinality control
 	CardinalityLimit  int
@@ -21,9 +25,53 @@ type Config struct {
 	// Circuit breaker configuration
 	CircuitBreaker CircuitConfig
 
-	// PII redaction
+	// PII redaction. When enabled, Initialize installs core.NewPIIRedactor
+	// (extended with PIIPatterns) as the shared core.SetRedactor policy,
+	// which redacts both log fields and metric label values. Off in every
+	// profile, since it changes the process-wide policy; opt in explicitly.
 	PIIRedaction bool
 	PIIPatterns  []string
+
+	// ServiceVersion is reported as the service.version resource attribute.
+	// Default: service.version from OTEL_RESOURCE_ATTRIBUTES, or 1.0.0.
+	ServiceVersion string
+
+	// ResourceAttributes are added to the resource attached to every trace
+	// and metric, such as deployment.environment. They are merged with
+	// OTEL_RESOURCE_ATTRIBUTES and win on conflicts; service.name,
+	// service.type and ServiceVersion win over both.
+	ResourceAttributes map[string]string
+
+	// Exemplars attaches the active span's trace and span IDs to metric
+	// recordings made with a context (EmitWithContext, HistogramWithContext).
+	// Disabled by default because exemplars increase export payload size.
+	Exemplars bool
+}
+
+// InitOption adjusts the Config passed to Initialize
+type InitOption func(*Config)
+
+// WithServiceVersion sets the service.version resource attribute
+func WithServiceVersion(version string) InitOption {
+	return func(c *Config) {
+		c.ServiceVersion = version
+	}
+}
+
+// WithResourceAttributes adds resource attributes to every trace and
+// metric. Attributes from repeated calls are merged, with later values
+// winning.
+func WithResourceAttributes(attributes map[string]string) InitOption {
+	return func(c *Config) {
+		merged := make(map[string]string, len(c.ResourceAttributes)+len(attributes))
+		for key, value := range c.ResourceAttributes {
+			merged[key] = value
+		}
+		for key, value := range attributes {
+			merged[key] = value
+		}
+		c.ResourceAttributes = merged
+	}
 }
 
 // Profile represents a pre-configured telemetry profile
@@ -57,7 +105,6 @@ var Profiles = map[Profile]Config{
 			MaxFailures:  10,
 			RecoveryTime: 15 * time.Second,
 		},
-		PIIRedaction: true,
 	},
 	ProfileProduction: {
 		Enabled:          true,
@@ -70,7 +117,6 @@ var Profiles = map[Profile]Config{
 			RecoveryTime: 30 * time.Second,
 			HalfOpenMax:  5,
 		},
-		PIIRedaction: true,
 		CardinalityLimits: map[string]int{
 			"agent_id":   100,
 			"capability": 50,
@@ -110,6 +156,18 @@ func (c Config) WithOverrides(overrides Config) Config {
 	if overrides.SamplingRate > 0 {
 		c.SamplingRate = overrides.SamplingRate
 	}
+	if overrides.Sampler.Type != "" {
+		c.Sampler.Type = overrides.Sampler.Type
+	}
+	if overrides.Sampler.Ratio > 0 {
+		c.Sampler.Ratio = overrides.Sampler.Ratio
+	}
+	if overrides.Sampler.KeepErrors {
+		c.Sampler.KeepErrors = overrides.Sampler.KeepErrors
+	}
+	if overrides.Sampler.KeepSlowerThan > 0 {
+		c.Sampler.KeepSlowerThan = overrides.Sampler.KeepSlowerThan
+	}
 	if overrides.CardinalityLimit > 0 {
 		c.CardinalityLimit = overrides.CardinalityLimit
 	}
@@ -125,6 +183,15 @@ func (c Config) WithOverrides(overrides Config) Config {
 	if len(overrides.PIIPatterns) > 0 {
 		c.PIIPatterns = overrides.PIIPatterns
 	}
+	if overrides.Exemplars {
+		c.Exemplars = overrides.Exemplars
+	}
+	if overrides.ServiceVersion != "" {
+		c.ServiceVersion = overrides.ServiceVersion
+	}
+	if overrides.ResourceAttributes != nil {
+		c.ResourceAttributes = overrides.ResourceAttributes
+	}
 
 	return c
 }
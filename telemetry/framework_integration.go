@@ -2,6 +2,8 @@ package telemetry
 
 import (
 	"context"
+	"net/http"
+
 	"github.com/itsneelabh/gomind/core"
 )
 
@@ -82,6 +84,12 @@ func (f *FrameworkMetricsRegistry) GetBaggage(ctx context.Context) map[string]st
 	return result
 }
 
+// WithBaggage implements the optional baggage support core uses to mirror
+// core.WithRequestMetadata into OpenTelemetry baggage. See WithBaggage.
+func (f *FrameworkMetricsRegistry) WithBaggage(ctx context.Context, labels ...string) context.Context {
+	return WithBaggage(ctx, labels...)
+}
+
 // Gauge implements core.MetricsRegistry
 func (f *FrameworkMetricsRegistry) Gauge(name string, value float64, labels ...string) {
 	if f.logger != nil && f.logger.debug {
@@ -114,6 +122,40 @@ func (f *FrameworkMetricsRegistry) Histogram(name string, value float64, labels
 	Histogram(name, value, labels...)
 }
 
+// Shutdown flushes and stops the telemetry system, so core.Framework can
+// shut telemetry down as the last stage of a graceful shutdown. Calling it
+// after telemetry has already been shut down does nothing.
+func (f *FrameworkMetricsRegistry) Shutdown(ctx context.Context) error {
+	return Shutdown(ctx)
+}
+
+// StartSpan implements the optional span support core uses to trace
+// capability invocations on components without their own Telemetry. It
+// returns a no-op span until telemetry is initialized.
+func (f *FrameworkMetricsRegistry) StartSpan(ctx context.Context, name string) (context.Context, core.Span) {
+	if r := GetRegistry(); r != nil && r.provider != nil {
+		return r.provider.StartSpan(ctx, name)
+	}
+	return ctx, disabledSpan
+}
+
+// SetSamplingRate lets core apply a reloaded telemetry.sampling_rate. It
+// reports false when telemetry is not initialized or its sampler has no
+// ratio. See OTelProvider.SetSamplingRate.
+func (f *FrameworkMetricsRegistry) SetSamplingRate(rate float64) bool {
+	if r := GetRegistry(); r != nil && r.provider != nil {
+		return r.provider.SetSamplingRate(rate)
+	}
+	return false
+}
+
+// MetricsHandler serves the framework's metrics for Prometheus to scrape, so
+// core can mount it at the path set with core.WithPrometheusEndpoint. See
+// PrometheusHandler.
+func (f *FrameworkMetricsRegistry) MetricsHandler() http.Handler {
+	return PrometheusHandler()
+}
+
 // EnableFrameworkIntegration registers the telemetry module with core
 // This must be called after telemetry initialization to enable framework-wide metrics
 func EnableFrameworkIntegration(logger *TelemetryLogger) {
@@ -12,6 +12,7 @@ type Health struct {
 	Provider        string `json:"provider"`
 	MetricsEmitted  int64  `json:"metrics_emitted"`
 	MetricsDropped  int64  `json:"metrics_dropped"`
+	SeriesDropped   int64  `json:"series_dropped"`
 	Errors          int64  `json:"errors"`
 	LastError       string `json:"last_error,omitempty"`
 	CircuitState    string `json:"circuit_state"`
@@ -66,6 +67,7 @@ func GetHealth() Health {
 		Provider:        "otel", // Could be dynamic based on provider type
 		MetricsEmitted:  r.emitted.Load(),
 		MetricsDropped:  telemetryDropped.Load(),
+		SeriesDropped:   telemetrySeriesDropped.Load(),
 		Errors:          telemetryErrors.Load(),
 		LastError:       lastErr,
 		CircuitState:    circuitState,
@@ -100,9 +102,10 @@ func HealthHandler(w http.ResponseWriter, r *http.Request) {
 
 // InternalMetrics returns internal telemetry metrics for monitoring
 type InternalMetrics struct {
-	Errors  int64 `json:"errors"`
-	Dropped int64 `json:"dropped"`
-	Emitted int64 `json:"emitted"`
+	Errors        int64 `json:"errors"`
+	Dropped       int64 `json:"dropped"`
+	DroppedSeries int64 `json:"dropped_series"`
+	Emitted       int64 `json:"emitted"`
 }
 
 // GetInternalMetrics returns internal telemetry metrics
@@ -115,9 +118,10 @@ func GetInternalMetrics() InternalMetrics {
 	}
 
 	return InternalMetrics{
-		Errors:  telemetryErrors.Load(),
-		Dropped: telemetryDropped.Load(),
-		Emitted: emitted,
+		Errors:        telemetryErrors.Load(),
+		Dropped:       telemetryDropped.Load(),
+		DroppedSeries: telemetrySeriesDropped.Load(),
+		Emitted:       emitted,
 	}
 }
 
@@ -125,10 +129,9 @@ func GetInternalMetrics() InternalMetrics {
 func ResetInternalMetrics() {
 	telemetryErrors.Store(0)
 	telemetryDropped.Store(0)
+	telemetrySeriesDropped.Store(0)
 
-	registry := globalRegistry.Load()
-	if registry != nil {
-		r := registry.(*Registry)
+	if r := GetRegistry(); r != nil {
 		r.emitted.Store(0)
 	}
 }
@@ -41,6 +41,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 // TracingMiddlewareConfig configures the tracing middleware behavior.
@@ -188,6 +189,36 @@ func NewTracedHTTPClient(baseTransport http.RoundTripper) *http.Client {
 	}
 }
 
+// NewTracedHTTPClientWithPropagator creates a traced HTTP client that writes
+// trace context with propagator instead of the global propagator set by
+// telemetry.Initialize(). Callers that inject their own headers can pass an
+// empty composite propagator so the transport only records spans and leaves
+// the request headers alone.
+//
+// Parameters:
+//   - baseTransport: The underlying transport to use. If nil, uses http.DefaultTransport.
+//   - propagator: The propagator used to inject headers. If nil, uses the global propagator.
+//
+// Example:
+//
+//	// Record client spans without touching the request headers
+//	client := telemetry.NewTracedHTTPClientWithPropagator(nil,
+//	    propagation.NewCompositeTextMapPropagator())
+func NewTracedHTTPClientWithPropagator(baseTransport http.RoundTripper, propagator propagation.TextMapPropagator) *http.Client {
+	if baseTransport == nil {
+		baseTransport = http.DefaultTransport
+	}
+
+	var opts []otelhttp.Option
+	if propagator != nil {
+		opts = append(opts, otelhttp.WithPropagators(propagator))
+	}
+
+	return &http.Client{
+		Transport: otelhttp.NewTransport(baseTransport, opts...),
+	}
+}
+
 // NewTracedHTTPClientWithTransport creates a traced HTTP client with a custom transport.
 //
 // This is a convenience function that creates a traced client with connection
@@ -3,6 +3,7 @@ package telemetry
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"sync"
 	"time"
@@ -10,23 +11,20 @@ import (
 	"github.com/itsneelabh/gomind/core"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
 // OTelProvider implements core.Telemetry with OpenTelemetry.
 // This is the main integration point between GoMind and OpenTelemetry.
-// It manages both tracing and metrics, exporting them via OTLP/HTTP.
+// It manages both tracing and metrics, exporting them via OTLP.
 //
 // Design decisions:
-//   - Uses HTTP instead of gRPC for smaller binary size
+//   - Uses OTLP/HTTP by default; OTLP/gRPC is selected with OTEL_EXPORTER_OTLP_PROTOCOL
 //   - Batches exports to reduce network overhead
 //   - Provides both traces and metrics from a single provider
 type OTelProvider struct {
@@ -38,20 +36,42 @@ type OTelProvider struct {
 	shutdownOnce   sync.Once                // Ensures shutdown happens only once
 	shutdown       bool                     // Tracks if provider is shutdown
 	mu             sync.RWMutex             // Protects shutdown flag
+	exemplars      bool                     // Attach trace exemplars to context-aware recordings
+	prometheus     http.Handler             // Serves metrics when the Prometheus exporter is in use
+	ratio          *ratioSampler            // Adjustable ratio of the ratio samplers, nil for the others
 }
 
-// NewOTelProvider creates a new OpenTelemetry provider using HTTP exporters.
+// NewOTelProvider creates a new OpenTelemetry provider using OTLP exporters.
 // This sets up the complete telemetry pipeline:
-//  1. Creates HTTP exporters for traces and metrics
+//  1. Creates OTLP exporters for traces and metrics
 //  2. Configures batching for efficient export
 //  3. Sets up global providers for SDK access
 //
-// The endpoint should be an OTLP/HTTP endpoint (typically port 4318).
-// For backward compatibility, gRPC ports (4317) are automatically converted.
+// The exporters use OTLP/HTTP (typically port 4318) unless
+// OTEL_EXPORTER_OTLP_PROTOCOL, or the per-signal _TRACES_/_METRICS_ variant,
+// is set to "grpc". endpoint is the base endpoint for both signals; the
+// per-signal OTEL_EXPORTER_OTLP_TRACES_ENDPOINT and _METRICS_ENDPOINT
+// override it. An unsupported protocol is an error.
+// The default endpoint for the other protocol (localhost:4317 or
+// localhost:4318) is converted to the selected one's.
 // The serviceType parameter should be "tool" or "agent" to enable dashboard segregation.
 func NewOTelProvider(serviceName, serviceType, endpoint string) (*OTelProvider, error) {
+	return newOTelProvider(Config{ServiceName: serviceName, ServiceType: serviceType, Endpoint: endpoint})
+}
+
+// newOTelProvider builds the provider from config's service identity,
+// endpoint, sampling and exemplar settings. When config.Exemplars is true
+// the meter provider samples exemplars from sampled spans in the recording
+// context; otherwise exemplar collection is switched off entirely.
+func newOTelProvider(config Config) (*OTelProvider, error) {
 	logger := GetLogger()
 	startTime := time.Now()
+	serviceName, serviceType, endpoint := config.ServiceName, config.ServiceType, config.Endpoint
+	exemplars := config.Exemplars
+
+	// Only an explicit Sampler (or OTEL_TRACES_SAMPLER) samples traces; the
+	// profiles' SamplingRate is not applied, so every trace is kept by default
+	sampling := config.Sampler
 
 	// Validate service name
 	if serviceName == "" {
@@ -68,134 +88,182 @@ func NewOTelProvider(serviceName, serviceType, endpoint string) (*OTelProvider,
 		serviceType = os.Getenv("GOMIND_SERVICE_TYPE")
 	}
 
-	logger.Info("Creating OpenTelemetry provider", map[string]interface{}{
-		"service_name": serviceName,
-		"service_type": serviceType,
-		"endpoint":     endpoint,
-		"protocol":     "OTLP/HTTP",
-	})
-
-	// Normalize endpoint - support both old gRPC and new HTTP formats
-	if endpoint == "" {
-		endpoint = "localhost:4318" // Default HTTP endpoint
+	sampler, sampling, ratio, err := newSampler(sampling, logger)
+	if err != nil {
+		logger.Error("Invalid trace sampler configuration", map[string]interface{}{
+			"error":  err.Error(),
+			"action": "Check Config.Sampler",
+			"impact": "No traces or metrics will be exported",
+		})
+		return nil, fmt.Errorf("invalid trace sampler configuration: %w", err)
 	}
-	// Auto-convert gRPC port to HTTP for backward compatibility
-	if endpoint == "localhost:4317" {
-		endpoint = "localhost:4318"
+
+	// Resolve protocol and endpoint for each signal. An unsupported protocol
+	// is reported rather than silently replaced with the default.
+	// Metrics are pulled rather than pushed when Prometheus is the provider.
+	usePrometheus := isPrometheusProvider(config.Provider)
+	traceTarget, err := resolveOTLPTarget("traces", endpoint)
+	var metricTarget otlpTarget
+	if err == nil && !usePrometheus {
+		metricTarget, err = resolveOTLPTarget("metrics", endpoint)
 	}
-	// Strip http:// or https:// prefix if present
-	// OTLP exporters expect just host:port, not a full URL
-	if len(endpoint) > 7 && endpoint[:7] == "http://" {
-		endpoint = endpoint[7:]
-	} else if len(endpoint) > 8 && endpoint[:8] == "https://" {
-		endpoint = endpoint[8:]
+	if err != nil {
+		logger.Error("Invalid OTLP exporter configuration", map[string]interface{}{
+			"error":    err.Error(),
+			"endpoint": endpoint,
+			"action":   "Set OTEL_EXPORTER_OTLP_PROTOCOL to grpc or http/protobuf and check the OTLP endpoint variables",
+			"impact":   "No traces or metrics will be exported",
+		})
+		return nil, fmt.Errorf("invalid OTLP exporter configuration: %w", err)
 	}
 
-	// Create resource with consistent schema
-	logger.Debug("Creating OpenTelemetry resource", map[string]interface{}{
-		"service_name": serviceName,
-		"service_type": serviceType,
-		"version":      "1.0.0",
-		"schema_url":   semconv.SchemaURL,
-	})
-
-	// Build resource attributes
-	attrs := []attribute.KeyValue{
-		semconv.ServiceNameKey.String(serviceName),
-		semconv.ServiceVersionKey.String("1.0.0"),
-	}
-	// Add service.type if provided (enables tool vs agent segregation in dashboards)
-	if serviceType != "" {
-		attrs = append(attrs, attribute.String("service.type", serviceType))
+	metricsEndpoint, metricsProtocol := metricTarget.url(), metricTarget.protocol
+	metricExporterName, metricReaderName := "OTLP "+metricTarget.protocol, "PeriodicReader"
+	if usePrometheus {
+		metricsEndpoint, metricsProtocol = "scraped from PrometheusHandler", "prometheus"
+		metricExporterName, metricReaderName = "Prometheus", "Prometheus pull"
 	}
 
-	res := resource.NewWithAttributes(
-		semconv.SchemaURL,
-		attrs...,
-	)
+	logger.Info("Creating OpenTelemetry provider", map[string]interface{}{
+		"service_name":     serviceName,
+		"service_type":     serviceType,
+		"traces_endpoint":  traceTarget.url(),
+		"traces_protocol":  traceTarget.protocol,
+		"metrics_endpoint": metricsEndpoint,
+		"metrics_protocol": metricsProtocol,
+	})
 
 	ctx := context.Background()
 
-	// Create HTTP trace exporter (instead of gRPC)
-	logger.Debug("Creating OTLP/HTTP trace exporter", map[string]interface{}{
-		"endpoint": endpoint,
-		"insecure": true,
-		"path":     "/v1/traces",
+	// Create resource with consistent schema, shared by traces and metrics
+	res := newResource(ctx, serviceName, serviceType, config, logger)
+
+	// Create trace exporter
+	logger.Debug("Creating OTLP trace exporter", map[string]interface{}{
+		"protocol": traceTarget.protocol,
+		"endpoint": traceTarget.endpoint,
+		"insecure": traceTarget.insecure,
+		"path":     traceTarget.urlPath,
+		"source":   traceTarget.source,
 	})
 
-	traceExporter, err := otlptracehttp.New(ctx,
-		otlptracehttp.WithEndpoint(endpoint),
-		otlptracehttp.WithInsecure(), // For development; use TLS in production
-	)
+	traceExporter, err := newTraceExporter(ctx, traceTarget)
 	if err != nil {
 		logger.Error("Failed to create trace exporter", map[string]interface{}{
 			"error":    err.Error(),
-			"endpoint": endpoint,
+			"protocol": traceTarget.protocol,
+			"endpoint": traceTarget.url(),
 			"action":   "Verify OTEL collector is running and accessible",
-			"command":  fmt.Sprintf("curl -v http://%s/v1/traces", endpoint),
 			"impact":   "No traces will be exported",
 		})
-		return nil, fmt.Errorf("failed to create trace exporter for endpoint %s: %w", endpoint, err)
+		return nil, fmt.Errorf("failed to create trace exporter for endpoint %s: %w", traceTarget.url(), err)
 	}
 
 	logger.Debug("Trace exporter created successfully", nil)
 
-	// Create HTTP metric exporter (this was missing!)
-	logger.Debug("Creating OTLP/HTTP metric exporter", map[string]interface{}{
-		"endpoint": endpoint,
-		"insecure": true,
-		"path":     "/v1/metrics",
-	})
+	// Create the metric reader: a Prometheus exporter collects on each
+	// scrape, otherwise a periodic reader pushes to the OTLP endpoint
+	var metricReader sdkmetric.Reader
+	var prometheusHandler http.Handler
+	if usePrometheus {
+		logger.Debug("Creating Prometheus metric exporter", nil)
+
+		metricReader, prometheusHandler, err = newPrometheusReader()
+		if err != nil {
+			if shutdownErr := traceExporter.Shutdown(ctx); shutdownErr != nil {
+				logger.Debug("Failed to cleanup trace exporter after metric exporter failure", map[string]interface{}{
+					"error": shutdownErr.Error(),
+				})
+			}
+
+			logger.Error("Failed to create Prometheus exporter", map[string]interface{}{
+				"error":  err.Error(),
+				"action": "Check the Prometheus exporter configuration",
+				"impact": "No metrics will be exported",
+			})
+			return nil, fmt.Errorf("failed to create Prometheus exporter: %w", err)
+		}
+	} else {
+		// Create metric exporter
+		logger.Debug("Creating OTLP metric exporter", map[string]interface{}{
+			"protocol": metricTarget.protocol,
+			"endpoint": metricTarget.endpoint,
+			"insecure": metricTarget.insecure,
+			"path":     metricTarget.urlPath,
+			"source":   metricTarget.source,
+		})
 
-	metricExporter, err := otlpmetrichttp.New(ctx,
-		otlpmetrichttp.WithEndpoint(endpoint),
-		otlpmetrichttp.WithInsecure(), // For development; use TLS in production
-	)
-	if err != nil {
-		// Clean up trace exporter before returning
-		if shutdownErr := traceExporter.Shutdown(ctx); shutdownErr != nil {
-			logger.Debug("Failed to cleanup trace exporter after metric exporter failure", map[string]interface{}{
-				"error": shutdownErr.Error(),
+		metricExporter, err := newMetricExporter(ctx, metricTarget)
+		if err != nil {
+			// Clean up trace exporter before returning
+			if shutdownErr := traceExporter.Shutdown(ctx); shutdownErr != nil {
+				logger.Debug("Failed to cleanup trace exporter after metric exporter failure", map[string]interface{}{
+					"error": shutdownErr.Error(),
+				})
+			}
+
+			logger.Error("Failed to create metric exporter", map[string]interface{}{
+				"error":    err.Error(),
+				"protocol": metricTarget.protocol,
+				"endpoint": metricTarget.url(),
+				"action":   "Verify OTEL collector is running and accessible",
+				"impact":   "No metrics will be exported",
 			})
+			return nil, fmt.Errorf("failed to create metric exporter for endpoint %s: %w", metricTarget.url(), err)
 		}
 
-		logger.Error("Failed to create metric exporter", map[string]interface{}{
-			"error":    err.Error(),
-			"endpoint": endpoint,
-			"action":   "Verify OTEL collector is running and accessible",
-			"command":  fmt.Sprintf("curl -v http://%s/v1/metrics", endpoint),
-			"impact":   "No metrics will be exported",
-		})
-		return nil, fmt.Errorf("failed to create metric exporter for endpoint %s: %w", endpoint, err)
-	}
+		logger.Debug("Metric exporter created successfully", nil)
 
-	logger.Debug("Metric exporter created successfully", nil)
+		// Periodic reader exports metrics every 30s
+		metricReader = sdkmetric.NewPeriodicReader(
+			metricExporter,
+			sdkmetric.WithInterval(30*time.Second),
+		)
+	}
 
 	// Create trace provider
 	logger.Debug("Creating trace provider with batching", map[string]interface{}{
-		"batch_processor": "default configuration",
-		"note":            "Using SDK defaults for batch timeout, size, and queue",
+		"batch_processor":  "default configuration",
+		"note":             "Using SDK defaults for batch timeout, size, and queue",
+		"sampler":          sampler.Description(),
+		"keep_errors":      sampling.KeepErrors,
+		"keep_slower_than": sampling.KeepSlowerThan.String(),
 	})
 
+	// Spans the sampler drops but the sampling config keeps are recorded,
+	// and keepSpanProcessor decides at the end which ones to export
+	var spanProcessor sdktrace.SpanProcessor = sdktrace.NewBatchSpanProcessor(traceExporter)
+	if sampling.keepsDropped() {
+		spanProcessor = &keepSpanProcessor{
+			SpanProcessor:  spanProcessor,
+			keepErrors:     sampling.KeepErrors,
+			keepSlowerThan: sampling.KeepSlowerThan,
+		}
+	}
+
 	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(traceExporter),
+		sdktrace.WithSpanProcessor(requestMetadataSpanProcessor{}),
+		sdktrace.WithSpanProcessor(spanProcessor),
+		sdktrace.WithSampler(sampler),
 		sdktrace.WithResource(res),
 	)
 
-	// Create metric provider with periodic reader (exports metrics every 30s)
-	logger.Debug("Creating metric provider with periodic reader", map[string]interface{}{
-		"export_interval": "30s",
-		"export_timeout":  "default",
+	logger.Debug("Creating metric provider", map[string]interface{}{
+		"reader":    metricsProtocol,
+		"exemplars": exemplars,
 	})
 
+	// Exemplars are forwarded by the exporter as part of each data point.
+	// TraceBasedFilter only keeps measurements made inside a sampled span.
+	exemplarFilter := exemplar.AlwaysOffFilter
+	if exemplars {
+		exemplarFilter = exemplar.TraceBasedFilter
+	}
+
 	mp := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(
-			sdkmetric.NewPeriodicReader(
-				metricExporter,
-				sdkmetric.WithInterval(30*time.Second),
-			),
-		),
+		sdkmetric.WithReader(metricReader),
 		sdkmetric.WithResource(res),
+		sdkmetric.WithExemplarFilter(exemplarFilter),
 	)
 
 	// Set global providers
@@ -226,37 +294,56 @@ func NewOTelProvider(serviceName, serviceType, endpoint string) (*OTelProvider,
 		traceProvider:  tp,
 		metricProvider: mp,
 		metrics:        NewMetricInstruments("gomind-telemetry"),
+		exemplars:      exemplars,
+		prometheus:     prometheusHandler,
+		ratio:          ratio,
 	}
 
 	logger.Info("OpenTelemetry provider created successfully", map[string]interface{}{
 		"service_name":      serviceName,
-		"endpoint":          endpoint,
+		"traces_endpoint":   traceTarget.url(),
+		"metrics_endpoint":  metricsEndpoint,
 		"initialization_ms": time.Since(startTime).Milliseconds(),
 		"components": map[string]string{
-			"trace_exporter":  "OTLP/HTTP",
-			"metric_exporter": "OTLP/HTTP",
+			"trace_exporter":  "OTLP " + traceTarget.protocol,
+			"metric_exporter": metricExporterName,
 			"trace_provider":  "BatchSpanProcessor",
-			"metric_provider": "PeriodicReader",
+			"metric_provider": metricReaderName,
 		},
 	})
 
 	return provider, nil
 }
 
+// SetSamplingRate changes the fraction of new traces the ratio samplers
+// keep. It reports false, changing nothing, when the provider uses another
+// sampler or rate is outside 0 to 1.
+func (o *OTelProvider) SetSamplingRate(rate float64) bool {
+	if o.ratio == nil || rate < 0 || rate > 1 {
+		return false
+	}
+	o.ratio.SetRatio(rate)
+	return true
+}
+
 // StartSpan starts a new telemetry span
 func (o *OTelProvider) StartSpan(ctx context.Context, name string) (context.Context, core.Span) {
+	if o == nil {
+		return ctx, disabledSpan
+	}
+
 	// Check if provider is shutdown
 	o.mu.RLock()
 	if o.shutdown {
 		o.mu.RUnlock()
 		// Return a no-op span if shutdown
-		return ctx, &noOpSpan{}
+		return ctx, disabledSpan
 	}
 	o.mu.RUnlock()
 
 	// Check for nil tracer (defensive programming)
 	if o.tracer == nil {
-		return ctx, &noOpSpan{}
+		return ctx, disabledSpan
 	}
 
 	ctx, span := o.tracer.Start(ctx, name)
@@ -270,9 +357,17 @@ func (o *OTelProvider) StartSpan(ctx context.Context, name string) (context.Cont
 //
 // Heuristics used:
 //   - Names with "duration", "latency", "time" → Histogram
-//   - Names with "count", "total", "errors" → Counter
+//   - Names with "count", "total", "errors", "invocations" → Counter
 //   - Names with "gauge", "current", "size" → Gauge/Histogram
 func (o *OTelProvider) RecordMetric(name string, value float64, labels map[string]string) {
+	o.RecordMetricWithContext(context.Background(), name, value, labels)
+}
+
+// RecordMetricWithContext records a metric like RecordMetric, but when
+// exemplars are enabled the active span in ctx is attached to the recording
+// as an exemplar. This lets a dashboard jump from a histogram bucket to the
+// trace that produced it.
+func (o *OTelProvider) RecordMetricWithContext(ctx context.Context, name string, value float64, labels map[string]string) {
 	// Check if provider is shutdown
 	o.mu.RLock()
 	if o.shutdown {
@@ -286,7 +381,10 @@ func (o *OTelProvider) RecordMetric(name string, value float64, labels map[strin
 		return // Silent no-op if metrics not initialized
 	}
 
-	ctx := context.Background()
+	// Without exemplars the span context is irrelevant to metrics, so drop it
+	if !o.exemplars || ctx == nil {
+		ctx = context.Background()
+	}
 
 	// Convert label map to OpenTelemetry attributes
 	// This allocates but is necessary for the OTel API
@@ -301,7 +399,7 @@ func (o *OTelProvider) RecordMetric(name string, value float64, labels map[strin
 	case contains(name, "duration", "latency", "time"):
 		// Record as histogram for timing metrics
 		_ = o.metrics.RecordHistogram(ctx, name, value, metric.WithAttributes(attrs...))
-	case contains(name, "count", "total", "errors", "success"):
+	case contains(name, "count", "total", "errors", "success", "invocations"):
 		// Record as counter for cumulative metrics
 		_ = o.metrics.RecordCounter(ctx, name, int64(value), metric.WithAttributes(attrs...))
 	case contains(name, "gauge", "current", "size", "queue"):
@@ -0,0 +1,184 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// OTLP protocols accepted in OTEL_EXPORTER_OTLP_PROTOCOL and the per-signal
+// OTEL_EXPORTER_OTLP_TRACES_PROTOCOL / OTEL_EXPORTER_OTLP_METRICS_PROTOCOL.
+// http/json is part of the OpenTelemetry specification but is not supported
+// by the Go exporters, so it is rejected.
+const (
+	OTLPProtocolGRPC         = "grpc"
+	OTLPProtocolHTTPProtobuf = "http/protobuf"
+)
+
+// Default collector endpoints for each protocol
+const (
+	defaultOTLPHTTPEndpoint = "localhost:4318"
+	defaultOTLPGRPCEndpoint = "localhost:4317"
+)
+
+// otlpTarget is where and how one signal (traces or metrics) is exported
+type otlpTarget struct {
+	signal   string // "traces" or "metrics"
+	protocol string
+	endpoint string // host:port
+	urlPath  string // HTTP only
+	insecure bool
+	source   string // where the endpoint came from, for logging
+}
+
+// resolveOTLPTarget works out the exporter for signal following the
+// OpenTelemetry environment variable conventions:
+//
+//   - The protocol comes from OTEL_EXPORTER_OTLP_<SIGNAL>_PROTOCOL, then
+//     OTEL_EXPORTER_OTLP_PROTOCOL, and defaults to http/protobuf.
+//   - OTEL_EXPORTER_OTLP_<SIGNAL>_ENDPOINT, when set, is used as-is: for
+//     HTTP its path is the full export path.
+//   - Otherwise endpoint (Config.Endpoint or OTEL_EXPORTER_OTLP_ENDPOINT) is
+//     a base URL: for HTTP, /v1/traces or /v1/metrics is appended to its path.
+//
+// Endpoints may be host:port or a URL. An https:// URL turns on TLS; any
+// other endpoint is exported in plain text.
+func resolveOTLPTarget(signal, endpoint string) (otlpTarget, error) {
+	upper := strings.ToUpper(signal)
+	target := otlpTarget{signal: signal, protocol: OTLPProtocolHTTPProtobuf}
+
+	protocolVar := "OTEL_EXPORTER_OTLP_" + upper + "_PROTOCOL"
+	protocol := strings.TrimSpace(os.Getenv(protocolVar))
+	if protocol == "" {
+		protocolVar = "OTEL_EXPORTER_OTLP_PROTOCOL"
+		protocol = strings.TrimSpace(os.Getenv(protocolVar))
+	}
+	if protocol != "" {
+		switch strings.ToLower(protocol) {
+		case OTLPProtocolGRPC, OTLPProtocolHTTPProtobuf:
+			target.protocol = strings.ToLower(protocol)
+		default:
+			return target, fmt.Errorf("unsupported OTLP protocol %q in %s: use %q or %q",
+				protocol, protocolVar, OTLPProtocolGRPC, OTLPProtocolHTTPProtobuf)
+		}
+	}
+
+	signalVar := "OTEL_EXPORTER_OTLP_" + upper + "_ENDPOINT"
+	perSignal := strings.TrimSpace(os.Getenv(signalVar))
+	if perSignal != "" {
+		endpoint = perSignal
+		target.source = signalVar
+	} else {
+		target.source = "endpoint"
+	}
+
+	// The framework default is the HTTP port; switch ports when the other
+	// protocol is selected, as gRPC endpoints have always been converted
+	switch {
+	case endpoint == "":
+		endpoint = defaultOTLPHTTPEndpoint
+		if target.protocol == OTLPProtocolGRPC {
+			endpoint = defaultOTLPGRPCEndpoint
+		}
+	case endpoint == defaultOTLPGRPCEndpoint && target.protocol == OTLPProtocolHTTPProtobuf:
+		endpoint = defaultOTLPHTTPEndpoint
+	case endpoint == defaultOTLPHTTPEndpoint && target.protocol == OTLPProtocolGRPC:
+		endpoint = defaultOTLPGRPCEndpoint
+	}
+
+	host, path, secure, err := splitOTLPEndpoint(endpoint)
+	if err != nil {
+		return target, err
+	}
+	target.endpoint = host
+	target.insecure = !secure
+
+	if target.protocol == OTLPProtocolHTTPProtobuf {
+		if perSignal != "" {
+			target.urlPath = path
+			if target.urlPath == "" {
+				target.urlPath = "/"
+			}
+		} else {
+			target.urlPath = strings.TrimSuffix(path, "/") + "/v1/" + signal
+		}
+	}
+	return target, nil
+}
+
+// splitOTLPEndpoint splits an endpoint into host:port and path, reporting
+// whether it is an https:// URL
+func splitOTLPEndpoint(endpoint string) (host, path string, secure bool, err error) {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", "", false, fmt.Errorf("invalid OTLP endpoint %q: %w", endpoint, err)
+	}
+	if u.Host == "" {
+		return "", "", false, fmt.Errorf("invalid OTLP endpoint %q: missing host", endpoint)
+	}
+	switch u.Scheme {
+	case "http", "https":
+	default:
+		return "", "", false, fmt.Errorf("invalid OTLP endpoint %q: unsupported scheme %q", endpoint, u.Scheme)
+	}
+	return u.Host, u.Path, u.Scheme == "https", nil
+}
+
+// url returns the endpoint as a URL, for log messages and curl hints
+func (t otlpTarget) url() string {
+	scheme := "http"
+	if !t.insecure {
+		scheme = "https"
+	}
+	return scheme + "://" + t.endpoint + t.urlPath
+}
+
+// newTraceExporter builds the span exporter for target's protocol
+func newTraceExporter(ctx context.Context, target otlpTarget) (sdktrace.SpanExporter, error) {
+	if target.protocol == OTLPProtocolGRPC {
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(target.endpoint)}
+		if target.insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, options...)
+	}
+	options := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(target.endpoint),
+		otlptracehttp.WithURLPath(target.urlPath),
+	}
+	if target.insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	return otlptracehttp.New(ctx, options...)
+}
+
+// newMetricExporter builds the metric exporter for target's protocol
+func newMetricExporter(ctx context.Context, target otlpTarget) (sdkmetric.Exporter, error) {
+	if target.protocol == OTLPProtocolGRPC {
+		options := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(target.endpoint)}
+		if target.insecure {
+			options = append(options, otlpmetricgrpc.WithInsecure())
+		}
+		return otlpmetricgrpc.New(ctx, options...)
+	}
+	options := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpoint(target.endpoint),
+		otlpmetrichttp.WithURLPath(target.urlPath),
+	}
+	if target.insecure {
+		options = append(options, otlpmetrichttp.WithInsecure())
+	}
+	return otlpmetrichttp.New(ctx, options...)
+}
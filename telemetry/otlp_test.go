@@ -0,0 +1,141 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// otlpEnvVars are cleared before each case so the host environment does
+// not leak into the tests
+var otlpEnvVars = []string{
+	"OTEL_EXPORTER_OTLP_PROTOCOL",
+	"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL",
+	"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL",
+	"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT",
+}
+
+func TestResolveOTLPTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		signal   string
+		endpoint string
+		env      map[string]string
+		want     otlpTarget
+	}{
+		{
+			name:     "default is http/protobuf",
+			signal:   "traces",
+			endpoint: "otel-collector:4318",
+			want:     otlpTarget{protocol: OTLPProtocolHTTPProtobuf, endpoint: "otel-collector:4318", urlPath: "/v1/traces", insecure: true},
+		},
+		{
+			name:     "base path gets the signal suffix",
+			signal:   "metrics",
+			endpoint: "https://collector.example.com/otlp/",
+			want:     otlpTarget{protocol: OTLPProtocolHTTPProtobuf, endpoint: "collector.example.com", urlPath: "/otlp/v1/metrics"},
+		},
+		{
+			name:     "grpc",
+			signal:   "traces",
+			endpoint: "http://otel-collector:4317",
+			env:      map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc"},
+			want:     otlpTarget{protocol: OTLPProtocolGRPC, endpoint: "otel-collector:4317", insecure: true},
+		},
+		{
+			name:     "grpc converts the default http endpoint",
+			signal:   "metrics",
+			endpoint: "localhost:4318",
+			env:      map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": "GRPC"},
+			want:     otlpTarget{protocol: OTLPProtocolGRPC, endpoint: "localhost:4317", insecure: true},
+		},
+		{
+			name:     "http converts the default grpc endpoint",
+			signal:   "traces",
+			endpoint: "localhost:4317",
+			want:     otlpTarget{protocol: OTLPProtocolHTTPProtobuf, endpoint: "localhost:4318", urlPath: "/v1/traces", insecure: true},
+		},
+		{
+			name:     "per-signal protocol overrides the general one",
+			signal:   "metrics",
+			endpoint: "collector:4317",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":         "http/protobuf",
+				"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL": "grpc",
+			},
+			want: otlpTarget{protocol: OTLPProtocolGRPC, endpoint: "collector:4317", insecure: true},
+		},
+		{
+			name:     "per-signal endpoint is used as-is",
+			signal:   "traces",
+			endpoint: "otel-collector:4318",
+			env:      map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "https://traces.example.com/custom/path"},
+			want:     otlpTarget{protocol: OTLPProtocolHTTPProtobuf, endpoint: "traces.example.com", urlPath: "/custom/path"},
+		},
+		{
+			name:     "other signal's endpoint is ignored",
+			signal:   "metrics",
+			endpoint: "otel-collector:4318",
+			env:      map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "https://traces.example.com/custom/path"},
+			want:     otlpTarget{protocol: OTLPProtocolHTTPProtobuf, endpoint: "otel-collector:4318", urlPath: "/v1/metrics", insecure: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range otlpEnvVars {
+				t.Setenv(name, "")
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			got, err := resolveOTLPTarget(tt.signal, tt.endpoint)
+			if err != nil {
+				t.Fatalf("resolveOTLPTarget() error = %v", err)
+			}
+			got.signal, got.source = "", ""
+			if got != tt.want {
+				t.Errorf("resolveOTLPTarget() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveOTLPTarget_RejectsUnsupportedProtocol(t *testing.T) {
+	for _, name := range otlpEnvVars {
+		t.Setenv(name, "")
+	}
+
+	for _, protocol := range []string{"http/json", "udp"} {
+		t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", protocol)
+		_, err := resolveOTLPTarget("traces", "localhost:4318")
+		if err == nil || !strings.Contains(err.Error(), protocol) || !strings.Contains(err.Error(), "OTEL_EXPORTER_OTLP_PROTOCOL") {
+			t.Errorf("Expected an error naming %q and the variable, got %v", protocol, err)
+		}
+	}
+
+	// The provider refuses to start rather than falling back to HTTP
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_PROTOCOL", "http/json")
+	if _, err := NewOTelProvider("test-service", "tool", "localhost:4318"); err == nil {
+		t.Error("Expected NewOTelProvider to fail for an unsupported protocol")
+	}
+}
+
+func TestNewOTelProvider_GRPC(t *testing.T) {
+	for _, name := range otlpEnvVars {
+		t.Setenv(name, "")
+	}
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "grpc")
+
+	// gRPC exporters connect lazily, so no collector is needed
+	provider, err := NewOTelProvider("test-service", "tool", "localhost:4317")
+	if err != nil {
+		t.Fatalf("NewOTelProvider() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	_ = provider.Shutdown(ctx)
+}
@@ -0,0 +1,66 @@
+package telemetry
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// ProviderPrometheus is the Config.Provider value that exposes metrics for
+// Prometheus to scrape instead of pushing them to the OTLP endpoint.
+// Traces are still exported over OTLP.
+const ProviderPrometheus = "prometheus"
+
+// isPrometheusProvider reports whether provider selects the Prometheus exporter
+func isPrometheusProvider(provider string) bool {
+	return strings.EqualFold(strings.TrimSpace(provider), ProviderPrometheus)
+}
+
+// newPrometheusReader creates a Prometheus exporter backed by its own
+// registry, so re-initializing telemetry never registers collectors twice,
+// and the handler that serves that registry
+func newPrometheusReader() (sdkmetric.Reader, http.Handler, error) {
+	registry := prometheus.NewRegistry()
+	exporter, err := otelprom.New(otelprom.WithRegisterer(registry))
+	if err != nil {
+		return nil, nil, err
+	}
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		Registry:          registry,
+		EnableOpenMetrics: true,
+	})
+	return exporter, handler, nil
+}
+
+// PrometheusHandler returns an http.Handler serving the framework's metrics
+// in the Prometheus exposition format, for Prometheus to scrape.
+//
+// The handler looks up the telemetry registry on every request, so it can
+// be mounted before Initialize is called. Until telemetry is initialized
+// with Config.Provider set to ProviderPrometheus it responds with
+// 503 Service Unavailable and a message saying why.
+//
+// Example:
+//
+//	config := telemetry.UseProfile(telemetry.ProfileProduction)
+//	config.Provider = telemetry.ProviderPrometheus
+//	telemetry.Initialize(config)
+//	http.Handle("/metrics", telemetry.PrometheusHandler())
+func PrometheusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var handler http.Handler
+		if registry := GetRegistry(); registry != nil && registry.provider != nil {
+			handler = registry.provider.prometheus
+		}
+		if handler == nil {
+			http.Error(w, "Prometheus metrics are not enabled: initialize telemetry with Config.Provider set to \""+
+				ProviderPrometheus+"\" to expose them here", http.StatusServiceUnavailable)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,85 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPrometheusHandler_NotEnabled(t *testing.T) {
+	initOnce = sync.Once{}
+	globalRegistry.Store((*Registry)(nil))
+	defer func() {
+		initOnce = sync.Once{}
+		globalRegistry.Store((*Registry)(nil))
+	}()
+
+	rec := httptest.NewRecorder()
+	PrometheusHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 before Initialize, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), ProviderPrometheus) {
+		t.Errorf("Expected the response to explain how to enable Prometheus, got %q", rec.Body.String())
+	}
+
+	// The OTLP provider pushes metrics, so there is nothing to scrape
+	if err := Initialize(UseProfile(ProfileDevelopment)); err != nil {
+		t.Fatalf("Failed to initialize: %v", err)
+	}
+	defer GetRegistry().provider.Shutdown(context.Background())
+
+	rec = httptest.NewRecorder()
+	PrometheusHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 with the OTLP provider, got %d", rec.Code)
+	}
+}
+
+func TestPrometheusHandler_ServesFrameworkMetrics(t *testing.T) {
+	initOnce = sync.Once{}
+	globalRegistry.Store((*Registry)(nil))
+	defer func() {
+		initOnce = sync.Once{}
+		globalRegistry.Store((*Registry)(nil))
+	}()
+
+	config := UseProfile(ProfileDevelopment)
+	config.ServiceName = "prometheus-test"
+	config.Provider = ProviderPrometheus
+	if err := Initialize(config); err != nil {
+		t.Fatalf("Failed to initialize: %v", err)
+	}
+	defer GetRegistry().provider.Shutdown(context.Background())
+
+	Counter("requests.total", "status", "200")
+	Histogram("capability.duration_ms", 12, "capability", "quote")
+
+	rec := httptest.NewRecorder()
+	NewFrameworkMetricsRegistry(nil).MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	for _, want := range []string{`requests_total{`, `status="200"`, `capability_duration_ms`, `service_name="prometheus-test"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected scrape output to contain %s, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestIsPrometheusProvider(t *testing.T) {
+	for provider, want := range map[string]bool{
+		"prometheus":   true,
+		" Prometheus ": true,
+		"otel":         false,
+		"":             false,
+	} {
+		if got := isPrometheusProvider(provider); got != want {
+			t.Errorf("isPrometheusProvider(%q) = %v, want %v", provider, got, want)
+		}
+	}
+}
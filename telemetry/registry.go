@@ -29,6 +29,10 @@ var (
 	// Internal health metrics tracked atomically for thread-safety
 	telemetryErrors  atomic.Int64 // Total errors encountered
 	telemetryDropped atomic.Int64 // Metrics dropped due to limits
+
+	// telemetrySeriesDropped counts emissions whose label combination was
+	// rejected by the cardinality limiter and collapsed into "other"
+	telemetrySeriesDropped atomic.Int64
 )
 
 // ModuleConfig represents metric configuration for a module
@@ -100,10 +104,17 @@ func DeclareMetrics(module string, config ModuleConfig) {
 //  4. Processes all previously declared metrics
 //  5. Stores the registry globally for use by Emit functions
 //
+// Options such as WithServiceVersion and WithResourceAttributes are applied
+// to config before it is used.
+//
 // Returns an error if initialization fails (e.g., can't create exporter).
 // Even if initialization fails, the Emit functions will still work
 // (they'll just discard metrics), so the application won't crash.
-func Initialize(config Config) error {
+func Initialize(config Config, opts ...InitOption) error {
+	for _, opt := range opts {
+		opt(&config)
+	}
+
 	var initErr error
 	initOnce.Do(func() {
 		// Create logger immediately for initialization visibility
@@ -133,6 +144,12 @@ func Initialize(config Config) error {
 		// Store logger in registry for future use
 		registry.logger = logger
 
+		// Share one PII policy across logs and metrics. A redactor installed
+		// by the application via core.SetRedactor takes precedence.
+		if config.PIIRedaction && core.GetRedactor() == nil {
+			core.SetRedactor(core.NewPIIRedactor(config.PIIPatterns...))
+		}
+
 		// Process all metrics declared via DeclareMetrics()
 		// This allows packages to declare their metrics in init()
 		declaredCount := 0
@@ -183,10 +200,10 @@ func Initialize(config Config) error {
 //	if err := telemetry.InitializeForComponent(agent, config); err != nil {
 //	    log.Fatal(err)
 //	}
-func InitializeForComponent(component interface{ GetType() core.ComponentType }, config Config) error {
+func InitializeForComponent(component interface{ GetType() core.ComponentType }, config Config, opts ...InitOption) error {
 	// Automatically infer service type from component
 	config.ServiceType = string(component.GetType())
-	return Initialize(config)
+	return Initialize(config, opts...)
 }
 
 // newRegistry creates a new telemetry registry
@@ -211,7 +228,7 @@ func newRegistry(config Config) (*Registry, error) {
 	}
 
 	// Create OpenTelemetry provider
-	provider, err := NewOTelProvider(config.ServiceName, config.ServiceType, config.Endpoint)
+	provider, err := newOTelProvider(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create OTel provider: %w", err)
 	}
@@ -265,27 +282,46 @@ func (r *Registry) registerModule(_ string, config ModuleConfig) {
 	}
 }
 
-// emit handles metric emission with all safety checks
-func (r *Registry) emit(name string, value float64, labels map[string]string) error {
+// emit handles metric emission with all safety checks.
+// ctx carries the active span used for exemplars when they are enabled.
+func (r *Registry) emit(ctx context.Context, name string, value float64, labels map[string]string) error {
 	// Check circuit breaker
 	if r.circuit != nil && !r.circuit.Allow() {
 		telemetryDropped.Add(1)
 		return fmt.Errorf("telemetry circuit breaker open")
 	}
 
+	// Apply the shared redaction policy to label values
+	if redactor := core.GetRedactor(); redactor != nil {
+		for key, val := range labels {
+			if redacted, ok := redactor(key, val).(string); ok {
+				labels[key] = redacted
+			} else {
+				labels[key] = core.RedactedValue
+			}
+		}
+	}
+
 	// Apply cardinality limiting
 	if r.limiter != nil {
+		var original map[string]string
 		for key, val := range labels {
 			limited := r.limiter.CheckAndLimit(name, key, val)
 			if limited != val {
+				if original == nil {
+					original = copyLabels(labels)
+				}
 				labels[key] = limited
 			}
 		}
+		if original != nil {
+			r.recordCardinalityDrop(name, original)
+		}
 	}
 
 	// Record the metric
 	if r.provider != nil {
-		r.provider.RecordMetric(name, value, labels)
+		r.provider.RecordMetricWithContext(ctx, name, value, labels)
 		r.emitted.Add(1)
 
 		// Record success with circuit breaker
@@ -299,13 +335,25 @@ func (r *Registry) emit(name string, value float64, labels map[string]string) er
 
 // Emit - Simple, thread-safe, developer-friendly
 func Emit(name string, value float64, labels ...string) {
-	registry := globalRegistry.Load()
-	if registry == nil {
+	emitGlobal(context.Background(), name, value, labels...)
+}
+
+// emitGlobal emits through the global registry, passing ctx down so the
+// active span can be attached as an exemplar
+func emitGlobal(ctx context.Context, name string, value float64, labels ...string) {
+	recordForTesting(name, value, labels, false)
+	emitToRegistry(ctx, name, value, labels...)
+}
+
+// emitToRegistry does the registry half of emitGlobal, for callers that
+// record the emission themselves
+func emitToRegistry(ctx context.Context, name string, value float64, labels ...string) {
+	r := GetRegistry()
+	if r == nil {
 		return // Telemetry not initialized, silent no-op
 	}
 
-	r := registry.(*Registry)
-	if err := r.emit(name, value, parseLabels(labels...)); err != nil {
+	if err := r.emit(ctx, name, value, parseLabels(labels...)); err != nil {
 		telemetryErrors.Add(1)
 		r.lastError.Store(err.Error())
 
@@ -337,7 +385,7 @@ func EmitWithContext(ctx context.Context, name string, value float64, labels ...
 		return
 	}
 	// Fall back to global with baggage labels included
-	Emit(name, value, allLabels...)
+	emitGlobal(ctx, name, value, allLabels...)
 }
 
 // FromContext retrieves telemetry provider from context
@@ -0,0 +1,37 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/itsneelabh/gomind/core"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// requestMetadataSpanProcessor sets the request metadata of a span's parent
+// context, from core.WithRequestMetadata, as attributes of the span when it
+// starts. Attributes the caller set on the span win.
+type requestMetadataSpanProcessor struct{}
+
+func (requestMetadataSpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	meta := core.RequestMetadataFromContext(parent)
+	if len(meta) == 0 {
+		return
+	}
+
+	set := make(map[attribute.Key]bool, len(s.Attributes()))
+	for _, attr := range s.Attributes() {
+		set[attr.Key] = true
+	}
+	attrs := make([]attribute.KeyValue, 0, len(meta))
+	for k, v := range meta {
+		if !set[attribute.Key(k)] {
+			attrs = append(attrs, attribute.String(k, v))
+		}
+	}
+	s.SetAttributes(attrs...)
+}
+
+func (requestMetadataSpanProcessor) OnEnd(sdktrace.ReadOnlySpan)      {}
+func (requestMetadataSpanProcessor) Shutdown(context.Context) error   { return nil }
+func (requestMetadataSpanProcessor) ForceFlush(context.Context) error { return nil }
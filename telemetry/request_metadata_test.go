@@ -0,0 +1,47 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/itsneelabh/gomind/core"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRequestMetadataSpanProcessor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(requestMetadataSpanProcessor{}),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+	defer tp.Shutdown(context.Background())
+
+	ctx := core.WithRequestMetadata(context.Background(), map[string]string{"tenant_id": "acme", "user_id": "u-1"})
+	_, span := tp.Tracer("test").Start(ctx, "capability.lookup")
+	span.End()
+	_, plain := tp.Tracer("test").Start(context.Background(), "no-metadata")
+	plain.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+	attrs := attribute.NewSet(spans[0].Attributes()...)
+	for key, want := range map[string]string{"tenant_id": "acme", "user_id": "u-1"} {
+		if got, _ := attrs.Value(attribute.Key(key)); got.AsString() != want {
+			t.Errorf("%s = %q, want %q", key, got.AsString(), want)
+		}
+	}
+	if len(spans[1].Attributes()) != 0 {
+		t.Errorf("Expected no attributes without metadata, got %v", spans[1].Attributes())
+	}
+}
+
+func TestFrameworkMetricsRegistry_WithBaggage(t *testing.T) {
+	ctx := NewFrameworkMetricsRegistry(nil).WithBaggage(context.Background(), "tenant_id", "acme")
+	if got := GetBaggage(ctx)["tenant_id"]; got != "acme" {
+		t.Errorf("tenant_id baggage = %q, want acme", got)
+	}
+}
@@ -0,0 +1,69 @@
+package telemetry
+
+import (
+	"context"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// defaultServiceVersion is reported when neither Config.ServiceVersion nor
+// OTEL_RESOURCE_ATTRIBUTES sets service.version
+const defaultServiceVersion = "1.0.0"
+
+// newResource builds the OpenTelemetry resource attached to both traces and
+// metrics. Attributes are layered so that later sources win on conflicts:
+//
+//  1. service.version defaulting to 1.0.0
+//  2. OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME
+//  3. config.ResourceAttributes
+//  4. service.name, service.type and config.ServiceVersion
+//
+// An unparsable OTEL_RESOURCE_ATTRIBUTES is logged and the rest of the
+// resource is still used.
+func newResource(ctx context.Context, serviceName, serviceType string, config Config, logger *TelemetryLogger) *resource.Resource {
+	custom := make([]attribute.KeyValue, 0, len(config.ResourceAttributes))
+	keys := make([]string, 0, len(config.ResourceAttributes))
+	for key := range config.ResourceAttributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		custom = append(custom, attribute.String(key, config.ResourceAttributes[key]))
+	}
+
+	identity := []attribute.KeyValue{semconv.ServiceNameKey.String(serviceName)}
+	// Add service.type if provided (enables tool vs agent segregation in dashboards)
+	if serviceType != "" {
+		identity = append(identity, attribute.String("service.type", serviceType))
+	}
+	if config.ServiceVersion != "" {
+		identity = append(identity, semconv.ServiceVersionKey.String(config.ServiceVersion))
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithAttributes(semconv.ServiceVersionKey.String(defaultServiceVersion)),
+		resource.WithFromEnv(),
+		resource.WithAttributes(custom...),
+		resource.WithAttributes(identity...),
+	)
+	if err != nil {
+		logger.Warn("Ignoring invalid OTEL_RESOURCE_ATTRIBUTES", map[string]interface{}{
+			"error":  err.Error(),
+			"action": "Use comma-separated key=value pairs with URL-encoded values",
+		})
+	}
+
+	version, _ := res.Set().Value(semconv.ServiceVersionKey)
+	logger.Debug("Created OpenTelemetry resource", map[string]interface{}{
+		"service_name":    serviceName,
+		"service_type":    serviceType,
+		"service_version": version.AsString(),
+		"attributes":      res.Len(),
+		"schema_url":      semconv.SchemaURL,
+	})
+	return res
+}
@@ -0,0 +1,57 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// resourceValue returns the string value of key in res
+func resourceValue(res *resource.Resource, key string) string {
+	value, _ := res.Set().Value(attribute.Key(key))
+	return value.AsString()
+}
+
+func TestNewResource(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "env-name")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "deployment.environment=staging,service.version=2.0.0,team=payments")
+
+	config := Config{}
+	WithResourceAttributes(map[string]string{"deployment.environment": "production", "service.name": "ignored"})(&config)
+	WithResourceAttributes(map[string]string{"region": "eu-west-1"})(&config)
+
+	res := newResource(context.Background(), "checkout", "agent", config, GetLogger())
+	want := map[string]string{
+		"service.name":           "checkout",
+		"service.type":           "agent",
+		"service.version":        "2.0.0",      // from the environment
+		"deployment.environment": "production", // explicit attribute wins
+		"team":                   "payments",
+		"region":                 "eu-west-1",
+	}
+	for key, value := range want {
+		if got := resourceValue(res, key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+
+	WithServiceVersion("3.1.0")(&config)
+	if got := resourceValue(newResource(context.Background(), "checkout", "", config, GetLogger()), "service.version"); got != "3.1.0" {
+		t.Errorf("Expected WithServiceVersion to win, got %q", got)
+	}
+}
+
+func TestNewResource_Defaults(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "not-a-pair")
+
+	res := newResource(context.Background(), "checkout", "", Config{}, GetLogger())
+	if got := resourceValue(res, "service.name"); got != "checkout" {
+		t.Errorf("Expected the resource to survive invalid OTEL_RESOURCE_ATTRIBUTES, got service.name %q", got)
+	}
+	if got := resourceValue(res, "service.version"); got != defaultServiceVersion {
+		t.Errorf("Expected the default service.version, got %q", got)
+	}
+}
@@ -0,0 +1,252 @@
+package telemetry
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Sampler names, the same values OTEL_TRACES_SAMPLER accepts
+const (
+	SamplerAlwaysOn                = "always_on"
+	SamplerAlwaysOff               = "always_off"
+	SamplerTraceIDRatio            = "traceidratio"
+	SamplerParentBasedAlwaysOn     = "parentbased_always_on"
+	SamplerParentBasedAlwaysOff    = "parentbased_always_off"
+	SamplerParentBasedTraceIDRatio = "parentbased_traceidratio"
+)
+
+// SamplerConfig configures which traces are sampled.
+//
+// The sampler makes a head decision when a span starts: Type picks the
+// sampler, and Ratio is the fraction of traces kept by the ratio samplers.
+// The parent-based samplers follow the parent span's decision, so a trace
+// is kept or dropped as a whole, and apply their root sampler only to
+// spans without a parent.
+//
+// KeepErrors and KeepSlowerThan rescue spans the head decision dropped.
+// Those spans are still recorded, and when one ends with an error status or
+// a recorded error, or lasts longer than KeepSlowerThan, it is exported
+// anyway with the gomind.sampling.kept attribute set to "error" or "slow".
+// Only the span itself is rescued, not the rest of its trace; keeping whole
+// traces that contain an error needs tail sampling in the OpenTelemetry
+// Collector. Recording every span costs CPU and memory even when few are
+// exported.
+//
+// OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG, when set, override Type
+// and Ratio.
+type SamplerConfig struct {
+	// Type is one of the Sampler* names. Default: parentbased_traceidratio.
+	Type string
+
+	// Ratio is the fraction of traces kept by traceidratio and
+	// parentbased_traceidratio, from 0 to 1. Zero means 1, keeping every
+	// trace; use always_off to keep nothing.
+	Ratio float64
+
+	// KeepErrors exports dropped spans that end with an error
+	KeepErrors bool
+
+	// KeepSlowerThan exports dropped spans that last longer than this.
+	// Zero disables it.
+	KeepSlowerThan time.Duration
+}
+
+// keepsDropped reports whether spans dropped by the sampler are recorded
+func (c SamplerConfig) keepsDropped() bool {
+	return c.KeepErrors || c.KeepSlowerThan > 0
+}
+
+// newSampler builds the sampler for config after applying the
+// OTEL_TRACES_SAMPLER environment variables. It returns the effective
+// configuration and, for the ratio samplers, the ratioSampler whose ratio
+// can be changed later. Invalid environment values are logged and ignored,
+// as the OpenTelemetry specification asks; an invalid config is an error.
+func newSampler(config SamplerConfig, logger *TelemetryLogger) (sdktrace.Sampler, SamplerConfig, *ratioSampler, error) {
+	if name := strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_SAMPLER"))); name != "" {
+		if isSamplerName(name) {
+			config.Type = name
+		} else if logger != nil {
+			logger.Warn("Ignoring unsupported OTEL_TRACES_SAMPLER", map[string]interface{}{
+				"value":   name,
+				"action":  "Use always_on, always_off, traceidratio, parentbased_always_on, parentbased_always_off or parentbased_traceidratio",
+				"sampler": config.Type,
+			})
+		}
+	}
+	// Zero means "not set" in SamplerConfig, but from the variable it asks
+	// for no root spans to be sampled
+	explicitZero := false
+	if arg := strings.TrimSpace(os.Getenv("OTEL_TRACES_SAMPLER_ARG")); arg != "" {
+		ratio, err := strconv.ParseFloat(arg, 64)
+		switch {
+		case err == nil && ratio >= 0 && ratio <= 1:
+			config.Ratio = ratio
+			explicitZero = ratio == 0
+		case logger != nil:
+			logger.Warn("Ignoring invalid OTEL_TRACES_SAMPLER_ARG", map[string]interface{}{
+				"value":  arg,
+				"action": "Set a sampling ratio between 0 and 1",
+			})
+		}
+	}
+
+	if config.Type == "" {
+		config.Type = SamplerParentBasedTraceIDRatio
+	}
+	if config.Ratio == 0 && !explicitZero {
+		config.Ratio = 1
+	}
+	if config.Ratio < 0 || config.Ratio > 1 {
+		return nil, config, nil, fmt.Errorf("invalid sampling ratio %v: must be between 0 and 1", config.Ratio)
+	}
+
+	var sampler sdktrace.Sampler
+	var ratio *ratioSampler
+	switch config.Type {
+	case SamplerAlwaysOn:
+		sampler = sdktrace.AlwaysSample()
+	case SamplerAlwaysOff:
+		sampler = sdktrace.NeverSample()
+	case SamplerTraceIDRatio:
+		ratio = newRatioSampler(config.Ratio)
+		sampler = ratio
+	case SamplerParentBasedAlwaysOn:
+		sampler = sdktrace.ParentBased(sdktrace.AlwaysSample())
+	case SamplerParentBasedAlwaysOff:
+		sampler = sdktrace.ParentBased(sdktrace.NeverSample())
+	case SamplerParentBasedTraceIDRatio:
+		ratio = newRatioSampler(config.Ratio)
+		sampler = sdktrace.ParentBased(ratio)
+	default:
+		return nil, config, nil, fmt.Errorf("unsupported sampler %q", config.Type)
+	}
+
+	if config.keepsDropped() {
+		sampler = recordDroppedSampler{sampler}
+	}
+	return sampler, config, ratio, nil
+}
+
+// ratioSampler is a TraceIDRatioBased sampler whose ratio can be changed
+// while spans are being started, so a configuration reload can apply a new
+// sampling rate without rebuilding the tracer provider
+type ratioSampler struct {
+	current atomic.Pointer[sdktrace.Sampler]
+}
+
+func newRatioSampler(ratio float64) *ratioSampler {
+	s := &ratioSampler{}
+	s.SetRatio(ratio)
+	return s
+}
+
+// SetRatio changes the fraction of traces kept from now on
+func (s *ratioSampler) SetRatio(ratio float64) {
+	sampler := sdktrace.TraceIDRatioBased(ratio)
+	s.current.Store(&sampler)
+}
+
+func (s *ratioSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return (*s.current.Load()).ShouldSample(p)
+}
+
+func (s *ratioSampler) Description() string {
+	return (*s.current.Load()).Description()
+}
+
+// isSamplerName reports whether name is one of the Sampler* names
+func isSamplerName(name string) bool {
+	switch name {
+	case SamplerAlwaysOn, SamplerAlwaysOff, SamplerTraceIDRatio,
+		SamplerParentBasedAlwaysOn, SamplerParentBasedAlwaysOff, SamplerParentBasedTraceIDRatio:
+		return true
+	}
+	return false
+}
+
+// recordDroppedSampler records the spans its sampler drops instead of
+// discarding them, so keepSpanProcessor can still export them
+type recordDroppedSampler struct {
+	sdktrace.Sampler
+}
+
+func (s recordDroppedSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	result := s.Sampler.ShouldSample(p)
+	if result.Decision == sdktrace.Drop {
+		result.Decision = sdktrace.RecordOnly
+	}
+	return result
+}
+
+func (s recordDroppedSampler) Description() string {
+	return "RecordDropped{" + s.Sampler.Description() + "}"
+}
+
+// keepSpanProcessor passes sampled spans to the wrapped processor, along
+// with dropped spans that ended with an error or ran slow. The wrapped
+// processor only exports sampled spans, so kept spans are marked sampled.
+type keepSpanProcessor struct {
+	sdktrace.SpanProcessor
+	keepErrors     bool
+	keepSlowerThan time.Duration
+}
+
+func (p *keepSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	spanContext := s.SpanContext()
+	if spanContext.IsSampled() {
+		p.SpanProcessor.OnEnd(s)
+		return
+	}
+	if reason := p.keepReason(s); reason != "" {
+		p.SpanProcessor.OnEnd(keptSpan{
+			ReadOnlySpan: s,
+			spanContext:  spanContext.WithTraceFlags(spanContext.TraceFlags().WithSampled(true)),
+			reason:       reason,
+		})
+	}
+}
+
+// keepReason returns why a dropped span should be exported, or ""
+func (p *keepSpanProcessor) keepReason(s sdktrace.ReadOnlySpan) string {
+	if p.keepErrors {
+		if s.Status().Code == codes.Error {
+			return "error"
+		}
+		for _, event := range s.Events() {
+			if event.Name == "exception" {
+				return "error"
+			}
+		}
+	}
+	if p.keepSlowerThan > 0 && s.EndTime().Sub(s.StartTime()) > p.keepSlowerThan {
+		return "slow"
+	}
+	return ""
+}
+
+// keptSpan is a dropped span exported by keepSpanProcessor
+type keptSpan struct {
+	sdktrace.ReadOnlySpan
+	spanContext trace.SpanContext
+	reason      string
+}
+
+func (s keptSpan) SpanContext() trace.SpanContext {
+	return s.spanContext
+}
+
+func (s keptSpan) Attributes() []attribute.KeyValue {
+	attrs := s.ReadOnlySpan.Attributes()
+	kept := make([]attribute.KeyValue, 0, len(attrs)+1)
+	kept = append(kept, attrs...)
+	return append(kept, attribute.String("gomind.sampling.kept", s.reason))
+}
@@ -0,0 +1,174 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewSampler(t *testing.T) {
+	tests := []struct {
+		name   string
+		config SamplerConfig
+		env    map[string]string
+		want   string
+	}{
+		{"default", SamplerConfig{}, nil, "ParentBased{root:AlwaysOnSampler"},
+		{"ratio", SamplerConfig{Ratio: 0.25}, nil, "ParentBased{root:TraceIDRatioBased{0.25}"},
+		{"type", SamplerConfig{Type: SamplerAlwaysOff}, nil, "AlwaysOffSampler"},
+		{"keep errors", SamplerConfig{Type: SamplerTraceIDRatio, Ratio: 0.5, KeepErrors: true}, nil, "RecordDropped{TraceIDRatioBased{0.5}}"},
+		{"env overrides config", SamplerConfig{Ratio: 0.25}, map[string]string{"OTEL_TRACES_SAMPLER": "traceidratio", "OTEL_TRACES_SAMPLER_ARG": "0.1"}, "TraceIDRatioBased{0.1}"},
+		{"env zero ratio", SamplerConfig{Ratio: 0.25}, map[string]string{"OTEL_TRACES_SAMPLER_ARG": "0"}, "ParentBased{root:TraceIDRatioBased{0}"},
+		{"invalid env ignored", SamplerConfig{Type: SamplerAlwaysOn}, map[string]string{"OTEL_TRACES_SAMPLER": "jaeger_remote", "OTEL_TRACES_SAMPLER_ARG": "2"}, "AlwaysOnSampler"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTEL_TRACES_SAMPLER", "")
+			t.Setenv("OTEL_TRACES_SAMPLER_ARG", "")
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			sampler, _, _, err := newSampler(tt.config, nil)
+			if err != nil {
+				t.Fatalf("newSampler() error = %v", err)
+			}
+			if got := sampler.Description(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("newSampler() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	t.Setenv("OTEL_TRACES_SAMPLER", "")
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "")
+	if _, _, _, err := newSampler(SamplerConfig{Type: "sometimes"}, nil); err == nil {
+		t.Error("Expected an error for an unsupported sampler type")
+	}
+	if _, _, _, err := newSampler(SamplerConfig{Ratio: 1.5}, nil); err == nil {
+		t.Error("Expected an error for a ratio above 1")
+	}
+}
+
+func TestOTelProvider_SetSamplingRate(t *testing.T) {
+	t.Setenv("OTEL_TRACES_SAMPLER", "")
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "")
+
+	sampler, _, ratio, err := newSampler(SamplerConfig{}, nil)
+	if err != nil {
+		t.Fatalf("newSampler() error = %v", err)
+	}
+	provider := &OTelProvider{ratio: ratio}
+	if provider.SetSamplingRate(1.5) {
+		t.Error("Expected a rate above 1 to be rejected")
+	}
+	if !provider.SetSamplingRate(0) {
+		t.Fatal("Expected the ratio sampler to accept a new rate")
+	}
+
+	// The tracer provider built with the sampler sees the new rate
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler), sdktrace.WithSyncer(exporter))
+	_, span := tp.Tracer("test").Start(context.Background(), "dropped")
+	span.End()
+	if got := len(exporter.GetSpans()); got != 0 {
+		t.Errorf("Expected no spans at rate 0, got %d", got)
+	}
+	if got := sampler.Description(); !strings.HasPrefix(got, "ParentBased{root:TraceIDRatioBased{0}") {
+		t.Errorf("Description() = %s, want the new ratio", got)
+	}
+
+	_, _, ratio, _ = newSampler(SamplerConfig{Type: SamplerAlwaysOn}, nil)
+	if (&OTelProvider{ratio: ratio}).SetSamplingRate(0.5) {
+		t.Error("Expected always_on to ignore sampling rate changes")
+	}
+}
+
+func TestKeepSpanProcessor(t *testing.T) {
+	t.Setenv("OTEL_TRACES_SAMPLER", "")
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "")
+
+	sampling := SamplerConfig{Type: SamplerParentBasedAlwaysOff, KeepErrors: true, KeepSlowerThan: time.Second}
+	sampler, _, _, err := newSampler(sampling, nil)
+	if err != nil {
+		t.Fatalf("newSampler() error = %v", err)
+	}
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithSpanProcessor(&keepSpanProcessor{
+			SpanProcessor:  sdktrace.NewSimpleSpanProcessor(exporter),
+			keepErrors:     sampling.KeepErrors,
+			keepSlowerThan: sampling.KeepSlowerThan,
+		}),
+	)
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+	tracer := tp.Tracer("test")
+
+	_, ok := tracer.Start(context.Background(), "ok")
+	ok.End()
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, failed := tracer.Start(ctx, "status-error")
+	failed.SetStatus(codes.Error, "boom")
+	failed.End()
+	parent.End()
+
+	_, recorded := tracer.Start(context.Background(), "recorded-error")
+	recorded.RecordError(errors.New("boom"))
+	recorded.End()
+
+	start := time.Now()
+	_, slow := tracer.Start(context.Background(), "slow", trace.WithTimestamp(start))
+	slow.End(trace.WithTimestamp(start.Add(2 * time.Second)))
+
+	want := map[string]string{"status-error": "error", "recorded-error": "error", "slow": "slow"}
+	spans := exporter.GetSpans()
+	if len(spans) != len(want) {
+		t.Fatalf("Expected %d kept spans, got %d", len(want), len(spans))
+	}
+	for _, span := range spans {
+		reason, expected := want[span.Name]
+		if !expected {
+			t.Errorf("Unexpected span %q exported", span.Name)
+			continue
+		}
+		if !span.SpanContext.IsSampled() {
+			t.Errorf("Expected kept span %q to be marked sampled", span.Name)
+		}
+		found := false
+		for _, attr := range span.Attributes {
+			if attr.Key == "gomind.sampling.kept" && attr.Value.AsString() == reason {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected span %q to carry gomind.sampling.kept=%s", span.Name, reason)
+		}
+	}
+}
+
+func TestProfiles_KeepAllTraces(t *testing.T) {
+	t.Setenv("OTEL_TRACES_SAMPLER", "")
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "")
+	for _, profile := range []Profile{ProfileDevelopment, ProfileStaging, ProfileProduction} {
+		config := UseProfile(profile)
+		if config.Sampler != (SamplerConfig{}) {
+			t.Errorf("Expected the %s profile to leave sampling unset, got %+v", profile, config.Sampler)
+		}
+		sampler, _, _, err := newSampler(config.Sampler, nil)
+		if err != nil {
+			t.Fatalf("newSampler() error = %v", err)
+		}
+		if got := sampler.Description(); !strings.HasPrefix(got, "ParentBased{root:AlwaysOnSampler") {
+			t.Errorf("Expected the %s profile to keep every trace, got %s", profile, got)
+		}
+	}
+}
@@ -5,6 +5,13 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/itsneelabh/gomind/core"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 func TestThreadSafeGlobalRegistry(t *testing.T) {
@@ -88,6 +95,96 @@ func TestCardinalityLimiter(t *testing.T) {
 	}
 }
 
+func TestCardinalityDropReporting(t *testing.T) {
+	previous := otel.GetMeterProvider()
+	defer otel.SetMeterProvider(previous)
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	limiter := NewCardinalityLimiter(map[string]int{"user_id": 1})
+	defer limiter.Stop()
+	r := &Registry{
+		provider: &OTelProvider{metrics: NewMetricInstruments("cardinality-test")},
+		limiter:  limiter,
+	}
+
+	var gotMetric string
+	var gotLabels map[string]string
+	OnCardinalityDrop(func(metricName string, labels map[string]string) {
+		gotMetric = metricName
+		gotLabels = labels
+	})
+	defer OnCardinalityDrop(nil)
+	ResetInternalMetrics()
+
+	ctx := context.Background()
+	_ = r.emit(ctx, "logins.total", 1, map[string]string{"user_id": "alice"})
+	if gotMetric != "" {
+		t.Fatalf("Expected no drop within the limit, got callback for %s", gotMetric)
+	}
+
+	_ = r.emit(ctx, "logins.total", 1, map[string]string{"user_id": "bob"})
+	if gotMetric != "logins.total" {
+		t.Errorf("Expected callback for logins.total, got %q", gotMetric)
+	}
+	if gotLabels["user_id"] != "bob" {
+		t.Errorf("Expected callback to receive original label value bob, got %q", gotLabels["user_id"])
+	}
+	if dropped := GetInternalMetrics().DroppedSeries; dropped != 1 {
+		t.Errorf("Expected 1 dropped series, got %d", dropped)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	found := false
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != CardinalityDropMetric {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok || len(sum.DataPoints) != 1 {
+				t.Fatalf("Expected one counter data point, got %#v", m.Data)
+			}
+			if v, _ := sum.DataPoints[0].Attributes.Value("metric"); v.AsString() != "logins.total" {
+				t.Errorf("Expected metric label logins.total, got %q", v.AsString())
+			}
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected %s to be recorded", CardinalityDropMetric)
+	}
+
+	t.Run("panicking callback is recovered", func(t *testing.T) {
+		OnCardinalityDrop(func(string, map[string]string) { panic("boom") })
+		_ = r.emit(ctx, "logins.total", 1, map[string]string{"user_id": "carol"})
+	})
+}
+
+func TestEmitRedactsLabels(t *testing.T) {
+	core.SetRedactor(core.NewPIIRedactor())
+	defer core.SetRedactor(nil)
+
+	r := &Registry{}
+	labels := map[string]string{"email": "jane@example.com", "note": "from bob@example.com", "plan": "pro"}
+	if err := r.emit(context.Background(), "signups.total", 1, labels); err != nil {
+		t.Fatalf("emit failed: %v", err)
+	}
+
+	if labels["email"] != core.RedactedValue {
+		t.Errorf("Expected email label to be redacted, got %q", labels["email"])
+	}
+	if labels["note"] != "from "+core.RedactedValue {
+		t.Errorf("Expected email inside label value to be redacted, got %q", labels["note"])
+	}
+	if labels["plan"] != "pro" {
+		t.Errorf("Expected plan label to be kept, got %q", labels["plan"])
+	}
+}
+
 func TestTelemetryCircuitBreaker(t *testing.T) {
 	config := CircuitConfig{
 		Enabled:      true,
@@ -217,6 +314,72 @@ func BenchmarkEmit(b *testing.B) {
 	})
 }
 
+func TestExemplars(t *testing.T) {
+	previous := otel.GetMeterProvider()
+	defer otel.SetMeterProvider(previous)
+
+	tracer := sdktrace.NewTracerProvider().Tracer("exemplar-test")
+
+	// collect records one histogram value inside a sampled span and returns
+	// the exemplars attached to it along with the span's trace ID
+	collect := func(t *testing.T, enabled bool) ([]metricdata.Exemplar[float64], [16]byte) {
+		t.Helper()
+		reader := sdkmetric.NewManualReader()
+		otel.SetMeterProvider(sdkmetric.NewMeterProvider(
+			sdkmetric.WithReader(reader),
+			sdkmetric.WithExemplarFilter(exemplar.TraceBasedFilter),
+		))
+
+		provider := &OTelProvider{
+			metrics:   NewMetricInstruments("exemplar-test"),
+			exemplars: enabled,
+		}
+
+		ctx, span := tracer.Start(context.Background(), "request")
+		provider.RecordMetricWithContext(ctx, "request.latency", 42, map[string]string{"endpoint": "/api"})
+		span.End()
+
+		var rm metricdata.ResourceMetrics
+		if err := reader.Collect(context.Background(), &rm); err != nil {
+			t.Fatalf("Collect failed: %v", err)
+		}
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name != "request.latency" {
+					continue
+				}
+				hist, ok := m.Data.(metricdata.Histogram[float64])
+				if !ok || len(hist.DataPoints) != 1 {
+					t.Fatalf("Expected one histogram data point, got %#v", m.Data)
+				}
+				return hist.DataPoints[0].Exemplars, span.SpanContext().TraceID()
+			}
+		}
+		t.Fatal("request.latency was not recorded")
+		return nil, [16]byte{}
+	}
+
+	t.Run("attaches active span when enabled", func(t *testing.T) {
+		exemplars, traceID := collect(t, true)
+		if len(exemplars) != 1 {
+			t.Fatalf("Expected 1 exemplar, got %d", len(exemplars))
+		}
+		if string(exemplars[0].TraceID) != string(traceID[:]) {
+			t.Errorf("Expected exemplar trace ID %x, got %x", traceID, exemplars[0].TraceID)
+		}
+		if exemplars[0].Value != 42 {
+			t.Errorf("Expected exemplar value 42, got %v", exemplars[0].Value)
+		}
+	})
+
+	t.Run("omits exemplars when disabled", func(t *testing.T) {
+		exemplars, _ := collect(t, false)
+		if len(exemplars) != 0 {
+			t.Errorf("Expected no exemplars, got %d", len(exemplars))
+		}
+	})
+}
+
 func BenchmarkEmitWithCardinality(b *testing.B) {
 	// Reset and initialize with cardinality limits
 	initOnce = sync.Once{}
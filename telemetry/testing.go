@@ -0,0 +1,107 @@
+package telemetry
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// testRecorder captures emitted metrics in memory once ResetForTesting has
+// been called. It is nil in production, so emission pays one atomic load.
+var testRecorder atomic.Pointer[metricRecorder]
+
+// metricRecorder accumulates metric values by name and by labelled series
+type metricRecorder struct {
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// record adds value to the metric's totals, or replaces them for gauges
+func (m *metricRecorder) record(name string, value float64, labels []string, gauge bool) {
+	series := seriesKey(name, labels)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if gauge {
+		m.values[name] = value
+		m.values[series] = value
+		return
+	}
+	m.values[name] += value
+	if series != name {
+		m.values[series] += value
+	}
+}
+
+// seriesKey renders a metric and its labels as name{key=value,...}, with
+// the labels sorted by key. A metric without labels is its bare name.
+func seriesKey(name string, labels []string) string {
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i < len(labels)-1; i += 2 {
+		pairs = append(pairs, labels[i]+"="+labels[i+1])
+	}
+	if len(pairs) == 0 {
+		return name
+	}
+	sort.Strings(pairs)
+	return name + "{" + strings.Join(pairs, ",") + "}"
+}
+
+// recordForTesting hands an emission to the test recorder, if one is active
+func recordForTesting(name string, value float64, labels []string, gauge bool) {
+	if recorder := testRecorder.Load(); recorder != nil {
+		recorder.record(name, value, labels, gauge)
+	}
+}
+
+// ResetForTesting clears every metric value recorded so far and starts
+// recording new emissions in memory, so they can be read with Snapshot. It
+// also resets the internal counters reported by GetInternalMetrics and
+// closes the circuit breaker of an initialized registry, so neither carries
+// over from a previous test.
+//
+// Recording works whether or not Initialize has been called, and does not
+// replace the exporter of an initialized registry. It is meant for tests
+// only: once enabled, every distinct label combination is kept in memory
+// for the life of the process. It is safe to call concurrently with
+// emission, but tests that run in parallel share the recorded values.
+func ResetForTesting() {
+	testRecorder.Store(&metricRecorder{values: make(map[string]float64)})
+	ResetInternalMetrics()
+
+	if r := GetRegistry(); r != nil && r.circuit != nil {
+		r.circuit.Reset()
+	}
+}
+
+// Snapshot returns the metric values recorded since the last call to
+// ResetForTesting, or nil if ResetForTesting was never called.
+//
+// Each metric appears under its name, with the total across all label
+// combinations, and under name{key=value,...} for each combination, with
+// labels sorted by key. Counters and histograms hold the sum of their
+// emitted values; gauges hold the last value set. Baggage labels added by
+// EmitWithContext are part of the series key.
+//
+// Example:
+//
+//	telemetry.ResetForTesting()
+//	telemetry.Counter("requests.total", "status", "200")
+//	snapshot := telemetry.Snapshot()
+//	snapshot["requests.total"]              // 1
+//	snapshot["requests.total{status=200}"]  // 1
+func Snapshot() map[string]float64 {
+	recorder := testRecorder.Load()
+	if recorder == nil {
+		return nil
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	snapshot := make(map[string]float64, len(recorder.values))
+	for key, value := range recorder.values {
+		snapshot[key] = value
+	}
+	return snapshot
+}
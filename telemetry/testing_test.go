@@ -0,0 +1,95 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestResetForTesting_Snapshot(t *testing.T) {
+	ResetForTesting()
+	defer testRecorder.Store(nil)
+
+	Counter("test.requests", "status", "200", "method", "GET")
+	Counter("test.requests", "method", "GET", "status", "200")
+	Counter("test.requests", "status", "500")
+	Histogram("test.latency_ms", 120)
+	Histogram("test.latency_ms", 30)
+	Gauge("test.queue_depth", 7)
+	Gauge("test.queue_depth", 3)
+	EmitWithContext(WithBaggage(context.Background(), "tenant", "acme"), "test.with_baggage", 1)
+
+	snapshot := Snapshot()
+	want := map[string]float64{
+		"test.requests":                        3,
+		"test.requests{method=GET,status=200}": 2,
+		"test.requests{status=500}":            1,
+		"test.latency_ms":                      150,
+		"test.queue_depth":                     3,
+		"test.with_baggage{tenant=acme}":       1,
+	}
+	for key, value := range want {
+		if snapshot[key] != value {
+			t.Errorf("Snapshot()[%q] = %v, want %v", key, snapshot[key], value)
+		}
+	}
+
+	// Values do not carry over into the next test
+	ResetForTesting()
+	if snapshot := Snapshot(); len(snapshot) != 0 {
+		t.Errorf("Expected an empty snapshot after ResetForTesting, got %v", snapshot)
+	}
+
+	// The returned map is a copy
+	Counter("test.requests")
+	Snapshot()["test.requests"] = 100
+	if Snapshot()["test.requests"] != 1 {
+		t.Error("Expected Snapshot to return a copy")
+	}
+}
+
+func TestSnapshot_WithoutReset(t *testing.T) {
+	testRecorder.Store(nil)
+	Counter("test.not_recorded")
+	if snapshot := Snapshot(); snapshot != nil {
+		t.Errorf("Expected nil before ResetForTesting, got %v", snapshot)
+	}
+}
+
+func TestResetForTesting_Concurrent(t *testing.T) {
+	ResetForTesting()
+	defer testRecorder.Store(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			Counter("test.concurrent", "worker", "a")
+		}()
+		go func() {
+			defer wg.Done()
+			_ = Snapshot()
+		}()
+		go func() {
+			defer wg.Done()
+			if i%10 == 0 {
+				ResetForTesting()
+			}
+		}()
+	}
+	wg.Wait()
+
+	ResetForTesting()
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Counter("test.concurrent")
+		}()
+	}
+	wg.Wait()
+	if got := Snapshot()["test.concurrent"]; got != 100 {
+		t.Errorf("Expected 100 concurrent increments, got %v", got)
+	}
+}
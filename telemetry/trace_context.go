@@ -23,6 +23,15 @@
 //	    attribute.String("endpoint", "/weather"),
 //	)
 //
+// # Starting Spans
+//
+// Use StartSpan to open a span through the initialized provider. When
+// telemetry is disabled or not yet initialized it returns a no-op span and
+// the caller's context unchanged, so instrumented code needs no guards:
+//
+//	ctx, span := telemetry.StartSpan(ctx, "process_order")
+//	defer span.End()
+//
 // # Error Recording
 //
 // Use RecordSpanError to capture errors with stack traces:
@@ -36,6 +45,8 @@ package telemetry
 import (
 	"context"
 
+	"github.com/itsneelabh/gomind/core"
+
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
@@ -112,6 +123,33 @@ func HasTraceContext(ctx context.Context) bool {
 	return span.SpanContext().IsValid()
 }
 
+// disabledSpan is returned by StartSpan when no provider is available.
+// It is shared so the disabled path does not allocate.
+var disabledSpan core.Span = &noOpSpan{}
+
+// StartSpan starts a span using the global telemetry provider.
+//
+// When telemetry is disabled, uninitialized, or already shut down, it returns
+// ctx unchanged together with a no-op span whose methods are safe to call.
+// The disabled path performs no allocations.
+//
+// Usage:
+//
+//	ctx, span := telemetry.StartSpan(ctx, "fetch_weather")
+//	defer span.End()
+//	span.SetAttribute("location", "Tokyo")
+func StartSpan(ctx context.Context, name string) (context.Context, core.Span) {
+	r := globalRegistry.Load()
+	if r == nil {
+		return ctx, disabledSpan
+	}
+	registry, ok := r.(*Registry)
+	if !ok || registry == nil || registry.provider == nil {
+		return ctx, disabledSpan
+	}
+	return registry.provider.StartSpan(ctx, name)
+}
+
 // AddSpanEvent adds a named event to the current span.
 // Events mark meaningful points in time during the span's duration.
 // They are visible in trace visualization tools like Jaeger.
@@ -433,3 +433,65 @@ func TestGetBaggageWithTraceContext(t *testing.T) {
 		}
 	})
 }
+
+// TestStartSpanWhenDisabled verifies the span lifecycle is a safe,
+// allocation-free no-op when telemetry has not been initialized
+func TestStartSpanWhenDisabled(t *testing.T) {
+	previous := globalRegistry.Load()
+	globalRegistry.Store((*Registry)(nil))
+	defer func() {
+		if previous != nil {
+			globalRegistry.Store(previous)
+		}
+	}()
+
+	type ctxKey struct{}
+	parent := context.WithValue(context.Background(), ctxKey{}, "value")
+	testErr := errors.New("boom")
+
+	lifecycle := func() {
+		ctx, span := StartSpan(parent, "disabled-operation")
+		if ctx != parent {
+			t.Fatal("Expected StartSpan to return the caller's context unchanged")
+		}
+		span.SetAttribute("key", "value")
+		span.SetAttribute("count", 42)
+		span.RecordError(testErr)
+		SetSpanStatus(ctx, codes.Error, "failed")
+		RecordSpanError(ctx, testErr)
+		span.End()
+	}
+
+	t.Run("full lifecycle does not panic", func(t *testing.T) {
+		lifecycle()
+		if HasTraceContext(parent) {
+			t.Error("Expected no trace context when telemetry is disabled")
+		}
+	})
+
+	t.Run("hot path does not allocate", func(t *testing.T) {
+		if allocs := testing.AllocsPerRun(100, lifecycle); allocs != 0 {
+			t.Errorf("Expected 0 allocations, got %v", allocs)
+		}
+	})
+
+	t.Run("shut down provider returns no-op span", func(t *testing.T) {
+		provider := &OTelProvider{shutdown: true}
+		ctx, span := provider.StartSpan(parent, "after-shutdown")
+		if ctx != parent {
+			t.Error("Expected context to be unchanged")
+		}
+		span.SetAttribute("key", "value")
+		span.End()
+	})
+
+	t.Run("nil provider returns no-op span", func(t *testing.T) {
+		var provider *OTelProvider
+		ctx, span := provider.StartSpan(parent, "nil-provider")
+		if ctx != parent {
+			t.Error("Expected context to be unchanged")
+		}
+		span.RecordError(testErr)
+		span.End()
+	})
+}